@@ -43,9 +43,17 @@ func main() {
 	// Initialize API server
 	server := api.NewServer(cfg, db, logger)
 
+	// Index products that were written without going through the application, e.g. by seeding
+	go ensureSearchIndex(server, logger)
+
 	// Start background checkout expiry process
 	go startCheckoutExpiryProcess(server, logger)
 
+	// Start background exchange rate refresh when a rate provider is configured
+	if cfg.ExchangeRate.Provider != "" {
+		go startExchangeRateRefreshProcess(server, cfg.ExchangeRate.RefreshInterval, logger)
+	}
+
 	// Start server in a goroutine
 	go func() {
 		logger.Info("Starting server on port %s", cfg.Server.Port)
@@ -103,3 +111,52 @@ func expireCheckouts(server *api.Server, logger logger.Logger) {
 			result.AbandonedCount+result.DeletedCount+result.ExpiredCount)
 	}
 }
+
+// ensureSearchIndex rebuilds the product search index when it is out of sync with the products
+func ensureSearchIndex(server *api.Server, logger logger.Logger) {
+	indexed, err := server.GetContainer().UseCases().ProductUseCase().EnsureSearchIndex()
+	if err != nil {
+		logger.Error("Failed to check product search index: %v", err)
+		return
+	}
+	if indexed > 0 {
+		logger.Info("Product search index rebuilt: %d products indexed", indexed)
+	}
+}
+
+// startExchangeRateRefreshProcess runs a background process to refresh exchange rates
+func startExchangeRateRefreshProcess(server *api.Server, intervalMinutes int, logger logger.Logger) {
+	if intervalMinutes <= 0 {
+		intervalMinutes = 60
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	// Run immediately on startup
+	refreshExchangeRates(server, logger)
+
+	for range ticker.C {
+		refreshExchangeRates(server, logger)
+	}
+}
+
+// refreshExchangeRates refreshes exchange rates from the configured provider
+func refreshExchangeRates(server *api.Server, logger logger.Logger) {
+	currencyUseCase := server.GetContainer().UseCases().CurrencyUsecase()
+	if currencyUseCase == nil || !currencyUseCase.HasExchangeRateProvider() {
+		logger.Error("Exchange rate provider not available")
+		return
+	}
+
+	result, err := currencyUseCase.RefreshExchangeRates()
+	if err != nil {
+		logger.Error("Failed to refresh exchange rates: %v", err)
+		return
+	}
+
+	logger.Info("Exchange rates refreshed from %s: %d updated", result.Source, len(result.Updated))
+	if len(result.Missing) > 0 {
+		logger.Warn("Exchange rate provider has no rate for: %v", result.Missing)
+	}
+}
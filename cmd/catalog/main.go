@@ -0,0 +1,139 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/infrastructure/container"
+	"github.com/zenfulcode/commercify/internal/infrastructure/database"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+)
+
+func main() {
+	// Parse command line flags
+	importFile := flag.String("import", "", "Import products from a CSV or JSON catalog file")
+	exportFile := flag.String("export", "", "Export all products to a CSV or JSON catalog file")
+	format := flag.String("format", "", "Catalog format, csv or json (defaults to the file extension)")
+	dryRun := flag.Bool("dry-run", false, "Validate the import without saving anything")
+	flag.Parse()
+
+	if (*importFile == "") == (*exportFile == "") {
+		log.Println("Use either -import <file> or -export <file>")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	// Initialize logger
+	logger := logger.NewLogger()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		logger.Fatal("Failed to load configuration: %v", err)
+	}
+
+	// Connect to database
+	db, err := database.InitDB(cfg.Database)
+	if err != nil {
+		logger.Fatal("Failed to connect to database: %v", err)
+	}
+	defer database.Close(db)
+
+	// Initialize dependency container
+	diContainer := container.NewContainer(cfg, db, logger)
+	catalogUseCase := diContainer.UseCases().CatalogUseCase()
+
+	if *exportFile != "" {
+		exportCatalog(catalogUseCase, logger, *exportFile, catalogFormat(*format, *exportFile))
+		return
+	}
+
+	if !importCatalog(catalogUseCase, logger, *importFile, catalogFormat(*format, *importFile), *dryRun) {
+		database.Close(db)
+		os.Exit(1)
+	}
+}
+
+// catalogFormat returns the format flag, or the format of the file name
+func catalogFormat(format, file string) string {
+	if format != "" {
+		return format
+	}
+	return contracts.CatalogFormatFromName(file)
+}
+
+// importCatalog imports a catalog file and logs the report, returning false when the file has errors
+func importCatalog(catalogUseCase *usecase.CatalogUseCase, logger logger.Logger, file, format string, dryRun bool) bool {
+	logger.Info("Importing catalog from %s", file)
+
+	f, err := os.Open(file)
+	if err != nil {
+		logger.Fatal("Failed to open catalog file: %v", err)
+	}
+	defer f.Close()
+
+	rows, invalid, err := contracts.ReadCatalog(f, format)
+	if err != nil {
+		logger.Fatal("Failed to read catalog file: %v", err)
+	}
+
+	result, err := catalogUseCase.ImportCatalog(usecase.CatalogImportInput{
+		Rows:    rows,
+		Invalid: invalid,
+		DryRun:  dryRun,
+	})
+	if err != nil {
+		logger.Fatal("Failed to import catalog: %v", err)
+	}
+
+	for _, rowError := range result.Errors {
+		logger.Error("Line %d (SKU %s): %s", rowError.Line, rowError.SKU, rowError.Message)
+	}
+
+	switch {
+	case len(result.Errors) > 0:
+		logger.Error("Catalog has %d errors, nothing was imported", len(result.Errors))
+	case result.DryRun:
+		logger.Info("Dry run completed, nothing was imported:")
+	default:
+		logger.Info("Catalog import completed:")
+	}
+	logger.Info("- Rows: %d", result.Rows)
+	logger.Info("- Products created: %d, updated: %d", result.ProductsCreated, result.ProductsUpdated)
+	logger.Info("- Variants created: %d, updated: %d", result.VariantsCreated, result.VariantsUpdated)
+	logger.Info("- Categories created: %d", result.CategoriesCreated)
+
+	return len(result.Errors) == 0
+}
+
+// exportCatalog writes all products to a catalog file
+func exportCatalog(catalogUseCase *usecase.CatalogUseCase, logger logger.Logger, file, format string) {
+	logger.Info("Exporting catalog to %s", file)
+
+	rows, err := catalogUseCase.ExportCatalog()
+	if err != nil {
+		logger.Fatal("Failed to export catalog: %v", err)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		logger.Fatal("Failed to create catalog file: %v", err)
+	}
+	defer f.Close()
+
+	if err := contracts.WriteCatalog(f, format, rows); err != nil {
+		logger.Fatal("Failed to write catalog file: %v", err)
+	}
+
+	logger.Info("Exported %d variants", len(rows))
+}
@@ -980,6 +980,7 @@ func seedDiscounts(db *gorm.DB) error {
 			discData.maxDiscountValue,
 			[]uint{}, // No specific products
 			[]uint{}, // No specific categories
+			nil,      // No specific collections
 			discData.startDate,
 			discData.endDate,
 			discData.usageLimit,
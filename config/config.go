@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -17,6 +18,10 @@ type Config struct {
 	Stripe          StripeConfig
 	MobilePay       MobilePayConfig
 	CORS            CORSConfig
+	ExchangeRate    ExchangeRateConfig
+	Storage         StorageConfig
+	Download        DownloadConfig
+	Locale          LocaleConfig
 	DefaultCurrency string // Default currency for the store
 }
 
@@ -41,8 +46,15 @@ type DatabaseConfig struct {
 
 // AuthConfig holds authentication-specific configuration
 type AuthConfig struct {
-	JWTSecret     string
-	TokenDuration int
+	JWTSecret       string
+	AccessTokenTTL  int // Minutes an access token stays valid
+	RefreshTokenTTL int // Days a refresh token stays valid, each refresh issues a new one
+
+	PasswordResetURL         string // Storefront page opened by password reset links
+	PasswordResetTTL         int    // Minutes a password reset link stays valid
+	EmailVerificationURL     string // Storefront page opened by email verification links
+	EmailVerificationTTL     int    // Hours an email verification link stays valid
+	RequireEmailVerification bool   // Whether customers must verify their email address before signing in
 }
 
 // PaymentConfig holds payment-specific configuration
@@ -93,6 +105,47 @@ type CORSConfig struct {
 	AllowAllOrigins bool
 }
 
+// ExchangeRateConfig holds configuration for automatic exchange rate updates
+type ExchangeRateConfig struct {
+	Provider        string // Rate provider: "feed" or empty to disable automatic updates
+	Source          string // File path or http(s) URL of the JSON rate feed
+	RefreshInterval int    // Minutes between refreshes
+}
+
+// StorageConfig holds configuration for uploaded media assets
+type StorageConfig struct {
+	Provider      string   // Storage backend: "local" (default) or "s3"
+	LocalPath     string   // Directory of the local backend
+	PublicURL     string   // Base URL of stored files, e.g. /media or a CDN URL
+	MaxUploadSize int64    // Maximum upload size in bytes
+	AllowedTypes  []string // Content types accepted for upload
+	ImageSizes    []string // Derivatives generated for images as name:width, e.g. thumbnail:200
+
+	S3Endpoint  string // Endpoint of the S3-compatible service, e.g. https://s3.eu-west-1.amazonaws.com
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+
+	DownloadsPath     string // Directory of the local backend for digital product files, never served directly
+	S3DownloadsBucket string // Bucket for digital product files, S3Bucket when empty. Should not be public.
+}
+
+// DownloadConfig holds configuration for the delivery of digital products
+type DownloadConfig struct {
+	BaseURL      string // Base URL of the API in download links, e.g. https://shop.example.com
+	LinkTTL      int    // Hours a download link stays valid after payment
+	MaxDownloads int    // Downloads allowed per file and order
+	SigningKey   string // Key signing download links, the JWT secret when empty
+	MaxFileSize  int64  // Maximum size of a digital product file in bytes
+}
+
+// LocaleConfig holds configuration for the languages of the catalog
+type LocaleConfig struct {
+	Default   string   // Locale of the product, category and shipping method fields, e.g. en
+	Supported []string // Locales customers can select, always including the default locale
+}
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
 	readTimeout, err := strconv.Atoi(getEnv("SERVER_READ_TIMEOUT", "15"))
@@ -105,9 +158,29 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("invalid SERVER_WRITE_TIMEOUT: %w", err)
 	}
 
-	tokenDuration, err := strconv.Atoi(getEnv("AUTH_TOKEN_DURATION", "24"))
+	accessTokenTTL, err := strconv.Atoi(getEnv("AUTH_ACCESS_TOKEN_TTL", "15"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTH_ACCESS_TOKEN_TTL: %w", err)
+	}
+
+	refreshTokenTTL, err := strconv.Atoi(getEnv("AUTH_REFRESH_TOKEN_TTL", "30"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTH_REFRESH_TOKEN_TTL: %w", err)
+	}
+
+	passwordResetTTL, err := strconv.Atoi(getEnv("AUTH_PASSWORD_RESET_TTL", "60"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTH_PASSWORD_RESET_TTL: %w", err)
+	}
+
+	emailVerificationTTL, err := strconv.Atoi(getEnv("AUTH_EMAIL_VERIFICATION_TTL", "48"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTH_EMAIL_VERIFICATION_TTL: %w", err)
+	}
+
+	requireEmailVerification, err := strconv.ParseBool(getEnv("AUTH_REQUIRE_EMAIL_VERIFICATION", "false"))
 	if err != nil {
-		return nil, fmt.Errorf("invalid AUTH_TOKEN_DURATION: %w", err)
+		return nil, fmt.Errorf("invalid AUTH_REQUIRE_EMAIL_VERIFICATION: %w", err)
 	}
 
 	smtpPort, err := strconv.Atoi(getEnv("EMAIL_SMTP_PORT", "587"))
@@ -135,6 +208,31 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("invalid MOBILEPAY_TEST_MODE: %w", err)
 	}
 
+	exchangeRateRefreshInterval, err := strconv.Atoi(getEnv("EXCHANGE_RATE_REFRESH_INTERVAL", "60"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EXCHANGE_RATE_REFRESH_INTERVAL: %w", err)
+	}
+
+	maxUploadSize, err := strconv.ParseInt(getEnv("STORAGE_MAX_UPLOAD_SIZE", "10485760"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid STORAGE_MAX_UPLOAD_SIZE: %w", err)
+	}
+
+	downloadLinkTTL, err := strconv.Atoi(getEnv("DOWNLOAD_LINK_TTL", "72"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DOWNLOAD_LINK_TTL: %w", err)
+	}
+
+	maxDownloads, err := strconv.Atoi(getEnv("DOWNLOAD_MAX_COUNT", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DOWNLOAD_MAX_COUNT: %w", err)
+	}
+
+	maxDownloadFileSize, err := strconv.ParseInt(getEnv("DOWNLOAD_MAX_FILE_SIZE", "524288000"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DOWNLOAD_MAX_FILE_SIZE: %w", err)
+	}
+
 	// Parse enabled payment providers
 	enabledProviders := []string{"mock"} // Always enable mock provider for testing
 	if stripeEnabled {
@@ -161,8 +259,15 @@ func LoadConfig() (*Config, error) {
 			Debug:    getEnv("DB_DEBUG", "false"),
 		},
 		Auth: AuthConfig{
-			JWTSecret:     getEnv("AUTH_JWT_SECRET", "your-secret-key"),
-			TokenDuration: tokenDuration,
+			JWTSecret:       getEnv("AUTH_JWT_SECRET", "your-secret-key"),
+			AccessTokenTTL:  accessTokenTTL,
+			RefreshTokenTTL: refreshTokenTTL,
+
+			PasswordResetURL:         getEnv("AUTH_PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+			PasswordResetTTL:         passwordResetTTL,
+			EmailVerificationURL:     getEnv("AUTH_EMAIL_VERIFICATION_URL", "http://localhost:3000/verify-email"),
+			EmailVerificationTTL:     emailVerificationTTL,
+			RequireEmailVerification: requireEmailVerification,
 		},
 		Payment: PaymentConfig{
 			EnabledProviders: enabledProviders,
@@ -201,14 +306,56 @@ func LoadConfig() (*Config, error) {
 			AllowedOrigins:  strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "*"), ","),
 			AllowAllOrigins: true,
 		},
+		ExchangeRate: ExchangeRateConfig{
+			Provider:        getEnv("EXCHANGE_RATE_PROVIDER", ""),
+			Source:          getEnv("EXCHANGE_RATE_SOURCE", ""),
+			RefreshInterval: exchangeRateRefreshInterval,
+		},
+		Storage: StorageConfig{
+			Provider:      getEnv("STORAGE_PROVIDER", "local"),
+			LocalPath:     getEnv("STORAGE_LOCAL_PATH", "./uploads"),
+			PublicURL:     getEnv("STORAGE_PUBLIC_URL", "/media"),
+			MaxUploadSize: maxUploadSize,
+			AllowedTypes:  strings.Split(getEnv("STORAGE_ALLOWED_TYPES", "image/jpeg,image/png,image/gif,image/webp"), ","),
+			ImageSizes:    strings.Split(getEnv("STORAGE_IMAGE_SIZES", "thumbnail:200,medium:800"), ","),
+			S3Endpoint:    getEnv("S3_ENDPOINT", ""),
+			S3Region:      getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:      getEnv("S3_BUCKET", ""),
+			S3AccessKey:   getEnv("S3_ACCESS_KEY", ""),
+			S3SecretKey:   getEnv("S3_SECRET_KEY", ""),
+
+			DownloadsPath:     getEnv("STORAGE_DOWNLOADS_PATH", "./downloads"),
+			S3DownloadsBucket: getEnv("S3_DOWNLOADS_BUCKET", ""),
+		},
+		Download: DownloadConfig{
+			BaseURL:      strings.TrimSuffix(getEnv("DOWNLOAD_BASE_URL", "http://localhost:6091"), "/"),
+			LinkTTL:      downloadLinkTTL,
+			MaxDownloads: maxDownloads,
+			MaxFileSize:  maxDownloadFileSize,
+		},
 		DefaultCurrency: getEnv("DEFAULT_CURRENCY", "USD"),
 	}
 
+	config.Locale.Default = normalizeLocale(getEnv("DEFAULT_LOCALE", "en"))
+	config.Locale.Supported = []string{config.Locale.Default}
+	for _, locale := range strings.Split(getEnv("SUPPORTED_LOCALES", config.Locale.Default), ",") {
+		locale = normalizeLocale(locale)
+		if locale != "" && !slices.Contains(config.Locale.Supported, locale) {
+			config.Locale.Supported = append(config.Locale.Supported, locale)
+		}
+	}
+
 	config.Email.ContactEmail = getEnv("EMAIL_CONTACT_ADDRESS", config.Email.FromEmail)
+	config.Download.SigningKey = getEnv("DOWNLOAD_SIGNING_KEY", config.Auth.JWTSecret)
 
 	return &config, nil
 }
 
+// normalizeLocale lowercases a language tag and uses hyphens, e.g. "da_DK" becomes "da-dk"
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
 // getEnv gets an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
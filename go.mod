@@ -12,6 +12,7 @@ require (
 	github.com/stripe/stripe-go/v82 v82.5.1
 	github.com/zenfulcode/vipps-mobilepay-sdk v1.0.2
 	golang.org/x/crypto v0.53.0
+	golang.org/x/text v0.38.0
 	gorm.io/datatypes v1.2.7
 	gorm.io/driver/postgres v1.6.0
 	gorm.io/driver/sqlite v1.6.0
@@ -33,7 +34,6 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rogpeppe/go-internal v1.14.1 // indirect
 	golang.org/x/sync v0.21.0 // indirect
-	golang.org/x/text v0.38.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	gorm.io/driver/mysql v1.6.0 // indirect
 )
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// ErrInvalidAPIKey is returned for unknown, revoked and expired API keys
+var ErrInvalidAPIKey = errors.New("invalid or expired API key")
+
+// apiKeyUsageResolution is how often the last-used timestamp of a busy key is written
+const apiKeyUsageResolution = time.Minute
+
+// APIKeyUseCase implements the use cases of API keys, which let integrations call the admin API
+// with a set of permissions instead of signing in as a user
+type APIKeyUseCase struct {
+	apiKeyRepo repository.APIKeyRepository
+	now        func() time.Time
+}
+
+// NewAPIKeyUseCase creates a new APIKeyUseCase
+func NewAPIKeyUseCase(apiKeyRepo repository.APIKeyRepository) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+		now:        time.Now,
+	}
+}
+
+// CreateAPIKeyInput contains the data needed to create an API key
+type CreateAPIKeyInput struct {
+	Name        string
+	Scopes      []entity.Permission
+	ExpiresAt   *time.Time
+	CreatedByID uint // Zero when the key is created with another API key
+}
+
+// CreateAPIKey creates an API key and returns it together with the plain key, which is not stored.
+// Keys cannot have permissions their creator does not have.
+func (uc *APIKeyUseCase) CreateAPIKey(grantor Grantor, input CreateAPIKeyInput) (*entity.APIKey, string, error) {
+	key, plain, err := entity.NewAPIKey(input.Name, input.Scopes, input.ExpiresAt, input.CreatedByID, uc.now())
+	if err != nil {
+		return nil, "", err
+	}
+	if err := grantor.checkGrants(key.Scopes); err != nil {
+		return nil, "", err
+	}
+	if err := uc.apiKeyRepo.Create(key); err != nil {
+		return nil, "", err
+	}
+	return key, plain, nil
+}
+
+// ListAPIKeys lists all API keys, newest first, including revoked and expired keys
+func (uc *APIKeyUseCase) ListAPIKeys() ([]*entity.APIKey, error) {
+	return uc.apiKeyRepo.List()
+}
+
+// RevokeAPIKey revokes an API key. Like creating it, this requires every scope of the key,
+// so a key cannot be used to revoke keys with more permissions. Revoking a revoked key has no effect.
+func (uc *APIKeyUseCase) RevokeAPIKey(grantor Grantor, keyID uint) error {
+	key, err := uc.apiKeyRepo.GetByID(keyID)
+	if err != nil {
+		return err
+	}
+	if err := grantor.checkGrants(key.Scopes); err != nil {
+		return err
+	}
+	_, err = uc.apiKeyRepo.Revoke(keyID, uc.now())
+	return err
+}
+
+// Authenticate returns the active API key matching the plain key and records its use
+func (uc *APIKeyUseCase) Authenticate(plain string) (*entity.APIKey, error) {
+	if !strings.HasPrefix(plain, entity.APIKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := uc.apiKeyRepo.GetByHash(entity.HashUserToken(plain))
+	if errors.Is(err, repository.ErrAPIKeyNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	if !key.IsActive(now) {
+		return nil, ErrInvalidAPIKey
+	}
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyUsageResolution {
+		if err := uc.apiKeyRepo.MarkUsed(key.ID, now); err != nil {
+			return nil, err
+		}
+		key.LastUsedAt = &now
+	}
+	return key, nil
+}
+
+// RecordRequest records a request made with the key
+func (uc *APIKeyUseCase) RecordRequest(key *entity.APIKey, method, path string) error {
+	return uc.apiKeyRepo.CreateRequest(entity.NewAPIKeyRequest(key.ID, method, path))
+}
+
+// ListAPIKeyRequests lists the requests made with an API key, newest first
+func (uc *APIKeyUseCase) ListAPIKeyRequests(keyID uint, offset, limit int) ([]*entity.APIKeyRequest, error) {
+	if _, err := uc.apiKeyRepo.GetByID(keyID); err != nil {
+		return nil, err
+	}
+	return uc.apiKeyRepo.ListRequests(keyID, offset, limit)
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestAPIKeyUseCase(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := gorm.NewAPIKeyRepository(db)
+	uc := NewAPIKeyUseCase(repo)
+	uc.now = func() time.Time { return now }
+
+	admin := testutil.CreateTestUser(t, db, 1)
+
+	grantor := Grantor{Permissions: entity.AllPermissions, IsAdmin: true}
+	expiresAt := now.Add(24 * time.Hour)
+	key, plain, err := uc.CreateAPIKey(grantor, CreateAPIKeyInput{
+		Name:        "ERP sync",
+		Scopes:      []entity.Permission{entity.PermissionCatalogWrite, entity.PermissionOrdersRead},
+		ExpiresAt:   &expiresAt,
+		CreatedByID: admin.ID,
+	})
+	require.NoError(t, err)
+
+	t.Run("CreateAPIKey requires every scope of the key", func(t *testing.T) {
+		limited := Grantor{Permissions: []entity.Permission{entity.PermissionAPIKeysWrite, entity.PermissionOrdersRead}}
+		_, _, err := uc.CreateAPIKey(limited, CreateAPIKeyInput{
+			Name:   "Escalation",
+			Scopes: []entity.Permission{entity.PermissionCatalogWrite},
+		})
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("Authenticate accepts the key and records its use", func(t *testing.T) {
+		authenticated, err := uc.Authenticate(plain)
+		require.NoError(t, err)
+		assert.Equal(t, key.ID, authenticated.ID)
+		assert.Equal(t, []entity.Permission{entity.PermissionCatalogWrite, entity.PermissionOrdersRead}, authenticated.Scopes)
+
+		stored, err := repo.GetByID(key.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.LastUsedAt)
+		assert.True(t, now.Equal(*stored.LastUsedAt))
+
+		// Uses within a minute are not written again
+		now = now.Add(30 * time.Second)
+		_, err = uc.Authenticate(plain)
+		require.NoError(t, err)
+		stored, err = repo.GetByID(key.ID)
+		require.NoError(t, err)
+		assert.True(t, now.Add(-30*time.Second).Equal(*stored.LastUsedAt))
+	})
+
+	t.Run("Authenticate rejects unknown and expired keys", func(t *testing.T) {
+		_, err := uc.Authenticate("ck_unknown")
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+		_, err = uc.Authenticate(plain[len(entity.APIKeyPrefix):])
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+		saved := now
+		now = expiresAt
+		_, err = uc.Authenticate(plain)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+		now = saved
+	})
+
+	t.Run("ListAPIKeys lists the keys without the plain key", func(t *testing.T) {
+		keys, err := uc.ListAPIKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, "ERP sync", keys[0].Name)
+		assert.Empty(t, keys[0].ToAPIKeyDTO().Key)
+	})
+
+	t.Run("Requests made with a key are recorded", func(t *testing.T) {
+		require.NoError(t, uc.RecordRequest(key, "PUT", "/api/admin/products/1"))
+		require.NoError(t, uc.RecordRequest(key, "GET", "/api/admin/orders/2"))
+
+		requests, err := uc.ListAPIKeyRequests(key.ID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, requests, 2)
+		assert.Equal(t, "GET", requests[0].Method)
+		assert.Equal(t, "/api/admin/orders/2", requests[0].Path)
+		assert.Equal(t, key.ID, requests[1].APIKeyID)
+
+		_, err = uc.ListAPIKeyRequests(key.ID+100, 0, 10)
+		assert.ErrorIs(t, err, repository.ErrAPIKeyNotFound)
+	})
+
+	t.Run("RevokeAPIKey", func(t *testing.T) {
+		// Revoking requires every scope of the key
+		limited := Grantor{Permissions: []entity.Permission{entity.PermissionAPIKeysWrite, entity.PermissionOrdersRead}}
+		assert.ErrorIs(t, uc.RevokeAPIKey(limited, key.ID), ErrPermissionDenied)
+
+		require.NoError(t, uc.RevokeAPIKey(grantor, key.ID))
+		require.NoError(t, uc.RevokeAPIKey(grantor, key.ID))
+
+		_, err := uc.Authenticate(plain)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+		assert.ErrorIs(t, uc.RevokeAPIKey(grantor, key.ID+100), repository.ErrAPIKeyNotFound)
+	})
+}
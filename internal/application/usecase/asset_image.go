@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	_ "image/gif" // Register the GIF decoder
+	"image/jpeg"
+	"image/png"
+)
+
+// maxResizePixels caps the size of images that derivatives are generated for, since the
+// decoded image is held in memory
+const maxResizePixels = 50_000_000
+
+// jpegQuality is the quality of JPEG derivatives
+const jpegQuality = 85
+
+// imageDimensions returns the pixel dimensions of an image, or zeros when it cannot be decoded
+func imageDimensions(data []byte) (int, int) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0
+	}
+	return config.Width, config.Height
+}
+
+// resizedImage is a derivative image encoded for storage
+type resizedImage struct {
+	size          ImageSize
+	data          []byte
+	contentType   string
+	width, height int
+}
+
+// resizeImages generates the derivatives of an image that are smaller than the original.
+// JPEG images stay JPEG, other formats become PNG to keep transparency.
+func resizeImages(data []byte, sizes []ImageSize) ([]resizedImage, error) {
+	width, height := imageDimensions(data)
+	if width == 0 || height == 0 || width*height > maxResizePixels {
+		return nil, nil
+	}
+
+	var src image.Image
+	var format string
+	var results []resizedImage
+	for _, size := range sizes {
+		if size.Width >= width {
+			continue
+		}
+		if src == nil {
+			var err error
+			if src, format, err = image.Decode(bytes.NewReader(data)); err != nil {
+				return nil, nil
+			}
+		}
+
+		resized := resizeImage(src, size.Width, max(1, (height*size.Width+width/2)/width))
+
+		var buf bytes.Buffer
+		contentType := "image/png"
+		var err error
+		if format == "jpeg" {
+			contentType = "image/jpeg"
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: jpegQuality})
+		} else {
+			err = png.Encode(&buf, resized)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		bounds := resized.Bounds()
+		results = append(results, resizedImage{
+			size:        size,
+			data:        buf.Bytes(),
+			contentType: contentType,
+			width:       bounds.Dx(),
+			height:      bounds.Dy(),
+		})
+	}
+	return results, nil
+}
+
+// resizeImage scales an image down to the given dimensions, averaging the source pixels
+// covered by each destination pixel
+func resizeImage(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	rgba, ok := src.(*image.RGBA)
+	if !ok || bounds.Min != (image.Point{}) {
+		rgba = image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+	}
+	srcWidth, srcHeight := rgba.Bounds().Dx(), rgba.Bounds().Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		y0 := y * srcHeight / height
+		y1 := max(y0+1, (y+1)*srcHeight/height)
+		for x := range width {
+			x0 := x * srcWidth / width
+			x1 := max(x0+1, (x+1)*srcWidth/width)
+
+			// Premultiplied RGBA values can be averaged directly
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint64(p[0])
+					g += uint64(p[1])
+					b += uint64(p[2])
+					a += uint64(p[3])
+					n++
+				}
+			}
+
+			offset := y*dst.Stride + x*4
+			dst.Pix[offset] = uint8((r + n/2) / n)
+			dst.Pix[offset+1] = uint8((g + n/2) / n)
+			dst.Pix[offset+2] = uint8((b + n/2) / n)
+			dst.Pix[offset+3] = uint8((a + n/2) / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,349 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// ImageSize is a resized copy generated for uploaded images
+type ImageSize struct {
+	Name  string
+	Width int
+}
+
+// AssetOptions configures the validation and processing of uploads
+type AssetOptions struct {
+	MaxUploadSize int64       // Maximum file size in bytes
+	AllowedTypes  []string    // Content types accepted for upload
+	ImageSizes    []ImageSize // Derivatives generated for images
+}
+
+// ParseImageSizes parses derivative sizes written as name:width, e.g. thumbnail:200
+func ParseImageSizes(values []string) ([]ImageSize, error) {
+	var sizes []ImageSize
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		name, width, ok := strings.Cut(value, ":")
+		name = strings.TrimSpace(name)
+		w, err := strconv.Atoi(strings.TrimSpace(width))
+		if !ok || name == "" || err != nil || w <= 0 {
+			return nil, fmt.Errorf("invalid image size %q, expected name:width", value)
+		}
+		if slices.ContainsFunc(sizes, func(s ImageSize) bool { return s.Name == name }) {
+			return nil, fmt.Errorf("duplicate image size %s", name)
+		}
+		sizes = append(sizes, ImageSize{Name: name, Width: w})
+	}
+	return sizes, nil
+}
+
+// AssetUseCase implements media asset use cases
+type AssetUseCase struct {
+	assetRepo        repository.AssetRepository
+	productAssetRepo repository.ProductAssetRepository
+	productRepo      repository.ProductRepository
+	storage          service.AssetStorage
+	options          AssetOptions
+}
+
+// NewAssetUseCase creates a new AssetUseCase
+func NewAssetUseCase(
+	assetRepo repository.AssetRepository,
+	productAssetRepo repository.ProductAssetRepository,
+	productRepo repository.ProductRepository,
+	storage service.AssetStorage,
+	options AssetOptions,
+) *AssetUseCase {
+	return &AssetUseCase{
+		assetRepo:        assetRepo,
+		productAssetRepo: productAssetRepo,
+		productRepo:      productRepo,
+		storage:          storage,
+		options:          options,
+	}
+}
+
+// UploadAssetInput contains the data for uploading an asset
+type UploadAssetInput struct {
+	FileName    string
+	ContentType string // Content type declared by the client, may be empty
+	Data        []byte
+}
+
+// UploadAsset validates and stores an uploaded file. The content type is detected from the file
+// contents, and images get resized derivatives for the configured sizes.
+func (uc *AssetUseCase) UploadAsset(input UploadAssetInput) (*entity.Asset, error) {
+	if uc.storage == nil {
+		return nil, errors.New("asset storage is not configured")
+	}
+	if len(input.Data) == 0 {
+		return nil, errors.New("file cannot be empty")
+	}
+	if uc.options.MaxUploadSize > 0 && int64(len(input.Data)) > uc.options.MaxUploadSize {
+		return nil, fmt.Errorf("file exceeds the maximum upload size of %d bytes", uc.options.MaxUploadSize)
+	}
+
+	contentType := mediaType(http.DetectContentType(input.Data))
+	if !slices.Contains(uc.options.AllowedTypes, contentType) {
+		return nil, fmt.Errorf("content type %s is not allowed", contentType)
+	}
+	if declared := mediaType(input.ContentType); declared != "" && declared != "application/octet-stream" && declared != contentType {
+		return nil, fmt.Errorf("content type %s does not match the file contents (%s)", declared, contentType)
+	}
+
+	width, height := imageDimensions(input.Data)
+	asset, err := entity.NewAsset(input.FileName, contentType, int64(len(input.Data)), width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	derivatives, err := resizeImages(input.Data, uc.options.ImageSizes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resize image: %w", err)
+	}
+
+	// Files are stored first, and removed again when the asset cannot be saved
+	base := "assets/" + uuid.New().String() + "/"
+	var stored []string
+	put := func(key, contentType string, data []byte) (string, error) {
+		if err := uc.storage.Put(key, contentType, bytes.NewReader(data), int64(len(data))); err != nil {
+			return "", err
+		}
+		stored = append(stored, key)
+		return uc.storage.URL(key), nil
+	}
+	cleanup := func() {
+		for _, key := range stored {
+			uc.storage.Delete(key)
+		}
+	}
+
+	asset.StorageKey = base + "original" + entity.AssetExtension(contentType)
+	if asset.URL, err = put(asset.StorageKey, contentType, input.Data); err != nil {
+		return nil, err
+	}
+
+	for _, derivative := range derivatives {
+		key := base + derivative.size.Name + entity.AssetExtension(derivative.contentType)
+		url, err := put(key, derivative.contentType, derivative.data)
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		asset.Derivatives = append(asset.Derivatives, entity.AssetDerivative{
+			Name:        derivative.size.Name,
+			ContentType: derivative.contentType,
+			Size:        int64(len(derivative.data)),
+			Width:       derivative.width,
+			Height:      derivative.height,
+			StorageKey:  key,
+			URL:         url,
+		})
+	}
+
+	if err := uc.assetRepo.Create(asset); err != nil {
+		cleanup()
+		return nil, err
+	}
+	return asset, nil
+}
+
+// GetAsset retrieves an asset by ID
+func (uc *AssetUseCase) GetAsset(assetID uint) (*entity.Asset, error) {
+	return uc.assetRepo.GetByID(assetID)
+}
+
+// ListAssets lists assets, newest first
+func (uc *AssetUseCase) ListAssets(offset, limit int) ([]*entity.Asset, error) {
+	return uc.assetRepo.List(offset, limit)
+}
+
+// DeleteAsset deletes an asset, its files and its links to products
+func (uc *AssetUseCase) DeleteAsset(assetID uint) error {
+	asset, err := uc.assetRepo.GetByID(assetID)
+	if err != nil {
+		return err
+	}
+	if err := uc.assetRepo.Delete(assetID); err != nil {
+		return err
+	}
+	if uc.storage == nil {
+		return errors.New("asset deleted, but asset storage is not configured to remove its files")
+	}
+
+	var errs []error
+	for _, key := range asset.StorageKeys() {
+		if err := uc.storage.Delete(key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("asset deleted, but failed to remove its files: %w", err)
+	}
+	return nil
+}
+
+// LinkProductAssetInput contains the data for linking an asset to a product
+type LinkProductAssetInput struct {
+	AssetID   uint
+	VariantID *uint // Links the asset to one variant of the product
+	AltText   string
+}
+
+// LinkProductAsset adds an asset to the end of a product's assets
+func (uc *AssetUseCase) LinkProductAsset(productID uint, input LinkProductAssetInput) (*entity.ProductAsset, error) {
+	if err := uc.checkProductVariant(productID, input.VariantID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.assetRepo.GetByID(input.AssetID); err != nil {
+		return nil, err
+	}
+
+	link, err := entity.NewProductAsset(productID, input.VariantID, input.AssetID, input.AltText)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := uc.productAssetRepo.ListByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range links {
+		link.Position = max(link.Position, other.Position+1)
+	}
+
+	if err := uc.productAssetRepo.Create(link); err != nil {
+		return nil, err
+	}
+	return uc.productAssetRepo.GetByID(link.ID)
+}
+
+// UpdateProductAssetInput contains the data for updating a product asset. Nil fields are unchanged.
+type UpdateProductAssetInput struct {
+	AltText   *string
+	VariantID *uint // 0 links the asset to the whole product again
+}
+
+// UpdateProductAsset updates the alt text or variant of a product asset
+func (uc *AssetUseCase) UpdateProductAsset(productID, linkID uint, input UpdateProductAssetInput) (*entity.ProductAsset, error) {
+	link, err := uc.getProductAsset(productID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.AltText != nil {
+		if err := link.SetAltText(*input.AltText); err != nil {
+			return nil, err
+		}
+	}
+	if input.VariantID != nil {
+		link.VariantID = nil
+		if *input.VariantID != 0 {
+			if err := uc.checkProductVariant(productID, input.VariantID); err != nil {
+				return nil, err
+			}
+			link.VariantID = input.VariantID
+		}
+	}
+
+	if err := uc.productAssetRepo.Update(link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// RemoveProductAsset unlinks an asset from a product. The asset itself is kept.
+func (uc *AssetUseCase) RemoveProductAsset(productID, linkID uint) error {
+	if _, err := uc.getProductAsset(productID, linkID); err != nil {
+		return err
+	}
+	return uc.productAssetRepo.Delete(linkID)
+}
+
+// ListProductAssets returns the assets of a product in display order. With a variant ID,
+// only the assets of the whole product and of that variant are returned.
+func (uc *AssetUseCase) ListProductAssets(productID uint, variantID *uint) ([]*entity.ProductAsset, error) {
+	if _, err := uc.productRepo.GetByID(productID); err != nil {
+		return nil, err
+	}
+
+	links, err := uc.productAssetRepo.ListByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	if variantID == nil {
+		return links, nil
+	}
+
+	return slices.DeleteFunc(links, func(link *entity.ProductAsset) bool {
+		return link.VariantID != nil && *link.VariantID != *variantID
+	}), nil
+}
+
+// ReorderProductAssets sets the display order of a product's assets to the given link IDs
+func (uc *AssetUseCase) ReorderProductAssets(productID uint, order []uint) ([]*entity.ProductAsset, error) {
+	links, err := uc.productAssetRepo.ListByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.OrderProductAssets(links, order); err != nil {
+		return nil, err
+	}
+	if err := uc.productAssetRepo.UpdatePositions(links); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(links, func(a, b *entity.ProductAsset) int { return a.Position - b.Position })
+	return links, nil
+}
+
+// getProductAsset returns a link of the product
+func (uc *AssetUseCase) getProductAsset(productID, linkID uint) (*entity.ProductAsset, error) {
+	link, err := uc.productAssetRepo.GetByID(linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link.ProductID != productID {
+		return nil, fmt.Errorf("product asset with ID %d not found", linkID)
+	}
+	return link, nil
+}
+
+// checkProductVariant checks that the product exists and the variant, if any, belongs to it
+func (uc *AssetUseCase) checkProductVariant(productID uint, variantID *uint) error {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return err
+	}
+	if variantID != nil && *variantID != 0 && product.GetVariantByID(*variantID) == nil {
+		return fmt.Errorf("variant with ID %d not found for product %d", *variantID, productID)
+	}
+	return nil
+}
+
+// mediaType returns the media type of a content type without parameters
+func mediaType(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/internal/infrastructure/storage"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+// memoryObjectClient is an in-memory S3-compatible object store
+type memoryObjectClient struct {
+	objects map[string][]byte
+}
+
+func (c *memoryObjectClient) PutObject(bucket, key, contentType string, body io.Reader, size int64) error {
+	data, err := io.ReadAll(body)
+	c.objects[key] = data
+	return err
+}
+
+func (c *memoryObjectClient) GetObject(bucket, key string) (io.ReadCloser, error) {
+	data, ok := c.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("object %s not found", key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (c *memoryObjectClient) DeleteObject(bucket, key string) error {
+	delete(c.objects, key)
+	return nil
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestAssetUseCase(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(currency).Error)
+
+	category, err := entity.NewCategory("Shirts", "", nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(category).Error)
+
+	red, err := entity.NewProductVariant("TEE-RED", 1, 1500, 0, entity.VariantAttributes{"color": "Red"}, nil, true)
+	require.NoError(t, err)
+	blue, err := entity.NewProductVariant("TEE-BLUE", 1, 1500, 0, entity.VariantAttributes{"color": "Blue"}, nil, false)
+	require.NoError(t, err)
+	product, err := entity.NewProduct("Tee", "", "USD", category.ID, nil, []*entity.ProductVariant{red, blue}, true)
+	require.NoError(t, err)
+	productRepo := gorm.NewProductRepository(db)
+	require.NoError(t, productRepo.Create(product))
+
+	objects := &memoryObjectClient{objects: map[string][]byte{}}
+	uc := NewAssetUseCase(
+		gorm.NewAssetRepository(db),
+		gorm.NewProductAssetRepository(db),
+		productRepo,
+		storage.NewS3Storage(objects, "media", "https://cdn.example.com"),
+		AssetOptions{
+			MaxUploadSize: 1 << 20,
+			AllowedTypes:  []string{"image/png", "image/jpeg"},
+			ImageSizes:    []ImageSize{{Name: "thumbnail", Width: 100}, {Name: "large", Width: 1000}},
+		},
+	)
+
+	upload := func(t *testing.T, name string) *entity.Asset {
+		asset, err := uc.UploadAsset(UploadAssetInput{FileName: name, ContentType: "image/png", Data: testPNG(t, 400, 200)})
+		require.NoError(t, err)
+		return asset
+	}
+
+	t.Run("Upload stores the image with resized derivatives", func(t *testing.T) {
+		asset := upload(t, "tee.png")
+		assert.Equal(t, "image/png", asset.ContentType)
+		assert.Equal(t, 400, asset.Width)
+		assert.Equal(t, 200, asset.Height)
+		assert.Regexp(t, `^https://cdn\.example\.com/assets/[0-9a-f-]{36}/original\.png$`, asset.URL)
+
+		// Images are not scaled up, so only the thumbnail is generated
+		require.Len(t, asset.Derivatives, 1)
+		thumbnail := asset.Derivatives[0]
+		assert.Equal(t, "thumbnail", thumbnail.Name)
+		assert.Equal(t, 100, thumbnail.Width)
+		assert.Equal(t, 50, thumbnail.Height)
+
+		config, format, err := image.DecodeConfig(bytes.NewReader(objects.objects[thumbnail.StorageKey]))
+		require.NoError(t, err)
+		assert.Equal(t, "png", format)
+		assert.Equal(t, 100, config.Width)
+
+		stored, err := uc.GetAsset(asset.ID)
+		require.NoError(t, err)
+		assert.Len(t, stored.Derivatives, 1)
+	})
+
+	t.Run("Upload validates the file", func(t *testing.T) {
+		_, err := uc.UploadAsset(UploadAssetInput{FileName: "notes.txt", Data: []byte("just some text")})
+		assert.ErrorContains(t, err, "content type text/plain is not allowed")
+
+		_, err = uc.UploadAsset(UploadAssetInput{FileName: "tee.jpg", ContentType: "image/jpeg", Data: testPNG(t, 10, 10)})
+		assert.ErrorContains(t, err, "does not match the file contents")
+
+		_, err = uc.UploadAsset(UploadAssetInput{FileName: "big.png", Data: make([]byte, 2<<20)})
+		assert.ErrorContains(t, err, "maximum upload size")
+
+		_, err = uc.UploadAsset(UploadAssetInput{FileName: "empty.png"})
+		assert.ErrorContains(t, err, "file cannot be empty")
+	})
+
+	t.Run("Assets are linked to products and variants in order", func(t *testing.T) {
+		front, back, detail := upload(t, "front.png"), upload(t, "back.png"), upload(t, "detail.png")
+
+		frontLink, err := uc.LinkProductAsset(product.ID, LinkProductAssetInput{AssetID: front.ID, AltText: "Front"})
+		require.NoError(t, err)
+		assert.Equal(t, 0, frontLink.Position)
+		require.NotNil(t, frontLink.Asset)
+		assert.Equal(t, front.URL, frontLink.Asset.URL)
+
+		backLink, err := uc.LinkProductAsset(product.ID, LinkProductAssetInput{AssetID: back.ID, VariantID: &red.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 1, backLink.Position)
+		detailLink, err := uc.LinkProductAsset(product.ID, LinkProductAssetInput{AssetID: detail.ID, VariantID: &blue.ID})
+		require.NoError(t, err)
+
+		_, err = uc.LinkProductAsset(product.ID, LinkProductAssetInput{AssetID: 9999})
+		assert.ErrorContains(t, err, "not found")
+		otherVariant := blue.ID + 100
+		_, err = uc.LinkProductAsset(product.ID, LinkProductAssetInput{AssetID: front.ID, VariantID: &otherVariant})
+		assert.ErrorContains(t, err, "not found for product")
+
+		links, err := uc.ListProductAssets(product.ID, &red.ID)
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+		assert.Equal(t, frontLink.ID, links[0].ID)
+		assert.Equal(t, backLink.ID, links[1].ID)
+
+		links, err = uc.ReorderProductAssets(product.ID, []uint{detailLink.ID, backLink.ID, frontLink.ID})
+		require.NoError(t, err)
+		assert.Equal(t, detailLink.ID, links[0].ID)
+
+		altText := "Back of the red tee"
+		productWide := uint(0)
+		updated, err := uc.UpdateProductAsset(product.ID, backLink.ID, UpdateProductAssetInput{AltText: &altText, VariantID: &productWide})
+		require.NoError(t, err)
+		assert.Equal(t, altText, updated.AltText)
+		assert.Nil(t, updated.VariantID)
+
+		links, err = uc.ListProductAssets(product.ID, nil)
+		require.NoError(t, err)
+		require.Len(t, links, 3)
+		assert.Equal(t, []uint{detailLink.ID, backLink.ID, frontLink.ID}, []uint{links[0].ID, links[1].ID, links[2].ID})
+
+		require.NoError(t, uc.RemoveProductAsset(product.ID, detailLink.ID))
+		_, err = uc.GetAsset(detail.ID)
+		assert.NoError(t, err, "removing a link keeps the asset")
+	})
+
+	t.Run("Delete removes the files and links", func(t *testing.T) {
+		asset := upload(t, "gone.png")
+		_, err := uc.LinkProductAsset(product.ID, LinkProductAssetInput{AssetID: asset.ID})
+		require.NoError(t, err)
+
+		require.NoError(t, uc.DeleteAsset(asset.ID))
+		for _, key := range asset.StorageKeys() {
+			assert.NotContains(t, objects.objects, key)
+		}
+
+		links, err := uc.ListProductAssets(product.ID, nil)
+		require.NoError(t, err)
+		for _, link := range links {
+			assert.NotEqual(t, asset.ID, link.AssetID)
+		}
+	})
+}
+
+func TestParseImageSizes(t *testing.T) {
+	sizes, err := ParseImageSizes([]string{"thumbnail:200", " medium : 800 ", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []ImageSize{{Name: "thumbnail", Width: 200}, {Name: "medium", Width: 800}}, sizes)
+
+	_, err = ParseImageSizes([]string{"thumbnail"})
+	assert.Error(t, err)
+	_, err = ParseImageSizes([]string{"thumbnail:0"})
+	assert.Error(t, err)
+	_, err = ParseImageSizes([]string{"a:1", "a:2"})
+	assert.ErrorContains(t, err, "duplicate image size")
+}
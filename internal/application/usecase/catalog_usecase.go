@@ -0,0 +1,637 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// CategoryPathSeparator separates the category names of a catalog category path, e.g. "Clothing > Shirts"
+const CategoryPathSeparator = ">"
+
+// CatalogUseCase implements bulk import and export of the product catalog
+type CatalogUseCase struct {
+	productRepo        repository.ProductRepository
+	productVariantRepo repository.ProductVariantRepository
+	categoryRepo       repository.CategoryRepository
+	currencyRepo       repository.CurrencyRepository
+	transactor         repository.CatalogTransactor
+}
+
+// NewCatalogUseCase creates a new CatalogUseCase
+func NewCatalogUseCase(
+	productRepo repository.ProductRepository,
+	productVariantRepo repository.ProductVariantRepository,
+	categoryRepo repository.CategoryRepository,
+	currencyRepo repository.CurrencyRepository,
+	transactor repository.CatalogTransactor,
+) *CatalogUseCase {
+	return &CatalogUseCase{
+		productRepo:        productRepo,
+		productVariantRepo: productVariantRepo,
+		categoryRepo:       categoryRepo,
+		currencyRepo:       currencyRepo,
+		transactor:         transactor,
+	}
+}
+
+// CatalogRow is one variant of a product in a catalog import or export. Rows with the same slug,
+// or the same product name when the slug is empty, belong to the same product. Product fields
+// may be left empty on all but one row of a product.
+type CatalogRow struct {
+	Line          int // Position of the row in the source, used in error reports
+	ProductName   string
+	Slug          string
+	Description   string
+	Category      string // Category names from the root, e.g. "Clothing > Shirts"
+	Currency      string
+	Active        *bool // Defaults to true for new products
+	Images        []string
+	SKU           string
+	Price         float64 // Major units of the product currency, which may only be known from another row
+	Stock         int
+	Weight        float64
+	IsDefault     bool
+	Attributes    entity.VariantAttributes
+	VariantImages []string
+}
+
+// CatalogRowError reports why a catalog row cannot be imported
+type CatalogRowError struct {
+	Line    int    `json:"line"`
+	SKU     string `json:"sku,omitempty"`
+	Message string `json:"message"`
+}
+
+// CatalogImportInput contains the rows of a catalog import
+type CatalogImportInput struct {
+	Rows []CatalogRow
+	// Invalid reports rows that could not be parsed. Like validation errors, they prevent the import.
+	Invalid []CatalogRowError
+	DryRun  bool
+}
+
+// CatalogImportResult summarizes a catalog import. Nothing is saved when any row has an error.
+type CatalogImportResult struct {
+	DryRun            bool              `json:"dry_run"`
+	Applied           bool              `json:"applied"`
+	Rows              int               `json:"rows"`
+	ProductsCreated   int               `json:"products_created"`
+	ProductsUpdated   int               `json:"products_updated"`
+	VariantsCreated   int               `json:"variants_created"`
+	VariantsUpdated   int               `json:"variants_updated"`
+	CategoriesCreated int               `json:"categories_created"`
+	Errors            []CatalogRowError `json:"errors"`
+}
+
+// catalogProduct is a product of an import with the rows it was built from
+type catalogProduct struct {
+	rows         []*CatalogRow
+	product      *entity.Product
+	exists       bool
+	categoryPath []string
+}
+
+// ImportCatalog creates or updates products from catalog rows, matching existing products by variant SKU.
+// Missing categories are created. All rows are validated first and nothing is saved if any row is
+// invalid or the import is a dry run.
+func (uc *CatalogUseCase) ImportCatalog(input CatalogImportInput) (*CatalogImportResult, error) {
+	result := &CatalogImportResult{
+		DryRun: input.DryRun,
+		Rows:   len(input.Rows) + len(input.Invalid),
+		Errors: append([]CatalogRowError{}, input.Invalid...),
+	}
+	report := func(row *CatalogRow, format string, args ...any) {
+		result.Errors = append(result.Errors, CatalogRowError{Line: row.Line, SKU: row.SKU, Message: fmt.Sprintf(format, args...)})
+	}
+
+	categories, err := newCatalogCategories(uc.categoryRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	products := uc.groupCatalogRows(input.Rows, report)
+	currencies := make(map[string]error)
+	planned := make(map[uint]bool)
+	for _, p := range products {
+		err := uc.planCatalogProduct(p, categories, currencies)
+		if err == nil && p.exists {
+			if planned[p.product.ID] {
+				err = fmt.Errorf("rows of product %s must share the same slug or product name", p.product.Name)
+			}
+			planned[p.product.ID] = true
+		}
+		if err != nil {
+			for _, row := range p.rows {
+				report(row, "%s", err.Error())
+			}
+			continue
+		}
+
+		if p.exists {
+			result.ProductsUpdated++
+		} else {
+			result.ProductsCreated++
+		}
+		for _, variant := range p.product.Variants {
+			if variant.ID == 0 {
+				result.VariantsCreated++
+			} else if rowForSKU(p.rows, variant.SKU) != nil {
+				result.VariantsUpdated++
+			}
+		}
+	}
+	result.CategoriesCreated = categories.missing()
+
+	sort.SliceStable(result.Errors, func(i, j int) bool { return result.Errors[i].Line < result.Errors[j].Line })
+	if input.DryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	// Everything is valid, save the products with their categories in one transaction, so a failure
+	// leaves the catalog as it was
+	err = uc.transactor.Transaction(func(repos repository.CatalogRepositories) error {
+		for _, p := range products {
+			if len(p.categoryPath) > 0 {
+				categoryID, err := categories.create(repos.Categories, p.categoryPath)
+				if err != nil {
+					return err
+				}
+				p.product.CategoryID = categoryID
+			}
+
+			var err error
+			if p.exists {
+				err = saveCatalogVariants(repos.Variants, p.product)
+				if err == nil {
+					err = repos.Products.Update(p.product)
+				}
+			} else {
+				err = repos.Products.Create(p.product)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to save product %s: %w", p.product.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Applied = true
+	return result, nil
+}
+
+// groupCatalogRows validates the rows and groups them by product in order of appearance
+func (uc *CatalogUseCase) groupCatalogRows(rows []CatalogRow, report func(*CatalogRow, string, ...any)) []*catalogProduct {
+	var products []*catalogProduct
+	byKey := make(map[string]*catalogProduct)
+	skus := make(map[string]int)
+
+	for i := range rows {
+		row := &rows[i]
+		row.ProductName = strings.TrimSpace(row.ProductName)
+		row.Slug = strings.TrimSpace(row.Slug)
+		row.Category = strings.TrimSpace(row.Category)
+		row.Currency = strings.ToUpper(strings.TrimSpace(row.Currency))
+		row.SKU = strings.TrimSpace(row.SKU)
+
+		if row.SKU == "" {
+			report(row, "SKU is required")
+			continue
+		}
+		if line, ok := skus[row.SKU]; ok {
+			report(row, "SKU %s is already used on line %d", row.SKU, line)
+			continue
+		}
+		skus[row.SKU] = row.Line
+
+		key := row.Slug
+		if key == "" {
+			key = "name:" + strings.ToLower(row.ProductName)
+		}
+		if key == "name:" {
+			report(row, "product name or slug is required")
+			continue
+		}
+
+		p, ok := byKey[key]
+		if !ok {
+			p = &catalogProduct{}
+			byKey[key] = p
+			products = append(products, p)
+		}
+		p.rows = append(p.rows, row)
+	}
+	return products
+}
+
+// planCatalogProduct builds the product of a group of rows without saving it
+func (uc *CatalogUseCase) planCatalogProduct(p *catalogProduct, categories *catalogCategories, currencies map[string]error) error {
+	fields, err := catalogProductFields(p.rows)
+	if err != nil {
+		return err
+	}
+
+	// Existing products are matched by the SKUs of their variants
+	var existingID uint
+	for _, row := range p.rows {
+		variant, err := uc.productVariantRepo.GetBySKU(row.SKU)
+		if err != nil || variant == nil {
+			continue
+		}
+		if existingID != 0 && variant.ProductID != existingID {
+			return fmt.Errorf("SKUs of product %s belong to different existing products", fields.ProductName)
+		}
+		existingID = variant.ProductID
+	}
+
+	if fields.Currency != "" {
+		if _, ok := currencies[fields.Currency]; !ok {
+			currencies[fields.Currency] = nil
+			if _, err := uc.currencyRepo.GetByCode(fields.Currency); err != nil {
+				currencies[fields.Currency] = fmt.Errorf("invalid currency code: %s", fields.Currency)
+			}
+		}
+		if err := currencies[fields.Currency]; err != nil {
+			return err
+		}
+	}
+
+	slug, err := entity.NormalizeSlug(fields.Slug)
+	if err != nil {
+		return err
+	}
+	if slug != "" {
+		if other, err := uc.productRepo.GetBySlug(slug); err == nil && other.Slug == slug && other.ID != existingID {
+			return fmt.Errorf("slug %s is already in use", slug)
+		}
+	}
+
+	if fields.Category != "" {
+		if p.categoryPath, err = categories.parse(fields.Category); err != nil {
+			return err
+		}
+	}
+
+	categoryID := categories.plannedID(p.categoryPath)
+	if existingID == 0 {
+		p.product, err = newCatalogProduct(p.rows, fields, categoryID)
+	} else {
+		p.exists = true
+		p.product, err = uc.updateCatalogProduct(existingID, p.rows, fields, categoryID)
+	}
+	if err != nil {
+		return err
+	}
+	if slug != "" {
+		p.product.Slug = slug
+	}
+
+	return p.product.ValidateVariantOptions()
+}
+
+// catalogProductFields merges the product fields of the rows of a product, which must not conflict
+func catalogProductFields(rows []*CatalogRow) (CatalogRow, error) {
+	var fields CatalogRow
+	merge := func(name string, target *string, value string) error {
+		if value == "" {
+			return nil
+		}
+		if *target != "" && *target != value {
+			return fmt.Errorf("rows of product %s have different %s values", fields.ProductName, name)
+		}
+		*target = value
+		return nil
+	}
+
+	for _, row := range rows {
+		for _, field := range []struct {
+			name          string
+			target, value *string
+		}{
+			{"product_name", &fields.ProductName, &row.ProductName},
+			{"slug", &fields.Slug, &row.Slug},
+			{"description", &fields.Description, &row.Description},
+			{"category", &fields.Category, &row.Category},
+			{"currency", &fields.Currency, &row.Currency},
+		} {
+			if err := merge(field.name, field.target, *field.value); err != nil {
+				return fields, err
+			}
+		}
+		if row.Active != nil {
+			if fields.Active != nil && *fields.Active != *row.Active {
+				return fields, fmt.Errorf("rows of product %s have different active values", fields.ProductName)
+			}
+			fields.Active = row.Active
+		}
+		if len(row.Images) > 0 {
+			fields.Images = row.Images
+		}
+	}
+	return fields, nil
+}
+
+// newCatalogProduct creates a product from the rows of a product that does not exist yet
+func newCatalogProduct(rows []*CatalogRow, fields CatalogRow, categoryID uint) (*entity.Product, error) {
+	if fields.ProductName == "" {
+		return nil, fmt.Errorf("product name is required for new product %s", fields.Slug)
+	}
+	if fields.Currency == "" {
+		return nil, fmt.Errorf("currency is required for new product %s", fields.ProductName)
+	}
+	if categoryID == 0 {
+		return nil, fmt.Errorf("category is required for new product %s", fields.ProductName)
+	}
+
+	variants := make([]*entity.ProductVariant, 0, len(rows))
+	for _, row := range rows {
+		price := money.ToMinor(row.Price, fields.Currency)
+		variant, err := entity.NewProductVariant(row.SKU, row.Stock, price, row.Weight, row.Attributes, row.VariantImages, row.IsDefault)
+		if err != nil {
+			return nil, fmt.Errorf("variant %s: %w", row.SKU, err)
+		}
+		variants = append(variants, variant)
+	}
+	if err := setCatalogDefaultVariant(variants, ""); err != nil {
+		return nil, err
+	}
+
+	active := true
+	if fields.Active != nil {
+		active = *fields.Active
+	}
+
+	product, err := entity.NewProduct(fields.ProductName, fields.Description, fields.Currency, categoryID, fields.Images, variants, active)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// updateCatalogProduct applies the rows of a product to the existing product. Empty fields and
+// a zero category ID keep the current values.
+func (uc *CatalogUseCase) updateCatalogProduct(productID uint, rows []*CatalogRow, fields CatalogRow, categoryID uint) (*entity.Product, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	var newCategoryID *uint
+	if categoryID != 0 {
+		newCategoryID = &categoryID
+	}
+	product.Update(&fields.ProductName, &fields.Description, &fields.Currency, &fields.Images, fields.Active, newCategoryID)
+
+	defaultSKU := ""
+	for _, row := range rows {
+		if row.IsDefault {
+			defaultSKU = row.SKU
+		}
+
+		price := money.ToMinor(row.Price, product.Currency)
+		variant := product.GetVariantBySKU(row.SKU)
+		if variant == nil {
+			variant, err = entity.NewProductVariant(row.SKU, row.Stock, price, row.Weight, row.Attributes, row.VariantImages, false)
+			if err != nil {
+				return nil, fmt.Errorf("variant %s: %w", row.SKU, err)
+			}
+			if err := product.AddVariant(variant); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if row.Stock < 0 || row.Price < 0 || row.Weight < 0 {
+			return nil, fmt.Errorf("variant %s: stock, price and weight cannot be negative", row.SKU)
+		}
+		if _, err := variant.Update("", row.Stock, price, row.Weight, row.VariantImages, row.Attributes, nil); err != nil {
+			return nil, fmt.Errorf("variant %s: %w", row.SKU, err)
+		}
+	}
+
+	if err := setCatalogDefaultVariant(product.Variants, defaultSKU); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// setCatalogDefaultVariant makes the variant with the SKU the default variant. Without a SKU,
+// the variant marked as default is kept, or the first variant becomes the default.
+func setCatalogDefaultVariant(variants []*entity.ProductVariant, sku string) error {
+	if sku == "" {
+		for _, variant := range variants {
+			if variant.IsDefault {
+				if sku != "" {
+					return fmt.Errorf("variants %s and %s are both marked as default", sku, variant.SKU)
+				}
+				sku = variant.SKU
+			}
+		}
+	}
+	if sku == "" && len(variants) > 0 {
+		sku = variants[0].SKU
+	}
+
+	for _, variant := range variants {
+		variant.IsDefault = variant.SKU == sku
+	}
+	return nil
+}
+
+// saveCatalogVariants saves the variants of an existing product, which the product repository
+// does not update
+func saveCatalogVariants(variantRepo repository.ProductVariantRepository, product *entity.Product) error {
+	var created []*entity.ProductVariant
+	for _, variant := range product.Variants {
+		if variant.ID == 0 {
+			variant.ProductID = product.ID
+			created = append(created, variant)
+			continue
+		}
+		if err := variantRepo.Update(variant); err != nil {
+			return fmt.Errorf("failed to update variant %s: %w", variant.SKU, err)
+		}
+	}
+
+	if len(created) > 0 {
+		if err := variantRepo.BatchCreate(created); err != nil {
+			return fmt.Errorf("failed to create variants: %w", err)
+		}
+	}
+	return nil
+}
+
+// rowForSKU returns the row of a variant
+func rowForSKU(rows []*CatalogRow, sku string) *CatalogRow {
+	for _, row := range rows {
+		if row.SKU == sku {
+			return row
+		}
+	}
+	return nil
+}
+
+// ExportCatalog returns one row per variant of every product, in the format accepted by ImportCatalog
+func (uc *CatalogUseCase) ExportCatalog() ([]CatalogRow, error) {
+	products, err := uc.productRepo.ListWithVariants("", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := newCatalogCategories(uc.categoryRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]CatalogRow, 0, len(products))
+	for _, product := range products {
+		category := strings.Join(categories.path(product.CategoryID), " "+CategoryPathSeparator+" ")
+		active := product.Active
+
+		variants := append([]*entity.ProductVariant{}, product.Variants...)
+		sort.Slice(variants, func(i, j int) bool { return variants[i].ID < variants[j].ID })
+		for _, variant := range variants {
+			rows = append(rows, CatalogRow{
+				Line:          len(rows) + 1,
+				ProductName:   product.Name,
+				Slug:          product.Slug,
+				Description:   product.Description,
+				Category:      category,
+				Currency:      product.Currency,
+				Active:        &active,
+				Images:        product.Images,
+				SKU:           variant.SKU,
+				Price:         money.FromMinor(variant.Price, product.Currency),
+				Stock:         variant.Stock,
+				Weight:        variant.Weight,
+				IsDefault:     variant.IsDefault,
+				Attributes:    variant.Attributes.Data(),
+				VariantImages: variant.Images,
+			})
+		}
+	}
+	return rows, nil
+}
+
+// catalogCategories resolves category paths to categories, planning the categories that do not exist yet
+type catalogCategories struct {
+	byID     map[uint]*entity.Category
+	byPath   map[string]uint
+	planned  map[string]uint // Placeholder IDs of missing categories, never saved
+	nextPlan uint
+}
+
+func newCatalogCategories(repo repository.CategoryRepository) (*catalogCategories, error) {
+	list, err := repo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &catalogCategories{
+		byID:    make(map[uint]*entity.Category, len(list)),
+		byPath:  make(map[string]uint, len(list)),
+		planned: make(map[string]uint),
+	}
+	for _, category := range list {
+		c.byID[category.ID] = category
+		c.nextPlan = max(c.nextPlan, category.ID)
+	}
+	for _, category := range list {
+		c.byPath[c.key(c.path(category.ID))] = category.ID
+	}
+	return c, nil
+}
+
+// parse splits a category path into category names
+func (c *catalogCategories) parse(path string) ([]string, error) {
+	names := strings.Split(path, CategoryPathSeparator)
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+		if names[i] == "" {
+			return nil, fmt.Errorf("invalid category path %q", path)
+		}
+		if len(names[i]) > 255 {
+			return nil, fmt.Errorf("category name cannot exceed 255 characters in %q", path)
+		}
+	}
+	return names, nil
+}
+
+// path returns the category names from the root to the category
+func (c *catalogCategories) path(categoryID uint) []string {
+	var names []string
+	for category := c.byID[categoryID]; category != nil && len(names) <= len(c.byID); {
+		names = append([]string{category.Name}, names...)
+		if category.ParentID == nil {
+			break
+		}
+		category = c.byID[*category.ParentID]
+	}
+	return names
+}
+
+func (c *catalogCategories) key(names []string) string {
+	return strings.ToLower(strings.Join(names, "\x00"))
+}
+
+// plannedID returns the ID of the category with the path, or a placeholder ID when it would be created
+func (c *catalogCategories) plannedID(names []string) uint {
+	if len(names) == 0 {
+		return 0
+	}
+
+	key := c.key(names)
+	if id, ok := c.byPath[key]; ok {
+		return id
+	}
+	if id, ok := c.planned[key]; ok {
+		return id
+	}
+
+	// Parent categories are created as well
+	c.plannedID(names[:len(names)-1])
+	c.nextPlan++
+	c.planned[key] = c.nextPlan
+	return c.nextPlan
+}
+
+// missing returns the number of categories that would be created
+func (c *catalogCategories) missing() int {
+	return len(c.planned)
+}
+
+// create returns the ID of the category with the path, creating it and its missing parents with the repository
+func (c *catalogCategories) create(repo repository.CategoryRepository, names []string) (uint, error) {
+	key := c.key(names)
+	if id, ok := c.byPath[key]; ok {
+		return id, nil
+	}
+
+	var parentID *uint
+	if len(names) > 1 {
+		id, err := c.create(repo, names[:len(names)-1])
+		if err != nil {
+			return 0, err
+		}
+		parentID = &id
+	}
+
+	category, err := entity.NewCategory(names[len(names)-1], "", parentID)
+	if err != nil {
+		return 0, err
+	}
+	if err := repo.Create(category); err != nil {
+		return 0, fmt.Errorf("failed to create category %s: %w", strings.Join(names, " "+CategoryPathSeparator+" "), err)
+	}
+
+	c.byID[category.ID] = category
+	c.byPath[key] = category.ID
+	return category.ID, nil
+}
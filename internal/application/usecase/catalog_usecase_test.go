@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+// failingCatalogTransactor fails to create the product with the name inside the transaction
+type failingCatalogTransactor struct {
+	repository.CatalogTransactor
+	name string
+}
+
+func (t failingCatalogTransactor) Transaction(fn func(repos repository.CatalogRepositories) error) error {
+	return t.CatalogTransactor.Transaction(func(repos repository.CatalogRepositories) error {
+		repos.Products = failingProductRepository{ProductRepository: repos.Products, name: t.name}
+		return fn(repos)
+	})
+}
+
+type failingProductRepository struct {
+	repository.ProductRepository
+	name string
+}
+
+func (r failingProductRepository) Create(product *entity.Product) error {
+	if product.Name == r.name {
+		return errors.New("disk full")
+	}
+	return r.ProductRepository.Create(product)
+}
+
+func TestCatalogUseCase_ImportCatalog(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(currency).Error)
+
+	clothing, err := entity.NewCategory("Clothing", "", nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(clothing).Error)
+
+	productRepo := gorm.NewProductRepository(db)
+	categoryRepo := gorm.NewCategoryRepository(db)
+	uc := NewCatalogUseCase(productRepo, gorm.NewProductVariantRepository(db), categoryRepo, gorm.NewCurrencyRepository(db),
+		gorm.NewCatalogTransactor(db))
+
+	rows := func() []CatalogRow {
+		return []CatalogRow{
+			{Line: 2, ProductName: "Tee", Category: "Clothing > Shirts", Currency: "usd", SKU: "TEE-S", Price: 15, Stock: 3,
+				Attributes: entity.VariantAttributes{"size": "S"}},
+			{Line: 3, ProductName: "Tee", SKU: "TEE-M", Price: 15.5, Stock: 1, IsDefault: true,
+				Attributes: entity.VariantAttributes{"size": "M"}},
+			{Line: 4, ProductName: "Mug", Slug: "coffee-mug", Category: "Kitchen", Currency: "USD", SKU: "MUG", Price: 8},
+		}
+	}
+
+	t.Run("Dry run validates without saving", func(t *testing.T) {
+		result, err := uc.ImportCatalog(CatalogImportInput{Rows: rows(), DryRun: true})
+		require.NoError(t, err)
+		assert.Empty(t, result.Errors)
+		assert.False(t, result.Applied)
+		assert.Equal(t, 2, result.ProductsCreated)
+		assert.Equal(t, 3, result.VariantsCreated)
+		assert.Equal(t, 2, result.CategoriesCreated)
+
+		var count int64
+		require.NoError(t, db.Model(&entity.Product{}).Count(&count).Error)
+		assert.Zero(t, count)
+		require.NoError(t, db.Model(&entity.Category{}).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Invalid rows prevent the import", func(t *testing.T) {
+		invalid := append(rows(),
+			CatalogRow{Line: 5, ProductName: "Cap", Category: "Hats", Currency: "XYZ", SKU: "CAP", Price: 5},
+			CatalogRow{Line: 6, ProductName: "Sock", Category: "Clothing", Currency: "USD", SKU: "MUG", Price: 2},
+		)
+		result, err := uc.ImportCatalog(CatalogImportInput{
+			Rows:    invalid,
+			Invalid: []CatalogRowError{{Line: 7, Message: "invalid price \"abc\""}},
+		})
+		require.NoError(t, err)
+		assert.False(t, result.Applied)
+		require.Len(t, result.Errors, 3)
+		assert.Equal(t, 5, result.Errors[0].Line)
+		assert.Contains(t, result.Errors[0].Message, "invalid currency code")
+		assert.Equal(t, 6, result.Errors[1].Line)
+		assert.Contains(t, result.Errors[1].Message, "already used on line 4")
+		assert.Equal(t, 7, result.Errors[2].Line)
+
+		var count int64
+		require.NoError(t, db.Model(&entity.Product{}).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+
+	t.Run("A failing save leaves the catalog unchanged", func(t *testing.T) {
+		failing := NewCatalogUseCase(productRepo, gorm.NewProductVariantRepository(db), categoryRepo, gorm.NewCurrencyRepository(db),
+			failingCatalogTransactor{CatalogTransactor: gorm.NewCatalogTransactor(db), name: "Mug"})
+
+		_, err := failing.ImportCatalog(CatalogImportInput{Rows: rows()})
+		assert.ErrorContains(t, err, "failed to save product Mug")
+
+		var count int64
+		require.NoError(t, db.Model(&entity.Product{}).Count(&count).Error)
+		assert.Zero(t, count)
+		require.NoError(t, db.Model(&entity.Category{}).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Creates products and missing categories", func(t *testing.T) {
+		result, err := uc.ImportCatalog(CatalogImportInput{Rows: rows()})
+		require.NoError(t, err)
+		require.Empty(t, result.Errors)
+		assert.True(t, result.Applied)
+		assert.Equal(t, 2, result.CategoriesCreated)
+
+		shirts, err := categoryRepo.GetBySlug("shirts")
+		require.NoError(t, err)
+		require.NotNil(t, shirts.ParentID)
+		assert.Equal(t, clothing.ID, *shirts.ParentID)
+
+		tee, err := productRepo.GetBySlug("tee")
+		require.NoError(t, err)
+		assert.Equal(t, shirts.ID, tee.CategoryID)
+		assert.Equal(t, "USD", tee.Currency)
+		assert.True(t, tee.Active)
+		require.Len(t, tee.Variants, 2)
+		assert.Equal(t, int64(1550), tee.GetVariantBySKU("TEE-M").Price)
+		assert.True(t, tee.GetVariantBySKU("TEE-M").IsDefault)
+		assert.False(t, tee.GetVariantBySKU("TEE-S").IsDefault)
+
+		_, err = productRepo.GetBySlug("coffee-mug")
+		require.NoError(t, err)
+	})
+
+	t.Run("Updates products matched by SKU", func(t *testing.T) {
+		active := false
+		result, err := uc.ImportCatalog(CatalogImportInput{Rows: []CatalogRow{
+			{Line: 2, ProductName: "Classic Tee", Slug: "tee", Category: "Clothing", Active: &active, SKU: "TEE-S", Price: 12, Stock: 9,
+				Attributes: entity.VariantAttributes{"size": "S"}},
+			{Line: 3, Slug: "tee", SKU: "TEE-L", Price: 16, Stock: 4, Attributes: entity.VariantAttributes{"size": "L"}},
+		}})
+		require.NoError(t, err)
+		require.Empty(t, result.Errors)
+		assert.Equal(t, 1, result.ProductsUpdated)
+		assert.Equal(t, 1, result.VariantsUpdated)
+		assert.Equal(t, 1, result.VariantsCreated)
+		assert.Zero(t, result.CategoriesCreated)
+
+		tee, err := productRepo.GetBySlug("tee")
+		require.NoError(t, err)
+		assert.Equal(t, "Classic Tee", tee.Name)
+		assert.Equal(t, clothing.ID, tee.CategoryID)
+		assert.False(t, tee.Active)
+		require.Len(t, tee.Variants, 3)
+		assert.Equal(t, int64(1200), tee.GetVariantBySKU("TEE-S").Price)
+		assert.Equal(t, 9, tee.GetVariantBySKU("TEE-S").Stock)
+		assert.Equal(t, int64(1600), tee.GetVariantBySKU("TEE-L").Price)
+		assert.True(t, tee.GetVariantBySKU("TEE-M").IsDefault)
+	})
+
+	t.Run("Export can be imported again", func(t *testing.T) {
+		exported, err := uc.ExportCatalog()
+		require.NoError(t, err)
+		require.Len(t, exported, 4)
+		assert.Equal(t, "Clothing", exported[0].Category)
+		assert.Equal(t, "TEE-S", exported[0].SKU)
+		assert.Equal(t, 12.0, exported[0].Price)
+		assert.Equal(t, "Kitchen", exported[3].Category)
+
+		result, err := uc.ImportCatalog(CatalogImportInput{Rows: exported})
+		require.NoError(t, err)
+		require.Empty(t, result.Errors)
+		assert.Equal(t, 2, result.ProductsUpdated)
+		assert.Equal(t, 4, result.VariantsUpdated)
+		assert.Zero(t, result.ProductsCreated+result.VariantsCreated+result.CategoriesCreated)
+	})
+}
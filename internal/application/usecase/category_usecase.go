@@ -3,6 +3,7 @@ package usecase
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
@@ -24,9 +25,11 @@ func NewCategoryUseCase(categoryRepo repository.CategoryRepository, productRepo
 }
 
 type CreateCategory struct {
-	Name        string `json:"name" validate:"required,min=1,max=255"`
-	Description string `json:"description,omitempty" validate:"max=1000"`
-	ParentID    *uint  `json:"parent_id,omitempty"` // Optional parent category ID
+	Name        string             `json:"name" validate:"required,min=1,max=255"`
+	Slug        string             `json:"slug,omitempty"` // Generated from the name when empty
+	Description string             `json:"description,omitempty" validate:"max=1000"`
+	SEO         entity.SEOMetadata `json:"seo"`
+	ParentID    *uint              `json:"parent_id,omitempty"` // Optional parent category ID
 }
 
 // CreateCategory creates a new category
@@ -48,6 +51,14 @@ func (uc *CategoryUseCase) CreateCategory(input CreateCategory) (*entity.Categor
 		return nil, err
 	}
 
+	if category.Slug, err = entity.NormalizeSlug(input.Slug); err != nil {
+		return nil, err
+	}
+	if err := input.SEO.Validate(); err != nil {
+		return nil, err
+	}
+	category.SEO = input.SEO
+
 	// Save to repository
 	if err := uc.categoryRepo.Create(category); err != nil {
 		// Check for unique constraint violations
@@ -67,21 +78,34 @@ func (uc *CategoryUseCase) CreateCategory(input CreateCategory) (*entity.Categor
 	return category, nil
 }
 
-// GetCategory retrieves a category by ID
+// GetCategoryBySlug retrieves a category by its current or a former slug.
+// The slug of the result differs from the given slug when it is a former slug.
+func (uc *CategoryUseCase) GetCategoryBySlug(slug string) (*entity.Category, error) {
+	category, err := uc.categoryRepo.GetBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return category, uc.setPath(category)
+}
+
+// GetCategory retrieves a category by ID with its breadcrumb path
 func (uc *CategoryUseCase) GetCategory(categoryID uint) (*entity.Category, error) {
 	category, err := uc.categoryRepo.GetByID(categoryID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get category: %w", err)
 	}
 
-	return category, nil
+	return category, uc.setPath(category)
 }
 
 type UpdateCategory struct {
-	CategoryID  uint   `json:"category_id" validate:"required"`
-	Name        string `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
-	Description string `json:"description,omitempty" validate:"max=1000"`
-	ParentID    *uint  `json:"parent_id,omitempty"` // Optional parent category ID (0 means remove parent)
+	CategoryID  uint                `json:"category_id" validate:"required"`
+	Name        string              `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Slug        *string             `json:"slug,omitempty"` // An empty slug is generated from the name again
+	Description string              `json:"description,omitempty" validate:"max=1000"`
+	SEO         *entity.SEOMetadata `json:"seo,omitempty"`
+	ParentID    *uint               `json:"parent_id,omitempty"` // Optional parent category ID (0 means remove parent)
 }
 
 // UpdateCategory updates an existing category
@@ -116,7 +140,11 @@ func (uc *CategoryUseCase) UpdateCategory(input UpdateCategory) (*entity.Categor
 		if parent == nil {
 			return nil, errors.New("parent category does not exist")
 		}
+		if err := uc.checkNoCycle(input.CategoryID, *actualParentID); err != nil {
+			return nil, err
+		}
 	}
+	parentChanged := input.ParentID != nil && !sameParent(category.ParentID, actualParentID)
 
 	// Update fields if provided
 	if input.Name != "" {
@@ -128,6 +156,18 @@ func (uc *CategoryUseCase) UpdateCategory(input UpdateCategory) (*entity.Categor
 	if input.ParentID != nil {
 		category.ParentID = actualParentID
 	}
+	// A changed slug is saved with the previous slug as a redirect
+	if input.Slug != nil {
+		if category.Slug, err = entity.NormalizeSlug(*input.Slug); err != nil {
+			return nil, err
+		}
+	}
+	if input.SEO != nil {
+		if err := input.SEO.Validate(); err != nil {
+			return nil, err
+		}
+		category.SEO = *input.SEO
+	}
 
 	// Save updated category
 	if err := uc.categoryRepo.Update(category); err != nil {
@@ -144,6 +184,13 @@ func (uc *CategoryUseCase) UpdateCategory(input UpdateCategory) (*entity.Categor
 		return nil, fmt.Errorf("failed to update category: %w", err)
 	}
 
+	// A category moved to another parent is placed after its new siblings
+	if parentChanged {
+		if err := uc.placeCategory(category.ID, actualParentID, -1); err != nil {
+			return nil, err
+		}
+	}
+
 	// Clear associations if ParentID was set to nil
 	if input.ParentID != nil && actualParentID == nil {
 		category.Parent = nil
@@ -194,13 +241,16 @@ func (uc *CategoryUseCase) DeleteCategory(categoryID uint) error {
 	return nil
 }
 
-// ListCategories retrieves all categories
+// ListCategories retrieves all categories with their breadcrumb paths
 func (uc *CategoryUseCase) ListCategories() ([]*entity.Category, error) {
 	categories, err := uc.categoryRepo.List()
 	if err != nil {
 		return nil, fmt.Errorf("failed to list categories: %w", err)
 	}
 
+	for _, category := range categories {
+		category.Path = entity.CategoryPath(categories, category.ID)
+	}
 	return categories, nil
 }
 
@@ -220,3 +270,165 @@ func (uc *CategoryUseCase) GetChildCategories(parentID uint) ([]*entity.Category
 
 	return children, nil
 }
+
+// GetCategoryTree retrieves all categories as a tree ordered by position on each level.
+// Product counts include the descendants and, with a sales channel, only the products visible in it.
+func (uc *CategoryUseCase) GetCategoryTree(channel entity.SalesChannel) ([]*entity.CategoryTreeNode, error) {
+	categories, err := uc.categoryRepo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	counts, err := uc.categoryRepo.CountProducts(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.BuildCategoryTree(categories, counts), nil
+}
+
+// MoveCategory contains the data needed to move a category with its subtree
+type MoveCategory struct {
+	CategoryID uint  `json:"category_id"`
+	ParentID   *uint `json:"parent_id"` // New parent, nil or 0 for the root
+	Position   int   `json:"position"`  // Position among the new siblings, -1 to place it last
+}
+
+// MoveCategory moves a category with its subtree below another parent, or to another position
+// among its siblings. A category cannot be moved below itself or one of its descendants.
+func (uc *CategoryUseCase) MoveCategory(input MoveCategory) (*entity.Category, error) {
+	if _, err := uc.categoryRepo.GetByID(input.CategoryID); err != nil {
+		return nil, err
+	}
+
+	parentID := input.ParentID
+	if parentID != nil && *parentID == 0 {
+		parentID = nil
+	}
+	if parentID != nil {
+		if _, err := uc.categoryRepo.GetByID(*parentID); err != nil {
+			return nil, fmt.Errorf("parent category not found: %w", err)
+		}
+		if err := uc.checkNoCycle(input.CategoryID, *parentID); err != nil {
+			return nil, err
+		}
+	}
+	if input.Position < -1 {
+		return nil, errors.New("category position cannot be negative")
+	}
+
+	if err := uc.placeCategory(input.CategoryID, parentID, input.Position); err != nil {
+		return nil, err
+	}
+	return uc.GetCategory(input.CategoryID)
+}
+
+// ReorderCategories sets the order of the children of a parent, or of the root categories when the
+// parent is nil. The IDs must list every category on that level exactly once.
+func (uc *CategoryUseCase) ReorderCategories(parentID *uint, categoryIDs []uint) ([]*entity.Category, error) {
+	if parentID != nil && *parentID == 0 {
+		parentID = nil
+	}
+
+	categories, err := uc.categoryRepo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	siblings := childrenOf(categories, parentID)
+
+	if len(categoryIDs) != len(siblings) {
+		return nil, fmt.Errorf("category order must list all %d categories on the level", len(siblings))
+	}
+	seen := make(map[uint]bool, len(categoryIDs))
+	for _, categoryID := range categoryIDs {
+		if seen[categoryID] {
+			return nil, fmt.Errorf("category %d is listed more than once", categoryID)
+		}
+		seen[categoryID] = true
+	}
+	for _, sibling := range siblings {
+		if !seen[sibling.ID] {
+			return nil, fmt.Errorf("category order must list all %d categories on the level", len(siblings))
+		}
+	}
+
+	if err := uc.categoryRepo.Reorder(parentID, categoryIDs); err != nil {
+		return nil, err
+	}
+
+	if parentID == nil {
+		categories, err = uc.categoryRepo.List()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list categories: %w", err)
+		}
+		return childrenOf(categories, nil), nil
+	}
+	return uc.categoryRepo.GetChildren(*parentID)
+}
+
+// placeCategory moves the category below the parent at the position among the siblings, last when -1
+func (uc *CategoryUseCase) placeCategory(categoryID uint, parentID *uint, position int) error {
+	categories, err := uc.categoryRepo.List()
+	if err != nil {
+		return fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	order := make([]uint, 0)
+	for _, sibling := range childrenOf(categories, parentID) {
+		if sibling.ID != categoryID {
+			order = append(order, sibling.ID)
+		}
+	}
+	if position < 0 || position > len(order) {
+		position = len(order)
+	}
+	order = slices.Insert(order, position, categoryID)
+
+	return uc.categoryRepo.Reorder(parentID, order)
+}
+
+// checkNoCycle checks that the parent is not the category itself or one of its descendants
+func (uc *CategoryUseCase) checkNoCycle(categoryID, parentID uint) error {
+	categories, err := uc.categoryRepo.List()
+	if err != nil {
+		return fmt.Errorf("failed to list categories: %w", err)
+	}
+	if slices.Contains(entity.CategoryDescendantIDs(categories, categoryID), parentID) {
+		return errors.New("category cannot be moved below itself or its descendants")
+	}
+	return nil
+}
+
+// setPath sets the breadcrumb path of the category
+func (uc *CategoryUseCase) setPath(category *entity.Category) error {
+	categories, err := uc.categoryRepo.List()
+	if err != nil {
+		return fmt.Errorf("failed to list categories: %w", err)
+	}
+	category.Path = entity.CategoryPath(categories, category.ID)
+	if len(category.Path) > 0 {
+		// The category itself ends its path
+		category.Path[len(category.Path)-1] = category
+	}
+	return nil
+}
+
+// childrenOf returns the categories below the parent, or the root categories when nil, in their order
+func childrenOf(categories []*entity.Category, parentID *uint) []*entity.Category {
+	var children []*entity.Category
+	for _, category := range categories {
+		if sameParent(category.ParentID, parentID) {
+			children = append(children, category)
+		}
+	}
+	entity.SortCategories(children)
+	return children
+}
+
+// sameParent reports whether two parent IDs refer to the same parent, nil being the root
+func sameParent(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
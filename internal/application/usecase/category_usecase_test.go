@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -174,3 +175,136 @@ func TestCategoryUseCase_UpdateCategory_ParentIDZero(t *testing.T) {
 		assert.Nil(t, fetchedCategory.ParentID, "ParentID should be nil after setting to 0")
 	})
 }
+
+func TestCategoryUseCase_Tree(t *testing.T) {
+	setup := func(t *testing.T) (*CategoryUseCase, map[string]*entity.Category) {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+
+		productRepo := gorm.NewProductRepository(db)
+		uc := NewCategoryUseCase(gorm.NewCategoryRepository(db), productRepo)
+
+		categories := make(map[string]*entity.Category)
+		for _, c := range []struct{ name, parent string }{
+			{"Clothing", ""}, {"Shirts", "Clothing"}, {"Linen", "Shirts"}, {"Trousers", "Clothing"}, {"Garden", ""},
+		} {
+			input := CreateCategory{Name: c.name}
+			if c.parent != "" {
+				input.ParentID = &categories[c.parent].ID
+			}
+			category, err := uc.CreateCategory(input)
+			require.NoError(t, err)
+			categories[c.name] = category
+		}
+
+		for i, c := range []struct {
+			category string
+			active   bool
+		}{{"Linen", true}, {"Shirts", true}, {"Trousers", false}, {"Garden", true}} {
+			variant, err := entity.NewProductVariant(fmt.Sprintf("SKU-%d", i), 1, 1000, 1, nil, nil, true)
+			require.NoError(t, err)
+			product, err := entity.NewProduct(fmt.Sprintf("Product %d", i), "", "USD", categories[c.category].ID, nil, []*entity.ProductVariant{variant}, true)
+			require.NoError(t, err)
+			require.NoError(t, productRepo.Create(product))
+			require.NoError(t, db.Model(product).Update("active", c.active).Error)
+		}
+		return uc, categories
+	}
+
+	names := func(nodes []*entity.CategoryTreeNode) []string {
+		result := make([]string, len(nodes))
+		for i, node := range nodes {
+			result[i] = node.Category.Name
+		}
+		return result
+	}
+
+	t.Run("Tree keeps creation order with product counts of descendants", func(t *testing.T) {
+		uc, _ := setup(t)
+
+		tree, err := uc.GetCategoryTree(entity.SalesChannelWebshop)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Clothing", "Garden"}, names(tree))
+		assert.Equal(t, []string{"Shirts", "Trousers"}, names(tree[0].Children))
+		assert.Equal(t, 2, tree[0].ProductCount, "inactive products are not counted in a channel")
+		assert.Equal(t, 1, tree[0].Children[0].Children[0].ProductCount)
+
+		tree, err = uc.GetCategoryTree("")
+		require.NoError(t, err)
+		assert.Equal(t, 3, tree[0].ProductCount)
+	})
+
+	t.Run("Categories have breadcrumbs", func(t *testing.T) {
+		uc, categories := setup(t)
+
+		category, err := uc.GetCategory(categories["Linen"].ID)
+		require.NoError(t, err)
+		dto := category.ToCategoryDTO()
+		require.Len(t, dto.Breadcrumbs, 3)
+		assert.Equal(t, "Clothing", dto.Breadcrumbs[0].Name)
+		assert.Equal(t, "Linen", dto.Breadcrumbs[2].Name)
+
+		list, err := uc.ListCategories()
+		require.NoError(t, err)
+		for _, c := range list {
+			assert.Equal(t, c.ID, c.Path[len(c.Path)-1].ID)
+		}
+	})
+
+	t.Run("MoveCategory moves the subtree and rejects cycles", func(t *testing.T) {
+		uc, categories := setup(t)
+
+		_, err := uc.MoveCategory(MoveCategory{CategoryID: categories["Clothing"].ID, ParentID: &categories["Linen"].ID, Position: -1})
+		assert.EqualError(t, err, "category cannot be moved below itself or its descendants")
+		_, err = uc.MoveCategory(MoveCategory{CategoryID: categories["Clothing"].ID, ParentID: &categories["Clothing"].ID, Position: -1})
+		assert.Error(t, err)
+
+		moved, err := uc.MoveCategory(MoveCategory{CategoryID: categories["Shirts"].ID, ParentID: &categories["Garden"].ID, Position: 0})
+		require.NoError(t, err)
+		assert.Equal(t, categories["Garden"].ID, *moved.ParentID)
+		assert.Len(t, moved.Path, 2)
+
+		tree, err := uc.GetCategoryTree("")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Trousers"}, names(tree[0].Children))
+		assert.Equal(t, []string{"Shirts"}, names(tree[1].Children))
+		assert.Equal(t, []string{"Linen"}, names(tree[1].Children[0].Children))
+		assert.Equal(t, 3, tree[1].ProductCount)
+
+		root := uint(0)
+		_, err = uc.MoveCategory(MoveCategory{CategoryID: categories["Shirts"].ID, ParentID: &root, Position: 0})
+		require.NoError(t, err)
+		tree, err = uc.GetCategoryTree("")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Shirts", "Clothing", "Garden"}, names(tree))
+	})
+
+	t.Run("UpdateCategory rejects moving below a descendant", func(t *testing.T) {
+		uc, categories := setup(t)
+
+		_, err := uc.UpdateCategory(UpdateCategory{CategoryID: categories["Clothing"].ID, ParentID: &categories["Shirts"].ID})
+		assert.EqualError(t, err, "category cannot be moved below itself or its descendants")
+
+		updated, err := uc.UpdateCategory(UpdateCategory{CategoryID: categories["Trousers"].ID, ParentID: &categories["Garden"].ID})
+		require.NoError(t, err)
+		assert.Equal(t, 0, updated.Position)
+	})
+
+	t.Run("ReorderCategories sets the order of a level", func(t *testing.T) {
+		uc, categories := setup(t)
+
+		children, err := uc.ReorderCategories(&categories["Clothing"].ID, []uint{categories["Trousers"].ID, categories["Shirts"].ID})
+		require.NoError(t, err)
+		require.Len(t, children, 2)
+		assert.Equal(t, "Trousers", children[0].Name)
+
+		roots, err := uc.ReorderCategories(nil, []uint{categories["Garden"].ID, categories["Clothing"].ID})
+		require.NoError(t, err)
+		assert.Equal(t, "Garden", roots[0].Name)
+
+		_, err = uc.ReorderCategories(nil, []uint{categories["Garden"].ID})
+		assert.ErrorContains(t, err, "must list all 2 categories")
+		_, err = uc.ReorderCategories(nil, []uint{categories["Garden"].ID, categories["Garden"].ID})
+		assert.ErrorContains(t, err, "more than once")
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
 	"github.com/zenfulcode/commercify/internal/domain/repository"
 )
 
@@ -213,20 +214,17 @@ func createDiscountOffer() *DiscountOfferData {
 	}
 }
 
-// Helper function to format currency
+// Helper function to format an amount in minor units of the currency
 func formatCurrency(amount int64, currency string) string {
-	// Format amount as decimal
-	decimal := float64(amount) / 100
-
 	// Format based on currency
 	switch currency {
 	case "USD":
-		return fmt.Sprintf("$%.2f", decimal)
+		return money.FormatMinor(amount, currency, "$")
 	case "EUR":
-		return fmt.Sprintf("€%.2f", decimal)
+		return money.FormatMinor(amount, currency, "€")
 	case "GBP":
-		return fmt.Sprintf("£%.2f", decimal)
+		return money.FormatMinor(amount, currency, "£")
 	default:
-		return fmt.Sprintf("%.2f %s", decimal, currency)
+		return money.FormatWithCode(amount, currency)
 	}
 }
@@ -22,12 +22,14 @@ type CheckoutInput struct {
 	VariantName string
 	ProductID   uint // Internal use only - resolved from SKU
 	VariantID   uint // Internal use only - resolved from SKU
+	UserID      uint // Signed-in customer whose group prices apply, 0 for guests
 }
 
 // UpdateCheckoutItemInput defines the input for updating a checkout item
 type UpdateCheckoutItemInput struct {
 	SKU      string
 	Quantity int
+	UserID   uint // Signed-in customer whose group prices apply, 0 for guests
 }
 
 // RemoveItemInput defines the input for removing an item from a checkout
@@ -43,11 +45,15 @@ type CheckoutUseCase struct {
 	shippingMethodRepo repository.ShippingMethodRepository
 	shippingRateRepo   repository.ShippingRateRepository
 	discountRepo       repository.DiscountRepository
+	discountCodeRepo   repository.DiscountCodeRepository
 	orderRepo          repository.OrderRepository
 	currencyRepo       repository.CurrencyRepository
 	paymentTxnRepo     repository.PaymentTransactionRepository
+	priceListRepo      repository.PriceListRepository
 	paymentSvc         service.PaymentService
 	shippingUsecase    *ShippingUseCase
+	collectionUsecase  *CollectionUseCase
+	translationUsecase *TranslationUseCase
 }
 
 type ProcessPaymentInput struct {
@@ -75,7 +81,7 @@ func (uc *CheckoutUseCase) ProcessPayment(order *entity.Order, input ProcessPaym
 		return nil, errors.New("customer details are required for payment processing")
 	}
 
-	if order.GetShippingOption() == nil {
+	if order.RequiresShipping() && order.GetShippingOption() == nil {
 		return nil, errors.New("shipping method is required for payment processing")
 	}
 
@@ -269,12 +275,15 @@ func NewCheckoutUseCase(
 	shippingMethodRepo repository.ShippingMethodRepository,
 	shippingRateRepo repository.ShippingRateRepository,
 	discountRepo repository.DiscountRepository,
+	discountCodeRepo repository.DiscountCodeRepository,
 	orderRepo repository.OrderRepository,
 	currencyRepo repository.CurrencyRepository,
 	paymentTxnRepo repository.PaymentTransactionRepository,
+	priceListRepo repository.PriceListRepository,
 	paymentSvc service.PaymentService,
 	shippingUsecase *ShippingUseCase,
-
+	collectionUsecase *CollectionUseCase,
+	translationUsecase *TranslationUseCase,
 ) *CheckoutUseCase {
 	return &CheckoutUseCase{
 		checkoutRepo:       checkoutRepo,
@@ -283,11 +292,15 @@ func NewCheckoutUseCase(
 		shippingMethodRepo: shippingMethodRepo,
 		shippingRateRepo:   shippingRateRepo,
 		discountRepo:       discountRepo,
+		discountCodeRepo:   discountCodeRepo,
 		orderRepo:          orderRepo,
 		paymentTxnRepo:     paymentTxnRepo,
 		currencyRepo:       currencyRepo,
+		priceListRepo:      priceListRepo,
 		paymentSvc:         paymentSvc,
 		shippingUsecase:    shippingUsecase,
+		collectionUsecase:  collectionUsecase,
+		translationUsecase: translationUsecase,
 	}
 }
 
@@ -411,6 +424,7 @@ func (uc *CheckoutUseCase) SetShippingMethod(checkout *entity.Checkout, methodID
 		Address:     *shippingAddr,
 		OrderValue:  checkout.TotalAmount,
 		OrderWeight: checkout.TotalWeight,
+		Currency:    checkout.Currency,
 	}
 
 	// Calculate shipping options
@@ -432,6 +446,11 @@ func (uc *CheckoutUseCase) SetShippingMethod(checkout *entity.Checkout, methodID
 		return nil, fmt.Errorf("shipping method %d is not available for the current checkout", methodID)
 	}
 
+	// Name the shipping method in the language of the customer
+	if err := uc.translationUsecase.LocalizeShippingOptions(checkout.Locale, selectedOption); err != nil {
+		return nil, fmt.Errorf("failed to translate shipping method: %w", err)
+	}
+
 	// Set shipping method and cost
 	checkout.SetShippingMethod(selectedOption)
 
@@ -466,7 +485,7 @@ func (uc *CheckoutUseCase) SetPaymentProvider(userID uint, provider string) (*en
 // ApplyDiscountCode applies a discount code to the user's checkout
 func (uc *CheckoutUseCase) ApplyDiscountCode(checkout *entity.Checkout, code string) (*entity.Checkout, error) {
 	// Get discount
-	discount, err := uc.discountRepo.GetByCode(code)
+	discount, err := uc.resolveCheckoutDiscount(checkout, code)
 	if err != nil {
 		return nil, err
 	}
@@ -488,6 +507,30 @@ func (uc *CheckoutUseCase) ApplyDiscountCode(checkout *entity.Checkout, code str
 	return checkout, nil
 }
 
+// resolveCheckoutDiscount looks up a discount code, resolves which checkout products are in its collections
+// and converts its amounts from the default currency into the checkout currency
+func (uc *CheckoutUseCase) resolveCheckoutDiscount(checkout *entity.Checkout, code string) (*entity.Discount, error) {
+	discount, _, err := resolveDiscountCode(uc.discountRepo, uc.discountCodeRepo, code)
+	if err != nil {
+		return nil, err
+	}
+
+	baseCurrency, checkoutCurrency, err := resolveCurrencyConversion(uc.currencyRepo, checkout.Currency)
+	if err != nil {
+		return nil, err
+	}
+	discount.SetCurrencyConversion(baseCurrency, checkoutCurrency)
+
+	productIDs := make([]uint, len(checkout.Items))
+	for i, item := range checkout.Items {
+		productIDs[i] = item.ProductID
+	}
+	if err := uc.collectionUsecase.ResolveDiscountCollections(discount, productIDs); err != nil {
+		return nil, err
+	}
+	return discount, nil
+}
+
 // RemoveDiscountCode removes a discount code from the user's checkout
 func (uc *CheckoutUseCase) RemoveDiscountCode(checkout *entity.Checkout) (*entity.Checkout, error) {
 	// Remove discount
@@ -607,22 +650,36 @@ func (uc *CheckoutUseCase) CreateOrderFromCheckout(checkoutID uint) (*entity.Ord
 		return nil, errors.New("checkout has no items")
 	}
 
-	// Validate stock availability for all items before creating order
+	// Validate availability and stock of all items before creating order. Products may have
+	// been unpublished since they were added.
+	variants := make(map[uint]*entity.ProductVariant, len(checkout.Items))
+	now := time.Now()
 	for _, item := range checkout.Items {
+		product, err := uc.productRepo.GetByID(item.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product for availability validation: %w", err)
+		}
+		if !product.IsVisible(entity.SalesChannelWebshop, now) {
+			return nil, fmt.Errorf("product %s is no longer available", item.ProductName)
+		}
+
 		variant, err := uc.productVariantRepo.GetByID(item.ProductVariantID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get variant for stock validation: %w", err)
 		}
 
 		if !variant.IsAvailable(item.Quantity) {
-			return nil, fmt.Errorf("insufficient stock for product variant '%s'. Available: %d, Required: %d", variant.SKU, variant.Stock, item.Quantity)
+			return nil, fmt.Errorf("insufficient stock for product variant '%s'. Available: %d, Required: %d", variant.SKU, variant.AvailableStock(), item.Quantity)
 		}
+		variants[item.ProductVariantID] = variant
 	}
 
+	// Checkouts of digital variants only are delivered as downloads without shipping
+	requiresShipping := checkout.RequiresShipping()
 	shippingAddr := checkout.GetShippingAddress()
 	billingAddr := checkout.GetBillingAddress()
 
-	if shippingAddr.Street1 == "" || shippingAddr.Country == "" {
+	if requiresShipping && (shippingAddr.Street1 == "" || shippingAddr.Country == "") {
 		return nil, errors.New("shipping address is required")
 	}
 
@@ -634,19 +691,53 @@ func (uc *CheckoutUseCase) CreateOrderFromCheckout(checkoutID uint) (*entity.Ord
 		return nil, errors.New("customer details are required")
 	}
 
-	if checkout.GetShippingOption() == nil {
+	if requiresShipping && checkout.GetShippingOption() == nil {
 		return nil, errors.New("shipping method is required")
 	}
 
+	// Single-use codes may have been redeemed by another checkout in the meantime
+	var redeemedCode *entity.DiscountCode
+	if appliedDiscount := checkout.GetAppliedDiscount(); appliedDiscount != nil {
+		if discountCode, err := uc.discountCodeRepo.GetByCode(appliedDiscount.DiscountCode); err == nil {
+			if !discountCode.IsRedeemable() {
+				return nil, errors.New("discount code has already been redeemed")
+			}
+			redeemedCode = discountCode
+		}
+	}
+
 	// Convert checkout to order
 	order, erro := entity.NewOrderFromCheckout(checkout)
 	if erro != nil {
 		return nil, fmt.Errorf("failed to create order from checkout: %w", erro)
 	}
 
+	// Record the components of bundles for fulfillment and returns
+	for i := range order.Items {
+		order.Items[i].SetBundleComponents(variants[order.Items[i].ProductVariantID])
+	}
+
+	// Snapshot the exchange rate so the order can be restated in the default currency later
+	if err := uc.snapshotExchangeRate(order); err != nil {
+		return nil, err
+	}
+
+	// Claim the redemption of the batch code before creating the order. The claim fails
+	// when a concurrent checkout redeemed the last use of the code first.
+	if redeemedCode != nil {
+		if err := uc.discountCodeRepo.IncrementUsage(redeemedCode.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create order in repository
 	err = uc.orderRepo.Create(order)
 	if err != nil {
+		if redeemedCode != nil {
+			if releaseErr := uc.discountCodeRepo.ReleaseUsage(redeemedCode.ID); releaseErr != nil {
+				log.Printf("Failed to release discount code redemption: %v", releaseErr)
+			}
+		}
 		return nil, err
 	}
 
@@ -662,7 +753,7 @@ func (uc *CheckoutUseCase) CreateOrderFromCheckout(checkoutID uint) (*entity.Ord
 	err = uc.checkoutRepo.Update(checkout)
 	// TODO: Handle error but do not return it, as we want to proceed with order creation even if updating the checkout fails
 	if err != nil {
-		fmt.Printf("Failed to update checkout after order creation: %v\n", err)
+		log.Printf("Failed to update checkout after order creation: %v", err)
 	}
 
 	// Increment discount usage if a discount was applied
@@ -902,8 +993,8 @@ func (uc *CheckoutUseCase) AddItemToCheckout(checkoutID uint, input CheckoutInpu
 		return nil, fmt.Errorf("failed to get product for variant: %w", err)
 	}
 
-	// Check if product is active
-	if !product.Active {
+	// The product must be published in the webshop
+	if !product.IsVisible(entity.SalesChannelWebshop, time.Now()) {
 		return nil, errors.New("product is not available")
 	}
 
@@ -919,13 +1010,15 @@ func (uc *CheckoutUseCase) AddItemToCheckout(checkoutID uint, input CheckoutInpu
 	totalQuantity := existingQuantity + input.Quantity
 
 	if !variant.IsAvailable(totalQuantity) {
-		return nil, fmt.Errorf("insufficient stock for product variant '%s'. Available: %d, Total requested: %d (existing: %d + new: %d)", variant.SKU, variant.Stock, totalQuantity, existingQuantity, input.Quantity)
+		return nil, fmt.Errorf("insufficient stock for product variant '%s'. Available: %d, Total requested: %d (existing: %d + new: %d)", variant.SKU, variant.AvailableStock(), totalQuantity, existingQuantity, input.Quantity)
 	}
 
 	// Handle currency mismatch
 	if product.Currency != checkout.Currency {
-		// If the checkout is empty, change the checkout currency to match the product
-		if len(checkout.Items) == 0 {
+		if _, ok := variant.GetExplicitPrice(checkout.Currency); ok {
+			// The variant has an explicit price in the checkout currency
+		} else if len(checkout.Items) == 0 {
+			// If the checkout is empty, change the checkout currency to match the product
 			checkout, err = uc.ChangeCurrency(checkout, product.Currency)
 			if err != nil {
 				return nil, fmt.Errorf("failed to change checkout currency to %s: %w", product.Currency, err)
@@ -936,13 +1029,28 @@ func (uc *CheckoutUseCase) AddItemToCheckout(checkoutID uint, input CheckoutInpu
 		}
 	}
 
+	priceList := resolveCustomerPriceList(uc.priceListRepo, input.UserID)
+
+	// Tell the shopper about items already in the checkout whose price changed since they were added
+	uc.repriceItems(checkout, priceList, true)
+
+	price, err := uc.catalogPrice(variant, checkout.Currency, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Item names are kept in the language of the customer for the order and its emails
+	if err := uc.translationUsecase.LocalizeProducts(checkout.Locale, product); err != nil {
+		return nil, fmt.Errorf("failed to translate product: %w", err)
+	}
+
 	// TODO: This might be redundant if we always use variants
 	// Populate input with variant details
 	input.ProductID = variant.ProductID
 	input.VariantID = variant.ID
 	input.ProductName = product.Name
 	input.VariantName = variant.Name()
-	input.Price = variant.Price
+	input.Price = price
 	input.Weight = variant.Weight
 
 	// Add the item to the checkout
@@ -951,6 +1059,9 @@ func (uc *CheckoutUseCase) AddItemToCheckout(checkoutID uint, input CheckoutInpu
 		return nil, err
 	}
 
+	// Customer group prices may depend on the total quantity of the item
+	uc.repriceItems(checkout, priceList, false)
+
 	// Save the updated checkout
 	err = uc.checkoutRepo.Update(checkout)
 	if err != nil {
@@ -995,25 +1106,32 @@ func (uc *CheckoutUseCase) UpdateCheckoutItemBySKU(checkoutID uint, input Update
 		return nil, fmt.Errorf("failed to get product for variant: %w", err)
 	}
 
-	// Check if product is active
-	if !product.Active {
+	// The product must be published in the webshop
+	if !product.IsVisible(entity.SalesChannelWebshop, time.Now()) {
 		return nil, errors.New("product is not available")
 	}
 
 	// Check stock availability for the new quantity
 	if !variant.IsAvailable(input.Quantity) {
-		return nil, fmt.Errorf("insufficient stock for product variant '%s'. Available: %d, Requested: %d", variant.SKU, variant.Stock, input.Quantity)
+		return nil, fmt.Errorf("insufficient stock for product variant '%s'. Available: %d, Requested: %d", variant.SKU, variant.AvailableStock(), input.Quantity)
 	}
 
 	productID := variant.ProductID
 	variantID := variant.ID
 
+	// Tell the shopper about items whose price changed since they were added
+	priceList := resolveCustomerPriceList(uc.priceListRepo, input.UserID)
+	uc.repriceItems(checkout, priceList, true)
+
 	// Update the item in the checkout
 	err = checkout.UpdateItem(productID, variantID, input.Quantity)
 	if err != nil {
 		return nil, err
 	}
 
+	// Customer group prices may depend on the new quantity
+	uc.repriceItems(checkout, priceList, false)
+
 	// Save the updated checkout
 	err = uc.checkoutRepo.Update(checkout)
 	if err != nil {
@@ -1097,6 +1215,23 @@ func (uc *CheckoutUseCase) ChangeCurrency(checkout *entity.Checkout, newCurrency
 	// Convert the checkout currency and all prices
 	checkout.SetCurrency(newCurrencyCode, fromCurrency, toCurrency)
 
+	// Price items from the catalog in the new currency, preferring explicit variant prices over converted ones
+	uc.repriceItems(checkout, nil, false)
+
+	// Re-apply the discount so fixed amounts use the explicit value for the new currency
+	if applied := checkout.GetAppliedDiscount(); applied != nil && applied.DiscountCode != "" {
+		if discount, err := uc.resolveCheckoutDiscount(checkout, applied.DiscountCode); err == nil && discount.IsValid() {
+			checkout.ApplyDiscount(discount)
+		}
+	}
+
+	// Recalculate shipping so per-currency rates are used
+	if option := checkout.GetShippingOption(); option != nil && option.ShippingMethodID != 0 {
+		if updated, err := uc.SetShippingMethod(checkout, option.ShippingMethodID); err == nil {
+			checkout = updated
+		}
+	}
+
 	// Update checkout in repository
 	err = uc.checkoutRepo.Update(checkout)
 	if err != nil {
@@ -1106,6 +1241,46 @@ func (uc *CheckoutUseCase) ChangeCurrency(checkout *entity.Checkout, newCurrency
 	return checkout, nil
 }
 
+// SetLocale changes the language of a checkout. The names of its items and shipping method
+// are translated, so the order and its emails use the language of the customer.
+func (uc *CheckoutUseCase) SetLocale(checkout *entity.Checkout, locale string) (*entity.Checkout, error) {
+	if locale == "" || checkout.Locale == locale || checkout.Status != entity.CheckoutStatusActive {
+		return checkout, nil
+	}
+	checkout.Locale = locale
+
+	// Products are loaded separately, as the products of the items are saved with the checkout
+	products := make(map[uint]*entity.Product, len(checkout.Items))
+	for _, item := range checkout.Items {
+		if _, ok := products[item.ProductID]; ok {
+			continue
+		}
+		product, err := uc.productRepo.GetByID(item.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product of checkout item: %w", err)
+		}
+		if err := uc.translationUsecase.LocalizeProducts(locale, product); err != nil {
+			return nil, fmt.Errorf("failed to translate product: %w", err)
+		}
+		products[item.ProductID] = product
+	}
+	for i := range checkout.Items {
+		checkout.Items[i].ProductName = products[checkout.Items[i].ProductID].Name
+	}
+
+	// Select the shipping method again so it is named in the new locale
+	if option := checkout.GetShippingOption(); option != nil && option.ShippingMethodID != 0 {
+		if updated, err := uc.SetShippingMethod(checkout, option.ShippingMethodID); err == nil {
+			checkout = updated
+		}
+	}
+
+	if err := uc.checkoutRepo.Update(checkout); err != nil {
+		return nil, fmt.Errorf("failed to update checkout: %w", err)
+	}
+	return checkout, nil
+}
+
 // ChangeCurrencyBySessionID changes the currency of a checkout by session ID
 func (uc *CheckoutUseCase) ChangeCurrencyBySessionID(sessionID string, newCurrencyCode string) (*entity.Checkout, error) {
 	// Get checkout by session ID
@@ -1128,35 +1303,174 @@ func (uc *CheckoutUseCase) ChangeCurrencyByUserID(userID uint, newCurrencyCode s
 	return uc.ChangeCurrency(checkout, newCurrencyCode)
 }
 
-// decreaseStockForOrder decreases stock for all items in an order when payment is authorized
+// decreaseStockForOrder decreases stock for all items in an order when payment is authorized.
+// Bundles decrease the stock of their components.
 func (uc *CheckoutUseCase) decreaseStockForOrder(order *entity.Order) error {
 	for _, item := range order.Items {
-		// Skip items without variant ID (shouldn't happen, but safety check)
-		if item.ProductVariantID == 0 {
-			continue
+		for _, unit := range item.StockUnits() {
+			// Skip items without variant ID (shouldn't happen, but safety check)
+			if unit.ProductVariantID == 0 {
+				continue
+			}
+
+			// Get the variant
+			variant, err := uc.productVariantRepo.GetByID(unit.ProductVariantID)
+			if err != nil {
+				return fmt.Errorf("failed to get variant %d: %w", unit.ProductVariantID, err)
+			}
+
+			// Check if there's enough stock
+			if variant.Stock < unit.Quantity {
+				return fmt.Errorf("insufficient stock for product %s (SKU: %s): available %d, required %d",
+					item.ProductName, unit.SKU, variant.Stock, unit.Quantity)
+			}
+
+			// Update stock
+			if err := variant.UpdateStock(-unit.Quantity); err != nil {
+				return fmt.Errorf("failed to update stock for variant %d: %w", unit.ProductVariantID, err)
+			}
+
+			// Save the updated variant
+			if err := uc.productVariantRepo.Update(variant); err != nil {
+				return fmt.Errorf("failed to save variant %d: %w", unit.ProductVariantID, err)
+			}
 		}
+	}
+	return nil
+}
+
+// snapshotExchangeRate records the rate between the order currency and the default currency on the order.
+// Without a default currency the order currency is recorded as the base with a rate of 1.
+func (uc *CheckoutUseCase) snapshotExchangeRate(order *entity.Order) error {
+	defaultCurrency, err := uc.currencyRepo.GetDefault()
+	if errors.Is(err, repository.ErrCurrencyNotFound) {
+		log.Printf("No default currency configured, recording the order currency %s as the base currency", order.Currency)
+		return order.SetExchangeRate(order.Currency, 1)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get default currency: %w", err)
+	}
+
+	rate := 1.0
+	if order.Currency != defaultCurrency.Code {
+		orderCurrency, err := uc.currencyRepo.GetByCode(order.Currency)
+		if err != nil {
+			return fmt.Errorf("failed to get order currency: %w", err)
+		}
+		rate = orderCurrency.ExchangeRate / defaultCurrency.ExchangeRate
+	}
+
+	return order.SetExchangeRate(defaultCurrency.Code, rate)
+}
+
+// ApplyCustomerPrices reprices the checkout with the price list of the user's customer group.
+// Checkouts of guests and users without a price list are returned unchanged.
+func (uc *CheckoutUseCase) ApplyCustomerPrices(checkout *entity.Checkout, userID uint) (*entity.Checkout, error) {
+	priceList := resolveCustomerPriceList(uc.priceListRepo, userID)
+	if priceList == nil || checkout.Status != entity.CheckoutStatusActive || len(checkout.Items) == 0 {
+		return checkout, nil
+	}
+
+	if !uc.repriceItems(checkout, priceList, false) {
+		return checkout, nil
+	}
+
+	if err := uc.checkoutRepo.Update(checkout); err != nil {
+		return nil, fmt.Errorf("failed to update checkout: %w", err)
+	}
+
+	return checkout, nil
+}
+
+// RefreshItemPrices reprices the items of an active checkout, for example when a sale started or
+// ended since they were added. Changed prices are listed in the PriceChanges of the returned checkout.
+func (uc *CheckoutUseCase) RefreshItemPrices(checkout *entity.Checkout, userID uint) (*entity.Checkout, error) {
+	if checkout.Status != entity.CheckoutStatusActive || len(checkout.Items) == 0 {
+		return checkout, nil
+	}
 
-		// Get the variant
+	priceList := resolveCustomerPriceList(uc.priceListRepo, userID)
+	if !uc.repriceItems(checkout, priceList, true) {
+		return checkout, nil
+	}
+
+	if err := uc.checkoutRepo.Update(checkout); err != nil {
+		return nil, fmt.Errorf("failed to update checkout: %w", err)
+	}
+
+	return checkout, nil
+}
+
+// repriceItems sets every item to its current unit price: the customer price list price for its
+// quantity when there is one, otherwise the variant price in the checkout currency, converted when
+// the variant has no explicit price in it, so items leaving a quantity break lose the list price.
+// Active sales apply when they are lower. With notify set, changed prices are recorded for the shopper.
+// The applied discount is recalculated so that minimum order values are checked against the new prices.
+func (uc *CheckoutUseCase) repriceItems(checkout *entity.Checkout, priceList *entity.PriceList, notify bool) bool {
+	now := time.Now()
+	changed := false
+
+	for _, item := range checkout.Items {
 		variant, err := uc.productVariantRepo.GetByID(item.ProductVariantID)
 		if err != nil {
-			return fmt.Errorf("failed to get variant %d: %w", item.ProductVariantID, err)
+			continue
+		}
+
+		price, err := uc.catalogPrice(variant, checkout.Currency, now)
+		if err != nil {
+			continue
+		}
+
+		if listPrice, ok := priceList.PriceFor(item.ProductVariantID, checkout.Currency, item.Quantity); ok {
+			if !variant.IsOnSale(now) || listPrice < price {
+				price = listPrice
+			}
 		}
 
-		// Check if there's enough stock
-		if variant.Stock < item.Quantity {
-			return fmt.Errorf("insufficient stock for product %s (SKU: %s): available %d, required %d",
-				item.ProductName, item.SKU, variant.Stock, item.Quantity)
+		if item.Price == price {
+			continue
 		}
 
-		// Update stock
-		if err := variant.UpdateStock(variant.Stock - item.Quantity); err != nil {
-			return fmt.Errorf("failed to update stock for variant %d: %w", item.ProductVariantID, err)
+		if notify {
+			checkout.RepriceItem(item.ProductVariantID, price)
+		} else {
+			checkout.SetItemPrice(item.ProductVariantID, price)
 		}
+		changed = true
+	}
+
+	if !changed {
+		return false
+	}
 
-		// Save the updated variant
-		if err := uc.productVariantRepo.Update(variant); err != nil {
-			return fmt.Errorf("failed to save variant %d: %w", item.ProductVariantID, err)
+	if applied := checkout.GetAppliedDiscount(); applied != nil && applied.DiscountCode != "" {
+		if discount, err := uc.resolveCheckoutDiscount(checkout, applied.DiscountCode); err == nil && discount.IsValid() {
+			checkout.ApplyDiscount(discount)
 		}
 	}
-	return nil
+
+	return true
+}
+
+// catalogPrice returns the variant price in the given currency at the given time, including active sales
+func (uc *CheckoutUseCase) catalogPrice(variant *entity.ProductVariant, currency string, at time.Time) (int64, error) {
+	if variant.Product.Currency == currency {
+		return variant.EffectivePrice(at), nil
+	}
+
+	if explicitPrice, ok := variant.GetExplicitPrice(currency); ok && !variant.IsOnSale(at) {
+		return explicitPrice, nil
+	}
+
+	productCurrency, err := uc.currencyRepo.GetByCode(variant.Product.Currency)
+	if err != nil {
+		return 0, fmt.Errorf("currency %s not found: %w", variant.Product.Currency, err)
+	}
+
+	targetCurrency, err := uc.currencyRepo.GetByCode(currency)
+	if err != nil {
+		return 0, fmt.Errorf("currency %s not found: %w", currency, err)
+	}
+
+	return variant.EffectivePriceIn(productCurrency, targetCurrency, at), nil
 }
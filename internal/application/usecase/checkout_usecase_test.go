@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormdb "gorm.io/gorm"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestCheckoutUseCase_SalePrices(t *testing.T) {
+	setup := func(t *testing.T) (*gormdb.DB, *CheckoutUseCase, *entity.ProductVariant) {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+
+		currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+		require.NoError(t, err)
+		require.NoError(t, db.Create(currency).Error)
+
+		testutil.CreateTestProduct(t, db, 1)
+		variant, err := entity.NewProductVariant("SALE-1", 100, 49900, 1, nil, nil, true)
+		require.NoError(t, err)
+		variant.ProductID = 1
+		require.NoError(t, db.Create(variant).Error)
+
+		checkoutUseCase := NewCheckoutUseCase(
+			gorm.NewCheckoutRepository(db),
+			gorm.NewProductRepository(db),
+			gorm.NewProductVariantRepository(db),
+			gorm.NewShippingMethodRepository(db),
+			gorm.NewShippingRateRepository(db),
+			gorm.NewDiscountRepository(db),
+			gorm.NewDiscountCodeRepository(db),
+			gorm.NewOrderRepository(db),
+			gorm.NewCurrencyRepository(db),
+			gorm.NewTransactionRepository(db),
+			gorm.NewPriceListRepository(db),
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+
+		return db, checkoutUseCase, variant
+	}
+
+	setSale := func(t *testing.T, db *gormdb.DB, variant *entity.ProductVariant, price int64, startsAt, endsAt *time.Time) {
+		require.NoError(t, variant.SetSalePrice(&price, startsAt, endsAt))
+		require.NoError(t, db.Save(variant).Error)
+	}
+
+	t.Run("Items added during a sale get the sale price", func(t *testing.T) {
+		db, checkoutUseCase, variant := setup(t)
+		setSale(t, db, variant, 39900, nil, nil)
+
+		checkout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID("session-1")
+		require.NoError(t, err)
+
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "SALE-1", Quantity: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(39900), checkout.Items[0].Price)
+		assert.Empty(t, checkout.PriceChanges)
+	})
+
+	t.Run("Checkout is repriced when a sale starts and ends", func(t *testing.T) {
+		db, checkoutUseCase, variant := setup(t)
+
+		checkout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID("session-1")
+		require.NoError(t, err)
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "SALE-1", Quantity: 2})
+		require.NoError(t, err)
+		assert.Equal(t, int64(99800), checkout.TotalAmount)
+
+		// The sale starts
+		startedAt := time.Now().Add(-time.Minute)
+		setSale(t, db, variant, 39900, &startedAt, nil)
+
+		checkout, err = checkoutUseCase.RefreshItemPrices(checkout, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(39900), checkout.Items[0].Price)
+		assert.Equal(t, int64(79800), checkout.TotalAmount)
+		require.Len(t, checkout.PriceChanges, 1)
+		assert.Equal(t, int64(49900), checkout.PriceChanges[0].PreviousPrice)
+		assert.Equal(t, int64(39900), checkout.PriceChanges[0].Price)
+
+		// The new price was persisted, so a later refresh has nothing to report
+		checkout, err = checkoutUseCase.GetCheckoutBySessionID("session-1")
+		require.NoError(t, err)
+		checkout, err = checkoutUseCase.RefreshItemPrices(checkout, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(39900), checkout.Items[0].Price)
+		assert.Empty(t, checkout.PriceChanges)
+
+		// The sale ends
+		endedAt := time.Now().Add(-time.Second)
+		setSale(t, db, variant, 39900, &startedAt, &endedAt)
+
+		checkout, err = checkoutUseCase.RefreshItemPrices(checkout, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(49900), checkout.Items[0].Price)
+		require.Len(t, checkout.PriceChanges, 1)
+		assert.Equal(t, int64(39900), checkout.PriceChanges[0].PreviousPrice)
+	})
+
+	t.Run("Adding an item reports other repriced items", func(t *testing.T) {
+		db, checkoutUseCase, variant := setup(t)
+
+		other, err := entity.NewProductVariant("OTHER-1", 100, 1000, 1, nil, nil, false)
+		require.NoError(t, err)
+		other.ProductID = 1
+		require.NoError(t, db.Create(other).Error)
+
+		checkout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID("session-1")
+		require.NoError(t, err)
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "SALE-1", Quantity: 1})
+		require.NoError(t, err)
+
+		setSale(t, db, variant, 39900, nil, nil)
+
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "OTHER-1", Quantity: 1})
+		require.NoError(t, err)
+		require.Len(t, checkout.PriceChanges, 1)
+		assert.Equal(t, "SALE-1", checkout.PriceChanges[0].SKU)
+		assert.Equal(t, int64(40900), checkout.TotalAmount)
+	})
+}
+
+func TestCheckoutUseCase_BundleStock(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(currency).Error)
+
+	testutil.CreateTestProduct(t, db, 1)
+	bundleProduct := testutil.CreateTestProduct(t, db, 2)
+	require.NoError(t, db.Model(bundleProduct).Update("type", entity.ProductTypeBundle).Error)
+
+	variantRepo := gorm.NewProductVariantRepository(db)
+	createVariant := func(productID uint, sku string, stock int) *entity.ProductVariant {
+		variant, err := entity.NewProductVariant(sku, stock, 2500, 1, nil, nil, true)
+		require.NoError(t, err)
+		variant.ProductID = productID
+		require.NoError(t, variantRepo.Create(variant))
+		return variant
+	}
+	mug := createVariant(1, "MUG", 5)
+	giftBox := createVariant(2, "GIFT-BOX", 0)
+
+	component, err := entity.NewBundleComponent(mug.ID, 2)
+	require.NoError(t, err)
+	require.NoError(t, variantRepo.SetComponents(giftBox.ID, []*entity.BundleComponent{component}))
+
+	checkoutUseCase := NewCheckoutUseCase(
+		gorm.NewCheckoutRepository(db),
+		gorm.NewProductRepository(db),
+		variantRepo,
+		gorm.NewShippingMethodRepository(db),
+		gorm.NewShippingRateRepository(db),
+		gorm.NewDiscountRepository(db),
+		gorm.NewDiscountCodeRepository(db),
+		gorm.NewOrderRepository(db),
+		gorm.NewCurrencyRepository(db),
+		gorm.NewTransactionRepository(db),
+		gorm.NewPriceListRepository(db),
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	t.Run("Bundle availability comes from the components", func(t *testing.T) {
+		checkout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID("session-1")
+		require.NoError(t, err)
+
+		_, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "GIFT-BOX", Quantity: 3})
+		assert.ErrorContains(t, err, "Available: 2")
+
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "GIFT-BOX", Quantity: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 2, checkout.Items[0].Quantity)
+	})
+
+	t.Run("Orders decrease and record the component stock", func(t *testing.T) {
+		bundle, err := variantRepo.GetByID(giftBox.ID)
+		require.NoError(t, err)
+
+		item := entity.OrderItem{ProductID: 2, ProductVariantID: giftBox.ID, Quantity: 2, Price: 2500, SKU: "GIFT-BOX", ProductName: "Gift Box"}
+		item.SetBundleComponents(bundle)
+		require.Len(t, item.Components, 1)
+		assert.Equal(t, "MUG", item.Components[0].SKU)
+
+		order, err := entity.NewOrder(nil, []entity.OrderItem{item}, "USD", &entity.Address{}, &entity.Address{}, entity.CustomerDetails{})
+		require.NoError(t, err)
+		require.NoError(t, gorm.NewOrderRepository(db).Create(order))
+
+		stored, err := gorm.NewOrderRepository(db).GetByID(order.ID)
+		require.NoError(t, err)
+		require.Len(t, stored.Items[0].Components, 1)
+		assert.Equal(t, 4, stored.ToOrderItemsDTO()[0].Components[0].TotalQuantity)
+
+		require.NoError(t, checkoutUseCase.decreaseStockForOrder(stored))
+
+		mug, err := variantRepo.GetByID(mug.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, mug.Stock)
+
+		bundle, err = variantRepo.GetByID(giftBox.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, bundle.Stock)
+		assert.Equal(t, 0, bundle.AvailableStock())
+
+		assert.ErrorContains(t, checkoutUseCase.decreaseStockForOrder(stored), "insufficient stock")
+	})
+}
+
+func TestCheckoutUseCase_ChangeCurrency(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	usd, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(usd).Error)
+	dkk, err := entity.NewCurrency("DKK", "Danish Krone", "kr", 7, true, false)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(dkk).Error)
+
+	testutil.CreateTestProduct(t, db, 1)
+	variant, err := entity.NewProductVariant("CURRENCY-1", 100, 10000, 1, nil, nil, true)
+	require.NoError(t, err)
+	variant.ProductID = 1
+	require.NoError(t, db.Create(variant).Error)
+
+	discount, err := entity.NewDiscount("TENOFF", entity.DiscountTypeBasket, entity.DiscountMethodFixed, 10, 0, 0,
+		nil, nil, nil, time.Now().Add(-time.Hour), time.Now().Add(time.Hour), 0)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(discount).Error)
+
+	checkoutUseCase := NewCheckoutUseCase(
+		gorm.NewCheckoutRepository(db),
+		gorm.NewProductRepository(db),
+		gorm.NewProductVariantRepository(db),
+		gorm.NewShippingMethodRepository(db),
+		gorm.NewShippingRateRepository(db),
+		gorm.NewDiscountRepository(db),
+		gorm.NewDiscountCodeRepository(db),
+		gorm.NewOrderRepository(db),
+		gorm.NewCurrencyRepository(db),
+		gorm.NewTransactionRepository(db),
+		gorm.NewPriceListRepository(db),
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	checkout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID("session-1")
+	require.NoError(t, err)
+	checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "CURRENCY-1", Quantity: 1})
+	require.NoError(t, err)
+	checkout, err = checkoutUseCase.ApplyDiscountCode(checkout, "TENOFF")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), checkout.DiscountAmount)
+
+	t.Run("Fixed discounts without an explicit amount are converted from the default currency", func(t *testing.T) {
+		checkout, err := checkoutUseCase.ChangeCurrency(checkout, "DKK")
+		require.NoError(t, err)
+		assert.Equal(t, int64(70000), checkout.Items[0].Price)
+		assert.Equal(t, int64(7000), checkout.DiscountAmount)
+		assert.Equal(t, int64(63000), checkout.FinalAmount)
+	})
+}
+
+func TestCheckoutUseCase_SnapshotExchangeRate(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	checkoutUseCase := &CheckoutUseCase{currencyRepo: gorm.NewCurrencyRepository(db)}
+
+	t.Run("Orders fall back to their own currency without a default currency", func(t *testing.T) {
+		order := &entity.Order{Currency: "DKK"}
+		require.NoError(t, checkoutUseCase.snapshotExchangeRate(order))
+		assert.Equal(t, "DKK", order.BaseCurrency)
+		assert.Equal(t, 1.0, order.ExchangeRate)
+	})
+
+	t.Run("Orders record the rate to the default currency", func(t *testing.T) {
+		usd, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+		require.NoError(t, err)
+		require.NoError(t, db.Create(usd).Error)
+		dkk, err := entity.NewCurrency("DKK", "Danish Krone", "kr", 7, true, false)
+		require.NoError(t, err)
+		require.NoError(t, db.Create(dkk).Error)
+
+		order := &entity.Order{Currency: "DKK"}
+		require.NoError(t, checkoutUseCase.snapshotExchangeRate(order))
+		assert.Equal(t, "USD", order.BaseCurrency)
+		assert.Equal(t, 7.0, order.ExchangeRate)
+	})
+}
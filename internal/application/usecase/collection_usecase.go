@@ -0,0 +1,502 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// CollectionUseCase implements the use cases of manual and smart product collections
+type CollectionUseCase struct {
+	collectionRepo repository.CollectionRepository
+	productRepo    repository.ProductRepository
+	categoryRepo   repository.CategoryRepository
+	priceListRepo  repository.PriceListRepository
+}
+
+// NewCollectionUseCase creates a new CollectionUseCase
+func NewCollectionUseCase(
+	collectionRepo repository.CollectionRepository,
+	productRepo repository.ProductRepository,
+	categoryRepo repository.CategoryRepository,
+	priceListRepo repository.PriceListRepository,
+) *CollectionUseCase {
+	return &CollectionUseCase{
+		collectionRepo: collectionRepo,
+		productRepo:    productRepo,
+		categoryRepo:   categoryRepo,
+		priceListRepo:  priceListRepo,
+	}
+}
+
+// CollectionRulesInput contains the rules of a smart collection (prices in major units of the currency)
+type CollectionRulesInput struct {
+	CategoryIDs       []uint              `json:"category_ids"`
+	Attributes        map[string][]string `json:"attributes"`
+	MinPrice          float64             `json:"min_price"`
+	MaxPrice          float64             `json:"max_price"`
+	Currency          string              `json:"currency"` // Currency of the prices, e.g. JPY prices have no decimals
+	CreatedAfter      *time.Time          `json:"created_after"`
+	CreatedWithinDays int                 `json:"created_within_days"`
+	InStockOnly       bool                `json:"in_stock_only"`
+}
+
+// toEntity converts the input to collection rules
+func (r *CollectionRulesInput) toEntity() entity.CollectionRules {
+	if r == nil {
+		return entity.CollectionRules{}
+	}
+	return entity.CollectionRules{
+		CategoryIDs:       r.CategoryIDs,
+		Attributes:        r.Attributes,
+		MinPrice:          money.ToMinor(r.MinPrice, r.Currency),
+		MaxPrice:          money.ToMinor(r.MaxPrice, r.Currency),
+		Currency:          strings.ToUpper(strings.TrimSpace(r.Currency)),
+		CreatedAfter:      r.CreatedAfter,
+		CreatedWithinDays: r.CreatedWithinDays,
+		InStockOnly:       r.InStockOnly,
+	}
+}
+
+// CreateCollectionInput contains the data needed to create a collection
+type CreateCollectionInput struct {
+	Name        string                `json:"name"`
+	Slug        string                `json:"slug,omitempty"` // Generated from the name when empty
+	Description string                `json:"description"`
+	SEO         entity.SEOMetadata    `json:"seo"`
+	Type        entity.CollectionType `json:"type"`
+	Rules       *CollectionRulesInput `json:"rules,omitempty"` // Required for smart collections
+	ProductIDs  []uint                `json:"product_ids"`     // Products of a manual collection in display order
+	Active      bool                  `json:"active"`
+}
+
+// CreateCollection creates a collection (admin only)
+func (uc *CollectionUseCase) CreateCollection(input CreateCollectionInput) (*entity.Collection, error) {
+	collection, err := entity.NewCollection(input.Name, input.Description, input.Type, input.Rules.toEntity())
+	if err != nil {
+		return nil, err
+	}
+	if collection.Slug, err = entity.NormalizeSlug(input.Slug); err != nil {
+		return nil, err
+	}
+	if err := input.SEO.Validate(); err != nil {
+		return nil, err
+	}
+	collection.SEO = input.SEO
+	collection.Active = input.Active
+
+	if err := uc.checkRules(collection); err != nil {
+		return nil, err
+	}
+	if collection.IsSmart() && len(input.ProductIDs) > 0 {
+		return nil, errors.New("products can only be placed in manual collections")
+	}
+	products, err := uc.newCollectionProducts(0, input.ProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.collectionRepo.Create(collection); err != nil {
+		return nil, err
+	}
+	if len(products) > 0 {
+		for _, product := range products {
+			product.CollectionID = collection.ID
+		}
+		if err := uc.collectionRepo.SetProducts(collection.ID, products); err != nil {
+			return nil, err
+		}
+	}
+	return collection, nil
+}
+
+// UpdateCollectionInput contains the data needed to update a collection, nil fields are left unchanged
+type UpdateCollectionInput struct {
+	Name        *string               `json:"name,omitempty"`
+	Slug        *string               `json:"slug,omitempty"` // An empty slug is generated from the name again
+	Description *string               `json:"description,omitempty"`
+	SEO         *entity.SEOMetadata   `json:"seo,omitempty"`
+	Rules       *CollectionRulesInput `json:"rules,omitempty"` // Replaces the rules of a smart collection
+	Active      *bool                 `json:"active,omitempty"`
+}
+
+// UpdateCollection updates a collection (admin only). The type of a collection cannot change.
+func (uc *CollectionUseCase) UpdateCollection(id uint, input UpdateCollectionInput) (*entity.Collection, error) {
+	collection, err := uc.collectionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	name, description := collection.Name, collection.Description
+	if input.Name != nil {
+		name = *input.Name
+	}
+	if input.Description != nil {
+		description = *input.Description
+	}
+	if err := collection.SetDetails(name, description); err != nil {
+		return nil, err
+	}
+	if input.Slug != nil {
+		if collection.Slug, err = entity.NormalizeSlug(*input.Slug); err != nil {
+			return nil, err
+		}
+	}
+	if input.SEO != nil {
+		if err := input.SEO.Validate(); err != nil {
+			return nil, err
+		}
+		collection.SEO = *input.SEO
+	}
+	if input.Rules != nil {
+		if err := collection.SetRules(input.Rules.toEntity()); err != nil {
+			return nil, err
+		}
+		if err := uc.checkRules(collection); err != nil {
+			return nil, err
+		}
+	}
+	if input.Active != nil {
+		collection.Active = *input.Active
+	}
+
+	if err := uc.collectionRepo.Update(collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// DeleteCollection deletes a collection (admin only)
+func (uc *CollectionUseCase) DeleteCollection(id uint) error {
+	if _, err := uc.collectionRepo.GetByID(id); err != nil {
+		return err
+	}
+	return uc.collectionRepo.Delete(id)
+}
+
+// GetCollection returns a collection by ID (admin only)
+func (uc *CollectionUseCase) GetCollection(id uint) (*entity.Collection, error) {
+	return uc.collectionRepo.GetByID(id)
+}
+
+// GetActiveCollectionBySlug returns an active collection by its current or a former slug.
+// The slug of the result differs from the given slug when it is a former slug.
+func (uc *CollectionUseCase) GetActiveCollectionBySlug(slug string) (*entity.Collection, error) {
+	collection, err := uc.collectionRepo.GetBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	if !collection.Active {
+		return nil, fmt.Errorf("collection with slug %s not found", slug)
+	}
+	return collection, nil
+}
+
+// ListCollections lists collections by name and returns the total count
+func (uc *CollectionUseCase) ListCollections(activeOnly bool, offset, limit int) ([]*entity.Collection, int, error) {
+	collections, err := uc.collectionRepo.List(activeOnly, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := uc.collectionRepo.Count(activeOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+	return collections, total, nil
+}
+
+// GetCollectionProductIDs returns the products placed in a manual collection in display order (admin only)
+func (uc *CollectionUseCase) GetCollectionProductIDs(id uint) ([]uint, error) {
+	if _, err := uc.collectionRepo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return uc.collectionRepo.ProductIDs(id)
+}
+
+// SetCollectionProducts replaces the products of a manual collection, in display order (admin only)
+func (uc *CollectionUseCase) SetCollectionProducts(id uint, productIDs []uint) ([]uint, error) {
+	collection, err := uc.collectionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if collection.IsSmart() {
+		return nil, errors.New("products can only be placed in manual collections")
+	}
+
+	products, err := uc.newCollectionProducts(collection.ID, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.collectionRepo.SetProducts(collection.ID, products); err != nil {
+		return nil, err
+	}
+	return uc.collectionRepo.ProductIDs(collection.ID)
+}
+
+// CollectionSort is the order of the products of a collection
+type CollectionSort string
+
+const (
+	CollectionSortManual    CollectionSort = "manual"     // Display order of a manual collection, newest first for smart collections
+	CollectionSortNewest    CollectionSort = "newest"     // Most recently created first
+	CollectionSortPriceAsc  CollectionSort = "price_asc"  // Lowest default variant price first
+	CollectionSortPriceDesc CollectionSort = "price_desc" // Highest default variant price first
+	CollectionSortName      CollectionSort = "name"       // Alphabetical
+	CollectionSortRating    CollectionSort = "rating"     // Highest rated first
+)
+
+// ListCollectionProductsInput contains the data needed to list the products of a collection
+type ListCollectionProductsInput struct {
+	CollectionID uint
+	Sort         CollectionSort // Manual order by default
+	CurrencyCode string         // Optional currency code for prices
+	Offset       int
+	Limit        int
+	UserID       uint // Signed-in customer whose group prices apply
+}
+
+// CollectionProducts is a page of the active products of a collection
+type CollectionProducts struct {
+	Collection *entity.Collection
+	Products   []*entity.Product
+	Total      int
+}
+
+// ListCollectionProducts lists the active products of an active collection, with the prices
+// of the customer's group when signed in
+func (uc *CollectionUseCase) ListCollectionProducts(input ListCollectionProductsInput) (*CollectionProducts, error) {
+	switch input.Sort {
+	case "", CollectionSortManual, CollectionSortNewest, CollectionSortPriceAsc, CollectionSortPriceDesc,
+		CollectionSortName, CollectionSortRating:
+	default:
+		return nil, fmt.Errorf("invalid collection sort %q", input.Sort)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(input.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	if !collection.Active {
+		return nil, fmt.Errorf("collection with ID %d not found", input.CollectionID)
+	}
+
+	categories, err := uc.categoryRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	members, err := uc.members(collection, nil, categories, now)
+	if err != nil {
+		return nil, err
+	}
+
+	sortCollectionProducts(members, input.Sort, collection.IsSmart(), now)
+
+	start := min(max(input.Offset, 0), len(members))
+	end := len(members)
+	if input.Limit > 0 {
+		end = min(start+input.Limit, len(members))
+	}
+	products := members[start:end]
+
+	// Price the page in the requested currency, keeping the order
+	if input.CurrencyCode != "" && len(products) > 0 {
+		ids := make([]uint, len(products))
+		for i, product := range products {
+			ids[i] = product.ID
+		}
+		priced, err := uc.productRepo.ListWithVariants(input.CurrencyCode, entity.SalesChannelWebshop, ids)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[uint]*entity.Product, len(priced))
+		for _, product := range priced {
+			byID[product.ID] = product
+		}
+		for i, product := range products {
+			if pricedProduct, ok := byID[product.ID]; ok {
+				products[i] = pricedProduct
+			}
+		}
+	}
+
+	categoriesByID := make(map[uint]*entity.Category, len(categories))
+	for _, category := range categories {
+		categoriesByID[category.ID] = category
+	}
+	priceList := resolveCustomerPriceList(uc.priceListRepo, input.UserID)
+	for _, product := range products {
+		if category, ok := categoriesByID[product.CategoryID]; ok {
+			product.Category = *category
+		}
+		if priceList != nil {
+			product.ApplyPriceList(priceList)
+		}
+	}
+
+	return &CollectionProducts{
+		Collection: collection,
+		Products:   products,
+		Total:      len(members),
+	}, nil
+}
+
+// ResolveDiscountCollections sets the products of the discount collections among the given products,
+// so the discount applies to them. Collections are matched whether active or not.
+func (uc *CollectionUseCase) ResolveDiscountCollections(discount *entity.Discount, productIDs []uint) error {
+	if discount == nil || len(discount.CollectionIDs) == 0 {
+		return nil
+	}
+	discount.CollectionProductIDs = nil
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	collections, err := uc.collectionRepo.GetByIDs(discount.CollectionIDs)
+	if err != nil {
+		return err
+	}
+	categories, err := uc.categoryRepo.List()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, collection := range collections {
+		members, err := uc.members(collection, productIDs, categories, now)
+		if err != nil {
+			return err
+		}
+		for _, product := range members {
+			if !slices.Contains(discount.CollectionProductIDs, product.ID) {
+				discount.CollectionProductIDs = append(discount.CollectionProductIDs, product.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckCollectionIDs returns an error when one of the collections does not exist
+func (uc *CollectionUseCase) CheckCollectionIDs(collectionIDs []uint) error {
+	collections, err := uc.collectionRepo.GetByIDs(collectionIDs)
+	if err != nil {
+		return err
+	}
+	for _, id := range collectionIDs {
+		if !slices.ContainsFunc(collections, func(c *entity.Collection) bool { return c.ID == id }) {
+			return fmt.Errorf("collection with ID %d not found", id)
+		}
+	}
+	return nil
+}
+
+// members returns the active products of a collection, in display order for manual collections.
+// When candidateIDs is not nil, only these products are considered.
+func (uc *CollectionUseCase) members(collection *entity.Collection, candidateIDs []uint, categories []*entity.Category, at time.Time) ([]*entity.Product, error) {
+	if collection.IsSmart() {
+		candidates, err := uc.productRepo.ListWithVariants("", entity.SalesChannelWebshop, candidateIDs)
+		if err != nil {
+			return nil, err
+		}
+		return collection.MatchRules(candidates, categories, at), nil
+	}
+
+	ids, err := uc.collectionRepo.ProductIDs(collection.ID)
+	if err != nil {
+		return nil, err
+	}
+	if candidateIDs != nil {
+		ids = slices.DeleteFunc(ids, func(id uint) bool { return !slices.Contains(candidateIDs, id) })
+	}
+	if len(ids) == 0 {
+		return []*entity.Product{}, nil
+	}
+
+	products, err := uc.productRepo.ListWithVariants("", entity.SalesChannelWebshop, ids)
+	if err != nil {
+		return nil, err
+	}
+	position := make(map[uint]int, len(ids))
+	for i, id := range ids {
+		position[id] = i
+	}
+	sort.SliceStable(products, func(i, j int) bool { return position[products[i].ID] < position[products[j].ID] })
+	return products, nil
+}
+
+// sortCollectionProducts orders the products of a collection, keeping the manual order on ties
+func sortCollectionProducts(products []*entity.Product, order CollectionSort, smart bool, at time.Time) {
+	if order == "" || order == CollectionSortManual {
+		if !smart {
+			return
+		}
+		order = CollectionSortNewest
+	}
+
+	price := func(product *entity.Product) int64 {
+		if variant := product.GetDefaultVariant(); variant != nil {
+			return variant.EffectivePrice(at)
+		}
+		return 0
+	}
+
+	sort.SliceStable(products, func(i, j int) bool {
+		a, b := products[i], products[j]
+		switch order {
+		case CollectionSortNewest:
+			return a.CreatedAt.After(b.CreatedAt)
+		case CollectionSortPriceAsc:
+			return price(a) < price(b)
+		case CollectionSortPriceDesc:
+			return price(a) > price(b)
+		case CollectionSortName:
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		case CollectionSortRating:
+			if a.RatingAverage != b.RatingAverage {
+				return a.RatingAverage > b.RatingAverage
+			}
+			return a.ReviewCount > b.ReviewCount
+		}
+		return false
+	})
+}
+
+// checkRules returns an error when a category of the rules does not exist
+func (uc *CollectionUseCase) checkRules(collection *entity.Collection) error {
+	for _, categoryID := range collection.Rules.Data().CategoryIDs {
+		if _, err := uc.categoryRepo.GetByID(categoryID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newCollectionProducts places existing products in a manual collection
+func (uc *CollectionUseCase) newCollectionProducts(collectionID uint, productIDs []uint) ([]*entity.CollectionProduct, error) {
+	products, err := entity.NewCollectionProducts(collectionID, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(productIDs) == 0 {
+		return products, nil
+	}
+
+	// Inactive products can be placed, they are only listed once activated
+	existing, err := uc.productRepo.ListWithVariants("", "", productIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range productIDs {
+		if !slices.ContainsFunc(existing, func(p *entity.Product) bool { return p.ID == id }) {
+			return nil, fmt.Errorf("product with ID %d not found", id)
+		}
+	}
+	return products, nil
+}
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestCollectionUseCase(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(currency).Error)
+	clothing := testutil.CreateTestCategory(t, db, 1)
+	shirts := testutil.CreateTestCategory(t, db, 2)
+	require.NoError(t, db.Model(shirts).Update("parent_id", clothing.ID).Error)
+	kitchen := testutil.CreateTestCategory(t, db, 3)
+
+	productRepo := gorm.NewProductRepository(db)
+	createProduct := func(name, sku string, categoryID uint, price int64, createdAt time.Time) *entity.Product {
+		variant, err := entity.NewProductVariant(sku, 10, price, 0, entity.VariantAttributes{"size": "M"}, nil, true)
+		require.NoError(t, err)
+		product, err := entity.NewProduct(name, "", "USD", categoryID, nil, []*entity.ProductVariant{variant}, true)
+		require.NoError(t, err)
+		require.NoError(t, productRepo.Create(product))
+		require.NoError(t, db.Model(product).Update("created_at", createdAt).Error)
+		product.CreatedAt = createdAt
+		return product
+	}
+	now := time.Now()
+	shirt := createProduct("Linen Shirt", "SHIRT", shirts.ID, 15000, now.AddDate(0, 0, -2))
+	jacket := createProduct("Jacket", "JACKET", clothing.ID, 45000, now.AddDate(0, 0, -1))
+	kettle := createProduct("Kettle", "KETTLE", kitchen.ID, 9000, now.AddDate(0, 0, -40))
+	hat := createProduct("Hat", "HAT", clothing.ID, 5000, now.AddDate(0, 0, -5))
+	require.NoError(t, db.Model(hat).Update("active", false).Error)
+
+	uc := NewCollectionUseCase(gorm.NewCollectionRepository(db), productRepo, gorm.NewCategoryRepository(db), gorm.NewPriceListRepository(db))
+
+	productIDs := func(products []*entity.Product) []uint {
+		ids := make([]uint, len(products))
+		for i, product := range products {
+			ids[i] = product.ID
+		}
+		return ids
+	}
+
+	var summerPicks, underTwoHundred *entity.Collection
+
+	t.Run("Manual collections list their products in display order", func(t *testing.T) {
+		_, err := uc.CreateCollection(CreateCollectionInput{
+			Name: "Summer picks", Type: entity.CollectionTypeManual, ProductIDs: []uint{shirt.ID, 999},
+		})
+		assert.ErrorContains(t, err, "product with ID 999 not found")
+
+		summerPicks, err = uc.CreateCollection(CreateCollectionInput{
+			Name:       "Summer picks",
+			Type:       entity.CollectionTypeManual,
+			ProductIDs: []uint{kettle.ID, hat.ID, shirt.ID},
+			Active:     true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "summer-picks", summerPicks.Slug)
+
+		// Inactive products are left out
+		result, err := uc.ListCollectionProducts(ListCollectionProductsInput{CollectionID: summerPicks.ID, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{kettle.ID, shirt.ID}, productIDs(result.Products))
+		assert.Equal(t, 2, result.Total)
+		assert.Equal(t, shirts.Name, result.Products[1].Category.Name)
+
+		ids, err := uc.SetCollectionProducts(summerPicks.ID, []uint{shirt.ID, jacket.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{shirt.ID, jacket.ID}, ids)
+
+		result, err = uc.ListCollectionProducts(ListCollectionProductsInput{CollectionID: summerPicks.ID, Sort: CollectionSortPriceDesc, Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{jacket.ID}, productIDs(result.Products))
+		assert.Equal(t, 2, result.Total)
+	})
+
+	t.Run("Smart collections list the products matching their rules", func(t *testing.T) {
+		_, err := uc.CreateCollection(CreateCollectionInput{Name: "Everything", Type: entity.CollectionTypeSmart})
+		assert.ErrorContains(t, err, "need at least one rule")
+		_, err = uc.CreateCollection(CreateCollectionInput{
+			Name: "Unknown", Type: entity.CollectionTypeSmart, Rules: &CollectionRulesInput{CategoryIDs: []uint{999}},
+		})
+		assert.ErrorContains(t, err, "not found")
+
+		underTwoHundred, err = uc.CreateCollection(CreateCollectionInput{
+			Name:   "Under 200",
+			Type:   entity.CollectionTypeSmart,
+			Rules:  &CollectionRulesInput{MaxPrice: 200},
+			Active: true,
+		})
+		require.NoError(t, err)
+
+		// Newest first by default
+		result, err := uc.ListCollectionProducts(ListCollectionProductsInput{CollectionID: underTwoHundred.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{shirt.ID, kettle.ID}, productIDs(result.Products))
+
+		result, err = uc.ListCollectionProducts(ListCollectionProductsInput{CollectionID: underTwoHundred.ID, Sort: CollectionSortPriceAsc})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{kettle.ID, shirt.ID}, productIDs(result.Products))
+
+		// Categories include their descendants
+		newClothing, err := uc.CreateCollection(CreateCollectionInput{
+			Name:   "New clothing",
+			Type:   entity.CollectionTypeSmart,
+			Rules:  &CollectionRulesInput{CategoryIDs: []uint{clothing.ID}, CreatedWithinDays: 30},
+			Active: true,
+		})
+		require.NoError(t, err)
+		result, err = uc.ListCollectionProducts(ListCollectionProductsInput{CollectionID: newClothing.ID, Sort: CollectionSortName})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{jacket.ID, shirt.ID}, productIDs(result.Products))
+
+		_, err = uc.SetCollectionProducts(newClothing.ID, []uint{kettle.ID})
+		assert.ErrorContains(t, err, "only be placed in manual collections")
+		_, err = uc.ListCollectionProducts(ListCollectionProductsInput{CollectionID: newClothing.ID, Sort: "popular"})
+		assert.ErrorContains(t, err, "invalid collection sort")
+	})
+
+	t.Run("Inactive collections are not public", func(t *testing.T) {
+		active := false
+		_, err := uc.UpdateCollection(summerPicks.ID, UpdateCollectionInput{Active: &active})
+		require.NoError(t, err)
+
+		_, err = uc.ListCollectionProducts(ListCollectionProductsInput{CollectionID: summerPicks.ID})
+		assert.ErrorContains(t, err, "not found")
+		_, err = uc.GetActiveCollectionBySlug("summer-picks")
+		assert.ErrorContains(t, err, "not found")
+
+		collections, total, err := uc.ListCollections(true, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.NotContains(t, collections, summerPicks)
+	})
+
+	t.Run("Renamed collections keep their former slug", func(t *testing.T) {
+		slug := "cheap-finds"
+		_, err := uc.UpdateCollection(underTwoHundred.ID, UpdateCollectionInput{Slug: &slug})
+		require.NoError(t, err)
+
+		collection, err := uc.GetActiveCollectionBySlug("under-200")
+		require.NoError(t, err)
+		assert.Equal(t, "cheap-finds", collection.Slug)
+	})
+
+	t.Run("Collections are discount targets", func(t *testing.T) {
+		discountRepo := gorm.NewDiscountRepository(db)
+		discountUseCase := NewDiscountUseCase(discountRepo, gorm.NewDiscountCodeRepository(db), productRepo,
+			gorm.NewCategoryRepository(db), gorm.NewOrderRepository(db), gorm.NewCurrencyRepository(db), uc)
+
+		_, err := discountUseCase.CreateDiscount(CreateDiscountInput{
+			Code: "UNKNOWN", Type: "product", Method: "percentage", Value: 10, CollectionIDs: []uint{999},
+			StartDate: now.Add(-time.Hour), EndDate: now.Add(time.Hour),
+		})
+		assert.ErrorContains(t, err, "invalid collection ID")
+
+		discount, err := discountUseCase.CreateDiscount(CreateDiscountInput{
+			Code: "CHEAP10", Type: "product", Method: "percentage", Value: 10, CollectionIDs: []uint{underTwoHundred.ID},
+			StartDate: now.Add(-time.Hour), EndDate: now.Add(time.Hour),
+		})
+		require.NoError(t, err)
+		stored, err := discountRepo.GetByID(discount.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{underTwoHundred.ID}, stored.CollectionIDs)
+
+		checkoutUseCase := NewCheckoutUseCase(
+			gorm.NewCheckoutRepository(db),
+			productRepo,
+			gorm.NewProductVariantRepository(db),
+			gorm.NewShippingMethodRepository(db),
+			gorm.NewShippingRateRepository(db),
+			discountRepo,
+			gorm.NewDiscountCodeRepository(db),
+			gorm.NewOrderRepository(db),
+			gorm.NewCurrencyRepository(db),
+			gorm.NewTransactionRepository(db),
+			gorm.NewPriceListRepository(db),
+			nil,
+			nil,
+			uc,
+			nil,
+		)
+		checkout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID("session-1")
+		require.NoError(t, err)
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "SHIRT", Quantity: 1})
+		require.NoError(t, err)
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "JACKET", Quantity: 1})
+		require.NoError(t, err)
+
+		// Only the shirt is in the collection
+		checkout, err = checkoutUseCase.ApplyDiscountCode(checkout, "CHEAP10")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1500), checkout.DiscountAmount)
+	})
+}
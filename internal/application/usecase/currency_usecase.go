@@ -2,32 +2,54 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
 )
 
 // CurrencyUseCase implements currency-related use cases
 type CurrencyUseCase struct {
-	currencyRepo repository.CurrencyRepository
+	currencyRepo    repository.CurrencyRepository
+	rateHistoryRepo repository.ExchangeRateHistoryRepository
+	rateProvider    service.ExchangeRateProvider
 }
 
-// NewCurrencyUseCase creates a new CurrencyUseCase
-func NewCurrencyUseCase(currencyRepo repository.CurrencyRepository) *CurrencyUseCase {
+// NewCurrencyUseCase creates a new CurrencyUseCase.
+// The exchange rate provider is optional; without it rates can only be set by hand.
+func NewCurrencyUseCase(
+	currencyRepo repository.CurrencyRepository,
+	rateHistoryRepo repository.ExchangeRateHistoryRepository,
+	rateProvider service.ExchangeRateProvider,
+) *CurrencyUseCase {
 	return &CurrencyUseCase{
-		currencyRepo: currencyRepo,
+		currencyRepo:    currencyRepo,
+		rateHistoryRepo: rateHistoryRepo,
+		rateProvider:    rateProvider,
 	}
 }
 
+// ExchangeRateRefreshResult contains the outcome of an exchange rate refresh
+type ExchangeRateRefreshResult struct {
+	Source       string    `json:"source"`
+	BaseCurrency string    `json:"base_currency"`
+	FetchedAt    time.Time `json:"fetched_at"`
+	Updated      []string  `json:"updated"`           // Currencies whose rate changed
+	Missing      []string  `json:"missing,omitempty"` // Currencies the provider has no rate for
+}
+
 // CurrencyInput represents input data for creating or updating a currency
 type CurrencyInput struct {
-	Code         string  `json:"code"`
-	Name         string  `json:"name"`
-	Symbol       string  `json:"symbol"`
-	ExchangeRate float64 `json:"exchange_rate"`
-	IsEnabled    bool    `json:"is_enabled"`
-	IsDefault    bool    `json:"is_default"`
+	Code             string  `json:"code"`
+	Name             string  `json:"name"`
+	Symbol           string  `json:"symbol"`
+	ExchangeRate     float64 `json:"exchange_rate"`
+	IsEnabled        bool    `json:"is_enabled"`
+	IsDefault        bool    `json:"is_default"`
+	RoundingStrategy string  `json:"rounding_strategy"`
 }
 
 // CreateCurrency creates a new currency
@@ -51,12 +73,20 @@ func (uc *CurrencyUseCase) CreateCurrency(input CurrencyInput) (*entity.Currency
 		return nil, err
 	}
 
+	if err := currency.SetRoundingStrategy(input.RoundingStrategy); err != nil {
+		return nil, err
+	}
+
 	// Persist the currency
 	err = uc.currencyRepo.Create(currency)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := uc.recordRateChange(currency, 0, entity.ExchangeRateSourceManual); err != nil {
+		return nil, err
+	}
+
 	return currency, nil
 }
 
@@ -80,12 +110,19 @@ func (uc *CurrencyUseCase) UpdateCurrency(code string, input CurrencyInput) (*en
 		currency.Symbol = input.Symbol
 	}
 
+	previousRate := currency.ExchangeRate
 	if input.ExchangeRate > 0 {
 		if err := currency.SetExchangeRate(input.ExchangeRate); err != nil {
 			return nil, err
 		}
 	}
 
+	if input.RoundingStrategy != "" {
+		if err := currency.SetRoundingStrategy(input.RoundingStrategy); err != nil {
+			return nil, err
+		}
+	}
+
 	// Handle enabled/disabled state
 	if currency.IsEnabled != input.IsEnabled {
 		if input.IsEnabled {
@@ -112,6 +149,12 @@ func (uc *CurrencyUseCase) UpdateCurrency(code string, input CurrencyInput) (*en
 		return nil, err
 	}
 
+	if currency.ExchangeRate != previousRate {
+		if err := uc.recordRateChange(currency, previousRate, entity.ExchangeRateSourceManual); err != nil {
+			return nil, err
+		}
+	}
+
 	return currency, nil
 }
 
@@ -161,3 +204,129 @@ func (uc *CurrencyUseCase) ConvertPrice(amount int64, fromCurrencyCode, toCurren
 	// Convert the amount
 	return fromCurrency.ConvertAmount(amount, toCurrency), nil
 }
+
+// HasExchangeRateProvider reports whether rates can be refreshed automatically
+func (uc *CurrencyUseCase) HasExchangeRateProvider() bool {
+	return uc.rateProvider != nil
+}
+
+// RefreshExchangeRates fetches the latest rates from the provider and updates every currency
+// whose rate changed. Rates are requested relative to the default currency.
+func (uc *CurrencyUseCase) RefreshExchangeRates() (*ExchangeRateRefreshResult, error) {
+	if uc.rateProvider == nil {
+		return nil, errors.New("no exchange rate provider configured")
+	}
+
+	defaultCurrency, err := uc.currencyRepo.GetDefault()
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := uc.rateProvider.FetchRates(defaultCurrency.Code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange rates from %s: %w", uc.rateProvider.Name(), err)
+	}
+
+	currencies, err := uc.currencyRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExchangeRateRefreshResult{
+		Source:       uc.rateProvider.Name(),
+		BaseCurrency: defaultCurrency.Code,
+		FetchedAt:    rates.FetchedAt,
+		Updated:      []string{},
+	}
+
+	for _, currency := range currencies {
+		// The default currency is the base of all rates
+		if currency.IsDefault {
+			continue
+		}
+
+		rate, ok := rates.Rates[currency.Code]
+		if !ok {
+			result.Missing = append(result.Missing, currency.Code)
+			continue
+		}
+		if rate == currency.ExchangeRate {
+			continue
+		}
+
+		previousRate := currency.ExchangeRate
+		if err := currency.SetExchangeRate(rate); err != nil {
+			return nil, fmt.Errorf("invalid rate for %s: %w", currency.Code, err)
+		}
+
+		if err := uc.currencyRepo.Update(currency); err != nil {
+			return nil, err
+		}
+
+		if err := uc.recordRateChange(currency, previousRate, result.Source); err != nil {
+			return nil, err
+		}
+
+		result.Updated = append(result.Updated, currency.Code)
+	}
+
+	return result, nil
+}
+
+// GetExchangeRateHistory lists the recorded rate changes of a currency, newest first.
+// An empty code returns the history of all currencies.
+func (uc *CurrencyUseCase) GetExchangeRateHistory(code string, offset, limit int) ([]*entity.ExchangeRateHistory, error) {
+	return uc.rateHistoryRepo.ListByCurrency(strings.ToUpper(code), offset, limit)
+}
+
+// recordRateChange stores a rate change in the exchange rate history
+func (uc *CurrencyUseCase) recordRateChange(currency *entity.Currency, previousRate float64, source string) error {
+	var baseCurrency string
+	if defaultCurrency, err := uc.currencyRepo.GetDefault(); err == nil {
+		baseCurrency = defaultCurrency.Code
+	}
+
+	history, err := entity.NewExchangeRateHistory(currency.Code, baseCurrency, previousRate, currency.ExchangeRate, source)
+	if err != nil {
+		return err
+	}
+
+	return uc.rateHistoryRepo.Create(history)
+}
+
+// resolveBaseCurrency looks up the default currency, in which prices and amounts without an explicit
+// value for a currency are configured. It is nil when no default currency is configured.
+func resolveBaseCurrency(currencyRepo repository.CurrencyRepository) (*entity.Currency, error) {
+	baseCurrency, err := currencyRepo.GetDefault()
+	if errors.Is(err, repository.ErrCurrencyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default currency: %w", err)
+	}
+	return baseCurrency, nil
+}
+
+// currencyCode returns the code of the currency, or an empty string when there is none
+func currencyCode(currency *entity.Currency) string {
+	if currency == nil {
+		return ""
+	}
+	return currency.Code
+}
+
+// resolveCurrencyConversion looks up the default currency and the given currency to convert amounts into.
+// Both are nil when no default currency is configured, so single-currency stores are not converted.
+func resolveCurrencyConversion(currencyRepo repository.CurrencyRepository, code string) (*entity.Currency, *entity.Currency, error) {
+	baseCurrency, err := resolveBaseCurrency(currencyRepo)
+	if err != nil || baseCurrency == nil {
+		return nil, nil, err
+	}
+
+	currency, err := currencyRepo.GetByCode(code)
+	if err != nil {
+		return nil, nil, fmt.Errorf("currency %s not found: %w", code, err)
+	}
+
+	return baseCurrency, currency, nil
+}
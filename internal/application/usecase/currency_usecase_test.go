@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+	"github.com/zenfulcode/commercify/internal/infrastructure/exchangerate"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestCurrencyUseCase_ExchangeRates(t *testing.T) {
+	setup := func(t *testing.T, provider service.ExchangeRateProvider) *CurrencyUseCase {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+
+		currencyUseCase := NewCurrencyUseCase(gorm.NewCurrencyRepository(db), gorm.NewExchangeRateHistoryRepository(db), provider)
+
+		for _, input := range []CurrencyInput{
+			{Code: "USD", Name: "US Dollar", Symbol: "$", ExchangeRate: 1, IsEnabled: true, IsDefault: true},
+			{Code: "EUR", Name: "Euro", Symbol: "€", ExchangeRate: 0.9, IsEnabled: true},
+			{Code: "DKK", Name: "Danish Krone", Symbol: "kr", ExchangeRate: 6.5, IsEnabled: true},
+		} {
+			_, err := currencyUseCase.CreateCurrency(input)
+			require.NoError(t, err)
+		}
+
+		return currencyUseCase
+	}
+
+	t.Run("Refresh updates changed rates and records history", func(t *testing.T) {
+		provider := exchangerate.NewFakeProvider("USD", map[string]float64{"EUR": 0.92, "DKK": 6.5})
+		currencyUseCase := setup(t, provider)
+
+		result, err := currencyUseCase.RefreshExchangeRates()
+		require.NoError(t, err)
+		assert.Equal(t, "fake", result.Source)
+		assert.Equal(t, "USD", result.BaseCurrency)
+		assert.Equal(t, []string{"EUR"}, result.Updated)
+		assert.Empty(t, result.Missing)
+
+		eur, err := currencyUseCase.GetCurrency("EUR")
+		require.NoError(t, err)
+		assert.Equal(t, 0.92, eur.ExchangeRate)
+
+		history, err := currencyUseCase.GetExchangeRateHistory("eur", 0, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, 0.9, history[0].PreviousRate)
+		assert.Equal(t, 0.92, history[0].Rate)
+		assert.Equal(t, "fake", history[0].Source)
+		assert.Equal(t, "USD", history[0].BaseCurrency)
+		assert.Equal(t, entity.ExchangeRateSourceManual, history[1].Source)
+	})
+
+	t.Run("Refresh rebases rates onto the default currency", func(t *testing.T) {
+		provider := exchangerate.NewFakeProvider("EUR", map[string]float64{"USD": 1.25, "DKK": 7.5})
+		currencyUseCase := setup(t, provider)
+
+		result, err := currencyUseCase.RefreshExchangeRates()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"EUR", "DKK"}, result.Updated)
+
+		eur, err := currencyUseCase.GetCurrency("EUR")
+		require.NoError(t, err)
+		assert.Equal(t, 0.8, eur.ExchangeRate)
+
+		dkk, err := currencyUseCase.GetCurrency("DKK")
+		require.NoError(t, err)
+		assert.Equal(t, 6.0, dkk.ExchangeRate)
+	})
+
+	t.Run("Refresh reports currencies without a rate", func(t *testing.T) {
+		provider := exchangerate.NewFakeProvider("USD", map[string]float64{"EUR": 0.9})
+		currencyUseCase := setup(t, provider)
+
+		result, err := currencyUseCase.RefreshExchangeRates()
+		require.NoError(t, err)
+		assert.Empty(t, result.Updated)
+		assert.Equal(t, []string{"DKK"}, result.Missing)
+	})
+
+	t.Run("Refresh fails when the provider fails", func(t *testing.T) {
+		provider := exchangerate.NewFakeProvider("USD", nil)
+		provider.SetError(errors.New("feed unavailable"))
+		currencyUseCase := setup(t, provider)
+
+		_, err := currencyUseCase.RefreshExchangeRates()
+		assert.ErrorContains(t, err, "feed unavailable")
+
+		dkk, err := currencyUseCase.GetCurrency("DKK")
+		require.NoError(t, err)
+		assert.Equal(t, 6.5, dkk.ExchangeRate)
+	})
+
+	t.Run("Refresh without provider", func(t *testing.T) {
+		currencyUseCase := setup(t, nil)
+
+		assert.False(t, currencyUseCase.HasExchangeRateProvider())
+		_, err := currencyUseCase.RefreshExchangeRates()
+		assert.Error(t, err)
+	})
+
+	t.Run("Manual rate change is recorded", func(t *testing.T) {
+		currencyUseCase := setup(t, nil)
+
+		_, err := currencyUseCase.UpdateCurrency("DKK", CurrencyInput{ExchangeRate: 6.8, IsEnabled: true})
+		require.NoError(t, err)
+
+		// Updating other fields does not add history
+		_, err = currencyUseCase.UpdateCurrency("DKK", CurrencyInput{Name: "Krone", IsEnabled: true})
+		require.NoError(t, err)
+
+		history, err := currencyUseCase.GetExchangeRateHistory("DKK", 0, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, 6.5, history[0].PreviousRate)
+		assert.Equal(t, 6.8, history[0].Rate)
+		assert.Equal(t, entity.ExchangeRateSourceManual, history[0].Source)
+	})
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// CustomFieldUseCase implements the use cases of custom fields. Admins define the fields of products,
+// variants, orders and users; values are validated against the definitions and stored with the resource.
+type CustomFieldUseCase struct {
+	customFieldRepo    repository.CustomFieldRepository
+	productVariantRepo repository.ProductVariantRepository
+}
+
+// NewCustomFieldUseCase creates a new CustomFieldUseCase
+func NewCustomFieldUseCase(
+	customFieldRepo repository.CustomFieldRepository,
+	productVariantRepo repository.ProductVariantRepository,
+) *CustomFieldUseCase {
+	return &CustomFieldUseCase{
+		customFieldRepo:    customFieldRepo,
+		productVariantRepo: productVariantRepo,
+	}
+}
+
+// CreateCustomFieldInput contains the data needed to define a custom field
+type CreateCustomFieldInput struct {
+	Resource string
+	Key      string
+	Type     entity.CustomFieldType
+	UpdateCustomFieldInput
+}
+
+// UpdateCustomFieldInput contains the label, visibility and validation rules of a custom field
+type UpdateCustomFieldInput struct {
+	Label    string
+	Required bool
+	Public   bool
+	Options  []string
+	Pattern  string
+	Min      *float64
+	Max      *float64
+}
+
+// CreateDefinition defines a new custom field
+func (uc *CustomFieldUseCase) CreateDefinition(input CreateCustomFieldInput) (*entity.CustomFieldDefinition, error) {
+	definition, err := entity.NewCustomFieldDefinition(input.Resource, input.Key, input.Type)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.applyRules(definition, input.UpdateCustomFieldInput); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.customFieldRepo.ListByResource(definition.Resource)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.Key == definition.Key {
+			return nil, fmt.Errorf("custom field %s already exists for %s", definition.Key, definition.Resource)
+		}
+	}
+
+	if err := uc.customFieldRepo.Create(definition); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+// UpdateDefinition changes the label, visibility and validation rules of a custom field.
+// Stored values are validated again the next time they are set.
+func (uc *CustomFieldUseCase) UpdateDefinition(definitionID uint, input UpdateCustomFieldInput) (*entity.CustomFieldDefinition, error) {
+	definition, err := uc.customFieldRepo.GetByID(definitionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.applyRules(definition, input); err != nil {
+		return nil, err
+	}
+	if err := uc.customFieldRepo.Update(definition); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+// DeleteDefinition deletes a custom field together with its values
+func (uc *CustomFieldUseCase) DeleteDefinition(definitionID uint) error {
+	return uc.customFieldRepo.Delete(definitionID)
+}
+
+// ListDefinitions lists the custom fields of a resource, or of all resources when empty
+func (uc *CustomFieldUseCase) ListDefinitions(resource string) ([]*entity.CustomFieldDefinition, error) {
+	if resource != "" && !entity.IsValidCustomFieldResource(resource) {
+		return nil, fmt.Errorf("invalid custom field resource %q", resource)
+	}
+	return uc.customFieldRepo.ListByResource(resource)
+}
+
+// GetValues returns the custom field values of a product, order or user
+func (uc *CustomFieldUseCase) GetValues(resource string, resourceID uint) (entity.CustomFields, error) {
+	definitions, err := uc.ListDefinitions(resource)
+	if err != nil {
+		return nil, err
+	}
+	values, err := uc.customFieldRepo.GetValues(resource, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	keepDefinedFields(values, definitionsByKey(definitions), true)
+	return values, nil
+}
+
+// SetValues merges values into the custom fields of a product, order or user and returns the result.
+// A null value removes a field.
+func (uc *CustomFieldUseCase) SetValues(resource string, resourceID uint, values map[string]any) (entity.CustomFields, error) {
+	definitions, err := uc.ListDefinitions(resource)
+	if err != nil {
+		return nil, err
+	}
+	current, err := uc.customFieldRepo.GetValues(resource, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := entity.ApplyCustomFields(definitions, current, values)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.customFieldRepo.SetValues(resource, resourceID, merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// GetVariantValues returns the custom field values of a variant of a product
+func (uc *CustomFieldUseCase) GetVariantValues(productID, variantID uint) (entity.CustomFields, error) {
+	if err := uc.checkVariant(productID, variantID); err != nil {
+		return nil, err
+	}
+	return uc.GetValues(entity.CustomFieldResourceProductVariant, variantID)
+}
+
+// SetVariantValues merges values into the custom fields of a variant of a product
+func (uc *CustomFieldUseCase) SetVariantValues(productID, variantID uint, values map[string]any) (entity.CustomFields, error) {
+	if err := uc.checkVariant(productID, variantID); err != nil {
+		return nil, err
+	}
+	return uc.SetValues(entity.CustomFieldResourceProductVariant, variantID, values)
+}
+
+// ParseFilters converts query parameter values by custom field key to filters on the fields of a resource
+func (uc *CustomFieldUseCase) ParseFilters(resource string, params map[string]string) ([]entity.CustomFieldFilter, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+
+	definitions, err := uc.ListDefinitions(resource)
+	if err != nil {
+		return nil, err
+	}
+	byKey := definitionsByKey(definitions)
+
+	filters := make([]entity.CustomFieldFilter, 0, len(params))
+	for key, raw := range params {
+		definition, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown custom field %s", key)
+		}
+		filter, err := definition.ParseFilter(raw)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+// FilterProductFields removes the values of undefined custom fields from the products and their variants,
+// and the values of private fields unless includePrivate is set
+func (uc *CustomFieldUseCase) FilterProductFields(includePrivate bool, products ...*entity.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	definitions, err := uc.definitionsByResource()
+	if err != nil {
+		return err
+	}
+	for _, product := range products {
+		keepDefinedFields(product.CustomFields, definitions[entity.CustomFieldResourceProduct], includePrivate)
+		for _, variant := range product.Variants {
+			keepDefinedFields(variant.CustomFields, definitions[entity.CustomFieldResourceProductVariant], includePrivate)
+		}
+	}
+	return nil
+}
+
+// FilterOrderFields removes the values of undefined custom fields from the orders,
+// and the values of private fields unless includePrivate is set
+func (uc *CustomFieldUseCase) FilterOrderFields(includePrivate bool, orders ...*entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	definitions, err := uc.definitionsByResource()
+	if err != nil {
+		return err
+	}
+	for _, order := range orders {
+		keepDefinedFields(order.CustomFields, definitions[entity.CustomFieldResourceOrder], includePrivate)
+	}
+	return nil
+}
+
+// FilterUserFields removes the values of undefined custom fields from the users,
+// and the values of private fields unless includePrivate is set
+func (uc *CustomFieldUseCase) FilterUserFields(includePrivate bool, users ...*entity.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	definitions, err := uc.definitionsByResource()
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		keepDefinedFields(user.CustomFields, definitions[entity.CustomFieldResourceUser], includePrivate)
+	}
+	return nil
+}
+
+// applyRules sets the label, visibility and validation rules of a definition
+func (uc *CustomFieldUseCase) applyRules(definition *entity.CustomFieldDefinition, input UpdateCustomFieldInput) error {
+	return definition.Update(input.Label, input.Required, input.Public, input.Options, input.Pattern, input.Min, input.Max)
+}
+
+// checkVariant checks that the variant belongs to the product
+func (uc *CustomFieldUseCase) checkVariant(productID, variantID uint) error {
+	variant, err := uc.productVariantRepo.GetByID(variantID)
+	if err != nil {
+		return err
+	}
+	if variant.ProductID != productID {
+		return fmt.Errorf("variant with ID %d not found for product %d", variantID, productID)
+	}
+	return nil
+}
+
+// definitionsByResource returns the definitions of all resources by resource and key
+func (uc *CustomFieldUseCase) definitionsByResource() (map[string]map[string]*entity.CustomFieldDefinition, error) {
+	definitions, err := uc.customFieldRepo.ListByResource("")
+	if err != nil {
+		return nil, err
+	}
+
+	byResource := make(map[string]map[string]*entity.CustomFieldDefinition)
+	for _, definition := range definitions {
+		if byResource[definition.Resource] == nil {
+			byResource[definition.Resource] = make(map[string]*entity.CustomFieldDefinition)
+		}
+		byResource[definition.Resource][definition.Key] = definition
+	}
+	return byResource, nil
+}
+
+// definitionsByKey indexes the definitions of a resource by key
+func definitionsByKey(definitions []*entity.CustomFieldDefinition) map[string]*entity.CustomFieldDefinition {
+	byKey := make(map[string]*entity.CustomFieldDefinition, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+	return byKey
+}
+
+// keepDefinedFields removes the values without a definition, and the values of private fields unless includePrivate is set
+func keepDefinedFields(values entity.CustomFields, definitions map[string]*entity.CustomFieldDefinition, includePrivate bool) {
+	for key := range values {
+		definition, ok := definitions[key]
+		if !ok || (!includePrivate && !definition.Public) {
+			delete(values, key)
+		}
+	}
+}
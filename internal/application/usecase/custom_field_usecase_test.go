@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestCustomFieldUseCase(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	shirt := testutil.CreateTestProduct(t, db, 1)
+	pants := testutil.CreateTestProduct(t, db, 2)
+	variant, err := entity.NewProductVariant("SHIRT-M", 10, 15000, 1, nil, nil, true)
+	require.NoError(t, err)
+	variant.ProductID = shirt.ID
+	require.NoError(t, db.Create(variant).Error)
+	order := testutil.CreateTestOrder(t, db, 1)
+	testutil.CreateTestOrder(t, db, 2)
+	user := testutil.CreateTestUser(t, db, 1)
+	testutil.CreateTestUser(t, db, 2)
+
+	productRepo := gorm.NewProductRepository(db)
+	orderRepo := gorm.NewOrderRepository(db)
+	userRepo := gorm.NewUserRepository(db)
+	uc := NewCustomFieldUseCase(gorm.NewCustomFieldRepository(db), gorm.NewProductVariantRepository(db))
+
+	createField := func(resource, key string, fieldType entity.CustomFieldType, rules UpdateCustomFieldInput) *entity.CustomFieldDefinition {
+		definition, err := uc.CreateDefinition(CreateCustomFieldInput{Resource: resource, Key: key, Type: fieldType, UpdateCustomFieldInput: rules})
+		require.NoError(t, err)
+		return definition
+	}
+	erpID := createField(entity.CustomFieldResourceProduct, "erp_id", entity.CustomFieldTypeText, UpdateCustomFieldInput{Label: "ERP ID"})
+	createField(entity.CustomFieldResourceProduct, "care", entity.CustomFieldTypeText, UpdateCustomFieldInput{Label: "Care instructions", Public: true})
+	createField(entity.CustomFieldResourceProductVariant, "bin", entity.CustomFieldTypeNumber, UpdateCustomFieldInput{Label: "Warehouse bin"})
+	createField(entity.CustomFieldResourceOrder, "gift", entity.CustomFieldTypeBoolean, UpdateCustomFieldInput{Label: "Gift", Public: true})
+	createField(entity.CustomFieldResourceUser, "segment", entity.CustomFieldTypeSelect, UpdateCustomFieldInput{Label: "Segment", Options: []string{"retail", "wholesale"}})
+
+	t.Run("CreateDefinition rejects duplicate keys per resource", func(t *testing.T) {
+		_, err := uc.CreateDefinition(CreateCustomFieldInput{
+			Resource: entity.CustomFieldResourceProduct, Key: "erp_id", Type: entity.CustomFieldTypeText,
+			UpdateCustomFieldInput: UpdateCustomFieldInput{Label: "ERP"},
+		})
+		assert.ErrorContains(t, err, "custom field erp_id already exists for product")
+
+		// The same key can be defined on another resource
+		createField(entity.CustomFieldResourceOrder, "erp_id", entity.CustomFieldTypeText, UpdateCustomFieldInput{Label: "ERP ID"})
+
+		definitions, err := uc.ListDefinitions(entity.CustomFieldResourceProduct)
+		require.NoError(t, err)
+		require.Len(t, definitions, 2)
+		assert.Equal(t, "care", definitions[0].Key)
+		assert.Equal(t, "erp_id", definitions[1].Key)
+	})
+
+	t.Run("SetValues validates and merges the values", func(t *testing.T) {
+		_, err := uc.SetValues(entity.CustomFieldResourceProduct, shirt.ID, map[string]any{"color": "blue"})
+		assert.ErrorContains(t, err, "unknown custom field color")
+		_, err = uc.SetValues(entity.CustomFieldResourceProduct, 999, map[string]any{"erp_id": "A-1"})
+		assert.ErrorContains(t, err, "product with ID 999 not found")
+
+		_, err = uc.SetValues(entity.CustomFieldResourceProduct, shirt.ID, map[string]any{"erp_id": "A-100"})
+		require.NoError(t, err)
+		values, err := uc.SetValues(entity.CustomFieldResourceProduct, shirt.ID, map[string]any{"care": "Wash at 30°C"})
+		require.NoError(t, err)
+		assert.Equal(t, entity.CustomFields{"erp_id": "A-100", "care": "Wash at 30°C"}, values)
+
+		values, err = uc.GetValues(entity.CustomFieldResourceProduct, shirt.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.CustomFields{"erp_id": "A-100", "care": "Wash at 30°C"}, values)
+
+		_, err = uc.SetValues(entity.CustomFieldResourceProduct, pants.ID, map[string]any{"erp_id": "A-200"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Variant values require the variant of the product", func(t *testing.T) {
+		_, err := uc.SetVariantValues(pants.ID, variant.ID, map[string]any{"bin": float64(12)})
+		assert.ErrorContains(t, err, "not found for product")
+
+		values, err := uc.SetVariantValues(shirt.ID, variant.ID, map[string]any{"bin": float64(12)})
+		require.NoError(t, err)
+		assert.Equal(t, entity.CustomFields{"bin": float64(12)}, values)
+	})
+
+	t.Run("Admin lists filter by custom field", func(t *testing.T) {
+		filters, err := uc.ParseFilters(entity.CustomFieldResourceProduct, map[string]string{"erp_id": "A-100"})
+		require.NoError(t, err)
+		products, err := productRepo.List("", "", 0, 0, 10, 0, 0, "", filters)
+		require.NoError(t, err)
+		require.Len(t, products, 1)
+		assert.Equal(t, shirt.ID, products[0].ID)
+		count, err := productRepo.Count("", "", 0, 0, 0, "", filters)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		_, err = uc.SetValues(entity.CustomFieldResourceOrder, order.ID, map[string]any{"gift": true})
+		require.NoError(t, err)
+		filters, err = uc.ParseFilters(entity.CustomFieldResourceOrder, map[string]string{"gift": "true"})
+		require.NoError(t, err)
+		orders, err := orderRepo.ListAll(0, 10, filters)
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, order.ID, orders[0].ID)
+
+		_, err = uc.SetValues(entity.CustomFieldResourceUser, user.ID, map[string]any{"segment": "wholesale"})
+		require.NoError(t, err)
+		filters, err = uc.ParseFilters(entity.CustomFieldResourceUser, map[string]string{"segment": "wholesale"})
+		require.NoError(t, err)
+		users, err := userRepo.List(0, 10, filters)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, user.ID, users[0].ID)
+
+		_, err = uc.ParseFilters(entity.CustomFieldResourceUser, map[string]string{"tier": "gold"})
+		assert.ErrorContains(t, err, "unknown custom field tier")
+	})
+
+	t.Run("Customers only see public fields", func(t *testing.T) {
+		product, err := productRepo.GetByID(shirt.ID)
+		require.NoError(t, err)
+		require.NoError(t, uc.FilterProductFields(false, product))
+		assert.Equal(t, entity.CustomFields{"care": "Wash at 30°C"}, product.CustomFields)
+		for _, v := range product.Variants {
+			assert.Empty(t, v.CustomFields)
+		}
+
+		product, err = productRepo.GetByID(shirt.ID)
+		require.NoError(t, err)
+		require.NoError(t, uc.FilterProductFields(true, product))
+		assert.Equal(t, "A-100", product.CustomFields["erp_id"])
+
+		customer, err := userRepo.GetByID(user.ID)
+		require.NoError(t, err)
+		require.NoError(t, uc.FilterUserFields(false, customer))
+		assert.Empty(t, customer.CustomFields)
+		assert.Empty(t, customer.ToUserDTO().CustomFields)
+	})
+
+	t.Run("DeleteDefinition removes the values of the field", func(t *testing.T) {
+		require.NoError(t, uc.DeleteDefinition(erpID.ID))
+
+		values, err := gorm.NewCustomFieldRepository(db).GetValues(entity.CustomFieldResourceProduct, shirt.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.CustomFields{"care": "Wash at 30°C"}, values)
+
+		_, err = uc.ParseFilters(entity.CustomFieldResourceProduct, map[string]string{"erp_id": "A-100"})
+		assert.ErrorContains(t, err, "unknown custom field erp_id")
+	})
+}
@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// CustomerGroupUseCase implements customer group and price list use cases
+type CustomerGroupUseCase struct {
+	customerGroupRepo  repository.CustomerGroupRepository
+	priceListRepo      repository.PriceListRepository
+	userRepo           repository.UserRepository
+	productVariantRepo repository.ProductVariantRepository
+}
+
+// NewCustomerGroupUseCase creates a new CustomerGroupUseCase
+func NewCustomerGroupUseCase(
+	customerGroupRepo repository.CustomerGroupRepository,
+	priceListRepo repository.PriceListRepository,
+	userRepo repository.UserRepository,
+	productVariantRepo repository.ProductVariantRepository,
+) *CustomerGroupUseCase {
+	return &CustomerGroupUseCase{
+		customerGroupRepo:  customerGroupRepo,
+		priceListRepo:      priceListRepo,
+		userRepo:           userRepo,
+		productVariantRepo: productVariantRepo,
+	}
+}
+
+// CustomerGroupInput contains the data needed to create or update a customer group
+type CustomerGroupInput struct {
+	Name        string
+	Description string
+	PriceListID *uint
+}
+
+// PriceListInput contains the data needed to create or update a price list
+type PriceListInput struct {
+	Name        string
+	Description string
+	Active      *bool
+	Entries     *[]PriceListEntryInput // nil leaves the entries unchanged
+}
+
+// PriceListEntryInput contains a variant price in minor units. The variant is identified by ID or SKU.
+type PriceListEntryInput struct {
+	VariantID   uint
+	SKU         string
+	Currency    string
+	MinQuantity int
+	Price       int64
+}
+
+// CreateCustomerGroup creates a new customer group
+func (uc *CustomerGroupUseCase) CreateCustomerGroup(input CustomerGroupInput) (*entity.CustomerGroup, error) {
+	if err := uc.validatePriceList(input.PriceListID); err != nil {
+		return nil, err
+	}
+
+	group, err := entity.NewCustomerGroup(input.Name, input.Description, input.PriceListID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.customerGroupRepo.Create(group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// GetCustomerGroup retrieves a customer group by ID
+func (uc *CustomerGroupUseCase) GetCustomerGroup(groupID uint) (*entity.CustomerGroup, error) {
+	return uc.customerGroupRepo.GetByID(groupID)
+}
+
+// UpdateCustomerGroup updates a customer group. A nil price list ID detaches the price list.
+func (uc *CustomerGroupUseCase) UpdateCustomerGroup(groupID uint, input CustomerGroupInput) (*entity.CustomerGroup, error) {
+	group, err := uc.customerGroupRepo.GetByID(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.validatePriceList(input.PriceListID); err != nil {
+		return nil, err
+	}
+
+	group.Update(input.Name, input.Description)
+	group.SetPriceList(input.PriceListID)
+
+	if err := uc.customerGroupRepo.Update(group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// DeleteCustomerGroup deletes a customer group, its members return to regular prices
+func (uc *CustomerGroupUseCase) DeleteCustomerGroup(groupID uint) error {
+	if _, err := uc.customerGroupRepo.GetByID(groupID); err != nil {
+		return err
+	}
+
+	return uc.customerGroupRepo.Delete(groupID)
+}
+
+// ListCustomerGroups lists customer groups
+func (uc *CustomerGroupUseCase) ListCustomerGroups(offset, limit int) ([]*entity.CustomerGroup, error) {
+	return uc.customerGroupRepo.List(offset, limit)
+}
+
+// AssignUserToGroup assigns a user to a customer group, or removes the user from its group when groupID is nil
+func (uc *CustomerGroupUseCase) AssignUserToGroup(userID uint, groupID *uint) (*entity.User, error) {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if groupID != nil {
+		if _, err := uc.customerGroupRepo.GetByID(*groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	user.SetCustomerGroup(groupID)
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// CreatePriceList creates a new price list with its entries
+func (uc *CustomerGroupUseCase) CreatePriceList(input PriceListInput) (*entity.PriceList, error) {
+	active := true
+	if input.Active != nil {
+		active = *input.Active
+	}
+
+	priceList, err := entity.NewPriceList(input.Name, input.Description, active)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.priceListRepo.Create(priceList); err != nil {
+		return nil, err
+	}
+
+	if input.Entries != nil {
+		if err := uc.replaceEntries(priceList, *input.Entries); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.priceListRepo.GetByID(priceList.ID)
+}
+
+// GetPriceList retrieves a price list with its entries
+func (uc *CustomerGroupUseCase) GetPriceList(priceListID uint) (*entity.PriceList, error) {
+	return uc.priceListRepo.GetByID(priceListID)
+}
+
+// UpdatePriceList updates a price list and, when given, replaces its entries
+func (uc *CustomerGroupUseCase) UpdatePriceList(priceListID uint, input PriceListInput) (*entity.PriceList, error) {
+	priceList, err := uc.priceListRepo.GetByID(priceListID)
+	if err != nil {
+		return nil, err
+	}
+
+	if priceList.Update(input.Name, input.Description, input.Active) {
+		if err := uc.priceListRepo.Update(priceList); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Entries != nil {
+		if err := uc.replaceEntries(priceList, *input.Entries); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.priceListRepo.GetByID(priceList.ID)
+}
+
+// DeletePriceList deletes a price list, groups using it return to regular prices
+func (uc *CustomerGroupUseCase) DeletePriceList(priceListID uint) error {
+	if _, err := uc.priceListRepo.GetByID(priceListID); err != nil {
+		return err
+	}
+
+	return uc.priceListRepo.Delete(priceListID)
+}
+
+// ListPriceLists lists price lists without their entries
+func (uc *CustomerGroupUseCase) ListPriceLists(offset, limit int) ([]*entity.PriceList, error) {
+	return uc.priceListRepo.List(offset, limit)
+}
+
+// GetPriceListForUser returns the active price list of the user's customer group, or nil
+func (uc *CustomerGroupUseCase) GetPriceListForUser(userID uint) *entity.PriceList {
+	return resolveCustomerPriceList(uc.priceListRepo, userID)
+}
+
+// replaceEntries validates the entry inputs and replaces all entries of the price list
+func (uc *CustomerGroupUseCase) replaceEntries(priceList *entity.PriceList, inputs []PriceListEntryInput) error {
+	entries := make([]entity.PriceListEntry, 0, len(inputs))
+	for _, input := range inputs {
+		variantID, err := uc.resolveVariantID(input)
+		if err != nil {
+			return err
+		}
+
+		entry, err := entity.NewPriceListEntry(variantID, input.Currency, input.MinQuantity, input.Price)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err := priceList.SetEntries(entries); err != nil {
+		return err
+	}
+
+	return uc.priceListRepo.ReplaceEntries(priceList)
+}
+
+// resolveVariantID resolves the variant of an entry by ID or SKU
+func (uc *CustomerGroupUseCase) resolveVariantID(input PriceListEntryInput) (uint, error) {
+	if input.VariantID != 0 {
+		variant, err := uc.productVariantRepo.GetByID(input.VariantID)
+		if err != nil {
+			return 0, err
+		}
+		return variant.ID, nil
+	}
+
+	if input.SKU == "" {
+		return 0, errors.New("price list entry requires a variant ID or SKU")
+	}
+
+	variant, err := uc.productVariantRepo.GetBySKU(input.SKU)
+	if err != nil {
+		return 0, fmt.Errorf("product variant not found with SKU '%s'", input.SKU)
+	}
+	return variant.ID, nil
+}
+
+// validatePriceList checks that an optional price list exists
+func (uc *CustomerGroupUseCase) validatePriceList(priceListID *uint) error {
+	if priceListID == nil {
+		return nil
+	}
+
+	_, err := uc.priceListRepo.GetByID(*priceListID)
+	return err
+}
+
+// resolveCustomerPriceList returns the active price list of the user's customer group.
+// Guests, users without a group and groups without an active price list get nil.
+func resolveCustomerPriceList(priceListRepo repository.PriceListRepository, userID uint) *entity.PriceList {
+	if userID == 0 || priceListRepo == nil {
+		return nil
+	}
+
+	priceList, err := priceListRepo.GetByUserID(userID)
+	if err != nil || !priceList.Active {
+		return nil
+	}
+
+	return priceList
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormdb "gorm.io/gorm"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestCustomerGroupUseCase(t *testing.T) {
+	setup := func(t *testing.T) (*gormdb.DB, *CustomerGroupUseCase, *entity.ProductVariant) {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+
+		currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+		require.NoError(t, err)
+		require.NoError(t, db.Create(currency).Error)
+
+		testutil.CreateTestProduct(t, db, 1)
+		variant, err := entity.NewProductVariant("WIDGET-1", 100, 1000, 1, nil, nil, true)
+		require.NoError(t, err)
+		variant.ProductID = 1
+		require.NoError(t, db.Create(variant).Error)
+
+		uc := NewCustomerGroupUseCase(
+			gorm.NewCustomerGroupRepository(db),
+			gorm.NewPriceListRepository(db),
+			gorm.NewUserRepository(db),
+			gorm.NewProductVariantRepository(db),
+		)
+
+		return db, uc, variant
+	}
+
+	createWholesale := func(t *testing.T, uc *CustomerGroupUseCase) (*entity.PriceList, *entity.CustomerGroup) {
+		entries := []PriceListEntryInput{
+			{SKU: "WIDGET-1", Currency: "USD", MinQuantity: 1, Price: 900},
+			{SKU: "WIDGET-1", Currency: "USD", MinQuantity: 10, Price: 800},
+		}
+		priceList, err := uc.CreatePriceList(PriceListInput{Name: "Wholesale", Entries: &entries})
+		require.NoError(t, err)
+
+		group, err := uc.CreateCustomerGroup(CustomerGroupInput{Name: "Trade", PriceListID: &priceList.ID})
+		require.NoError(t, err)
+
+		return priceList, group
+	}
+
+	t.Run("Create price list resolves variants by SKU", func(t *testing.T) {
+		_, uc, variant := setup(t)
+		priceList, _ := createWholesale(t, uc)
+
+		stored, err := uc.GetPriceList(priceList.ID)
+		require.NoError(t, err)
+		require.Len(t, stored.Entries, 2)
+		assert.Equal(t, variant.ID, stored.Entries[0].ProductVariantID)
+		assert.True(t, stored.Active)
+	})
+
+	t.Run("Create price list rejects unknown variants", func(t *testing.T) {
+		_, uc, _ := setup(t)
+
+		entries := []PriceListEntryInput{{SKU: "MISSING", Currency: "USD", Price: 100}}
+		_, err := uc.CreatePriceList(PriceListInput{Name: "Broken", Entries: &entries})
+		assert.Error(t, err)
+	})
+
+	t.Run("Create customer group rejects unknown price list", func(t *testing.T) {
+		_, uc, _ := setup(t)
+
+		missing := uint(999)
+		_, err := uc.CreateCustomerGroup(CustomerGroupInput{Name: "Trade", PriceListID: &missing})
+		assert.Error(t, err)
+	})
+
+	t.Run("Price list resolves for group members only", func(t *testing.T) {
+		db, uc, _ := setup(t)
+		priceList, group := createWholesale(t, uc)
+		testutil.CreateTestUser(t, db, 1)
+		testutil.CreateTestUser(t, db, 2)
+
+		user, err := uc.AssignUserToGroup(1, &group.ID)
+		require.NoError(t, err)
+		require.NotNil(t, user.CustomerGroupID)
+
+		resolved := uc.GetPriceListForUser(1)
+		require.NotNil(t, resolved)
+		assert.Equal(t, priceList.ID, resolved.ID)
+		assert.Nil(t, uc.GetPriceListForUser(2))
+		assert.Nil(t, uc.GetPriceListForUser(0))
+
+		inactive := false
+		_, err = uc.UpdatePriceList(priceList.ID, PriceListInput{Active: &inactive})
+		require.NoError(t, err)
+		assert.Nil(t, uc.GetPriceListForUser(1))
+	})
+
+	t.Run("Deleting a group detaches its members", func(t *testing.T) {
+		db, uc, _ := setup(t)
+		_, group := createWholesale(t, uc)
+		testutil.CreateTestUser(t, db, 1)
+
+		_, err := uc.AssignUserToGroup(1, &group.ID)
+		require.NoError(t, err)
+		require.NoError(t, uc.DeleteCustomerGroup(group.ID))
+
+		user, err := gorm.NewUserRepository(db).GetByID(1)
+		require.NoError(t, err)
+		assert.Nil(t, user.CustomerGroupID)
+	})
+
+	t.Run("Product prices use the customer price list", func(t *testing.T) {
+		db, uc, variant := setup(t)
+		_, group := createWholesale(t, uc)
+		testutil.CreateTestUser(t, db, 1)
+		_, err := uc.AssignUserToGroup(1, &group.ID)
+		require.NoError(t, err)
+
+		productUseCase := NewProductUseCase(
+			gorm.NewProductRepository(db),
+			gorm.NewCategoryRepository(db),
+			gorm.NewProductVariantRepository(db),
+			gorm.NewCurrencyRepository(db),
+			gorm.NewOrderRepository(db),
+			gorm.NewCheckoutRepository(db),
+			gorm.NewPriceListRepository(db),
+			gorm.NewProductSearchRepository(db),
+		)
+
+		product, err := productUseCase.GetProductForCustomer(1, "", 1, "")
+		require.NoError(t, err)
+		for _, v := range product.Variants {
+			if v.ID == variant.ID {
+				assert.Equal(t, int64(900), v.Price)
+				assert.Len(t, v.PriceBreaks, 2)
+			}
+		}
+
+		product, err = productUseCase.GetProductForCustomer(1, "", 0, "")
+		require.NoError(t, err)
+		for _, v := range product.Variants {
+			if v.ID == variant.ID {
+				assert.Equal(t, int64(1000), v.Price)
+				assert.Empty(t, v.PriceBreaks)
+			}
+		}
+	})
+
+	t.Run("Checkout items use quantity breaks of the customer price list", func(t *testing.T) {
+		db, uc, _ := setup(t)
+		_, group := createWholesale(t, uc)
+		testutil.CreateTestUser(t, db, 1)
+		_, err := uc.AssignUserToGroup(1, &group.ID)
+		require.NoError(t, err)
+
+		checkoutUseCase := NewCheckoutUseCase(
+			gorm.NewCheckoutRepository(db),
+			gorm.NewProductRepository(db),
+			gorm.NewProductVariantRepository(db),
+			gorm.NewShippingMethodRepository(db),
+			gorm.NewShippingRateRepository(db),
+			gorm.NewDiscountRepository(db),
+			gorm.NewDiscountCodeRepository(db),
+			gorm.NewOrderRepository(db),
+			gorm.NewCurrencyRepository(db),
+			gorm.NewTransactionRepository(db),
+			gorm.NewPriceListRepository(db),
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+
+		checkout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID("session-1")
+		require.NoError(t, err)
+
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "WIDGET-1", Quantity: 2, UserID: 1})
+		require.NoError(t, err)
+		require.Len(t, checkout.Items, 1)
+		assert.Equal(t, int64(900), checkout.Items[0].Price)
+		assert.Equal(t, int64(1800), checkout.TotalAmount)
+
+		checkout, err = checkoutUseCase.UpdateCheckoutItemBySKU(checkout.ID, UpdateCheckoutItemInput{SKU: "WIDGET-1", Quantity: 10, UserID: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(800), checkout.Items[0].Price)
+		assert.Equal(t, int64(8000), checkout.TotalAmount)
+
+		guestCheckout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID("session-2")
+		require.NoError(t, err)
+		guestCheckout, err = checkoutUseCase.AddItemToCheckout(guestCheckout.ID, CheckoutInput{SKU: "WIDGET-1", Quantity: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000), guestCheckout.Items[0].Price)
+
+		guestCheckout, err = checkoutUseCase.ApplyCustomerPrices(guestCheckout, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(800), guestCheckout.Items[0].Price)
+	})
+
+	t.Run("Checkout items leaving a quantity break get the converted price", func(t *testing.T) {
+		db, uc, _ := setup(t)
+		dkk, err := entity.NewCurrency("DKK", "Danish Krone", "kr", 7, true, false)
+		require.NoError(t, err)
+		require.NoError(t, db.Create(dkk).Error)
+
+		// The variant has no explicit DKK price, only a DKK quantity break
+		entries := []PriceListEntryInput{{SKU: "WIDGET-1", Currency: "DKK", MinQuantity: 10, Price: 5000}}
+		priceList, err := uc.CreatePriceList(PriceListInput{Name: "Danish wholesale", Entries: &entries})
+		require.NoError(t, err)
+		group, err := uc.CreateCustomerGroup(CustomerGroupInput{Name: "Danish trade", PriceListID: &priceList.ID})
+		require.NoError(t, err)
+		testutil.CreateTestUser(t, db, 1)
+		_, err = uc.AssignUserToGroup(1, &group.ID)
+		require.NoError(t, err)
+
+		checkoutUseCase := NewCheckoutUseCase(
+			gorm.NewCheckoutRepository(db),
+			gorm.NewProductRepository(db),
+			gorm.NewProductVariantRepository(db),
+			gorm.NewShippingMethodRepository(db),
+			gorm.NewShippingRateRepository(db),
+			gorm.NewDiscountRepository(db),
+			gorm.NewDiscountCodeRepository(db),
+			gorm.NewOrderRepository(db),
+			gorm.NewCurrencyRepository(db),
+			gorm.NewTransactionRepository(db),
+			gorm.NewPriceListRepository(db),
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+
+		checkout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID("session-1")
+		require.NoError(t, err)
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "WIDGET-1", Quantity: 2, UserID: 1})
+		require.NoError(t, err)
+		checkout, err = checkoutUseCase.ChangeCurrency(checkout, "DKK")
+		require.NoError(t, err)
+		assert.Equal(t, int64(7000), checkout.Items[0].Price)
+
+		checkout, err = checkoutUseCase.UpdateCheckoutItemBySKU(checkout.ID, UpdateCheckoutItemInput{SKU: "WIDGET-1", Quantity: 10, UserID: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(5000), checkout.Items[0].Price)
+
+		checkout, err = checkoutUseCase.UpdateCheckoutItemBySKU(checkout.ID, UpdateCheckoutItemInput{SKU: "WIDGET-1", Quantity: 2, UserID: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "DKK", checkout.Currency)
+		assert.Equal(t, int64(7000), checkout.Items[0].Price)
+		assert.Equal(t, int64(14000), checkout.TotalAmount)
+	})
+}
@@ -433,3 +433,60 @@ func TestDashboardUseCase_GetDashboardStats_OnlyPaidOrders(t *testing.T) {
 	assert.Equal(t, int64(1), result.TopProducts[0].QuantitySold) // Only from paid order
 	assert.Equal(t, int64(1000), result.TopProducts[0].Revenue)   // Only from paid order
 }
+
+func TestDashboardUseCase_RevenueRestatedInDefaultCurrency(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	orderRepo := gorm.NewOrderRepository(db)
+	userRepo := gorm.NewUserRepository(db)
+	productRepo := gorm.NewProductRepository(db)
+	dashboardUseCase := NewDashboardUseCase(orderRepo, userRepo, productRepo)
+
+	user, err := entity.NewUser("test@example.com", "password123", "Test", "User", entity.RoleUser)
+	require.NoError(t, err)
+	err = userRepo.Create(user)
+	require.NoError(t, err)
+
+	category := &entity.Category{Name: "Test Category", Description: "Test category"}
+	err = db.Create(category).Error
+	require.NoError(t, err)
+
+	variant, err := entity.NewProductVariant("SKU-001", 10, 1000, 1.0, map[string]string{}, []string{}, true)
+	require.NoError(t, err)
+	product, err := entity.NewProduct("Test Product", "Description", "USD", category.ID, []string{}, []*entity.ProductVariant{variant}, true)
+	require.NoError(t, err)
+	err = db.Create(product).Error
+	require.NoError(t, err)
+
+	// 10.00 USD in the default currency
+	orderUSD, err := entity.NewOrder(
+		&user.ID,
+		[]entity.OrderItem{{ProductID: product.ID, ProductVariantID: product.Variants[0].ID, Quantity: 1, Price: 1000}},
+		"USD", nil, nil,
+		entity.CustomerDetails{Email: user.Email, Phone: "555-0123", FullName: "Test User"},
+	)
+	require.NoError(t, err)
+	require.NoError(t, orderUSD.SetExchangeRate("USD", 1))
+	orderUSD.Status = entity.OrderStatusPaid
+	err = orderRepo.Create(orderUSD)
+	require.NoError(t, err)
+
+	// 100.00 DKK at 6.25 DKK per USD is 16.00 USD
+	orderDKK, err := entity.NewOrder(
+		nil,
+		[]entity.OrderItem{{ProductID: product.ID, ProductVariantID: product.Variants[0].ID, Quantity: 1, Price: 10000}},
+		"DKK", nil, nil,
+		entity.CustomerDetails{Email: "guest@example.com", Phone: "555-0124", FullName: "Guest"},
+	)
+	require.NoError(t, err)
+	require.NoError(t, orderDKK.SetExchangeRate("USD", 6.25))
+	orderDKK.Status = entity.OrderStatusPaid
+	err = orderRepo.Create(orderDKK)
+	require.NoError(t, err)
+
+	result, err := dashboardUseCase.GetDashboardStats(dto.DashboardStatsRequest{Days: 30})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2600), result.TotalRevenue)
+}
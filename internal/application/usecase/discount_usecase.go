@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
@@ -11,40 +12,51 @@ import (
 
 // DiscountUseCase implements discount-related use cases
 type DiscountUseCase struct {
-	discountRepo repository.DiscountRepository
-	productRepo  repository.ProductRepository
-	categoryRepo repository.CategoryRepository
-	orderRepo    repository.OrderRepository
+	discountRepo      repository.DiscountRepository
+	discountCodeRepo  repository.DiscountCodeRepository
+	productRepo       repository.ProductRepository
+	categoryRepo      repository.CategoryRepository
+	orderRepo         repository.OrderRepository
+	currencyRepo      repository.CurrencyRepository
+	collectionUsecase *CollectionUseCase
 }
 
 // NewDiscountUseCase creates a new DiscountUseCase
 func NewDiscountUseCase(
 	discountRepo repository.DiscountRepository,
+	discountCodeRepo repository.DiscountCodeRepository,
 	productRepo repository.ProductRepository,
 	categoryRepo repository.CategoryRepository,
 	orderRepo repository.OrderRepository,
+	currencyRepo repository.CurrencyRepository,
+	collectionUsecase *CollectionUseCase,
 ) *DiscountUseCase {
 	return &DiscountUseCase{
-		discountRepo: discountRepo,
-		productRepo:  productRepo,
-		categoryRepo: categoryRepo,
-		orderRepo:    orderRepo,
+		discountRepo:      discountRepo,
+		discountCodeRepo:  discountCodeRepo,
+		productRepo:       productRepo,
+		categoryRepo:      categoryRepo,
+		orderRepo:         orderRepo,
+		currencyRepo:      currencyRepo,
+		collectionUsecase: collectionUsecase,
 	}
 }
 
 // CreateDiscountInput contains the data needed to create a discount
 type CreateDiscountInput struct {
-	Code             string    `json:"code"`
-	Type             string    `json:"type"`
-	Method           string    `json:"method"`
-	Value            float64   `json:"value"`
-	MinOrderValue    float64   `json:"min_order_value"`
-	MaxDiscountValue float64   `json:"max_discount_value"`
-	ProductIDs       []uint    `json:"product_ids"`
-	CategoryIDs      []uint    `json:"category_ids"`
-	StartDate        time.Time `json:"start_date"`
-	EndDate          time.Time `json:"end_date"`
-	UsageLimit       int       `json:"usage_limit"`
+	Code             string           `json:"code"`
+	Type             string           `json:"type"`
+	Method           string           `json:"method"`
+	Value            float64          `json:"value"`
+	MinOrderValue    float64          `json:"min_order_value"`
+	MaxDiscountValue float64          `json:"max_discount_value"`
+	ProductIDs       []uint           `json:"product_ids"`
+	CategoryIDs      []uint           `json:"category_ids"`
+	CollectionIDs    []uint           `json:"collection_ids"`
+	StartDate        time.Time        `json:"start_date"`
+	EndDate          time.Time        `json:"end_date"`
+	UsageLimit       int              `json:"usage_limit"`
+	CurrencyValues   map[string]int64 `json:"currency_values"` // Explicit fixed amounts in cents per currency
 }
 
 // CreateDiscount creates a new discount
@@ -76,6 +88,9 @@ func (uc *DiscountUseCase) CreateDiscount(input CreateDiscountInput) (*entity.Di
 	if err == nil && existingDiscount != nil {
 		return nil, errors.New("discount code already exists")
 	}
+	if existingCode, err := uc.discountCodeRepo.GetByCode(input.Code); err == nil && existingCode != nil {
+		return nil, errors.New("discount code already exists")
+	}
 
 	// Validate product IDs if it's a product discount
 	if discountType == entity.DiscountTypeProduct && len(input.ProductIDs) > 0 {
@@ -97,16 +112,30 @@ func (uc *DiscountUseCase) CreateDiscount(input CreateDiscountInput) (*entity.Di
 		}
 	}
 
+	// Validate collection IDs if it's a product discount
+	if discountType == entity.DiscountTypeProduct && len(input.CollectionIDs) > 0 {
+		if err := uc.collectionUsecase.CheckCollectionIDs(input.CollectionIDs); err != nil {
+			return nil, errors.New("invalid collection ID: " + err.Error())
+		}
+	}
+
+	// The limits are configured in the default currency
+	baseCurrency, err := resolveBaseCurrency(uc.currencyRepo)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create discount
 	discount, err := entity.NewDiscount(
 		input.Code,
 		discountType,
 		discountMethod,
 		input.Value,
-		money.ToCents(input.MinOrderValue),
-		money.ToCents(input.MaxDiscountValue),
+		money.ToMinor(input.MinOrderValue, currencyCode(baseCurrency)),
+		money.ToMinor(input.MaxDiscountValue, currencyCode(baseCurrency)),
 		input.ProductIDs,
 		input.CategoryIDs,
+		input.CollectionIDs,
 		input.StartDate,
 		input.EndDate,
 		input.UsageLimit,
@@ -115,6 +144,11 @@ func (uc *DiscountUseCase) CreateDiscount(input CreateDiscountInput) (*entity.Di
 		return nil, err
 	}
 
+	if err := discount.SetCurrencyValues(input.CurrencyValues); err != nil {
+		return nil, err
+	}
+	discount.SetBaseCurrency(baseCurrency)
+
 	// Save discount
 	if err := uc.discountRepo.Create(discount); err != nil {
 		return nil, err
@@ -125,28 +159,44 @@ func (uc *DiscountUseCase) CreateDiscount(input CreateDiscountInput) (*entity.Di
 
 // GetDiscountByID retrieves a discount by ID
 func (uc *DiscountUseCase) GetDiscountByID(id uint) (*entity.Discount, error) {
-	return uc.discountRepo.GetByID(id)
+	discount, err := uc.discountRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.setBaseCurrency(discount); err != nil {
+		return nil, err
+	}
+	return discount, nil
 }
 
-// GetDiscountByCode retrieves a discount by code
+// GetDiscountByCode retrieves a discount by code, including codes that belong to a batch
 func (uc *DiscountUseCase) GetDiscountByCode(code string) (*entity.Discount, error) {
-	return uc.discountRepo.GetByCode(code)
+	discount, _, err := resolveDiscountCode(uc.discountRepo, uc.discountCodeRepo, code)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.setBaseCurrency(discount); err != nil {
+		return nil, err
+	}
+	return discount, nil
 }
 
 // UpdateDiscountInput contains the data needed to update a discount
 type UpdateDiscountInput struct {
-	Code             string    `json:"code"`
-	Type             string    `json:"type"`
-	Method           string    `json:"method"`
-	Value            float64   `json:"value"`
-	MinOrderValue    float64   `json:"min_order_value"`
-	MaxDiscountValue float64   `json:"max_discount_value"`
-	ProductIDs       []uint    `json:"product_ids"`
-	CategoryIDs      []uint    `json:"category_ids"`
-	StartDate        time.Time `json:"start_date"`
-	EndDate          time.Time `json:"end_date"`
-	UsageLimit       int       `json:"usage_limit"`
-	Active           bool      `json:"active"`
+	Code             string            `json:"code"`
+	Type             string            `json:"type"`
+	Method           string            `json:"method"`
+	Value            float64           `json:"value"`
+	MinOrderValue    float64           `json:"min_order_value"`
+	MaxDiscountValue float64           `json:"max_discount_value"`
+	ProductIDs       []uint            `json:"product_ids"`
+	CategoryIDs      []uint            `json:"category_ids"`
+	CollectionIDs    []uint            `json:"collection_ids"`
+	StartDate        time.Time         `json:"start_date"`
+	EndDate          time.Time         `json:"end_date"`
+	UsageLimit       int               `json:"usage_limit"`
+	Active           bool              `json:"active"`
+	CurrencyValues   *map[string]int64 `json:"currency_values"` // nil leaves them unchanged
 }
 
 // UpdateDiscount updates a discount
@@ -195,12 +245,18 @@ func (uc *DiscountUseCase) UpdateDiscount(id uint, input UpdateDiscountInput) (*
 		discount.Value = input.Value
 	}
 
+	baseCurrency, err := resolveBaseCurrency(uc.currencyRepo)
+	if err != nil {
+		return nil, err
+	}
+	discount.SetBaseCurrency(baseCurrency)
+
 	if input.MinOrderValue >= 0 {
-		discount.MinOrderValue = money.ToCents(input.MinOrderValue)
+		discount.MinOrderValue = money.ToMinor(input.MinOrderValue, currencyCode(baseCurrency))
 	}
 
 	if input.MaxDiscountValue >= 0 {
-		discount.MaxDiscountValue = money.ToCents(input.MaxDiscountValue)
+		discount.MaxDiscountValue = money.ToMinor(input.MaxDiscountValue, currencyCode(baseCurrency))
 	}
 
 	if len(input.ProductIDs) > 0 {
@@ -225,6 +281,14 @@ func (uc *DiscountUseCase) UpdateDiscount(id uint, input UpdateDiscountInput) (*
 		discount.CategoryIDs = input.CategoryIDs
 	}
 
+	if len(input.CollectionIDs) > 0 {
+		// Validate collection IDs
+		if err := uc.collectionUsecase.CheckCollectionIDs(input.CollectionIDs); err != nil {
+			return nil, errors.New("invalid collection ID: " + err.Error())
+		}
+		discount.CollectionIDs = input.CollectionIDs
+	}
+
 	if !input.StartDate.IsZero() {
 		discount.StartDate = input.StartDate
 	}
@@ -237,6 +301,12 @@ func (uc *DiscountUseCase) UpdateDiscount(id uint, input UpdateDiscountInput) (*
 		discount.UsageLimit = input.UsageLimit
 	}
 
+	if input.CurrencyValues != nil {
+		if err := discount.SetCurrencyValues(*input.CurrencyValues); err != nil {
+			return nil, err
+		}
+	}
+
 	discount.Active = input.Active
 	discount.UpdatedAt = time.Now()
 
@@ -265,12 +335,38 @@ func (uc *DiscountUseCase) DeleteDiscount(id uint) error {
 
 // ListDiscounts lists all discounts with pagination
 func (uc *DiscountUseCase) ListDiscounts(offset, limit int) ([]*entity.Discount, error) {
-	return uc.discountRepo.List(offset, limit)
+	discounts, err := uc.discountRepo.List(offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.setBaseCurrency(discounts...); err != nil {
+		return nil, err
+	}
+	return discounts, nil
 }
 
 // ListActiveDiscounts lists all active discounts with pagination
 func (uc *DiscountUseCase) ListActiveDiscounts(offset, limit int) ([]*entity.Discount, error) {
-	return uc.discountRepo.ListActive(offset, limit)
+	discounts, err := uc.discountRepo.ListActive(offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.setBaseCurrency(discounts...); err != nil {
+		return nil, err
+	}
+	return discounts, nil
+}
+
+// setBaseCurrency sets the default currency, in which the limits are configured, on the discounts
+func (uc *DiscountUseCase) setBaseCurrency(discounts ...*entity.Discount) error {
+	baseCurrency, err := resolveBaseCurrency(uc.currencyRepo)
+	if err != nil {
+		return err
+	}
+	for _, discount := range discounts {
+		discount.SetBaseCurrency(baseCurrency)
+	}
+	return nil
 }
 
 // ApplyDiscountToOrderInput contains the data needed to apply a discount to an order
@@ -282,15 +378,36 @@ type ApplyDiscountToOrderInput struct {
 // ApplyDiscountToOrder applies a discount to an order
 func (uc *DiscountUseCase) ApplyDiscountToOrder(input ApplyDiscountToOrderInput, order *entity.Order) (*entity.Order, error) {
 	// Get discount by code
-	discount, err := uc.discountRepo.GetByCode(input.DiscountCode)
+	discount, discountCode, err := resolveDiscountCode(uc.discountRepo, uc.discountCodeRepo, input.DiscountCode)
 	if err != nil {
 		return nil, errors.New("invalid discount code")
 	}
 
+	productIDs := make([]uint, len(order.Items))
+	for i, item := range order.Items {
+		productIDs[i] = item.ProductID
+	}
+	if err := uc.collectionUsecase.ResolveDiscountCollections(discount, productIDs); err != nil {
+		return nil, err
+	}
+
+	baseCurrency, orderCurrency, err := resolveCurrencyConversion(uc.currencyRepo, order.Currency)
+	if err != nil {
+		return nil, err
+	}
+	discount.SetCurrencyConversion(baseCurrency, orderCurrency)
+
 	if err := order.ApplyDiscount(discount); err != nil {
 		return nil, err
 	}
 
+	// Claim the redemption of the individual code before saving, so concurrent requests cannot redeem it twice
+	if discountCode != nil {
+		if err := uc.discountCodeRepo.IncrementUsage(discountCode.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	uc.orderRepo.Update(order)
 
 	// Increment discount usage
@@ -310,3 +427,238 @@ func (uc *DiscountUseCase) RemoveDiscountFromOrder(order *entity.Order) {
 	order.RemoveDiscount()
 	uc.orderRepo.Update(order)
 }
+
+// GenerateDiscountCodeBatchInput contains the data needed to generate a batch of unique codes
+type GenerateDiscountCodeBatchInput struct {
+	DiscountID        uint   `json:"discount_id"`
+	Name              string `json:"name"`
+	Prefix            string `json:"prefix"`
+	Quantity          int    `json:"quantity"`
+	CodeLength        int    `json:"code_length"`
+	UsageLimitPerCode int    `json:"usage_limit_per_code"`
+}
+
+// maxCodeGenerationAttempts bounds how often colliding codes are regenerated
+const maxCodeGenerationAttempts = 10
+
+// GenerateDiscountCodeBatch generates a batch of unique codes sharing the rules of a discount
+func (uc *DiscountUseCase) GenerateDiscountCodeBatch(input GenerateDiscountCodeBatchInput) (*entity.DiscountCodeBatch, error) {
+	if input.Quantity <= 0 {
+		return nil, errors.New("quantity must be greater than zero")
+	}
+	if input.Quantity > entity.MaxDiscountCodeBatchSize {
+		return nil, fmt.Errorf("quantity cannot exceed %d codes", entity.MaxDiscountCodeBatchSize)
+	}
+
+	if _, err := uc.discountRepo.GetByID(input.DiscountID); err != nil {
+		return nil, err
+	}
+
+	batch, err := entity.NewDiscountCodeBatch(
+		input.DiscountID,
+		input.Name,
+		input.Prefix,
+		entity.DiscountCodeBatchSourceGenerated,
+		input.UsageLimitPerCode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make(map[string]struct{}, input.Quantity)
+	for attempt := 0; len(codes) < input.Quantity; attempt++ {
+		if attempt >= maxCodeGenerationAttempts {
+			return nil, errors.New("unable to generate enough unique codes, try a longer code length")
+		}
+
+		// Generate the missing codes, skipping duplicates within the batch
+		pending := make(map[string]struct{}, input.Quantity-len(codes))
+		candidates := make([]string, 0, input.Quantity-len(codes))
+		for len(codes)+len(candidates) < input.Quantity {
+			code, err := entity.GenerateDiscountCode(batch.Prefix, input.CodeLength)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := codes[code]; exists {
+				continue
+			}
+			if _, exists := pending[code]; exists {
+				continue
+			}
+			pending[code] = struct{}{}
+			candidates = append(candidates, code)
+		}
+
+		// Drop candidates that collide with codes already stored
+		existing, err := uc.discountCodeRepo.FindExistingCodes(candidates)
+		if err != nil {
+			return nil, err
+		}
+		taken := make(map[string]struct{}, len(existing))
+		for _, code := range existing {
+			taken[code] = struct{}{}
+		}
+
+		for _, code := range candidates {
+			if _, exists := taken[code]; !exists {
+				codes[code] = struct{}{}
+			}
+		}
+	}
+
+	for code := range codes {
+		if err := batch.AddCode(code); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.discountCodeRepo.CreateBatch(batch); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// ImportDiscountCodeBatchInput contains the data needed to import a batch of codes
+type ImportDiscountCodeBatchInput struct {
+	DiscountID        uint     `json:"discount_id"`
+	Name              string   `json:"name"`
+	UsageLimitPerCode int      `json:"usage_limit_per_code"`
+	Codes             []string `json:"codes"`
+}
+
+// ImportDiscountCodeBatch creates a batch from a list of externally supplied codes
+func (uc *DiscountUseCase) ImportDiscountCodeBatch(input ImportDiscountCodeBatchInput) (*entity.DiscountCodeBatch, error) {
+	if len(input.Codes) == 0 {
+		return nil, errors.New("no codes to import")
+	}
+	if len(input.Codes) > entity.MaxDiscountCodeBatchSize {
+		return nil, fmt.Errorf("cannot import more than %d codes", entity.MaxDiscountCodeBatchSize)
+	}
+
+	if _, err := uc.discountRepo.GetByID(input.DiscountID); err != nil {
+		return nil, err
+	}
+
+	batch, err := entity.NewDiscountCodeBatch(
+		input.DiscountID,
+		input.Name,
+		"",
+		entity.DiscountCodeBatchSourceImported,
+		input.UsageLimitPerCode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(input.Codes))
+	normalized := make([]string, 0, len(input.Codes))
+	for _, code := range input.Codes {
+		code = entity.NormalizeDiscountCode(code)
+		if code == "" {
+			continue
+		}
+		if _, exists := seen[code]; exists {
+			return nil, fmt.Errorf("duplicate code in import: %s", code)
+		}
+		seen[code] = struct{}{}
+		normalized = append(normalized, code)
+	}
+
+	existing, err := uc.discountCodeRepo.FindExistingCodes(normalized)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("%d codes already exist, e.g. %s", len(existing), existing[0])
+	}
+
+	for _, code := range normalized {
+		if err := batch.AddCode(code); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.discountCodeRepo.CreateBatch(batch); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// GetDiscountCodeBatch retrieves a batch along with its redemption statistics
+func (uc *DiscountUseCase) GetDiscountCodeBatch(batchID uint) (*entity.DiscountCodeBatch, *entity.DiscountCodeBatchStats, error) {
+	batch, err := uc.discountCodeRepo.GetBatchByID(batchID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stats, err := uc.discountCodeRepo.GetBatchStats(batchID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return batch, stats, nil
+}
+
+// ListDiscountCodeBatches lists the batches of a discount along with their redemption statistics
+func (uc *DiscountUseCase) ListDiscountCodeBatches(discountID uint, offset, limit int) ([]*entity.DiscountCodeBatch, map[uint]*entity.DiscountCodeBatchStats, error) {
+	batches, err := uc.discountCodeRepo.ListBatchesByDiscount(discountID, offset, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stats := make(map[uint]*entity.DiscountCodeBatchStats, len(batches))
+	for _, batch := range batches {
+		batchStats, err := uc.discountCodeRepo.GetBatchStats(batch.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		stats[batch.ID] = batchStats
+	}
+
+	return batches, stats, nil
+}
+
+// ListDiscountCodes lists all codes in a batch, e.g. for export
+func (uc *DiscountUseCase) ListDiscountCodes(batchID uint) ([]*entity.DiscountCode, error) {
+	if _, err := uc.discountCodeRepo.GetBatchByID(batchID); err != nil {
+		return nil, err
+	}
+	return uc.discountCodeRepo.ListCodesByBatch(batchID)
+}
+
+// DeleteDiscountCodeBatch deletes a batch and all of its codes
+func (uc *DiscountUseCase) DeleteDiscountCodeBatch(batchID uint) error {
+	if _, err := uc.discountCodeRepo.GetBatchByID(batchID); err != nil {
+		return err
+	}
+	return uc.discountCodeRepo.DeleteBatch(batchID)
+}
+
+// resolveDiscountCode looks up a code as a standalone discount first and falls back to
+// batch codes. When the code belongs to a batch, the returned discount carries the batch
+// code so that it is recorded on the checkout or order.
+func resolveDiscountCode(
+	discountRepo repository.DiscountRepository,
+	discountCodeRepo repository.DiscountCodeRepository,
+	code string,
+) (*entity.Discount, *entity.DiscountCode, error) {
+	discount, err := discountRepo.GetByCode(code)
+	if err == nil {
+		return discount, nil, nil
+	}
+
+	discountCode, codeErr := discountCodeRepo.GetByCode(code)
+	if codeErr != nil || discountCode.Discount == nil {
+		return nil, nil, err
+	}
+
+	if !discountCode.IsRedeemable() {
+		return nil, nil, errors.New("discount code has already been redeemed")
+	}
+
+	redeemable := *discountCode.Discount
+	redeemable.Code = discountCode.Code
+	return &redeemable, discountCode, nil
+}
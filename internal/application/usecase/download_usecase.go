@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// DownloadOptions configures the delivery of digital products
+type DownloadOptions struct {
+	BaseURL      string        // Base URL of the API in download links
+	LinkTTL      time.Duration // How long downloads stay available after payment
+	MaxDownloads int           // Downloads allowed per file and order, 0 for unlimited
+	SigningKey   string        // Key signing download links
+	MaxFileSize  int64         // Maximum file size in bytes, 0 for unlimited
+}
+
+// DownloadUseCase implements the use cases of digital product files and their downloads
+type DownloadUseCase struct {
+	fileRepo     repository.DigitalFileRepository
+	downloadRepo repository.OrderDownloadRepository
+	productRepo  repository.ProductRepository
+	orderRepo    repository.OrderRepository
+	storage      service.FileStorage
+	options      DownloadOptions
+	now          func() time.Time
+}
+
+// NewDownloadUseCase creates a new DownloadUseCase
+func NewDownloadUseCase(
+	fileRepo repository.DigitalFileRepository,
+	downloadRepo repository.OrderDownloadRepository,
+	productRepo repository.ProductRepository,
+	orderRepo repository.OrderRepository,
+	storage service.FileStorage,
+	options DownloadOptions,
+) *DownloadUseCase {
+	return &DownloadUseCase{
+		fileRepo:     fileRepo,
+		downloadRepo: downloadRepo,
+		productRepo:  productRepo,
+		orderRepo:    orderRepo,
+		storage:      storage,
+		options:      options,
+		now:          time.Now,
+	}
+}
+
+// UploadDigitalFileInput contains the data for uploading a file of a digital variant
+type UploadDigitalFileInput struct {
+	FileName    string
+	ContentType string // Content type declared by the client, may be empty
+	Body        io.Reader
+	Size        int64
+}
+
+// UploadDigitalFile stores a file delivered to the customers of a digital variant (admin only)
+func (uc *DownloadUseCase) UploadDigitalFile(productID, variantID uint, input UploadDigitalFileInput) (*entity.DigitalFile, error) {
+	if uc.storage == nil {
+		return nil, errors.New("download storage is not configured")
+	}
+	variant, err := uc.getVariant(productID, variantID)
+	if err != nil {
+		return nil, err
+	}
+	if !variant.IsDigital {
+		return nil, fmt.Errorf("variant %s is not digital", variant.SKU)
+	}
+	if uc.options.MaxFileSize > 0 && input.Size > uc.options.MaxFileSize {
+		return nil, fmt.Errorf("file exceeds the maximum upload size of %d bytes", uc.options.MaxFileSize)
+	}
+
+	contentType := mediaType(input.ContentType)
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = mediaType(mime.TypeByExtension(filepath.Ext(input.FileName)))
+	}
+	file, err := entity.NewDigitalFile(variantID, input.FileName, contentType, input.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	file.StorageKey = "downloads/" + uuid.New().String()
+	if err := uc.storage.Put(file.StorageKey, file.ContentType, input.Body, file.Size); err != nil {
+		return nil, err
+	}
+	if err := uc.fileRepo.Create(file); err != nil {
+		uc.storage.Delete(file.StorageKey)
+		return nil, err
+	}
+	return file, nil
+}
+
+// ListDigitalFiles returns the files of a variant (admin only)
+func (uc *DownloadUseCase) ListDigitalFiles(productID, variantID uint) ([]*entity.DigitalFile, error) {
+	if _, err := uc.getVariant(productID, variantID); err != nil {
+		return nil, err
+	}
+	return uc.fileRepo.ListByVariant(variantID)
+}
+
+// DeleteDigitalFile deletes a file of a variant with the downloads granted for it (admin only)
+func (uc *DownloadUseCase) DeleteDigitalFile(productID, variantID, fileID uint) error {
+	if _, err := uc.getVariant(productID, variantID); err != nil {
+		return err
+	}
+	file, err := uc.fileRepo.GetByID(fileID)
+	if err != nil {
+		return err
+	}
+	if file.VariantID != variantID {
+		return fmt.Errorf("digital file with ID %d not found", fileID)
+	}
+
+	if err := uc.fileRepo.Delete(fileID); err != nil {
+		return err
+	}
+	if uc.storage == nil {
+		return errors.New("digital file deleted, but download storage is not configured to remove it")
+	}
+	if err := uc.storage.Delete(file.StorageKey); err != nil {
+		return fmt.Errorf("digital file deleted, but failed to remove it: %w", err)
+	}
+	return nil
+}
+
+// LoadOrderDownloads sets the downloads of a paid order with signed links. Downloads of the
+// digital items are granted the first time, later calls keep their count and expiry.
+// Unpaid orders get no downloads.
+func (uc *DownloadUseCase) LoadOrderDownloads(order *entity.Order) error {
+	order.Downloads = nil
+	if !order.HasDigitalItems() || !order.IsPaid() {
+		return nil
+	}
+
+	expiresAt := uc.now().Add(uc.options.LinkTTL)
+	var grants []*entity.OrderDownload
+	for _, item := range order.Items {
+		if !item.IsDigital {
+			continue
+		}
+		files, err := uc.fileRepo.ListByVariant(item.ProductVariantID)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			grant, err := entity.NewOrderDownload(order.ID, item.ID, file, uc.options.MaxDownloads, expiresAt)
+			if err != nil {
+				return err
+			}
+			grants = append(grants, grant)
+		}
+	}
+	if err := uc.downloadRepo.CreateMissing(grants); err != nil {
+		return err
+	}
+
+	downloads, err := uc.downloadRepo.ListByOrder(order.ID)
+	if err != nil {
+		return err
+	}
+	for _, download := range downloads {
+		download.URL = uc.downloadURL(download)
+	}
+	order.Downloads = downloads
+	return nil
+}
+
+// DownloadFile checks a signed download link and counts the download. It returns the file with
+// its contents, which the caller closes.
+func (uc *DownloadUseCase) DownloadFile(downloadID uint, expires, signature string) (*entity.DigitalFile, io.ReadCloser, error) {
+	if uc.storage == nil {
+		return nil, nil, errors.New("download storage is not configured")
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(uc.sign(downloadID, expiresAt))) {
+		return nil, nil, errors.New("invalid download signature")
+	}
+
+	download, err := uc.downloadRepo.GetByID(downloadID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if download.ExpiresAt.Unix() != expiresAt {
+		return nil, nil, errors.New("invalid download signature")
+	}
+	if err := download.CheckAvailable(uc.now()); err != nil {
+		return nil, nil, err
+	}
+
+	// Refunded and cancelled orders lose their downloads
+	order, err := uc.orderRepo.GetByID(download.OrderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !order.IsPaid() {
+		return nil, nil, fmt.Errorf("downloads of order %d are no longer available", order.ID)
+	}
+
+	body, err := uc.storage.Open(download.DigitalFile.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := uc.downloadRepo.RecordDownload(download.ID); err != nil {
+		body.Close()
+		return nil, nil, err
+	}
+	return download.DigitalFile, body, nil
+}
+
+// downloadURL returns the signed link of a download, valid until the download expires
+func (uc *DownloadUseCase) downloadURL(download *entity.OrderDownload) string {
+	expiresAt := download.ExpiresAt.Unix()
+	query := url.Values{
+		"expires":   {strconv.FormatInt(expiresAt, 10)},
+		"signature": {uc.sign(download.ID, expiresAt)},
+	}
+	return fmt.Sprintf("%s/api/downloads/%d?%s", uc.options.BaseURL, download.ID, query.Encode())
+}
+
+// sign returns the HMAC-SHA256 signature of a download link
+func (uc *DownloadUseCase) sign(downloadID uint, expiresAt int64) string {
+	mac := hmac.New(sha256.New, []byte(uc.options.SigningKey))
+	fmt.Fprintf(mac, "%d:%d", downloadID, expiresAt)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// getVariant returns a variant of the product
+func (uc *DownloadUseCase) getVariant(productID, variantID uint) (*entity.ProductVariant, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	variant := product.GetVariantByID(variantID)
+	if variant == nil {
+		return nil, fmt.Errorf("variant with ID %d not found for product %d", variantID, productID)
+	}
+	return variant, nil
+}
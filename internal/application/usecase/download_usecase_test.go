@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/internal/infrastructure/storage"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestDownloadUseCase(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(currency).Error)
+
+	testutil.CreateTestProduct(t, db, 1)
+	variantRepo := gorm.NewProductVariantRepository(db)
+	createVariant := func(sku string, digital bool) *entity.ProductVariant {
+		variant, err := entity.NewProductVariant(sku, 10, 1500, 0, entity.VariantAttributes{"format": sku}, nil, false)
+		require.NoError(t, err)
+		variant.ProductID = 1
+		variant.IsDigital = digital
+		require.NoError(t, variantRepo.Create(variant))
+		return variant
+	}
+	ebook := createVariant("EBOOK", true)
+	paperback := createVariant("PAPERBACK", false)
+
+	orderRepo := gorm.NewOrderRepository(db)
+	uc := NewDownloadUseCase(
+		gorm.NewDigitalFileRepository(db),
+		gorm.NewOrderDownloadRepository(db),
+		gorm.NewProductRepository(db),
+		orderRepo,
+		storage.NewLocalStorage(t.TempDir(), ""),
+		DownloadOptions{
+			BaseURL:      "https://shop.example.com",
+			LinkTTL:      48 * time.Hour,
+			MaxDownloads: 2,
+			SigningKey:   "secret",
+			MaxFileSize:  1 << 20,
+		},
+	)
+
+	checkoutUseCase := NewCheckoutUseCase(
+		gorm.NewCheckoutRepository(db),
+		gorm.NewProductRepository(db),
+		variantRepo,
+		gorm.NewShippingMethodRepository(db),
+		gorm.NewShippingRateRepository(db),
+		gorm.NewDiscountRepository(db),
+		gorm.NewDiscountCodeRepository(db),
+		orderRepo,
+		gorm.NewCurrencyRepository(db),
+		gorm.NewTransactionRepository(db),
+		gorm.NewPriceListRepository(db),
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	createOrder := func(t *testing.T, sessionID, sku string) *entity.Order {
+		checkout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID(sessionID)
+		require.NoError(t, err)
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: sku, Quantity: 1})
+		require.NoError(t, err)
+
+		checkout.SetBillingAddress(entity.Address{Street1: "1 Main St", City: "Copenhagen", Country: "DK"})
+		checkout.SetCustomerDetails(entity.CustomerDetails{Email: "reader@example.com", FullName: "Avid Reader"})
+		require.NoError(t, gorm.NewCheckoutRepository(db).Update(checkout))
+
+		order, err := checkoutUseCase.CreateOrderFromCheckout(checkout.ID)
+		if err != nil {
+			t.Fatalf("failed to create order: %v", err)
+		}
+		return order
+	}
+
+	t.Run("Files can only be attached to digital variants", func(t *testing.T) {
+		_, err := uc.UploadDigitalFile(1, paperback.ID, UploadDigitalFileInput{FileName: "book.pdf", Body: strings.NewReader("%PDF"), Size: 4})
+		assert.ErrorContains(t, err, "is not digital")
+
+		_, err = uc.UploadDigitalFile(1, ebook.ID, UploadDigitalFileInput{FileName: "book.pdf", Body: strings.NewReader("%PDF"), Size: 2 << 20})
+		assert.ErrorContains(t, err, "maximum upload size")
+
+		file, err := uc.UploadDigitalFile(1, ebook.ID, UploadDigitalFileInput{FileName: "book.pdf", Body: strings.NewReader("%PDF"), Size: 4})
+		require.NoError(t, err)
+		assert.Equal(t, "application/pdf", file.ContentType)
+		assert.True(t, strings.HasPrefix(file.StorageKey, "downloads/"))
+
+		files, err := uc.ListDigitalFiles(1, ebook.ID)
+		require.NoError(t, err)
+		assert.Len(t, files, 1)
+	})
+
+	t.Run("Physical orders still require shipping", func(t *testing.T) {
+		checkout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID("physical")
+		require.NoError(t, err)
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "PAPERBACK", Quantity: 1})
+		require.NoError(t, err)
+
+		_, err = checkoutUseCase.CreateOrderFromCheckout(checkout.ID)
+		assert.ErrorContains(t, err, "shipping address is required")
+	})
+
+	t.Run("Paid digital orders get signed, limited downloads", func(t *testing.T) {
+		order := createOrder(t, "digital", "EBOOK")
+		assert.False(t, order.RequiresShipping())
+
+		require.NoError(t, uc.LoadOrderDownloads(order))
+		assert.Empty(t, order.Downloads, "unpaid orders get no downloads")
+
+		order.PaymentStatus = entity.PaymentStatusAuthorized
+		require.NoError(t, orderRepo.Update(order))
+
+		require.NoError(t, uc.LoadOrderDownloads(order))
+		require.Len(t, order.Downloads, 1)
+		download := order.Downloads[0]
+		assert.Equal(t, "book.pdf", download.DigitalFile.FileName)
+		assert.Equal(t, 2, download.RemainingDownloads())
+		assert.True(t, strings.HasPrefix(download.URL, "https://shop.example.com/api/downloads/"))
+
+		// Loading again keeps the granted download
+		require.NoError(t, uc.LoadOrderDownloads(order))
+		require.Len(t, order.Downloads, 1)
+		assert.Equal(t, download.ID, order.Downloads[0].ID)
+
+		link, err := url.Parse(download.URL)
+		require.NoError(t, err)
+		expires, signature := link.Query().Get("expires"), link.Query().Get("signature")
+
+		_, _, err = uc.DownloadFile(download.ID, expires, signature+"0")
+		assert.ErrorContains(t, err, "invalid download signature")
+		_, _, err = uc.DownloadFile(download.ID+1, expires, signature)
+		assert.ErrorContains(t, err, "invalid download signature")
+
+		for range 2 {
+			file, body, err := uc.DownloadFile(download.ID, expires, signature)
+			require.NoError(t, err)
+			data, _ := io.ReadAll(body)
+			body.Close()
+			assert.Equal(t, "%PDF", string(data))
+			assert.Equal(t, "book.pdf", file.FileName)
+		}
+
+		_, _, err = uc.DownloadFile(download.ID, expires, signature)
+		assert.ErrorContains(t, err, "download limit")
+	})
+
+	t.Run("Downloads expire and end with a refund", func(t *testing.T) {
+		order := createOrder(t, "expiring", "EBOOK")
+		order.PaymentStatus = entity.PaymentStatusCaptured
+		require.NoError(t, orderRepo.Update(order))
+		require.NoError(t, uc.LoadOrderDownloads(order))
+		require.Len(t, order.Downloads, 1)
+
+		link, err := url.Parse(order.Downloads[0].URL)
+		require.NoError(t, err)
+		expires, signature := link.Query().Get("expires"), link.Query().Get("signature")
+
+		uc.now = func() time.Time { return time.Now().Add(49 * time.Hour) }
+		_, _, err = uc.DownloadFile(order.Downloads[0].ID, expires, signature)
+		assert.ErrorContains(t, err, "expired")
+		uc.now = time.Now
+
+		order.PaymentStatus = entity.PaymentStatusRefunded
+		require.NoError(t, orderRepo.Update(order))
+		_, _, err = uc.DownloadFile(order.Downloads[0].ID, expires, signature)
+		assert.ErrorContains(t, err, "no longer available")
+	})
+
+	t.Run("Deleting a file removes its downloads", func(t *testing.T) {
+		files, err := uc.ListDigitalFiles(1, ebook.ID)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		assert.ErrorContains(t, uc.DeleteDigitalFile(1, paperback.ID, files[0].ID), "not found")
+		require.NoError(t, uc.DeleteDigitalFile(1, ebook.ID, files[0].ID))
+
+		downloads, err := gorm.NewOrderDownloadRepository(db).ListByOrder(1)
+		require.NoError(t, err)
+		assert.Empty(t, downloads)
+	})
+}
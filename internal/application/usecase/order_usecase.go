@@ -23,6 +23,7 @@ type OrderUseCase struct {
 	emailSvc           service.EmailService
 	paymentTxnRepo     repository.PaymentTransactionRepository
 	currencyRepo       repository.CurrencyRepository
+	downloadUseCase    *DownloadUseCase
 }
 
 // NewOrderUseCase creates a new OrderUseCase
@@ -35,6 +36,7 @@ func NewOrderUseCase(
 	emailSvc service.EmailService,
 	paymentTxnRepo repository.PaymentTransactionRepository,
 	currencyRepo repository.CurrencyRepository,
+	downloadUseCase *DownloadUseCase,
 ) *OrderUseCase {
 	return &OrderUseCase{
 		orderRepo:          orderRepo,
@@ -45,6 +47,7 @@ func NewOrderUseCase(
 		emailSvc:           emailSvc,
 		paymentTxnRepo:     paymentTxnRepo,
 		currencyRepo:       currencyRepo,
+		downloadUseCase:    downloadUseCase,
 	}
 }
 
@@ -187,8 +190,9 @@ func (uc *OrderUseCase) GetUserOrders(userID uint, offset, limit int) ([]*entity
 	return uc.orderRepo.GetByUser(userID, offset, limit)
 }
 
-func (uc *OrderUseCase) ListOrdersByStatus(status entity.OrderStatus, offset, limit int) ([]*entity.Order, error) {
-	return uc.orderRepo.ListByStatus(status, offset, limit)
+// ListOrdersByStatus lists the orders with a status whose custom fields match the filters
+func (uc *OrderUseCase) ListOrdersByStatus(status entity.OrderStatus, offset, limit int, customFields []entity.CustomFieldFilter) ([]*entity.Order, error) {
+	return uc.orderRepo.ListByStatus(status, offset, limit, customFields)
 }
 
 func (uc *OrderUseCase) FailOrder(order *entity.Order) error {
@@ -298,7 +302,7 @@ func (uc *OrderUseCase) CapturePayment(transactionID string, amount int64) error
 			txn.AddMetadata("remaining_amount", "0")
 		} else {
 			remainingAmount := order.FinalAmount - amount
-			txn.AddMetadata("remaining_amount", fmt.Sprintf("%.2f", money.FromCents(remainingAmount)))
+			txn.AddMetadata("remaining_amount", money.FormatMinor(remainingAmount, order.Currency, ""))
 		}
 
 		if err := uc.paymentTxnRepo.Create(txn); err != nil {
@@ -500,11 +504,11 @@ func (uc *OrderUseCase) RefundPayment(transactionID string, amount int64) error
 
 		// Record total refunded amount including this transaction
 		totalRefunded := totalRefundedSoFar + amount
-		txn.AddMetadata("total_refunded", fmt.Sprintf("%.2f", money.FromCents(totalRefunded)))
+		txn.AddMetadata("total_refunded", money.FormatMinor(totalRefunded, order.Currency, ""))
 
 		// Record remaining amount still available for refund
 		remainingAmount := max(order.FinalAmount-totalRefunded, 0)
-		txn.AddMetadata("remaining_available", fmt.Sprintf("%.2f", money.FromCents(remainingAmount)))
+		txn.AddMetadata("remaining_available", money.FormatMinor(remainingAmount, order.Currency, ""))
 
 		if err := uc.paymentTxnRepo.Create(txn); err != nil {
 			log.Printf("Failed to save refund transaction: %v\n", err)
@@ -546,9 +550,9 @@ func (uc *OrderUseCase) GetUserByID(id uint) (*entity.User, error) {
 	return uc.userRepo.GetByID(id)
 }
 
-// ListAllOrders lists all orders
-func (uc *OrderUseCase) ListAllOrders(offset, limit int) ([]*entity.Order, error) {
-	return uc.orderRepo.ListAll(offset, limit)
+// ListAllOrders lists all orders whose custom fields match the filters
+func (uc *OrderUseCase) ListAllOrders(offset, limit int, customFields []entity.CustomFieldFilter) ([]*entity.Order, error) {
+	return uc.orderRepo.ListAll(offset, limit, customFields)
 }
 
 // RecordPaymentTransaction records a payment transaction for an order
@@ -654,6 +658,11 @@ func (uc *OrderUseCase) UpdatePaymentStatus(input UpdatePaymentStatusInput) (*en
 		log.Printf("Warning: Failed to update stock for order %d: %v", order.ID, err)
 	}
 
+	// Grant the downloads of digital items once paid, so the confirmation email links them
+	if err := uc.LoadOrderDownloads(order); err != nil {
+		log.Printf("Warning: Failed to grant downloads for order %d: %v", order.ID, err)
+	}
+
 	// Send emails for payment status changes
 	if err := uc.handleEmailsForPaymentStatusChange(order, previousPaymentStatus, input.PaymentStatus); err != nil {
 		// Log the error but don't fail the status update since the payment status change was successful
@@ -663,6 +672,14 @@ func (uc *OrderUseCase) UpdatePaymentStatus(input UpdatePaymentStatusInput) (*en
 	return order, nil
 }
 
+// LoadOrderDownloads sets the downloads of the digital items of a paid order with signed links
+func (uc *OrderUseCase) LoadOrderDownloads(order *entity.Order) error {
+	if uc.downloadUseCase == nil {
+		return nil
+	}
+	return uc.downloadUseCase.LoadOrderDownloads(order)
+}
+
 // handleStockUpdatesForPaymentStatusChange handles stock updates when payment status changes
 func (uc *OrderUseCase) handleStockUpdatesForPaymentStatusChange(order *entity.Order, previousStatus, newStatus entity.PaymentStatus) error {
 	// Only handle stock changes for specific transitions
@@ -697,63 +714,68 @@ func (uc *OrderUseCase) handleStockUpdatesForPaymentStatusChange(order *entity.O
 	}
 }
 
-// decreaseStock decreases stock for all items in an order
+// decreaseStock decreases stock for all items in an order. Bundles decrease the stock of their components.
 func (uc *OrderUseCase) decreaseStock(order *entity.Order) error {
 	for _, item := range order.Items {
-		// Skip items without variant ID (shouldn't happen, but safety check)
-		if item.ProductVariantID == 0 {
-			continue
-		}
+		for _, unit := range item.StockUnits() {
+			// Skip items without variant ID (shouldn't happen, but safety check)
+			if unit.ProductVariantID == 0 {
+				continue
+			}
 
-		// Get the variant
-		variant, err := uc.productVariantRepo.GetByID(item.ProductVariantID)
-		if err != nil {
-			return fmt.Errorf("failed to get variant %d: %w", item.ProductVariantID, err)
-		}
+			// Get the variant
+			variant, err := uc.productVariantRepo.GetByID(unit.ProductVariantID)
+			if err != nil {
+				return fmt.Errorf("failed to get variant %d: %w", unit.ProductVariantID, err)
+			}
 
-		// Check if there's enough stock
-		if variant.Stock < item.Quantity {
-			return fmt.Errorf("insufficient stock for product %s (SKU: %s): available %d, required %d",
-				item.ProductName, item.SKU, variant.Stock, item.Quantity)
-		}
+			// Check if there's enough stock
+			if variant.Stock < unit.Quantity {
+				return fmt.Errorf("insufficient stock for product %s (SKU: %s): available %d, required %d",
+					item.ProductName, unit.SKU, variant.Stock, unit.Quantity)
+			}
 
-		// Update stock
-		changeAmount := -item.Quantity // Negative because we're decreasing
-		if err := variant.UpdateStock(changeAmount); err != nil {
-			return fmt.Errorf("failed to update stock for variant %d: %w", item.ProductVariantID, err)
-		}
+			// Update stock
+			changeAmount := -unit.Quantity // Negative because we're decreasing
+			if err := variant.UpdateStock(changeAmount); err != nil {
+				return fmt.Errorf("failed to update stock for variant %d: %w", unit.ProductVariantID, err)
+			}
 
-		// Save the updated variant
-		if err := uc.productVariantRepo.Update(variant); err != nil {
-			return fmt.Errorf("failed to save variant %d: %w", item.ProductVariantID, err)
+			// Save the updated variant
+			if err := uc.productVariantRepo.Update(variant); err != nil {
+				return fmt.Errorf("failed to save variant %d: %w", unit.ProductVariantID, err)
+			}
 		}
 	}
 	return nil
 }
 
-// increaseStock increases stock for all items in an order (for cancellations/refunds)
+// increaseStock increases stock for all items in an order (for cancellations/refunds).
+// Bundles restore the components recorded at time of order.
 func (uc *OrderUseCase) increaseStock(order *entity.Order) error {
 	for _, item := range order.Items {
-		// Skip items without variant ID (shouldn't happen, but safety check)
-		if item.ProductVariantID == 0 {
-			continue
-		}
+		for _, unit := range item.StockUnits() {
+			// Skip items without variant ID (shouldn't happen, but safety check)
+			if unit.ProductVariantID == 0 {
+				continue
+			}
 
-		// Get the variant
-		variant, err := uc.productVariantRepo.GetByID(item.ProductVariantID)
-		if err != nil {
-			return fmt.Errorf("failed to get variant %d: %w", item.ProductVariantID, err)
-		}
+			// Get the variant
+			variant, err := uc.productVariantRepo.GetByID(unit.ProductVariantID)
+			if err != nil {
+				return fmt.Errorf("failed to get variant %d: %w", unit.ProductVariantID, err)
+			}
 
-		// Update stock
-		changeAmount := item.Quantity // Positive because we're increasing
-		if err := variant.UpdateStock(changeAmount); err != nil {
-			return fmt.Errorf("failed to update stock for variant %d: %w", item.ProductVariantID, err)
-		}
+			// Update stock
+			changeAmount := unit.Quantity // Positive because we're increasing
+			if err := variant.UpdateStock(changeAmount); err != nil {
+				return fmt.Errorf("failed to update stock for variant %d: %w", unit.ProductVariantID, err)
+			}
 
-		// Save the updated variant
-		if err := uc.productVariantRepo.Update(variant); err != nil {
-			return fmt.Errorf("failed to save variant %d: %w", item.ProductVariantID, err)
+			// Save the updated variant
+			if err := uc.productVariantRepo.Update(variant); err != nil {
+				return fmt.Errorf("failed to save variant %d: %w", unit.ProductVariantID, err)
+			}
 		}
 	}
 	return nil
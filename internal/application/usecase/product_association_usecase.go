@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// frequentlyBoughtTogetherLimit is the number of products derived from orders
+const frequentlyBoughtTogetherLimit = 10
+
+// ProductAssociationUseCase implements the use cases of related, cross-sell, upsell and accessory products
+type ProductAssociationUseCase struct {
+	associationRepo repository.ProductAssociationRepository
+	productRepo     repository.ProductRepository
+	categoryRepo    repository.CategoryRepository
+	checkoutRepo    repository.CheckoutRepository
+	priceListRepo   repository.PriceListRepository
+}
+
+// NewProductAssociationUseCase creates a new ProductAssociationUseCase
+func NewProductAssociationUseCase(
+	associationRepo repository.ProductAssociationRepository,
+	productRepo repository.ProductRepository,
+	categoryRepo repository.CategoryRepository,
+	checkoutRepo repository.CheckoutRepository,
+	priceListRepo repository.PriceListRepository,
+) *ProductAssociationUseCase {
+	return &ProductAssociationUseCase{
+		associationRepo: associationRepo,
+		productRepo:     productRepo,
+		categoryRepo:    categoryRepo,
+		checkoutRepo:    checkoutRepo,
+		priceListRepo:   priceListRepo,
+	}
+}
+
+// ProductAssociations are the active products associated with a product or a checkout, by type
+type ProductAssociations struct {
+	Related                  []*entity.Product
+	CrossSell                []*entity.Product
+	Upsell                   []*entity.Product
+	Accessory                []*entity.Product
+	FrequentlyBoughtTogether []*entity.Product // Derived from orders when no cross-sells are maintained
+}
+
+// Products returns the products of all association types
+func (a *ProductAssociations) Products() []*entity.Product {
+	products := make([]*entity.Product, 0, len(a.Related)+len(a.CrossSell)+len(a.Upsell)+len(a.Accessory)+len(a.FrequentlyBoughtTogether))
+	products = append(products, a.Related...)
+	products = append(products, a.CrossSell...)
+	products = append(products, a.Upsell...)
+	products = append(products, a.Accessory...)
+	return append(products, a.FrequentlyBoughtTogether...)
+}
+
+// ListAssociations returns the associations of a product of all types (admin only)
+func (uc *ProductAssociationUseCase) ListAssociations(productID uint) ([]*entity.ProductAssociation, error) {
+	if _, err := uc.productRepo.GetByID(productID); err != nil {
+		return nil, err
+	}
+	return uc.associationRepo.ListByProducts([]uint{productID})
+}
+
+// SetAssociations replaces the products associated with a product with one type, in display order (admin only)
+func (uc *ProductAssociationUseCase) SetAssociations(productID uint, associationType entity.AssociationType, associatedProductIDs []uint) ([]*entity.ProductAssociation, error) {
+	if _, err := uc.productRepo.GetByID(productID); err != nil {
+		return nil, err
+	}
+
+	associations, err := entity.NewProductAssociations(productID, associationType, associatedProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inactive products can be associated, they are only hidden until activated
+	products, err := uc.productRepo.ListWithVariants("", "", associatedProductIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) != len(associatedProductIDs) {
+		for _, id := range associatedProductIDs {
+			if !slices.ContainsFunc(products, func(p *entity.Product) bool { return p.ID == id }) {
+				return nil, fmt.Errorf("associated product with ID %d not found", id)
+			}
+		}
+	}
+
+	if err := uc.associationRepo.Replace(productID, associationType, associations); err != nil {
+		return nil, err
+	}
+	return associations, nil
+}
+
+// GetProductAssociations returns the active products associated with a product, with the
+// prices of the customer's group when signed in
+func (uc *ProductAssociationUseCase) GetProductAssociations(productID, userID uint) (*ProductAssociations, error) {
+	if _, err := uc.productRepo.GetByID(productID); err != nil {
+		return nil, err
+	}
+	return uc.resolve([]uint{productID}, userID)
+}
+
+// GetCheckoutAssociations returns the active products associated with the contents of a checkout.
+// Products already in the checkout are left out; a missing checkout has no associations.
+func (uc *ProductAssociationUseCase) GetCheckoutAssociations(sessionID string, userID uint) (*ProductAssociations, error) {
+	if sessionID == "" {
+		return &ProductAssociations{}, nil
+	}
+	checkout, err := uc.checkoutRepo.GetBySessionID(sessionID)
+	if err != nil {
+		// No active checkout for the session
+		return &ProductAssociations{}, nil
+	}
+
+	var productIDs []uint
+	for _, item := range checkout.Items {
+		if !slices.Contains(productIDs, item.ProductID) {
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+	if len(productIDs) == 0 {
+		return &ProductAssociations{}, nil
+	}
+	return uc.resolve(productIDs, userID)
+}
+
+// resolve loads the products associated with the source products. Associations of several sources
+// are merged in the order of the sources, without duplicates or the sources themselves.
+func (uc *ProductAssociationUseCase) resolve(sourceIDs []uint, userID uint) (*ProductAssociations, error) {
+	associations, err := uc.associationRepo.ListByProducts(sourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	idsByType := make(map[entity.AssociationType][]uint)
+	for _, sourceID := range sourceIDs {
+		for _, association := range associations {
+			if association.ProductID != sourceID || slices.Contains(sourceIDs, association.AssociatedProductID) {
+				continue
+			}
+			ids := idsByType[association.Type]
+			if !slices.Contains(ids, association.AssociatedProductID) {
+				idsByType[association.Type] = append(ids, association.AssociatedProductID)
+			}
+		}
+	}
+
+	var frequentIDs []uint
+	if len(idsByType[entity.AssociationCrossSell]) == 0 {
+		frequentIDs, err = uc.associationRepo.FrequentlyBoughtTogether(sourceIDs, frequentlyBoughtTogetherLimit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	allIDs := slices.Clone(frequentIDs)
+	for _, ids := range idsByType {
+		allIDs = append(allIDs, ids...)
+	}
+	if len(allIDs) == 0 {
+		return &ProductAssociations{}, nil
+	}
+	products, err := uc.productRepo.ListWithVariants("", entity.SalesChannelWebshop, allIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := uc.categoryRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	categoriesByID := make(map[uint]*entity.Category, len(categories))
+	for _, category := range categories {
+		categoriesByID[category.ID] = category
+	}
+
+	productsByID := make(map[uint]*entity.Product, len(products))
+	priceList := resolveCustomerPriceList(uc.priceListRepo, userID)
+	for _, product := range products {
+		if category, ok := categoriesByID[product.CategoryID]; ok {
+			product.Category = *category
+		}
+		if priceList != nil {
+			product.ApplyPriceList(priceList)
+		}
+		productsByID[product.ID] = product
+	}
+
+	// Keep the display order, leaving out inactive and deleted products
+	inOrder := func(ids []uint) []*entity.Product {
+		result := make([]*entity.Product, 0, len(ids))
+		for _, id := range ids {
+			if product, ok := productsByID[id]; ok {
+				result = append(result, product)
+			}
+		}
+		return result
+	}
+
+	return &ProductAssociations{
+		Related:                  inOrder(idsByType[entity.AssociationRelated]),
+		CrossSell:                inOrder(idsByType[entity.AssociationCrossSell]),
+		Upsell:                   inOrder(idsByType[entity.AssociationUpsell]),
+		Accessory:                inOrder(idsByType[entity.AssociationAccessory]),
+		FrequentlyBoughtTogether: inOrder(frequentIDs),
+	}, nil
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestProductAssociationUseCase(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(currency).Error)
+	category := testutil.CreateTestCategory(t, db, 1)
+
+	productRepo := gorm.NewProductRepository(db)
+	createProduct := func(name, sku string) *entity.Product {
+		variant, err := entity.NewProductVariant(sku, 10, 2500, 0, entity.VariantAttributes{"size": "M"}, nil, true)
+		require.NoError(t, err)
+		product, err := entity.NewProduct(name, "", "USD", category.ID, nil, []*entity.ProductVariant{variant}, true)
+		require.NoError(t, err)
+		require.NoError(t, productRepo.Create(product))
+		return product
+	}
+	kettle := createProduct("Kettle", "KETTLE")
+	teapot := createProduct("Teapot", "TEAPOT")
+	cups := createProduct("Cups", "CUPS")
+	tea := createProduct("Tea", "TEA")
+	filters := createProduct("Filters", "FILTERS")
+	retired := createProduct("Retired Kettle", "RETIRED")
+	require.NoError(t, db.Model(retired).Update("active", false).Error)
+
+	orderNumber := 0
+	createOrder := func(status entity.PaymentStatus, products ...*entity.Product) {
+		orderNumber++
+		order := &entity.Order{
+			OrderNumber:   fmt.Sprintf("ORD-%d", orderNumber),
+			Currency:      "USD",
+			Status:        entity.OrderStatusPaid,
+			PaymentStatus: status,
+		}
+		for _, product := range products {
+			variant := product.Variants[0]
+			order.Items = append(order.Items, entity.OrderItem{
+				ProductID:        product.ID,
+				ProductVariantID: variant.ID,
+				Quantity:         1,
+				Price:            variant.Price,
+				Subtotal:         variant.Price,
+				ProductName:      product.Name,
+				SKU:              variant.SKU,
+			})
+		}
+		require.NoError(t, db.Create(order).Error)
+	}
+	createOrder(entity.PaymentStatusCaptured, kettle, tea, filters)
+	createOrder(entity.PaymentStatusAuthorized, kettle, tea)
+	createOrder(entity.PaymentStatusCaptured, teapot, cups)
+	// Unpaid orders are not counted
+	createOrder(entity.PaymentStatusPending, kettle, filters)
+	createOrder(entity.PaymentStatusFailed, kettle, filters)
+	createOrder(entity.PaymentStatusFailed, kettle, filters)
+
+	checkoutRepo := gorm.NewCheckoutRepository(db)
+	uc := NewProductAssociationUseCase(
+		gorm.NewProductAssociationRepository(db),
+		productRepo,
+		gorm.NewCategoryRepository(db),
+		checkoutRepo,
+		gorm.NewPriceListRepository(db),
+	)
+
+	productIDs := func(products []*entity.Product) []uint {
+		ids := make([]uint, len(products))
+		for i, product := range products {
+			ids[i] = product.ID
+		}
+		return ids
+	}
+
+	t.Run("Admins maintain associations by type", func(t *testing.T) {
+		_, err := uc.SetAssociations(kettle.ID, entity.AssociationRelated, []uint{teapot.ID, 999})
+		assert.ErrorContains(t, err, "associated product with ID 999 not found")
+		_, err = uc.SetAssociations(999, entity.AssociationRelated, []uint{teapot.ID})
+		assert.ErrorContains(t, err, "not found")
+		_, err = uc.SetAssociations(kettle.ID, "similar", []uint{teapot.ID})
+		assert.ErrorContains(t, err, "invalid association type")
+
+		associations, err := uc.SetAssociations(kettle.ID, entity.AssociationRelated, []uint{retired.ID, teapot.ID})
+		require.NoError(t, err)
+		assert.Len(t, associations, 2)
+		_, err = uc.SetAssociations(kettle.ID, entity.AssociationAccessory, []uint{filters.ID, cups.ID})
+		require.NoError(t, err)
+
+		// Replacing one type leaves the others
+		_, err = uc.SetAssociations(kettle.ID, entity.AssociationAccessory, []uint{cups.ID})
+		require.NoError(t, err)
+
+		associations, err = uc.ListAssociations(kettle.ID)
+		require.NoError(t, err)
+		require.Len(t, associations, 3)
+	})
+
+	t.Run("Product associations hide inactive products and keep the display order", func(t *testing.T) {
+		result, err := uc.GetProductAssociations(kettle.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{teapot.ID}, productIDs(result.Related))
+		assert.Equal(t, []uint{cups.ID}, productIDs(result.Accessory))
+		assert.Equal(t, category.Name, result.Related[0].Category.Name)
+		assert.Empty(t, result.CrossSell)
+		assert.Empty(t, result.Upsell)
+	})
+
+	t.Run("Frequently bought together is derived from paid orders", func(t *testing.T) {
+		result, err := uc.GetProductAssociations(kettle.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{tea.ID, filters.ID}, productIDs(result.FrequentlyBoughtTogether))
+
+		result, err = uc.GetProductAssociations(cups.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{teapot.ID}, productIDs(result.FrequentlyBoughtTogether))
+
+		// Maintained cross-sells replace the computed products
+		_, err = uc.SetAssociations(kettle.ID, entity.AssociationCrossSell, []uint{cups.ID})
+		require.NoError(t, err)
+		result, err = uc.GetProductAssociations(kettle.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{cups.ID}, productIDs(result.CrossSell))
+		assert.Empty(t, result.FrequentlyBoughtTogether)
+	})
+
+	t.Run("Checkout associations merge the items and leave them out", func(t *testing.T) {
+		_, err := uc.SetAssociations(teapot.ID, entity.AssociationRelated, []uint{kettle.ID, tea.ID})
+		require.NoError(t, err)
+
+		checkout, err := entity.NewCheckout("session-1", "USD")
+		require.NoError(t, err)
+		require.NoError(t, checkout.AddItem(kettle.ID, kettle.Variants[0].ID, 1, 2500, 0, kettle.Name, "", "KETTLE"))
+		require.NoError(t, checkout.AddItem(teapot.ID, teapot.Variants[0].ID, 1, 2500, 0, teapot.Name, "", "TEAPOT"))
+		require.NoError(t, checkoutRepo.Create(checkout))
+
+		result, err := uc.GetCheckoutAssociations("session-1", 0)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{tea.ID}, productIDs(result.Related))
+		assert.Equal(t, []uint{cups.ID}, productIDs(result.Accessory))
+		assert.Equal(t, []uint{cups.ID}, productIDs(result.CrossSell))
+
+		result, err = uc.GetCheckoutAssociations("unknown", 0)
+		require.NoError(t, err)
+		assert.Empty(t, result.Related)
+	})
+
+	t.Run("Deleting a product removes its associations", func(t *testing.T) {
+		require.NoError(t, productRepo.Delete(retired.ID))
+		associations, err := uc.ListAssociations(kettle.ID)
+		require.NoError(t, err)
+		for _, association := range associations {
+			assert.NotEqual(t, retired.ID, association.AssociatedProductID)
+		}
+	})
+}
@@ -3,6 +3,9 @@ package usecase
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/money"
@@ -17,6 +20,8 @@ type ProductUseCase struct {
 	currencyRepo       repository.CurrencyRepository
 	orderRepo          repository.OrderRepository
 	checkoutRepo       repository.CheckoutRepository
+	priceListRepo      repository.PriceListRepository
+	searchRepo         repository.ProductSearchRepository
 	defaultCurrency    *entity.Currency
 }
 
@@ -28,6 +33,8 @@ func NewProductUseCase(
 	currencyRepo repository.CurrencyRepository,
 	orderRepo repository.OrderRepository,
 	checkoutRepo repository.CheckoutRepository,
+	priceListRepo repository.PriceListRepository,
+	searchRepo repository.ProductSearchRepository,
 ) *ProductUseCase {
 	uc := &ProductUseCase{
 		productRepo:        productRepo,
@@ -36,6 +43,8 @@ func NewProductUseCase(
 		currencyRepo:       currencyRepo,
 		orderRepo:          orderRepo,
 		checkoutRepo:       checkoutRepo,
+		priceListRepo:      priceListRepo,
+		searchRepo:         searchRepo,
 	}
 
 	// Try to get default currency but don't fail if it doesn't exist
@@ -56,18 +65,71 @@ type VariantInput struct {
 	Images     []string
 	Attributes entity.VariantAttributes
 	Price      int64
+	Prices     map[string]int64     // Explicit prices in other currencies, nil leaves them unchanged
+	Sale       *ScheduledPriceInput // nil leaves the sale unchanged
+	CompareAt  *ScheduledPriceInput // nil leaves the compare-at price unchanged
+	IsDigital  *bool                // nil leaves the digital flag unchanged
 	IsDefault  bool
 }
 
+// ScheduledPriceInput contains a price in minor units of the product currency with an optional
+// time window. A nil price removes the scheduled price.
+type ScheduledPriceInput struct {
+	Price    *int64
+	StartsAt *time.Time
+	EndsAt   *time.Time
+}
+
+// applyVariantSettings applies the optional sale and compare-at prices and digital flag of the input
+func applyVariantSettings(variant *entity.ProductVariant, input VariantInput) (bool, error) {
+	updated := false
+	if input.IsDigital != nil && variant.IsDigital != *input.IsDigital {
+		variant.IsDigital = *input.IsDigital
+		updated = true
+	}
+	if input.Sale != nil {
+		if err := variant.SetSalePrice(input.Sale.Price, input.Sale.StartsAt, input.Sale.EndsAt); err != nil {
+			return false, err
+		}
+		updated = true
+	}
+	if input.CompareAt != nil {
+		if err := variant.SetCompareAtPrice(input.CompareAt.Price, input.CompareAt.StartsAt, input.CompareAt.EndsAt); err != nil {
+			return false, err
+		}
+		updated = true
+	}
+	return updated, nil
+}
+
 // CreateProductInput contains the data needed to create a product
 type CreateProductInput struct {
+	Type        entity.ProductType // Standard when empty
 	Name        string
+	Slug        string // Generated from the name when empty
 	Description string
+	SEO         entity.SEOMetadata
 	Currency    string
 	CategoryID  uint
 	Images      []string
+	Options     []ProductOptionInput
 	Variants    []CreateVariantInput
 	Active      bool
+	Visibility  *ProductVisibilityInput // Visible in all channels without a publishing window when nil
+}
+
+// ProductVisibilityInput contains the optional publishing window of a product and the sales
+// channels showing it. No channels make the product visible in all channels.
+type ProductVisibilityInput struct {
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+	Channels    []entity.SalesChannel
+}
+
+// ProductOptionInput contains the name and ordered allowed values of a product option
+type ProductOptionInput struct {
+	Name   string
+	Values []string
 }
 
 // CreateVariantInput contains the data needed to create a product variant
@@ -89,6 +151,14 @@ func (uc *ProductUseCase) CreateProduct(input CreateProductInput) (*entity.Produ
 		return nil, errors.New("invalid currency code: " + input.Currency)
 	}
 
+	slug, err := entity.NormalizeSlug(input.Slug)
+	if err != nil {
+		return nil, err
+	}
+	if err := input.SEO.Validate(); err != nil {
+		return nil, err
+	}
+
 	variants := make([]*entity.ProductVariant, 0, len(input.Variants))
 
 	// If product has variants, create them
@@ -132,6 +202,15 @@ func (uc *ProductUseCase) CreateProduct(input CreateProductInput) (*entity.Produ
 				return nil, err
 			}
 
+			if variantInput.Prices != nil {
+				if err := variant.SetPrices(variantInput.Prices); err != nil {
+					return nil, err
+				}
+			}
+			if _, err := applyVariantSettings(variant, variantInput.VariantInput); err != nil {
+				return nil, err
+			}
+
 			variants = append(variants, variant)
 		}
 	}
@@ -149,6 +228,29 @@ func (uc *ProductUseCase) CreateProduct(input CreateProductInput) (*entity.Produ
 	if err != nil {
 		return nil, err
 	}
+	product.Slug = slug
+	product.SEO = input.SEO
+	if input.Visibility != nil {
+		if err := product.SetVisibility(input.Visibility.PublishAt, input.Visibility.UnpublishAt, input.Visibility.Channels); err != nil {
+			return nil, err
+		}
+	}
+	if input.Type != "" {
+		if err := product.SetType(input.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate variant attributes against the option definitions
+	if len(input.Options) > 0 {
+		options, err := newProductOptions(input.Options)
+		if err != nil {
+			return nil, err
+		}
+		if err := product.SetOptions(options); err != nil {
+			return nil, err
+		}
+	}
 
 	// Save product
 	if err := uc.productRepo.Create(product); err != nil {
@@ -169,14 +271,69 @@ func (uc *ProductUseCase) GetProductByID(id uint) (*entity.Product, error) {
 	return product, nil
 }
 
+// GetProductBySlugForCustomer retrieves a product by its current or a former slug, priced like
+// GetProductForCustomer. The slug of the result differs from the given slug when it is a former slug.
+func (uc *ProductUseCase) GetProductBySlugForCustomer(slug, currency string, userID uint, channel entity.SalesChannel) (*entity.Product, error) {
+	product, err := uc.productRepo.GetBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	if currency == "" || currency == product.Currency {
+		if channel != "" && !product.IsVisible(channel, time.Now()) {
+			return nil, fmt.Errorf("product with ID %d not found", product.ID)
+		}
+		product.ApplyPriceList(resolveCustomerPriceList(uc.priceListRepo, userID))
+		return product, nil
+	}
+
+	return uc.GetProductForCustomer(product.ID, currency, userID, channel)
+}
+
+// GetProductByIDInCurrency retrieves a product with its prices expressed in the given currency
+func (uc *ProductUseCase) GetProductByIDInCurrency(id uint, currency string) (*entity.Product, error) {
+	if currency == "" {
+		return uc.GetProductByID(id)
+	}
+
+	targetCurrency, err := uc.currencyRepo.GetByCode(currency)
+	if err != nil {
+		return nil, errors.New("invalid currency code: " + currency)
+	}
+	if !targetCurrency.IsEnabled {
+		return nil, fmt.Errorf("currency %s is not enabled", currency)
+	}
+
+	return uc.productRepo.GetByIDAndCurrency(id, targetCurrency.Code)
+}
+
+// GetProductForCustomer retrieves a product priced in the given currency and with the
+// prices of the customer's group price list applied. The result must not be persisted.
+// With a sales channel, products that are not visible in the channel are not found.
+func (uc *ProductUseCase) GetProductForCustomer(id uint, currency string, userID uint, channel entity.SalesChannel) (*entity.Product, error) {
+	product, err := uc.GetProductByIDInCurrency(id, currency)
+	if err != nil {
+		return nil, err
+	}
+	if channel != "" && !product.IsVisible(channel, time.Now()) {
+		return nil, fmt.Errorf("product with ID %d not found", id)
+	}
+
+	product.ApplyPriceList(resolveCustomerPriceList(uc.priceListRepo, userID))
+	return product, nil
+}
+
 // UpdateProductInput contains the data needed to update a product (prices in dollars)
 type UpdateProductInput struct {
+	Type        *entity.ProductType
 	Name        *string
+	Slug        *string // An empty slug is generated from the name again
 	Description *string
+	SEO         *entity.SEOMetadata
 	Currency    *string
 	CategoryID  *uint
 	Images      *[]string
 	Active      *bool
+	Visibility  *ProductVisibilityInput // Replaces the publishing window and channels, nil leaves them unchanged
 	Variants    *[]UpdateVariantInput
 }
 
@@ -207,6 +364,37 @@ func (uc *ProductUseCase) UpdateProduct(id uint, input UpdateProductInput) (*ent
 	// Update basic product fields
 	updated := product.Update(input.Name, input.Description, input.Currency, input.Images, input.Active, input.CategoryID)
 
+	// A changed slug is saved with the previous slug as a redirect
+	if input.Slug != nil {
+		slug, err := entity.NormalizeSlug(*input.Slug)
+		if err != nil {
+			return nil, err
+		}
+		if slug != product.Slug {
+			product.Slug = slug
+			updated = true
+		}
+	}
+	if input.Type != nil && *input.Type != product.Type {
+		if err := product.SetType(*input.Type); err != nil {
+			return nil, err
+		}
+		updated = true
+	}
+	if input.SEO != nil && *input.SEO != product.SEO {
+		if err := input.SEO.Validate(); err != nil {
+			return nil, err
+		}
+		product.SEO = *input.SEO
+		updated = true
+	}
+	if input.Visibility != nil {
+		if err := product.SetVisibility(input.Visibility.PublishAt, input.Visibility.UnpublishAt, input.Visibility.Channels); err != nil {
+			return nil, err
+		}
+		updated = true
+	}
+
 	// Handle variant updates if provided
 	if input.Variants != nil {
 		for _, variantUpdate := range *input.Variants {
@@ -248,6 +436,17 @@ func (uc *ProductUseCase) UpdateProduct(id uint, input UpdateProductInput) (*ent
 				if err != nil {
 					return nil, fmt.Errorf("failed to update variant: %w", err)
 				}
+				if variantUpdate.Prices != nil {
+					if err := targetVariant.SetPrices(variantUpdate.Prices); err != nil {
+						return nil, fmt.Errorf("failed to update variant prices: %w", err)
+					}
+					variantUpdated = true
+				}
+				if settingsUpdated, err := applyVariantSettings(targetVariant, variantUpdate.VariantInput); err != nil {
+					return nil, fmt.Errorf("failed to update variant prices: %w", err)
+				} else if settingsUpdated {
+					variantUpdated = true
+				}
 				if variantUpdated {
 					updated = true
 				}
@@ -276,6 +475,15 @@ func (uc *ProductUseCase) UpdateProduct(id uint, input UpdateProductInput) (*ent
 						return nil, fmt.Errorf("failed to create variant: %w", err)
 					}
 
+					if variantUpdate.Prices != nil {
+						if err := newVariant.SetPrices(variantUpdate.Prices); err != nil {
+							return nil, fmt.Errorf("failed to set variant prices: %w", err)
+						}
+					}
+					if _, err := applyVariantSettings(newVariant, variantUpdate.VariantInput); err != nil {
+						return nil, fmt.Errorf("failed to set variant prices: %w", err)
+					}
+
 					err = product.AddVariant(newVariant)
 					if err != nil {
 						return nil, fmt.Errorf("failed to add variant to product: %w", err)
@@ -290,6 +498,10 @@ func (uc *ProductUseCase) UpdateProduct(id uint, input UpdateProductInput) (*ent
 		return product, nil // No changes to update
 	}
 
+	if err := product.ValidateVariantOptions(); err != nil {
+		return nil, err
+	}
+
 	// Update product in repository
 	if err := uc.productRepo.Update(product); err != nil {
 		return nil, err
@@ -331,6 +543,15 @@ func (uc *ProductUseCase) UpdateVariant(productId, variantId uint, input UpdateV
 		return nil, fmt.Errorf("failed to update variant: %w", err)
 	}
 
+	if input.Prices != nil {
+		if err := variant.SetPrices(input.Prices); err != nil {
+			return nil, fmt.Errorf("failed to update variant prices: %w", err)
+		}
+	}
+	if _, err := applyVariantSettings(variant, input.VariantInput); err != nil {
+		return nil, fmt.Errorf("failed to update variant prices: %w", err)
+	}
+
 	// Handle default status if changed
 	if updated && input.IsDefault != variant.IsDefault {
 		// If setting this variant as default, unset any other default variants
@@ -343,6 +564,10 @@ func (uc *ProductUseCase) UpdateVariant(productId, variantId uint, input UpdateV
 		}
 	}
 
+	if err := product.ValidateVariantOptions(); err != nil {
+		return nil, err
+	}
+
 	// Update variant in repository
 	if err := uc.productRepo.Update(product); err != nil {
 		return nil, err
@@ -372,6 +597,15 @@ func (uc *ProductUseCase) AddVariant(productID uint, input CreateVariantInput) (
 		return nil, err
 	}
 
+	if input.Prices != nil {
+		if err := variant.SetPrices(input.Prices); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := applyVariantSettings(variant, input.VariantInput); err != nil {
+		return nil, err
+	}
+
 	err = product.AddVariant(variant)
 	if err != nil {
 		return nil, err
@@ -387,6 +621,10 @@ func (uc *ProductUseCase) AddVariant(productID uint, input CreateVariantInput) (
 		}
 	}
 
+	if err := product.ValidateVariantOptions(); err != nil {
+		return nil, err
+	}
+
 	// Update the product to persist the recalculated stock
 	if err := uc.productRepo.Update(product); err != nil {
 		return nil, err
@@ -395,6 +633,178 @@ func (uc *ProductUseCase) AddVariant(productID uint, input CreateVariantInput) (
 	return variant, nil
 }
 
+// SetProductOptions replaces the option definitions of a product (admin only).
+// Existing variants with attributes must be valid combinations of the new options.
+func (uc *ProductUseCase) SetProductOptions(productID uint, input []ProductOptionInput) (*entity.Product, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := newProductOptions(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := product.SetOptions(options); err != nil {
+		return nil, err
+	}
+
+	if err := uc.productRepo.SetOptions(productID, options); err != nil {
+		return nil, err
+	}
+
+	// Persist attributes rewritten to the exact option names and values
+	if len(options) > 0 {
+		if err := uc.productRepo.Update(product); err != nil {
+			return nil, err
+		}
+	}
+
+	return product, nil
+}
+
+// newProductOptions creates product options from input
+func newProductOptions(input []ProductOptionInput) ([]*entity.ProductOption, error) {
+	options := make([]*entity.ProductOption, 0, len(input))
+	for _, optionInput := range input {
+		option, err := entity.NewProductOption(optionInput.Name, optionInput.Values)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, option)
+	}
+	return options, nil
+}
+
+// GenerateVariantsInput contains the defaults of generated variants. Prices are in minor units
+// of the product currency; a zero price uses the price of the default variant.
+type GenerateVariantsInput struct {
+	SKUPattern string
+	Price      int64
+	Stock      int
+	Weight     float64
+}
+
+// GenerateVariants creates a variant for every option combination the product does not have yet (admin only)
+func (uc *ProductUseCase) GenerateVariants(productID uint, input GenerateVariantsInput) ([]*entity.ProductVariant, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(product.Options) == 0 {
+		return nil, errors.New("product has no options to generate variants from")
+	}
+
+	pattern := strings.TrimSpace(input.SKUPattern)
+	if pattern == "" {
+		pattern = product.DefaultSKUPattern()
+	}
+
+	price := input.Price
+	if price == 0 {
+		if defaultVariant := product.GetDefaultVariant(); defaultVariant != nil {
+			price = defaultVariant.Price
+		}
+	}
+
+	combinations := product.MissingOptionCombinations()
+	variants := make([]*entity.ProductVariant, 0, len(combinations))
+	skus := make(map[string]bool, len(combinations))
+	for _, attributes := range combinations {
+		sku, err := product.FormatVariantSKU(pattern, attributes)
+		if err != nil {
+			return nil, err
+		}
+		if skus[sku] {
+			return nil, fmt.Errorf("SKU pattern produces duplicate SKU %s", sku)
+		}
+		skus[sku] = true
+		if existing, err := uc.productVariantRepo.GetBySKU(sku); err == nil && existing != nil {
+			return nil, fmt.Errorf("variant with SKU %s already exists", sku)
+		}
+
+		variant, err := entity.NewProductVariant(sku, input.Stock, price, input.Weight, attributes, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		variant.ProductID = product.ID
+		variants = append(variants, variant)
+	}
+
+	if err := uc.productVariantRepo.BatchCreate(variants); err != nil {
+		return nil, fmt.Errorf("failed to create variants: %w", err)
+	}
+
+	return variants, nil
+}
+
+// BundleComponentInput contains a component variant of a bundle with the quantity per bundle
+type BundleComponentInput struct {
+	VariantID uint
+	Quantity  int
+}
+
+// SetBundleComponents replaces the components of a variant of a bundle product (admin only).
+// The bundle takes its stock from the components; no components make it a regular variant again.
+func (uc *ProductUseCase) SetBundleComponents(productID, variantID uint, input []BundleComponentInput) (*entity.ProductVariant, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsBundle() && len(input) > 0 {
+		return nil, fmt.Errorf("product %d is not a bundle", productID)
+	}
+
+	variant := product.GetVariantByID(variantID)
+	if variant == nil {
+		return nil, fmt.Errorf("variant with ID %d not found", variantID)
+	}
+
+	if len(input) > 0 {
+		isComponent, err := uc.productVariantRepo.IsBundleComponent(variant.ID)
+		if err != nil {
+			return nil, err
+		}
+		if isComponent {
+			return nil, fmt.Errorf("variant %s is a component of a bundle and cannot be a bundle itself", variant.SKU)
+		}
+	}
+
+	components := make([]*entity.BundleComponent, 0, len(input))
+	for _, componentInput := range input {
+		component, err := entity.NewBundleComponent(componentInput.VariantID, componentInput.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if component.ComponentVariant, err = uc.productVariantRepo.GetByID(componentInput.VariantID); err != nil {
+			return nil, err
+		}
+		components = append(components, component)
+	}
+
+	if err := variant.SetComponents(components); err != nil {
+		return nil, err
+	}
+	if err := uc.productVariantRepo.SetComponents(variant.ID, components); err != nil {
+		return nil, err
+	}
+
+	return uc.productVariantRepo.GetByID(variant.ID)
+}
+
+// checkNotBundleComponent returns an error when the variant is a component of a bundle
+func (uc *ProductUseCase) checkNotBundleComponent(variant *entity.ProductVariant) error {
+	isComponent, err := uc.productVariantRepo.IsBundleComponent(variant.ID)
+	if err != nil {
+		return err
+	}
+	if isComponent {
+		return fmt.Errorf("cannot delete variant %s that is a component of a bundle", variant.SKU)
+	}
+	return nil
+}
+
 // DeleteVariant deletes a product variant (admin only)
 func (uc *ProductUseCase) DeleteVariant(productID, variantID uint) error {
 	product, err := uc.productRepo.GetByID(productID)
@@ -413,6 +823,10 @@ func (uc *ProductUseCase) DeleteVariant(productID, variantID uint) error {
 		return errors.New("cannot delete the last variant of a product")
 	}
 
+	if err := uc.checkNotBundleComponent(variant); err != nil {
+		return err
+	}
+
 	// TODO: Add checks for orders and checkouts with this specific variant
 	// For now, we'll check at the product level which is safer
 	hasOrders, err := uc.orderRepo.HasOrdersWithProduct(productID)
@@ -467,6 +881,17 @@ func (uc *ProductUseCase) DeleteProduct(id uint) error {
 		return errors.New("cannot delete product that has existing orders")
 	}
 
+	// Bundles keep their components, so those cannot be deleted
+	variants, err := uc.productVariantRepo.GetByProduct(id)
+	if err != nil {
+		return err
+	}
+	for _, variant := range variants {
+		if err := uc.checkNotBundleComponent(variant); err != nil {
+			return err
+		}
+	}
+
 	// Check if product has any active checkouts
 	hasActiveCheckouts, err := uc.checkoutRepo.HasActiveCheckoutsWithProduct(id)
 	if err != nil {
@@ -482,20 +907,63 @@ func (uc *ProductUseCase) DeleteProduct(id uint) error {
 
 // SearchProductsInput contains the data needed to search for products (prices in dollars)
 type SearchProductsInput struct {
-	Query        string  `json:"query"`
-	CurrencyCode string  `json:"currency_code"` // Optional currency code for prices
-	MaxPrice     float64 `json:"max_price"`     // Price in dollars
-	MinPrice     float64 `json:"min_price"`     // Price in dollars
-	CategoryID   uint    `json:"category_id"`
-	Offset       uint    `json:"offset"`
-	Limit        uint    `json:"limit"`
-	ActiveOnly   bool    `json:"active_only"` // Whether to filter active products only
+	Query        string              `json:"query"`
+	CurrencyCode string              `json:"currency_code"` // Optional currency code for prices
+	MaxPrice     float64             `json:"max_price"`     // Price in dollars
+	MinPrice     float64             `json:"min_price"`     // Price in dollars
+	CategoryID   uint                `json:"category_id"`
+	Offset       uint                `json:"offset"`
+	Limit        uint                `json:"limit"`
+	ActiveOnly   bool                `json:"active_only"` // Whether to list only the products visible in the sales channel now
+	Channel      entity.SalesChannel `json:"channel"`     // Sales channel of ActiveOnly, the webshop when empty
+	UserID       uint                `json:"-"`           // Signed-in customer whose group prices apply
+
+	// Custom field filters, used by the admin product list
+	CustomFields []entity.CustomFieldFilter `json:"-"`
+
+	// Faceted filters, only applied by SearchProducts
+	Attributes           map[string][]string `json:"attributes"`            // Variant attribute name to accepted values
+	InStockOnly          bool                `json:"in_stock_only"`         // Only products with a matching variant in stock
+	IncludeSubcategories bool                `json:"include_subcategories"` // Whether CategoryID also matches its descendants
+	Sort                 ProductSort         `json:"sort"`                  // Order of the results, relevance by default
+}
+
+// visibleIn returns the sales channel the products must be visible in, or no channel to list all products
+func (input SearchProductsInput) visibleIn() entity.SalesChannel {
+	if !input.ActiveOnly {
+		return ""
+	}
+	if input.Channel == "" {
+		return entity.SalesChannelWebshop
+	}
+	return input.Channel
 }
 
-// ListProducts lists all products with pagination and returns total count
+// ProductSort is the order of product search results
+type ProductSort string
+
+const (
+	ProductSortRelevance ProductSort = "relevance" // Search relevance, or the catalog order without a query
+	ProductSortRating    ProductSort = "rating"    // Highest rated first, more reviews first on equal ratings
+)
+
+// ProductSearchResult is a page of products with the facet counts of all matching products
+type ProductSearchResult struct {
+	Products      []*entity.Product
+	Total         int
+	Facets        *entity.ProductFacets
+	FacetCurrency string // Currency of the price buckets
+}
+
+// ListProducts lists all products with pagination and returns total count.
+// When a query is given, products are found with full-text search and sorted by relevance.
 func (uc *ProductUseCase) ListProducts(input SearchProductsInput) ([]*entity.Product, int, error) {
-	minPriceCents := money.ToCents(input.MinPrice)
-	maxPriceCents := money.ToCents(input.MaxPrice)
+	minPriceCents := money.ToMinor(input.MinPrice, input.CurrencyCode)
+	maxPriceCents := money.ToMinor(input.MaxPrice, input.CurrencyCode)
+
+	if strings.TrimSpace(input.Query) != "" {
+		return uc.searchProducts(input, minPriceCents, maxPriceCents)
+	}
 
 	products, err := uc.productRepo.List(
 		input.Query,
@@ -505,7 +973,8 @@ func (uc *ProductUseCase) ListProducts(input SearchProductsInput) ([]*entity.Pro
 		input.Limit,
 		minPriceCents, // Convert to cents
 		maxPriceCents, // Convert to cents
-		input.ActiveOnly,
+		input.visibleIn(),
+		input.CustomFields,
 	)
 	if err != nil {
 		return nil, 0, err
@@ -517,15 +986,183 @@ func (uc *ProductUseCase) ListProducts(input SearchProductsInput) ([]*entity.Pro
 		input.CategoryID,
 		minPriceCents, // Pass cents
 		maxPriceCents, // Pass cents
-		input.ActiveOnly,
+		input.visibleIn(),
+		input.CustomFields,
 	)
 	if err != nil {
 		return products, 0, err
 	}
 
+	if priceList := resolveCustomerPriceList(uc.priceListRepo, input.UserID); priceList != nil {
+		for _, product := range products {
+			product.ApplyPriceList(priceList)
+		}
+	}
+
 	return products, total, nil
 }
 
+// searchProducts runs a full-text search and loads the matching products in relevance order
+func (uc *ProductUseCase) searchProducts(input SearchProductsInput, minPriceCents, maxPriceCents int64) ([]*entity.Product, int, error) {
+	hits, total, err := uc.searchRepo.Search(repository.ProductSearchQuery{
+		Query:         input.Query,
+		Currency:      input.CurrencyCode,
+		CategoryID:    input.CategoryID,
+		MinPriceCents: minPriceCents,
+		MaxPriceCents: maxPriceCents,
+		ActiveOnly:    input.ActiveOnly,
+		Channel:       input.Channel,
+		CustomFields:  input.CustomFields,
+		Offset:        input.Offset,
+		Limit:         input.Limit,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	priceList := resolveCustomerPriceList(uc.priceListRepo, input.UserID)
+
+	products := make([]*entity.Product, 0, len(hits))
+	for _, hit := range hits {
+		product, err := uc.productRepo.GetByID(hit.ProductID)
+		if err != nil {
+			return nil, 0, err
+		}
+		product.SearchRank = hit.Rank
+		product.SearchHighlights = hit.Highlights
+		if priceList != nil {
+			product.ApplyPriceList(priceList)
+		}
+		products = append(products, product)
+	}
+
+	return products, total, nil
+}
+
+// SearchProducts finds products with full-text search and faceted filters on variant attributes,
+// price, stock and the category tree. The result carries facet counts for rendering filter options.
+func (uc *ProductUseCase) SearchProducts(input SearchProductsInput) (*ProductSearchResult, error) {
+	if input.Sort != "" && input.Sort != ProductSortRelevance && input.Sort != ProductSortRating {
+		return nil, fmt.Errorf("invalid search sort %q", input.Sort)
+	}
+
+	// Without a query all products are candidates, otherwise the search hits in relevance order
+	var candidateIDs []uint
+	hitsByID := make(map[uint]repository.ProductSearchHit)
+	if strings.TrimSpace(input.Query) != "" {
+		hits, _, err := uc.searchRepo.Search(repository.ProductSearchQuery{
+			Query:      input.Query,
+			Currency:   input.CurrencyCode,
+			ActiveOnly: input.ActiveOnly,
+			Channel:    input.Channel,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		candidateIDs = make([]uint, len(hits))
+		for i, hit := range hits {
+			candidateIDs[i] = hit.ProductID
+			hitsByID[hit.ProductID] = hit
+		}
+	}
+
+	candidates, err := uc.productRepo.ListWithVariants(input.CurrencyCode, input.visibleIn(), candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	if candidateIDs != nil {
+		rank := make(map[uint]int, len(candidateIDs))
+		for i, id := range candidateIDs {
+			rank[id] = i
+		}
+		sort.SliceStable(candidates, func(i, j int) bool { return rank[candidates[i].ID] < rank[candidates[j].ID] })
+	}
+
+	categories, err := uc.categoryRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	currency := input.CurrencyCode
+	if currency == "" && uc.defaultCurrency != nil {
+		currency = uc.defaultCurrency.Code
+	}
+
+	filter := entity.ProductFilter{
+		Attributes:  input.Attributes,
+		MinPrice:    money.ToMinor(input.MinPrice, currency),
+		MaxPrice:    money.ToMinor(input.MaxPrice, currency),
+		InStockOnly: input.InStockOnly,
+	}
+	if input.CategoryID > 0 {
+		filter.CategoryIDs = []uint{input.CategoryID}
+		if input.IncludeSubcategories {
+			filter.CategoryIDs = entity.CategoryDescendantIDs(categories, input.CategoryID)
+		}
+	}
+
+	matched, facets := entity.FilterProducts(candidates, filter, categories, time.Now())
+	if input.Sort == ProductSortRating {
+		sort.SliceStable(matched, func(i, j int) bool {
+			if matched[i].RatingAverage != matched[j].RatingAverage {
+				return matched[i].RatingAverage > matched[j].RatingAverage
+			}
+			return matched[i].ReviewCount > matched[j].ReviewCount
+		})
+	}
+
+	// Page the matches and complete them with their category, search details and customer prices
+	start := min(int(input.Offset), len(matched))
+	end := len(matched)
+	if input.Limit > 0 {
+		end = min(start+int(input.Limit), len(matched))
+	}
+
+	categoriesByID := make(map[uint]*entity.Category, len(categories))
+	for _, category := range categories {
+		categoriesByID[category.ID] = category
+	}
+	priceList := resolveCustomerPriceList(uc.priceListRepo, input.UserID)
+
+	products := matched[start:end]
+	for _, product := range products {
+		if category, ok := categoriesByID[product.CategoryID]; ok {
+			product.Category = *category
+		}
+		if hit, ok := hitsByID[product.ID]; ok {
+			product.SearchRank = hit.Rank
+			product.SearchHighlights = hit.Highlights
+		}
+		if priceList != nil {
+			product.ApplyPriceList(priceList)
+		}
+	}
+
+	return &ProductSearchResult{
+		Products:      products,
+		Total:         len(matched),
+		Facets:        facets,
+		FacetCurrency: currency,
+	}, nil
+}
+
+// RebuildSearchIndex reindexes all products for full-text search (admin only)
+func (uc *ProductUseCase) RebuildSearchIndex() (int, error) {
+	return uc.searchRepo.Rebuild()
+}
+
+// EnsureSearchIndex rebuilds the search index when products were written without
+// going through the product repository, e.g. by seeding or a data import.
+// It returns the number of indexed products, or zero when the index was up to date.
+func (uc *ProductUseCase) EnsureSearchIndex() (int, error) {
+	stale, err := uc.searchRepo.IsStale()
+	if err != nil || !stale {
+		return 0, err
+	}
+	return uc.searchRepo.Rebuild()
+}
+
 // ListCategories lists all product categories
 func (uc *ProductUseCase) ListCategories() ([]*entity.Category, error) {
 	return uc.categoryRepo.List()
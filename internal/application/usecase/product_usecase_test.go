@@ -0,0 +1,484 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestProductUseCase_SearchProducts(t *testing.T) {
+	setup := func(t *testing.T) (*ProductUseCase, map[string]*entity.Product, map[string]*entity.Category) {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+
+		currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+		require.NoError(t, err)
+		require.NoError(t, db.Create(currency).Error)
+
+		categories := make(map[string]*entity.Category)
+		for _, c := range []struct{ name, parent string }{{"Clothing", ""}, {"Shirts", "Clothing"}, {"Garden", ""}} {
+			var parentID *uint
+			if c.parent != "" {
+				parentID = &categories[c.parent].ID
+			}
+			category, err := entity.NewCategory(c.name, "", parentID)
+			require.NoError(t, err)
+			require.NoError(t, db.Create(category).Error)
+			categories[c.name] = category
+		}
+
+		productRepo := gorm.NewProductRepository(db)
+		products := make(map[string]*entity.Product)
+		for _, p := range []struct {
+			name, category, sku, color string
+			stock                      int
+			price                      int64
+		}{
+			{"Linen Shirt", "Shirts", "LINEN-RED", "red", 4, 3000},
+			{"Oxford Shirt", "Shirts", "OXFORD-BLUE", "blue", 0, 4500},
+			{"Garden Hose", "Garden", "HOSE-GREEN", "green", 9, 2500},
+		} {
+			variant, err := entity.NewProductVariant(p.sku, p.stock, p.price, 1, entity.VariantAttributes{"color": p.color}, nil, true)
+			require.NoError(t, err)
+			product, err := entity.NewProduct(p.name, "", "USD", categories[p.category].ID, nil, []*entity.ProductVariant{variant}, true)
+			require.NoError(t, err)
+			require.NoError(t, productRepo.Create(product))
+			products[p.name] = product
+		}
+
+		uc := NewProductUseCase(
+			productRepo,
+			gorm.NewCategoryRepository(db),
+			gorm.NewProductVariantRepository(db),
+			gorm.NewCurrencyRepository(db),
+			gorm.NewOrderRepository(db),
+			gorm.NewCheckoutRepository(db),
+			gorm.NewPriceListRepository(db),
+			gorm.NewProductSearchRepository(db),
+		)
+
+		return uc, products, categories
+	}
+
+	t.Run("Category filter includes descendants", func(t *testing.T) {
+		uc, products, categories := setup(t)
+
+		result, err := uc.SearchProducts(SearchProductsInput{CategoryID: categories["Clothing"].ID, IncludeSubcategories: true, ActiveOnly: true, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Total)
+		assert.Equal(t, products["Linen Shirt"].ID, result.Products[0].ID)
+		assert.Equal(t, "Shirts", result.Products[0].Category.Name)
+
+		result, err = uc.SearchProducts(SearchProductsInput{CategoryID: categories["Clothing"].ID, ActiveOnly: true, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Total)
+	})
+
+	t.Run("ListProducts category filter includes descendants", func(t *testing.T) {
+		uc, _, categories := setup(t)
+
+		products, total, err := uc.ListProducts(SearchProductsInput{CategoryID: categories["Clothing"].ID, ActiveOnly: true, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, products, 2)
+
+		products, total, err = uc.ListProducts(SearchProductsInput{Query: "shirt", CategoryID: categories["Clothing"].ID, ActiveOnly: true, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, products, 2)
+
+		_, total, err = uc.ListProducts(SearchProductsInput{CategoryID: categories["Garden"].ID, ActiveOnly: true, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("Attribute and stock filters with facet counts", func(t *testing.T) {
+		uc, products, categories := setup(t)
+
+		result, err := uc.SearchProducts(SearchProductsInput{
+			Attributes:  map[string][]string{"color": {"red", "blue"}},
+			InStockOnly: true,
+			ActiveOnly:  true,
+			Limit:       10,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Total)
+		assert.Equal(t, products["Linen Shirt"].ID, result.Products[0].ID)
+
+		require.Len(t, result.Facets.Attributes, 1)
+		assert.Equal(t, "color", result.Facets.Attributes[0].Name)
+		assert.Len(t, result.Facets.Attributes[0].Values, 2) // Out of stock blue is not counted
+
+		categoryCounts := make(map[uint]int)
+		for _, facet := range result.Facets.Categories {
+			categoryCounts[facet.CategoryID] = facet.Count
+		}
+		assert.Equal(t, 1, categoryCounts[categories["Clothing"].ID])
+		assert.Equal(t, 1, categoryCounts[categories["Shirts"].ID])
+		assert.Equal(t, "USD", result.FacetCurrency)
+	})
+
+	t.Run("Text search keeps relevance order and highlights", func(t *testing.T) {
+		uc, products, _ := setup(t)
+
+		result, err := uc.SearchProducts(SearchProductsInput{Query: "shirt", MaxPrice: 40, ActiveOnly: true, Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Total)
+		assert.Equal(t, products["Linen Shirt"].ID, result.Products[0].ID)
+		assert.Contains(t, result.Products[0].SearchHighlights[entity.SearchFieldName], "<mark>Shirt</mark>")
+
+		require.Len(t, result.Facets.PriceBuckets, 2)
+		assert.Equal(t, 1, result.Facets.PriceBuckets[0].Count)
+	})
+
+	t.Run("Pagination applies after filtering", func(t *testing.T) {
+		uc, products, _ := setup(t)
+
+		result, err := uc.SearchProducts(SearchProductsInput{ActiveOnly: true, Offset: 2, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Total)
+		require.Len(t, result.Products, 1)
+		assert.Equal(t, products["Garden Hose"].ID, result.Products[0].ID)
+	})
+}
+
+func TestProductUseCase_Visibility(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(currency).Error)
+	category := testutil.CreateTestCategory(t, db, 1)
+
+	productRepo := gorm.NewProductRepository(db)
+	uc := NewProductUseCase(
+		productRepo,
+		gorm.NewCategoryRepository(db),
+		gorm.NewProductVariantRepository(db),
+		gorm.NewCurrencyRepository(db),
+		gorm.NewOrderRepository(db),
+		gorm.NewCheckoutRepository(db),
+		gorm.NewPriceListRepository(db),
+		gorm.NewProductSearchRepository(db),
+	)
+
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	tomorrow := now.AddDate(0, 0, 1)
+	createProduct := func(name, sku string, visibility *ProductVisibilityInput) *entity.Product {
+		product, err := uc.CreateProduct(CreateProductInput{
+			Name:       name,
+			Currency:   "USD",
+			CategoryID: category.ID,
+			Variants:   []CreateVariantInput{{VariantInput: VariantInput{SKU: sku, Stock: 10, Price: 2000}}},
+			Active:     true,
+			Visibility: visibility,
+		})
+		require.NoError(t, err)
+		return product
+	}
+	mug := createProduct("Blue Mug", "MUG", nil)
+	launch := createProduct("Launch Mug", "LAUNCH", &ProductVisibilityInput{PublishAt: &tomorrow})
+	retired := createProduct("Retired Mug", "RETIRED", &ProductVisibilityInput{UnpublishAt: &yesterday})
+	storeOnly := createProduct("Store Mug", "STORE", &ProductVisibilityInput{Channels: []entity.SalesChannel{entity.SalesChannelPOS}})
+
+	productIDs := func(products []*entity.Product) []uint {
+		ids := make([]uint, len(products))
+		for i, product := range products {
+			ids[i] = product.ID
+		}
+		return ids
+	}
+
+	t.Run("Only visible products are listed and found", func(t *testing.T) {
+		products, total, err := uc.ListProducts(SearchProductsInput{ActiveOnly: true, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{mug.ID}, productIDs(products))
+		assert.Equal(t, 1, total)
+
+		products, total, err = uc.ListProducts(SearchProductsInput{ActiveOnly: true, Channel: entity.SalesChannelPOS, Limit: 10})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uint{mug.ID, storeOnly.ID}, productIDs(products))
+		assert.Equal(t, 2, total)
+
+		products, _, err = uc.ListProducts(SearchProductsInput{Query: "mug", ActiveOnly: true, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{mug.ID}, productIDs(products))
+
+		result, err := uc.SearchProducts(SearchProductsInput{ActiveOnly: true, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{mug.ID}, productIDs(result.Products))
+
+		// Admins list all products
+		_, total, err = uc.ListProducts(SearchProductsInput{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+	})
+
+	t.Run("Hidden products are not found by customers", func(t *testing.T) {
+		_, err := uc.GetProductForCustomer(launch.ID, "", 0, entity.SalesChannelWebshop)
+		assert.ErrorContains(t, err, "not found")
+		_, err = uc.GetProductForCustomer(retired.ID, "", 0, entity.SalesChannelWebshop)
+		assert.ErrorContains(t, err, "not found")
+		_, err = uc.GetProductBySlugForCustomer(storeOnly.Slug, "", 0, entity.SalesChannelWebshop)
+		assert.ErrorContains(t, err, "not found")
+
+		product, err := uc.GetProductBySlugForCustomer(storeOnly.Slug, "", 0, entity.SalesChannelPOS)
+		require.NoError(t, err)
+		assert.Equal(t, storeOnly.ID, product.ID)
+		product, err = uc.GetProductForCustomer(launch.ID, "", 0, "")
+		require.NoError(t, err)
+		require.NotNil(t, product.PublishAt)
+		assert.True(t, tomorrow.Equal(*product.PublishAt))
+	})
+
+	t.Run("Updating the visibility publishes a product", func(t *testing.T) {
+		_, err := uc.UpdateProduct(launch.ID, UpdateProductInput{Visibility: &ProductVisibilityInput{UnpublishAt: &yesterday, PublishAt: &tomorrow}})
+		assert.ErrorContains(t, err, "unpublish time must be after the publish time")
+
+		_, err = uc.UpdateProduct(launch.ID, UpdateProductInput{Visibility: &ProductVisibilityInput{PublishAt: &yesterday}})
+		require.NoError(t, err)
+
+		product, err := uc.GetProductForCustomer(launch.ID, "", 0, entity.SalesChannelWebshop)
+		require.NoError(t, err)
+		assert.Equal(t, launch.ID, product.ID)
+	})
+
+	t.Run("Checkout refuses products that are not visible", func(t *testing.T) {
+		checkoutUseCase := NewCheckoutUseCase(
+			gorm.NewCheckoutRepository(db),
+			productRepo,
+			gorm.NewProductVariantRepository(db),
+			gorm.NewShippingMethodRepository(db),
+			gorm.NewShippingRateRepository(db),
+			gorm.NewDiscountRepository(db),
+			gorm.NewDiscountCodeRepository(db),
+			gorm.NewOrderRepository(db),
+			gorm.NewCurrencyRepository(db),
+			gorm.NewTransactionRepository(db),
+			gorm.NewPriceListRepository(db),
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+
+		checkout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID("session-1")
+		require.NoError(t, err)
+		_, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "STORE", Quantity: 1})
+		assert.ErrorContains(t, err, "product is not available")
+
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "MUG", Quantity: 1})
+		require.NoError(t, err)
+
+		// The product is unpublished before the order is placed
+		_, err = uc.UpdateProduct(mug.ID, UpdateProductInput{Visibility: &ProductVisibilityInput{UnpublishAt: &yesterday}})
+		require.NoError(t, err)
+		_, err = checkoutUseCase.CreateOrderFromCheckout(checkout.ID)
+		assert.ErrorContains(t, err, "product Blue Mug is no longer available")
+	})
+}
+
+func TestProductUseCase_GenerateVariants(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(currency).Error)
+
+	category, err := entity.NewCategory("Shirts", "", nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(category).Error)
+
+	uc := NewProductUseCase(
+		gorm.NewProductRepository(db),
+		gorm.NewCategoryRepository(db),
+		gorm.NewProductVariantRepository(db),
+		gorm.NewCurrencyRepository(db),
+		gorm.NewOrderRepository(db),
+		gorm.NewCheckoutRepository(db),
+		gorm.NewPriceListRepository(db),
+		gorm.NewProductSearchRepository(db),
+	)
+
+	product, err := uc.CreateProduct(CreateProductInput{
+		Name:       "Tee",
+		Currency:   "USD",
+		CategoryID: category.ID,
+		Active:     true,
+		Options: []ProductOptionInput{
+			{Name: "Color", Values: []string{"Red", "Blue"}},
+			{Name: "Size", Values: []string{"S", "M"}},
+		},
+		Variants: []CreateVariantInput{{VariantInput: VariantInput{
+			SKU:        "TEE-RED-S",
+			Price:      1500,
+			Stock:      3,
+			Attributes: entity.VariantAttributes{"color": "red", "size": "s"},
+		}}},
+	})
+	require.NoError(t, err)
+
+	t.Run("Variant attributes are validated", func(t *testing.T) {
+		_, err := uc.AddVariant(product.ID, CreateVariantInput{VariantInput: VariantInput{
+			SKU:        "TEE-GREEN-S",
+			Price:      1500,
+			Attributes: entity.VariantAttributes{"Colour": "Green", "Size": "S"},
+		}})
+		assert.ErrorContains(t, err, "unknown option Colour")
+
+		stored, err := uc.GetProductByID(product.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.VariantAttributes{"Color": "Red", "Size": "S"}, stored.Variants[0].Attributes.Data())
+	})
+
+	t.Run("Generates the missing combinations", func(t *testing.T) {
+		variants, err := uc.GenerateVariants(product.ID, GenerateVariantsInput{SKUPattern: "TEE-{Color}-{Size}", Stock: 2})
+		require.NoError(t, err)
+		require.Len(t, variants, 3)
+		assert.Equal(t, "TEE-RED-M", variants[0].SKU)
+		assert.Equal(t, int64(1500), variants[0].Price)
+
+		_, err = uc.SetProductOptions(product.ID, []ProductOptionInput{
+			{Name: "Color", Values: []string{"Red", "Blue"}},
+			{Name: "Size", Values: []string{"S", "M", "L"}},
+		})
+		require.NoError(t, err)
+
+		variants, err = uc.GenerateVariants(product.ID, GenerateVariantsInput{SKUPattern: "TEE-{Color}-{Size}", Price: 1800})
+		require.NoError(t, err)
+		require.Len(t, variants, 2)
+		assert.Equal(t, "TEE-RED-L", variants[0].SKU)
+		assert.Equal(t, "TEE-BLUE-L", variants[1].SKU)
+		assert.Equal(t, int64(1800), variants[1].Price)
+
+		stored, err := uc.GetProductByID(product.ID)
+		require.NoError(t, err)
+		assert.Len(t, stored.Variants, 6)
+		assert.Len(t, stored.Options, 2)
+
+		variants, err = uc.GenerateVariants(product.ID, GenerateVariantsInput{})
+		require.NoError(t, err)
+		assert.Empty(t, variants)
+	})
+
+	t.Run("Options cannot drop values in use", func(t *testing.T) {
+		_, err := uc.SetProductOptions(product.ID, []ProductOptionInput{
+			{Name: "Color", Values: []string{"Red"}},
+			{Name: "Size", Values: []string{"S", "M", "L"}},
+		})
+		assert.ErrorContains(t, err, "Blue is not a value of option Color")
+	})
+}
+
+func TestProductUseCase_BundleComponents(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(currency).Error)
+
+	category, err := entity.NewCategory("Gifts", "", nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(category).Error)
+
+	uc := NewProductUseCase(
+		gorm.NewProductRepository(db),
+		gorm.NewCategoryRepository(db),
+		gorm.NewProductVariantRepository(db),
+		gorm.NewCurrencyRepository(db),
+		gorm.NewOrderRepository(db),
+		gorm.NewCheckoutRepository(db),
+		gorm.NewPriceListRepository(db),
+		gorm.NewProductSearchRepository(db),
+	)
+
+	createProduct := func(name, sku string, productType entity.ProductType, stock int) *entity.Product {
+		product, err := uc.CreateProduct(CreateProductInput{
+			Type:       productType,
+			Name:       name,
+			Currency:   "USD",
+			CategoryID: category.ID,
+			Active:     true,
+			Variants:   []CreateVariantInput{{VariantInput: VariantInput{SKU: sku, Price: 1000, Stock: stock}}},
+		})
+		require.NoError(t, err)
+		return product
+	}
+
+	mug := createProduct("Mug", "MUG", entity.ProductTypeStandard, 7)
+	tea := createProduct("Tea", "TEA", "", 2)
+	giftBox := createProduct("Gift Box", "GIFT-BOX", entity.ProductTypeBundle, 0)
+	assert.Equal(t, entity.ProductTypeStandard, tea.Type)
+	assert.True(t, giftBox.IsBundle())
+
+	mugID, teaID, giftBoxID := mug.Variants[0].ID, tea.Variants[0].ID, giftBox.Variants[0].ID
+
+	t.Run("Only bundle products get components", func(t *testing.T) {
+		_, err := uc.SetBundleComponents(mug.ID, mugID, []BundleComponentInput{{VariantID: teaID, Quantity: 1}})
+		assert.ErrorContains(t, err, "is not a bundle")
+	})
+
+	t.Run("Bundle stock is computed from the components", func(t *testing.T) {
+		variant, err := uc.SetBundleComponents(giftBox.ID, giftBoxID, []BundleComponentInput{
+			{VariantID: mugID, Quantity: 2},
+			{VariantID: teaID, Quantity: 1},
+		})
+		require.NoError(t, err)
+		require.Len(t, variant.Components, 2)
+		assert.Equal(t, "MUG", variant.Components[0].ComponentVariant.SKU)
+		assert.Equal(t, 2, variant.AvailableStock())
+		assert.True(t, variant.IsAvailable(2))
+		assert.False(t, variant.IsAvailable(3))
+
+		stored, err := uc.GetProductByID(giftBox.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stored.GetTotalStock())
+		assert.Equal(t, 2, stored.ToProductDTO().Variants[0].Stock)
+		assert.Equal(t, "bundle", stored.ToProductDTO().Type)
+	})
+
+	t.Run("Components are validated", func(t *testing.T) {
+		_, err := uc.SetBundleComponents(giftBox.ID, giftBoxID, []BundleComponentInput{{VariantID: mugID, Quantity: 0}})
+		assert.ErrorContains(t, err, "quantity must be greater than zero")
+
+		_, err = uc.SetBundleComponents(giftBox.ID, giftBoxID, []BundleComponentInput{{VariantID: giftBoxID, Quantity: 1}})
+		assert.Error(t, err)
+
+		_, err = uc.SetBundleComponents(giftBox.ID, giftBoxID, []BundleComponentInput{{VariantID: mugID, Quantity: 1}, {VariantID: mugID, Quantity: 1}})
+		assert.ErrorContains(t, err, "more than once")
+
+		_, err = uc.SetBundleComponents(giftBox.ID, giftBoxID, []BundleComponentInput{{VariantID: 9999, Quantity: 1}})
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("Components cannot be deleted", func(t *testing.T) {
+		err := uc.DeleteProduct(mug.ID)
+		assert.ErrorContains(t, err, "component of a bundle")
+	})
+
+	t.Run("Bundles become standard products once their components are removed", func(t *testing.T) {
+		standard := entity.ProductTypeStandard
+		_, err := uc.UpdateProduct(giftBox.ID, UpdateProductInput{Type: &standard})
+		assert.ErrorContains(t, err, "remove the components")
+
+		variant, err := uc.SetBundleComponents(giftBox.ID, giftBoxID, nil)
+		require.NoError(t, err)
+		assert.False(t, variant.IsBundle())
+
+		product, err := uc.UpdateProduct(giftBox.ID, UpdateProductInput{Type: &standard})
+		require.NoError(t, err)
+		assert.False(t, product.IsBundle())
+
+		require.NoError(t, uc.DeleteProduct(mug.ID))
+	})
+}
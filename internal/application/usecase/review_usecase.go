@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// ReviewUseCase implements product review and moderation use cases
+type ReviewUseCase struct {
+	reviewRepo  repository.ReviewRepository
+	productRepo repository.ProductRepository
+	orderRepo   repository.OrderRepository
+	userRepo    repository.UserRepository
+}
+
+// NewReviewUseCase creates a new ReviewUseCase
+func NewReviewUseCase(
+	reviewRepo repository.ReviewRepository,
+	productRepo repository.ProductRepository,
+	orderRepo repository.OrderRepository,
+	userRepo repository.UserRepository,
+) *ReviewUseCase {
+	return &ReviewUseCase{
+		reviewRepo:  reviewRepo,
+		productRepo: productRepo,
+		orderRepo:   orderRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// SubmitReviewInput contains the data for reviewing a product
+type SubmitReviewInput struct {
+	Rating int
+	Title  string
+	Body   string
+}
+
+// ProductReviews is a page of the approved reviews of a product with its rating summary
+type ProductReviews struct {
+	Reviews       []*entity.Review
+	Total         int
+	RatingAverage float64
+	ReviewCount   int
+	Distribution  map[int]int
+}
+
+// SubmitReview adds a review of a product by a customer with a paid order of it.
+// The review is flagged as a verified purchase and awaits moderation.
+func (uc *ReviewUseCase) SubmitReview(userID, productID uint, input SubmitReviewInput) (*entity.Review, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsPublished(time.Now()) {
+		return nil, fmt.Errorf("product with ID %d not found", productID)
+	}
+
+	if _, err := uc.reviewRepo.GetByProductAndUser(productID, userID); err == nil {
+		return nil, errors.New("you have already reviewed this product")
+	} else if !strings.Contains(err.Error(), "not found") {
+		return nil, err
+	}
+
+	orderID, err := uc.orderRepo.FindPaidOrderIDWithProduct(userID, productID)
+	if err != nil {
+		return nil, err
+	}
+	if orderID == 0 {
+		return nil, errors.New("only customers with a paid order of the product can review it")
+	}
+
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := entity.NewReview(productID, userID, input.Rating, input.Title, input.Body,
+		entity.ReviewAuthorName(user.FirstName, user.LastName))
+	if err != nil {
+		return nil, err
+	}
+	review.OrderID = &orderID
+	review.VerifiedPurchase = true
+
+	if err := uc.reviewRepo.Create(review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// ListProductReviews returns the approved reviews of a product, newest first, with its rating summary
+func (uc *ReviewUseCase) ListProductReviews(productID uint, offset, limit int) (*ProductReviews, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := uc.reviewRepo.ListByProduct(productID, entity.ReviewStatusApproved, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	distribution, err := uc.reviewRepo.RatingDistribution(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProductReviews{
+		Reviews:       reviews,
+		Total:         product.ReviewCount,
+		RatingAverage: product.RatingAverage,
+		ReviewCount:   product.ReviewCount,
+		Distribution:  distribution,
+	}, nil
+}
+
+// ListReviews returns the reviews with the status, oldest first (admin only).
+// Pending reviews make up the moderation queue; an empty status lists all reviews.
+func (uc *ReviewUseCase) ListReviews(status entity.ReviewStatus, offset, limit int) ([]*entity.Review, int, error) {
+	if status != "" && !entity.IsValidReviewStatus(status) {
+		return nil, 0, fmt.Errorf("invalid review status %q", status)
+	}
+
+	reviews, err := uc.reviewRepo.List(status, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := uc.reviewRepo.Count(status)
+	if err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+// ApproveReview publishes a review and updates the rating of its product (admin only)
+func (uc *ReviewUseCase) ApproveReview(reviewID uint) (*entity.Review, error) {
+	review, err := uc.reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	review.Approve(time.Now())
+	if err := uc.saveModeration(review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// RejectReview hides a review with an optional reason and updates the rating of its product (admin only)
+func (uc *ReviewUseCase) RejectReview(reviewID uint, note string) (*entity.Review, error) {
+	review, err := uc.reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := review.Reject(note, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.saveModeration(review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// DeleteReview deletes a review and updates the rating of its product (admin only)
+func (uc *ReviewUseCase) DeleteReview(reviewID uint) error {
+	review, err := uc.reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.reviewRepo.Delete(reviewID); err != nil {
+		return err
+	}
+	return uc.reviewRepo.RefreshProductRating(review.ProductID)
+}
+
+// saveModeration saves a moderated review and recomputes the rating of its product
+func (uc *ReviewUseCase) saveModeration(review *entity.Review) error {
+	if err := uc.reviewRepo.Update(review); err != nil {
+		return err
+	}
+	return uc.reviewRepo.RefreshProductRating(review.ProductID)
+}
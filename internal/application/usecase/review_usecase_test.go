@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestReviewUseCase(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(currency).Error)
+	category := testutil.CreateTestCategory(t, db, 1)
+
+	productRepo := gorm.NewProductRepository(db)
+	createProduct := func(name, sku string) *entity.Product {
+		variant, err := entity.NewProductVariant(sku, 10, 2500, 0, entity.VariantAttributes{"size": "M"}, nil, true)
+		require.NoError(t, err)
+		product, err := entity.NewProduct(name, "", "USD", category.ID, nil, []*entity.ProductVariant{variant}, true)
+		require.NoError(t, err)
+		require.NoError(t, productRepo.Create(product))
+		return product
+	}
+	kettle := createProduct("Kettle", "KETTLE")
+	teapot := createProduct("Teapot", "TEAPOT")
+
+	createOrder := func(userID uint, product *entity.Product, status entity.PaymentStatus) *entity.Order {
+		variant := product.Variants[0]
+		order := &entity.Order{
+			OrderNumber:   fmt.Sprintf("ORD-%d-%s", userID, variant.SKU),
+			UserID:        &userID,
+			Currency:      "USD",
+			Status:        entity.OrderStatusPaid,
+			PaymentStatus: status,
+			TotalAmount:   variant.Price,
+			Items: []entity.OrderItem{{
+				ProductID:        product.ID,
+				ProductVariantID: variant.ID,
+				Quantity:         1,
+				Price:            variant.Price,
+				Subtotal:         variant.Price,
+				ProductName:      product.Name,
+				SKU:              variant.SKU,
+			}},
+		}
+		require.NoError(t, db.Create(order).Error)
+		return order
+	}
+
+	buyer := testutil.CreateTestUser(t, db, 1)
+	browser := testutil.CreateTestUser(t, db, 2)
+	refunded := testutil.CreateTestUser(t, db, 3)
+	paidOrder := createOrder(buyer.ID, kettle, entity.PaymentStatusCaptured)
+	createOrder(buyer.ID, teapot, entity.PaymentStatusAuthorized)
+	createOrder(refunded.ID, kettle, entity.PaymentStatusRefunded)
+
+	uc := NewReviewUseCase(gorm.NewReviewRepository(db), productRepo, gorm.NewOrderRepository(db), gorm.NewUserRepository(db))
+
+	t.Run("Only customers with a paid order can review", func(t *testing.T) {
+		_, err := uc.SubmitReview(browser.ID, kettle.ID, SubmitReviewInput{Rating: 5})
+		assert.ErrorContains(t, err, "paid order")
+		_, err = uc.SubmitReview(refunded.ID, kettle.ID, SubmitReviewInput{Rating: 5})
+		assert.ErrorContains(t, err, "paid order")
+		_, err = uc.SubmitReview(buyer.ID, 999, SubmitReviewInput{Rating: 5})
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("Reviews await moderation as verified purchases", func(t *testing.T) {
+		_, err := uc.SubmitReview(buyer.ID, kettle.ID, SubmitReviewInput{Rating: 6})
+		assert.ErrorContains(t, err, "rating must be between")
+
+		review, err := uc.SubmitReview(buyer.ID, kettle.ID, SubmitReviewInput{Rating: 4, Title: "Boils fast", Body: "Quiet, too."})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ReviewStatusPending, review.Status)
+		assert.True(t, review.VerifiedPurchase)
+		require.NotNil(t, review.OrderID)
+		assert.Equal(t, paidOrder.ID, *review.OrderID)
+		assert.Equal(t, "User1 T.", review.AuthorName)
+
+		_, err = uc.SubmitReview(buyer.ID, kettle.ID, SubmitReviewInput{Rating: 5})
+		assert.ErrorContains(t, err, "already reviewed")
+
+		queue, total, err := uc.ListReviews(entity.ReviewStatusPending, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, review.ID, queue[0].ID)
+
+		// Pending reviews are not public
+		reviews, err := uc.ListProductReviews(kettle.ID, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, reviews.Reviews)
+		assert.Equal(t, 0, reviews.ReviewCount)
+	})
+
+	t.Run("Moderation updates the product rating", func(t *testing.T) {
+		queue, _, err := uc.ListReviews(entity.ReviewStatusPending, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, queue, 1)
+		kettleReview := queue[0]
+
+		teapotReview, err := uc.SubmitReview(buyer.ID, teapot.ID, SubmitReviewInput{Rating: 5})
+		require.NoError(t, err)
+
+		_, err = uc.ApproveReview(kettleReview.ID)
+		require.NoError(t, err)
+		_, err = uc.ApproveReview(teapotReview.ID)
+		require.NoError(t, err)
+
+		reviews, err := uc.ListProductReviews(kettle.ID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, reviews.Reviews, 1)
+		assert.Equal(t, 4.0, reviews.RatingAverage)
+		assert.Equal(t, 1, reviews.ReviewCount)
+		assert.Equal(t, 1, reviews.Distribution[4])
+		assert.Equal(t, 0, reviews.Distribution[1])
+
+		product, err := productRepo.GetByID(teapot.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 5.0, product.RatingAverage)
+		assert.Equal(t, 1, product.ReviewCount)
+
+		// Rejecting an approved review removes it from the rating
+		rejected, err := uc.RejectReview(teapotReview.ID, "Off topic")
+		require.NoError(t, err)
+		assert.Equal(t, "Off topic", rejected.ModerationNote)
+		product, err = productRepo.GetByID(teapot.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, product.RatingAverage)
+		assert.Equal(t, 0, product.ReviewCount)
+
+		_, err = uc.ApproveReview(teapotReview.ID)
+		require.NoError(t, err)
+
+		_, _, err = uc.ListReviews("flagged", 0, 10)
+		assert.ErrorContains(t, err, "invalid review status")
+	})
+
+	t.Run("Search sorts by rating", func(t *testing.T) {
+		productUseCase := NewProductUseCase(
+			productRepo,
+			gorm.NewCategoryRepository(db),
+			gorm.NewProductVariantRepository(db),
+			gorm.NewCurrencyRepository(db),
+			gorm.NewOrderRepository(db),
+			gorm.NewCheckoutRepository(db),
+			gorm.NewPriceListRepository(db),
+			gorm.NewProductSearchRepository(db),
+		)
+
+		result, err := productUseCase.SearchProducts(SearchProductsInput{ActiveOnly: true, Sort: ProductSortRating, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, result.Products, 2)
+		assert.Equal(t, teapot.ID, result.Products[0].ID)
+		assert.Equal(t, kettle.ID, result.Products[1].ID)
+		assert.Equal(t, 5.0, result.Products[0].ToProductSummaryDTO().RatingAverage)
+
+		_, err = productUseCase.SearchProducts(SearchProductsInput{Sort: "popularity"})
+		assert.ErrorContains(t, err, "invalid search sort")
+	})
+
+	t.Run("Deleting a review updates the product rating", func(t *testing.T) {
+		reviews, err := uc.ListProductReviews(teapot.ID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, reviews.Reviews, 1)
+
+		require.NoError(t, uc.DeleteReview(reviews.Reviews[0].ID))
+		product, err := productRepo.GetByID(teapot.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, product.ReviewCount)
+
+		assert.ErrorContains(t, uc.DeleteReview(reviews.Reviews[0].ID), "not found")
+	})
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// ErrPermissionDenied is returned when a user or API key grants permissions it does not hold itself
+var ErrPermissionDenied = errors.New("permission denied")
+
+// RoleUseCase implements the use cases of roles. Users are assigned a role by name; the permissions
+// of the role decide which admin endpoints they can use.
+type RoleUseCase struct {
+	roleRepo repository.RoleRepository
+	userRepo repository.UserRepository
+}
+
+// NewRoleUseCase creates a new RoleUseCase
+func NewRoleUseCase(roleRepo repository.RoleRepository, userRepo repository.UserRepository) *RoleUseCase {
+	return &RoleUseCase{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+	}
+}
+
+// Grantor is the user or API key changing roles, so it cannot grant more than it holds
+type Grantor struct {
+	Permissions []entity.Permission
+	IsAdmin     bool // Only admins can assign the admin role or change the role of an admin
+}
+
+// checkGrants checks that the grantor holds every permission
+func (g Grantor) checkGrants(permissions []entity.Permission) error {
+	for _, permission := range permissions {
+		if !slices.Contains(g.Permissions, permission) {
+			return fmt.Errorf("%w: permission %s is required to grant it", ErrPermissionDenied, permission)
+		}
+	}
+	return nil
+}
+
+// CreateRoleInput contains the data needed to define a role
+type CreateRoleInput struct {
+	Name string
+	UpdateRoleInput
+}
+
+// UpdateRoleInput contains the description and permissions of a role
+type UpdateRoleInput struct {
+	Description string
+	Permissions []entity.Permission
+}
+
+// ListRoles lists the built-in roles followed by the admin-defined roles
+func (uc *RoleUseCase) ListRoles() ([]*entity.Role, error) {
+	roles, err := uc.roleRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	return append(entity.BuiltInRoles(), roles...), nil
+}
+
+// CreateRole defines a new role with permissions the grantor holds
+func (uc *RoleUseCase) CreateRole(grantor Grantor, input CreateRoleInput) (*entity.Role, error) {
+	role, err := entity.NewRole(input.Name, input.Description, input.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	if err := grantor.checkGrants(role.Permissions); err != nil {
+		return nil, err
+	}
+	if _, err := uc.roleRepo.GetByName(role.Name); err == nil {
+		return nil, fmt.Errorf("role %s already exists", role.Name)
+	} else if !errors.Is(err, repository.ErrRoleNotFound) {
+		return nil, err
+	}
+
+	if err := uc.roleRepo.Create(role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// UpdateRole changes the description and permissions of a role. The grantor must hold the current
+// and the new permissions of the role. Users with the role get the new permissions on their next request.
+func (uc *RoleUseCase) UpdateRole(grantor Grantor, roleID uint, input UpdateRoleInput) (*entity.Role, error) {
+	role, err := uc.roleRepo.GetByID(roleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := grantor.checkGrants(role.Permissions); err != nil {
+		return nil, err
+	}
+	if err := role.Update(input.Description, input.Permissions); err != nil {
+		return nil, err
+	}
+	if err := grantor.checkGrants(role.Permissions); err != nil {
+		return nil, err
+	}
+	if err := uc.roleRepo.Update(role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// DeleteRole deletes a role that is not assigned to any user
+func (uc *RoleUseCase) DeleteRole(roleID uint) error {
+	role, err := uc.roleRepo.GetByID(roleID)
+	if err != nil {
+		return err
+	}
+	count, err := uc.userRepo.CountByRole(role.Name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("role %s is assigned to %d users", role.Name, count)
+	}
+	return uc.roleRepo.Delete(roleID)
+}
+
+// GetPermissions returns the permissions of the role with the name.
+// Unknown roles have no permissions.
+func (uc *RoleUseCase) GetPermissions(name string) ([]entity.Permission, error) {
+	switch name {
+	case string(entity.RoleAdmin):
+		return slices.Clone(entity.AllPermissions), nil
+	case string(entity.RoleUser), "":
+		return nil, nil
+	}
+
+	role, err := uc.roleRepo.GetByName(name)
+	if err != nil {
+		if errors.Is(err, repository.ErrRoleNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return role.Permissions, nil
+}
+
+// AssignRole assigns the role with the name to a user. The grantor must hold every permission of the role,
+// and only admins can assign the admin role or change the role of an admin. The last admin cannot be given another role.
+func (uc *RoleUseCase) AssignRole(grantor Grantor, userID uint, name string) (*entity.User, error) {
+	if name == string(entity.RoleAdmin) && !grantor.IsAdmin {
+		return nil, fmt.Errorf("%w: only admins can assign the admin role", ErrPermissionDenied)
+	}
+	permissions, _ := uc.GetPermissions(name)
+	if !entity.IsBuiltInRole(name) {
+		role, err := uc.roleRepo.GetByName(name)
+		if err != nil {
+			return nil, err
+		}
+		permissions = role.Permissions
+	}
+	if err := grantor.checkGrants(permissions); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsAdmin() && !grantor.IsAdmin {
+		return nil, fmt.Errorf("%w: only admins can change the role of an admin", ErrPermissionDenied)
+	}
+	// Users cannot be moved out of a role that grants more than the grantor holds
+	current, err := uc.GetPermissions(user.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err := grantor.checkGrants(current); err != nil {
+		return nil, err
+	}
+	if user.IsAdmin() && name != string(entity.RoleAdmin) {
+		count, err := uc.userRepo.CountByRole(string(entity.RoleAdmin))
+		if err != nil {
+			return nil, err
+		}
+		if count <= 1 {
+			return nil, errors.New("cannot remove the role of the last admin")
+		}
+	}
+
+	user.Role = name
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
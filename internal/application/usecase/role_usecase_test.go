@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestRoleUseCase(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	userRepo := gorm.NewUserRepository(db)
+	uc := NewRoleUseCase(gorm.NewRoleRepository(db), userRepo)
+
+	createUser := func(email string, role entity.UserRole) *entity.User {
+		user, err := entity.NewUser(email, "password123", "Jane", "Doe", role)
+		require.NoError(t, err)
+		require.NoError(t, userRepo.Create(user))
+		return user
+	}
+
+	admin := createUser("admin@example.com", entity.RoleAdmin)
+	owner := Grantor{Permissions: entity.AllPermissions, IsAdmin: true}
+	customer := createUser("customer@example.com", entity.RoleUser)
+
+	role, err := uc.CreateRole(owner, CreateRoleInput{
+		Name: "warehouse",
+		UpdateRoleInput: UpdateRoleInput{
+			Description: "Picks and ships orders",
+			Permissions: []entity.Permission{entity.PermissionOrdersRead, entity.PermissionOrdersFulfill},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("CreateRole rejects duplicate and built-in names", func(t *testing.T) {
+		_, err := uc.CreateRole(owner, CreateRoleInput{Name: "warehouse"})
+		assert.EqualError(t, err, "role warehouse already exists")
+
+		_, err = uc.CreateRole(owner, CreateRoleInput{Name: "user"})
+		assert.Error(t, err)
+	})
+
+	t.Run("ListRoles lists the built-in roles first", func(t *testing.T) {
+		roles, err := uc.ListRoles()
+		require.NoError(t, err)
+		require.Len(t, roles, 3)
+		assert.Equal(t, "admin", roles[0].Name)
+		assert.Equal(t, "user", roles[1].Name)
+		assert.Equal(t, "warehouse", roles[2].Name)
+	})
+
+	t.Run("GetPermissions", func(t *testing.T) {
+		permissions, err := uc.GetPermissions("warehouse")
+		require.NoError(t, err)
+		assert.Equal(t, []entity.Permission{entity.PermissionOrdersRead, entity.PermissionOrdersFulfill}, permissions)
+
+		permissions, err = uc.GetPermissions("admin")
+		require.NoError(t, err)
+		assert.Equal(t, entity.AllPermissions, permissions)
+
+		permissions, err = uc.GetPermissions("user")
+		require.NoError(t, err)
+		assert.Empty(t, permissions)
+
+		// Roles that no longer exist grant nothing
+		permissions, err = uc.GetPermissions("seller")
+		require.NoError(t, err)
+		assert.Empty(t, permissions)
+	})
+
+	t.Run("UpdateRole changes the permissions of the users with the role", func(t *testing.T) {
+		_, err := uc.UpdateRole(owner, role.ID, UpdateRoleInput{
+			Permissions: []entity.Permission{entity.PermissionOrdersRead},
+		})
+		require.NoError(t, err)
+
+		permissions, err := uc.GetPermissions("warehouse")
+		require.NoError(t, err)
+		assert.Equal(t, []entity.Permission{entity.PermissionOrdersRead}, permissions)
+
+		_, err = uc.UpdateRole(owner, role.ID, UpdateRoleInput{Permissions: []entity.Permission{"orders:delete"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("AssignRole", func(t *testing.T) {
+		updated, err := uc.AssignRole(owner, customer.ID, "warehouse")
+		require.NoError(t, err)
+		assert.Equal(t, "warehouse", updated.Role)
+
+		_, err = uc.AssignRole(owner, customer.ID, "seller")
+		assert.Error(t, err)
+	})
+
+	t.Run("AssignRole keeps the last admin", func(t *testing.T) {
+		_, err := uc.AssignRole(owner, admin.ID, "user")
+		assert.EqualError(t, err, "cannot remove the role of the last admin")
+
+		other := createUser("other-admin@example.com", entity.RoleAdmin)
+		_, err = uc.AssignRole(owner, admin.ID, "user")
+		require.NoError(t, err)
+
+		_, err = uc.AssignRole(owner, other.ID, "warehouse")
+		assert.EqualError(t, err, "cannot remove the role of the last admin")
+	})
+
+	t.Run("Grantors cannot grant permissions they do not hold", func(t *testing.T) {
+		manager := Grantor{Permissions: []entity.Permission{
+			entity.PermissionRolesWrite, entity.PermissionOrdersRead, entity.PermissionOrdersFulfill,
+		}}
+
+		_, err := uc.CreateRole(manager, CreateRoleInput{
+			Name:            "finance",
+			UpdateRoleInput: UpdateRoleInput{Permissions: []entity.Permission{entity.PermissionPaymentsRefund}},
+		})
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+
+		_, err = uc.UpdateRole(manager, role.ID, UpdateRoleInput{
+			Permissions: []entity.Permission{entity.PermissionOrdersRead, entity.PermissionRolesWrite, entity.PermissionPaymentsRefund},
+		})
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+
+		_, err = uc.AssignRole(manager, customer.ID, "admin")
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+
+		other := createUser("staff@example.com", entity.RoleUser)
+		_, err = uc.AssignRole(manager, other.ID, "warehouse")
+		require.NoError(t, err)
+
+		secondAdmin := createUser("second-admin@example.com", entity.RoleAdmin)
+		_, err = uc.AssignRole(manager, secondAdmin.ID, "user")
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+
+		finance, err := uc.CreateRole(owner, CreateRoleInput{
+			Name:            "finance",
+			UpdateRoleInput: UpdateRoleInput{Permissions: []entity.Permission{entity.PermissionPaymentsRefund}},
+		})
+		require.NoError(t, err)
+		_, err = uc.AssignRole(manager, other.ID, "finance")
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+		_, err = uc.UpdateRole(manager, finance.ID, UpdateRoleInput{})
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+
+		_, err = uc.AssignRole(owner, other.ID, "user")
+		require.NoError(t, err)
+		require.NoError(t, uc.DeleteRole(finance.ID))
+	})
+
+	t.Run("DeleteRole refuses roles in use", func(t *testing.T) {
+		err := uc.DeleteRole(role.ID)
+		assert.EqualError(t, err, "role warehouse is assigned to 1 users")
+
+		_, err = uc.AssignRole(owner, customer.ID, "user")
+		require.NoError(t, err)
+		require.NoError(t, uc.DeleteRole(role.ID))
+
+		_, err = uc.AssignRole(owner, customer.ID, "warehouse")
+		assert.Error(t, err)
+	})
+}
@@ -14,6 +14,7 @@ type ShippingUseCase struct {
 	shippingMethodRepo repository.ShippingMethodRepository
 	shippingZoneRepo   repository.ShippingZoneRepository
 	shippingRateRepo   repository.ShippingRateRepository
+	currencyRepo       repository.CurrencyRepository
 }
 
 // NewShippingUseCase creates a new ShippingUseCase
@@ -21,11 +22,13 @@ func NewShippingUseCase(
 	shippingMethodRepo repository.ShippingMethodRepository,
 	shippingZoneRepo repository.ShippingZoneRepository,
 	shippingRateRepo repository.ShippingRateRepository,
+	currencyRepo repository.CurrencyRepository,
 ) *ShippingUseCase {
 	return &ShippingUseCase{
 		shippingMethodRepo: shippingMethodRepo,
 		shippingZoneRepo:   shippingZoneRepo,
 		shippingRateRepo:   shippingRateRepo,
+		currencyRepo:       currencyRepo,
 	}
 }
 
@@ -163,12 +166,13 @@ func (uc *ShippingUseCase) UpdateShippingZone(input UpdateShippingZoneInput) (*e
 
 // CreateShippingRateInput contains the data needed to create a shipping rate
 type CreateShippingRateInput struct {
-	ShippingMethodID      uint     `json:"shipping_method_id"`
-	ShippingZoneID        uint     `json:"shipping_zone_id"`
-	BaseRate              float64  `json:"base_rate"`
-	MinOrderValue         float64  `json:"min_order_value"`
-	FreeShippingThreshold *float64 `json:"free_shipping_threshold"`
-	Active                bool     `json:"active"`
+	ShippingMethodID      uint               `json:"shipping_method_id"`
+	ShippingZoneID        uint               `json:"shipping_zone_id"`
+	BaseRate              float64            `json:"base_rate"`
+	MinOrderValue         float64            `json:"min_order_value"`
+	FreeShippingThreshold *float64           `json:"free_shipping_threshold"`
+	CurrencyBaseRates     map[string]float64 `json:"currency_base_rates"`
+	Active                bool               `json:"active"`
 }
 
 // CreateShippingRate creates a new shipping rate
@@ -185,9 +189,12 @@ func (uc *ShippingUseCase) CreateShippingRate(input CreateShippingRateInput) (*e
 		return nil, errors.New("shipping zone not found")
 	}
 
-	baseRateCents := money.ToCents(input.BaseRate)
-	minOrderValueCents := money.ToCents(input.MinOrderValue)
-	freeShippingThresholdCents := money.ConvertNullableToCents(input.FreeShippingThreshold)
+	// Rates are configured in the default currency
+	baseCurrency, err := resolveBaseCurrency(uc.currencyRepo)
+	if err != nil {
+		return nil, err
+	}
+	currency := currencyCode(baseCurrency)
 
 	// Create shipping rate
 	rate := &entity.ShippingRate{
@@ -195,11 +202,16 @@ func (uc *ShippingUseCase) CreateShippingRate(input CreateShippingRateInput) (*e
 		ShippingZoneID:        input.ShippingZoneID,
 		ShippingMethod:        method,
 		ShippingZone:          zone,
-		BaseRate:              baseRateCents,
-		MinOrderValue:         minOrderValueCents,
-		FreeShippingThreshold: freeShippingThresholdCents,
+		BaseRate:              money.ToMinor(input.BaseRate, currency),
+		MinOrderValue:         money.ToMinor(input.MinOrderValue, currency),
+		FreeShippingThreshold: money.ConvertNullableToMinor(input.FreeShippingThreshold, currency),
 		Active:                input.Active,
 	}
+	rate.SetBaseCurrency(baseCurrency)
+
+	if err := rate.SetCurrencyBaseRates(money.MapToMinor(input.CurrencyBaseRates)); err != nil {
+		return nil, err
+	}
 
 	// Save to repository
 	if err := uc.shippingRateRepo.Create(rate); err != nil {
@@ -220,25 +232,24 @@ type CreateWeightBasedRateInput struct {
 // CreateWeightBasedRate creates a weight-based shipping rate
 func (uc *ShippingUseCase) CreateWeightBasedRate(input CreateWeightBasedRateInput) (*entity.WeightBasedRate, error) {
 	// Validate shipping rate exists
-	_, err := uc.shippingRateRepo.GetByID(input.ShippingRateID)
+	rate, err := uc.GetShippingRateByID(input.ShippingRateID)
 	if err != nil {
 		return nil, errors.New("shipping rate not found")
 	}
 
-	rateCents := money.ToCents(input.Rate)
-
 	// Create weight-based rate
 	weightRate := &entity.WeightBasedRate{
 		ShippingRateID: input.ShippingRateID,
 		MinWeight:      input.MinWeight,
 		MaxWeight:      input.MaxWeight,
-		Rate:           rateCents,
+		Rate:           money.ToMinor(input.Rate, rate.BaseCurrencyCode()),
 	}
 
 	// Save to repository
 	if err := uc.shippingRateRepo.CreateWeightBasedRate(weightRate); err != nil {
 		return nil, err
 	}
+	weightRate.ShippingRate = *rate
 
 	return weightRate, nil
 }
@@ -254,64 +265,77 @@ type CreateValueBasedRateInput struct {
 // CreateValueBasedRate creates a value-based shipping rate
 func (uc *ShippingUseCase) CreateValueBasedRate(input CreateValueBasedRateInput) (*entity.ValueBasedRate, error) {
 	// Validate shipping rate exists
-	_, err := uc.shippingRateRepo.GetByID(input.ShippingRateID)
+	rate, err := uc.GetShippingRateByID(input.ShippingRateID)
 	if err != nil {
 		return nil, errors.New("shipping rate not found")
 	}
-
-	minOrderValueCents := money.ToCents(input.MinOrderValue)
-	maxOrderValueCents := money.ToCents(input.MaxOrderValue)
-	rateCents := money.ToCents(input.Rate)
+	currency := rate.BaseCurrencyCode()
 
 	// Create value-based rate
 	valueRate := &entity.ValueBasedRate{
 		ShippingRateID: input.ShippingRateID,
-		MinOrderValue:  minOrderValueCents,
-		MaxOrderValue:  maxOrderValueCents,
-		Rate:           rateCents,
+		MinOrderValue:  money.ToMinor(input.MinOrderValue, currency),
+		MaxOrderValue:  money.ToMinor(input.MaxOrderValue, currency),
+		Rate:           money.ToMinor(input.Rate, currency),
 	}
 
 	// Save to repository
 	if err := uc.shippingRateRepo.CreateValueBasedRate(valueRate); err != nil {
 		return nil, err
 	}
+	valueRate.ShippingRate = *rate
 
 	return valueRate, nil
 }
 
 // GetShippingRateByID retrieves a shipping rate by ID
 func (uc *ShippingUseCase) GetShippingRateByID(id uint) (*entity.ShippingRate, error) {
-	return uc.shippingRateRepo.GetByID(id)
+	rate, err := uc.shippingRateRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	baseCurrency, err := resolveBaseCurrency(uc.currencyRepo)
+	if err != nil {
+		return nil, err
+	}
+	rate.SetBaseCurrency(baseCurrency)
+
+	return rate, nil
 }
 
 // UpdateShippingRateInput contains the data needed to update a shipping rate
 type UpdateShippingRateInput struct {
-	ID                    uint     `json:"id"`
-	BaseRate              float64  `json:"base_rate"`
-	MinOrderValue         float64  `json:"min_order_value"`
-	FreeShippingThreshold *float64 `json:"free_shipping_threshold"`
-	Active                bool     `json:"active"`
+	ID                    uint                `json:"id"`
+	BaseRate              float64             `json:"base_rate"`
+	MinOrderValue         float64             `json:"min_order_value"`
+	FreeShippingThreshold *float64            `json:"free_shipping_threshold"`
+	CurrencyBaseRates     *map[string]float64 `json:"currency_base_rates"` // nil leaves them unchanged
+	Active                bool                `json:"active"`
 }
 
 // UpdateShippingRate updates a shipping rate
 func (uc *ShippingUseCase) UpdateShippingRate(input UpdateShippingRateInput) (*entity.ShippingRate, error) {
 	// Get existing shipping rate
-	rate, err := uc.shippingRateRepo.GetByID(input.ID)
+	rate, err := uc.GetShippingRateByID(input.ID)
 	if err != nil {
 		return nil, err
 	}
-
-	baseRateCents := money.ToCents(input.BaseRate)
-	minOrderValueCents := money.ToCents(input.MinOrderValue)
-	freeShippingThresholdCents := money.ConvertNullableToCents(input.FreeShippingThreshold)
+	currency := rate.BaseCurrencyCode()
 
 	// Update fields
-	rate.BaseRate = baseRateCents
-	rate.MinOrderValue = minOrderValueCents
-	rate.FreeShippingThreshold = freeShippingThresholdCents
+	rate.BaseRate = money.ToMinor(input.BaseRate, currency)
+	rate.MinOrderValue = money.ToMinor(input.MinOrderValue, currency)
+	rate.FreeShippingThreshold = money.ConvertNullableToMinor(input.FreeShippingThreshold, currency)
 	rate.Active = input.Active
 	rate.UpdatedAt = time.Now()
 
+	if input.CurrencyBaseRates != nil {
+		if err := rate.SetCurrencyBaseRates(money.MapToMinor(*input.CurrencyBaseRates)); err != nil {
+			return nil, err
+		}
+	}
+
 	// Save changes
 	if err := uc.shippingRateRepo.Update(rate); err != nil {
 		return nil, err
@@ -329,6 +353,7 @@ type CalculateShippingOptionsInput struct {
 	Address     entity.Address `json:"address"`
 	OrderValue  int64          `json:"order_value"`  // in cents
 	OrderWeight float64        `json:"order_weight"` // in kg
+	Currency    string         `json:"currency"`     // optional, the currency of the order value and the costs
 }
 
 // CalculateShippingOptions calculates available shipping options for an order
@@ -339,12 +364,22 @@ func (uc *ShippingUseCase) CalculateShippingOptions(input CalculateShippingOptio
 		return nil, err
 	}
 
+	// Rates are configured in the default currency and converted unless an explicit rate is set
+	var baseCurrency, currency *entity.Currency
+	if input.Currency != "" {
+		baseCurrency, currency, err = resolveCurrencyConversion(uc.currencyRepo, input.Currency)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	options := &ShippingOptions{
 		Options: make([]*entity.ShippingOption, 0, len(rates)),
 	}
 
 	for _, rate := range rates {
-		cost, err := rate.CalculateShippingCost(input.OrderValue, input.OrderWeight)
+		rate.SetCurrencyConversion(baseCurrency, currency)
+		cost, err := rate.CalculateShippingCostForCurrency(input.OrderValue, input.OrderWeight, input.Currency)
 		if err != nil {
 			continue // Skip this rate if there's an error calculating cost
 		}
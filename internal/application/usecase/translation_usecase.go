@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// TranslationUseCase implements the use cases of catalog translations. Products, categories and
+// shipping methods hold their content in the default locale; translations override the name and
+// description in the other supported locales.
+type TranslationUseCase struct {
+	translationRepo    repository.TranslationRepository
+	productRepo        repository.ProductRepository
+	categoryRepo       repository.CategoryRepository
+	shippingMethodRepo repository.ShippingMethodRepository
+	defaultLocale      string
+	supportedLocales   []string
+}
+
+// NewTranslationUseCase creates a new TranslationUseCase
+func NewTranslationUseCase(
+	translationRepo repository.TranslationRepository,
+	productRepo repository.ProductRepository,
+	categoryRepo repository.CategoryRepository,
+	shippingMethodRepo repository.ShippingMethodRepository,
+	defaultLocale string,
+	supportedLocales []string,
+) *TranslationUseCase {
+	return &TranslationUseCase{
+		translationRepo:    translationRepo,
+		productRepo:        productRepo,
+		categoryRepo:       categoryRepo,
+		shippingMethodRepo: shippingMethodRepo,
+		defaultLocale:      defaultLocale,
+		supportedLocales:   supportedLocales,
+	}
+}
+
+// TranslationInput contains the name and description of a resource in a locale
+type TranslationInput struct {
+	Locale      string `json:"locale"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// GetTranslations returns the translations of a product, category or shipping method by locale
+func (uc *TranslationUseCase) GetTranslations(resourceType string, resourceID uint) ([]*entity.Translation, error) {
+	if err := uc.checkResource(resourceType, resourceID); err != nil {
+		return nil, err
+	}
+	return uc.translationRepo.ListByResource(resourceType, resourceID)
+}
+
+// SetTranslations replaces the translations of a product, category or shipping method
+func (uc *TranslationUseCase) SetTranslations(resourceType string, resourceID uint, inputs []TranslationInput) ([]*entity.Translation, error) {
+	if err := uc.checkResource(resourceType, resourceID); err != nil {
+		return nil, err
+	}
+
+	translations := make([]*entity.Translation, 0, len(inputs))
+	seen := make(map[string]bool, len(inputs))
+	for _, input := range inputs {
+		translation, err := entity.NewTranslation(resourceType, resourceID, input.Locale, input.Name, input.Description)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(uc.supportedLocales, translation.Locale) {
+			return nil, fmt.Errorf("locale %s is not supported", translation.Locale)
+		}
+		if translation.Locale == uc.defaultLocale {
+			return nil, fmt.Errorf("locale %s is the default locale and cannot be translated", translation.Locale)
+		}
+		if seen[translation.Locale] {
+			return nil, fmt.Errorf("locale %s is listed more than once", translation.Locale)
+		}
+		seen[translation.Locale] = true
+		translations = append(translations, translation)
+	}
+
+	if err := uc.translationRepo.SetForResource(resourceType, resourceID, translations); err != nil {
+		return nil, fmt.Errorf("failed to save translations: %w", err)
+	}
+	return uc.translationRepo.ListByResource(resourceType, resourceID)
+}
+
+// LocalizeProducts replaces the name and description of the products and their categories
+// with their translations in the locale. Untranslated products keep the default content.
+func (uc *TranslationUseCase) LocalizeProducts(locale string, products ...*entity.Product) error {
+	if uc.isDefaultLocale(locale) || len(products) == 0 {
+		return nil
+	}
+
+	productIDs := make([]uint, 0, len(products))
+	var categories []*entity.Category
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+		if product.Category.ID != 0 {
+			categories = append(categories, &product.Category)
+		}
+	}
+
+	translations, err := uc.translationRepo.GetByResources(entity.TranslationResourceProduct, productIDs, locale)
+	if err != nil {
+		return err
+	}
+	for _, product := range products {
+		if translation, ok := translations[product.ID]; ok {
+			product.Name = translation.Name
+			product.Description = translation.Description
+		}
+	}
+
+	return uc.localizeCategories(locale, categories)
+}
+
+// LocalizeCategories replaces the name and description of the categories, their parents, their
+// children and their breadcrumbs with their translations in the locale
+func (uc *TranslationUseCase) LocalizeCategories(locale string, categories ...*entity.Category) error {
+	if uc.isDefaultLocale(locale) || len(categories) == 0 {
+		return nil
+	}
+
+	related := make([]*entity.Category, 0, len(categories))
+	for _, category := range categories {
+		related = append(related, category)
+		if category.Parent != nil {
+			related = append(related, category.Parent)
+		}
+		related = append(related, category.Path...)
+		for i := range category.Children {
+			related = append(related, &category.Children[i])
+		}
+	}
+	return uc.localizeCategories(locale, related)
+}
+
+// LocalizeShippingOptions replaces the name and description of the shipping options with the
+// translations of their shipping methods in the locale
+func (uc *TranslationUseCase) LocalizeShippingOptions(locale string, options ...*entity.ShippingOption) error {
+	if uc.isDefaultLocale(locale) || len(options) == 0 {
+		return nil
+	}
+
+	methodIDs := make([]uint, len(options))
+	for i, option := range options {
+		methodIDs[i] = option.ShippingMethodID
+	}
+
+	translations, err := uc.translationRepo.GetByResources(entity.TranslationResourceShippingMethod, methodIDs, locale)
+	if err != nil {
+		return err
+	}
+	for _, option := range options {
+		if translation, ok := translations[option.ShippingMethodID]; ok {
+			option.Name = translation.Name
+			option.Description = translation.Description
+		}
+	}
+	return nil
+}
+
+// LocalizeCategoryFacets replaces the names of the category facets of a search with their translations in the locale
+func (uc *TranslationUseCase) LocalizeCategoryFacets(locale string, facets []entity.CategoryFacet) error {
+	if uc.isDefaultLocale(locale) || len(facets) == 0 {
+		return nil
+	}
+
+	categoryIDs := make([]uint, len(facets))
+	for i, facet := range facets {
+		categoryIDs[i] = facet.CategoryID
+	}
+
+	translations, err := uc.translationRepo.GetByResources(entity.TranslationResourceCategory, categoryIDs, locale)
+	if err != nil {
+		return err
+	}
+	for i := range facets {
+		if translation, ok := translations[facets[i].CategoryID]; ok {
+			facets[i].Name = translation.Name
+		}
+	}
+	return nil
+}
+
+// localizeCategories translates the categories in the locale
+func (uc *TranslationUseCase) localizeCategories(locale string, categories []*entity.Category) error {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	categoryIDs := make([]uint, len(categories))
+	for i, category := range categories {
+		categoryIDs[i] = category.ID
+	}
+
+	translations, err := uc.translationRepo.GetByResources(entity.TranslationResourceCategory, categoryIDs, locale)
+	if err != nil {
+		return err
+	}
+	for _, category := range categories {
+		if translation, ok := translations[category.ID]; ok {
+			category.Name = translation.Name
+			category.Description = translation.Description
+		}
+	}
+	return nil
+}
+
+// isDefaultLocale returns true when the content is shown in the default locale
+func (uc *TranslationUseCase) isDefaultLocale(locale string) bool {
+	return locale == "" || locale == uc.defaultLocale
+}
+
+// checkResource checks that the translated product, category or shipping method exists
+func (uc *TranslationUseCase) checkResource(resourceType string, resourceID uint) error {
+	var err error
+	switch resourceType {
+	case entity.TranslationResourceProduct:
+		_, err = uc.productRepo.GetByID(resourceID)
+	case entity.TranslationResourceCategory:
+		_, err = uc.categoryRepo.GetByID(resourceID)
+	case entity.TranslationResourceShippingMethod:
+		_, err = uc.shippingMethodRepo.GetByID(resourceID)
+	default:
+		return fmt.Errorf("invalid translation resource type %q", resourceType)
+	}
+	return err
+}
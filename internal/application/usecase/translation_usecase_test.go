@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestTranslationUseCase(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	currency, err := entity.NewCurrency("USD", "US Dollar", "$", 1, true, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(currency).Error)
+
+	// The test product is created in category 1, which becomes the parent of its new category
+	product := testutil.CreateTestProduct(t, db, 1)
+	var parent entity.Category
+	require.NoError(t, db.First(&parent, 1).Error)
+	category := testutil.CreateTestCategory(t, db, 2)
+	require.NoError(t, db.Model(category).Update("parent_id", parent.ID).Error)
+	require.NoError(t, db.Model(product).Update("category_id", category.ID).Error)
+	variant, err := entity.NewProductVariant("SHIRT-M", 10, 15000, 1, nil, nil, true)
+	require.NoError(t, err)
+	variant.ProductID = product.ID
+	require.NoError(t, db.Create(variant).Error)
+
+	method, err := entity.NewShippingMethod("Standard", "Delivered in 3-5 days", 4)
+	require.NoError(t, err)
+	shippingMethodRepo := gorm.NewShippingMethodRepository(db)
+	require.NoError(t, shippingMethodRepo.Create(method))
+
+	productRepo := gorm.NewProductRepository(db)
+	categoryRepo := gorm.NewCategoryRepository(db)
+	uc := NewTranslationUseCase(gorm.NewTranslationRepository(db), productRepo, categoryRepo, shippingMethodRepo, "en", []string{"en", "da", "de"})
+
+	t.Run("SetTranslations validates the locales", func(t *testing.T) {
+		_, err := uc.SetTranslations(entity.TranslationResourceProduct, 999, nil)
+		assert.ErrorContains(t, err, "not found")
+		_, err = uc.SetTranslations(entity.TranslationResourceProduct, product.ID, []TranslationInput{{Locale: "sv", Name: "Skjorta"}})
+		assert.ErrorContains(t, err, "locale sv is not supported")
+		_, err = uc.SetTranslations(entity.TranslationResourceProduct, product.ID, []TranslationInput{{Locale: "en", Name: "Shirt"}})
+		assert.ErrorContains(t, err, "locale en is the default locale")
+		_, err = uc.SetTranslations(entity.TranslationResourceProduct, product.ID, []TranslationInput{
+			{Locale: "da", Name: "Skjorte"}, {Locale: "DA", Name: "Skjorte"},
+		})
+		assert.ErrorContains(t, err, "locale da is listed more than once")
+	})
+
+	t.Run("SetTranslations replaces the translations of a resource", func(t *testing.T) {
+		translations, err := uc.SetTranslations(entity.TranslationResourceProduct, product.ID, []TranslationInput{
+			{Locale: "da", Name: "Skjorte", Description: "Af hør"},
+			{Locale: "de", Name: "Hemd", Description: "Aus Leinen"},
+		})
+		require.NoError(t, err)
+		assert.Len(t, translations, 2)
+
+		translations, err = uc.SetTranslations(entity.TranslationResourceProduct, product.ID, []TranslationInput{
+			{Locale: "da", Name: "Hørskjorte", Description: "Af hør"},
+		})
+		require.NoError(t, err)
+		require.Len(t, translations, 1)
+		assert.Equal(t, "Hørskjorte", translations[0].Name)
+
+		_, err = uc.SetTranslations(entity.TranslationResourceCategory, category.ID, []TranslationInput{{Locale: "da", Name: "Skjorter"}})
+		require.NoError(t, err)
+		_, err = uc.SetTranslations(entity.TranslationResourceCategory, parent.ID, []TranslationInput{{Locale: "da", Name: "Tøj"}})
+		require.NoError(t, err)
+		_, err = uc.SetTranslations(entity.TranslationResourceShippingMethod, method.ID, []TranslationInput{{Locale: "da", Name: "Standardlevering"}})
+		require.NoError(t, err)
+	})
+
+	t.Run("Products, categories and shipping options are shown in the locale", func(t *testing.T) {
+		loaded, err := productRepo.GetByID(product.ID)
+		require.NoError(t, err)
+		require.NoError(t, uc.LocalizeProducts("da", loaded))
+		assert.Equal(t, "Hørskjorte", loaded.Name)
+		assert.Equal(t, "Skjorter", loaded.Category.Name)
+
+		// The German translation was replaced, so the default content is shown
+		untranslated, err := productRepo.GetByID(product.ID)
+		require.NoError(t, err)
+		require.NoError(t, uc.LocalizeProducts("de", untranslated))
+		assert.Equal(t, product.Name, untranslated.Name)
+		assert.Equal(t, category.Name, untranslated.Category.Name)
+
+		loadedCategory, err := categoryRepo.GetByID(category.ID)
+		require.NoError(t, err)
+		loadedCategory.Parent = &parent
+		require.NoError(t, uc.LocalizeCategories("da", loadedCategory))
+		assert.Equal(t, "Skjorter", loadedCategory.Name)
+		assert.Equal(t, "Tøj", loadedCategory.Parent.Name)
+
+		facets := []entity.CategoryFacet{{CategoryID: category.ID, Name: category.Name}}
+		require.NoError(t, uc.LocalizeCategoryFacets("da", facets))
+		assert.Equal(t, "Skjorter", facets[0].Name)
+
+		option := &entity.ShippingOption{ShippingMethodID: method.ID, Name: method.Name, Description: method.Description}
+		require.NoError(t, uc.LocalizeShippingOptions("da", option))
+		assert.Equal(t, "Standardlevering", option.Name)
+		assert.Empty(t, option.Description)
+	})
+
+	t.Run("Checkout items are named in the locale of the checkout", func(t *testing.T) {
+		checkoutUseCase := NewCheckoutUseCase(
+			gorm.NewCheckoutRepository(db),
+			productRepo,
+			gorm.NewProductVariantRepository(db),
+			shippingMethodRepo,
+			gorm.NewShippingRateRepository(db),
+			gorm.NewDiscountRepository(db),
+			gorm.NewDiscountCodeRepository(db),
+			gorm.NewOrderRepository(db),
+			gorm.NewCurrencyRepository(db),
+			gorm.NewTransactionRepository(db),
+			gorm.NewPriceListRepository(db),
+			nil,
+			nil,
+			nil,
+			uc,
+		)
+
+		checkout, err := checkoutUseCase.GetOrCreateCheckoutBySessionID("session-1")
+		require.NoError(t, err)
+		checkout, err = checkoutUseCase.AddItemToCheckout(checkout.ID, CheckoutInput{SKU: "SHIRT-M", Quantity: 1})
+		require.NoError(t, err)
+		assert.Equal(t, product.Name, checkout.Items[0].ProductName)
+
+		checkout, err = checkoutUseCase.SetLocale(checkout, "da")
+		require.NoError(t, err)
+		assert.Equal(t, "da", checkout.Locale)
+		assert.Equal(t, "Hørskjorte", checkout.Items[0].ProductName)
+
+		// The product itself keeps its default name
+		stored, err := productRepo.GetByID(product.ID)
+		require.NoError(t, err)
+		assert.Equal(t, product.Name, stored.Name)
+
+		checkout, err = checkoutUseCase.GetOrCreateCheckoutBySessionID("session-1")
+		require.NoError(t, err)
+		assert.Equal(t, "da", checkout.Locale)
+		assert.Equal(t, "Hørskjorte", checkout.Items[0].ProductName)
+	})
+}
@@ -2,20 +2,63 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
 )
 
+var (
+	// ErrEmailNotVerified is returned when a user signs in before verifying their email address
+	ErrEmailNotVerified = errors.New("email address is not verified")
+	// ErrInvalidRefreshToken is returned for unknown, expired and revoked refresh tokens
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	// ErrEmptyPassword is returned when a password is reset to an empty password
+	ErrEmptyPassword = errors.New("password cannot be empty")
+)
+
+// UserOptions configures sessions and the password reset and email verification flows
+type UserOptions struct {
+	RefreshTokenTTL          time.Duration // How long a refresh token stays valid
+	PasswordResetURL         string        // Storefront page of password reset links, the token is added as the token query parameter
+	EmailVerificationURL     string        // Storefront page of email verification links
+	PasswordResetTTL         time.Duration // How long a password reset link stays valid
+	EmailVerificationTTL     time.Duration // How long an email verification link stays valid
+	RequireEmailVerification bool          // Whether customers must verify their email address before signing in
+}
+
 // UserUseCase implements user-related use cases
 type UserUseCase struct {
-	userRepo repository.UserRepository
+	userRepo         repository.UserRepository
+	tokenRepo        repository.UserTokenRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	roleUseCase      *RoleUseCase
+	emailSvc         service.EmailService
+	options          UserOptions
+	now              func() time.Time
 }
 
 // NewUserUseCase creates a new UserUseCase
-func NewUserUseCase(userRepo repository.UserRepository) *UserUseCase {
+func NewUserUseCase(
+	userRepo repository.UserRepository,
+	tokenRepo repository.UserTokenRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	roleUseCase *RoleUseCase,
+	emailSvc service.EmailService,
+	options UserOptions,
+) *UserUseCase {
 	return &UserUseCase{
-		userRepo: userRepo,
+		userRepo:         userRepo,
+		tokenRepo:        tokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		roleUseCase:      roleUseCase,
+		emailSvc:         emailSvc,
+		options:          options,
+		now:              time.Now,
 	}
 }
 
@@ -46,6 +89,11 @@ func (uc *UserUseCase) Register(input RegisterInput) (*entity.User, error) {
 		return nil, err
 	}
 
+	// The account exists even when the link cannot be created; the customer can request a new one
+	if err := uc.sendEmailVerification(user); err != nil {
+		log.Printf("Failed to create email verification for user %d: %v", user.ID, err)
+	}
+
 	return user, nil
 }
 
@@ -68,9 +116,27 @@ func (uc *UserUseCase) Login(input LoginInput) (*entity.User, error) {
 		return nil, errors.New("invalid email or password")
 	}
 
+	if uc.MustVerifyEmail(user) {
+		return nil, ErrEmailNotVerified
+	}
+
 	return user, nil
 }
 
+// MustVerifyEmail returns true when the user cannot sign in before verifying their email address.
+// Staff, whose role has permissions, can always sign in.
+func (uc *UserUseCase) MustVerifyEmail(user *entity.User) bool {
+	if !uc.options.RequireEmailVerification || user.EmailVerified {
+		return false
+	}
+	permissions, err := uc.roleUseCase.GetPermissions(user.Role)
+	if err != nil {
+		log.Printf("Failed to get permissions of role %s: %v", user.Role, err)
+		return true
+	}
+	return len(permissions) == 0
+}
+
 // GetUserByID retrieves a user by ID
 func (uc *UserUseCase) GetUserByID(id uint) (*entity.User, error) {
 	return uc.userRepo.GetByID(id)
@@ -104,7 +170,7 @@ type ChangePasswordInput struct {
 	NewPassword     string `json:"new_password"`
 }
 
-// ChangePassword changes a user's password
+// ChangePassword changes a user's password and signs the user out of all sessions
 func (uc *UserUseCase) ChangePassword(id uint, input ChangePasswordInput) error {
 	user, err := uc.userRepo.GetByID(id)
 	if err != nil {
@@ -121,13 +187,243 @@ func (uc *UserUseCase) ChangePassword(id uint, input ChangePasswordInput) error
 		return err
 	}
 
-	return uc.userRepo.Update(user)
+	return uc.revokeSessions(user)
 }
 
-func (uc *UserUseCase) ListUsers(offset, limit int) ([]*entity.User, error) {
-	users, err := uc.userRepo.List(offset, limit)
+// ListUsers lists the users whose custom fields match the filters
+func (uc *UserUseCase) ListUsers(offset, limit int, customFields []entity.CustomFieldFilter) ([]*entity.User, error) {
+	users, err := uc.userRepo.List(offset, limit, customFields)
 	if err != nil {
 		return nil, err
 	}
 	return users, nil
 }
+
+// RequestPasswordReset emails a password reset link to the user with the email address.
+// Unknown addresses are ignored, so the response does not reveal which addresses have accounts.
+func (uc *UserUseCase) RequestPasswordReset(email string) error {
+	user, err := uc.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil
+	}
+
+	// Only the latest link works
+	if err := uc.tokenRepo.DeleteUnusedByUser(user.ID, entity.UserTokenPasswordReset); err != nil {
+		return err
+	}
+	token, plain, err := entity.NewUserToken(user.ID, entity.UserTokenPasswordReset, uc.options.PasswordResetTTL, uc.now())
+	if err != nil {
+		return err
+	}
+	if err := uc.tokenRepo.Create(token); err != nil {
+		return err
+	}
+
+	resetURL, err := tokenURL(uc.options.PasswordResetURL, plain)
+	if err != nil {
+		return err
+	}
+	// Failures are only logged, as an error for existing accounts would reveal which addresses have one
+	if err := uc.emailSvc.SendPasswordReset(user, resetURL, token.ExpiresAt); err != nil {
+		log.Printf("Failed to send password reset email to user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// ResetPasswordInput contains the data needed to reset a password with a reset link
+type ResetPasswordInput struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// ResetPassword sets a new password with the token of a password reset link and signs the user out of all sessions.
+// The token can only be used once. Following the link proves access to the inbox, so the email address is verified too.
+func (uc *UserUseCase) ResetPassword(input ResetPasswordInput) error {
+	if input.NewPassword == "" {
+		return ErrEmptyPassword
+	}
+
+	token, err := uc.tokenRepo.Consume(entity.HashUserToken(input.Token), entity.UserTokenPasswordReset, uc.now())
+	if err != nil {
+		return err
+	}
+	user, err := uc.userRepo.GetByID(token.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := user.UpdatePassword(input.NewPassword); err != nil {
+		return err
+	}
+	user.VerifyEmail(uc.now())
+	if err := uc.revokeSessions(user); err != nil {
+		return err
+	}
+	return uc.tokenRepo.DeleteUnusedByUser(user.ID, entity.UserTokenPasswordReset)
+}
+
+// RequestEmailVerification emails a new verification link to the user with the email address.
+// Unknown and already verified addresses are ignored.
+func (uc *UserUseCase) RequestEmailVerification(email string) error {
+	user, err := uc.userRepo.GetByEmail(email)
+	if err != nil || user.EmailVerified {
+		return nil
+	}
+	return uc.sendEmailVerification(user)
+}
+
+// VerifyEmail verifies the email address of a user with the token of a verification link
+func (uc *UserUseCase) VerifyEmail(plainToken string) (*entity.User, error) {
+	token, err := uc.tokenRepo.Consume(entity.HashUserToken(plainToken), entity.UserTokenEmailVerification, uc.now())
+	if err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.GetByID(token.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.VerifyEmail(uc.now())
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	if err := uc.tokenRepo.DeleteUnusedByUser(user.ID, entity.UserTokenEmailVerification); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// StartSession starts a session of the signed in user and returns its refresh token
+func (uc *UserUseCase) StartSession(user *entity.User) (string, error) {
+	token, plain, err := entity.NewRefreshToken(user.ID, "", uc.options.RefreshTokenTTL, uc.now())
+	if err != nil {
+		return "", err
+	}
+	if err := uc.refreshTokenRepo.Create(token); err != nil {
+		return "", err
+	}
+	return plain, nil
+}
+
+// RefreshSession exchanges a refresh token for a new one in the same session and returns the user of the session.
+// A refresh token can only be used once; using a revoked token again ends the session, as the token may have been stolen.
+func (uc *UserUseCase) RefreshSession(plainToken string) (*entity.User, string, error) {
+	now := uc.now()
+	token, err := uc.refreshTokenRepo.GetByHash(entity.HashUserToken(plainToken))
+	if err != nil {
+		return nil, "", ErrInvalidRefreshToken
+	}
+	if token.RevokedAt != nil {
+		if err := uc.refreshTokenRepo.RevokeSession(token.SessionID, now); err != nil {
+			return nil, "", err
+		}
+		return nil, "", ErrInvalidRefreshToken
+	}
+	if !token.IsActive(now) {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	// A concurrent request rotated the token first
+	revoked, err := uc.refreshTokenRepo.Revoke(token.ID, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if !revoked {
+		if err := uc.refreshTokenRepo.RevokeSession(token.SessionID, now); err != nil {
+			return nil, "", err
+		}
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	user, err := uc.userRepo.GetByID(token.UserID)
+	if err != nil {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	next, plain, err := entity.NewRefreshToken(user.ID, token.SessionID, uc.options.RefreshTokenTTL, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := uc.refreshTokenRepo.Create(next); err != nil {
+		return nil, "", err
+	}
+	return user, plain, nil
+}
+
+// Logout ends the session of the refresh token. Unknown tokens are ignored.
+// Access tokens already issued in the session stay valid until they expire.
+func (uc *UserUseCase) Logout(plainToken string) error {
+	token, err := uc.refreshTokenRepo.GetByHash(entity.HashUserToken(plainToken))
+	if err != nil {
+		return nil
+	}
+	return uc.refreshTokenRepo.RevokeSession(token.SessionID, uc.now())
+}
+
+// LogoutAll ends all sessions of the user, invalidating their refresh and access tokens
+func (uc *UserUseCase) LogoutAll(userID uint) error {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	return uc.revokeSessions(user)
+}
+
+// ValidateTokenVersion checks that an access token with the token version was issued after the
+// user last signed out of all sessions, and returns the user
+func (uc *UserUseCase) ValidateTokenVersion(userID, tokenVersion uint) (*entity.User, error) {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TokenVersion != tokenVersion {
+		return nil, errors.New("token has been revoked")
+	}
+	return user, nil
+}
+
+// revokeSessions saves the user with a new token version and revokes their refresh tokens
+func (uc *UserUseCase) revokeSessions(user *entity.User) error {
+	user.RevokeSessions()
+	if err := uc.userRepo.Update(user); err != nil {
+		return err
+	}
+	return uc.refreshTokenRepo.RevokeByUser(user.ID, uc.now())
+}
+
+// sendEmailVerification replaces the verification links of the user with a new one and emails it
+func (uc *UserUseCase) sendEmailVerification(user *entity.User) error {
+	if err := uc.tokenRepo.DeleteUnusedByUser(user.ID, entity.UserTokenEmailVerification); err != nil {
+		return err
+	}
+	token, plain, err := entity.NewUserToken(user.ID, entity.UserTokenEmailVerification, uc.options.EmailVerificationTTL, uc.now())
+	if err != nil {
+		return err
+	}
+	if err := uc.tokenRepo.Create(token); err != nil {
+		return err
+	}
+
+	verificationURL, err := tokenURL(uc.options.EmailVerificationURL, plain)
+	if err != nil {
+		return err
+	}
+	// Failures are only logged, as an error for existing accounts would reveal which addresses have one;
+	// the customer can request a new link
+	if err := uc.emailSvc.SendEmailVerification(user, verificationURL, token.ExpiresAt); err != nil {
+		log.Printf("Failed to send email verification to user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// tokenURL adds the token to the query of a link
+func tokenURL(base, token string) (string, error) {
+	link, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid link URL %q: %w", base, err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
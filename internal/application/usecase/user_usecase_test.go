@@ -0,0 +1,345 @@
+package usecase
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+	"github.com/zenfulcode/commercify/internal/infrastructure/repository/gorm"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+// sentLink is a password reset or verification link sent by the recordingEmailService
+type sentLink struct {
+	user      *entity.User
+	url       string
+	expiresAt time.Time
+}
+
+// recordingEmailService records the links it is asked to send
+type recordingEmailService struct {
+	resets        []sentLink
+	verifications []sentLink
+	failResets    bool // Whether sending password reset links fails
+	failVerifies  bool // Whether sending verification links fails
+}
+
+func (s *recordingEmailService) SendEmail(service.EmailData) error { return nil }
+func (s *recordingEmailService) SendOrderConfirmation(*entity.Order, *entity.User) error {
+	return nil
+}
+func (s *recordingEmailService) SendOrderNotification(*entity.Order, *entity.User) error {
+	return nil
+}
+func (s *recordingEmailService) SendOrderShipped(*entity.Order, *entity.User, string, string) error {
+	return nil
+}
+
+func (s *recordingEmailService) SendPasswordReset(user *entity.User, resetURL string, expiresAt time.Time) error {
+	if s.failResets {
+		return errors.New("mail server unavailable")
+	}
+	s.resets = append(s.resets, sentLink{user: user, url: resetURL, expiresAt: expiresAt})
+	return nil
+}
+
+func (s *recordingEmailService) SendEmailVerification(user *entity.User, verificationURL string, expiresAt time.Time) error {
+	if s.failVerifies {
+		return errors.New("mail server unavailable")
+	}
+	s.verifications = append(s.verifications, sentLink{user: user, url: verificationURL, expiresAt: expiresAt})
+	return nil
+}
+
+// linkToken returns the token query parameter of a sent link
+func linkToken(t *testing.T, link sentLink) string {
+	parsed, err := url.Parse(link.url)
+	require.NoError(t, err)
+	token := parsed.Query().Get("token")
+	require.NotEmpty(t, token)
+	return token
+}
+
+func TestUserUseCase_PasswordResetAndEmailVerification(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	emails := &recordingEmailService{}
+	userRepo := gorm.NewUserRepository(db)
+	roleUseCase := NewRoleUseCase(gorm.NewRoleRepository(db), userRepo)
+	uc := NewUserUseCase(userRepo, gorm.NewUserTokenRepository(db), gorm.NewRefreshTokenRepository(db), roleUseCase, emails, UserOptions{
+		RefreshTokenTTL:          30 * 24 * time.Hour,
+		PasswordResetURL:         "https://shop.example.com/reset-password?ref=email",
+		EmailVerificationURL:     "https://shop.example.com/verify-email",
+		PasswordResetTTL:         time.Hour,
+		EmailVerificationTTL:     48 * time.Hour,
+		RequireEmailVerification: true,
+	})
+	uc.now = func() time.Time { return now }
+
+	user, err := uc.Register(RegisterInput{
+		Email:     "jane@example.com",
+		Password:  "password123",
+		FirstName: "Jane",
+		LastName:  "Doe",
+	})
+	require.NoError(t, err)
+	assert.False(t, user.EmailVerified)
+	assert.True(t, uc.MustVerifyEmail(user))
+
+	t.Run("Register sends a verification link", func(t *testing.T) {
+		require.Len(t, emails.verifications, 1)
+		link := emails.verifications[0]
+		assert.Equal(t, user.ID, link.user.ID)
+		assert.Contains(t, link.url, "https://shop.example.com/verify-email?token=")
+		assert.Equal(t, now.Add(48*time.Hour), link.expiresAt)
+	})
+
+	t.Run("Login is blocked until the email address is verified", func(t *testing.T) {
+		_, err := uc.Login(LoginInput{Email: "jane@example.com", Password: "password123"})
+		assert.ErrorIs(t, err, ErrEmailNotVerified)
+
+		_, err = uc.Login(LoginInput{Email: "jane@example.com", Password: "wrong"})
+		assert.EqualError(t, err, "invalid email or password")
+	})
+
+	t.Run("Staff can sign in without verifying", func(t *testing.T) {
+		admin, err := entity.NewUser("admin@example.com", "adminpass", "Ada", "Admin", entity.RoleAdmin)
+		require.NoError(t, err)
+		require.NoError(t, userRepo.Create(admin))
+
+		_, err = uc.Login(LoginInput{Email: "admin@example.com", Password: "adminpass"})
+		assert.NoError(t, err)
+
+		_, err = roleUseCase.CreateRole(Grantor{Permissions: entity.AllPermissions, IsAdmin: true}, CreateRoleInput{
+			Name:            "warehouse",
+			UpdateRoleInput: UpdateRoleInput{Permissions: []entity.Permission{entity.PermissionOrdersRead}},
+		})
+		require.NoError(t, err)
+		staff, err := entity.NewUser("staff@example.com", "staffpass", "Sam", "Staff", "warehouse")
+		require.NoError(t, err)
+		require.NoError(t, userRepo.Create(staff))
+
+		_, err = uc.Login(LoginInput{Email: "staff@example.com", Password: "staffpass"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failing to send a verification link does not reveal the account", func(t *testing.T) {
+		emails.failVerifies = true
+		defer func() { emails.failVerifies = false }()
+
+		assert.NoError(t, uc.RequestEmailVerification("jane@example.com"))
+		assert.Len(t, emails.verifications, 1)
+	})
+
+	t.Run("Requesting a new verification link replaces the old one", func(t *testing.T) {
+		require.NoError(t, uc.RequestEmailVerification("jane@example.com"))
+		require.Len(t, emails.verifications, 2)
+
+		_, err := uc.VerifyEmail(linkToken(t, emails.verifications[0]))
+		assert.ErrorIs(t, err, repository.ErrTokenInvalid)
+
+		verified, err := uc.VerifyEmail(linkToken(t, emails.verifications[1]))
+		require.NoError(t, err)
+		assert.True(t, verified.EmailVerified)
+		require.NotNil(t, verified.EmailVerifiedAt)
+
+		_, err = uc.VerifyEmail(linkToken(t, emails.verifications[1]))
+		assert.ErrorIs(t, err, repository.ErrTokenUsed)
+
+		_, err = uc.Login(LoginInput{Email: "jane@example.com", Password: "password123"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Verified and unknown addresses get no verification link", func(t *testing.T) {
+		require.NoError(t, uc.RequestEmailVerification("jane@example.com"))
+		require.NoError(t, uc.RequestEmailVerification("nobody@example.com"))
+		assert.Len(t, emails.verifications, 2)
+	})
+
+	t.Run("Unknown addresses get no password reset link", func(t *testing.T) {
+		require.NoError(t, uc.RequestPasswordReset("nobody@example.com"))
+		assert.Empty(t, emails.resets)
+	})
+
+	t.Run("Failing to send a reset link does not reveal the account", func(t *testing.T) {
+		emails.failResets = true
+		defer func() { emails.failResets = false }()
+
+		assert.NoError(t, uc.RequestPasswordReset("jane@example.com"))
+		assert.Empty(t, emails.resets)
+	})
+
+	t.Run("Reset links can be used once", func(t *testing.T) {
+		require.NoError(t, uc.RequestPasswordReset("jane@example.com"))
+		require.Len(t, emails.resets, 1)
+		link := emails.resets[0]
+		assert.Contains(t, link.url, "ref=email")
+		assert.Equal(t, now.Add(time.Hour), link.expiresAt)
+		token := linkToken(t, link)
+
+		err := uc.ResetPassword(ResetPasswordInput{Token: token, NewPassword: ""})
+		assert.ErrorIs(t, err, ErrEmptyPassword)
+
+		require.NoError(t, uc.ResetPassword(ResetPasswordInput{Token: token, NewPassword: "newpassword"}))
+		_, err = uc.Login(LoginInput{Email: "jane@example.com", Password: "newpassword"})
+		assert.NoError(t, err)
+
+		err = uc.ResetPassword(ResetPasswordInput{Token: token, NewPassword: "another"})
+		assert.ErrorIs(t, err, repository.ErrTokenUsed)
+	})
+
+	t.Run("Reset links expire", func(t *testing.T) {
+		require.NoError(t, uc.RequestPasswordReset("jane@example.com"))
+		require.Len(t, emails.resets, 2)
+		token := linkToken(t, emails.resets[1])
+
+		uc.now = func() time.Time { return now.Add(time.Hour) }
+		defer func() { uc.now = func() time.Time { return now } }()
+
+		err := uc.ResetPassword(ResetPasswordInput{Token: token, NewPassword: "another"})
+		assert.ErrorIs(t, err, repository.ErrTokenExpired)
+	})
+
+	t.Run("Resetting the password verifies the email address", func(t *testing.T) {
+		other, err := uc.Register(RegisterInput{
+			Email:     "john@example.com",
+			Password:  "password123",
+			FirstName: "John",
+			LastName:  "Doe",
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, uc.RequestPasswordReset("john@example.com"))
+		token := linkToken(t, emails.resets[len(emails.resets)-1])
+		require.NoError(t, uc.ResetPassword(ResetPasswordInput{Token: token, NewPassword: "newpassword"}))
+
+		reloaded, err := userRepo.GetByID(other.ID)
+		require.NoError(t, err)
+		assert.True(t, reloaded.EmailVerified)
+	})
+}
+
+func TestUserUseCase_Sessions(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	userRepo := gorm.NewUserRepository(db)
+	uc := NewUserUseCase(userRepo, gorm.NewUserTokenRepository(db), gorm.NewRefreshTokenRepository(db),
+		NewRoleUseCase(gorm.NewRoleRepository(db), userRepo), &recordingEmailService{}, UserOptions{
+			RefreshTokenTTL:      24 * time.Hour,
+			PasswordResetTTL:     time.Hour,
+			EmailVerificationTTL: time.Hour,
+		})
+	uc.now = func() time.Time { return now }
+
+	user, err := uc.Register(RegisterInput{
+		Email:     "jane@example.com",
+		Password:  "password123",
+		FirstName: "Jane",
+		LastName:  "Doe",
+	})
+	require.NoError(t, err)
+	_, err = uc.ValidateTokenVersion(user.ID, 0)
+	require.NoError(t, err)
+
+	t.Run("Refresh tokens rotate", func(t *testing.T) {
+		first, err := uc.StartSession(user)
+		require.NoError(t, err)
+
+		sessionUser, second, err := uc.RefreshSession(first)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, sessionUser.ID)
+		assert.NotEqual(t, first, second)
+
+		_, third, err := uc.RefreshSession(second)
+		require.NoError(t, err)
+		assert.NotEmpty(t, third)
+	})
+
+	t.Run("Reusing a refresh token ends the session", func(t *testing.T) {
+		first, err := uc.StartSession(user)
+		require.NoError(t, err)
+		other, err := uc.StartSession(user)
+		require.NoError(t, err)
+
+		_, second, err := uc.RefreshSession(first)
+		require.NoError(t, err)
+
+		_, _, err = uc.RefreshSession(first)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+		_, _, err = uc.RefreshSession(second)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+		// Other sessions are not affected
+		_, _, err = uc.RefreshSession(other)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Refresh tokens expire", func(t *testing.T) {
+		token, err := uc.StartSession(user)
+		require.NoError(t, err)
+
+		uc.now = func() time.Time { return now.Add(24 * time.Hour) }
+		defer func() { uc.now = func() time.Time { return now } }()
+
+		_, _, err = uc.RefreshSession(token)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+		_, _, err = uc.RefreshSession("unknown")
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+
+	t.Run("Logout ends the session", func(t *testing.T) {
+		token, err := uc.StartSession(user)
+		require.NoError(t, err)
+		_, rotated, err := uc.RefreshSession(token)
+		require.NoError(t, err)
+
+		require.NoError(t, uc.Logout(token))
+		_, _, err = uc.RefreshSession(rotated)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+		assert.NoError(t, uc.Logout("unknown"))
+	})
+
+	t.Run("LogoutAll revokes refresh and access tokens", func(t *testing.T) {
+		token, err := uc.StartSession(user)
+		require.NoError(t, err)
+
+		require.NoError(t, uc.LogoutAll(user.ID))
+		_, err = uc.ValidateTokenVersion(user.ID, 0)
+		assert.Error(t, err)
+		_, err = uc.ValidateTokenVersion(user.ID, 1)
+		assert.NoError(t, err)
+
+		_, _, err = uc.RefreshSession(token)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+
+	t.Run("Changing the password ends all sessions", func(t *testing.T) {
+		token, err := uc.StartSession(user)
+		require.NoError(t, err)
+
+		require.NoError(t, uc.ChangePassword(user.ID, ChangePasswordInput{
+			CurrentPassword: "password123",
+			NewPassword:     "newpassword",
+		}))
+		_, err = uc.ValidateTokenVersion(user.ID, 1)
+		assert.Error(t, err)
+		_, err = uc.ValidateTokenVersion(user.ID, 2)
+		assert.NoError(t, err)
+
+		_, _, err = uc.RefreshSession(token)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+}
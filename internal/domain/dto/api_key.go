@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// APIKeyDTO represents an API key. The key itself is only returned once, when it is created.
+type APIKeyDTO struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	Scopes      []string   `json:"scopes"`
+	CreatedByID *uint      `json:"created_by_id,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	Key         string     `json:"key,omitempty"`
+}
+
+// APIKeyRequestDTO represents a request made with an API key
+type APIKeyRequestDTO struct {
+	ID        uint      `json:"id"`
+	APIKeyID  uint      `json:"api_key_id"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+}
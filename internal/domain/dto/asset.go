@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// AssetDTO represents an uploaded media file
+type AssetDTO struct {
+	ID          uint                 `json:"id"`
+	FileName    string               `json:"file_name"`
+	URL         string               `json:"url"`
+	ContentType string               `json:"content_type"`
+	Size        int64                `json:"size"`
+	Width       int                  `json:"width,omitempty"`
+	Height      int                  `json:"height,omitempty"`
+	Derivatives []AssetDerivativeDTO `json:"derivatives"`
+	CreatedAt   time.Time            `json:"created_at"`
+}
+
+// AssetDerivativeDTO represents a resized copy of an image asset
+type AssetDerivativeDTO struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+// ProductAssetDTO represents an asset linked to a product or one of its variants
+type ProductAssetDTO struct {
+	ID        uint      `json:"id"`
+	ProductID uint      `json:"product_id"`
+	VariantID *uint     `json:"variant_id,omitempty"`
+	AssetID   uint      `json:"asset_id"`
+	Position  int       `json:"position"`
+	AltText   string    `json:"alt_text"`
+	Asset     *AssetDTO `json:"asset,omitempty"`
+}
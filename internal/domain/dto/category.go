@@ -8,8 +8,28 @@ import (
 type CategoryDTO struct {
 	ID          uint      `json:"id"`
 	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
 	Description string    `json:"description"`
+	SEO         SEODTO    `json:"seo"`
 	ParentID    *uint     `json:"parent_id"`
+	Position    int       `json:"position"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Path from the root category down to the category
+	Breadcrumbs []CategoryBreadcrumbDTO `json:"breadcrumbs,omitempty"`
+}
+
+// CategoryBreadcrumbDTO represents a category in a breadcrumb path
+type CategoryBreadcrumbDTO struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// CategoryTreeDTO represents a category with its children in the category tree
+type CategoryTreeDTO struct {
+	CategoryDTO
+	ProductCount int               `json:"product_count"` // Products in the category and its descendants
+	Children     []CategoryTreeDTO `json:"children"`
 }
@@ -6,27 +6,38 @@ import (
 
 // CheckoutDTO represents a checkout session in the system
 type CheckoutDTO struct {
-	ID               uint                `json:"id"`
-	UserID           uint                `json:"user_id,omitempty"`
-	SessionID        string              `json:"session_id,omitempty"`
-	Items            []CheckoutItemDTO   `json:"items"`
-	Status           string              `json:"status"`
-	ShippingAddress  AddressDTO          `json:"shipping_address"`
-	BillingAddress   AddressDTO          `json:"billing_address"`
-	ShippingMethodID uint                `json:"shipping_method_id"`
-	ShippingOption   *ShippingOptionDTO  `json:"shipping_option,omitempty"`
-	PaymentProvider  string              `json:"payment_provider,omitempty"`
-	TotalAmount      float64             `json:"total_amount"`
-	ShippingCost     float64             `json:"shipping_cost"`
-	TotalWeight      float64             `json:"total_weight"`
-	CustomerDetails  CustomerDetailsDTO  `json:"customer_details"`
-	Currency         string              `json:"currency"`
-	DiscountCode     string              `json:"discount_code,omitempty"`
-	DiscountAmount   float64             `json:"discount_amount"`
-	FinalAmount      float64             `json:"final_amount"`
-	AppliedDiscount  *AppliedDiscountDTO `json:"applied_discount,omitempty"`
-	LastActivityAt   time.Time           `json:"last_activity_at"`
-	ExpiresAt        time.Time           `json:"expires_at"`
+	ID               uint                     `json:"id"`
+	UserID           uint                     `json:"user_id,omitempty"`
+	SessionID        string                   `json:"session_id,omitempty"`
+	Items            []CheckoutItemDTO        `json:"items"`
+	Status           string                   `json:"status"`
+	ShippingAddress  AddressDTO               `json:"shipping_address"`
+	BillingAddress   AddressDTO               `json:"billing_address"`
+	ShippingMethodID uint                     `json:"shipping_method_id"`
+	ShippingOption   *ShippingOptionDTO       `json:"shipping_option,omitempty"`
+	PaymentProvider  string                   `json:"payment_provider,omitempty"`
+	TotalAmount      float64                  `json:"total_amount"`
+	ShippingCost     float64                  `json:"shipping_cost"`
+	TotalWeight      float64                  `json:"total_weight"`
+	CustomerDetails  CustomerDetailsDTO       `json:"customer_details"`
+	Currency         string                   `json:"currency"`
+	Locale           string                   `json:"locale,omitempty"`
+	DiscountCode     string                   `json:"discount_code,omitempty"`
+	DiscountAmount   float64                  `json:"discount_amount"`
+	FinalAmount      float64                  `json:"final_amount"`
+	AppliedDiscount  *AppliedDiscountDTO      `json:"applied_discount,omitempty"`
+	PriceChanges     []CheckoutPriceChangeDTO `json:"price_changes,omitempty"` // Items repriced since the last response
+	LastActivityAt   time.Time                `json:"last_activity_at"`
+	ExpiresAt        time.Time                `json:"expires_at"`
+}
+
+// CheckoutPriceChangeDTO tells the shopper that the unit price of an item changed, e.g. when a sale started or ended
+type CheckoutPriceChangeDTO struct {
+	SKU           string  `json:"sku"`
+	ProductName   string  `json:"product_name"`
+	VariantName   string  `json:"variant_name,omitempty"`
+	PreviousPrice float64 `json:"previous_price"`
+	Price         float64 `json:"price"`
 }
 
 // CheckoutItemDTO represents an item in a checkout
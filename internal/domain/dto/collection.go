@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+// CollectionDTO represents a manual or smart collection of products
+type CollectionDTO struct {
+	ID          uint                `json:"id"`
+	Name        string              `json:"name"`
+	Slug        string              `json:"slug"`
+	Description string              `json:"description"`
+	SEO         SEODTO              `json:"seo"`
+	Type        string              `json:"type"`
+	Rules       *CollectionRulesDTO `json:"rules,omitempty"` // Only set for smart collections
+	Active      bool                `json:"active"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// CollectionRulesDTO represents the rules selecting the products of a smart collection
+type CollectionRulesDTO struct {
+	CategoryIDs       []uint              `json:"category_ids,omitempty"`
+	Attributes        map[string][]string `json:"attributes,omitempty"`
+	MinPrice          float64             `json:"min_price,omitempty"`
+	MaxPrice          float64             `json:"max_price,omitempty"`
+	Currency          string              `json:"currency,omitempty"`
+	CreatedAfter      *time.Time          `json:"created_after,omitempty"`
+	CreatedWithinDays int                 `json:"created_within_days,omitempty"`
+	InStockOnly       bool                `json:"in_stock_only,omitempty"`
+}
@@ -17,6 +17,13 @@ type CustomerDetailsDTO struct {
 	FullName string `json:"full_name"`
 }
 
+// SEODTO represents the search engine metadata of a product or category page
+type SEODTO struct {
+	MetaTitle       string `json:"meta_title,omitempty"`
+	MetaDescription string `json:"meta_description,omitempty"`
+	CanonicalURL    string `json:"canonical_url,omitempty"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error string `json:"error"`
@@ -6,12 +6,25 @@ import (
 
 // CurrencyDTO represents a currency entity
 type CurrencyDTO struct {
-	Code         string    `json:"code"`
-	Name         string    `json:"name"`
-	Symbol       string    `json:"symbol"`
-	ExchangeRate float64   `json:"exchange_rate"`
-	IsEnabled    bool      `json:"is_enabled"`
-	IsDefault    bool      `json:"is_default"`
+	Code             string    `json:"code"`
+	Name             string    `json:"name"`
+	Symbol           string    `json:"symbol"`
+	ExchangeRate     float64   `json:"exchange_rate"`
+	IsEnabled        bool      `json:"is_enabled"`
+	IsDefault        bool      `json:"is_default"`
+	MinorUnits       int       `json:"minor_units"`
+	RoundingStrategy string    `json:"rounding_strategy"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// ExchangeRateHistoryDTO represents a recorded exchange rate change
+type ExchangeRateHistoryDTO struct {
+	ID           uint      `json:"id"`
+	CurrencyCode string    `json:"currency_code"`
+	BaseCurrency string    `json:"base_currency"`
+	PreviousRate float64   `json:"previous_rate"`
+	Rate         float64   `json:"rate"`
+	Source       string    `json:"source"`
 	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
 }
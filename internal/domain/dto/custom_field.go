@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// CustomFieldDefinitionDTO represents the definition of a custom field
+type CustomFieldDefinitionDTO struct {
+	ID        uint      `json:"id"`
+	Resource  string    `json:"resource"`
+	Key       string    `json:"key"`
+	Label     string    `json:"label"`
+	Type      string    `json:"type"`
+	Required  bool      `json:"required"`
+	Public    bool      `json:"public"`
+	Options   []string  `json:"options,omitempty"`
+	Pattern   string    `json:"pattern,omitempty"`
+	Min       *float64  `json:"min,omitempty"`
+	Max       *float64  `json:"max,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// CustomerGroupDTO represents a customer group
+type CustomerGroupDTO struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	PriceListID *uint     `json:"price_list_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PriceListDTO represents a price list with its entries
+type PriceListDTO struct {
+	ID          uint                `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Active      bool                `json:"active"`
+	Entries     []PriceListEntryDTO `json:"entries"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// PriceListEntryDTO represents the price of a variant in a currency from a minimum quantity onwards
+type PriceListEntryDTO struct {
+	ID          uint    `json:"id"`
+	VariantID   uint    `json:"variant_id"`
+	SKU         string  `json:"sku,omitempty"`
+	Currency    string  `json:"currency"`
+	MinQuantity int     `json:"min_quantity"`
+	Price       float64 `json:"price"`
+}
+
+// PriceBreakDTO represents a quantity break of a customer-specific variant price
+type PriceBreakDTO struct {
+	MinQuantity int     `json:"min_quantity"`
+	Price       float64 `json:"price"`
+}
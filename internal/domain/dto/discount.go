@@ -6,22 +6,24 @@ import (
 
 // DiscountDTO represents a discount in the system
 type DiscountDTO struct {
-	ID               uint      `json:"id"`
-	Code             string    `json:"code"`
-	Type             string    `json:"type"`
-	Method           string    `json:"method"`
-	Value            float64   `json:"value"`
-	MinOrderValue    float64   `json:"min_order_value"`
-	MaxDiscountValue float64   `json:"max_discount_value"`
-	ProductIDs       []uint    `json:"product_ids,omitempty"`
-	CategoryIDs      []uint    `json:"category_ids,omitempty"`
-	StartDate        time.Time `json:"start_date"`
-	EndDate          time.Time `json:"end_date"`
-	UsageLimit       int       `json:"usage_limit"`
-	CurrentUsage     int       `json:"current_usage"`
-	Active           bool      `json:"active"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID               uint               `json:"id"`
+	Code             string             `json:"code"`
+	Type             string             `json:"type"`
+	Method           string             `json:"method"`
+	Value            float64            `json:"value"`
+	MinOrderValue    float64            `json:"min_order_value"`
+	MaxDiscountValue float64            `json:"max_discount_value"`
+	ProductIDs       []uint             `json:"product_ids,omitempty"`
+	CategoryIDs      []uint             `json:"category_ids,omitempty"`
+	CollectionIDs    []uint             `json:"collection_ids,omitempty"`
+	StartDate        time.Time          `json:"start_date"`
+	EndDate          time.Time          `json:"end_date"`
+	UsageLimit       int                `json:"usage_limit"`
+	CurrentUsage     int                `json:"current_usage"`
+	Active           bool               `json:"active"`
+	CurrencyValues   map[string]float64 `json:"currency_values,omitempty"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
 }
 
 // AppliedDiscountDTO represents an applied discount in a checkout
@@ -33,3 +35,32 @@ type AppliedDiscountDTO struct {
 	Value  float64 `json:"value"`
 	Amount float64 `json:"amount"`
 }
+
+// DiscountCodeBatchDTO represents a batch of unique discount codes with redemption stats
+type DiscountCodeBatchDTO struct {
+	ID                uint      `json:"id"`
+	DiscountID        uint      `json:"discount_id"`
+	Name              string    `json:"name"`
+	Prefix            string    `json:"prefix,omitempty"`
+	Source            string    `json:"source"`
+	UsageLimitPerCode int       `json:"usage_limit_per_code"`
+	TotalCodes        int       `json:"total_codes"`
+	RedeemedCodes     int       `json:"redeemed_codes"`
+	ExhaustedCodes    int       `json:"exhausted_codes"`
+	TotalRedemptions  int       `json:"total_redemptions"`
+	RedemptionRate    float64   `json:"redemption_rate"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// DiscountCodeDTO represents a single code in a discount code batch
+type DiscountCodeDTO struct {
+	ID             uint       `json:"id"`
+	BatchID        uint       `json:"batch_id"`
+	DiscountID     uint       `json:"discount_id"`
+	Code           string     `json:"code"`
+	UsageLimit     int        `json:"usage_limit"`
+	CurrentUsage   int        `json:"current_usage"`
+	LastRedeemedAt *time.Time `json:"last_redeemed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// DigitalFileDTO represents a file delivered to the customers of a digital variant
+type DigitalFileDTO struct {
+	ID          uint      `json:"id"`
+	VariantID   uint      `json:"variant_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// OrderDownloadDTO represents a download of a digital file granted by a paid order
+type OrderDownloadDTO struct {
+	ID                 uint      `json:"id"`
+	OrderItemID        uint      `json:"order_item_id"`
+	FileName           string    `json:"file_name"`
+	Size               int64     `json:"size"`
+	URL                string    `json:"url,omitempty"` // Signed link, valid until ExpiresAt
+	DownloadCount      int       `json:"download_count"`
+	MaxDownloads       int       `json:"max_downloads"`       // 0 allows unlimited downloads
+	RemainingDownloads int       `json:"remaining_downloads"` // -1 when unlimited
+	ExpiresAt          time.Time `json:"expires_at"`
+}
@@ -18,14 +18,19 @@ type OrderDTO struct {
 	DiscountAmount      float64                 `json:"discount_amount"` // Discount applied amount
 	FinalAmount         float64                 `json:"final_amount"`    // Total including shipping and discounts
 	Currency            string                  `json:"currency"`
+	BaseCurrency        string                  `json:"base_currency,omitempty"` // Default currency at the time of the order
+	ExchangeRate        float64                 `json:"exchange_rate"`           // Rate of Currency against BaseCurrency
+	Locale              string                  `json:"locale,omitempty"`        // Language of the customer
 	ShippingAddress     AddressDTO              `json:"shipping_address"`
 	BillingAddress      AddressDTO              `json:"billing_address"`
 	ShippingDetails     ShippingOptionDTO       `json:"shipping_details"`
 	DiscountDetails     *AppliedDiscountDTO     `json:"discount_details"`
 	PaymentTransactions []PaymentTransactionDTO `json:"payment_transactions,omitempty"`
+	Downloads           []OrderDownloadDTO      `json:"downloads,omitempty"` // Downloads of digital items once paid
 	CustomerDetails     CustomerDetailsDTO      `json:"customer"`
 	ActionRequired      bool                    `json:"action_required"`      // Indicates if action is needed (e.g., payment)
 	ActionURL           string                  `json:"action_url,omitempty"` // URL for payment or order actions
+	CustomFields        map[string]any          `json:"custom_fields,omitempty"`
 	CreatedAt           time.Time               `json:"created_at"`
 	UpdatedAt           time.Time               `json:"updated_at"`
 }
@@ -44,6 +49,7 @@ type OrderSummaryDTO struct {
 	FinalAmount      float64            `json:"final_amount"`    // Total including shipping and discounts
 	OrderLinesAmount int                `json:"order_lines_amount"`
 	Currency         string             `json:"currency"`
+	CustomFields     map[string]any     `json:"custom_fields,omitempty"`
 	CreatedAt        time.Time          `json:"created_at"`
 	UpdatedAt        time.Time          `json:"updated_at"`
 }
@@ -59,19 +65,31 @@ type PaymentDetails struct {
 
 // OrderItemDTO represents an item in an order
 type OrderItemDTO struct {
-	ID          uint      `json:"id"`
-	OrderID     uint      `json:"order_id"`
-	ProductID   uint      `json:"product_id"`
-	VariantID   uint      `json:"variant_id,omitempty"`
-	SKU         string    `json:"sku"`
-	ProductName string    `json:"product_name"`
-	VariantName string    `json:"variant_name"`
-	Quantity    int       `json:"quantity"`
-	UnitPrice   float64   `json:"unit_price"`
-	TotalPrice  float64   `json:"total_price"`
-	ImageURL    string    `json:"image_url"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uint                    `json:"id"`
+	OrderID     uint                    `json:"order_id"`
+	ProductID   uint                    `json:"product_id"`
+	VariantID   uint                    `json:"variant_id,omitempty"`
+	SKU         string                  `json:"sku"`
+	ProductName string                  `json:"product_name"`
+	VariantName string                  `json:"variant_name"`
+	Quantity    int                     `json:"quantity"`
+	UnitPrice   float64                 `json:"unit_price"`
+	TotalPrice  float64                 `json:"total_price"`
+	ImageURL    string                  `json:"image_url"`
+	IsDigital   bool                    `json:"is_digital"`
+	Components  []OrderItemComponentDTO `json:"components,omitempty"` // Components of a bundle at time of order
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+// OrderItemComponentDTO represents a component of an ordered bundle
+type OrderItemComponentDTO struct {
+	VariantID     uint   `json:"variant_id"`
+	SKU           string `json:"sku"`
+	ProductName   string `json:"product_name"`
+	VariantName   string `json:"variant_name"`
+	Quantity      int    `json:"quantity"`       // Units per bundle
+	TotalQuantity int    `json:"total_quantity"` // Units for the ordered quantity of bundles
 }
 
 // PaymentMethod represents the payment method used for an order
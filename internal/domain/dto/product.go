@@ -6,36 +6,120 @@ import (
 
 // ProductDTO represents a product in the system
 type ProductDTO struct {
-	ID          uint         `json:"id"`
-	Name        string       `json:"name"`
-	Description string       `json:"description"`
-	Currency    string       `json:"currency"`
-	Price       float64      `json:"price"`       // Default variant price in given currency
-	SKU         string       `json:"sku"`         // Default variant SKU
-	TotalStock  int          `json:"total_stock"` // Total stock across all variants
-	Category    string       `json:"category"`
-	CategoryID  uint         `json:"category_id,omitempty"`
-	Images      []string     `json:"images"`
-	HasVariants bool         `json:"has_variants"`
-	Active      bool         `json:"active"`
-	Variants    []VariantDTO `json:"variants,omitempty"`
-	CreatedAt   time.Time    `json:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at"`
+	ID             uint               `json:"id"`
+	Type           string             `json:"type"`
+	Name           string             `json:"name"`
+	Slug           string             `json:"slug"`
+	Description    string             `json:"description"`
+	SEO            SEODTO             `json:"seo"`
+	Currency       string             `json:"currency"`
+	Price          float64            `json:"price"`                      // Default variant price in given currency
+	CompareAtPrice *float64           `json:"compare_at_price,omitempty"` // Former default variant price to show next to the price
+	OnSale         bool               `json:"on_sale"`
+	SKU            string             `json:"sku"`         // Default variant SKU
+	TotalStock     int                `json:"total_stock"` // Total stock across all variants
+	Category       string             `json:"category"`
+	CategoryID     uint               `json:"category_id,omitempty"`
+	Images         []string           `json:"images"`
+	HasVariants    bool               `json:"has_variants"`
+	Active         bool               `json:"active"`
+	PublishAt      *time.Time         `json:"publish_at,omitempty"`   // Hidden before this time when set
+	UnpublishAt    *time.Time         `json:"unpublish_at,omitempty"` // Hidden from this time when set
+	Channels       []string           `json:"channels,omitempty"`     // Sales channels showing the product, all when empty
+	Variants       []VariantDTO       `json:"variants,omitempty"`
+	Options        []ProductOptionDTO `json:"options,omitempty"`     // Variant option definitions in display order
+	RatingAverage  float64            `json:"rating_average"`        // Average rating of the approved reviews, 0 without reviews
+	ReviewCount    int                `json:"review_count"`          // Number of approved reviews
+	SearchRank     float64            `json:"search_rank,omitempty"` // Relevance of the product to the search query
+	Highlights     map[string]string  `json:"highlights,omitempty"`  // Matched search terms wrapped in <mark> tags, by field
+	CustomFields   map[string]any     `json:"custom_fields,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+// ProductOptionDTO represents a variant option of a product with its allowed values
+type ProductOptionDTO struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
 }
 
 // VariantDTO represents a product variant
 type VariantDTO struct {
-	ID          uint              `json:"id"`
-	ProductID   uint              `json:"product_id"`
-	VariantName string            `json:"variant_name"`
-	SKU         string            `json:"sku"`
-	Stock       int               `json:"stock"`
-	Attributes  map[string]string `json:"attributes"`
-	Images      []string          `json:"images"`
-	IsDefault   bool              `json:"is_default"`
-	Weight      float64           `json:"weight"`
-	Price       float64           `json:"price"`
-	Currency    string            `json:"currency"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
+	ID             uint                 `json:"id"`
+	ProductID      uint                 `json:"product_id"`
+	VariantName    string               `json:"variant_name"`
+	SKU            string               `json:"sku"`
+	Stock          int                  `json:"stock"`
+	Attributes     map[string]string    `json:"attributes"`
+	Images         []string             `json:"images"`
+	IsDefault      bool                 `json:"is_default"`
+	Weight         float64              `json:"weight"`
+	IsDigital      bool                 `json:"is_digital"`
+	Price          float64              `json:"price"`                      // Effective price, the sale price while a sale is active
+	RegularPrice   float64              `json:"regular_price"`              // Price without an active sale
+	CompareAtPrice *float64             `json:"compare_at_price,omitempty"` // Former price to show next to the price
+	OnSale         bool                 `json:"on_sale"`
+	Sale           *ScheduledPriceDTO   `json:"sale,omitempty"`
+	CompareAt      *ScheduledPriceDTO   `json:"compare_at,omitempty"`
+	Prices         map[string]float64   `json:"prices,omitempty"`       // Explicit prices in other currencies
+	PriceBreaks    []PriceBreakDTO      `json:"price_breaks,omitempty"` // Customer group quantity breaks
+	Components     []BundleComponentDTO `json:"components,omitempty"`   // Variants contained in a bundle
+	Currency       string               `json:"currency"`
+	CustomFields   map[string]any       `json:"custom_fields,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// BundleComponentDTO represents a variant contained in a bundle variant
+type BundleComponentDTO struct {
+	VariantID   uint   `json:"variant_id"`
+	SKU         string `json:"sku"`
+	ProductName string `json:"product_name,omitempty"`
+	VariantName string `json:"variant_name,omitempty"`
+	Quantity    int    `json:"quantity"` // Units per bundle
+	Stock       int    `json:"stock"`
+}
+
+// ScheduledPriceDTO represents a price that applies within an optional time window
+type ScheduledPriceDTO struct {
+	Price    float64    `json:"price"`
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+}
+
+// ProductFacetsDTO holds the filter options of a product search with the number of matching products
+type ProductFacetsDTO struct {
+	Currency     string              `json:"currency"` // Currency of the price buckets
+	Attributes   []AttributeFacetDTO `json:"attributes"`
+	PriceBuckets []PriceBucketDTO    `json:"price_buckets"`
+	Categories   []CategoryFacetDTO  `json:"categories"`
+}
+
+// AttributeFacetDTO holds the values of a variant attribute
+type AttributeFacetDTO struct {
+	Name   string          `json:"name"`
+	Values []FacetValueDTO `json:"values"`
+}
+
+// FacetValueDTO is a filter option with the number of matching products
+type FacetValueDTO struct {
+	Value    string `json:"value"`
+	Count    int    `json:"count"`
+	Selected bool   `json:"selected"`
+}
+
+// PriceBucketDTO counts the products priced from min (inclusive) to max (exclusive)
+type PriceBucketDTO struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int     `json:"count"`
+}
+
+// CategoryFacetDTO counts the matching products in a category including its descendants
+type CategoryFacetDTO struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	ParentID *uint  `json:"parent_id,omitempty"`
+	Count    int    `json:"count"`
+	Selected bool   `json:"selected"`
 }
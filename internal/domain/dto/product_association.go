@@ -0,0 +1,20 @@
+package dto
+
+// ProductAssociationDTO represents a link from a product to another product
+type ProductAssociationDTO struct {
+	ID                  uint   `json:"id"`
+	ProductID           uint   `json:"product_id"`
+	AssociatedProductID uint   `json:"associated_product_id"`
+	Type                string `json:"type"`
+	Position            int    `json:"position"`
+}
+
+// ProductAssociationsDTO represents the products associated with a product or a checkout, by type
+type ProductAssociationsDTO struct {
+	Related   []ProductDTO `json:"related"`
+	CrossSell []ProductDTO `json:"cross_sell"`
+	Upsell    []ProductDTO `json:"upsell"`
+	Accessory []ProductDTO `json:"accessory"`
+	// Products often ordered together, derived from orders when no cross-sells are maintained
+	FrequentlyBoughtTogether []ProductDTO `json:"frequently_bought_together"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// ReviewDTO represents an approved review of a product
+type ReviewDTO struct {
+	ID               uint      `json:"id"`
+	ProductID        uint      `json:"product_id"`
+	Rating           int       `json:"rating"`
+	Title            string    `json:"title,omitempty"`
+	Body             string    `json:"body,omitempty"`
+	AuthorName       string    `json:"author_name"`
+	VerifiedPurchase bool      `json:"verified_purchase"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// AdminReviewDTO represents a review with its moderation details
+type AdminReviewDTO struct {
+	ReviewDTO
+	UserID         uint       `json:"user_id"`
+	OrderID        *uint      `json:"order_id,omitempty"`
+	Status         string     `json:"status"`
+	ModerationNote string     `json:"moderation_note,omitempty"`
+	ModeratedAt    *time.Time `json:"moderated_at,omitempty"`
+}
+
+// ReviewSummaryDTO represents the aggregated rating of a product
+type ReviewSummaryDTO struct {
+	RatingAverage float64     `json:"rating_average"`
+	ReviewCount   int         `json:"review_count"`
+	Distribution  map[int]int `json:"distribution"` // Number of approved reviews by rating
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// RoleDTO represents a role and its permissions
+type RoleDTO struct {
+	ID          uint      `json:"id,omitempty"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	BuiltIn     bool      `json:"built_in"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
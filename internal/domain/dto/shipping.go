@@ -36,6 +36,7 @@ type ShippingRateDTO struct {
 	BaseRate              float64                  `json:"base_rate"`
 	MinOrderValue         float64                  `json:"min_order_value"`
 	FreeShippingThreshold float64                  `json:"free_shipping_threshold"`
+	CurrencyBaseRates     map[string]float64       `json:"currency_base_rates,omitempty"`
 	WeightBasedRates      []WeightBasedRateDTO     `json:"weight_based_rates,omitempty"`
 	ValueBasedRates       []ValueBasedRateDTO      `json:"value_based_rates,omitempty"`
 	Active                bool                     `json:"active"`
@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// TranslationDTO represents the name and description of a resource in a locale
+type TranslationDTO struct {
+	Locale      string    `json:"locale"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
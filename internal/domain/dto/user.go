@@ -6,11 +6,14 @@ import (
 
 // UserDTO represents a user in the system
 type UserDTO struct {
-	ID        uint      `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uint           `json:"id"`
+	Email           string         `json:"email"`
+	FirstName       string         `json:"first_name"`
+	LastName        string         `json:"last_name"`
+	Role            string         `json:"role"`
+	EmailVerified   bool           `json:"email_verified"`
+	CustomerGroupID *uint          `json:"customer_group_id,omitempty"`
+	CustomFields    map[string]any `json:"custom_fields,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
 }
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+)
+
+// APIKeyPrefix starts every API key, so leaked keys are easy to recognize
+const APIKeyPrefix = "ck_"
+
+// apiKeyDisplayLength is the number of leading characters of a key shown to identify it
+const apiKeyDisplayLength = len(APIKeyPrefix) + 8
+
+// APIKey lets a server-to-server integration call the admin API without a user. Its scopes are permissions
+// of the role model. Like user tokens, only the SHA-256 hash of the key is stored; the key is shown once.
+type APIKey struct {
+	ID          uint         `gorm:"primaryKey"`
+	Name        string       `gorm:"not null;size:100"`
+	Prefix      string       `gorm:"not null;size:20"` // Leading characters of the key, e.g. ck_1a2b3c4d
+	KeyHash     string       `gorm:"not null;size:64;uniqueIndex"`
+	Scopes      []Permission `gorm:"type:jsonb;serializer:json"`
+	CreatedByID *uint        `gorm:"index"`
+	CreatedBy   *User        `gorm:"foreignKey:CreatedByID;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+	ExpiresAt   *time.Time
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewAPIKey creates an API key with the scopes, optionally expiring. A zero createdByID means the key
+// was created by another API key. It returns the key to store and the plain key to show to the admin.
+func NewAPIKey(name string, scopes []Permission, expiresAt *time.Time, createdByID uint, now time.Time) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", errors.New("API key name is required")
+	}
+	if len(name) > 100 {
+		return nil, "", errors.New("API key name cannot be longer than 100 characters")
+	}
+	if len(scopes) == 0 {
+		return nil, "", errors.New("API key needs at least one scope")
+	}
+	normalized, err := normalizePermissions(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", errors.New("API key expiry must be in the future")
+	}
+
+	secret, err := newSecretToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	plain := APIKeyPrefix + secret
+
+	key := &APIKey{
+		Name:      name,
+		Prefix:    plain[:apiKeyDisplayLength],
+		KeyHash:   HashUserToken(plain),
+		Scopes:    normalized,
+		ExpiresAt: expiresAt,
+	}
+	if createdByID != 0 {
+		key.CreatedByID = &createdByID
+	}
+	return key, plain, nil
+}
+
+// IsActive returns true when the key has not been revoked and has not expired
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// HasScope returns true when the key grants the permission
+func (k *APIKey) HasScope(permission Permission) bool {
+	return slices.Contains(k.Scopes, permission)
+}
+
+// APIKeyRequest records a request made with an API key, so changes made by integrations can be traced to the key
+type APIKeyRequest struct {
+	ID        uint    `gorm:"primaryKey"`
+	APIKeyID  uint    `gorm:"index;not null"`
+	APIKey    *APIKey `gorm:"foreignKey:APIKeyID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	Method    string  `gorm:"not null;size:10"`
+	Path      string  `gorm:"not null;size:2048"`
+	CreatedAt time.Time
+}
+
+// NewAPIKeyRequest creates the record of a request made with the key
+func NewAPIKeyRequest(keyID uint, method, path string) *APIKeyRequest {
+	return &APIKeyRequest{
+		APIKeyID: keyID,
+		Method:   method,
+		Path:     path,
+	}
+}
+
+// ToAPIKeyDTO converts the API key to a DTO without the key itself
+func (k *APIKey) ToAPIKeyDTO() *dto.APIKeyDTO {
+	scopes := make([]string, len(k.Scopes))
+	for i, scope := range k.Scopes {
+		scopes[i] = string(scope)
+	}
+	return &dto.APIKeyDTO{
+		ID:          k.ID,
+		Name:        k.Name,
+		Prefix:      k.Prefix,
+		Scopes:      scopes,
+		CreatedByID: k.CreatedByID,
+		ExpiresAt:   k.ExpiresAt,
+		LastUsedAt:  k.LastUsedAt,
+		RevokedAt:   k.RevokedAt,
+		CreatedAt:   k.CreatedAt,
+	}
+}
+
+// ToAPIKeyRequestDTO converts the request record to a DTO
+func (r *APIKeyRequest) ToAPIKeyRequestDTO() *dto.APIKeyRequestDTO {
+	return &dto.APIKeyRequestDTO{
+		ID:        r.ID,
+		APIKeyID:  r.APIKeyID,
+		Method:    r.Method,
+		Path:      r.Path,
+		CreatedAt: r.CreatedAt,
+	}
+}
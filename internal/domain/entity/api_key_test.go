@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKey(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("NewAPIKey stores the hash of the plain key", func(t *testing.T) {
+		key, plain, err := NewAPIKey("ERP sync", []Permission{PermissionCatalogWrite, PermissionCatalogRead}, nil, 1, now)
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(plain, APIKeyPrefix))
+		assert.Len(t, plain, len(APIKeyPrefix)+64)
+		assert.Equal(t, HashUserToken(plain), key.KeyHash)
+		assert.Equal(t, plain[:len(key.Prefix)], key.Prefix)
+		assert.Equal(t, []Permission{PermissionCatalogRead, PermissionCatalogWrite}, key.Scopes)
+		require.NotNil(t, key.CreatedByID)
+		assert.Equal(t, uint(1), *key.CreatedByID)
+		assert.True(t, key.HasScope(PermissionCatalogWrite))
+		assert.False(t, key.HasScope(PermissionOrdersRead))
+	})
+
+	t.Run("NewAPIKey validation", func(t *testing.T) {
+		_, _, err := NewAPIKey(" ", []Permission{PermissionOrdersRead}, nil, 1, now)
+		assert.Error(t, err)
+
+		_, _, err = NewAPIKey("ERP sync", nil, nil, 1, now)
+		assert.Error(t, err)
+
+		_, _, err = NewAPIKey("ERP sync", []Permission{"orders:delete"}, nil, 1, now)
+		assert.Error(t, err)
+
+		past := now.Add(-time.Hour)
+		_, _, err = NewAPIKey("ERP sync", []Permission{PermissionOrdersRead}, &past, 1, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("IsActive", func(t *testing.T) {
+		expiresAt := now.Add(time.Hour)
+		key, _, err := NewAPIKey("ERP sync", []Permission{PermissionOrdersRead}, &expiresAt, 0, now)
+		require.NoError(t, err)
+		assert.Nil(t, key.CreatedByID)
+
+		assert.True(t, key.IsActive(now))
+		assert.False(t, key.IsActive(expiresAt))
+
+		key.RevokedAt = &now
+		assert.False(t, key.IsActive(now))
+	})
+}
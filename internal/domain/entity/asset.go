@@ -0,0 +1,211 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+)
+
+// MaxAltTextLength is the maximum length of the alt text of a product asset
+const MaxAltTextLength = 255
+
+// assetExtensions maps the content types of uploads to file extensions
+var assetExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Asset is an uploaded media file, such as a product image, with its resized derivatives
+type Asset struct {
+	ID          uint              `gorm:"primaryKey"`
+	FileName    string            `gorm:"not null;size:255"` // Name of the uploaded file
+	ContentType string            `gorm:"not null;size:100"`
+	Size        int64             `gorm:"not null"`
+	Width       int               `gorm:"default:0"` // Pixel dimensions, 0 when unknown
+	Height      int               `gorm:"default:0"`
+	StorageKey  string            `gorm:"not null;size:500;uniqueIndex"`
+	URL         string            `gorm:"not null;size:2048"`
+	Derivatives []AssetDerivative `gorm:"foreignKey:AssetID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// AssetDerivative is a resized copy of an image asset, e.g. a thumbnail
+type AssetDerivative struct {
+	ID          uint   `gorm:"primaryKey"`
+	AssetID     uint   `gorm:"not null;uniqueIndex:idx_asset_derivatives_asset_name"`
+	Name        string `gorm:"not null;size:50;uniqueIndex:idx_asset_derivatives_asset_name"`
+	ContentType string `gorm:"not null;size:100"`
+	Size        int64  `gorm:"not null"`
+	Width       int    `gorm:"not null"`
+	Height      int    `gorm:"not null"`
+	StorageKey  string `gorm:"not null;size:500"`
+	URL         string `gorm:"not null;size:2048"`
+}
+
+// ProductAsset links an asset to a product, or to one variant of the product, in display order
+type ProductAsset struct {
+	ID        uint   `gorm:"primaryKey"`
+	ProductID uint   `gorm:"not null;index"`
+	VariantID *uint  `gorm:"index"` // NULL for assets of the whole product
+	AssetID   uint   `gorm:"not null;index"`
+	Asset     *Asset `gorm:"foreignKey:AssetID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	Position  int    `gorm:"not null;default:0"`
+	AltText   string `gorm:"size:255"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewAsset creates an asset for an uploaded file. The storage key and URL are set when the file is stored.
+func NewAsset(fileName, contentType string, size int64, width, height int) (*Asset, error) {
+	fileName = filepath.Base(strings.ReplaceAll(strings.TrimSpace(fileName), "\\", "/"))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return nil, errors.New("file name cannot be empty")
+	}
+	if len(fileName) > 255 {
+		return nil, errors.New("file name cannot exceed 255 characters")
+	}
+	if contentType == "" {
+		return nil, errors.New("content type cannot be empty")
+	}
+	if size <= 0 {
+		return nil, errors.New("file cannot be empty")
+	}
+	if width < 0 || height < 0 {
+		return nil, errors.New("image dimensions cannot be negative")
+	}
+
+	return &Asset{
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		Width:       width,
+		Height:      height,
+	}, nil
+}
+
+// AssetExtension returns the file extension stored files of a content type are given
+func AssetExtension(contentType string) string {
+	if ext, ok := assetExtensions[contentType]; ok {
+		return ext
+	}
+	return ".bin"
+}
+
+// IsImage reports whether the asset is an image
+func (a *Asset) IsImage() bool {
+	return strings.HasPrefix(a.ContentType, "image/")
+}
+
+// StorageKeys returns the storage keys of the asset and its derivatives
+func (a *Asset) StorageKeys() []string {
+	keys := []string{a.StorageKey}
+	for _, derivative := range a.Derivatives {
+		keys = append(keys, derivative.StorageKey)
+	}
+	return keys
+}
+
+// ToAssetDTO converts the asset to a DTO
+func (a *Asset) ToAssetDTO() dto.AssetDTO {
+	derivatives := make([]dto.AssetDerivativeDTO, len(a.Derivatives))
+	for i, derivative := range a.Derivatives {
+		derivatives[i] = dto.AssetDerivativeDTO{
+			Name:        derivative.Name,
+			URL:         derivative.URL,
+			ContentType: derivative.ContentType,
+			Size:        derivative.Size,
+			Width:       derivative.Width,
+			Height:      derivative.Height,
+		}
+	}
+
+	return dto.AssetDTO{
+		ID:          a.ID,
+		FileName:    a.FileName,
+		URL:         a.URL,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Width:       a.Width,
+		Height:      a.Height,
+		Derivatives: derivatives,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// NewProductAsset links an asset to a product, or to a variant of the product when variantID is set
+func NewProductAsset(productID uint, variantID *uint, assetID uint, altText string) (*ProductAsset, error) {
+	if productID == 0 {
+		return nil, errors.New("product ID cannot be zero")
+	}
+	if assetID == 0 {
+		return nil, errors.New("asset ID cannot be zero")
+	}
+	if variantID != nil && *variantID == 0 {
+		variantID = nil
+	}
+
+	link := &ProductAsset{ProductID: productID, VariantID: variantID, AssetID: assetID}
+	if err := link.SetAltText(altText); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// SetAltText sets the alt text shown when the asset cannot be displayed
+func (l *ProductAsset) SetAltText(altText string) error {
+	altText = strings.TrimSpace(altText)
+	if len(altText) > MaxAltTextLength {
+		return fmt.Errorf("alt text cannot exceed %d characters", MaxAltTextLength)
+	}
+	l.AltText = altText
+	return nil
+}
+
+// ToProductAssetDTO converts the product asset to a DTO
+func (l *ProductAsset) ToProductAssetDTO() dto.ProductAssetDTO {
+	result := dto.ProductAssetDTO{
+		ID:        l.ID,
+		ProductID: l.ProductID,
+		VariantID: l.VariantID,
+		AssetID:   l.AssetID,
+		Position:  l.Position,
+		AltText:   l.AltText,
+	}
+	if l.Asset != nil {
+		asset := l.Asset.ToAssetDTO()
+		result.Asset = &asset
+	}
+	return result
+}
+
+// OrderProductAssets sets the positions of the assets of a product to the order of the given
+// link IDs, which must list every asset of the product exactly once
+func OrderProductAssets(links []*ProductAsset, order []uint) error {
+	if len(order) != len(links) {
+		return fmt.Errorf("order must list all %d assets of the product", len(links))
+	}
+
+	positions := make(map[uint]int, len(order))
+	for i, id := range order {
+		if _, ok := positions[id]; ok {
+			return fmt.Errorf("product asset %d is listed more than once", id)
+		}
+		positions[id] = i
+	}
+
+	for _, link := range links {
+		position, ok := positions[link.ID]
+		if !ok {
+			return fmt.Errorf("product asset %d is missing from the order", link.ID)
+		}
+		link.Position = position
+	}
+	return nil
+}
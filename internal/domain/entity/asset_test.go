@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsset(t *testing.T) {
+	t.Run("NewAsset keeps only the base name of the file", func(t *testing.T) {
+		asset, err := NewAsset("../../photos\\shirt.png", "image/png", 1024, 400, 300)
+		require.NoError(t, err)
+		assert.Equal(t, "shirt.png", asset.FileName)
+		assert.True(t, asset.IsImage())
+	})
+
+	t.Run("NewAsset validates the file", func(t *testing.T) {
+		_, err := NewAsset("", "image/png", 1024, 0, 0)
+		assert.ErrorContains(t, err, "file name cannot be empty")
+		_, err = NewAsset("shirt.png", "", 1024, 0, 0)
+		assert.ErrorContains(t, err, "content type cannot be empty")
+		_, err = NewAsset("shirt.png", "image/png", 0, 0, 0)
+		assert.ErrorContains(t, err, "file cannot be empty")
+	})
+
+	t.Run("StorageKeys include the derivatives", func(t *testing.T) {
+		asset := &Asset{
+			StorageKey:  "assets/a/original.png",
+			Derivatives: []AssetDerivative{{StorageKey: "assets/a/thumbnail.png"}},
+		}
+		assert.Equal(t, []string{"assets/a/original.png", "assets/a/thumbnail.png"}, asset.StorageKeys())
+	})
+
+	t.Run("AssetExtension", func(t *testing.T) {
+		assert.Equal(t, ".jpg", AssetExtension("image/jpeg"))
+		assert.Equal(t, ".bin", AssetExtension("application/pdf"))
+	})
+}
+
+func TestProductAsset(t *testing.T) {
+	t.Run("NewProductAsset validates the link", func(t *testing.T) {
+		variantID := uint(0)
+		link, err := NewProductAsset(1, &variantID, 2, "  Red shirt ")
+		require.NoError(t, err)
+		assert.Nil(t, link.VariantID)
+		assert.Equal(t, "Red shirt", link.AltText)
+
+		_, err = NewProductAsset(0, nil, 2, "")
+		assert.Error(t, err)
+		_, err = NewProductAsset(1, nil, 0, "")
+		assert.Error(t, err)
+		_, err = NewProductAsset(1, nil, 2, strings.Repeat("a", MaxAltTextLength+1))
+		assert.ErrorContains(t, err, "alt text cannot exceed")
+	})
+
+	t.Run("OrderProductAssets sets positions", func(t *testing.T) {
+		links := []*ProductAsset{{ID: 1, Position: 0}, {ID: 2, Position: 1}, {ID: 3, Position: 2}}
+		require.NoError(t, OrderProductAssets(links, []uint{3, 1, 2}))
+		assert.Equal(t, 1, links[0].Position)
+		assert.Equal(t, 2, links[1].Position)
+		assert.Equal(t, 0, links[2].Position)
+	})
+
+	t.Run("OrderProductAssets requires every asset once", func(t *testing.T) {
+		links := []*ProductAsset{{ID: 1}, {ID: 2}}
+		assert.ErrorContains(t, OrderProductAssets(links, []uint{1}), "must list all 2 assets")
+		assert.ErrorContains(t, OrderProductAssets(links, []uint{1, 1}), "listed more than once")
+		assert.ErrorContains(t, OrderProductAssets(links, []uint{1, 3}), "product asset 2 is missing")
+	})
+}
@@ -0,0 +1,122 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+)
+
+// MaxBundleComponents caps the number of component variants of a bundle variant
+const MaxBundleComponents = 50
+
+// BundleComponent is a variant contained in a bundle variant with the quantity per bundle,
+// e.g. two mugs in a gift box
+type BundleComponent struct {
+	ID                 uint            `gorm:"primaryKey"`
+	BundleVariantID    uint            `gorm:"not null;uniqueIndex:idx_bundle_components_bundle_component"`
+	ComponentVariantID uint            `gorm:"not null;index;uniqueIndex:idx_bundle_components_bundle_component"`
+	ComponentVariant   *ProductVariant `gorm:"foreignKey:ComponentVariantID;constraint:OnDelete:RESTRICT,OnUpdate:CASCADE"`
+	Quantity           int             `gorm:"not null"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// NewBundleComponent creates a component of a bundle
+func NewBundleComponent(componentVariantID uint, quantity int) (*BundleComponent, error) {
+	if componentVariantID == 0 {
+		return nil, errors.New("component variant ID is required")
+	}
+	if quantity <= 0 {
+		return nil, errors.New("component quantity must be greater than zero")
+	}
+
+	return &BundleComponent{
+		ComponentVariantID: componentVariantID,
+		Quantity:           quantity,
+	}, nil
+}
+
+// IsBundle reports whether the variant is made of component variants
+func (v *ProductVariant) IsBundle() bool {
+	return len(v.Components) > 0
+}
+
+// AvailableStock returns the number of units that can be sold. A bundle is limited by its
+// scarcest component, its own stock is not used.
+func (v *ProductVariant) AvailableStock() int {
+	if !v.IsBundle() {
+		return v.Stock
+	}
+
+	available := -1
+	for _, component := range v.Components {
+		if component.ComponentVariant == nil || component.Quantity <= 0 {
+			return 0
+		}
+		units := max(component.ComponentVariant.Stock, 0) / component.Quantity
+		if available < 0 || units < available {
+			available = units
+		}
+	}
+	return available
+}
+
+// SetComponents replaces the components of a bundle variant. The component variants must be
+// loaded and cannot be bundles themselves. No components make the variant a regular variant again.
+func (v *ProductVariant) SetComponents(components []*BundleComponent) error {
+	if len(components) > MaxBundleComponents {
+		return fmt.Errorf("a bundle can have at most %d components", MaxBundleComponents)
+	}
+
+	seen := make(map[uint]bool, len(components))
+	for _, component := range components {
+		if v.ID != 0 && component.ComponentVariantID == v.ID {
+			return errors.New("a bundle cannot contain itself")
+		}
+		if seen[component.ComponentVariantID] {
+			return fmt.Errorf("bundle component %d is listed more than once", component.ComponentVariantID)
+		}
+		seen[component.ComponentVariantID] = true
+
+		if component.ComponentVariant == nil {
+			return fmt.Errorf("bundle component %d is not loaded", component.ComponentVariantID)
+		}
+		if component.ComponentVariant.IsBundle() || component.ComponentVariant.Product.IsBundle() {
+			return fmt.Errorf("bundle component %s is a bundle itself", component.ComponentVariant.SKU)
+		}
+		component.BundleVariantID = v.ID
+	}
+
+	v.Components = components
+	return nil
+}
+
+// ToBundleComponentDTO converts the component to a DTO
+func (c *BundleComponent) ToBundleComponentDTO() dto.BundleComponentDTO {
+	componentDTO := dto.BundleComponentDTO{
+		VariantID: c.ComponentVariantID,
+		Quantity:  c.Quantity,
+	}
+	if variant := c.ComponentVariant; variant != nil {
+		componentDTO.SKU = variant.SKU
+		componentDTO.ProductName = variant.Product.Name
+		componentDTO.VariantName = variant.Name()
+		componentDTO.Stock = variant.Stock
+	}
+	return componentDTO
+}
+
+// toBundleComponentDTOs converts bundle components to DTOs
+func toBundleComponentDTOs(components []*BundleComponent) []dto.BundleComponentDTO {
+	if len(components) == 0 {
+		return nil
+	}
+
+	componentDTOs := make([]dto.BundleComponentDTO, len(components))
+	for i, component := range components {
+		componentDTOs[i] = component.ToBundleComponentDTO()
+	}
+	return componentDTOs
+}
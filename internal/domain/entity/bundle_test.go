@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductVariant_Bundle(t *testing.T) {
+	component := func(variant *ProductVariant, quantity int) *BundleComponent {
+		c, err := NewBundleComponent(variant.ID, quantity)
+		require.NoError(t, err)
+		c.ComponentVariant = variant
+		return c
+	}
+
+	mug := &ProductVariant{SKU: "MUG", Stock: 7}
+	mug.ID = 1
+	tea := &ProductVariant{SKU: "TEA", Stock: 2}
+	tea.ID = 2
+
+	t.Run("Regular variants use their own stock", func(t *testing.T) {
+		assert.False(t, mug.IsBundle())
+		assert.Equal(t, 7, mug.AvailableStock())
+		assert.True(t, mug.IsAvailable(7))
+	})
+
+	t.Run("Bundles are limited by the scarcest component", func(t *testing.T) {
+		box := &ProductVariant{SKU: "BOX", Stock: 100}
+		box.ID = 3
+		require.NoError(t, box.SetComponents([]*BundleComponent{component(mug, 3), component(tea, 1)}))
+
+		assert.True(t, box.IsBundle())
+		assert.Equal(t, 2, box.AvailableStock())
+		assert.False(t, box.IsAvailable(3))
+		assert.Equal(t, uint(3), box.Components[0].BundleVariantID)
+	})
+
+	t.Run("Components are validated", func(t *testing.T) {
+		_, err := NewBundleComponent(0, 1)
+		assert.Error(t, err)
+		_, err = NewBundleComponent(1, -1)
+		assert.Error(t, err)
+
+		box := &ProductVariant{SKU: "BOX"}
+		box.ID = 3
+		assert.ErrorContains(t, box.SetComponents([]*BundleComponent{component(box, 1)}), "cannot contain itself")
+		assert.ErrorContains(t, box.SetComponents([]*BundleComponent{component(mug, 1), component(mug, 2)}), "more than once")
+
+		nested := &ProductVariant{SKU: "NESTED", Product: Product{Type: ProductTypeBundle}}
+		nested.ID = 4
+		assert.ErrorContains(t, box.SetComponents([]*BundleComponent{component(nested, 1)}), "is a bundle itself")
+	})
+}
+
+func TestProduct_SetType(t *testing.T) {
+	product := &Product{Type: ProductTypeStandard, Variants: []*ProductVariant{{SKU: "BOX"}}}
+
+	require.NoError(t, product.SetType(ProductTypeBundle))
+	assert.True(t, product.IsBundle())
+	assert.Error(t, product.SetType("kit"))
+
+	product.Variants[0].Components = []*BundleComponent{{ComponentVariantID: 1, Quantity: 1}}
+	assert.ErrorContains(t, product.SetType(ProductTypeStandard), "remove the components")
+}
+
+func TestOrderItem_StockUnits(t *testing.T) {
+	item := OrderItem{ProductVariantID: 3, SKU: "BOX", Quantity: 2}
+	assert.Equal(t, []StockUnit{{ProductVariantID: 3, SKU: "BOX", Quantity: 2}}, item.StockUnits())
+
+	mug := &ProductVariant{SKU: "MUG", Product: Product{Name: "Mug"}}
+	mug.ID = 1
+	box := &ProductVariant{Components: []*BundleComponent{{ComponentVariantID: 1, ComponentVariant: mug, Quantity: 3}}}
+
+	item.SetBundleComponents(box)
+	require.Len(t, item.Components, 1)
+	assert.Equal(t, "Mug", item.Components[0].ProductName)
+	assert.Equal(t, []StockUnit{{ProductVariantID: 1, SKU: "MUG", Quantity: 6}}, item.StockUnits())
+}
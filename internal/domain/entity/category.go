@@ -2,6 +2,8 @@ package entity
 
 import (
 	"errors"
+	"slices"
+	"sort"
 
 	"github.com/zenfulcode/commercify/internal/domain/dto"
 	"gorm.io/gorm"
@@ -10,12 +12,25 @@ import (
 // Category represents a product category
 type Category struct {
 	gorm.Model
-	Name        string     `gorm:"not null;size:255"`
-	Description string     `gorm:"type:text"`
-	ParentID    *uint      `gorm:"index"` // Nullable for top-level categories
-	Parent      *Category  `gorm:"foreignKey:ParentID;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
-	Children    []Category `gorm:"foreignKey:ParentID;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
-	Products    []Product  `gorm:"foreignKey:CategoryID;constraint:OnDelete:RESTRICT,OnUpdate:CASCADE"`
+	Name        string      `gorm:"not null;size:255"`
+	Slug        string      `gorm:"size:200;index:idx_categories_slug,unique,where:slug <> ''"`
+	Description string      `gorm:"type:text"`
+	SEO         SEOMetadata `gorm:"embedded"`
+	ParentID    *uint       `gorm:"index"`              // Nullable for top-level categories
+	Position    int         `gorm:"not null;default:0"` // Sort order among the categories with the same parent
+	Parent      *Category   `gorm:"foreignKey:ParentID;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+	Children    []Category  `gorm:"foreignKey:ParentID;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+	Products    []Product   `gorm:"foreignKey:CategoryID;constraint:OnDelete:RESTRICT,OnUpdate:CASCADE"`
+
+	// Breadcrumbs from the root category down to the category, set when loaded with its path
+	Path []*Category `gorm:"-"`
+}
+
+// CategoryTreeNode is a category with its children in the category tree
+type CategoryTreeNode struct {
+	Category     *Category
+	ProductCount int // Products in the category and its descendants
+	Children     []*CategoryTreeNode
 }
 
 // NewCategory creates a new category
@@ -43,13 +58,107 @@ func NewCategory(name, description string, parentID *uint) (*Category, error) {
 	}, nil
 }
 
+// SortCategories orders categories by position, then by name
+func SortCategories(categories []*Category) {
+	sort.SliceStable(categories, func(i, j int) bool {
+		if categories[i].Position != categories[j].Position {
+			return categories[i].Position < categories[j].Position
+		}
+		return categories[i].Name < categories[j].Name
+	})
+}
+
+// CategoryPath returns the categories from the root down to the category, or nil when the
+// category is not in the list. A cycle in the parents ends the path.
+func CategoryPath(categories []*Category, categoryID uint) []*Category {
+	byID := make(map[uint]*Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	var path []*Category
+	for category, ok := byID[categoryID]; ok; {
+		if slices.Contains(path, category) {
+			break
+		}
+		path = append(path, category)
+		if category.ParentID == nil {
+			break
+		}
+		category, ok = byID[*category.ParentID]
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// BuildCategoryTree arranges the categories in a tree ordered by position on each level.
+// The product counts by category ID are summed up the tree.
+func BuildCategoryTree(categories []*Category, productCounts map[uint]int) []*CategoryTreeNode {
+	nodes := make(map[uint]*CategoryTreeNode, len(categories))
+	sorted := slices.Clone(categories)
+	SortCategories(sorted)
+	for _, category := range sorted {
+		nodes[category.ID] = &CategoryTreeNode{Category: category}
+	}
+
+	var roots []*CategoryTreeNode
+	for _, category := range sorted {
+		node := nodes[category.ID]
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	for _, root := range roots {
+		root.countProducts(productCounts)
+	}
+	return roots
+}
+
+// countProducts sets the product counts of the node and its descendants and returns the count of the node
+func (n *CategoryTreeNode) countProducts(productCounts map[uint]int) int {
+	n.ProductCount = productCounts[n.Category.ID]
+	for _, child := range n.Children {
+		n.ProductCount += child.countProducts(productCounts)
+	}
+	return n.ProductCount
+}
+
 func (c *Category) ToCategoryDTO() *dto.CategoryDTO {
-	return &dto.CategoryDTO{
+	categoryDTO := &dto.CategoryDTO{
 		ID:          c.ID,
 		Name:        c.Name,
+		Slug:        c.Slug,
 		Description: c.Description,
+		SEO:         c.SEO.ToSEODTO(),
 		ParentID:    c.ParentID,
+		Position:    c.Position,
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
 	}
+	for _, category := range c.Path {
+		categoryDTO.Breadcrumbs = append(categoryDTO.Breadcrumbs, dto.CategoryBreadcrumbDTO{
+			ID:   category.ID,
+			Name: category.Name,
+			Slug: category.Slug,
+		})
+	}
+	return categoryDTO
+}
+
+// ToCategoryTreeDTO converts the node and its descendants to DTOs
+func (n *CategoryTreeNode) ToCategoryTreeDTO() dto.CategoryTreeDTO {
+	treeDTO := dto.CategoryTreeDTO{
+		CategoryDTO:  *n.Category.ToCategoryDTO(),
+		ProductCount: n.ProductCount,
+		Children:     make([]dto.CategoryTreeDTO, len(n.Children)),
+	}
+	for i, child := range n.Children {
+		treeDTO.Children[i] = child.ToCategoryTreeDTO()
+	}
+	return treeDTO
 }
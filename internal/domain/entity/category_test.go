@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestCategory(t *testing.T) {
@@ -103,4 +104,62 @@ func TestCategory(t *testing.T) {
 		assert.NotNil(t, dto.CreatedAt)
 		assert.NotNil(t, dto.UpdatedAt)
 	})
+
+	t.Run("CategoryPath", func(t *testing.T) {
+		categories := testCategoryTree()
+
+		path := CategoryPath(categories, 4)
+		require.Len(t, path, 3)
+		assert.Equal(t, []string{"Clothing", "Shirts", "Linen"}, []string{path[0].Name, path[1].Name, path[2].Name})
+		assert.Len(t, CategoryPath(categories, 1), 1)
+		assert.Nil(t, CategoryPath(categories, 99))
+
+		dto := categories[3].ToCategoryDTO()
+		assert.Empty(t, dto.Breadcrumbs)
+		categories[3].Path = path
+		dto = categories[3].ToCategoryDTO()
+		require.Len(t, dto.Breadcrumbs, 3)
+		assert.Equal(t, "clothing", dto.Breadcrumbs[0].Slug)
+	})
+
+	t.Run("CategoryPath stops at a cycle", func(t *testing.T) {
+		one, two := uint(1), uint(2)
+		categories := []*Category{
+			{Model: gorm.Model{ID: 1}, Name: "A", ParentID: &two},
+			{Model: gorm.Model{ID: 2}, Name: "B", ParentID: &one},
+		}
+		assert.Len(t, CategoryPath(categories, 1), 2)
+	})
+
+	t.Run("BuildCategoryTree", func(t *testing.T) {
+		tree := BuildCategoryTree(testCategoryTree(), map[uint]int{1: 1, 2: 2, 3: 4, 4: 8, 5: 16})
+
+		require.Len(t, tree, 2)
+		assert.Equal(t, "Home", tree[0].Category.Name, "position orders the root level")
+		assert.Equal(t, 16, tree[0].ProductCount)
+
+		clothing := tree[1]
+		assert.Equal(t, 15, clothing.ProductCount)
+		require.Len(t, clothing.Children, 2)
+		assert.Equal(t, "Trousers", clothing.Children[0].Category.Name)
+		assert.Equal(t, "Shirts", clothing.Children[1].Category.Name)
+		assert.Equal(t, 10, clothing.Children[1].ProductCount)
+
+		dto := clothing.ToCategoryTreeDTO()
+		assert.Equal(t, 15, dto.ProductCount)
+		assert.Len(t, dto.Children, 2)
+		assert.NotNil(t, dto.Children[0].Children)
+	})
+}
+
+// testCategoryTree returns Home and Clothing with the children Trousers, Shirts and Shirts > Linen
+func testCategoryTree() []*Category {
+	clothing, shirts := uint(1), uint(2)
+	return []*Category{
+		{Model: gorm.Model{ID: 1}, Name: "Clothing", Slug: "clothing", Position: 1},
+		{Model: gorm.Model{ID: 2}, Name: "Shirts", Slug: "shirts", ParentID: &clothing, Position: 1},
+		{Model: gorm.Model{ID: 3}, Name: "Trousers", Slug: "trousers", ParentID: &clothing, Position: 0},
+		{Model: gorm.Model{ID: 4}, Name: "Linen", Slug: "linen", ParentID: &shirts},
+		{Model: gorm.Model{ID: 5}, Name: "Home", Slug: "home", Position: 0},
+	}
 }
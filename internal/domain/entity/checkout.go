@@ -41,6 +41,7 @@ type Checkout struct {
 	TotalWeight      float64                             `gorm:"default:0"`
 	CustomerDetails  CustomerDetails                     `gorm:"embedded;embeddedPrefix:customer_"`
 	Currency         string                              `gorm:"not null;size:3"`
+	Locale           string                              `gorm:"size:10"` // Language of the customer, empty for the default locale
 	DiscountCode     string                              `gorm:"size:100"`
 	DiscountAmount   int64                               `gorm:"default:0"`
 	FinalAmount      int64                               `gorm:"default:0"`
@@ -50,6 +51,19 @@ type Checkout struct {
 	CompletedAt      *time.Time
 	ConvertedOrderID *uint  `gorm:"index"`
 	ConvertedOrder   *Order `gorm:"foreignKey:ConvertedOrderID;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+
+	// PriceChanges lists items repriced during the current request, never persisted
+	PriceChanges []CheckoutPriceChange `gorm:"-"`
+}
+
+// CheckoutPriceChange describes an item whose unit price changed after it was added,
+// for example because a sale started or ended
+type CheckoutPriceChange struct {
+	SKU           string
+	ProductName   string
+	VariantName   string
+	PreviousPrice int64
+	Price         int64
 }
 
 func (c *Checkout) CalculateTotals() {
@@ -209,6 +223,50 @@ func (c *Checkout) RemoveItem(productID uint, variantID uint) error {
 	return errors.New("product not found in checkout")
 }
 
+// SetItemPrice sets the unit price of a variant in the checkout
+func (c *Checkout) SetItemPrice(variantID uint, price int64) error {
+	if price < 0 {
+		return errors.New("price cannot be negative")
+	}
+
+	for i, item := range c.Items {
+		if item.ProductVariantID == variantID {
+			c.Items[i].Price = price
+			c.recalculateTotals()
+			c.LastActivityAt = time.Now()
+			return nil
+		}
+	}
+
+	return errors.New("product not found in checkout")
+}
+
+// RepriceItem sets the current unit price of an item and records the change so the shopper can be told.
+// It reports whether the price changed.
+func (c *Checkout) RepriceItem(variantID uint, price int64) bool {
+	for _, item := range c.Items {
+		if item.ProductVariantID != variantID || item.Price == price {
+			continue
+		}
+
+		previous := item.Price
+		if err := c.SetItemPrice(variantID, price); err != nil {
+			return false
+		}
+
+		c.PriceChanges = append(c.PriceChanges, CheckoutPriceChange{
+			SKU:           item.SKU,
+			ProductName:   item.ProductName,
+			VariantName:   item.VariantName,
+			PreviousPrice: previous,
+			Price:         price,
+		})
+		return true
+	}
+
+	return false
+}
+
 // SetShippingAddress sets the shipping address for the checkout
 func (c *Checkout) SetShippingAddress(address Address) {
 	c.ShippingAddress = datatypes.NewJSONType(address)
@@ -262,11 +320,9 @@ func (c *Checkout) SetCurrency(newCurrency string, fromCurrency *Currency, toCur
 		c.Items[i].Price = fromCurrency.ConvertAmount(c.Items[i].Price, toCurrency)
 	}
 
-	// Convert shipping cost
-	c.ShippingCost = fromCurrency.ConvertAmount(c.ShippingCost, toCurrency)
-
-	// Convert discount amount
-	c.DiscountAmount = fromCurrency.ConvertAmount(c.DiscountAmount, toCurrency)
+	// Convert shipping cost and discount amount, which are not rounded like prices
+	c.ShippingCost = fromCurrency.ConvertAmountHalfUp(c.ShippingCost, toCurrency)
+	c.DiscountAmount = fromCurrency.ConvertAmountHalfUp(c.DiscountAmount, toCurrency)
 
 	// Update currency
 	c.Currency = newCurrency
@@ -288,6 +344,7 @@ func (c *Checkout) ApplyDiscount(discount *Discount) {
 		// Calculate discount amount
 		discountAmount := discount.CalculateDiscount(&Order{
 			TotalAmount: c.TotalAmount,
+			Currency:    c.Currency,
 			Items:       convertCheckoutItemsToOrderItems(c.Items),
 		})
 
@@ -410,6 +467,17 @@ func (c *Checkout) HasShippingInfo() bool {
 		shippingAddr.Country != ""
 }
 
+// RequiresShipping returns true if any item of the checkout is a physical variant. Checkouts of
+// digital variants only need no shipping address or method.
+func (c *Checkout) RequiresShipping() bool {
+	for _, item := range c.Items {
+		if !item.ProductVariant.IsDigital {
+			return true
+		}
+	}
+	return false
+}
+
 // HasCustomerOrShippingInfo returns true if the checkout has either customer or shipping information
 func (c *Checkout) HasCustomerOrShippingInfo() bool {
 	return c.HasCustomerInfo() || c.HasShippingInfo()
@@ -488,6 +556,7 @@ func convertCheckoutItemsToOrderItems(checkoutItems []CheckoutItem) []OrderItem
 			Price:            item.Price,
 			Subtotal:         item.Price * int64(item.Quantity),
 			Weight:           item.Weight,
+			IsDigital:        item.ProductVariant.IsDigital,
 			ProductName:      item.ProductName,
 			SKU:              item.SKU,
 		}
@@ -540,7 +609,7 @@ func (c *Checkout) ToCheckoutDTO() *dto.CheckoutDTO {
 			Name:                  storedOption.Name,
 			Description:           storedOption.Description,
 			EstimatedDeliveryDays: storedOption.EstimatedDeliveryDays,
-			Cost:                  money.FromCents(storedOption.Cost),
+			Cost:                  money.FromMinor(storedOption.Cost, c.Currency),
 			FreeShipping:          storedOption.FreeShipping,
 		}
 	}
@@ -568,7 +637,18 @@ func (c *Checkout) ToCheckoutDTO() *dto.CheckoutDTO {
 	// Convert items
 	var itemDTOs []dto.CheckoutItemDTO
 	for _, item := range c.Items {
-		itemDTOs = append(itemDTOs, item.ToCheckoutItemDTO())
+		itemDTOs = append(itemDTOs, item.ToCheckoutItemDTO(c.Currency))
+	}
+
+	var priceChangeDTOs []dto.CheckoutPriceChangeDTO
+	for _, change := range c.PriceChanges {
+		priceChangeDTOs = append(priceChangeDTOs, dto.CheckoutPriceChangeDTO{
+			SKU:           change.SKU,
+			ProductName:   change.ProductName,
+			VariantName:   change.VariantName,
+			PreviousPrice: money.FromMinor(change.PreviousPrice, c.Currency),
+			Price:         money.FromMinor(change.Price, c.Currency),
+		})
 	}
 
 	return &dto.CheckoutDTO{
@@ -583,20 +663,22 @@ func (c *Checkout) ToCheckoutDTO() *dto.CheckoutDTO {
 		ShippingOption:   shippingOption,
 		CustomerDetails:  customerDetailsDTO,
 		PaymentProvider:  c.PaymentProvider,
-		TotalAmount:      money.FromCents(c.TotalAmount),
-		ShippingCost:     money.FromCents(c.ShippingCost),
+		TotalAmount:      money.FromMinor(c.TotalAmount, c.Currency),
+		ShippingCost:     money.FromMinor(c.ShippingCost, c.Currency),
 		TotalWeight:      c.TotalWeight,
 		Currency:         c.Currency,
+		Locale:           c.Locale,
 		DiscountCode:     c.DiscountCode,
-		DiscountAmount:   money.FromCents(c.DiscountAmount),
-		FinalAmount:      money.FromCents(c.FinalAmount),
+		DiscountAmount:   money.FromMinor(c.DiscountAmount, c.Currency),
+		FinalAmount:      money.FromMinor(c.FinalAmount, c.Currency),
+		PriceChanges:     priceChangeDTOs,
 		LastActivityAt:   c.LastActivityAt,
 		ExpiresAt:        c.ExpiresAt,
 	}
 }
 
-// ToAppliedDiscountDTO converts AppliedDiscount to DTO
-func (a *AppliedDiscount) ToAppliedDiscountDTO() *dto.AppliedDiscountDTO {
+// ToAppliedDiscountDTO converts AppliedDiscount to DTO, expressing the amount in the given currency
+func (a *AppliedDiscount) ToAppliedDiscountDTO(currency string) *dto.AppliedDiscountDTO {
 	if a == nil {
 		return nil
 	}
@@ -616,12 +698,12 @@ func (a *AppliedDiscount) ToAppliedDiscountDTO() *dto.AppliedDiscountDTO {
 		Type:   discountType,
 		Method: discountMethod,
 		Value:  discountValue,
-		Amount: money.FromCents(a.DiscountAmount),
+		Amount: money.FromMinor(a.DiscountAmount, currency),
 	}
 }
 
-// ToCheckoutItemDTO converts CheckoutItem to DTO
-func (c *CheckoutItem) ToCheckoutItemDTO() dto.CheckoutItemDTO {
+// ToCheckoutItemDTO converts CheckoutItem to DTO, expressing prices in the given currency
+func (c *CheckoutItem) ToCheckoutItemDTO(currency string) dto.CheckoutItemDTO {
 	return dto.CheckoutItemDTO{
 		ID:          c.ID,
 		ProductID:   c.ProductID,
@@ -630,10 +712,10 @@ func (c *CheckoutItem) ToCheckoutItemDTO() dto.CheckoutItemDTO {
 		VariantName: c.VariantName,
 		ImageURL:    c.ImageURL,
 		SKU:         c.SKU,
-		Price:       money.FromCents(c.Price),
+		Price:       money.FromMinor(c.Price, currency),
 		Quantity:    c.Quantity,
 		Weight:      c.Weight,
-		Subtotal:    money.FromCents(c.Price * int64(c.Quantity)),
+		Subtotal:    money.FromMinor(c.Price*int64(c.Quantity), currency),
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
 	}
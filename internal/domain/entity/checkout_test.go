@@ -159,6 +159,50 @@ func TestCheckout(t *testing.T) {
 		assert.Contains(t, err.Error(), "product not found in checkout")
 	})
 
+	t.Run("SetItemPrice", func(t *testing.T) {
+		checkout, err := NewCheckout("session123", "USD")
+		require.NoError(t, err)
+
+		err = checkout.AddItem(1, 1, 2, 9999, 1.5, "Test Product", "Size M", "SKU-001")
+		require.NoError(t, err)
+
+		err = checkout.SetItemPrice(1, 4500)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4500), checkout.Items[0].Price)
+		assert.Equal(t, int64(9000), checkout.TotalAmount)
+		assert.Equal(t, int64(9000), checkout.FinalAmount)
+
+		// Try to reprice non-existent item
+		err = checkout.SetItemPrice(999, 100)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "product not found in checkout")
+	})
+
+	t.Run("RepriceItem records price changes", func(t *testing.T) {
+		checkout, err := NewCheckout("session123", "USD")
+		require.NoError(t, err)
+
+		err = checkout.AddItem(1, 1, 2, 49900, 1.5, "Test Product", "Size M", "SKU-001")
+		require.NoError(t, err)
+
+		assert.False(t, checkout.RepriceItem(1, 49900))
+		assert.Empty(t, checkout.PriceChanges)
+
+		assert.True(t, checkout.RepriceItem(1, 39900))
+		assert.Equal(t, int64(79800), checkout.TotalAmount)
+		require.Len(t, checkout.PriceChanges, 1)
+		assert.Equal(t, "SKU-001", checkout.PriceChanges[0].SKU)
+		assert.Equal(t, int64(49900), checkout.PriceChanges[0].PreviousPrice)
+		assert.Equal(t, int64(39900), checkout.PriceChanges[0].Price)
+
+		checkoutDTO := checkout.ToCheckoutDTO()
+		require.Len(t, checkoutDTO.PriceChanges, 1)
+		assert.Equal(t, 499.0, checkoutDTO.PriceChanges[0].PreviousPrice)
+		assert.Equal(t, 399.0, checkoutDTO.PriceChanges[0].Price)
+
+		assert.False(t, checkout.RepriceItem(999, 100))
+	})
+
 	t.Run("RemoveItem", func(t *testing.T) {
 		checkout, err := NewCheckout("session123", "USD")
 		require.NoError(t, err)
@@ -0,0 +1,245 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+	"gorm.io/datatypes"
+)
+
+// CollectionType distinguishes hand-picked collections from collections filled by rules
+type CollectionType string
+
+const (
+	CollectionTypeManual CollectionType = "manual" // Ordered list of products picked by admins
+	CollectionTypeSmart  CollectionType = "smart"  // Products matching the rules of the collection
+)
+
+// MaxCollectionProducts is the maximum number of products in a manual collection
+const MaxCollectionProducts = 500
+
+// CollectionRules select the products of a smart collection. A product matches when it
+// is in one of the categories and was created in the period, and a single variant
+// satisfies all attribute, price and stock conditions.
+type CollectionRules struct {
+	CategoryIDs       []uint              `json:"category_ids,omitempty"` // Any of these categories or their descendants
+	Attributes        map[string][]string `json:"attributes,omitempty"`   // Attribute name to accepted values
+	MinPrice          int64               `json:"min_price,omitempty"`    // Minimum effective variant price in minor units
+	MaxPrice          int64               `json:"max_price,omitempty"`    // Maximum effective variant price in minor units
+	Currency          string              `json:"currency,omitempty"`     // Currency of the price rules
+	CreatedAfter      *time.Time          `json:"created_after,omitempty"`
+	CreatedWithinDays int                 `json:"created_within_days,omitempty"` // Products created in the last days, e.g. new arrivals
+	InStockOnly       bool                `json:"in_stock_only,omitempty"`
+}
+
+// IsEmpty returns true when the rules select every product
+func (r CollectionRules) IsEmpty() bool {
+	return len(r.CategoryIDs) == 0 && len(r.Attributes) == 0 && r.MinPrice == 0 && r.MaxPrice == 0 &&
+		r.CreatedAfter == nil && r.CreatedWithinDays == 0 && !r.InStockOnly
+}
+
+// Validate checks the rules
+func (r CollectionRules) Validate() error {
+	if r.MinPrice < 0 || r.MaxPrice < 0 {
+		return errors.New("collection price rules cannot be negative")
+	}
+	if r.MaxPrice > 0 && r.MinPrice > r.MaxPrice {
+		return errors.New("collection minimum price cannot exceed the maximum price")
+	}
+	if r.CreatedWithinDays < 0 {
+		return errors.New("collection created within days cannot be negative")
+	}
+	for name := range r.Attributes {
+		if strings.TrimSpace(name) == "" {
+			return errors.New("collection attribute name cannot be empty")
+		}
+	}
+	return nil
+}
+
+// Collection groups products for merchandising, e.g. "Summer picks", independently of categories
+type Collection struct {
+	ID          uint                                `gorm:"primaryKey"`
+	Name        string                              `gorm:"not null;size:255"`
+	Slug        string                              `gorm:"size:200;index:idx_collections_slug,unique,where:slug <> ''"`
+	Description string                              `gorm:"type:text"`
+	SEO         SEOMetadata                         `gorm:"embedded"`
+	Type        CollectionType                      `gorm:"not null;size:20"`
+	Rules       datatypes.JSONType[CollectionRules] `gorm:"not null;default:'{}'"` // Only used by smart collections
+	Active      bool                                `gorm:"not null;default:false"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// CollectionProduct places a product in a manual collection
+type CollectionProduct struct {
+	ID           uint `gorm:"primaryKey"`
+	CollectionID uint `gorm:"not null;uniqueIndex:idx_collection_products_link"`
+	ProductID    uint `gorm:"not null;index;uniqueIndex:idx_collection_products_link"`
+	Position     int  `gorm:"not null;default:0"`
+	CreatedAt    time.Time
+}
+
+// IsValidCollectionType returns true for the known collection types
+func IsValidCollectionType(collectionType CollectionType) bool {
+	return collectionType == CollectionTypeManual || collectionType == CollectionTypeSmart
+}
+
+// NewCollection creates an inactive collection. Smart collections need at least one rule.
+func NewCollection(name, description string, collectionType CollectionType, rules CollectionRules) (*Collection, error) {
+	if !IsValidCollectionType(collectionType) {
+		return nil, fmt.Errorf("invalid collection type %q", collectionType)
+	}
+
+	collection := &Collection{Type: collectionType}
+	if err := collection.SetDetails(name, description); err != nil {
+		return nil, err
+	}
+	if err := collection.SetRules(rules); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// SetDetails updates the name and description of the collection
+func (c *Collection) SetDetails(name, description string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("collection name cannot be empty")
+	}
+	if len(name) > 255 {
+		return errors.New("collection name cannot exceed 255 characters")
+	}
+	if len(description) > 65535 {
+		return errors.New("collection description cannot exceed 65535 characters")
+	}
+
+	c.Name = name
+	c.Description = description
+	return nil
+}
+
+// SetRules replaces the rules of a smart collection
+func (c *Collection) SetRules(rules CollectionRules) error {
+	if c.Type == CollectionTypeManual {
+		if !rules.IsEmpty() {
+			return errors.New("manual collections cannot have rules")
+		}
+		c.Rules = datatypes.NewJSONType(CollectionRules{})
+		return nil
+	}
+
+	if rules.IsEmpty() {
+		return errors.New("smart collections need at least one rule")
+	}
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+	c.Rules = datatypes.NewJSONType(rules)
+	return nil
+}
+
+// IsSmart returns true when the products of the collection are selected by its rules
+func (c *Collection) IsSmart() bool {
+	return c.Type == CollectionTypeSmart
+}
+
+// MatchRules returns the products matching the rules of a smart collection at the given time, keeping their order
+func (c *Collection) MatchRules(products []*Product, categories []*Category, at time.Time) []*Product {
+	rules := c.Rules.Data()
+	filter := ProductFilter{
+		Attributes:  rules.Attributes,
+		MinPrice:    rules.MinPrice,
+		MaxPrice:    rules.MaxPrice,
+		InStockOnly: rules.InStockOnly,
+	}
+	for _, categoryID := range rules.CategoryIDs {
+		for _, id := range CategoryDescendantIDs(categories, categoryID) {
+			if !slices.Contains(filter.CategoryIDs, id) {
+				filter.CategoryIDs = append(filter.CategoryIDs, id)
+			}
+		}
+	}
+
+	var createdAfter time.Time
+	if rules.CreatedAfter != nil {
+		createdAfter = *rules.CreatedAfter
+	}
+	if rules.CreatedWithinDays > 0 {
+		if since := at.AddDate(0, 0, -rules.CreatedWithinDays); since.After(createdAfter) {
+			createdAfter = since
+		}
+	}
+
+	matched := make([]*Product, 0, len(products))
+	for _, product := range products {
+		if product.CreatedAt.Before(createdAfter) {
+			continue
+		}
+		if filter.matches(product, facetDimension{}, at) {
+			matched = append(matched, product)
+		}
+	}
+	return matched
+}
+
+// NewCollectionProducts places the products in a manual collection in the given order
+func NewCollectionProducts(collectionID uint, productIDs []uint) ([]*CollectionProduct, error) {
+	if len(productIDs) > MaxCollectionProducts {
+		return nil, fmt.Errorf("a collection cannot have more than %d products", MaxCollectionProducts)
+	}
+
+	products := make([]*CollectionProduct, 0, len(productIDs))
+	seen := make(map[uint]bool, len(productIDs))
+	for i, productID := range productIDs {
+		if productID == 0 {
+			return nil, errors.New("product ID is required")
+		}
+		if seen[productID] {
+			return nil, fmt.Errorf("product %d is listed more than once", productID)
+		}
+		seen[productID] = true
+
+		products = append(products, &CollectionProduct{
+			CollectionID: collectionID,
+			ProductID:    productID,
+			Position:     i,
+		})
+	}
+	return products, nil
+}
+
+// ToCollectionDTO converts the collection to a DTO
+func (c *Collection) ToCollectionDTO() dto.CollectionDTO {
+	collectionDTO := dto.CollectionDTO{
+		ID:          c.ID,
+		Name:        c.Name,
+		Slug:        c.Slug,
+		Description: c.Description,
+		SEO:         c.SEO.ToSEODTO(),
+		Type:        string(c.Type),
+		Active:      c.Active,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+
+	if c.IsSmart() {
+		rules := c.Rules.Data()
+		collectionDTO.Rules = &dto.CollectionRulesDTO{
+			CategoryIDs:       rules.CategoryIDs,
+			Attributes:        rules.Attributes,
+			MinPrice:          money.FromMinor(rules.MinPrice, rules.Currency),
+			MaxPrice:          money.FromMinor(rules.MaxPrice, rules.Currency),
+			Currency:          rules.Currency,
+			CreatedAfter:      rules.CreatedAfter,
+			CreatedWithinDays: rules.CreatedWithinDays,
+			InStockOnly:       rules.InStockOnly,
+		}
+	}
+	return collectionDTO
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollection(t *testing.T) {
+	t.Run("NewCollection validates the type and rules", func(t *testing.T) {
+		_, err := NewCollection("Summer picks", "", "seasonal", CollectionRules{})
+		assert.ErrorContains(t, err, "invalid collection type")
+		_, err = NewCollection(" ", "", CollectionTypeManual, CollectionRules{})
+		assert.ErrorContains(t, err, "collection name cannot be empty")
+		_, err = NewCollection("Summer picks", "", CollectionTypeManual, CollectionRules{MaxPrice: 20000})
+		assert.ErrorContains(t, err, "manual collections cannot have rules")
+		_, err = NewCollection("Everything", "", CollectionTypeSmart, CollectionRules{})
+		assert.ErrorContains(t, err, "need at least one rule")
+		_, err = NewCollection("Odd prices", "", CollectionTypeSmart, CollectionRules{MinPrice: 500, MaxPrice: 100})
+		assert.ErrorContains(t, err, "minimum price cannot exceed the maximum price")
+
+		collection, err := NewCollection(" Under 200 kr ", "Gifts", CollectionTypeSmart, CollectionRules{MaxPrice: 20000})
+		require.NoError(t, err)
+		assert.Equal(t, "Under 200 kr", collection.Name)
+		assert.False(t, collection.Active)
+		assert.Equal(t, int64(20000), collection.Rules.Data().MaxPrice)
+	})
+
+	t.Run("MatchRules selects products by category, price, attributes, stock and creation date", func(t *testing.T) {
+		now := time.Now()
+		parent := uint(1)
+		categories := []*Category{{Name: "Clothing"}, {Name: "Shirts", ParentID: &parent}, {Name: "Kitchen"}}
+		categories[0].ID, categories[1].ID, categories[2].ID = 1, 2, 3
+
+		newProduct := func(id, categoryID uint, price int64, color string, stock int, created time.Time) *Product {
+			variant, err := NewProductVariant("SKU", stock, price, 0, VariantAttributes{"color": color}, nil, true)
+			require.NoError(t, err)
+			product := &Product{CategoryID: categoryID, Variants: []*ProductVariant{variant}}
+			product.ID = id
+			product.CreatedAt = created
+			return product
+		}
+		products := []*Product{
+			newProduct(1, 2, 15000, "Blue", 5, now.AddDate(0, 0, -3)),
+			newProduct(2, 2, 25000, "Blue", 5, now.AddDate(0, 0, -3)),
+			newProduct(3, 3, 9000, "Blue", 5, now.AddDate(0, 0, -3)),
+			newProduct(4, 1, 9000, "Red", 5, now.AddDate(0, 0, -3)),
+			newProduct(5, 1, 9000, "Blue", 0, now.AddDate(0, 0, -3)),
+			newProduct(6, 1, 9000, "Blue", 5, now.AddDate(0, 0, -60)),
+		}
+		ids := func(products []*Product) []uint {
+			result := make([]uint, len(products))
+			for i, product := range products {
+				result[i] = product.ID
+			}
+			return result
+		}
+
+		collection, err := NewCollection("Blue clothing under 200", "", CollectionTypeSmart, CollectionRules{
+			CategoryIDs:       []uint{1},
+			Attributes:        map[string][]string{"color": {"blue"}},
+			MaxPrice:          20000,
+			InStockOnly:       true,
+			CreatedWithinDays: 30,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{1}, ids(collection.MatchRules(products, categories, now)))
+
+		after := now.AddDate(0, 0, -90)
+		require.NoError(t, collection.SetRules(CollectionRules{MaxPrice: 10000, CreatedAfter: &after}))
+		assert.Equal(t, []uint{3, 4, 5, 6}, ids(collection.MatchRules(products, categories, now)))
+	})
+
+	t.Run("NewCollectionProducts keeps the display order", func(t *testing.T) {
+		products, err := NewCollectionProducts(1, []uint{3, 1, 2})
+		require.NoError(t, err)
+		require.Len(t, products, 3)
+		assert.Equal(t, uint(3), products[0].ProductID)
+		assert.Equal(t, 2, products[2].Position)
+
+		_, err = NewCollectionProducts(1, []uint{3, 3})
+		assert.ErrorContains(t, err, "product 3 is listed more than once")
+		_, err = NewCollectionProducts(1, []uint{0})
+		assert.ErrorContains(t, err, "product ID is required")
+	})
+}
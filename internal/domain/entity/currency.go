@@ -2,9 +2,11 @@ package entity
 
 import (
 	"errors"
+	"math"
 	"strings"
 
 	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/money"
 	"gorm.io/gorm"
 )
 
@@ -17,6 +19,64 @@ type Currency struct {
 	ExchangeRate float64 `gorm:"not null;default:1.0"`
 	IsEnabled    bool    `gorm:"not null;default:true"`
 	IsDefault    bool    `gorm:"not null;default:false"`
+	// RoundingStrategy is applied to amounts converted into this currency
+	RoundingStrategy money.RoundingStrategy `gorm:"size:20;not null;default:'half_up'"`
+}
+
+// CurrencyAmounts holds explicit amounts in cents keyed by currency code.
+// It is used to override amounts that would otherwise be converted using exchange rates.
+type CurrencyAmounts map[string]int64
+
+// NewCurrencyAmounts validates and normalizes a set of per-currency amounts
+func NewCurrencyAmounts(amounts map[string]int64) (CurrencyAmounts, error) {
+	result := make(CurrencyAmounts, len(amounts))
+	for code, amount := range amounts {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" {
+			return nil, errors.New("currency code is required")
+		}
+		if amount < 0 {
+			return nil, errors.New("amount for currency " + code + " cannot be negative")
+		}
+		result[code] = amount
+	}
+
+	return result, nil
+}
+
+// Get returns the explicit amount for the given currency, if one is set
+func (a CurrencyAmounts) Get(currency string) (int64, bool) {
+	amount, ok := a[strings.ToUpper(currency)]
+	return amount, ok
+}
+
+// currencyConversion converts amounts configured in the default currency into the currency of a
+// checkout or order. The zero value converts nothing, as in stores with a single currency.
+type currencyConversion struct {
+	base   *Currency
+	target *Currency
+}
+
+// convertsTo reports whether amounts are converted into the given currency
+func (c currencyConversion) convertsTo(currency string) bool {
+	return c.base != nil && c.target != nil && strings.EqualFold(c.target.Code, currency)
+}
+
+// baseCode returns the code of the default currency the amounts are configured in, or an empty
+// string when it is not known
+func (c currencyConversion) baseCode() string {
+	if c.base == nil {
+		return ""
+	}
+	return c.base.Code
+}
+
+// amountIn expresses an amount of the default currency in the given currency
+func (c currencyConversion) amountIn(amount int64, currency string) int64 {
+	if amount == 0 || !c.convertsTo(currency) {
+		return amount
+	}
+	return c.base.ConvertAmountHalfUp(amount, c.target)
 }
 
 // NewCurrency creates a new Currency
@@ -39,15 +99,32 @@ func NewCurrency(code, name, symbol string, exchangeRate float64, isEnabled bool
 	}
 
 	return &Currency{
-		Code:         strings.ToUpper(code),
-		Name:         name,
-		Symbol:       symbol,
-		ExchangeRate: exchangeRate,
-		IsEnabled:    isEnabled,
-		IsDefault:    isDefault,
+		Code:             strings.ToUpper(code),
+		Name:             name,
+		Symbol:           symbol,
+		ExchangeRate:     exchangeRate,
+		IsEnabled:        isEnabled,
+		IsDefault:        isDefault,
+		RoundingStrategy: money.DefaultRoundingStrategy,
 	}, nil
 }
 
+// SetRoundingStrategy sets the rounding strategy used for amounts converted into this currency
+func (c *Currency) SetRoundingStrategy(name string) error {
+	strategy, err := money.ParseRoundingStrategy(name)
+	if err != nil {
+		return err
+	}
+	c.RoundingStrategy = strategy
+
+	return nil
+}
+
+// MinorUnits returns the number of decimals of the currency's minor unit
+func (c *Currency) MinorUnits() int {
+	return money.MinorUnits(c.Code)
+}
+
 // SetExchangeRate sets the exchange rate for the currency
 func (c *Currency) SetExchangeRate(rate float64) error {
 	if rate <= 0 {
@@ -88,29 +165,51 @@ func (c *Currency) UnsetAsDefault() error {
 	return nil
 }
 
-// ConvertAmount converts an amount from this currency to the target currency
+// ConvertAmount converts a price in minor units of this currency to minor units of the target currency.
+// The result is rounded using the target currency's rounding strategy, e.g. to a charm price.
 func (c *Currency) ConvertAmount(amount int64, targetCurrency *Currency) int64 {
+	return c.convert(amount, targetCurrency, targetCurrency.RoundingStrategy)
+}
+
+// ConvertAmountHalfUp converts an amount in minor units of this currency to minor units of the target
+// currency, rounded half up. It is used for amounts other than prices, like shipping costs and discounts,
+// to which the rounding strategy of the target currency does not apply.
+func (c *Currency) ConvertAmountHalfUp(amount int64, targetCurrency *Currency) int64 {
+	return c.convert(amount, targetCurrency, money.RoundHalfUp)
+}
+
+// convert converts an amount in minor units to the target currency, rounded with the strategy
+func (c *Currency) convert(amount int64, targetCurrency *Currency, strategy money.RoundingStrategy) int64 {
 	if c.Code == targetCurrency.Code {
 		return amount
 	}
 
 	// First convert to a base unit
-	baseAmount := float64(amount) / c.ExchangeRate
+	baseAmount := money.FromMinor(amount, c.Code) / c.ExchangeRate
 
-	// Then convert to target currency
-	targetAmount := baseAmount * targetCurrency.ExchangeRate
+	// Then convert to target currency minor units
+	targetAmount := baseAmount * targetCurrency.ExchangeRate * math.Pow10(targetCurrency.MinorUnits())
 
-	// Round to nearest cent instead of truncating
-	return int64(targetAmount)
+	return money.Round(targetAmount, targetCurrency.Code, strategy)
 }
 
 func (c Currency) ToCurrencyDTO() *dto.CurrencyDTO {
 	return &dto.CurrencyDTO{
-		Code:         c.Code,
-		Name:         c.Name,
-		Symbol:       c.Symbol,
-		ExchangeRate: c.ExchangeRate,
-		IsEnabled:    c.IsEnabled,
-		IsDefault:    c.IsDefault,
+		Code:             c.Code,
+		Name:             c.Name,
+		Symbol:           c.Symbol,
+		ExchangeRate:     c.ExchangeRate,
+		IsEnabled:        c.IsEnabled,
+		IsDefault:        c.IsDefault,
+		MinorUnits:       c.MinorUnits(),
+		RoundingStrategy: string(c.roundingStrategy()),
+	}
+}
+
+// roundingStrategy returns the configured rounding strategy or the default one
+func (c Currency) roundingStrategy() money.RoundingStrategy {
+	if c.RoundingStrategy == "" {
+		return money.DefaultRoundingStrategy
 	}
+	return c.RoundingStrategy
 }
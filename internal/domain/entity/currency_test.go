@@ -173,6 +173,56 @@ func TestCurrency(t *testing.T) {
 		assert.Equal(t, int64(10000), converted)
 	})
 
+	t.Run("ConvertAmount respects minor units", func(t *testing.T) {
+		usd, err := NewCurrency("USD", "US Dollar", "$", 1.0, true, true)
+		require.NoError(t, err)
+
+		jpy, err := NewCurrency("JPY", "Japanese Yen", "¥", 150.0, true, false)
+		require.NoError(t, err)
+
+		kwd, err := NewCurrency("KWD", "Kuwaiti Dinar", "KD", 0.3, true, false)
+		require.NoError(t, err)
+
+		// $10.00 is 1500 yen, which has no minor units
+		assert.Equal(t, int64(1500), usd.ConvertAmount(1000, jpy))
+		assert.Equal(t, int64(1000), jpy.ConvertAmount(1500, usd))
+
+		// $10.00 is 3.000 dinar, which has three decimals
+		assert.Equal(t, int64(3000), usd.ConvertAmount(1000, kwd))
+	})
+
+	t.Run("ConvertAmount uses target rounding strategy", func(t *testing.T) {
+		usd, err := NewCurrency("USD", "US Dollar", "$", 1.0, true, true)
+		require.NoError(t, err)
+
+		eur, err := NewCurrency("EUR", "Euro", "€", 0.85, true, false)
+		require.NoError(t, err)
+
+		// $12.34 is €10.489
+		assert.Equal(t, int64(1049), usd.ConvertAmount(1234, eur))
+
+		require.NoError(t, eur.SetRoundingStrategy("down"))
+		assert.Equal(t, int64(1048), usd.ConvertAmount(1234, eur))
+
+		require.NoError(t, eur.SetRoundingStrategy("charm_99"))
+		assert.Equal(t, int64(1099), usd.ConvertAmount(1234, eur))
+
+		assert.Error(t, eur.SetRoundingStrategy("sideways"))
+	})
+
+	t.Run("ConvertAmountHalfUp ignores the target rounding strategy", func(t *testing.T) {
+		usd, err := NewCurrency("USD", "US Dollar", "$", 1.0, true, true)
+		require.NoError(t, err)
+
+		eur, err := NewCurrency("EUR", "Euro", "€", 0.85, true, false)
+		require.NoError(t, err)
+		require.NoError(t, eur.SetRoundingStrategy("charm_99"))
+
+		// $12.34 is €10.489
+		assert.Equal(t, int64(1049), usd.ConvertAmountHalfUp(1234, eur))
+		assert.Equal(t, int64(1234), usd.ConvertAmountHalfUp(1234, usd))
+	})
+
 	t.Run("ToCurrencyDTO", func(t *testing.T) {
 		currency, err := NewCurrency("JPY", "Japanese Yen", "¥", 110.0, true, false)
 		require.NoError(t, err)
@@ -184,5 +234,7 @@ func TestCurrency(t *testing.T) {
 		assert.Equal(t, 110.0, dto.ExchangeRate)
 		assert.True(t, dto.IsEnabled)
 		assert.False(t, dto.IsDefault)
+		assert.Equal(t, 0, dto.MinorUnits)
+		assert.Equal(t, "half_up", dto.RoundingStrategy)
 	})
 }
@@ -0,0 +1,308 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+)
+
+// Custom field resources
+const (
+	CustomFieldResourceProduct        = "product"
+	CustomFieldResourceProductVariant = "product_variant"
+	CustomFieldResourceOrder          = "order"
+	CustomFieldResourceUser           = "user"
+)
+
+// CustomFieldType is the type of the values of a custom field
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText    CustomFieldType = "text"
+	CustomFieldTypeNumber  CustomFieldType = "number"
+	CustomFieldTypeBoolean CustomFieldType = "boolean"
+	CustomFieldTypeDate    CustomFieldType = "date"   // Calendar date formatted as 2006-01-02
+	CustomFieldTypeSelect  CustomFieldType = "select" // One of the options of the definition
+)
+
+// MaxCustomFieldTextLength is the maximum length of a text value
+const MaxCustomFieldTextLength = 1000
+
+// customFieldKeyPattern matches the keys of custom fields, e.g. "erp_id"
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// CustomFields holds the custom field values of a product, variant, order or user by key
+type CustomFields map[string]any
+
+// CustomFieldDefinition describes a custom field admins can set on a resource. Values are validated
+// against the definition; only public fields are shown to customers.
+type CustomFieldDefinition struct {
+	ID       uint            `gorm:"primaryKey"`
+	Resource string          `gorm:"not null;size:20;uniqueIndex:idx_custom_field_definitions_key"`
+	Key      string          `gorm:"not null;size:64;uniqueIndex:idx_custom_field_definitions_key"`
+	Label    string          `gorm:"not null;size:255"`
+	Type     CustomFieldType `gorm:"not null;size:20"`
+	Required bool            `gorm:"not null;default:false"`
+	Public   bool            `gorm:"not null;default:false"` // Whether customers see the field in storefront responses
+
+	// Validation rules. Min and Max bound numbers, or the length of texts.
+	Options   []string `gorm:"type:jsonb;serializer:json"` // Accepted values of select fields
+	Pattern   string   `gorm:"size:255"`                   // Regular expression texts must match
+	Min       *float64
+	Max       *float64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CustomFieldFilter matches the resources whose custom field equals a value
+type CustomFieldFilter struct {
+	Key   string
+	Value any
+}
+
+// IsValidCustomFieldResource returns true for the resources that can have custom fields
+func IsValidCustomFieldResource(resource string) bool {
+	return resource == CustomFieldResourceProduct ||
+		resource == CustomFieldResourceProductVariant ||
+		resource == CustomFieldResourceOrder ||
+		resource == CustomFieldResourceUser
+}
+
+// IsValid returns true for the known custom field types
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case CustomFieldTypeText, CustomFieldTypeNumber, CustomFieldTypeBoolean, CustomFieldTypeDate, CustomFieldTypeSelect:
+		return true
+	}
+	return false
+}
+
+// NewCustomFieldDefinition creates the definition of a custom field. The resource, key and type cannot change
+// afterwards; the label and validation rules are set with Update.
+func NewCustomFieldDefinition(resource, key string, fieldType CustomFieldType) (*CustomFieldDefinition, error) {
+	if !IsValidCustomFieldResource(resource) {
+		return nil, fmt.Errorf("invalid custom field resource %q", resource)
+	}
+	if !customFieldKeyPattern.MatchString(key) {
+		return nil, fmt.Errorf("invalid custom field key %q: use lowercase letters, digits and underscores, starting with a letter", key)
+	}
+	if !fieldType.IsValid() {
+		return nil, fmt.Errorf("invalid custom field type %q", fieldType)
+	}
+
+	return &CustomFieldDefinition{
+		Resource: resource,
+		Key:      key,
+		Type:     fieldType,
+	}, nil
+}
+
+// Update sets the label, visibility and validation rules of the definition
+func (d *CustomFieldDefinition) Update(label string, required, public bool, options []string, pattern string, min, max *float64) error {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return errors.New("custom field label is required")
+	}
+	if len(label) > 255 {
+		return errors.New("custom field label cannot exceed 255 characters")
+	}
+
+	cleanOptions := make([]string, 0, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return errors.New("custom field options cannot be empty")
+		}
+		if slices.Contains(cleanOptions, option) {
+			return fmt.Errorf("custom field option %q is listed more than once", option)
+		}
+		cleanOptions = append(cleanOptions, option)
+	}
+	switch {
+	case d.Type == CustomFieldTypeSelect && len(cleanOptions) == 0:
+		return errors.New("select custom fields require at least one option")
+	case d.Type != CustomFieldTypeSelect && len(cleanOptions) > 0:
+		return errors.New("only select custom fields can have options")
+	}
+
+	if pattern != "" {
+		if d.Type != CustomFieldTypeText {
+			return errors.New("only text custom fields can have a pattern")
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid custom field pattern: %w", err)
+		}
+	}
+
+	if min != nil || max != nil {
+		if d.Type != CustomFieldTypeText && d.Type != CustomFieldTypeNumber {
+			return errors.New("only text and number custom fields can have a minimum or maximum")
+		}
+		if d.Type == CustomFieldTypeText && ((min != nil && *min < 0) || (max != nil && *max < 0)) {
+			return errors.New("custom field lengths cannot be negative")
+		}
+		if min != nil && max != nil && *min > *max {
+			return errors.New("custom field minimum cannot be greater than the maximum")
+		}
+	}
+
+	d.Label = label
+	d.Required = required
+	d.Public = public
+	d.Options = cleanOptions
+	d.Pattern = pattern
+	d.Min = min
+	d.Max = max
+	return nil
+}
+
+// Validate checks a value decoded from JSON against the definition and returns it in its stored form
+func (d *CustomFieldDefinition) Validate(value any) (any, error) {
+	switch d.Type {
+	case CustomFieldTypeText:
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("custom field %s must be a text", d.Key)
+		}
+		length := utf8.RuneCountInString(text)
+		if length > MaxCustomFieldTextLength {
+			return nil, fmt.Errorf("custom field %s cannot exceed %d characters", d.Key, MaxCustomFieldTextLength)
+		}
+		if d.Min != nil && float64(length) < *d.Min {
+			return nil, fmt.Errorf("custom field %s must be at least %g characters", d.Key, *d.Min)
+		}
+		if d.Max != nil && float64(length) > *d.Max {
+			return nil, fmt.Errorf("custom field %s cannot exceed %g characters", d.Key, *d.Max)
+		}
+		if d.Pattern != "" {
+			if matched, err := regexp.MatchString(d.Pattern, text); err != nil || !matched {
+				return nil, fmt.Errorf("custom field %s does not match the pattern %s", d.Key, d.Pattern)
+			}
+		}
+		return text, nil
+
+	case CustomFieldTypeNumber:
+		number, ok := value.(float64)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, fmt.Errorf("custom field %s must be a number", d.Key)
+		}
+		if d.Min != nil && number < *d.Min {
+			return nil, fmt.Errorf("custom field %s must be at least %g", d.Key, *d.Min)
+		}
+		if d.Max != nil && number > *d.Max {
+			return nil, fmt.Errorf("custom field %s cannot exceed %g", d.Key, *d.Max)
+		}
+		return number, nil
+
+	case CustomFieldTypeBoolean:
+		flag, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("custom field %s must be true or false", d.Key)
+		}
+		return flag, nil
+
+	case CustomFieldTypeDate:
+		date, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("custom field %s must be a date formatted as YYYY-MM-DD", d.Key)
+		}
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return nil, fmt.Errorf("custom field %s must be a date formatted as YYYY-MM-DD", d.Key)
+		}
+		return date, nil
+
+	case CustomFieldTypeSelect:
+		option, ok := value.(string)
+		if !ok || !slices.Contains(d.Options, option) {
+			return nil, fmt.Errorf("custom field %s must be one of %s", d.Key, strings.Join(d.Options, ", "))
+		}
+		return option, nil
+	}
+	return nil, fmt.Errorf("invalid custom field type %q", d.Type)
+}
+
+// ParseFilter converts a query parameter value to a filter on the field
+func (d *CustomFieldDefinition) ParseFilter(raw string) (CustomFieldFilter, error) {
+	var value any = raw
+	switch d.Type {
+	case CustomFieldTypeNumber:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return CustomFieldFilter{}, fmt.Errorf("custom field %s must be a number", d.Key)
+		}
+		value = number
+	case CustomFieldTypeBoolean:
+		flag, err := strconv.ParseBool(raw)
+		if err != nil {
+			return CustomFieldFilter{}, fmt.Errorf("custom field %s must be true or false", d.Key)
+		}
+		value = flag
+	}
+	return CustomFieldFilter{Key: d.Key, Value: value}, nil
+}
+
+// ApplyCustomFields merges values into the current custom fields of a resource. A null value removes the
+// field. Values of fields that are no longer defined are dropped, and required fields must have a value.
+func ApplyCustomFields(definitions []*CustomFieldDefinition, current CustomFields, values map[string]any) (CustomFields, error) {
+	byKey := make(map[string]*CustomFieldDefinition, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	result := make(CustomFields, len(current)+len(values))
+	for key, value := range current {
+		if _, ok := byKey[key]; ok {
+			result[key] = value
+		}
+	}
+
+	for key, value := range values {
+		definition, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown custom field %s", key)
+		}
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		validated, err := definition.Validate(value)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = validated
+	}
+
+	for _, definition := range definitions {
+		if _, ok := result[definition.Key]; definition.Required && !ok {
+			return nil, fmt.Errorf("custom field %s is required", definition.Key)
+		}
+	}
+	return result, nil
+}
+
+// ToCustomFieldDefinitionDTO converts the definition to a DTO
+func (d *CustomFieldDefinition) ToCustomFieldDefinitionDTO() dto.CustomFieldDefinitionDTO {
+	return dto.CustomFieldDefinitionDTO{
+		ID:        d.ID,
+		Resource:  d.Resource,
+		Key:       d.Key,
+		Label:     d.Label,
+		Type:      string(d.Type),
+		Required:  d.Required,
+		Public:    d.Public,
+		Options:   d.Options,
+		Pattern:   d.Pattern,
+		Min:       d.Min,
+		Max:       d.Max,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomFieldDefinition(t *testing.T) {
+	t.Run("NewCustomFieldDefinition validates the resource, key and type", func(t *testing.T) {
+		_, err := NewCustomFieldDefinition("category", "erp_id", CustomFieldTypeText)
+		assert.ErrorContains(t, err, "invalid custom field resource")
+		_, err = NewCustomFieldDefinition(CustomFieldResourceProduct, "ERP-id", CustomFieldTypeText)
+		assert.ErrorContains(t, err, "invalid custom field key")
+		_, err = NewCustomFieldDefinition(CustomFieldResourceProduct, "erp_id", "json")
+		assert.ErrorContains(t, err, "invalid custom field type")
+
+		definition, err := NewCustomFieldDefinition(CustomFieldResourceOrder, "gift", CustomFieldTypeBoolean)
+		require.NoError(t, err)
+		assert.Equal(t, CustomFieldResourceOrder, definition.Resource)
+		assert.Equal(t, "gift", definition.Key)
+	})
+
+	t.Run("Update only accepts the rules of the field type", func(t *testing.T) {
+		min, max := 5.0, 1.0
+
+		text, err := NewCustomFieldDefinition(CustomFieldResourceProduct, "erp_id", CustomFieldTypeText)
+		require.NoError(t, err)
+		assert.ErrorContains(t, text.Update(" ", false, false, nil, "", nil, nil), "label is required")
+		assert.ErrorContains(t, text.Update("ERP ID", false, false, []string{"a"}, "", nil, nil), "only select custom fields can have options")
+		assert.ErrorContains(t, text.Update("ERP ID", false, false, nil, "[", nil, nil), "invalid custom field pattern")
+		assert.ErrorContains(t, text.Update("ERP ID", false, false, nil, "", &min, &max), "minimum cannot be greater than the maximum")
+
+		selection, err := NewCustomFieldDefinition(CustomFieldResourceProduct, "season", CustomFieldTypeSelect)
+		require.NoError(t, err)
+		assert.ErrorContains(t, selection.Update("Season", false, true, nil, "", nil, nil), "require at least one option")
+		assert.ErrorContains(t, selection.Update("Season", false, true, []string{"summer", "summer"}, "", nil, nil), "listed more than once")
+		require.NoError(t, selection.Update(" Season ", true, true, []string{" summer ", "winter"}, "", nil, nil))
+		assert.Equal(t, "Season", selection.Label)
+		assert.Equal(t, []string{"summer", "winter"}, selection.Options)
+		assert.True(t, selection.Required)
+		assert.True(t, selection.Public)
+
+		flag, err := NewCustomFieldDefinition(CustomFieldResourceOrder, "gift", CustomFieldTypeBoolean)
+		require.NoError(t, err)
+		assert.ErrorContains(t, flag.Update("Gift", false, false, nil, "^a", nil, nil), "only text custom fields can have a pattern")
+		assert.ErrorContains(t, flag.Update("Gift", false, false, nil, "", &min, nil), "only text and number custom fields")
+	})
+
+	t.Run("Validate checks values against the definition", func(t *testing.T) {
+		min, max := 0.0, 100.0
+		number := &CustomFieldDefinition{Key: "weight_class", Type: CustomFieldTypeNumber, Min: &min, Max: &max}
+		value, err := number.Validate(float64(42))
+		require.NoError(t, err)
+		assert.Equal(t, float64(42), value)
+		_, err = number.Validate("42")
+		assert.ErrorContains(t, err, "custom field weight_class must be a number")
+		_, err = number.Validate(float64(101))
+		assert.ErrorContains(t, err, "cannot exceed 100")
+
+		length := 3.0
+		text := &CustomFieldDefinition{Key: "erp_id", Type: CustomFieldTypeText, Pattern: `^A-\d+$`, Min: &length}
+		_, err = text.Validate("A-1")
+		require.NoError(t, err)
+		_, err = text.Validate("B-1")
+		assert.ErrorContains(t, err, "does not match the pattern")
+		_, err = text.Validate("A-")
+		assert.ErrorContains(t, err, "at least 3 characters")
+
+		date := &CustomFieldDefinition{Key: "launch", Type: CustomFieldTypeDate}
+		_, err = date.Validate("2026-02-30")
+		assert.ErrorContains(t, err, "must be a date")
+		_, err = date.Validate("2026-02-28")
+		assert.NoError(t, err)
+
+		selection := &CustomFieldDefinition{Key: "season", Type: CustomFieldTypeSelect, Options: []string{"summer", "winter"}}
+		_, err = selection.Validate("spring")
+		assert.ErrorContains(t, err, "must be one of summer, winter")
+
+		flag := &CustomFieldDefinition{Key: "gift", Type: CustomFieldTypeBoolean}
+		_, err = flag.Validate("true")
+		assert.ErrorContains(t, err, "must be true or false")
+	})
+
+	t.Run("ParseFilter converts query values to the field type", func(t *testing.T) {
+		filter, err := (&CustomFieldDefinition{Key: "gift", Type: CustomFieldTypeBoolean}).ParseFilter("true")
+		require.NoError(t, err)
+		assert.Equal(t, CustomFieldFilter{Key: "gift", Value: true}, filter)
+
+		filter, err = (&CustomFieldDefinition{Key: "rank", Type: CustomFieldTypeNumber}).ParseFilter("2.5")
+		require.NoError(t, err)
+		assert.Equal(t, 2.5, filter.Value)
+
+		_, err = (&CustomFieldDefinition{Key: "rank", Type: CustomFieldTypeNumber}).ParseFilter("high")
+		assert.ErrorContains(t, err, "must be a number")
+	})
+
+	t.Run("ApplyCustomFields merges, removes and requires values", func(t *testing.T) {
+		definitions := []*CustomFieldDefinition{
+			{Key: "erp_id", Type: CustomFieldTypeText, Required: true},
+			{Key: "gift", Type: CustomFieldTypeBoolean},
+		}
+		current := CustomFields{"erp_id": "A-1", "gift": true, "removed_field": "x"}
+
+		result, err := ApplyCustomFields(definitions, current, map[string]any{"gift": nil})
+		require.NoError(t, err)
+		assert.Equal(t, CustomFields{"erp_id": "A-1"}, result)
+
+		_, err = ApplyCustomFields(definitions, current, map[string]any{"color": "red"})
+		assert.ErrorContains(t, err, "unknown custom field color")
+		_, err = ApplyCustomFields(definitions, current, map[string]any{"erp_id": nil})
+		assert.ErrorContains(t, err, "custom field erp_id is required")
+		_, err = ApplyCustomFields(definitions, nil, map[string]any{"gift": false})
+		assert.ErrorContains(t, err, "custom field erp_id is required")
+	})
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"gorm.io/gorm"
+)
+
+// CustomerGroup groups customers that share pricing, e.g. wholesale accounts
+type CustomerGroup struct {
+	gorm.Model
+	Name        string     `gorm:"uniqueIndex;not null;size:100"`
+	Description string     `gorm:"type:text"`
+	PriceListID *uint      `gorm:"index"` // Price list applied to members, NULL for regular prices
+	PriceList   *PriceList `gorm:"foreignKey:PriceListID;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+}
+
+// NewCustomerGroup creates a new customer group
+func NewCustomerGroup(name, description string, priceListID *uint) (*CustomerGroup, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("customer group name cannot be empty")
+	}
+
+	return &CustomerGroup{
+		Name:        strings.TrimSpace(name),
+		Description: description,
+		PriceListID: priceListID,
+	}, nil
+}
+
+// Update updates the name and description of the group
+func (g *CustomerGroup) Update(name, description string) bool {
+	updated := false
+	if name = strings.TrimSpace(name); name != "" && g.Name != name {
+		g.Name = name
+		updated = true
+	}
+	if description != "" && g.Description != description {
+		g.Description = description
+		updated = true
+	}
+
+	return updated
+}
+
+// SetPriceList attaches a price list to the group, or detaches it when priceListID is nil
+func (g *CustomerGroup) SetPriceList(priceListID *uint) {
+	g.PriceListID = priceListID
+	g.PriceList = nil
+}
+
+func (g *CustomerGroup) ToCustomerGroupDTO() *dto.CustomerGroupDTO {
+	return &dto.CustomerGroupDTO{
+		ID:          g.ID,
+		Name:        g.Name,
+		Description: g.Description,
+		PriceListID: g.PriceListID,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+)
+
+// DigitalFile is a file delivered to the customers of a digital variant, kept in private storage
+type DigitalFile struct {
+	ID          uint   `gorm:"primaryKey"`
+	VariantID   uint   `gorm:"not null;index"`
+	FileName    string `gorm:"not null;size:255"` // Name the file is downloaded as
+	ContentType string `gorm:"not null;size:100"`
+	Size        int64  `gorm:"not null"`
+	StorageKey  string `gorm:"not null;size:500;uniqueIndex"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// OrderDownload grants the customer of a paid order a limited number of downloads of a digital
+// file until it expires
+type OrderDownload struct {
+	ID            uint         `gorm:"primaryKey"`
+	OrderID       uint         `gorm:"not null;uniqueIndex:idx_order_downloads_order_item_file"`
+	OrderItemID   uint         `gorm:"not null;uniqueIndex:idx_order_downloads_order_item_file"`
+	DigitalFileID uint         `gorm:"not null;index;uniqueIndex:idx_order_downloads_order_item_file"`
+	DigitalFile   *DigitalFile `gorm:"foreignKey:DigitalFileID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	DownloadCount int          `gorm:"not null;default:0"`
+	MaxDownloads  int          `gorm:"not null"` // 0 allows unlimited downloads
+	ExpiresAt     time.Time    `gorm:"not null"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	// URL is the signed download link, set when the download is shown to the customer and never persisted
+	URL string `gorm:"-"`
+}
+
+// NewDigitalFile creates a digital file of a variant. The storage key is set when the file is stored.
+func NewDigitalFile(variantID uint, fileName, contentType string, size int64) (*DigitalFile, error) {
+	if variantID == 0 {
+		return nil, errors.New("variant ID is required")
+	}
+	fileName = filepath.Base(strings.ReplaceAll(strings.TrimSpace(fileName), "\\", "/"))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return nil, errors.New("file name cannot be empty")
+	}
+	if len(fileName) > 255 {
+		return nil, errors.New("file name cannot exceed 255 characters")
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if size <= 0 {
+		return nil, errors.New("file cannot be empty")
+	}
+
+	return &DigitalFile{
+		VariantID:   variantID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+	}, nil
+}
+
+// NewOrderDownload grants the download of a file for an item of an order
+func NewOrderDownload(orderID, orderItemID uint, file *DigitalFile, maxDownloads int, expiresAt time.Time) (*OrderDownload, error) {
+	if orderID == 0 || orderItemID == 0 {
+		return nil, errors.New("order and order item are required")
+	}
+	if file == nil || file.ID == 0 {
+		return nil, errors.New("digital file is required")
+	}
+	if maxDownloads < 0 {
+		return nil, errors.New("maximum downloads cannot be negative")
+	}
+
+	return &OrderDownload{
+		OrderID:       orderID,
+		OrderItemID:   orderItemID,
+		DigitalFileID: file.ID,
+		DigitalFile:   file,
+		MaxDownloads:  maxDownloads,
+		ExpiresAt:     expiresAt,
+	}, nil
+}
+
+// CheckAvailable returns an error when the download has expired or its downloads are used up
+func (d *OrderDownload) CheckAvailable(now time.Time) error {
+	if !now.Before(d.ExpiresAt) {
+		return errors.New("download link has expired")
+	}
+	if d.MaxDownloads > 0 && d.DownloadCount >= d.MaxDownloads {
+		return fmt.Errorf("download limit of %d reached", d.MaxDownloads)
+	}
+	return nil
+}
+
+// RemainingDownloads returns the number of downloads left, or -1 when unlimited
+func (d *OrderDownload) RemainingDownloads() int {
+	if d.MaxDownloads == 0 {
+		return -1
+	}
+	return max(d.MaxDownloads-d.DownloadCount, 0)
+}
+
+// ToDigitalFileDTO converts the digital file to a DTO
+func (f *DigitalFile) ToDigitalFileDTO() dto.DigitalFileDTO {
+	return dto.DigitalFileDTO{
+		ID:          f.ID,
+		VariantID:   f.VariantID,
+		FileName:    f.FileName,
+		ContentType: f.ContentType,
+		Size:        f.Size,
+		CreatedAt:   f.CreatedAt,
+	}
+}
+
+// ToOrderDownloadDTO converts the download to a DTO
+func (d *OrderDownload) ToOrderDownloadDTO() dto.OrderDownloadDTO {
+	downloadDTO := dto.OrderDownloadDTO{
+		ID:                 d.ID,
+		OrderItemID:        d.OrderItemID,
+		URL:                d.URL,
+		DownloadCount:      d.DownloadCount,
+		MaxDownloads:       d.MaxDownloads,
+		RemainingDownloads: d.RemainingDownloads(),
+		ExpiresAt:          d.ExpiresAt,
+	}
+	if d.DigitalFile != nil {
+		downloadDTO.FileName = d.DigitalFile.FileName
+		downloadDTO.Size = d.DigitalFile.Size
+	}
+	return downloadDTO
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigitalFile(t *testing.T) {
+	t.Run("NewDigitalFile keeps only the base name of the file", func(t *testing.T) {
+		file, err := NewDigitalFile(1, "../books\\guide.pdf", "", 2048)
+		require.NoError(t, err)
+		assert.Equal(t, "guide.pdf", file.FileName)
+		assert.Equal(t, "application/octet-stream", file.ContentType)
+	})
+
+	t.Run("NewDigitalFile validates the file", func(t *testing.T) {
+		_, err := NewDigitalFile(0, "guide.pdf", "application/pdf", 2048)
+		assert.ErrorContains(t, err, "variant ID is required")
+		_, err = NewDigitalFile(1, " ", "application/pdf", 2048)
+		assert.ErrorContains(t, err, "file name cannot be empty")
+		_, err = NewDigitalFile(1, "guide.pdf", "application/pdf", 0)
+		assert.ErrorContains(t, err, "file cannot be empty")
+	})
+}
+
+func TestOrderDownload(t *testing.T) {
+	file := &DigitalFile{ID: 1, FileName: "guide.pdf"}
+	now := time.Now()
+
+	t.Run("NewOrderDownload validates the grant", func(t *testing.T) {
+		_, err := NewOrderDownload(0, 1, file, 3, now)
+		assert.ErrorContains(t, err, "order and order item are required")
+		_, err = NewOrderDownload(1, 1, &DigitalFile{}, 3, now)
+		assert.ErrorContains(t, err, "digital file is required")
+		_, err = NewOrderDownload(1, 1, file, -1, now)
+		assert.ErrorContains(t, err, "maximum downloads cannot be negative")
+	})
+
+	t.Run("CheckAvailable enforces expiry and limit", func(t *testing.T) {
+		download, err := NewOrderDownload(1, 1, file, 2, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.NoError(t, download.CheckAvailable(now))
+		assert.Equal(t, 2, download.RemainingDownloads())
+
+		download.DownloadCount = 2
+		assert.ErrorContains(t, download.CheckAvailable(now), "download limit of 2 reached")
+		assert.Equal(t, 0, download.RemainingDownloads())
+
+		download.DownloadCount = 0
+		assert.ErrorContains(t, download.CheckAvailable(now.Add(time.Hour)), "download link has expired")
+	})
+
+	t.Run("Zero maximum allows unlimited downloads", func(t *testing.T) {
+		download, err := NewOrderDownload(1, 1, file, 0, now.Add(time.Hour))
+		require.NoError(t, err)
+		download.DownloadCount = 100
+		assert.NoError(t, download.CheckAvailable(now))
+		assert.Equal(t, -1, download.RemainingDownloads())
+	})
+}
+
+func TestRequiresShipping(t *testing.T) {
+	t.Run("Checkout", func(t *testing.T) {
+		checkout := &Checkout{Items: []CheckoutItem{{ProductVariant: ProductVariant{IsDigital: true}}}}
+		assert.False(t, checkout.RequiresShipping())
+		checkout.Items = append(checkout.Items, CheckoutItem{ProductVariant: ProductVariant{}})
+		assert.True(t, checkout.RequiresShipping())
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		order := &Order{Items: []OrderItem{{IsDigital: true}}}
+		assert.False(t, order.RequiresShipping())
+		assert.True(t, order.HasDigitalItems())
+		order.Items = append(order.Items, OrderItem{})
+		assert.True(t, order.RequiresShipping())
+	})
+}
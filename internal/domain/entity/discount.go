@@ -7,6 +7,7 @@ import (
 
 	"github.com/zenfulcode/commercify/internal/domain/dto"
 	"github.com/zenfulcode/commercify/internal/domain/money"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -39,13 +40,24 @@ type Discount struct {
 	Value            float64        `gorm:"not null"`
 	MinOrderValue    int64          `gorm:"default:0"`
 	MaxDiscountValue int64          `gorm:"default:0"`
-	ProductIDs       []uint         `gorm:"type:jsonb"`
-	CategoryIDs      []uint         `gorm:"type:jsonb"`
+	ProductIDs       []uint         `gorm:"type:jsonb;serializer:json"`
+	CategoryIDs      []uint         `gorm:"type:jsonb;serializer:json"`
+	CollectionIDs    []uint         `gorm:"type:jsonb;serializer:json"`
 	StartDate        time.Time      `gorm:"index"`
 	EndDate          time.Time      `gorm:"index"`
 	UsageLimit       int            `gorm:"default:0"`
 	CurrentUsage     int            `gorm:"default:0"`
 	Active           bool           `gorm:"default:true"`
+	// CurrencyValues holds explicit fixed amounts in cents per currency for the fixed method
+	CurrencyValues datatypes.JSONType[CurrencyAmounts] `gorm:"not null;default:'{}'"`
+
+	// CollectionProductIDs are the products of an order in the collections of the discount,
+	// resolved by the use case before the discount is calculated, never persisted
+	CollectionProductIDs []uint `gorm:"-"`
+
+	// conversion converts the value and limits, which are in the default currency, into
+	// the currency of an order; set by the use case with SetCurrencyConversion
+	conversion currencyConversion
 }
 
 // NewDiscount creates a new discount
@@ -58,6 +70,7 @@ func NewDiscount(
 	maxDiscountValue int64,
 	productIDs []uint,
 	categoryIDs []uint,
+	collectionIDs []uint,
 	startDate time.Time,
 	endDate time.Time,
 	usageLimit int,
@@ -74,8 +87,8 @@ func NewDiscount(
 		return nil, errors.New("percentage discount cannot exceed 100%")
 	}
 
-	if discountType == DiscountTypeProduct && len(productIDs) == 0 && len(categoryIDs) == 0 {
-		return nil, errors.New("product discount must specify at least one product or category")
+	if discountType == DiscountTypeProduct && len(productIDs) == 0 && len(categoryIDs) == 0 && len(collectionIDs) == 0 {
+		return nil, errors.New("product discount must specify at least one product, category or collection")
 	}
 
 	if endDate.Before(startDate) {
@@ -91,14 +104,51 @@ func NewDiscount(
 		MaxDiscountValue: maxDiscountValue,
 		ProductIDs:       productIDs,
 		CategoryIDs:      categoryIDs,
+		CollectionIDs:    collectionIDs,
 		StartDate:        startDate,
 		EndDate:          endDate,
 		UsageLimit:       usageLimit,
 		CurrentUsage:     0,
 		Active:           true,
+		CurrencyValues:   datatypes.NewJSONType(CurrencyAmounts{}),
 	}, nil
 }
 
+// SetCurrencyValues replaces the explicit per-currency fixed amounts of the discount
+func (d *Discount) SetCurrencyValues(values map[string]int64) error {
+	amounts, err := NewCurrencyAmounts(values)
+	if err != nil {
+		return err
+	}
+
+	d.CurrencyValues = datatypes.NewJSONType(amounts)
+	return nil
+}
+
+// SetCurrencyConversion sets the default currency of the discount amounts and the currency of the order
+// they are applied to, so amounts without an explicit value for the order currency are converted
+func (d *Discount) SetCurrencyConversion(baseCurrency, orderCurrency *Currency) {
+	d.conversion = currencyConversion{base: baseCurrency, target: orderCurrency}
+}
+
+// SetBaseCurrency sets the default currency in which the discount amounts are configured,
+// without converting them into another currency
+func (d *Discount) SetBaseCurrency(baseCurrency *Currency) {
+	d.conversion = currencyConversion{base: baseCurrency}
+}
+
+// FixedAmount returns the fixed discount amount in minor units of the given currency.
+// The explicit amount is used when present, otherwise the base value is converted from the default currency.
+func (d *Discount) FixedAmount(currency string) int64 {
+	if amount, ok := d.CurrencyValues.Data().Get(currency); ok && currency != "" {
+		return amount
+	}
+	if d.conversion.convertsTo(currency) {
+		return d.conversion.amountIn(money.ToMinor(d.Value, d.conversion.base.Code), currency)
+	}
+	return money.ToMinor(d.Value, currency)
+}
+
 // IsValid checks if the discount is valid for the current time and usage
 func (d *Discount) IsValid() bool {
 	now := time.Now().Local()
@@ -115,7 +165,7 @@ func (d *Discount) IsApplicableToOrder(order *Order) bool {
 	}
 
 	// Check minimum order value
-	if d.MinOrderValue > 0 && order.TotalAmount < d.MinOrderValue {
+	if d.MinOrderValue > 0 && order.TotalAmount < d.conversion.amountIn(d.MinOrderValue, order.Currency) {
 		return false
 	}
 
@@ -124,8 +174,8 @@ func (d *Discount) IsApplicableToOrder(order *Order) bool {
 		return true
 	case DiscountTypeProduct:
 		for _, item := range order.Items {
-			// Check if the product is directly included
-			if slices.Contains(d.ProductIDs, item.ProductID) {
+			// Check if the product is directly included or in one of the collections
+			if d.targetsProduct(item.ProductID) {
 				return true
 			}
 			// Note: Category check is handled separately in the CalculateDiscount method
@@ -156,9 +206,8 @@ func (d *Discount) CalculateDiscount(order *Order) int64 {
 		// Calculate discount for the entire order
 		switch d.Method {
 		case DiscountMethodFixed:
-			// For fixed amount method, the value is in dollars and needs to be converted to cents
-			// But since we updated the structure, the database will provide the value already in cents
-			discountAmount = money.ToCents(d.Value)
+			// For fixed amount method, use the explicit amount for the order currency if set
+			discountAmount = d.FixedAmount(order.Currency)
 		case DiscountMethodPercentage:
 			// For percentage, apply the percentage to the total amount
 			discountAmount = money.ApplyPercentage(order.TotalAmount, d.Value)
@@ -166,7 +215,7 @@ func (d *Discount) CalculateDiscount(order *Order) int64 {
 	case DiscountTypeProduct:
 		// Calculate discount for eligible products only
 		for _, item := range order.Items {
-			isEligible := slices.Contains(d.ProductIDs, item.ProductID)
+			isEligible := d.targetsProduct(item.ProductID)
 
 			if isEligible {
 				itemTotal := item.Subtotal
@@ -174,7 +223,7 @@ func (d *Discount) CalculateDiscount(order *Order) int64 {
 				case DiscountMethodFixed:
 					// For fixed discount, apply once per item (not per quantity)
 					// This matches with the current implementation in ApplyDiscountToOrder
-					fixedDiscountInCents := money.ToCents(d.Value)
+					fixedDiscountInCents := d.FixedAmount(order.Currency)
 					itemDiscount := min(fixedDiscountInCents, itemTotal)
 					discountAmount += itemDiscount
 				case DiscountMethodPercentage:
@@ -186,8 +235,8 @@ func (d *Discount) CalculateDiscount(order *Order) int64 {
 	}
 
 	// Apply maximum discount cap if specified
-	if d.MaxDiscountValue > 0 && discountAmount > d.MaxDiscountValue {
-		discountAmount = d.MaxDiscountValue
+	if maxDiscount := d.conversion.amountIn(d.MaxDiscountValue, order.Currency); maxDiscount > 0 && discountAmount > maxDiscount {
+		discountAmount = maxDiscount
 	}
 
 	// Ensure discount doesn't exceed order total
@@ -198,6 +247,11 @@ func (d *Discount) CalculateDiscount(order *Order) int64 {
 	return discountAmount
 }
 
+// targetsProduct reports whether the product is one of the discount products or in one of its collections
+func (d *Discount) targetsProduct(productID uint) bool {
+	return slices.Contains(d.ProductIDs, productID) || slices.Contains(d.CollectionProductIDs, productID)
+}
+
 // IncrementUsage increments the usage count of the discount
 func (d *Discount) IncrementUsage() {
 	d.CurrentUsage++
@@ -210,15 +264,17 @@ func (d *Discount) ToDiscountDTO() *dto.DiscountDTO {
 		Type:             string(d.Type),
 		Method:           string(d.Method),
 		Value:            d.Value,
-		MinOrderValue:    money.FromCents(d.MinOrderValue),
-		MaxDiscountValue: money.FromCents(d.MaxDiscountValue),
+		MinOrderValue:    money.FromMinor(d.MinOrderValue, d.conversion.baseCode()),
+		MaxDiscountValue: money.FromMinor(d.MaxDiscountValue, d.conversion.baseCode()),
 		ProductIDs:       d.ProductIDs,
 		CategoryIDs:      d.CategoryIDs,
+		CollectionIDs:    d.CollectionIDs,
 		StartDate:        d.StartDate,
 		EndDate:          d.EndDate,
 		UsageLimit:       d.UsageLimit,
 		CurrentUsage:     d.CurrentUsage,
 		Active:           d.Active,
+		CurrencyValues:   money.MapFromMinor(d.CurrencyValues.Data()),
 		CreatedAt:        d.CreatedAt,
 		UpdatedAt:        d.UpdatedAt,
 	}
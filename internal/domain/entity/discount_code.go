@@ -0,0 +1,202 @@
+package entity
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxDiscountCodeBatchSize is the maximum number of codes a single batch may hold
+	MaxDiscountCodeBatchSize = 10000
+	// DefaultDiscountCodeLength is the default length of the random segment of a generated code
+	DefaultDiscountCodeLength = 8
+
+	// discountCodeAlphabet excludes characters that are easily confused (0/O, 1/I/L)
+	discountCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+)
+
+// DiscountCodeBatchSource describes how the codes in a batch were created
+type DiscountCodeBatchSource string
+
+const (
+	// DiscountCodeBatchSourceGenerated means the codes were generated from a pattern
+	DiscountCodeBatchSourceGenerated DiscountCodeBatchSource = "generated"
+	// DiscountCodeBatchSourceImported means the codes were imported from a file
+	DiscountCodeBatchSourceImported DiscountCodeBatchSource = "imported"
+)
+
+// DiscountCodeBatch groups a set of unique codes that share the rules of a single discount
+type DiscountCodeBatch struct {
+	gorm.Model
+	DiscountID        uint                    `gorm:"index;not null"`
+	Discount          *Discount               `gorm:"foreignKey:DiscountID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	Name              string                  `gorm:"not null;size:255"`
+	Prefix            string                  `gorm:"size:50"`
+	Source            DiscountCodeBatchSource `gorm:"not null;size:20"`
+	UsageLimitPerCode int                     `gorm:"default:1"`
+	Codes             []DiscountCode          `gorm:"foreignKey:BatchID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+}
+
+// DiscountCode is a single redeemable code belonging to a batch
+type DiscountCode struct {
+	gorm.Model
+	BatchID        uint      `gorm:"index;not null"`
+	DiscountID     uint      `gorm:"index;not null"`
+	Discount       *Discount `gorm:"foreignKey:DiscountID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	Code           string    `gorm:"uniqueIndex;not null;size:100"`
+	UsageLimit     int       `gorm:"default:1"`
+	CurrentUsage   int       `gorm:"default:0"`
+	LastRedeemedAt *time.Time
+}
+
+// DiscountCodeBatchStats contains redemption statistics for a batch
+type DiscountCodeBatchStats struct {
+	TotalCodes       int
+	RedeemedCodes    int
+	ExhaustedCodes   int
+	TotalRedemptions int
+}
+
+// NewDiscountCodeBatch creates a new, empty discount code batch
+func NewDiscountCodeBatch(discountID uint, name, prefix string, source DiscountCodeBatchSource, usageLimitPerCode int) (*DiscountCodeBatch, error) {
+	if discountID == 0 {
+		return nil, errors.New("discount ID cannot be empty")
+	}
+
+	if name == "" {
+		return nil, errors.New("batch name cannot be empty")
+	}
+
+	if source != DiscountCodeBatchSourceGenerated && source != DiscountCodeBatchSourceImported {
+		return nil, errors.New("invalid batch source")
+	}
+
+	if usageLimitPerCode < 0 {
+		return nil, errors.New("usage limit per code cannot be negative")
+	}
+
+	return &DiscountCodeBatch{
+		DiscountID:        discountID,
+		Name:              name,
+		Prefix:            strings.ToUpper(strings.TrimSpace(prefix)),
+		Source:            source,
+		UsageLimitPerCode: usageLimitPerCode,
+	}, nil
+}
+
+// AddCode adds a code to the batch, using the batch's per-code usage limit
+func (b *DiscountCodeBatch) AddCode(code string) error {
+	code = NormalizeDiscountCode(code)
+	if code == "" {
+		return errors.New("discount code cannot be empty")
+	}
+
+	if len(code) > 100 {
+		return errors.New("discount code cannot exceed 100 characters")
+	}
+
+	if len(b.Codes) >= MaxDiscountCodeBatchSize {
+		return errors.New("discount code batch is full")
+	}
+
+	b.Codes = append(b.Codes, DiscountCode{
+		DiscountID: b.DiscountID,
+		Code:       code,
+		UsageLimit: b.UsageLimitPerCode,
+	})
+
+	return nil
+}
+
+// IsRedeemable checks whether the code has remaining uses
+func (c *DiscountCode) IsRedeemable() bool {
+	return c.UsageLimit == 0 || c.CurrentUsage < c.UsageLimit
+}
+
+// Redeem records a single redemption of the code
+func (c *DiscountCode) Redeem() error {
+	if !c.IsRedeemable() {
+		return errors.New("discount code has already been redeemed")
+	}
+
+	now := time.Now()
+	c.CurrentUsage++
+	c.LastRedeemedAt = &now
+	return nil
+}
+
+// NormalizeDiscountCode trims and upper-cases a code so lookups are case-insensitive
+func NormalizeDiscountCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// GenerateDiscountCode generates a code consisting of the prefix followed by a random segment
+func GenerateDiscountCode(prefix string, length int) (string, error) {
+	if length <= 0 {
+		length = DefaultDiscountCodeLength
+	}
+
+	if length < 4 || length > 32 {
+		return "", errors.New("code length must be between 4 and 32 characters")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.ToUpper(strings.TrimSpace(prefix)))
+
+	alphabetSize := big.NewInt(int64(len(discountCodeAlphabet)))
+	for range length {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(discountCodeAlphabet[n.Int64()])
+	}
+
+	return sb.String(), nil
+}
+
+// ToDiscountCodeDTO converts a discount code to a DTO
+func (c *DiscountCode) ToDiscountCodeDTO() dto.DiscountCodeDTO {
+	return dto.DiscountCodeDTO{
+		ID:             c.ID,
+		BatchID:        c.BatchID,
+		DiscountID:     c.DiscountID,
+		Code:           c.Code,
+		UsageLimit:     c.UsageLimit,
+		CurrentUsage:   c.CurrentUsage,
+		LastRedeemedAt: c.LastRedeemedAt,
+		CreatedAt:      c.CreatedAt,
+	}
+}
+
+// ToDiscountCodeBatchDTO converts a batch and its statistics to a DTO
+func (b *DiscountCodeBatch) ToDiscountCodeBatchDTO(stats *DiscountCodeBatchStats) dto.DiscountCodeBatchDTO {
+	batchDTO := dto.DiscountCodeBatchDTO{
+		ID:                b.ID,
+		DiscountID:        b.DiscountID,
+		Name:              b.Name,
+		Prefix:            b.Prefix,
+		Source:            string(b.Source),
+		UsageLimitPerCode: b.UsageLimitPerCode,
+		CreatedAt:         b.CreatedAt,
+		UpdatedAt:         b.UpdatedAt,
+	}
+
+	if stats != nil {
+		batchDTO.TotalCodes = stats.TotalCodes
+		batchDTO.RedeemedCodes = stats.RedeemedCodes
+		batchDTO.ExhaustedCodes = stats.ExhaustedCodes
+		batchDTO.TotalRedemptions = stats.TotalRedemptions
+		if stats.TotalCodes > 0 {
+			batchDTO.RedemptionRate = float64(stats.RedeemedCodes) / float64(stats.TotalCodes) * 100
+		}
+	}
+
+	return batchDTO
+}
@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscountCodeBatch(t *testing.T) {
+	t.Run("NewDiscountCodeBatch success", func(t *testing.T) {
+		batch, err := NewDiscountCodeBatch(1, "Spring campaign", " spring-", DiscountCodeBatchSourceGenerated, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), batch.DiscountID)
+		assert.Equal(t, "Spring campaign", batch.Name)
+		assert.Equal(t, "SPRING-", batch.Prefix)
+		assert.Equal(t, DiscountCodeBatchSourceGenerated, batch.Source)
+		assert.Equal(t, 1, batch.UsageLimitPerCode)
+	})
+
+	t.Run("NewDiscountCodeBatch validation errors", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			discountID  uint
+			batchName   string
+			source      DiscountCodeBatchSource
+			usageLimit  int
+			expectedErr string
+		}{
+			{"missing discount", 0, "Batch", DiscountCodeBatchSourceGenerated, 1, "discount ID cannot be empty"},
+			{"missing name", 1, "", DiscountCodeBatchSourceGenerated, 1, "batch name cannot be empty"},
+			{"invalid source", 1, "Batch", "unknown", 1, "invalid batch source"},
+			{"negative usage limit", 1, "Batch", DiscountCodeBatchSourceImported, -1, "usage limit per code cannot be negative"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				batch, err := NewDiscountCodeBatch(tt.discountID, tt.batchName, "", tt.source, tt.usageLimit)
+				assert.Error(t, err)
+				assert.Nil(t, batch)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			})
+		}
+	})
+
+	t.Run("AddCode normalizes codes and applies usage limit", func(t *testing.T) {
+		batch, err := NewDiscountCodeBatch(7, "Import", "", DiscountCodeBatchSourceImported, 3)
+		require.NoError(t, err)
+
+		require.NoError(t, batch.AddCode("  welcome-abc "))
+		assert.Error(t, batch.AddCode("   "))
+
+		require.Len(t, batch.Codes, 1)
+		assert.Equal(t, "WELCOME-ABC", batch.Codes[0].Code)
+		assert.Equal(t, uint(7), batch.Codes[0].DiscountID)
+		assert.Equal(t, 3, batch.Codes[0].UsageLimit)
+	})
+
+	t.Run("ToDiscountCodeBatchDTO includes stats", func(t *testing.T) {
+		batch, err := NewDiscountCodeBatch(1, "Batch", "X", DiscountCodeBatchSourceGenerated, 1)
+		require.NoError(t, err)
+
+		batchDTO := batch.ToDiscountCodeBatchDTO(&DiscountCodeBatchStats{
+			TotalCodes:       4,
+			RedeemedCodes:    1,
+			ExhaustedCodes:   1,
+			TotalRedemptions: 1,
+		})
+
+		assert.Equal(t, 4, batchDTO.TotalCodes)
+		assert.Equal(t, 1, batchDTO.RedeemedCodes)
+		assert.Equal(t, 25.0, batchDTO.RedemptionRate)
+	})
+}
+
+func TestDiscountCode(t *testing.T) {
+	t.Run("Redeem single-use code", func(t *testing.T) {
+		code := &DiscountCode{Code: "ONCE", UsageLimit: 1}
+
+		assert.True(t, code.IsRedeemable())
+		require.NoError(t, code.Redeem())
+		assert.Equal(t, 1, code.CurrentUsage)
+		assert.NotNil(t, code.LastRedeemedAt)
+
+		assert.False(t, code.IsRedeemable())
+		assert.Error(t, code.Redeem())
+	})
+
+	t.Run("Unlimited code stays redeemable", func(t *testing.T) {
+		code := &DiscountCode{Code: "ALWAYS", UsageLimit: 0, CurrentUsage: 50}
+		assert.True(t, code.IsRedeemable())
+	})
+}
+
+func TestGenerateDiscountCode(t *testing.T) {
+	t.Run("uses prefix and requested length", func(t *testing.T) {
+		code, err := GenerateDiscountCode("promo-", 10)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(code, "PROMO-"))
+		assert.Len(t, code, len("PROMO-")+10)
+		for _, r := range strings.TrimPrefix(code, "PROMO-") {
+			assert.True(t, strings.ContainsRune(discountCodeAlphabet, r))
+		}
+	})
+
+	t.Run("defaults length", func(t *testing.T) {
+		code, err := GenerateDiscountCode("", 0)
+
+		require.NoError(t, err)
+		assert.Len(t, code, DefaultDiscountCodeLength)
+	})
+
+	t.Run("rejects invalid length", func(t *testing.T) {
+		_, err := GenerateDiscountCode("", 2)
+		assert.Error(t, err)
+
+		_, err = GenerateDiscountCode("", 64)
+		assert.Error(t, err)
+	})
+}
@@ -22,6 +22,7 @@ func TestDiscount(t *testing.T) {
 			1000, // $10 max discount
 			nil,
 			nil,
+			nil,
 			startDate,
 			endDate,
 			100,
@@ -54,6 +55,7 @@ func TestDiscount(t *testing.T) {
 			0,
 			productIDs,
 			categoryIDs,
+			nil,
 			startDate,
 			endDate,
 			50,
@@ -129,7 +131,7 @@ func TestDiscount(t *testing.T) {
 				categoryIDs:   nil,
 				startDate:     startDate,
 				endDate:       endDate,
-				expectedError: "product discount must specify at least one product or category",
+				expectedError: "product discount must specify at least one product, category or collection",
 			},
 			{
 				name:          "end date before start date",
@@ -154,6 +156,7 @@ func TestDiscount(t *testing.T) {
 					0,
 					tt.productIDs,
 					tt.categoryIDs,
+					nil,
 					tt.startDate,
 					tt.endDate,
 					0,
@@ -177,6 +180,7 @@ func TestDiscount(t *testing.T) {
 			0,
 			nil,
 			nil,
+			nil,
 			startDate,
 			endDate,
 			100,
@@ -222,6 +226,7 @@ func TestDiscount(t *testing.T) {
 			0,
 			nil,
 			nil,
+			nil,
 			startDate,
 			endDate,
 			100,
@@ -262,6 +267,7 @@ func TestDiscount(t *testing.T) {
 				1000, // $10 max discount
 				nil,
 				nil,
+				nil,
 				startDate,
 				endDate,
 				100,
@@ -294,6 +300,7 @@ func TestDiscount(t *testing.T) {
 				0,
 				nil,
 				nil,
+				nil,
 				startDate,
 				endDate,
 				100,
@@ -321,6 +328,7 @@ func TestDiscount(t *testing.T) {
 				0,
 				[]uint{1, 2}, // Products 1 and 2
 				nil,
+				nil,
 				startDate,
 				endDate,
 				100,
@@ -353,6 +361,7 @@ func TestDiscount(t *testing.T) {
 			0,
 			nil,
 			nil,
+			nil,
 			startDate,
 			endDate,
 			100,
@@ -381,6 +390,7 @@ func TestDiscount(t *testing.T) {
 			10000, // 100.00 dollars in cents
 			[]uint{1, 2},
 			[]uint{3, 4},
+			nil,
 			startDate,
 			endDate,
 			500,
@@ -408,4 +418,72 @@ func TestDiscount(t *testing.T) {
 		assert.True(t, dto.Active)
 	})
 
+	t.Run("ToDiscountDTO uses the minor units of the default currency", func(t *testing.T) {
+		discount, err := NewDiscount("YEN", DiscountTypeBasket, DiscountMethodPercentage, 10, 5000, 1000, nil, nil, nil, time.Now(), time.Now().Add(time.Hour), 0)
+		require.NoError(t, err)
+		discount.SetBaseCurrency(&Currency{Code: "JPY", ExchangeRate: 1})
+
+		dto := discount.ToDiscountDTO()
+		assert.Equal(t, 5000.0, dto.MinOrderValue)
+		assert.Equal(t, 1000.0, dto.MaxDiscountValue)
+	})
+
+}
+
+func TestDiscountCurrencyValues(t *testing.T) {
+	newFixedDiscount := func(t *testing.T) *Discount {
+		discount, err := NewDiscount(
+			"FIXED10",
+			DiscountTypeBasket,
+			DiscountMethodFixed,
+			10.0,
+			0,
+			0,
+			nil,
+			nil,
+			nil,
+			time.Now().Add(-time.Hour),
+			time.Now().Add(time.Hour),
+			0,
+		)
+		require.NoError(t, err)
+		return discount
+	}
+
+	t.Run("fixed discount uses explicit amount for order currency", func(t *testing.T) {
+		discount := newFixedDiscount(t)
+		require.NoError(t, discount.SetCurrencyValues(map[string]int64{"dkk": 7500}))
+
+		order := &Order{TotalAmount: 50000, Currency: "DKK"}
+		assert.Equal(t, int64(7500), discount.CalculateDiscount(order))
+	})
+
+	t.Run("fixed discount falls back to base value", func(t *testing.T) {
+		discount := newFixedDiscount(t)
+		require.NoError(t, discount.SetCurrencyValues(map[string]int64{"DKK": 7500}))
+
+		order := &Order{TotalAmount: 50000, Currency: "EUR"}
+		assert.Equal(t, int64(1000), discount.CalculateDiscount(order))
+	})
+
+	t.Run("fixed discount converts base value from the default currency", func(t *testing.T) {
+		discount := newFixedDiscount(t)
+		discount.MinOrderValue = 10000
+		discount.SetCurrencyConversion(
+			&Currency{Code: "USD", ExchangeRate: 1},
+			&Currency{Code: "DKK", ExchangeRate: 7},
+		)
+
+		order := &Order{TotalAmount: 80000, Currency: "DKK"}
+		assert.Equal(t, int64(7000), discount.CalculateDiscount(order))
+
+		// The minimum order value of 100 USD is 700 DKK
+		order = &Order{TotalAmount: 60000, Currency: "DKK"}
+		assert.Equal(t, int64(0), discount.CalculateDiscount(order))
+	})
+
+	t.Run("SetCurrencyValues rejects negative amounts", func(t *testing.T) {
+		discount := newFixedDiscount(t)
+		assert.Error(t, discount.SetCurrencyValues(map[string]int64{"DKK": -1}))
+	})
 }
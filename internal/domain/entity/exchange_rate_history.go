@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"gorm.io/gorm"
+)
+
+// ExchangeRateSourceManual is the source recorded for rates set through the admin API
+const ExchangeRateSourceManual = "manual"
+
+// ExchangeRateHistory records a change of a currency's exchange rate
+type ExchangeRateHistory struct {
+	gorm.Model
+	CurrencyCode string  `gorm:"index;not null;size:3"`
+	BaseCurrency string  `gorm:"not null;size:3"` // Default currency the rate is relative to
+	PreviousRate float64 `gorm:"not null"`
+	Rate         float64 `gorm:"not null"`
+	Source       string  `gorm:"not null;size:100"` // "manual" or the name of the rate provider
+}
+
+// NewExchangeRateHistory creates a new exchange rate history entry
+func NewExchangeRateHistory(currencyCode, baseCurrency string, previousRate, rate float64, source string) (*ExchangeRateHistory, error) {
+	if strings.TrimSpace(currencyCode) == "" {
+		return nil, errors.New("currency code is required")
+	}
+	if rate <= 0 {
+		return nil, errors.New("exchange rate must be positive")
+	}
+	if strings.TrimSpace(source) == "" {
+		return nil, errors.New("exchange rate source is required")
+	}
+
+	return &ExchangeRateHistory{
+		CurrencyCode: strings.ToUpper(currencyCode),
+		BaseCurrency: strings.ToUpper(baseCurrency),
+		PreviousRate: previousRate,
+		Rate:         rate,
+		Source:       source,
+	}, nil
+}
+
+func (h *ExchangeRateHistory) ToExchangeRateHistoryDTO() *dto.ExchangeRateHistoryDTO {
+	return &dto.ExchangeRateHistoryDTO{
+		ID:           h.ID,
+		CurrencyCode: h.CurrencyCode,
+		BaseCurrency: h.BaseCurrency,
+		PreviousRate: h.PreviousRate,
+		Rate:         h.Rate,
+		Source:       h.Source,
+		CreatedAt:    h.CreatedAt,
+	}
+}
@@ -41,6 +41,7 @@ type Order struct {
 	gorm.Model
 	OrderNumber       string                              `gorm:"uniqueIndex;not null;size:100"`
 	Currency          string                              `gorm:"not null;size:3"` // e.g., "USD", "EUR"
+	Locale            string                              `gorm:"size:10"`         // Language of the customer, used for emails
 	UserID            *uint                               `gorm:"index"`           // NULL for guest orders
 	User              *User                               `gorm:"foreignKey:UserID;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
 	Items             []OrderItem                         `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
@@ -71,8 +72,18 @@ type Order struct {
 	DiscountAmount int64
 	FinalAmount    int64 `gorm:"not null"` // stored in cents
 
+	// Exchange rate snapshot used to restate the order in the store's default currency
+	BaseCurrency string  `gorm:"size:3"`
+	ExchangeRate float64 `gorm:"not null;default:1"` // Units of Currency per unit of BaseCurrency
+
+	// Values of the admin-defined custom fields by key
+	CustomFields CustomFields `gorm:"type:jsonb;serializer:json"`
+
 	// Payment transactions
 	PaymentTransactions []PaymentTransaction `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+
+	// Downloads of the digital items, loaded with signed links once the order is paid and never persisted with the order
+	Downloads []*OrderDownload `gorm:"-"`
 }
 
 // OrderItem represents an item in an order
@@ -88,11 +99,71 @@ type OrderItem struct {
 	Price            int64          `gorm:"not null"` // Price at time of order
 	Subtotal         int64          `gorm:"not null"`
 	Weight           float64        `gorm:"default:0"`
+	IsDigital        bool           `gorm:"not null;default:false"` // Delivered as downloads, without shipping
 
 	// Snapshot data at time of order
 	ProductName string `gorm:"not null;size:255"`
 	SKU         string `gorm:"not null;size:100"`
 	ImageURL    string `gorm:"size:500"`
+
+	// Components of a bundle at time of order, picked for fulfillment and restocked on returns
+	Components []OrderItemComponent `gorm:"foreignKey:OrderItemID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+}
+
+// OrderItemComponent records a component of a bundle item at time of order
+type OrderItemComponent struct {
+	ID               uint   `gorm:"primaryKey"`
+	OrderItemID      uint   `gorm:"index;not null"`
+	ProductVariantID uint   `gorm:"index;not null"`
+	SKU              string `gorm:"not null;size:100"`
+	ProductName      string `gorm:"size:255"`
+	VariantName      string `gorm:"size:255"`
+	Quantity         int    `gorm:"not null"` // Units per bundle
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// StockUnit is a quantity of a variant taken from stock for an order item
+type StockUnit struct {
+	ProductVariantID uint
+	SKU              string
+	Quantity         int
+}
+
+// SetBundleComponents records the components of the bundle variant ordered by the item.
+// Items of regular variants get no components.
+func (item *OrderItem) SetBundleComponents(variant *ProductVariant) {
+	item.Components = nil
+	for _, component := range variant.Components {
+		recorded := OrderItemComponent{
+			ProductVariantID: component.ComponentVariantID,
+			Quantity:         component.Quantity,
+		}
+		if componentVariant := component.ComponentVariant; componentVariant != nil {
+			recorded.SKU = componentVariant.SKU
+			recorded.ProductName = componentVariant.Product.Name
+			recorded.VariantName = componentVariant.Name()
+		}
+		item.Components = append(item.Components, recorded)
+	}
+}
+
+// StockUnits returns the variants and quantities the item takes from stock.
+// A bundle takes its recorded components instead of the bundle variant.
+func (item *OrderItem) StockUnits() []StockUnit {
+	if len(item.Components) == 0 {
+		return []StockUnit{{ProductVariantID: item.ProductVariantID, SKU: item.SKU, Quantity: item.Quantity}}
+	}
+
+	units := make([]StockUnit, len(item.Components))
+	for i, component := range item.Components {
+		units[i] = StockUnit{
+			ProductVariantID: component.ProductVariantID,
+			SKU:              component.SKU,
+			Quantity:         component.Quantity * item.Quantity,
+		}
+	}
+	return units
 }
 
 // Address represents a shipping or billing address
@@ -128,6 +199,7 @@ func NewOrderFromCheckout(checkout *Checkout) (*Order, error) {
 			ProductName:      item.ProductName,
 			ImageURL:         item.ImageURL,
 			Weight:           item.Weight,
+			IsDigital:        item.ProductVariant.IsDigital,
 		}
 	}
 
@@ -139,6 +211,7 @@ func NewOrderFromCheckout(checkout *Checkout) (*Order, error) {
 	order.SetShippingMethod(checkout.GetShippingOption())
 	order.SetAppliedDiscount(checkout.GetAppliedDiscount())
 	order.CheckoutSessionID = checkout.SessionID
+	order.Locale = checkout.Locale
 
 	return order, nil
 }
@@ -179,6 +252,7 @@ func NewOrder(userID *uint, items []OrderItem, currency string, shippingAddr, bi
 		PaymentStatus:   PaymentStatusPending, // Initialize payment status
 		CustomerDetails: &customerDetails,
 		IsGuestOrder:    false,
+		ExchangeRate:    1,
 	}
 
 	// Set addresses using JSON helper methods
@@ -219,6 +293,7 @@ func NewGuestOrder(items []OrderItem, shippingAddr, billingAddr *Address, custom
 		FinalAmount:    totalAmount, // Initially same as total amount
 		Status:         OrderStatusPending,
 		PaymentStatus:  PaymentStatusPending, // Initialize payment status
+		ExchangeRate:   1,
 
 		// Guest-specific information
 		CustomerDetails: &customerDetails,
@@ -374,6 +449,37 @@ func (o *Order) SetAppliedDiscount(discount *AppliedDiscount) {
 	o.FinalAmount = o.TotalAmount + o.ShippingCost - discount.DiscountAmount
 }
 
+// SetExchangeRate snapshots the exchange rate between the order currency and the base currency
+func (o *Order) SetExchangeRate(baseCurrency string, rate float64) error {
+	if baseCurrency == "" {
+		return errors.New("base currency cannot be empty")
+	}
+	if rate <= 0 {
+		return errors.New("exchange rate must be positive")
+	}
+
+	o.BaseCurrency = baseCurrency
+	o.ExchangeRate = rate
+	return nil
+}
+
+// AmountInBaseCurrency restates an amount in the order currency in the base currency
+// using the exchange rate snapshotted when the order was placed
+func (o *Order) AmountInBaseCurrency(amount int64) int64 {
+	return RestateAmount(amount, o.Currency, o.BaseCurrency, o.ExchangeRate)
+}
+
+// RestateAmount converts an amount in minor units of currency to minor units of baseCurrency,
+// where rate is the number of currency units per unit of baseCurrency. Amounts without a
+// base currency predate rate snapshots and are returned unchanged.
+func RestateAmount(amount int64, currency, baseCurrency string, rate float64) int64 {
+	if baseCurrency == "" || currency == baseCurrency || rate <= 0 {
+		return amount
+	}
+
+	return money.ToMinor(money.FromMinor(amount, currency)/rate, baseCurrency)
+}
+
 // SetActionURL sets the action URL for the order
 func (o *Order) SetActionURL(actionURL string) error {
 	if actionURL == "" {
@@ -446,6 +552,11 @@ func (o *Order) IsCaptured() bool {
 	return o.PaymentStatus == PaymentStatusCaptured
 }
 
+// IsPaid returns true if the payment is authorized or captured
+func (o *Order) IsPaid() bool {
+	return o.PaymentStatus == PaymentStatusAuthorized || o.PaymentStatus == PaymentStatusCaptured
+}
+
 // IsRefunded returns true if the payment is refunded
 func (o *Order) IsRefunded() bool {
 	return o.PaymentStatus == PaymentStatusRefunded
@@ -529,12 +640,13 @@ func (o *Order) ToOrderSummaryDTO() *dto.OrderSummaryDTO {
 		Customer:         customer,
 		Status:           dto.OrderStatus(o.Status),
 		PaymentStatus:    dto.PaymentStatus(o.PaymentStatus),
-		TotalAmount:      money.FromCents(o.TotalAmount),
-		FinalAmount:      money.FromCents(o.FinalAmount),
-		ShippingCost:     money.FromCents(o.ShippingCost),
-		DiscountAmount:   money.FromCents(o.DiscountAmount),
+		TotalAmount:      money.FromMinor(o.TotalAmount, o.Currency),
+		FinalAmount:      money.FromMinor(o.FinalAmount, o.Currency),
+		ShippingCost:     money.FromMinor(o.ShippingCost, o.Currency),
+		DiscountAmount:   money.FromMinor(o.DiscountAmount, o.Currency),
 		OrderLinesAmount: len(o.Items),
 		Currency:         o.Currency,
+		CustomFields:     o.CustomFields,
 		CreatedAt:        o.CreatedAt,
 		UpdatedAt:        o.UpdatedAt,
 	}
@@ -550,12 +662,12 @@ type OrderDetailOptions struct {
 func (o *Order) ToOrderDetailsDTOWithOptions(options OrderDetailOptions) *dto.OrderDTO {
 	var discountDetails *dto.AppliedDiscountDTO
 	if appliedDiscount := o.GetAppliedDiscount(); appliedDiscount != nil {
-		discountDetails = appliedDiscount.ToAppliedDiscountDTO()
+		discountDetails = appliedDiscount.ToAppliedDiscountDTO(o.Currency)
 	}
 
 	var shippingDetails *dto.ShippingOptionDTO
 	if shippingOption := o.GetShippingOption(); shippingOption != nil {
-		shippingDetails = shippingOption.ToShippingOptionDTO()
+		shippingDetails = shippingOption.ToShippingOptionDTOInCurrency(o.Currency)
 	}
 
 	shippingAddr := o.GetShippingAddress()
@@ -604,14 +716,18 @@ func (o *Order) ToOrderDetailsDTOWithOptions(options OrderDetailOptions) *dto.Or
 		Status:          dto.OrderStatus(o.Status),
 		PaymentStatus:   dto.PaymentStatus(o.PaymentStatus),
 		Currency:        o.Currency,
-		TotalAmount:     money.FromCents(o.TotalAmount),
-		ShippingCost:    money.FromCents(o.ShippingCost),
-		DiscountAmount:  money.FromCents(o.DiscountAmount),
-		FinalAmount:     money.FromCents(o.FinalAmount),
+		Locale:          o.Locale,
+		TotalAmount:     money.FromMinor(o.TotalAmount, o.Currency),
+		ShippingCost:    money.FromMinor(o.ShippingCost, o.Currency),
+		DiscountAmount:  money.FromMinor(o.DiscountAmount, o.Currency),
+		FinalAmount:     money.FromMinor(o.FinalAmount, o.Currency),
+		BaseCurrency:    o.BaseCurrency,
+		ExchangeRate:    o.ExchangeRate,
 		ShippingAddress: shippingAddressValue,
 		BillingAddress:  billingAddressValue,
 		ActionRequired:  o.ActionRequired(),
 		ActionURL:       o.ActionURL.String,
+		CustomFields:    o.CustomFields,
 		CreatedAt:       o.CreatedAt,
 		UpdatedAt:       o.UpdatedAt,
 	}
@@ -621,6 +737,13 @@ func (o *Order) ToOrderDetailsDTOWithOptions(options OrderDetailOptions) *dto.Or
 		orderDTO.Items = o.ToOrderItemsDTO()
 	}
 
+	if len(o.Downloads) > 0 {
+		orderDTO.Downloads = make([]dto.OrderDownloadDTO, len(o.Downloads))
+		for i, download := range o.Downloads {
+			orderDTO.Downloads[i] = download.ToOrderDownloadDTO()
+		}
+	}
+
 	// Conditionally include payment transactions
 	if options.IncludePaymentTransactions {
 		paymentTransactions := make([]dto.PaymentTransactionDTO, len(o.PaymentTransactions))
@@ -646,13 +769,35 @@ func (o *Order) ToOrderItemsDTO() []dto.OrderItemDTO {
 			VariantName: item.ProductVariant.Name(),
 			ImageURL:    item.ImageURL,
 			Quantity:    item.Quantity,
-			UnitPrice:   money.FromCents(item.Price),
-			TotalPrice:  money.FromCents(item.Subtotal),
+			UnitPrice:   money.FromMinor(item.Price, o.Currency),
+			TotalPrice:  money.FromMinor(item.Subtotal, o.Currency),
+			IsDigital:   item.IsDigital,
+			Components:  item.toOrderItemComponentDTOs(),
 		}
 	}
 	return itemsDTO
 }
 
+// toOrderItemComponentDTOs converts the bundle components of the item to DTOs
+func (item *OrderItem) toOrderItemComponentDTOs() []dto.OrderItemComponentDTO {
+	if len(item.Components) == 0 {
+		return nil
+	}
+
+	componentDTOs := make([]dto.OrderItemComponentDTO, len(item.Components))
+	for i, component := range item.Components {
+		componentDTOs[i] = dto.OrderItemComponentDTO{
+			VariantID:     component.ProductVariantID,
+			SKU:           component.SKU,
+			ProductName:   component.ProductName,
+			VariantName:   component.VariantName,
+			Quantity:      component.Quantity,
+			TotalQuantity: component.Quantity * item.Quantity,
+		}
+	}
+	return componentDTOs
+}
+
 func (a *Address) ToAddressDTO() *dto.AddressDTO {
 	return &dto.AddressDTO{
 		AddressLine1: a.Street1,
@@ -723,6 +868,26 @@ func (o *Order) GetAppliedDiscount() *AppliedDiscount {
 	return &data
 }
 
+// RequiresShipping returns true if any item of the order is a physical variant
+func (o *Order) RequiresShipping() bool {
+	for _, item := range o.Items {
+		if !item.IsDigital {
+			return true
+		}
+	}
+	return false
+}
+
+// HasDigitalItems returns true if any item of the order is delivered as downloads
+func (o *Order) HasDigitalItems() bool {
+	for _, item := range o.Items {
+		if item.IsDigital {
+			return true
+		}
+	}
+	return false
+}
+
 // GetShippingOption returns the shipping option from JSON
 func (o *Order) GetShippingOption() *ShippingOption {
 	// Handle cases where the JSON data might be empty/null
@@ -276,6 +276,44 @@ func TestOrderDTOConversions(t *testing.T) {
 	})
 }
 
+func TestOrderExchangeRateSnapshot(t *testing.T) {
+	items := []OrderItem{{ProductID: 1, Quantity: 1, Price: 10000}}
+
+	t.Run("New orders default to a rate of 1", func(t *testing.T) {
+		order, err := NewOrder(nil, items, "USD", nil, nil, CustomerDetails{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1.0, order.ExchangeRate)
+		assert.Empty(t, order.BaseCurrency)
+		assert.Equal(t, int64(10000), order.AmountInBaseCurrency(order.FinalAmount))
+	})
+
+	t.Run("SetExchangeRate", func(t *testing.T) {
+		order, err := NewOrder(nil, items, "DKK", nil, nil, CustomerDetails{})
+		require.NoError(t, err)
+
+		assert.Error(t, order.SetExchangeRate("", 6.25))
+		assert.Error(t, order.SetExchangeRate("USD", 0))
+
+		require.NoError(t, order.SetExchangeRate("USD", 6.25))
+		assert.Equal(t, "USD", order.BaseCurrency)
+		assert.Equal(t, 6.25, order.ExchangeRate)
+
+		// 100.00 DKK at 6.25 DKK per USD
+		assert.Equal(t, int64(1600), order.AmountInBaseCurrency(order.FinalAmount))
+
+		orderDTO := order.ToOrderDetailsDTOWithOptions(OrderDetailOptions{})
+		assert.Equal(t, "USD", orderDTO.BaseCurrency)
+		assert.Equal(t, 6.25, orderDTO.ExchangeRate)
+	})
+
+	t.Run("RestateAmount respects minor units", func(t *testing.T) {
+		// 1000 JPY at 160 JPY per EUR is 6.25 EUR
+		assert.Equal(t, int64(625), RestateAmount(1000, "JPY", "EUR", 160))
+		assert.Equal(t, int64(1000), RestateAmount(1000, "EUR", "EUR", 1))
+	})
+}
+
 func TestOrderStatusConstants(t *testing.T) {
 	assert.Equal(t, OrderStatus("pending"), OrderStatusPending)
 	assert.Equal(t, OrderStatus("paid"), OrderStatusPaid)
@@ -227,7 +227,7 @@ func (pt *PaymentTransaction) ToPaymentTransactionDTO() dto.PaymentTransactionDT
 		ExternalID:    pt.ExternalID,
 		Type:          dto.TransactionType(pt.Type),
 		Status:        dto.TransactionStatus(pt.Status),
-		Amount:        money.FromCents(pt.Amount),
+		Amount:        money.FromMinor(pt.Amount, pt.Currency),
 		Currency:      pt.Currency,
 		Provider:      pt.Provider,
 		CreatedAt:     pt.CreatedAt,
@@ -0,0 +1,190 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+	"gorm.io/gorm"
+)
+
+// PriceList holds customer-specific variant prices that replace the regular variant price
+type PriceList struct {
+	gorm.Model
+	Name        string           `gorm:"uniqueIndex;not null;size:100"`
+	Description string           `gorm:"type:text"`
+	Active      bool             `gorm:"default:true"`
+	Entries     []PriceListEntry `gorm:"foreignKey:PriceListID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+}
+
+// PriceListEntry is the price of a variant in a currency from a minimum quantity onwards.
+// Several entries for the same variant and currency define quantity breaks.
+type PriceListEntry struct {
+	gorm.Model
+	PriceListID      uint            `gorm:"uniqueIndex:idx_price_list_entry;not null"`
+	ProductVariantID uint            `gorm:"uniqueIndex:idx_price_list_entry;not null"`
+	ProductVariant   *ProductVariant `gorm:"foreignKey:ProductVariantID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	Currency         string          `gorm:"uniqueIndex:idx_price_list_entry;not null;size:3"`
+	MinQuantity      int             `gorm:"uniqueIndex:idx_price_list_entry;not null;default:1"`
+	Price            int64           `gorm:"not null"` // stored in minor units
+}
+
+// NewPriceList creates a new price list
+func NewPriceList(name, description string, active bool) (*PriceList, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("price list name cannot be empty")
+	}
+
+	return &PriceList{
+		Name:        strings.TrimSpace(name),
+		Description: description,
+		Active:      active,
+	}, nil
+}
+
+// NewPriceListEntry creates a new price list entry. A minimum quantity of zero means 1.
+func NewPriceListEntry(variantID uint, currency string, minQuantity int, price int64) (*PriceListEntry, error) {
+	if variantID == 0 {
+		return nil, errors.New("variant ID cannot be zero")
+	}
+	if strings.TrimSpace(currency) == "" {
+		return nil, errors.New("currency is required")
+	}
+	if minQuantity < 0 {
+		return nil, errors.New("minimum quantity cannot be negative")
+	}
+	if minQuantity == 0 {
+		minQuantity = 1
+	}
+	if price < 0 {
+		return nil, errors.New("price cannot be negative")
+	}
+
+	return &PriceListEntry{
+		ProductVariantID: variantID,
+		Currency:         strings.ToUpper(strings.TrimSpace(currency)),
+		MinQuantity:      minQuantity,
+		Price:            price,
+	}, nil
+}
+
+// Update updates the name, description and active state of the price list
+func (p *PriceList) Update(name, description string, active *bool) bool {
+	updated := false
+	if name = strings.TrimSpace(name); name != "" && p.Name != name {
+		p.Name = name
+		updated = true
+	}
+	if description != "" && p.Description != description {
+		p.Description = description
+		updated = true
+	}
+	if active != nil && p.Active != *active {
+		p.Active = *active
+		updated = true
+	}
+
+	return updated
+}
+
+// SetEntries replaces all entries of the price list
+func (p *PriceList) SetEntries(entries []PriceListEntry) error {
+	type entryKey struct {
+		variantID   uint
+		currency    string
+		minQuantity int
+	}
+
+	seen := make(map[entryKey]bool, len(entries))
+	for i := range entries {
+		key := entryKey{entries[i].ProductVariantID, entries[i].Currency, entries[i].MinQuantity}
+		if seen[key] {
+			return fmt.Errorf("duplicate price for variant %d in %s from quantity %d", key.variantID, key.currency, key.minQuantity)
+		}
+		seen[key] = true
+		entries[i].PriceListID = p.ID
+	}
+
+	p.Entries = entries
+	return nil
+}
+
+// PriceFor returns the list price of a variant in a currency for the given quantity.
+// The entry with the highest minimum quantity not above the quantity wins.
+func (p *PriceList) PriceFor(variantID uint, currency string, quantity int) (int64, bool) {
+	if p == nil || !p.Active {
+		return 0, false
+	}
+
+	currency = strings.ToUpper(currency)
+	best := -1
+	for i, entry := range p.Entries {
+		if entry.ProductVariantID != variantID || entry.Currency != currency || entry.MinQuantity > quantity {
+			continue
+		}
+		if best == -1 || entry.MinQuantity > p.Entries[best].MinQuantity {
+			best = i
+		}
+	}
+
+	if best == -1 {
+		return 0, false
+	}
+	return p.Entries[best].Price, true
+}
+
+// PriceBreaks returns the entries of a variant in a currency ordered by minimum quantity
+func (p *PriceList) PriceBreaks(variantID uint, currency string) []PriceListEntry {
+	if p == nil || !p.Active {
+		return nil
+	}
+
+	currency = strings.ToUpper(currency)
+	var breaks []PriceListEntry
+	for _, entry := range p.Entries {
+		if entry.ProductVariantID == variantID && entry.Currency == currency {
+			breaks = append(breaks, entry)
+		}
+	}
+
+	slices.SortFunc(breaks, func(a, b PriceListEntry) int {
+		return a.MinQuantity - b.MinQuantity
+	})
+	return breaks
+}
+
+func (p *PriceList) ToPriceListDTO() *dto.PriceListDTO {
+	entries := make([]dto.PriceListEntryDTO, len(p.Entries))
+	for i, entry := range p.Entries {
+		entries[i] = entry.ToPriceListEntryDTO()
+	}
+
+	return &dto.PriceListDTO{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Active:      p.Active,
+		Entries:     entries,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+func (e PriceListEntry) ToPriceListEntryDTO() dto.PriceListEntryDTO {
+	var sku string
+	if e.ProductVariant != nil {
+		sku = e.ProductVariant.SKU
+	}
+
+	return dto.PriceListEntryDTO{
+		ID:          e.ID,
+		VariantID:   e.ProductVariantID,
+		SKU:         sku,
+		Currency:    e.Currency,
+		MinQuantity: e.MinQuantity,
+		Price:       money.FromMinor(e.Price, e.Currency),
+	}
+}
@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceList(t *testing.T) {
+	newPriceList := func(t *testing.T) *PriceList {
+		priceList, err := NewPriceList("Wholesale", "Trade customers", true)
+		require.NoError(t, err)
+
+		entries := []PriceListEntry{}
+		for _, e := range []struct {
+			variantID   uint
+			currency    string
+			minQuantity int
+			price       int64
+		}{
+			{1, "USD", 0, 900},
+			{1, "USD", 10, 800},
+			{1, "USD", 50, 700},
+			{1, "EUR", 1, 850},
+		} {
+			entry, err := NewPriceListEntry(e.variantID, e.currency, e.minQuantity, e.price)
+			require.NoError(t, err)
+			entries = append(entries, *entry)
+		}
+		require.NoError(t, priceList.SetEntries(entries))
+
+		return priceList
+	}
+
+	t.Run("NewPriceList validation", func(t *testing.T) {
+		_, err := NewPriceList("", "", true)
+		assert.Error(t, err)
+	})
+
+	t.Run("NewPriceListEntry validation", func(t *testing.T) {
+		entry, err := NewPriceListEntry(1, "usd", 0, 100)
+		require.NoError(t, err)
+		assert.Equal(t, "USD", entry.Currency)
+		assert.Equal(t, 1, entry.MinQuantity)
+
+		_, err = NewPriceListEntry(0, "USD", 1, 100)
+		assert.Error(t, err)
+		_, err = NewPriceListEntry(1, "", 1, 100)
+		assert.Error(t, err)
+		_, err = NewPriceListEntry(1, "USD", 1, -1)
+		assert.Error(t, err)
+	})
+
+	t.Run("SetEntries rejects duplicates", func(t *testing.T) {
+		priceList, err := NewPriceList("Wholesale", "", true)
+		require.NoError(t, err)
+
+		entry, err := NewPriceListEntry(1, "USD", 5, 100)
+		require.NoError(t, err)
+		assert.Error(t, priceList.SetEntries([]PriceListEntry{*entry, *entry}))
+	})
+
+	t.Run("PriceFor uses the highest applicable quantity break", func(t *testing.T) {
+		priceList := newPriceList(t)
+
+		price, ok := priceList.PriceFor(1, "USD", 1)
+		assert.True(t, ok)
+		assert.Equal(t, int64(900), price)
+
+		price, ok = priceList.PriceFor(1, "USD", 25)
+		assert.True(t, ok)
+		assert.Equal(t, int64(800), price)
+
+		price, ok = priceList.PriceFor(1, "USD", 50)
+		assert.True(t, ok)
+		assert.Equal(t, int64(700), price)
+
+		price, ok = priceList.PriceFor(1, "EUR", 3)
+		assert.True(t, ok)
+		assert.Equal(t, int64(850), price)
+
+		_, ok = priceList.PriceFor(1, "DKK", 1)
+		assert.False(t, ok)
+		_, ok = priceList.PriceFor(2, "USD", 1)
+		assert.False(t, ok)
+	})
+
+	t.Run("PriceFor ignores inactive and nil lists", func(t *testing.T) {
+		priceList := newPriceList(t)
+		inactive := false
+		priceList.Update("", "", &inactive)
+
+		_, ok := priceList.PriceFor(1, "USD", 1)
+		assert.False(t, ok)
+
+		var nilList *PriceList
+		_, ok = nilList.PriceFor(1, "USD", 1)
+		assert.False(t, ok)
+	})
+
+	t.Run("PriceBreaks are sorted by quantity", func(t *testing.T) {
+		breaks := newPriceList(t).PriceBreaks(1, "USD")
+		require.Len(t, breaks, 3)
+		assert.Equal(t, 1, breaks[0].MinQuantity)
+		assert.Equal(t, 10, breaks[1].MinQuantity)
+		assert.Equal(t, 50, breaks[2].MinQuantity)
+	})
+
+	t.Run("Product ApplyPriceList sets list prices and breaks", func(t *testing.T) {
+		listed, err := NewProductVariant("SKU-LISTED", 10, 1000, 1, nil, nil, true)
+		require.NoError(t, err)
+		listed.ID = 1
+		unlisted, err := NewProductVariant("SKU-UNLISTED", 10, 2000, 1, nil, nil, false)
+		require.NoError(t, err)
+		unlisted.ID = 2
+
+		product, err := NewProduct("Widget", "", "USD", 1, nil, []*ProductVariant{listed, unlisted}, true)
+		require.NoError(t, err)
+
+		product.ApplyPriceList(newPriceList(t))
+
+		assert.Equal(t, int64(900), listed.Price)
+		assert.Len(t, listed.PriceBreaks, 3)
+		assert.Equal(t, int64(2000), unlisted.Price)
+		assert.Empty(t, unlisted.PriceBreaks)
+
+		productDTO := product.ToProductDTO()
+		require.Len(t, productDTO.Variants[0].PriceBreaks, 3)
+		assert.Equal(t, 8.0, productDTO.Variants[0].PriceBreaks[1].Price)
+	})
+}
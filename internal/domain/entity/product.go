@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/dto"
 	"github.com/zenfulcode/commercify/internal/domain/money"
@@ -11,18 +12,44 @@ import (
 	"gorm.io/gorm"
 )
 
+// ProductType distinguishes regular products from bundles made of other products
+type ProductType string
+
+const (
+	ProductTypeStandard ProductType = "standard"
+	ProductTypeBundle   ProductType = "bundle" // Variants are made of component variants and take their stock
+)
+
 // Product represents a product in the system
 // All products must have at least one variant as per the database schema
 type Product struct {
 	gorm.Model
+	Type        ProductType                 `gorm:"not null;size:20;default:standard"`
 	Name        string                      `gorm:"not null;size:255"`
+	Slug        string                      `gorm:"size:200;index:idx_products_slug,unique,where:slug <> ''"`
 	Description string                      `gorm:"type:text"`
+	SEO         SEOMetadata                 `gorm:"embedded"`
 	Currency    string                      `gorm:"not null;size:3"`
 	CategoryID  uint                        `gorm:"not null;index"`
 	Category    Category                    `gorm:"foreignKey:CategoryID;constraint:OnDelete:RESTRICT,OnUpdate:CASCADE"`
 	Images      datatypes.JSONSlice[string] `gorm:"type:text[];default:'[]'"`
 	Active      bool                        `gorm:"default:true"`
+	PublishAt   *time.Time                  `gorm:"index"`                     // Hidden before this time when set
+	UnpublishAt *time.Time                  `gorm:"index"`                     // Hidden from this time when set
+	Channels    []SalesChannel              `gorm:"type:text;serializer:json"` // Sales channels showing the product, all when empty
 	Variants    []*ProductVariant           `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	Options     []*ProductOption            `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+
+	// Aggregate of the approved reviews, recomputed when reviews are moderated
+	RatingAverage float64 `gorm:"not null;default:0"`
+	ReviewCount   int     `gorm:"not null;default:0"`
+
+	// Values of the admin-defined custom fields by key
+	CustomFields CustomFields `gorm:"type:jsonb;serializer:json"`
+
+	// SearchRank and SearchHighlights describe how the product matched a search query, never persisted
+	SearchRank       float64           `gorm:"-"`
+	SearchHighlights map[string]string `gorm:"-"`
 }
 
 // NewProduct creates a new product with the given details
@@ -45,6 +72,7 @@ func NewProduct(name, description, currency string, categoryID uint, images []st
 	copy(productVariants, variants)
 
 	return &Product{
+		Type:        ProductTypeStandard,
 		Name:        name,
 		Description: description,
 		Currency:    currency,
@@ -179,11 +207,35 @@ func (p *Product) GetStockForVariant(variantID uint) (int, error) {
 func (p *Product) GetTotalStock() int {
 	totalStock := 0
 	for _, variant := range p.Variants {
-		totalStock += variant.Stock
+		totalStock += variant.AvailableStock()
 	}
 	return totalStock
 }
 
+// IsBundle reports whether the variants of the product are bundles of other variants
+func (p *Product) IsBundle() bool {
+	return p.Type == ProductTypeBundle
+}
+
+// SetType changes the type of the product. A bundle can only become a standard product
+// once its variants no longer have components.
+func (p *Product) SetType(productType ProductType) error {
+	switch productType {
+	case ProductTypeStandard:
+		for _, variant := range p.Variants {
+			if variant.IsBundle() {
+				return fmt.Errorf("remove the components of bundle variant %s before changing the product type", variant.SKU)
+			}
+		}
+	case ProductTypeBundle:
+	default:
+		return fmt.Errorf("invalid product type %q", productType)
+	}
+
+	p.Type = productType
+	return nil
+}
+
 func (p *Product) HasVariants() bool {
 	return len(p.Variants) > 0
 }
@@ -244,6 +296,62 @@ func (p *Product) GetPrice() int64 {
 	return 0
 }
 
+// defaultVariantPrices returns the effective and compare-at price of the default variant at the given time
+func (p *Product) defaultVariantPrices(at time.Time) (float64, *float64, bool) {
+	defaultVariant := p.GetDefaultVariant()
+	if defaultVariant == nil {
+		return 0, nil, false
+	}
+
+	price := money.FromMinor(defaultVariant.EffectivePrice(at), p.Currency)
+	if compareAt, ok := defaultVariant.CompareAtPriceAt(at); ok {
+		major := money.FromMinor(compareAt, p.Currency)
+		return price, &major, defaultVariant.IsOnSale(at)
+	}
+
+	return price, nil, false
+}
+
+// ApplyCurrency prices the product in the target currency, using explicit variant prices
+// where present and exchange-rate conversion otherwise. The result is meant for display
+// and must not be persisted.
+func (p *Product) ApplyCurrency(productCurrency, targetCurrency *Currency) {
+	if productCurrency.Code == targetCurrency.Code {
+		return
+	}
+
+	for _, variant := range p.Variants {
+		// Scheduled prices are scaled against the original price, so convert them first
+		if variant.Sale.IsSet() {
+			price := variant.scheduledPriceIn(*variant.Sale.Price, productCurrency, targetCurrency)
+			variant.Sale.Price = &price
+		}
+		if variant.CompareAt.IsSet() {
+			price := variant.scheduledPriceIn(*variant.CompareAt.Price, productCurrency, targetCurrency)
+			variant.CompareAt.Price = &price
+		}
+		variant.Price = variant.PriceIn(productCurrency, targetCurrency)
+		variant.Product.Currency = targetCurrency.Code
+	}
+
+	p.Currency = targetCurrency.Code
+}
+
+// ApplyPriceList replaces variant prices with the customer price list prices for a single unit
+// and attaches the quantity breaks. It must be applied after ApplyCurrency and not be persisted.
+func (p *Product) ApplyPriceList(priceList *PriceList) {
+	if priceList == nil || !priceList.Active {
+		return
+	}
+
+	for _, variant := range p.Variants {
+		if price, ok := priceList.PriceFor(variant.ID, p.Currency, 1); ok {
+			variant.Price = price
+		}
+		variant.PriceBreaks = priceList.PriceBreaks(variant.ID, p.Currency)
+	}
+}
+
 func (p *Product) ToProductDTO() *dto.ProductDTO {
 	if p == nil {
 		return nil
@@ -251,47 +359,75 @@ func (p *Product) ToProductDTO() *dto.ProductDTO {
 
 	variantsDTO := make([]dto.VariantDTO, len(p.Variants))
 	for i, v := range p.Variants {
-		variantsDTO[i] = *v.ToVariantDTO()
+		variantsDTO[i] = *v.toVariantDTOInCurrency(p.Currency)
 	}
 
-	defaultVariant := p.GetDefaultVariant()
-	if defaultVariant == nil {
-
+	optionsDTO := make([]dto.ProductOptionDTO, len(p.Options))
+	for i, option := range p.Options {
+		optionsDTO[i] = option.ToProductOptionDTO()
 	}
 
+	price, compareAtPrice, onSale := p.defaultVariantPrices(time.Now())
+
 	return &dto.ProductDTO{
-		ID:          p.ID,
-		Name:        p.Name,
-		SKU:         p.GetProdNumber(),
-		Description: p.Description,
-		Currency:    p.Currency,
-		TotalStock:  p.GetTotalStock(),
-		Price:       money.FromCents(p.GetPrice()),
-		Category:    p.Category.Name,
-		CategoryID:  p.CategoryID,
-		Images:      p.Images,
-		HasVariants: p.HasVariants(),
-		Active:      p.Active,
-		Variants:    variantsDTO,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+		ID:             p.ID,
+		Type:           string(p.Type),
+		Name:           p.Name,
+		Slug:           p.Slug,
+		SKU:            p.GetProdNumber(),
+		Description:    p.Description,
+		SEO:            p.SEO.ToSEODTO(),
+		Currency:       p.Currency,
+		TotalStock:     p.GetTotalStock(),
+		Price:          price,
+		CompareAtPrice: compareAtPrice,
+		OnSale:         onSale,
+		Category:       p.Category.Name,
+		CategoryID:     p.CategoryID,
+		Images:         p.Images,
+		HasVariants:    p.HasVariants(),
+		Active:         p.Active,
+		PublishAt:      p.PublishAt,
+		UnpublishAt:    p.UnpublishAt,
+		Channels:       p.channelNames(),
+		Variants:       variantsDTO,
+		Options:        optionsDTO,
+		RatingAverage:  p.RatingAverage,
+		ReviewCount:    p.ReviewCount,
+		SearchRank:     p.SearchRank,
+		Highlights:     p.SearchHighlights,
+		CustomFields:   p.CustomFields,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
 	}
 }
 
 func (p *Product) ToProductSummaryDTO() *dto.ProductDTO {
+	price, compareAtPrice, onSale := p.defaultVariantPrices(time.Now())
+
 	return &dto.ProductDTO{
-		ID:          p.ID,
-		Name:        p.Name,
-		SKU:         p.GetProdNumber(),
-		Description: p.Description,
-		Currency:    p.Currency,
-		TotalStock:  p.GetTotalStock(),
-		Price:       money.FromCents(p.GetPrice()),
-		Category:    p.Category.Name,
-		Images:      p.Images,
-		HasVariants: p.HasVariants(),
-		Active:      p.Active,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+		ID:             p.ID,
+		Type:           string(p.Type),
+		Name:           p.Name,
+		Slug:           p.Slug,
+		SKU:            p.GetProdNumber(),
+		Description:    p.Description,
+		SEO:            p.SEO.ToSEODTO(),
+		Currency:       p.Currency,
+		TotalStock:     p.GetTotalStock(),
+		Price:          price,
+		CompareAtPrice: compareAtPrice,
+		OnSale:         onSale,
+		Category:       p.Category.Name,
+		Images:         p.Images,
+		HasVariants:    p.HasVariants(),
+		Active:         p.Active,
+		RatingAverage:  p.RatingAverage,
+		ReviewCount:    p.ReviewCount,
+		SearchRank:     p.SearchRank,
+		Highlights:     p.SearchHighlights,
+		CustomFields:   p.CustomFields,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
 	}
 }
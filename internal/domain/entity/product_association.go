@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+)
+
+// AssociationType is the kind of link between two products
+type AssociationType string
+
+const (
+	AssociationRelated   AssociationType = "related"    // Similar products to browse
+	AssociationCrossSell AssociationType = "cross_sell" // Products bought in addition
+	AssociationUpsell    AssociationType = "upsell"     // More expensive alternatives
+	AssociationAccessory AssociationType = "accessory"  // Products used with the product
+)
+
+// AssociationTypes lists the association types maintained by admins in display order
+var AssociationTypes = []AssociationType{AssociationRelated, AssociationCrossSell, AssociationUpsell, AssociationAccessory}
+
+// MaxAssociationsPerType is the maximum number of products linked to a product with one type
+const MaxAssociationsPerType = 50
+
+// ProductAssociation links a product to another product with a type, in display order
+type ProductAssociation struct {
+	ID                  uint            `gorm:"primaryKey"`
+	ProductID           uint            `gorm:"not null;uniqueIndex:idx_product_associations_link"`
+	AssociatedProductID uint            `gorm:"not null;index;uniqueIndex:idx_product_associations_link"`
+	Type                AssociationType `gorm:"not null;size:20;uniqueIndex:idx_product_associations_link"`
+	Position            int             `gorm:"not null;default:0"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// IsValidAssociationType returns true for the association types maintained by admins
+func IsValidAssociationType(associationType AssociationType) bool {
+	return slices.Contains(AssociationTypes, associationType)
+}
+
+// NewProductAssociations creates the associations of a product with one type, in the order of the associated products
+func NewProductAssociations(productID uint, associationType AssociationType, associatedProductIDs []uint) ([]*ProductAssociation, error) {
+	if !IsValidAssociationType(associationType) {
+		return nil, fmt.Errorf("invalid association type %q", associationType)
+	}
+	if len(associatedProductIDs) > MaxAssociationsPerType {
+		return nil, fmt.Errorf("a product cannot have more than %d %s associations", MaxAssociationsPerType, associationType)
+	}
+
+	associations := make([]*ProductAssociation, 0, len(associatedProductIDs))
+	seen := make(map[uint]bool, len(associatedProductIDs))
+	for i, associatedID := range associatedProductIDs {
+		if associatedID == 0 {
+			return nil, errors.New("associated product ID is required")
+		}
+		if associatedID == productID {
+			return nil, errors.New("a product cannot be associated with itself")
+		}
+		if seen[associatedID] {
+			return nil, fmt.Errorf("product %d is listed more than once", associatedID)
+		}
+		seen[associatedID] = true
+
+		associations = append(associations, &ProductAssociation{
+			ProductID:           productID,
+			AssociatedProductID: associatedID,
+			Type:                associationType,
+			Position:            i,
+		})
+	}
+	return associations, nil
+}
+
+// ToProductAssociationDTO converts the association to a DTO
+func (a *ProductAssociation) ToProductAssociationDTO() dto.ProductAssociationDTO {
+	return dto.ProductAssociationDTO{
+		ID:                  a.ID,
+		ProductID:           a.ProductID,
+		AssociatedProductID: a.AssociatedProductID,
+		Type:                string(a.Type),
+		Position:            a.Position,
+	}
+}
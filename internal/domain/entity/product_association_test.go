@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductAssociation(t *testing.T) {
+	t.Run("NewProductAssociations keeps the display order", func(t *testing.T) {
+		associations, err := NewProductAssociations(1, AssociationUpsell, []uint{4, 2, 3})
+		require.NoError(t, err)
+		require.Len(t, associations, 3)
+		for i, id := range []uint{4, 2, 3} {
+			assert.Equal(t, uint(1), associations[i].ProductID)
+			assert.Equal(t, id, associations[i].AssociatedProductID)
+			assert.Equal(t, AssociationUpsell, associations[i].Type)
+			assert.Equal(t, i, associations[i].Position)
+		}
+
+		associations, err = NewProductAssociations(1, AssociationRelated, nil)
+		require.NoError(t, err)
+		assert.Empty(t, associations)
+	})
+
+	t.Run("NewProductAssociations validates the associations", func(t *testing.T) {
+		_, err := NewProductAssociations(1, "similar", []uint{2})
+		assert.ErrorContains(t, err, "invalid association type")
+		_, err = NewProductAssociations(1, AssociationRelated, []uint{0})
+		assert.ErrorContains(t, err, "associated product ID is required")
+		_, err = NewProductAssociations(1, AssociationRelated, []uint{2, 1})
+		assert.ErrorContains(t, err, "cannot be associated with itself")
+		_, err = NewProductAssociations(1, AssociationRelated, []uint{2, 3, 2})
+		assert.ErrorContains(t, err, "product 2 is listed more than once")
+
+		ids := make([]uint, MaxAssociationsPerType+1)
+		for i := range ids {
+			ids[i] = uint(i + 2)
+		}
+		_, err = NewProductAssociations(1, AssociationAccessory, ids)
+		assert.ErrorContains(t, err, "cannot have more than")
+	})
+}
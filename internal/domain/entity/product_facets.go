@@ -0,0 +1,337 @@
+package entity
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+)
+
+// priceBucketTarget is the approximate number of price buckets in a facet
+const priceBucketTarget = 5
+
+// ProductFilter narrows a set of products by category and variant properties.
+// A product matches when it is in one of the categories and a single variant
+// satisfies all attribute, price and stock conditions.
+type ProductFilter struct {
+	CategoryIDs []uint              // Any of these categories, empty for all
+	Attributes  map[string][]string // Attribute name to accepted values; names are ANDed, values ORed
+	MinPrice    int64               // Minimum effective variant price, 0 for no minimum
+	MaxPrice    int64               // Maximum effective variant price, 0 for no maximum
+	InStockOnly bool
+}
+
+// ProductFacets holds the number of products per filter option.
+// Each facet is counted with every filter applied except its own, so options
+// of a facet can be combined without the other options dropping to zero.
+type ProductFacets struct {
+	Attributes   []AttributeFacet
+	PriceBuckets []PriceBucket
+	Categories   []CategoryFacet
+}
+
+// AttributeFacet holds the product counts per value of a variant attribute
+type AttributeFacet struct {
+	Name   string
+	Values []FacetValue
+}
+
+// FacetValue is an attribute value with the number of matching products
+type FacetValue struct {
+	Value    string
+	Count    int
+	Selected bool
+}
+
+// PriceBucket counts the products whose lowest matching variant price is in [Min, Max)
+type PriceBucket struct {
+	Min   int64
+	Max   int64
+	Count int
+}
+
+// CategoryFacet counts the matching products in a category and its descendants
+type CategoryFacet struct {
+	CategoryID uint
+	Name       string
+	ParentID   *uint
+	Count      int
+	Selected   bool
+}
+
+// facetDimension identifies the filter left out when counting a facet
+type facetDimension struct {
+	category  bool
+	price     bool
+	attribute string
+}
+
+// CategoryDescendantIDs returns the ID of the category and of all categories below it
+func CategoryDescendantIDs(categories []*Category, categoryID uint) []uint {
+	children := make(map[uint][]uint)
+	for _, category := range categories {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	ids := []uint{categoryID}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !slices.Contains(ids, child) {
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
+
+// FilterProducts returns the products matching the filter, keeping their order,
+// together with the facet counts over the products
+func FilterProducts(products []*Product, filter ProductFilter, categories []*Category, at time.Time) ([]*Product, *ProductFacets) {
+	matched := make([]*Product, 0, len(products))
+	for _, product := range products {
+		if filter.matches(product, facetDimension{}, at) {
+			matched = append(matched, product)
+		}
+	}
+
+	facets := &ProductFacets{
+		Attributes:   filter.attributeFacets(products, at),
+		PriceBuckets: filter.priceBuckets(products, at),
+		Categories:   filter.categoryFacets(products, categories, at),
+	}
+
+	return matched, facets
+}
+
+// matches reports whether the product matches the filter, ignoring the given dimension
+func (f ProductFilter) matches(product *Product, skip facetDimension, at time.Time) bool {
+	if !skip.category && len(f.CategoryIDs) > 0 && !slices.Contains(f.CategoryIDs, product.CategoryID) {
+		return false
+	}
+	return len(f.matchingVariants(product, skip, at)) > 0
+}
+
+// matchingVariants returns the variants of the product satisfying the variant conditions, ignoring the given dimension
+func (f ProductFilter) matchingVariants(product *Product, skip facetDimension, at time.Time) []*ProductVariant {
+	variants := make([]*ProductVariant, 0, len(product.Variants))
+	for _, variant := range product.Variants {
+		if f.InStockOnly && !variant.IsAvailable(1) {
+			continue
+		}
+
+		if !skip.price {
+			price := variant.EffectivePrice(at)
+			if (f.MinPrice > 0 && price < f.MinPrice) || (f.MaxPrice > 0 && price > f.MaxPrice) {
+				continue
+			}
+		}
+
+		if f.attributesMatch(variant.Attributes.Data(), skip.attribute) {
+			variants = append(variants, variant)
+		}
+	}
+	return variants
+}
+
+// attributesMatch reports whether the attributes hold an accepted value for every filtered name except skip
+func (f ProductFilter) attributesMatch(attributes VariantAttributes, skip string) bool {
+	for name, accepted := range f.Attributes {
+		if name == skip || len(accepted) == 0 {
+			continue
+		}
+		value, ok := attributes[name]
+		if !ok || !slices.ContainsFunc(accepted, func(v string) bool { return strings.EqualFold(v, value) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// attributeFacets counts products per attribute value
+func (f ProductFilter) attributeFacets(products []*Product, at time.Time) []AttributeFacet {
+	names := make(map[string]bool)
+	for _, product := range products {
+		for _, variant := range product.Variants {
+			for name := range variant.Attributes.Data() {
+				names[name] = true
+			}
+		}
+	}
+
+	facets := make([]AttributeFacet, 0, len(names))
+	for name := range names {
+		skip := facetDimension{attribute: name}
+		counts := make(map[string]int)
+		for _, product := range products {
+			if len(f.CategoryIDs) > 0 && !slices.Contains(f.CategoryIDs, product.CategoryID) {
+				continue
+			}
+
+			// Count each value once per product
+			seen := make(map[string]bool)
+			for _, variant := range f.matchingVariants(product, skip, at) {
+				if value, ok := variant.Attributes.Data()[name]; ok && value != "" && !seen[value] {
+					seen[value] = true
+					counts[value]++
+				}
+			}
+		}
+
+		if len(counts) == 0 {
+			continue
+		}
+
+		facet := AttributeFacet{Name: name, Values: make([]FacetValue, 0, len(counts))}
+		for value, count := range counts {
+			facet.Values = append(facet.Values, FacetValue{
+				Value: value,
+				Count: count,
+				Selected: slices.ContainsFunc(f.Attributes[name], func(v string) bool {
+					return strings.EqualFold(v, value)
+				}),
+			})
+		}
+		sort.Slice(facet.Values, func(i, j int) bool { return facet.Values[i].Value < facet.Values[j].Value })
+		facets = append(facets, facet)
+	}
+
+	sort.Slice(facets, func(i, j int) bool { return facets[i].Name < facets[j].Name })
+	return facets
+}
+
+// priceBuckets counts products by their lowest matching variant price in evenly sized buckets
+func (f ProductFilter) priceBuckets(products []*Product, at time.Time) []PriceBucket {
+	skip := facetDimension{price: true}
+	prices := make([]int64, 0, len(products))
+	for _, product := range products {
+		if !f.matches(product, skip, at) {
+			continue
+		}
+
+		lowest := int64(-1)
+		for _, variant := range f.matchingVariants(product, skip, at) {
+			if price := variant.EffectivePrice(at); lowest < 0 || price < lowest {
+				lowest = price
+			}
+		}
+		prices = append(prices, lowest)
+	}
+
+	if len(prices) == 0 {
+		return []PriceBucket{}
+	}
+
+	step := priceBucketStep(slices.Max(prices) / priceBucketTarget)
+	counts := make(map[int64]int)
+	for _, price := range prices {
+		counts[price/step]++
+	}
+
+	buckets := make([]PriceBucket, 0, len(counts))
+	for index, count := range counts {
+		buckets = append(buckets, PriceBucket{Min: index * step, Max: (index + 1) * step, Count: count})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Min < buckets[j].Min })
+	return buckets
+}
+
+// priceBucketStep rounds a bucket size up to 1, 2 or 5 times a power of ten, with 1.00 as the smallest size
+func priceBucketStep(size int64) int64 {
+	step := int64(100)
+	for {
+		for _, factor := range []int64{1, 2, 5} {
+			if step*factor >= size {
+				return step * factor
+			}
+		}
+		step *= 10
+	}
+}
+
+// categoryFacets counts products per category, including products of descendant categories
+func (f ProductFilter) categoryFacets(products []*Product, categories []*Category, at time.Time) []CategoryFacet {
+	byID := make(map[uint]*Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	skip := facetDimension{category: true}
+	counts := make(map[uint]int)
+	for _, product := range products {
+		if !f.matches(product, skip, at) {
+			continue
+		}
+
+		// Count the product for its category and every ancestor, guarding against cycles
+		visited := make(map[uint]bool)
+		for id := product.CategoryID; id != 0 && !visited[id]; {
+			visited[id] = true
+			counts[id]++
+
+			category, ok := byID[id]
+			if !ok || category.ParentID == nil {
+				break
+			}
+			id = *category.ParentID
+		}
+	}
+
+	facets := make([]CategoryFacet, 0, len(counts))
+	for _, category := range categories {
+		if counts[category.ID] == 0 {
+			continue
+		}
+		facets = append(facets, CategoryFacet{
+			CategoryID: category.ID,
+			Name:       category.Name,
+			ParentID:   category.ParentID,
+			Count:      counts[category.ID],
+			Selected:   len(f.CategoryIDs) > 0 && f.CategoryIDs[0] == category.ID,
+		})
+	}
+	return facets
+}
+
+// ToProductFacetsDTO converts the facets to a DTO with prices in the given currency
+func (f *ProductFacets) ToProductFacetsDTO(currency string) dto.ProductFacetsDTO {
+	attributes := make([]dto.AttributeFacetDTO, len(f.Attributes))
+	for i, facet := range f.Attributes {
+		values := make([]dto.FacetValueDTO, len(facet.Values))
+		for j, value := range facet.Values {
+			values[j] = dto.FacetValueDTO{Value: value.Value, Count: value.Count, Selected: value.Selected}
+		}
+		attributes[i] = dto.AttributeFacetDTO{Name: facet.Name, Values: values}
+	}
+
+	buckets := make([]dto.PriceBucketDTO, len(f.PriceBuckets))
+	for i, bucket := range f.PriceBuckets {
+		buckets[i] = dto.PriceBucketDTO{
+			Min:   money.FromMinor(bucket.Min, currency),
+			Max:   money.FromMinor(bucket.Max, currency),
+			Count: bucket.Count,
+		}
+	}
+
+	categories := make([]dto.CategoryFacetDTO, len(f.Categories))
+	for i, facet := range f.Categories {
+		categories[i] = dto.CategoryFacetDTO{
+			ID:       facet.CategoryID,
+			Name:     facet.Name,
+			ParentID: facet.ParentID,
+			Count:    facet.Count,
+			Selected: facet.Selected,
+		}
+	}
+
+	return dto.ProductFacetsDTO{
+		Currency:     currency,
+		Attributes:   attributes,
+		PriceBuckets: buckets,
+		Categories:   categories,
+	}
+}
@@ -0,0 +1,153 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductFacets(t *testing.T) {
+	parentID := uint(1)
+	categories := []*Category{
+		{Name: "Clothing"},
+		{Name: "Shirts", ParentID: &parentID},
+		{Name: "Hats"},
+	}
+	for i, category := range categories {
+		category.ID = uint(i + 1)
+	}
+
+	newProduct := func(t *testing.T, id, categoryID uint, variants ...*ProductVariant) *Product {
+		product, err := NewProduct("Product", "", "USD", categoryID, nil, variants, true)
+		require.NoError(t, err)
+		product.ID = id
+		return product
+	}
+
+	newVariant := func(t *testing.T, sku string, stock int, price int64, attributes VariantAttributes) *ProductVariant {
+		variant, err := NewProductVariant(sku, stock, price, 1, attributes, nil, false)
+		require.NoError(t, err)
+		return variant
+	}
+
+	// Red shirt only in stock in size M, blue shirt in S and M, red hat without size
+	products := func(t *testing.T) []*Product {
+		return []*Product{
+			newProduct(t, 1, 2,
+				newVariant(t, "RED-S", 0, 2000, VariantAttributes{"color": "Red", "size": "S"}),
+				newVariant(t, "RED-M", 5, 2000, VariantAttributes{"color": "Red", "size": "M"}),
+			),
+			newProduct(t, 2, 2,
+				newVariant(t, "BLUE-S", 3, 2500, VariantAttributes{"color": "Blue", "size": "S"}),
+				newVariant(t, "BLUE-M", 3, 2500, VariantAttributes{"color": "Blue", "size": "M"}),
+			),
+			newProduct(t, 3, 3,
+				newVariant(t, "HAT-RED", 1, 900, VariantAttributes{"color": "Red"}),
+			),
+		}
+	}
+
+	ids := func(products []*Product) []uint {
+		result := make([]uint, len(products))
+		for i, product := range products {
+			result[i] = product.ID
+		}
+		return result
+	}
+
+	facetValues := func(facets *ProductFacets, name string) map[string]int {
+		for _, facet := range facets.Attributes {
+			if facet.Name == name {
+				values := make(map[string]int)
+				for _, value := range facet.Values {
+					values[value.Value] = value.Count
+				}
+				return values
+			}
+		}
+		return nil
+	}
+
+	t.Run("CategoryDescendantIDs includes the whole subtree", func(t *testing.T) {
+		assert.ElementsMatch(t, []uint{1, 2}, CategoryDescendantIDs(categories, 1))
+		assert.Equal(t, []uint{3}, CategoryDescendantIDs(categories, 3))
+	})
+
+	t.Run("Attributes must match on a single variant", func(t *testing.T) {
+		matched, _ := FilterProducts(products(t), ProductFilter{
+			Attributes:  map[string][]string{"color": {"red"}, "size": {"M"}},
+			InStockOnly: true,
+		}, categories, time.Now())
+		assert.Equal(t, []uint{1}, ids(matched))
+
+		matched, _ = FilterProducts(products(t), ProductFilter{
+			Attributes:  map[string][]string{"color": {"red"}, "size": {"S"}},
+			InStockOnly: true,
+		}, categories, time.Now())
+		assert.Empty(t, matched)
+
+		matched, _ = FilterProducts(products(t), ProductFilter{
+			Attributes: map[string][]string{"color": {"red", "blue"}},
+		}, categories, time.Now())
+		assert.Equal(t, []uint{1, 2, 3}, ids(matched))
+	})
+
+	t.Run("Attribute facets ignore their own filter", func(t *testing.T) {
+		_, facets := FilterProducts(products(t), ProductFilter{
+			Attributes: map[string][]string{"color": {"Red"}, "size": {"S"}},
+		}, categories, time.Now())
+
+		assert.Equal(t, map[string]int{"Red": 1, "Blue": 1}, facetValues(facets, "color"))
+		assert.Equal(t, map[string]int{"S": 1, "M": 1}, facetValues(facets, "size"))
+		for _, facet := range facets.Attributes {
+			for _, value := range facet.Values {
+				assert.Equal(t, value.Value == "Red" || value.Value == "S", value.Selected)
+			}
+		}
+	})
+
+	t.Run("Category filter and facets use the category tree", func(t *testing.T) {
+		matched, facets := FilterProducts(products(t), ProductFilter{
+			CategoryIDs: CategoryDescendantIDs(categories, 1),
+		}, categories, time.Now())
+		assert.Equal(t, []uint{1, 2}, ids(matched))
+
+		counts := make(map[uint]int)
+		for _, facet := range facets.Categories {
+			counts[facet.CategoryID] = facet.Count
+			assert.Equal(t, facet.CategoryID == 1, facet.Selected)
+		}
+		assert.Equal(t, map[uint]int{1: 2, 2: 2, 3: 1}, counts)
+	})
+
+	t.Run("Price buckets use the lowest matching price", func(t *testing.T) {
+		matched, facets := FilterProducts(products(t), ProductFilter{MinPrice: 1000}, categories, time.Now())
+		assert.Equal(t, []uint{1, 2}, ids(matched))
+
+		assert.Equal(t, []PriceBucket{
+			{Min: 500, Max: 1000, Count: 1},
+			{Min: 2000, Max: 2500, Count: 1},
+			{Min: 2500, Max: 3000, Count: 1},
+		}, facets.PriceBuckets)
+	})
+
+	t.Run("Sale prices are used for price filters", func(t *testing.T) {
+		catalog := products(t)
+		salePrice := int64(1500)
+		for _, variant := range catalog[1].Variants {
+			require.NoError(t, variant.SetSalePrice(&salePrice, nil, nil))
+		}
+
+		matched, _ := FilterProducts(catalog, ProductFilter{MaxPrice: 1500}, categories, time.Now())
+		assert.Equal(t, []uint{2, 3}, ids(matched))
+	})
+
+	t.Run("priceBucketStep rounds to 1, 2 or 5", func(t *testing.T) {
+		assert.Equal(t, int64(100), priceBucketStep(0))
+		assert.Equal(t, int64(200), priceBucketStep(130))
+		assert.Equal(t, int64(500), priceBucketStep(480))
+		assert.Equal(t, int64(1000), priceBucketStep(501))
+	})
+}
@@ -0,0 +1,249 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// MaxGeneratedVariants caps the number of combinations a product's options may produce
+const MaxGeneratedVariants = 500
+
+// ProductOption defines a variant attribute of a product with its allowed values in display order
+type ProductOption struct {
+	gorm.Model
+	ProductID uint                        `gorm:"not null;index"`
+	Name      string                      `gorm:"not null;size:100"`
+	Position  int                         `gorm:"not null;default:0"`
+	Values    datatypes.JSONSlice[string] `gorm:"not null"`
+}
+
+// NewProductOption creates a product option with its allowed values
+func NewProductOption(name string, values []string) (*ProductOption, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("option name cannot be empty")
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("option %s must have at least one value", name)
+	}
+
+	cleaned := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, fmt.Errorf("option %s has an empty value", name)
+		}
+		if slices.ContainsFunc(cleaned, func(v string) bool { return strings.EqualFold(v, value) }) {
+			return nil, fmt.Errorf("option %s has duplicate value %s", name, value)
+		}
+		cleaned = append(cleaned, value)
+	}
+
+	return &ProductOption{Name: name, Values: cleaned}, nil
+}
+
+// value returns the allowed value matching the given value case-insensitively
+func (o *ProductOption) value(value string) (string, bool) {
+	for _, v := range o.Values {
+		if strings.EqualFold(v, value) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// ToProductOptionDTO converts the option to a DTO
+func (o *ProductOption) ToProductOptionDTO() dto.ProductOptionDTO {
+	return dto.ProductOptionDTO{
+		Name:   o.Name,
+		Values: o.Values,
+	}
+}
+
+// SetOptions replaces the option definitions of the product. Existing variants with
+// attributes must still be valid combinations of the new options.
+func (p *Product) SetOptions(options []*ProductOption) error {
+	for i, option := range options {
+		for _, other := range options[:i] {
+			if strings.EqualFold(option.Name, other.Name) {
+				return fmt.Errorf("duplicate option %s", option.Name)
+			}
+		}
+		option.ProductID = p.ID
+		option.Position = i
+	}
+
+	combinations := 1
+	for _, option := range options {
+		combinations *= len(option.Values)
+		if combinations > MaxGeneratedVariants {
+			return fmt.Errorf("options cannot produce more than %d variants", MaxGeneratedVariants)
+		}
+	}
+
+	previous := p.Options
+	p.Options = options
+	if err := p.ValidateVariantOptions(); err != nil {
+		p.Options = previous
+		return err
+	}
+	return nil
+}
+
+// ValidateVariantOptions checks the attributes of all variants against the product options and
+// rewrites them to the exact option names and values. Variants must set every option, apart from
+// existing variants without attributes, and no two variants may share a combination.
+// Products without options accept any attributes.
+func (p *Product) ValidateVariantOptions() error {
+	if len(p.Options) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]string)
+	for _, variant := range p.Variants {
+		attributes := variant.Attributes.Data()
+		if len(attributes) == 0 && variant.ID != 0 {
+			continue
+		}
+
+		normalized, err := p.normalizeAttributes(attributes)
+		if err != nil {
+			return fmt.Errorf("variant %s: %w", variant.SKU, err)
+		}
+
+		key := p.combinationKey(normalized)
+		if sku, ok := seen[key]; ok {
+			return fmt.Errorf("variants %s and %s have the same options", sku, variant.SKU)
+		}
+		seen[key] = variant.SKU
+
+		variant.Attributes = datatypes.NewJSONType(normalized)
+	}
+	return nil
+}
+
+// normalizeAttributes maps attributes to the option names and values, failing on unknown or missing options
+func (p *Product) normalizeAttributes(attributes VariantAttributes) (VariantAttributes, error) {
+	normalized := make(VariantAttributes, len(p.Options))
+	for name, value := range attributes {
+		index := slices.IndexFunc(p.Options, func(o *ProductOption) bool { return strings.EqualFold(o.Name, name) })
+		if index < 0 {
+			return nil, fmt.Errorf("unknown option %s", name)
+		}
+
+		option := p.Options[index]
+		allowed, ok := option.value(value)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a value of option %s", value, option.Name)
+		}
+		normalized[option.Name] = allowed
+	}
+
+	for _, option := range p.Options {
+		if _, ok := normalized[option.Name]; !ok {
+			return nil, fmt.Errorf("missing value for option %s", option.Name)
+		}
+	}
+	return normalized, nil
+}
+
+// combinationKey identifies a normalized attribute combination
+func (p *Product) combinationKey(attributes VariantAttributes) string {
+	parts := make([]string, len(p.Options))
+	for i, option := range p.Options {
+		parts[i] = attributes[option.Name]
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// OptionCombinations returns every combination of the option values, varying the last option fastest
+func (p *Product) OptionCombinations() []VariantAttributes {
+	if len(p.Options) == 0 {
+		return nil
+	}
+
+	combinations := []VariantAttributes{{}}
+	for _, option := range p.Options {
+		next := make([]VariantAttributes, 0, len(combinations)*len(option.Values))
+		for _, combination := range combinations {
+			for _, value := range option.Values {
+				attributes := make(VariantAttributes, len(combination)+1)
+				for k, v := range combination {
+					attributes[k] = v
+				}
+				attributes[option.Name] = value
+				next = append(next, attributes)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// MissingOptionCombinations returns the option combinations no variant has yet
+func (p *Product) MissingOptionCombinations() []VariantAttributes {
+	existing := make(map[string]bool, len(p.Variants))
+	for _, variant := range p.Variants {
+		if normalized, err := p.normalizeAttributes(variant.Attributes.Data()); err == nil {
+			existing[p.combinationKey(normalized)] = true
+		}
+	}
+
+	missing := make([]VariantAttributes, 0)
+	for _, combination := range p.OptionCombinations() {
+		if !existing[p.combinationKey(combination)] {
+			missing = append(missing, combination)
+		}
+	}
+	return missing
+}
+
+// skuPatternToken matches the placeholders of a SKU pattern
+var skuPatternToken = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// skuUnsafeChars matches characters replaced when option values are used in SKUs
+var skuUnsafeChars = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// DefaultSKUPattern returns the SKU pattern used when none is given, e.g. P12-{Color}-{Size}
+func (p *Product) DefaultSKUPattern() string {
+	pattern := "P{id}"
+	for _, option := range p.Options {
+		pattern += "-{" + option.Name + "}"
+	}
+	return pattern
+}
+
+// FormatVariantSKU builds a SKU from a pattern. {id} is replaced with the product ID and
+// {Option} placeholders with the upper-cased option value of the combination.
+func (p *Product) FormatVariantSKU(pattern string, attributes VariantAttributes) (string, error) {
+	var unknown string
+	sku := skuPatternToken.ReplaceAllStringFunc(pattern, func(token string) string {
+		name := token[1 : len(token)-1]
+		if strings.EqualFold(name, "id") {
+			return strconv.FormatUint(uint64(p.ID), 10)
+		}
+		for _, option := range p.Options {
+			if strings.EqualFold(option.Name, name) {
+				return strings.Trim(skuUnsafeChars.ReplaceAllString(strings.ToUpper(attributes[option.Name]), "-"), "-")
+			}
+		}
+		unknown = name
+		return token
+	})
+
+	if unknown != "" {
+		return "", fmt.Errorf("unknown SKU pattern placeholder {%s}", unknown)
+	}
+	if strings.TrimSpace(sku) == "" {
+		return "", errors.New("SKU pattern produces an empty SKU")
+	}
+	return sku, nil
+}
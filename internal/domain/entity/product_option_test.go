@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductOptions(t *testing.T) {
+	newOptions := func(t *testing.T) []*ProductOption {
+		color, err := NewProductOption("Color", []string{"Red", "Blue"})
+		require.NoError(t, err)
+		size, err := NewProductOption("Size", []string{"S", "M", "XL"})
+		require.NoError(t, err)
+		return []*ProductOption{color, size}
+	}
+
+	newProduct := func(t *testing.T, variants ...*ProductVariant) *Product {
+		product, err := NewProduct("Shirt", "", "USD", 1, nil, variants, true)
+		require.NoError(t, err)
+		product.ID = 7
+		return product
+	}
+
+	newVariant := func(t *testing.T, sku string, attributes VariantAttributes) *ProductVariant {
+		variant, err := NewProductVariant(sku, 1, 1000, 1, attributes, nil, false)
+		require.NoError(t, err)
+		return variant
+	}
+
+	t.Run("NewProductOption validates values", func(t *testing.T) {
+		option, err := NewProductOption(" Color ", []string{" Red ", "Blue"})
+		require.NoError(t, err)
+		assert.Equal(t, "Color", option.Name)
+		assert.Equal(t, []string{"Red", "Blue"}, []string(option.Values))
+
+		_, err = NewProductOption("", []string{"Red"})
+		assert.Error(t, err)
+		_, err = NewProductOption("Color", nil)
+		assert.Error(t, err)
+		_, err = NewProductOption("Color", []string{"Red", "red"})
+		assert.Error(t, err)
+	})
+
+	t.Run("SetOptions normalizes variant attributes", func(t *testing.T) {
+		variant := newVariant(t, "RED-S", VariantAttributes{"colour": "red", "size": "s"})
+		product := newProduct(t, variant)
+
+		err := product.SetOptions(newOptions(t))
+		assert.ErrorContains(t, err, "unknown option colour")
+		assert.Empty(t, product.Options)
+
+		variant.Attributes.Data()["color"] = "red"
+		delete(variant.Attributes.Data(), "colour")
+		require.NoError(t, product.SetOptions(newOptions(t)))
+		assert.Equal(t, VariantAttributes{"Color": "Red", "Size": "S"}, variant.Attributes.Data())
+		assert.Equal(t, 1, product.Options[1].Position)
+	})
+
+	t.Run("Variants must set every option once", func(t *testing.T) {
+		product := newProduct(t, newVariant(t, "RED", VariantAttributes{"Color": "Red"}))
+		assert.ErrorContains(t, product.SetOptions(newOptions(t)), "missing value for option Size")
+
+		product = newProduct(t, newVariant(t, "GREEN-S", VariantAttributes{"Color": "Green", "Size": "S"}))
+		assert.ErrorContains(t, product.SetOptions(newOptions(t)), "Green is not a value of option Color")
+
+		product = newProduct(t,
+			newVariant(t, "A", VariantAttributes{"Color": "Red", "Size": "S"}),
+			newVariant(t, "B", VariantAttributes{"color": "RED", "size": "s"}),
+		)
+		assert.ErrorContains(t, product.SetOptions(newOptions(t)), "same options")
+
+		color, err := NewProductOption("color", []string{"Green"})
+		require.NoError(t, err)
+		product = newProduct(t, newVariant(t, "RED-S", VariantAttributes{"Color": "Red", "Size": "S"}))
+		assert.ErrorContains(t, product.SetOptions(append(newOptions(t), color)), "duplicate option")
+	})
+
+	t.Run("MissingOptionCombinations skips existing variants", func(t *testing.T) {
+		product := newProduct(t, newVariant(t, "RED-M", VariantAttributes{"Color": "Red", "Size": "M"}))
+		require.NoError(t, product.SetOptions(newOptions(t)))
+
+		assert.Len(t, product.OptionCombinations(), 6)
+		missing := product.MissingOptionCombinations()
+		require.Len(t, missing, 5)
+		assert.Equal(t, VariantAttributes{"Color": "Red", "Size": "S"}, missing[0])
+		assert.Equal(t, VariantAttributes{"Color": "Red", "Size": "XL"}, missing[1])
+		assert.NotContains(t, missing, VariantAttributes{"Color": "Red", "Size": "M"})
+	})
+
+	t.Run("FormatVariantSKU fills placeholders", func(t *testing.T) {
+		product := newProduct(t, newVariant(t, "LIGHT", VariantAttributes{"color": "light blue"}))
+		light, err := NewProductOption("Color", []string{"Light blue"})
+		require.NoError(t, err)
+		require.NoError(t, product.SetOptions([]*ProductOption{light}))
+
+		sku, err := product.FormatVariantSKU(product.DefaultSKUPattern(), VariantAttributes{"Color": "Light blue"})
+		require.NoError(t, err)
+		assert.Equal(t, "P7-LIGHT-BLUE", sku)
+
+		sku, err = product.FormatVariantSKU("TEE-{color}", VariantAttributes{"Color": "Light blue"})
+		require.NoError(t, err)
+		assert.Equal(t, "TEE-LIGHT-BLUE", sku)
+
+		_, err = product.FormatVariantSKU("TEE-{Material}", VariantAttributes{"Color": "Light blue"})
+		assert.ErrorContains(t, err, "unknown SKU pattern placeholder")
+	})
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Search highlight fields
+const (
+	SearchFieldName        = "name"
+	SearchFieldDescription = "description"
+	SearchFieldSKUs        = "skus"
+	SearchFieldAttributes  = "attributes"
+	SearchFieldCategory    = "category_name"
+)
+
+// ProductSearchDocument is the denormalized, searchable text of a product.
+// Search backends build their full-text index from these documents.
+type ProductSearchDocument struct {
+	ProductID    uint   `gorm:"primaryKey;autoIncrement:false"`
+	Name         string `gorm:"not null;size:255"`
+	Description  string `gorm:"type:text"`
+	SKUs         string `gorm:"column:skus;type:text"`
+	Attributes   string `gorm:"type:text"`
+	CategoryName string `gorm:"size:255"`
+	UpdatedAt    time.Time
+}
+
+// ProductSearchTerm is a distinct word of the search document of a product. Together the terms
+// are the vocabulary that misspelled search terms are corrected to.
+type ProductSearchTerm struct {
+	ProductID uint   `gorm:"primaryKey;autoIncrement:false"`
+	Term      string `gorm:"primaryKey;size:255;index"`
+}
+
+// NewProductSearchDocument builds the search document of a product.
+// Variant SKUs and attribute values are joined with spaces so each one is indexed as its own terms.
+func NewProductSearchDocument(product *Product, categoryName string) *ProductSearchDocument {
+	skus := make([]string, 0, len(product.Variants))
+	attributes := make([]string, 0)
+	for _, variant := range product.Variants {
+		skus = append(skus, variant.SKU)
+		for _, value := range variant.Attributes.Data() {
+			if value != "" && !slices.Contains(attributes, value) {
+				attributes = append(attributes, value)
+			}
+		}
+	}
+	slices.Sort(attributes)
+
+	return &ProductSearchDocument{
+		ProductID:    product.ID,
+		Name:         product.Name,
+		Description:  product.Description,
+		SKUs:         strings.Join(skus, " "),
+		Attributes:   strings.Join(attributes, " "),
+		CategoryName: categoryName,
+	}
+}
+
+// Fields returns the searchable text of the document keyed by highlight field
+func (d *ProductSearchDocument) Fields() map[string]string {
+	return map[string]string{
+		SearchFieldName:        d.Name,
+		SearchFieldDescription: d.Description,
+		SearchFieldSKUs:        d.SKUs,
+		SearchFieldAttributes:  d.Attributes,
+		SearchFieldCategory:    d.CategoryName,
+	}
+}
@@ -2,7 +2,9 @@ package entity
 
 import (
 	"errors"
+	"fmt"
 	"slices"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/dto"
 	"github.com/zenfulcode/commercify/internal/domain/money"
@@ -22,8 +24,24 @@ type ProductVariant struct {
 	Attributes datatypes.JSONType[VariantAttributes] `gorm:"not null"`
 	IsDefault  bool                                  `gorm:"default:false"`
 	Weight     float64                               `gorm:"default:0"`
+	IsDigital  bool                                  `gorm:"not null;default:false"` // Delivered as downloads, without shipping
 	Price      int64                                 `gorm:"not null"`
+	Prices     datatypes.JSONType[CurrencyAmounts]   `gorm:"not null;default:'{}'"` // Explicit prices in other currencies
 	Images     datatypes.JSONSlice[string]
+
+	// Sale replaces the price while active, CompareAt is shown as the former price ("was 499, now 399").
+	// Both are expressed in the product currency.
+	Sale      ScheduledPrice `gorm:"embedded;embeddedPrefix:sale_"`
+	CompareAt ScheduledPrice `gorm:"embedded;embeddedPrefix:compare_at_"`
+
+	// Values of the admin-defined custom fields by key
+	CustomFields CustomFields `gorm:"type:jsonb;serializer:json"`
+
+	// Components are the variants a bundle variant is made of
+	Components []*BundleComponent `gorm:"foreignKey:BundleVariantID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+
+	// PriceBreaks holds the customer group quantity breaks resolved for display, never persisted
+	PriceBreaks []PriceListEntry `gorm:"-"`
 }
 
 // NewProductVariant creates a new product variant
@@ -49,6 +67,7 @@ func NewProductVariant(sku string, stock int, price int64, weight float64, attri
 		SKU:        sku,
 		Stock:      stock,
 		Attributes: datatypes.NewJSONType(attributes),
+		Prices:     datatypes.NewJSONType(CurrencyAmounts{}),
 		Images:     images,
 		IsDefault:  isDefault,
 		Weight:     weight,
@@ -91,6 +110,107 @@ func (v *ProductVariant) Update(SKU string, stock int, price int64, weight float
 	return updated, nil
 }
 
+// SetPrices replaces the explicit per-currency prices of the variant
+func (v *ProductVariant) SetPrices(prices map[string]int64) error {
+	amounts, err := NewCurrencyAmounts(prices)
+	if err != nil {
+		return err
+	}
+
+	v.Prices = datatypes.NewJSONType(amounts)
+	return nil
+}
+
+// GetExplicitPrice returns the explicit price for a currency, if one is set
+func (v *ProductVariant) GetExplicitPrice(currency string) (int64, bool) {
+	return v.Prices.Data().Get(currency)
+}
+
+// PriceIn returns the variant price in the target currency. The explicit price is used
+// when present, otherwise the base price is converted from the product currency.
+func (v *ProductVariant) PriceIn(productCurrency, targetCurrency *Currency) int64 {
+	if productCurrency.Code == targetCurrency.Code {
+		return v.Price
+	}
+
+	if price, ok := v.GetExplicitPrice(targetCurrency.Code); ok {
+		return price
+	}
+
+	return productCurrency.ConvertAmount(v.Price, targetCurrency)
+}
+
+// SetSalePrice schedules a sale price in the product currency. A nil price ends the sale.
+func (v *ProductVariant) SetSalePrice(price *int64, startsAt, endsAt *time.Time) error {
+	sale, err := NewScheduledPrice(price, startsAt, endsAt)
+	if err != nil {
+		return fmt.Errorf("invalid sale price: %w", err)
+	}
+
+	v.Sale = sale
+	return nil
+}
+
+// SetCompareAtPrice schedules a compare-at price in the product currency. A nil price removes it.
+func (v *ProductVariant) SetCompareAtPrice(price *int64, startsAt, endsAt *time.Time) error {
+	compareAt, err := NewScheduledPrice(price, startsAt, endsAt)
+	if err != nil {
+		return fmt.Errorf("invalid compare-at price: %w", err)
+	}
+
+	v.CompareAt = compareAt
+	return nil
+}
+
+// IsOnSale reports whether a sale price lower than the regular price applies at the given time
+func (v *ProductVariant) IsOnSale(at time.Time) bool {
+	return v.Sale.IsActive(at) && *v.Sale.Price < v.Price
+}
+
+// EffectivePrice returns the price a shopper pays at the given time
+func (v *ProductVariant) EffectivePrice(at time.Time) int64 {
+	if v.IsOnSale(at) {
+		return *v.Sale.Price
+	}
+	return v.Price
+}
+
+// CompareAtPriceAt returns the former price to show next to the effective price. An active
+// compare-at price is used when it is higher, otherwise the regular price during a sale.
+func (v *ProductVariant) CompareAtPriceAt(at time.Time) (int64, bool) {
+	effective := v.EffectivePrice(at)
+	if v.CompareAt.IsActive(at) && *v.CompareAt.Price > effective {
+		return *v.CompareAt.Price, true
+	}
+	if v.IsOnSale(at) {
+		return v.Price, true
+	}
+	return 0, false
+}
+
+// EffectivePriceIn returns the price a shopper pays at the given time in the target currency.
+// A sale lowers an explicit price in the target currency by the same ratio as the base price.
+func (v *ProductVariant) EffectivePriceIn(productCurrency, targetCurrency *Currency, at time.Time) int64 {
+	if !v.IsOnSale(at) {
+		return v.PriceIn(productCurrency, targetCurrency)
+	}
+	return v.scheduledPriceIn(*v.Sale.Price, productCurrency, targetCurrency)
+}
+
+// scheduledPriceIn expresses a sale or compare-at amount of the product currency in the target currency
+func (v *ProductVariant) scheduledPriceIn(amount int64, productCurrency, targetCurrency *Currency) int64 {
+	if productCurrency.Code == targetCurrency.Code {
+		return amount
+	}
+
+	if explicit, ok := v.GetExplicitPrice(targetCurrency.Code); ok && v.Price > 0 {
+		scaled := float64(explicit) * float64(amount) / float64(v.Price)
+		return money.Round(scaled, targetCurrency.Code, targetCurrency.RoundingStrategy)
+	}
+
+	return productCurrency.ConvertAmount(amount, targetCurrency)
+}
+
 // UpdateStock updates the variant's stock
 func (v *ProductVariant) UpdateStock(quantity int) error {
 	newStock := v.Stock + quantity
@@ -102,9 +222,10 @@ func (v *ProductVariant) UpdateStock(quantity int) error {
 	return nil
 }
 
-// IsAvailable checks if the variant is available in the requested quantity
+// IsAvailable checks if the variant is available in the requested quantity.
+// A bundle is available when all of its components are.
 func (v *ProductVariant) IsAvailable(quantity int) bool {
-	return v.Stock >= quantity
+	return v.AvailableStock() >= quantity
 }
 
 func (v *ProductVariant) Name() string {
@@ -126,19 +247,71 @@ func (variant *ProductVariant) ToVariantDTO() *dto.VariantDTO {
 		return nil
 	}
 
+	return variant.toVariantDTOInCurrency(variant.Product.Currency)
+}
+
+// toVariantDTOInCurrency converts the variant to a DTO with its price expressed in the given currency
+func (variant *ProductVariant) toVariantDTOInCurrency(currency string) *dto.VariantDTO {
+	now := time.Now()
+
+	var compareAtPrice *float64
+	if price, ok := variant.CompareAtPriceAt(now); ok {
+		major := money.FromMinor(price, currency)
+		compareAtPrice = &major
+	}
+
 	return &dto.VariantDTO{
-		ID:          variant.ID,
-		ProductID:   variant.ProductID,
-		VariantName: variant.Name(),
-		SKU:         variant.SKU,
-		Stock:       variant.Stock,
-		Attributes:  variant.Attributes.Data(),
-		Images:      variant.Images,
-		IsDefault:   variant.IsDefault,
-		Weight:      variant.Weight,
-		Price:       money.FromCents(variant.Price),
-		Currency:    variant.Product.Currency,
-		CreatedAt:   variant.CreatedAt,
-		UpdatedAt:   variant.UpdatedAt,
+		ID:             variant.ID,
+		ProductID:      variant.ProductID,
+		VariantName:    variant.Name(),
+		SKU:            variant.SKU,
+		Stock:          variant.AvailableStock(),
+		Attributes:     variant.Attributes.Data(),
+		Images:         variant.Images,
+		IsDefault:      variant.IsDefault,
+		Weight:         variant.Weight,
+		IsDigital:      variant.IsDigital,
+		Price:          money.FromMinor(variant.EffectivePrice(now), currency),
+		RegularPrice:   money.FromMinor(variant.Price, currency),
+		CompareAtPrice: compareAtPrice,
+		OnSale:         variant.IsOnSale(now),
+		Sale:           toScheduledPriceDTO(variant.Sale, currency),
+		CompareAt:      toScheduledPriceDTO(variant.CompareAt, currency),
+		Prices:         money.MapFromMinor(variant.Prices.Data()),
+		PriceBreaks:    toPriceBreakDTOs(variant.PriceBreaks, currency),
+		Components:     toBundleComponentDTOs(variant.Components),
+		Currency:       currency,
+		CustomFields:   variant.CustomFields,
+		CreatedAt:      variant.CreatedAt,
+		UpdatedAt:      variant.UpdatedAt,
+	}
+}
+
+// toScheduledPriceDTO converts a scheduled price to a DTO, nil when no price is scheduled
+func toScheduledPriceDTO(scheduled ScheduledPrice, currency string) *dto.ScheduledPriceDTO {
+	if !scheduled.IsSet() {
+		return nil
+	}
+
+	return &dto.ScheduledPriceDTO{
+		Price:    money.FromMinor(*scheduled.Price, currency),
+		StartsAt: scheduled.StartsAt,
+		EndsAt:   scheduled.EndsAt,
+	}
+}
+
+// toPriceBreakDTOs converts price list entries to quantity break DTOs
+func toPriceBreakDTOs(entries []PriceListEntry, currency string) []dto.PriceBreakDTO {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	breaks := make([]dto.PriceBreakDTO, len(entries))
+	for i, entry := range entries {
+		breaks[i] = dto.PriceBreakDTO{
+			MinQuantity: entry.MinQuantity,
+			Price:       money.FromMinor(entry.Price, currency),
+		}
 	}
+	return breaks
 }
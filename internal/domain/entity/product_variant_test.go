@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -281,3 +282,156 @@ func TestProductVariant(t *testing.T) {
 		assert.Contains(t, actualName, " / ")
 	})
 }
+
+func TestProductVariantCurrencyPrices(t *testing.T) {
+	usd := &Currency{Code: "USD", ExchangeRate: 1.0}
+	eur := &Currency{Code: "EUR", ExchangeRate: 0.5}
+	dkk := &Currency{Code: "DKK", ExchangeRate: 7.0}
+
+	t.Run("SetPrices normalizes currency codes", func(t *testing.T) {
+		variant, err := NewProductVariant("SKU-001", 10, 10000, 1.0, VariantAttributes{"color": "red"}, nil, true)
+		require.NoError(t, err)
+
+		err = variant.SetPrices(map[string]int64{"eur": 4500})
+		require.NoError(t, err)
+
+		price, ok := variant.GetExplicitPrice("EUR")
+		assert.True(t, ok)
+		assert.Equal(t, int64(4500), price)
+
+		_, ok = variant.GetExplicitPrice("DKK")
+		assert.False(t, ok)
+	})
+
+	t.Run("SetPrices rejects negative prices", func(t *testing.T) {
+		variant, err := NewProductVariant("SKU-001", 10, 10000, 1.0, VariantAttributes{"color": "red"}, nil, true)
+		require.NoError(t, err)
+
+		err = variant.SetPrices(map[string]int64{"EUR": -1})
+		assert.Error(t, err)
+	})
+
+	t.Run("PriceIn prefers explicit price and falls back to conversion", func(t *testing.T) {
+		variant, err := NewProductVariant("SKU-001", 10, 10000, 1.0, VariantAttributes{"color": "red"}, nil, true)
+		require.NoError(t, err)
+		require.NoError(t, variant.SetPrices(map[string]int64{"EUR": 4500}))
+
+		assert.Equal(t, int64(10000), variant.PriceIn(usd, usd))
+		assert.Equal(t, int64(4500), variant.PriceIn(usd, eur))
+		assert.Equal(t, int64(70000), variant.PriceIn(usd, dkk))
+	})
+
+	t.Run("ToVariantDTO includes explicit prices", func(t *testing.T) {
+		variant, err := NewProductVariant("SKU-001", 10, 10000, 1.0, VariantAttributes{"color": "red"}, nil, true)
+		require.NoError(t, err)
+		require.NoError(t, variant.SetPrices(map[string]int64{"EUR": 4550}))
+
+		dto := variant.ToVariantDTO()
+		assert.Equal(t, map[string]float64{"EUR": 45.50}, dto.Prices)
+	})
+
+	t.Run("Sale price applies within its window", func(t *testing.T) {
+		variant, err := NewProductVariant("SALE-SKU", 10, 49900, 1, nil, nil, true)
+		require.NoError(t, err)
+
+		now := time.Now()
+		startsAt := now.Add(time.Hour)
+		endsAt := now.Add(2 * time.Hour)
+		salePrice := int64(39900)
+		require.NoError(t, variant.SetSalePrice(&salePrice, &startsAt, &endsAt))
+
+		assert.False(t, variant.IsOnSale(now))
+		assert.Equal(t, int64(49900), variant.EffectivePrice(now))
+
+		during := now.Add(90 * time.Minute)
+		assert.True(t, variant.IsOnSale(during))
+		assert.Equal(t, int64(39900), variant.EffectivePrice(during))
+		compareAt, ok := variant.CompareAtPriceAt(during)
+		assert.True(t, ok)
+		assert.Equal(t, int64(49900), compareAt)
+
+		assert.False(t, variant.IsOnSale(endsAt))
+		assert.Equal(t, int64(49900), variant.EffectivePrice(endsAt))
+	})
+
+	t.Run("Sale price above the regular price is ignored", func(t *testing.T) {
+		variant, err := NewProductVariant("SALE-SKU", 10, 1000, 1, nil, nil, true)
+		require.NoError(t, err)
+
+		salePrice := int64(1500)
+		require.NoError(t, variant.SetSalePrice(&salePrice, nil, nil))
+		assert.False(t, variant.IsOnSale(time.Now()))
+		assert.Equal(t, int64(1000), variant.EffectivePrice(time.Now()))
+	})
+
+	t.Run("Scheduled price validation", func(t *testing.T) {
+		variant, err := NewProductVariant("SALE-SKU", 10, 1000, 1, nil, nil, true)
+		require.NoError(t, err)
+
+		negative := int64(-1)
+		assert.Error(t, variant.SetSalePrice(&negative, nil, nil))
+
+		price := int64(500)
+		now := time.Now()
+		earlier := now.Add(-time.Hour)
+		assert.Error(t, variant.SetCompareAtPrice(&price, &now, &earlier))
+
+		require.NoError(t, variant.SetSalePrice(&price, nil, nil))
+		require.NoError(t, variant.SetSalePrice(nil, nil, nil))
+		assert.False(t, variant.Sale.IsSet())
+	})
+
+	t.Run("Compare-at price is shown when higher than the price", func(t *testing.T) {
+		variant, err := NewProductVariant("SALE-SKU", 10, 39900, 1, nil, nil, true)
+		require.NoError(t, err)
+		variant.Product.Currency = "USD"
+
+		compareAtPrice := int64(49900)
+		require.NoError(t, variant.SetCompareAtPrice(&compareAtPrice, nil, nil))
+
+		variantDTO := variant.ToVariantDTO()
+		assert.Equal(t, 399.0, variantDTO.Price)
+		require.NotNil(t, variantDTO.CompareAtPrice)
+		assert.Equal(t, 499.0, *variantDTO.CompareAtPrice)
+		assert.False(t, variantDTO.OnSale)
+		require.NotNil(t, variantDTO.CompareAt)
+		assert.Nil(t, variantDTO.Sale)
+	})
+
+	t.Run("ToVariantDTO resolves the sale price", func(t *testing.T) {
+		variant, err := NewProductVariant("SALE-SKU", 10, 49900, 1, nil, nil, true)
+		require.NoError(t, err)
+		variant.Product.Currency = "USD"
+
+		salePrice := int64(39900)
+		require.NoError(t, variant.SetSalePrice(&salePrice, nil, nil))
+
+		variantDTO := variant.ToVariantDTO()
+		assert.Equal(t, 399.0, variantDTO.Price)
+		assert.Equal(t, 499.0, variantDTO.RegularPrice)
+		require.NotNil(t, variantDTO.CompareAtPrice)
+		assert.Equal(t, 499.0, *variantDTO.CompareAtPrice)
+		assert.True(t, variantDTO.OnSale)
+	})
+
+	t.Run("EffectivePriceIn scales explicit prices during a sale", func(t *testing.T) {
+		variant, err := NewProductVariant("SALE-SKU", 10, 10000, 1, nil, nil, true)
+		require.NoError(t, err)
+		require.NoError(t, variant.SetPrices(map[string]int64{"EUR": 9000}))
+
+		salePrice := int64(8000)
+		require.NoError(t, variant.SetSalePrice(&salePrice, nil, nil))
+
+		usd, err := NewCurrency("USD", "US Dollar", "$", 1, true, true)
+		require.NoError(t, err)
+		eur, err := NewCurrency("EUR", "Euro", "€", 0.9, true, false)
+		require.NoError(t, err)
+		dkk, err := NewCurrency("DKK", "Danish Krone", "kr", 7, true, false)
+		require.NoError(t, err)
+
+		now := time.Now()
+		assert.Equal(t, int64(8000), variant.EffectivePriceIn(usd, usd, now))
+		assert.Equal(t, int64(7200), variant.EffectivePriceIn(usd, eur, now))
+		assert.Equal(t, int64(56000), variant.EffectivePriceIn(usd, dkk, now))
+	})
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// SalesChannel is a storefront through which products are sold
+type SalesChannel string
+
+const (
+	SalesChannelWebshop SalesChannel = "webshop"
+	SalesChannelPOS     SalesChannel = "pos" // Point of sale in physical stores
+)
+
+// SalesChannels lists the sales channels a product can be limited to
+var SalesChannels = []SalesChannel{SalesChannelWebshop, SalesChannelPOS}
+
+// ParseSalesChannel returns the sales channel with the given name
+func ParseSalesChannel(name string) (SalesChannel, error) {
+	channel := SalesChannel(name)
+	if !slices.Contains(SalesChannels, channel) {
+		return "", fmt.Errorf("invalid sales channel %q", name)
+	}
+	return channel, nil
+}
+
+// SetVisibility sets the publishing window and the sales channels of the product.
+// Either end of the window is optional, and no channels make the product visible in all channels.
+func (p *Product) SetVisibility(publishAt, unpublishAt *time.Time, channels []SalesChannel) error {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return errors.New("unpublish time must be after the publish time")
+	}
+
+	var visibleIn []SalesChannel
+	for _, channel := range channels {
+		if _, err := ParseSalesChannel(string(channel)); err != nil {
+			return err
+		}
+		if !slices.Contains(visibleIn, channel) {
+			visibleIn = append(visibleIn, channel)
+		}
+	}
+
+	p.PublishAt = publishAt
+	p.UnpublishAt = unpublishAt
+	p.Channels = visibleIn
+	return nil
+}
+
+// IsPublished reports whether the product is active and inside its publishing window at the given time
+func (p *Product) IsPublished(at time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.PublishAt != nil && at.Before(*p.PublishAt) {
+		return false
+	}
+	return p.UnpublishAt == nil || at.Before(*p.UnpublishAt)
+}
+
+// IsVisible reports whether customers of the sales channel can see and buy the product at the given time
+func (p *Product) IsVisible(channel SalesChannel, at time.Time) bool {
+	if !p.IsPublished(at) {
+		return false
+	}
+	return len(p.Channels) == 0 || slices.Contains(p.Channels, channel)
+}
+
+// channelNames returns the names of the sales channels of the product
+func (p *Product) channelNames() []string {
+	names := make([]string, len(p.Channels))
+	for i, channel := range p.Channels {
+		names[i] = string(channel)
+	}
+	return names
+}
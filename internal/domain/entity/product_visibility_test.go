@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductVisibility(t *testing.T) {
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	tomorrow := now.AddDate(0, 0, 1)
+
+	t.Run("SetVisibility validates the window and channels", func(t *testing.T) {
+		product := &Product{Active: true}
+		assert.ErrorContains(t, product.SetVisibility(&tomorrow, &yesterday, nil), "unpublish time must be after the publish time")
+		assert.ErrorContains(t, product.SetVisibility(nil, nil, []SalesChannel{"marketplace"}), "invalid sales channel")
+
+		require.NoError(t, product.SetVisibility(&yesterday, &tomorrow, []SalesChannel{SalesChannelPOS, SalesChannelPOS}))
+		assert.Equal(t, []SalesChannel{SalesChannelPOS}, product.Channels)
+		assert.Equal(t, &yesterday, product.PublishAt)
+		assert.Equal(t, &tomorrow, product.UnpublishAt)
+	})
+
+	t.Run("IsVisible respects the active flag, the window and the channels", func(t *testing.T) {
+		product := &Product{Active: true}
+		assert.True(t, product.IsVisible(SalesChannelWebshop, now))
+		assert.True(t, product.IsVisible(SalesChannelPOS, now))
+
+		require.NoError(t, product.SetVisibility(&tomorrow, nil, nil))
+		assert.False(t, product.IsVisible(SalesChannelWebshop, now))
+		assert.True(t, product.IsVisible(SalesChannelWebshop, tomorrow))
+
+		require.NoError(t, product.SetVisibility(nil, &tomorrow, nil))
+		assert.True(t, product.IsVisible(SalesChannelWebshop, now))
+		assert.False(t, product.IsVisible(SalesChannelWebshop, tomorrow))
+
+		require.NoError(t, product.SetVisibility(nil, nil, []SalesChannel{SalesChannelPOS}))
+		assert.False(t, product.IsVisible(SalesChannelWebshop, now))
+		assert.True(t, product.IsVisible(SalesChannelPOS, now))
+
+		product.Active = false
+		assert.False(t, product.IsVisible(SalesChannelPOS, now))
+	})
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RefreshToken lets a client get a new access token without signing in again. Refresh tokens rotate:
+// each refresh revokes the token and issues a new one in the same session. Like user tokens,
+// only the SHA-256 hash of the token is stored.
+type RefreshToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	SessionID string    `gorm:"not null;size:32;index"` // Shared by the tokens rotated from the same sign-in
+	TokenHash string    `gorm:"not null;size:64;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// NewRefreshToken creates a refresh token for the user valid for the given duration.
+// An empty session ID starts a new session. It returns the token to store and the plain token to send to the client.
+func NewRefreshToken(userID uint, sessionID string, validFor time.Duration, now time.Time) (*RefreshToken, string, error) {
+	if userID == 0 {
+		return nil, "", errors.New("user ID cannot be zero")
+	}
+	if validFor <= 0 {
+		return nil, "", errors.New("refresh token validity must be positive")
+	}
+
+	if sessionID == "" {
+		raw := make([]byte, 16)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, "", fmt.Errorf("failed to generate session ID: %w", err)
+		}
+		sessionID = hex.EncodeToString(raw)
+	}
+
+	plain, err := newSecretToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return &RefreshToken{
+		UserID:    userID,
+		SessionID: sessionID,
+		TokenHash: HashUserToken(plain),
+		ExpiresAt: now.Add(validFor),
+	}, plain, nil
+}
+
+// IsActive returns true when the token has not been revoked and has not expired
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
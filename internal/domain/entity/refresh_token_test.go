@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshToken(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("NewRefreshToken starts a new session", func(t *testing.T) {
+		token, plain, err := NewRefreshToken(1, "", time.Hour, now)
+		require.NoError(t, err)
+
+		assert.Len(t, token.SessionID, 32)
+		assert.Equal(t, HashUserToken(plain), token.TokenHash)
+		assert.Equal(t, now.Add(time.Hour), token.ExpiresAt)
+		assert.True(t, token.IsActive(now))
+	})
+
+	t.Run("NewRefreshToken continues a session", func(t *testing.T) {
+		first, firstPlain, err := NewRefreshToken(1, "", time.Hour, now)
+		require.NoError(t, err)
+		next, nextPlain, err := NewRefreshToken(1, first.SessionID, time.Hour, now)
+		require.NoError(t, err)
+
+		assert.Equal(t, first.SessionID, next.SessionID)
+		assert.NotEqual(t, firstPlain, nextPlain)
+	})
+
+	t.Run("NewRefreshToken validation", func(t *testing.T) {
+		_, _, err := NewRefreshToken(0, "", time.Hour, now)
+		assert.Error(t, err)
+
+		_, _, err = NewRefreshToken(1, "", 0, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("IsActive", func(t *testing.T) {
+		token, _, err := NewRefreshToken(1, "", time.Hour, now)
+		require.NoError(t, err)
+		assert.False(t, token.IsActive(now.Add(time.Hour)))
+
+		revokedAt := now
+		token.RevokedAt = &revokedAt
+		assert.False(t, token.IsActive(now))
+	})
+}
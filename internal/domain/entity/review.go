@@ -0,0 +1,152 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+)
+
+// Review limits
+const (
+	MinReviewRating      = 1
+	MaxReviewRating      = 5
+	MaxReviewTitleLength = 200
+	MaxReviewBodyLength  = 5000
+)
+
+// ReviewStatus is the moderation state of a review
+type ReviewStatus string
+
+const (
+	ReviewStatusPending  ReviewStatus = "pending"  // Awaiting moderation, not shown publicly
+	ReviewStatusApproved ReviewStatus = "approved" // Shown on the product and counted in its rating
+	ReviewStatusRejected ReviewStatus = "rejected"
+)
+
+// Review is a customer's rating and text of a product, shown once approved by an admin
+type Review struct {
+	ID               uint         `gorm:"primaryKey"`
+	ProductID        uint         `gorm:"not null;uniqueIndex:idx_reviews_product_user;index:idx_reviews_product_status"`
+	UserID           uint         `gorm:"not null;uniqueIndex:idx_reviews_product_user"`
+	OrderID          *uint        `gorm:"index"` // Paid order containing the product, NULL when the order was deleted
+	Rating           int          `gorm:"not null"`
+	Title            string       `gorm:"size:200"`
+	Body             string       `gorm:"type:text"`
+	AuthorName       string       `gorm:"not null;size:100"` // Shown publicly, e.g. "Jane D."
+	VerifiedPurchase bool         `gorm:"not null;default:false"`
+	Status           ReviewStatus `gorm:"not null;size:20;default:pending;index:idx_reviews_product_status"`
+	ModerationNote   string       `gorm:"size:500"` // Reason of a rejection, only shown to admins
+	ModeratedAt      *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// NewReview creates a pending review of a product
+func NewReview(productID, userID uint, rating int, title, body, authorName string) (*Review, error) {
+	if productID == 0 {
+		return nil, errors.New("product ID is required")
+	}
+	if userID == 0 {
+		return nil, errors.New("user ID is required")
+	}
+
+	review := &Review{
+		ProductID:  productID,
+		UserID:     userID,
+		AuthorName: strings.TrimSpace(authorName),
+		Status:     ReviewStatusPending,
+	}
+	if err := review.SetContent(rating, title, body); err != nil {
+		return nil, err
+	}
+	if review.AuthorName == "" {
+		review.AuthorName = "Anonymous"
+	}
+	return review, nil
+}
+
+// SetContent validates and sets the rating and text of the review
+func (r *Review) SetContent(rating int, title, body string) error {
+	if rating < MinReviewRating || rating > MaxReviewRating {
+		return fmt.Errorf("rating must be between %d and %d", MinReviewRating, MaxReviewRating)
+	}
+	title = strings.TrimSpace(title)
+	if len(title) > MaxReviewTitleLength {
+		return fmt.Errorf("review title cannot exceed %d characters", MaxReviewTitleLength)
+	}
+	body = strings.TrimSpace(body)
+	if len(body) > MaxReviewBodyLength {
+		return fmt.Errorf("review text cannot exceed %d characters", MaxReviewBodyLength)
+	}
+
+	r.Rating = rating
+	r.Title = title
+	r.Body = body
+	return nil
+}
+
+// Approve publishes the review
+func (r *Review) Approve(at time.Time) {
+	r.Status = ReviewStatusApproved
+	r.ModerationNote = ""
+	r.ModeratedAt = &at
+}
+
+// Reject hides the review with an optional reason
+func (r *Review) Reject(note string, at time.Time) error {
+	note = strings.TrimSpace(note)
+	if len(note) > 500 {
+		return errors.New("moderation note cannot exceed 500 characters")
+	}
+	r.Status = ReviewStatusRejected
+	r.ModerationNote = note
+	r.ModeratedAt = &at
+	return nil
+}
+
+// IsValidReviewStatus returns true for the known review statuses
+func IsValidReviewStatus(status ReviewStatus) bool {
+	switch status {
+	case ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected:
+		return true
+	}
+	return false
+}
+
+// ReviewAuthorName returns the public name of a reviewer, the first name with the initial of the last name
+func ReviewAuthorName(firstName, lastName string) string {
+	name := strings.TrimSpace(firstName)
+	if last := strings.TrimSpace(lastName); last != "" {
+		name = strings.TrimSpace(name + " " + strings.ToUpper(string([]rune(last)[0])) + ".")
+	}
+	return name
+}
+
+// ToReviewDTO converts the review to a public DTO
+func (r *Review) ToReviewDTO() dto.ReviewDTO {
+	return dto.ReviewDTO{
+		ID:               r.ID,
+		ProductID:        r.ProductID,
+		Rating:           r.Rating,
+		Title:            r.Title,
+		Body:             r.Body,
+		AuthorName:       r.AuthorName,
+		VerifiedPurchase: r.VerifiedPurchase,
+		CreatedAt:        r.CreatedAt,
+	}
+}
+
+// ToAdminReviewDTO converts the review to a DTO with its moderation details
+func (r *Review) ToAdminReviewDTO() dto.AdminReviewDTO {
+	return dto.AdminReviewDTO{
+		ReviewDTO:      r.ToReviewDTO(),
+		UserID:         r.UserID,
+		OrderID:        r.OrderID,
+		Status:         string(r.Status),
+		ModerationNote: r.ModerationNote,
+		ModeratedAt:    r.ModeratedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReview(t *testing.T) {
+	t.Run("NewReview creates a pending review", func(t *testing.T) {
+		review, err := NewReview(1, 2, 4, "  Great fit ", " Comfortable all day ", "Jane D.")
+		require.NoError(t, err)
+		assert.Equal(t, ReviewStatusPending, review.Status)
+		assert.Equal(t, "Great fit", review.Title)
+		assert.Equal(t, "Comfortable all day", review.Body)
+		assert.False(t, review.VerifiedPurchase)
+	})
+
+	t.Run("NewReview validates the review", func(t *testing.T) {
+		_, err := NewReview(0, 2, 4, "", "", "Jane D.")
+		assert.ErrorContains(t, err, "product ID is required")
+		_, err = NewReview(1, 0, 4, "", "", "Jane D.")
+		assert.ErrorContains(t, err, "user ID is required")
+		_, err = NewReview(1, 2, 0, "", "", "Jane D.")
+		assert.ErrorContains(t, err, "rating must be between 1 and 5")
+		_, err = NewReview(1, 2, 6, "", "", "Jane D.")
+		assert.ErrorContains(t, err, "rating must be between 1 and 5")
+		_, err = NewReview(1, 2, 5, strings.Repeat("a", MaxReviewTitleLength+1), "", "Jane D.")
+		assert.ErrorContains(t, err, "review title cannot exceed")
+		_, err = NewReview(1, 2, 5, "", strings.Repeat("a", MaxReviewBodyLength+1), "Jane D.")
+		assert.ErrorContains(t, err, "review text cannot exceed")
+	})
+
+	t.Run("Moderation", func(t *testing.T) {
+		review, err := NewReview(1, 2, 3, "", "", "")
+		require.NoError(t, err)
+		assert.Equal(t, "Anonymous", review.AuthorName)
+
+		now := time.Now()
+		require.NoError(t, review.Reject("Contains a phone number", now))
+		assert.Equal(t, ReviewStatusRejected, review.Status)
+		assert.Equal(t, "Contains a phone number", review.ModerationNote)
+		assert.Equal(t, now, *review.ModeratedAt)
+
+		review.Approve(now)
+		assert.Equal(t, ReviewStatusApproved, review.Status)
+		assert.Empty(t, review.ModerationNote)
+
+		assert.ErrorContains(t, review.Reject(strings.Repeat("a", 501), now), "moderation note cannot exceed")
+	})
+
+	t.Run("ReviewAuthorName", func(t *testing.T) {
+		assert.Equal(t, "Jane D.", ReviewAuthorName("Jane", "doe"))
+		assert.Equal(t, "Jane", ReviewAuthorName("Jane", ""))
+		assert.Equal(t, "Ø.", ReviewAuthorName("", "øster"))
+	})
+}
@@ -0,0 +1,162 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+)
+
+// Permission grants access to a group of admin endpoints
+type Permission string
+
+const (
+	PermissionCatalogRead     Permission = "catalog:read"     // View products, files and catalog exports
+	PermissionCatalogWrite    Permission = "catalog:write"    // Manage products, variants, categories, collections, media and translations
+	PermissionOrdersRead      Permission = "orders:read"      // View all orders and checkouts
+	PermissionOrdersFulfill   Permission = "orders:fulfill"   // Change order status and tracking
+	PermissionOrdersWrite     Permission = "orders:write"     // Edit orders and their discounts, delete checkouts
+	PermissionPaymentsCapture Permission = "payments:capture" // Capture, cancel and approve payments
+	PermissionPaymentsRefund  Permission = "payments:refund"  // Refund payments
+	PermissionCustomersRead   Permission = "customers:read"   // View users, customer groups and price lists
+	PermissionCustomersWrite  Permission = "customers:write"  // Manage customer groups, price lists and customer fields
+	PermissionDiscountsRead   Permission = "discounts:read"   // View discounts and code batches
+	PermissionDiscountsWrite  Permission = "discounts:write"  // Manage discounts and code batches
+	PermissionReviewsModerate Permission = "reviews:moderate" // Approve, reject and delete reviews
+	PermissionDashboardRead   Permission = "dashboard:read"   // View dashboard statistics
+	PermissionSettingsRead    Permission = "settings:read"    // View currencies, payment providers and custom field definitions
+	PermissionSettingsWrite   Permission = "settings:write"   // Manage currencies, payment providers, shipping and custom field definitions
+	PermissionRolesRead       Permission = "roles:read"       // View roles
+	PermissionRolesWrite      Permission = "roles:write"      // Manage roles and assign them to users
+	PermissionAPIKeysRead     Permission = "api-keys:read"    // View API keys
+	PermissionAPIKeysWrite    Permission = "api-keys:write"   // Create and revoke API keys
+)
+
+// AllPermissions lists the permissions in display order
+var AllPermissions = []Permission{
+	PermissionCatalogRead,
+	PermissionCatalogWrite,
+	PermissionOrdersRead,
+	PermissionOrdersFulfill,
+	PermissionOrdersWrite,
+	PermissionPaymentsCapture,
+	PermissionPaymentsRefund,
+	PermissionCustomersRead,
+	PermissionCustomersWrite,
+	PermissionDiscountsRead,
+	PermissionDiscountsWrite,
+	PermissionReviewsModerate,
+	PermissionDashboardRead,
+	PermissionSettingsRead,
+	PermissionSettingsWrite,
+	PermissionRolesRead,
+	PermissionRolesWrite,
+	PermissionAPIKeysRead,
+	PermissionAPIKeysWrite,
+}
+
+// IsValid returns true for the known permissions
+func (p Permission) IsValid() bool {
+	return slices.Contains(AllPermissions, p)
+}
+
+// roleNamePattern matches the names of roles, e.g. "warehouse"
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,49}$`)
+
+// Role is a named set of permissions assigned to users by name. The built-in admin role has all
+// permissions and the built-in user role has none; other roles are defined by admins.
+type Role struct {
+	ID          uint         `gorm:"primaryKey"`
+	Name        string       `gorm:"not null;size:50;uniqueIndex"`
+	Description string       `gorm:"size:255"`
+	Permissions []Permission `gorm:"type:jsonb;serializer:json"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewRole creates a role with the permissions
+func NewRole(name, description string, permissions []Permission) (*Role, error) {
+	if !roleNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid role name %q: use lowercase letters, digits, underscores and hyphens, starting with a letter", name)
+	}
+	if IsBuiltInRole(name) {
+		return nil, fmt.Errorf("role %s is built in", name)
+	}
+
+	role := &Role{Name: name}
+	if err := role.Update(description, permissions); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// Update changes the description and permissions of the role
+func (r *Role) Update(description string, permissions []Permission) error {
+	if len(description) > 255 {
+		return errors.New("role description cannot be longer than 255 characters")
+	}
+
+	granted, err := normalizePermissions(permissions)
+	if err != nil {
+		return err
+	}
+
+	r.Description = description
+	r.Permissions = granted
+	return nil
+}
+
+// normalizePermissions validates permissions and returns them in display order without duplicates
+func normalizePermissions(permissions []Permission) ([]Permission, error) {
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return nil, fmt.Errorf("unknown permission %q", permission)
+		}
+	}
+
+	normalized := make([]Permission, 0, len(permissions))
+	for _, permission := range AllPermissions {
+		if slices.Contains(permissions, permission) {
+			normalized = append(normalized, permission)
+		}
+	}
+	return normalized, nil
+}
+
+// HasPermission returns true when the role grants the permission
+func (r *Role) HasPermission(permission Permission) bool {
+	return slices.Contains(r.Permissions, permission)
+}
+
+// IsBuiltInRole returns true for the admin and user roles, which cannot be changed
+func IsBuiltInRole(name string) bool {
+	return name == string(RoleAdmin) || name == string(RoleUser)
+}
+
+// BuiltInRoles returns the admin and user roles
+func BuiltInRoles() []*Role {
+	return []*Role{
+		{Name: string(RoleAdmin), Description: "Full access", Permissions: slices.Clone(AllPermissions)},
+		{Name: string(RoleUser), Description: "Customer without admin access", Permissions: []Permission{}},
+	}
+}
+
+// ToRoleDTO converts the role to a DTO
+func (r *Role) ToRoleDTO() *dto.RoleDTO {
+	permissions := make([]string, len(r.Permissions))
+	for i, permission := range r.Permissions {
+		permissions[i] = string(permission)
+	}
+	return &dto.RoleDTO{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: permissions,
+		BuiltIn:     IsBuiltInRole(r.Name),
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRole(t *testing.T) {
+	t.Run("NewRole stores the permissions in display order without duplicates", func(t *testing.T) {
+		role, err := NewRole("warehouse", "Picks and ships orders", []Permission{
+			PermissionOrdersFulfill, PermissionOrdersRead, PermissionOrdersFulfill,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "warehouse", role.Name)
+		assert.Equal(t, []Permission{PermissionOrdersRead, PermissionOrdersFulfill}, role.Permissions)
+		assert.True(t, role.HasPermission(PermissionOrdersRead))
+		assert.False(t, role.HasPermission(PermissionPaymentsRefund))
+	})
+
+	t.Run("NewRole validation", func(t *testing.T) {
+		_, err := NewRole("Warehouse", "", nil)
+		assert.Error(t, err)
+
+		_, err = NewRole("", "", nil)
+		assert.Error(t, err)
+
+		_, err = NewRole("admin", "", nil)
+		assert.EqualError(t, err, "role admin is built in")
+
+		_, err = NewRole("support", "", []Permission{"orders:delete"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Update replaces the permissions", func(t *testing.T) {
+		role, err := NewRole("support", "", []Permission{PermissionOrdersRead})
+		require.NoError(t, err)
+
+		require.NoError(t, role.Update("Customer support", []Permission{PermissionPaymentsRefund}))
+		assert.Equal(t, "Customer support", role.Description)
+		assert.Equal(t, []Permission{PermissionPaymentsRefund}, role.Permissions)
+
+		assert.Error(t, role.Update("", []Permission{"refunds"}))
+		assert.Equal(t, []Permission{PermissionPaymentsRefund}, role.Permissions)
+	})
+
+	t.Run("BuiltInRoles", func(t *testing.T) {
+		roles := BuiltInRoles()
+		require.Len(t, roles, 2)
+
+		assert.Equal(t, AllPermissions, roles[0].Permissions)
+		assert.True(t, roles[0].ToRoleDTO().BuiltIn)
+		assert.Empty(t, roles[1].Permissions)
+		assert.True(t, IsBuiltInRole(string(RoleUser)))
+		assert.False(t, IsBuiltInRole("warehouse"))
+	})
+}
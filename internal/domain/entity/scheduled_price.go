@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// ScheduledPrice is a price in minor units that applies within an optional time window.
+// A nil start applies immediately and a nil end never expires.
+type ScheduledPrice struct {
+	Price    *int64
+	StartsAt *time.Time
+	EndsAt   *time.Time
+}
+
+// NewScheduledPrice creates a scheduled price. A nil price clears the schedule.
+func NewScheduledPrice(price *int64, startsAt, endsAt *time.Time) (ScheduledPrice, error) {
+	if price == nil {
+		return ScheduledPrice{}, nil
+	}
+	if *price < 0 {
+		return ScheduledPrice{}, errors.New("scheduled price cannot be negative")
+	}
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return ScheduledPrice{}, errors.New("scheduled price must end after it starts")
+	}
+
+	return ScheduledPrice{Price: price, StartsAt: startsAt, EndsAt: endsAt}, nil
+}
+
+// IsSet reports whether a price is scheduled
+func (s ScheduledPrice) IsSet() bool {
+	return s.Price != nil
+}
+
+// IsActive reports whether the price applies at the given time
+func (s ScheduledPrice) IsActive(at time.Time) bool {
+	if s.Price == nil {
+		return false
+	}
+	if s.StartsAt != nil && at.Before(*s.StartsAt) {
+		return false
+	}
+	if s.EndsAt != nil && !at.Before(*s.EndsAt) {
+		return false
+	}
+	return true
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/zenfulcode/commercify/internal/domain/dto"
 	"github.com/zenfulcode/commercify/internal/domain/money"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -21,6 +22,12 @@ type ShippingRate struct {
 	WeightBasedRates      []WeightBasedRate `gorm:"foreignKey:ShippingRateID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
 	ValueBasedRates       []ValueBasedRate  `gorm:"foreignKey:ShippingRateID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
 	Active                bool              `gorm:"default:true"`
+	// CurrencyBaseRates holds explicit base rates in cents per currency
+	CurrencyBaseRates datatypes.JSONType[CurrencyAmounts] `gorm:"not null;default:'{}'"`
+
+	// conversion converts the rates and order values, which are in the default currency, into
+	// the currency of a checkout; set by the use case with SetCurrencyConversion
+	conversion currencyConversion
 }
 
 // WeightBasedRate represents additional costs based on order weight
@@ -78,11 +85,12 @@ func NewShippingRate(
 	}
 
 	return &ShippingRate{
-		ShippingMethodID: shippingMethodID,
-		ShippingZoneID:   shippingZoneID,
-		BaseRate:         baseRate,
-		MinOrderValue:    minOrderValue,
-		Active:           true,
+		ShippingMethodID:  shippingMethodID,
+		ShippingZoneID:    shippingZoneID,
+		BaseRate:          baseRate,
+		MinOrderValue:     minOrderValue,
+		Active:            true,
+		CurrencyBaseRates: datatypes.NewJSONType(CurrencyAmounts{}),
 	}, nil
 }
 
@@ -113,33 +121,79 @@ func (r *ShippingRate) SetFreeShippingThreshold(threshold *int64) {
 
 }
 
+// SetCurrencyBaseRates replaces the explicit per-currency base rates
+func (r *ShippingRate) SetCurrencyBaseRates(rates map[string]int64) error {
+	amounts, err := NewCurrencyAmounts(rates)
+	if err != nil {
+		return err
+	}
+
+	r.CurrencyBaseRates = datatypes.NewJSONType(amounts)
+	return nil
+}
+
+// SetCurrencyConversion sets the default currency of the rates and the currency of the checkout
+// they are calculated for, so rates without an explicit value for that currency are converted
+func (r *ShippingRate) SetCurrencyConversion(baseCurrency, checkoutCurrency *Currency) {
+	r.conversion = currencyConversion{base: baseCurrency, target: checkoutCurrency}
+}
+
+// SetBaseCurrency sets the default currency in which the rates and order values are configured,
+// without converting them into another currency
+func (r *ShippingRate) SetBaseCurrency(baseCurrency *Currency) {
+	r.conversion = currencyConversion{base: baseCurrency}
+}
+
+// BaseCurrencyCode returns the code of the default currency the rates are configured in, or an
+// empty string when none is set
+func (r *ShippingRate) BaseCurrencyCode() string {
+	return r.conversion.baseCode()
+}
+
+// BaseRateFor returns the base rate for the given currency, falling back to the default base rate
+// converted into that currency
+func (r *ShippingRate) BaseRateFor(currency string) int64 {
+	if rate, ok := r.CurrencyBaseRates.Data().Get(currency); ok && currency != "" {
+		return rate
+	}
+	return r.conversion.amountIn(r.BaseRate, currency)
+}
+
 // CalculateShippingCost calculates the shipping cost for an order
 func (r *ShippingRate) CalculateShippingCost(orderValue int64, weight float64) (int64, error) {
+	return r.CalculateShippingCostForCurrency(orderValue, weight, "")
+}
+
+// CalculateShippingCostForCurrency calculates the shipping cost for an order in the given currency,
+// using the explicit base rate for that currency when one is set. Thresholds and surcharges are
+// converted from the default currency when a currency conversion is set.
+func (r *ShippingRate) CalculateShippingCostForCurrency(orderValue int64, weight float64, currency string) (int64, error) {
 	// Check if order qualifies for free shipping
-	if r.FreeShippingThreshold != nil && orderValue >= *r.FreeShippingThreshold {
+	if r.FreeShippingThreshold != nil && orderValue >= r.conversion.amountIn(*r.FreeShippingThreshold, currency) {
 		return 0, nil // Free shipping applies
 	}
 
 	// Check if order meets minimum value
-	if orderValue < r.MinOrderValue {
+	if orderValue < r.conversion.amountIn(r.MinOrderValue, currency) {
 		return 0, errors.New("order value does not meet minimum requirement")
 	}
 
 	// Start with the base rate
-	cost := r.BaseRate
+	cost := r.BaseRateFor(currency)
 
 	// Apply weight-based rates
 	for _, wbr := range r.WeightBasedRates {
 		if weight >= wbr.MinWeight && weight <= wbr.MaxWeight {
-			cost += wbr.Rate
+			cost += r.conversion.amountIn(wbr.Rate, currency)
 			break // Only apply the first matching weight rate
 		}
 	}
 
 	// Apply value-based rates
 	for _, vbr := range r.ValueBasedRates {
-		if orderValue >= vbr.MinOrderValue && orderValue <= vbr.MaxOrderValue {
-			cost += vbr.Rate
+		if orderValue >= r.conversion.amountIn(vbr.MinOrderValue, currency) &&
+			orderValue <= r.conversion.amountIn(vbr.MaxOrderValue, currency) {
+			cost += r.conversion.amountIn(vbr.Rate, currency)
 			break // Only apply the first matching value rate
 		}
 	}
@@ -164,29 +218,36 @@ func (r *ShippingRate) Deactivate() {
 }
 
 func (s *ShippingOption) ToShippingOptionDTO() *dto.ShippingOptionDTO {
+	return s.ToShippingOptionDTOInCurrency("")
+}
+
+// ToShippingOptionDTOInCurrency converts the option to a DTO, expressing the cost in the given currency
+func (s *ShippingOption) ToShippingOptionDTOInCurrency(currency string) *dto.ShippingOptionDTO {
 	return &dto.ShippingOptionDTO{
 		ShippingRateID:        s.ShippingRateID,
 		ShippingMethodID:      s.ShippingMethodID,
 		Name:                  s.Name,
 		Description:           s.Description,
 		EstimatedDeliveryDays: s.EstimatedDeliveryDays,
-		Cost:                  money.FromCents(s.Cost),
+		Cost:                  money.FromMinor(s.Cost, currency),
 		FreeShipping:          s.FreeShipping,
 	}
 }
 
 func (r *ShippingRate) ToShippingRateDTO() *dto.ShippingRateDTO {
+	currency := r.BaseCurrencyCode()
 	var shippingRateDto = dto.ShippingRateDTO{
-		ID:               r.ID,
-		ShippingMethodID: r.ShippingMethodID,
-		ShippingZoneID:   r.ShippingZoneID,
-		BaseRate:         money.FromCents(r.BaseRate),
-		MinOrderValue:    money.FromCents(r.MinOrderValue),
-		Active:           r.Active,
+		ID:                r.ID,
+		ShippingMethodID:  r.ShippingMethodID,
+		ShippingZoneID:    r.ShippingZoneID,
+		BaseRate:          money.FromMinor(r.BaseRate, currency),
+		MinOrderValue:     money.FromMinor(r.MinOrderValue, currency),
+		CurrencyBaseRates: money.MapFromMinor(r.CurrencyBaseRates.Data()),
+		Active:            r.Active,
 	}
 
 	if r.FreeShippingThreshold != nil {
-		shippingRateDto.FreeShippingThreshold = money.FromCents(*r.FreeShippingThreshold)
+		shippingRateDto.FreeShippingThreshold = money.FromMinor(*r.FreeShippingThreshold, currency)
 	}
 	if r.ShippingMethod != nil {
 		shippingRateDto.ShippingMethod = r.ShippingMethod.ToShippingMethodDTO()
@@ -201,27 +262,30 @@ func (r *ShippingRate) ToShippingRateDTO() *dto.ShippingRateDTO {
 				ID:        wbr.ID,
 				MinWeight: wbr.MinWeight,
 				MaxWeight: wbr.MaxWeight,
-				Rate:      money.FromCents(wbr.Rate),
+				Rate:      money.FromMinor(wbr.Rate, currency),
 			}
 		}
 	}
 	return &shippingRateDto
 }
 
+// ToWeightBasedRateDTO converts the rate to a DTO, in the default currency set on its shipping rate
 func (w *WeightBasedRate) ToWeightBasedRateDTO() *dto.WeightBasedRateDTO {
 	return &dto.WeightBasedRateDTO{
 		ID:        w.ID,
 		MinWeight: w.MinWeight,
 		MaxWeight: w.MaxWeight,
-		Rate:      money.FromCents(w.Rate),
+		Rate:      money.FromMinor(w.Rate, w.ShippingRate.BaseCurrencyCode()),
 	}
 }
 
+// ToValueBasedRateDTO converts the rate to a DTO, in the default currency set on its shipping rate
 func (v *ValueBasedRate) ToValueBasedRateDTO() *dto.ValueBasedRateDTO {
+	currency := v.ShippingRate.BaseCurrencyCode()
 	return &dto.ValueBasedRateDTO{
 		ID:            v.ID,
-		MinOrderValue: money.FromCents(v.MinOrderValue),
-		MaxOrderValue: money.FromCents(v.MaxOrderValue),
-		Rate:          money.FromCents(v.Rate),
+		MinOrderValue: money.FromMinor(v.MinOrderValue, currency),
+		MaxOrderValue: money.FromMinor(v.MaxOrderValue, currency),
+		Rate:          money.FromMinor(v.Rate, currency),
 	}
 }
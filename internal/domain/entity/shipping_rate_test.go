@@ -64,6 +64,23 @@ func TestShippingRateDTOConversions(t *testing.T) {
 		assert.Equal(t, float64(7.99), dto.Rate)
 	})
 
+	t.Run("DTOs use the minor units of the default currency", func(t *testing.T) {
+		shippingRate, err := NewShippingRate(1, 1, 800, 5000)
+		require.NoError(t, err)
+		shippingRate.SetBaseCurrency(&Currency{Code: "JPY", ExchangeRate: 1})
+		shippingRate.WeightBasedRates = []WeightBasedRate{{MinWeight: 0, MaxWeight: 5, Rate: 300}}
+
+		dto := shippingRate.ToShippingRateDTO()
+		assert.Equal(t, 800.0, dto.BaseRate)
+		assert.Equal(t, 5000.0, dto.MinOrderValue)
+		assert.Equal(t, 300.0, dto.WeightBasedRates[0].Rate)
+
+		valueRate := &ValueBasedRate{ShippingRate: *shippingRate, MaxOrderValue: 10000, Rate: 200}
+		valueDTO := valueRate.ToValueBasedRateDTO()
+		assert.Equal(t, 10000.0, valueDTO.MaxOrderValue)
+		assert.Equal(t, 200.0, valueDTO.Rate)
+	})
+
 	t.Run("ToShippingOptionDTO", func(t *testing.T) {
 		shippingOption := &ShippingOption{
 			ShippingRateID:        1,
@@ -83,3 +100,50 @@ func TestShippingRateDTOConversions(t *testing.T) {
 		assert.Equal(t, 7, dto.EstimatedDeliveryDays)
 	})
 }
+
+func TestShippingRateCurrencyBaseRates(t *testing.T) {
+	rate, err := NewShippingRate(1, 1, 999, 0)
+	require.NoError(t, err)
+	require.NoError(t, rate.SetCurrencyBaseRates(map[string]int64{"dkk": 4900}))
+
+	t.Run("uses explicit base rate for currency", func(t *testing.T) {
+		cost, err := rate.CalculateShippingCostForCurrency(10000, 1.0, "DKK")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4900), cost)
+	})
+
+	t.Run("falls back to default base rate", func(t *testing.T) {
+		cost, err := rate.CalculateShippingCostForCurrency(10000, 1.0, "EUR")
+		require.NoError(t, err)
+		assert.Equal(t, int64(999), cost)
+
+		cost, err = rate.CalculateShippingCost(10000, 1.0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(999), cost)
+	})
+
+	t.Run("converts default rates and thresholds into the checkout currency", func(t *testing.T) {
+		threshold := int64(10000)
+		converted, err := NewShippingRate(1, 1, 999, 0)
+		require.NoError(t, err)
+		converted.SetFreeShippingThreshold(&threshold)
+		converted.SetCurrencyConversion(
+			&Currency{Code: "USD", ExchangeRate: 1},
+			&Currency{Code: "DKK", ExchangeRate: 7},
+		)
+
+		cost, err := converted.CalculateShippingCostForCurrency(20000, 1.0, "DKK")
+		require.NoError(t, err)
+		assert.Equal(t, int64(6993), cost)
+
+		// The free shipping threshold of 100 USD is 700 DKK
+		cost, err = converted.CalculateShippingCostForCurrency(70000, 1.0, "DKK")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), cost)
+	})
+
+	t.Run("ToShippingRateDTO includes currency base rates", func(t *testing.T) {
+		dto := rate.ToShippingRateDTO()
+		assert.Equal(t, map[string]float64{"DKK": 49.0}, dto.CurrencyBaseRates)
+	})
+}
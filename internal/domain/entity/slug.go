@@ -0,0 +1,153 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Slug resource types
+const (
+	SlugResourceProduct    = "product"
+	SlugResourceCategory   = "category"
+	SlugResourceCollection = "collection"
+)
+
+// MaxSlugLength is the maximum length of a slug
+const MaxSlugLength = 200
+
+// SlugRedirect maps a former slug of a product, category or collection to the resource, so old URLs keep working
+type SlugRedirect struct {
+	ID           uint      `gorm:"primaryKey"`
+	ResourceType string    `gorm:"not null;size:20;uniqueIndex:idx_slug_redirects_type_slug"`
+	Slug         string    `gorm:"not null;size:200;uniqueIndex:idx_slug_redirects_type_slug"`
+	ResourceID   uint      `gorm:"not null;index"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+// SEOMetadata contains the search engine metadata of a storefront page
+type SEOMetadata struct {
+	MetaTitle       string `gorm:"size:255"`
+	MetaDescription string `gorm:"size:500"`
+	CanonicalURL    string `gorm:"size:2048"`
+}
+
+// Validate checks the lengths of the metadata
+func (m SEOMetadata) Validate() error {
+	if len(m.MetaTitle) > 255 {
+		return errors.New("meta title cannot exceed 255 characters")
+	}
+	if len(m.MetaDescription) > 500 {
+		return errors.New("meta description cannot exceed 500 characters")
+	}
+	if len(m.CanonicalURL) > 2048 {
+		return errors.New("canonical URL cannot exceed 2048 characters")
+	}
+	return nil
+}
+
+// ToSEODTO converts the metadata to a DTO
+func (m SEOMetadata) ToSEODTO() dto.SEODTO {
+	return dto.SEODTO{
+		MetaTitle:       m.MetaTitle,
+		MetaDescription: m.MetaDescription,
+		CanonicalURL:    m.CanonicalURL,
+	}
+}
+
+// slugTransliterations spells out letters that do not decompose into a base letter and marks
+var slugTransliterations = map[rune]string{
+	'æ': "ae", 'ø': "o", 'å': "a", 'ß': "ss", 'œ': "oe", 'ð': "d", 'þ': "th", 'đ': "d", 'ł': "l", 'ı': "i", '&': " and ",
+}
+
+// slugPattern matches valid slugs
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Slugify creates a URL slug from text, transliterating accented letters to ASCII,
+// e.g. "Smørrebrød & Café" becomes "smorrebrod-and-cafe"
+func Slugify(text string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range norm.NFKD.String(strings.ToLower(text)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if s, ok := slugTransliterations[r]; ok {
+			for _, c := range s {
+				dash = writeSlugRune(&b, c, dash)
+			}
+			continue
+		}
+		dash = writeSlugRune(&b, r, dash)
+	}
+
+	slug := strings.TrimSuffix(b.String(), "-")
+	if len(slug) > MaxSlugLength {
+		slug = strings.TrimSuffix(slug[:MaxSlugLength], "-")
+	}
+	return slug
+}
+
+// writeSlugRune writes an ASCII letter or digit, or a single dash between words
+func writeSlugRune(b *strings.Builder, r rune, dash bool) bool {
+	if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+		if dash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteRune(r)
+		return false
+	}
+	return true
+}
+
+// ValidateSlug checks that a slug only contains lowercase letters, digits and single dashes
+func ValidateSlug(slug string) error {
+	if len(slug) > MaxSlugLength {
+		return fmt.Errorf("slug cannot exceed %d characters", MaxSlugLength)
+	}
+	if !slugPattern.MatchString(slug) {
+		return fmt.Errorf("invalid slug %q: use lowercase letters, digits and dashes", slug)
+	}
+	return nil
+}
+
+// NormalizeSlug trims and validates a slug given by a user. An empty slug is allowed and
+// means the slug is generated from the name when the resource is saved.
+func NormalizeSlug(slug string) (string, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return "", nil
+	}
+	if err := ValidateSlug(slug); err != nil {
+		return "", err
+	}
+	return slug, nil
+}
+
+// UniqueSlug returns the base slug, or the base slug with the lowest numeric suffix
+// starting at 2 that is not taken
+func UniqueSlug(base string, taken func(slug string) (bool, error)) (string, error) {
+	if base == "" {
+		return "", errors.New("slug cannot be empty")
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		exists, err := taken(slug)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+
+		suffix := fmt.Sprintf("-%d", i)
+		slug = strings.TrimSuffix(base[:min(len(base), MaxSlugLength-len(suffix))], "-") + suffix
+	}
+}
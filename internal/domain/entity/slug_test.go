@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlug(t *testing.T) {
+	t.Run("Slugify transliterates and joins words", func(t *testing.T) {
+		assert.Equal(t, "smorrebrod-and-cafe", Slugify("Smørrebrød & Café"))
+		assert.Equal(t, "strasse-creme-brulee", Slugify("  Straße: Crème brûlée!! "))
+		assert.Equal(t, "t-shirt-2-pack", Slugify("T-Shirt (2-pack)"))
+		assert.Equal(t, "", Slugify("日本"))
+		assert.Len(t, Slugify(strings.Repeat("abc ", 100)), MaxSlugLength-1)
+	})
+
+	t.Run("ValidateSlug", func(t *testing.T) {
+		assert.NoError(t, ValidateSlug("summer-tee-2"))
+		assert.Error(t, ValidateSlug("Summer-Tee"))
+		assert.Error(t, ValidateSlug("summer--tee"))
+		assert.Error(t, ValidateSlug("-tee"))
+
+		slug, err := NormalizeSlug("  ")
+		require.NoError(t, err)
+		assert.Empty(t, slug)
+	})
+
+	t.Run("UniqueSlug appends the lowest free suffix", func(t *testing.T) {
+		taken := map[string]bool{"tee": true, "tee-2": true}
+		slug, err := UniqueSlug("tee", func(s string) (bool, error) { return taken[s], nil })
+		require.NoError(t, err)
+		assert.Equal(t, "tee-3", slug)
+
+		long := strings.Repeat("a", MaxSlugLength)
+		slug, err = UniqueSlug(long, func(s string) (bool, error) { return s == long, nil })
+		require.NoError(t, err)
+		assert.Len(t, slug, MaxSlugLength)
+		assert.True(t, strings.HasSuffix(slug, "-2"))
+	})
+
+	t.Run("SEO metadata lengths are validated", func(t *testing.T) {
+		assert.NoError(t, SEOMetadata{MetaTitle: "Tee"}.Validate())
+		assert.Error(t, SEOMetadata{MetaDescription: strings.Repeat("a", 501)}.Validate())
+	})
+}
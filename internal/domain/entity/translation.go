@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+)
+
+// Translation resource types
+const (
+	TranslationResourceProduct        = "product"
+	TranslationResourceCategory       = "category"
+	TranslationResourceShippingMethod = "shipping_method"
+)
+
+// MaxLocaleLength is the maximum length of a locale, e.g. "da" or "pt-br"
+const MaxLocaleLength = 10
+
+// Translation holds the name and description of a product, category or shipping method in
+// another locale than the default locale. The fields of the resource itself are in the default locale.
+type Translation struct {
+	ID           uint   `gorm:"primaryKey"`
+	ResourceType string `gorm:"not null;size:20;uniqueIndex:idx_translations_resource_locale"`
+	ResourceID   uint   `gorm:"not null;uniqueIndex:idx_translations_resource_locale"`
+	Locale       string `gorm:"not null;size:10;uniqueIndex:idx_translations_resource_locale"`
+	Name         string `gorm:"not null;size:255"`
+	Description  string `gorm:"type:text"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// IsValidTranslationResource returns true for the resource types that can be translated
+func IsValidTranslationResource(resourceType string) bool {
+	return resourceType == TranslationResourceProduct ||
+		resourceType == TranslationResourceCategory ||
+		resourceType == TranslationResourceShippingMethod
+}
+
+// NewTranslation creates a translation of a resource in a locale
+func NewTranslation(resourceType string, resourceID uint, locale, name, description string) (*Translation, error) {
+	if !IsValidTranslationResource(resourceType) {
+		return nil, fmt.Errorf("invalid translation resource type %q", resourceType)
+	}
+	if resourceID == 0 {
+		return nil, errors.New("resource ID is required")
+	}
+
+	locale = NormalizeLocale(locale)
+	if locale == "" {
+		return nil, errors.New("locale is required")
+	}
+	if len(locale) > MaxLocaleLength {
+		return nil, fmt.Errorf("locale cannot exceed %d characters", MaxLocaleLength)
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("translated name for locale %s cannot be empty", locale)
+	}
+	if len(name) > 255 {
+		return nil, errors.New("translated name cannot exceed 255 characters")
+	}
+	if len(description) > 65535 {
+		return nil, errors.New("translated description cannot exceed 65535 characters")
+	}
+
+	return &Translation{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Locale:       locale,
+		Name:         name,
+		Description:  description,
+	}, nil
+}
+
+// NormalizeLocale lowercases a language tag and uses hyphens, e.g. "da_DK" becomes "da-dk"
+func NormalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// MatchLocale returns the supported locale for a requested language tag, or an empty string.
+// A tag matches a supported locale exactly or by its language, so "da-DK" matches "da" and
+// "en" matches "en-us". Norwegian ("no" and "nn") matches Norwegian Bokmål ("nb").
+func MatchLocale(requested string, supported []string) string {
+	requested = NormalizeLocale(requested)
+	if requested == "" {
+		return ""
+	}
+	if slices.Contains(supported, requested) {
+		return requested
+	}
+
+	language, _, _ := strings.Cut(requested, "-")
+	candidates := []string{language}
+	if language == "no" || language == "nn" {
+		candidates = append(candidates, "nb")
+	}
+	for _, candidate := range candidates {
+		for _, locale := range supported {
+			if locale == candidate || strings.HasPrefix(locale, candidate+"-") {
+				return locale
+			}
+		}
+	}
+	return ""
+}
+
+// ToTranslationDTO converts the translation to a DTO
+func (t *Translation) ToTranslationDTO() dto.TranslationDTO {
+	return dto.TranslationDTO{
+		Locale:      t.Locale,
+		Name:        t.Name,
+		Description: t.Description,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
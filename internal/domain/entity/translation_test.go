@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslation(t *testing.T) {
+	t.Run("NewTranslation validates the resource, locale and name", func(t *testing.T) {
+		_, err := NewTranslation("order", 1, "da", "Skjorte", "")
+		assert.ErrorContains(t, err, "invalid translation resource type")
+		_, err = NewTranslation(TranslationResourceProduct, 0, "da", "Skjorte", "")
+		assert.ErrorContains(t, err, "resource ID is required")
+		_, err = NewTranslation(TranslationResourceProduct, 1, " ", "Skjorte", "")
+		assert.ErrorContains(t, err, "locale is required")
+		_, err = NewTranslation(TranslationResourceProduct, 1, "da", " ", "")
+		assert.ErrorContains(t, err, "translated name for locale da cannot be empty")
+
+		translation, err := NewTranslation(TranslationResourceProduct, 1, "pt_BR", " Camisa ", "De linho")
+		require.NoError(t, err)
+		assert.Equal(t, "pt-br", translation.Locale)
+		assert.Equal(t, "Camisa", translation.Name)
+		assert.Equal(t, "De linho", translation.Description)
+	})
+
+	t.Run("MatchLocale matches by exact tag, then by language", func(t *testing.T) {
+		supported := []string{"en", "da", "nb", "pt-br"}
+
+		assert.Equal(t, "da", MatchLocale("da", supported))
+		assert.Equal(t, "da", MatchLocale("da-DK", supported))
+		assert.Equal(t, "pt-br", MatchLocale("pt_BR", supported))
+		assert.Equal(t, "pt-br", MatchLocale("pt", supported))
+		assert.Equal(t, "nb", MatchLocale("no", supported))
+		assert.Equal(t, "nb", MatchLocale("nn-NO", supported))
+		assert.Empty(t, MatchLocale("sv", supported))
+		assert.Empty(t, MatchLocale("", supported))
+	})
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"errors"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/dto"
 	"golang.org/x/crypto/bcrypt"
@@ -16,6 +17,20 @@ type User struct {
 	FirstName string `gorm:"not null;size:100"`
 	LastName  string `gorm:"not null;size:100"`
 	Role      string `gorm:"not null;size:50;default:'user'"`
+
+	// Whether the user confirmed owning the email address, with the time of the confirmation
+	EmailVerified   bool `gorm:"not null;default:false"`
+	EmailVerifiedAt *time.Time
+
+	// Version of the access tokens of the user; incrementing it signs the user out of all sessions
+	TokenVersion uint `gorm:"not null;default:0"`
+
+	// Customer group used to resolve B2B prices, NULL for regular customers
+	CustomerGroupID *uint          `gorm:"index"`
+	CustomerGroup   *CustomerGroup `gorm:"foreignKey:CustomerGroupID;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+
+	// Values of the admin-defined custom fields by key
+	CustomFields CustomFields `gorm:"type:jsonb;serializer:json"`
 }
 
 // UserRole defines the available roles for users
@@ -91,6 +106,26 @@ func (u *User) UpdatePassword(password string) error {
 	return nil
 }
 
+// VerifyEmail marks the email address of the user as verified
+func (u *User) VerifyEmail(at time.Time) {
+	if u.EmailVerified {
+		return
+	}
+	u.EmailVerified = true
+	u.EmailVerifiedAt = &at
+}
+
+// RevokeSessions invalidates all access tokens issued to the user
+func (u *User) RevokeSessions() {
+	u.TokenVersion++
+}
+
+// SetCustomerGroup assigns the user to a customer group, or removes the assignment when groupID is nil
+func (u *User) SetCustomerGroup(groupID *uint) {
+	u.CustomerGroupID = groupID
+	u.CustomerGroup = nil
+}
+
 // FullName returns the user's full name
 func (u *User) FullName() string {
 	return u.FirstName + " " + u.LastName
@@ -103,10 +138,13 @@ func (u *User) IsAdmin() bool {
 
 func (u *User) ToUserDTO() *dto.UserDTO {
 	return &dto.UserDTO{
-		ID:        u.ID,
-		Email:     u.Email,
-		FirstName: u.FirstName,
-		LastName:  u.LastName,
-		Role:      u.Role,
+		ID:              u.ID,
+		Email:           u.Email,
+		FirstName:       u.FirstName,
+		LastName:        u.LastName,
+		Role:            u.Role,
+		EmailVerified:   u.EmailVerified,
+		CustomerGroupID: u.CustomerGroupID,
+		CustomFields:    u.CustomFields,
 	}
 }
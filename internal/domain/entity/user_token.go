@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// UserTokenPurpose is what a user token can be used for
+type UserTokenPurpose string
+
+const (
+	UserTokenPasswordReset     UserTokenPurpose = "password_reset"
+	UserTokenEmailVerification UserTokenPurpose = "email_verification"
+)
+
+// secretTokenBytes is the number of random bytes of user and refresh tokens
+const secretTokenBytes = 32
+
+// UserToken is a single-use, expiring token sent to the email address of a user.
+// Only the SHA-256 hash of the token is stored, so a leaked database does not reveal usable tokens.
+type UserToken struct {
+	ID        uint             `gorm:"primaryKey"`
+	UserID    uint             `gorm:"not null;index"`
+	User      User             `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	Purpose   UserTokenPurpose `gorm:"not null;size:30;index"`
+	TokenHash string           `gorm:"not null;size:64;uniqueIndex"`
+	ExpiresAt time.Time        `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NewUserToken creates a token for the user valid for the given duration.
+// It returns the token to store and the plain token to send to the user.
+func NewUserToken(userID uint, purpose UserTokenPurpose, validFor time.Duration, now time.Time) (*UserToken, string, error) {
+	if userID == 0 {
+		return nil, "", errors.New("user ID cannot be zero")
+	}
+	if purpose != UserTokenPasswordReset && purpose != UserTokenEmailVerification {
+		return nil, "", fmt.Errorf("invalid user token purpose %q", purpose)
+	}
+	if validFor <= 0 {
+		return nil, "", errors.New("user token validity must be positive")
+	}
+
+	plain, err := newSecretToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate user token: %w", err)
+	}
+
+	return &UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: HashUserToken(plain),
+		ExpiresAt: now.Add(validFor),
+	}, plain, nil
+}
+
+// newSecretToken returns a random, hex-encoded token
+func newSecretToken() (string, error) {
+	raw := make([]byte, secretTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// HashUserToken returns the stored hash of a plain user or refresh token
+func HashUserToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsUsable returns true when the token has not been used and has not expired
+func (t *UserToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
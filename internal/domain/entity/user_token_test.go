@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserToken(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("NewUserToken stores the hash of the plain token", func(t *testing.T) {
+		token, plain, err := NewUserToken(1, UserTokenPasswordReset, time.Hour, now)
+		require.NoError(t, err)
+
+		assert.Len(t, plain, 64)
+		assert.NotEqual(t, plain, token.TokenHash)
+		assert.Equal(t, HashUserToken(plain), token.TokenHash)
+		assert.Equal(t, now.Add(time.Hour), token.ExpiresAt)
+		assert.Equal(t, UserTokenPasswordReset, token.Purpose)
+
+		_, other, err := NewUserToken(1, UserTokenPasswordReset, time.Hour, now)
+		require.NoError(t, err)
+		assert.NotEqual(t, plain, other)
+	})
+
+	t.Run("NewUserToken validation", func(t *testing.T) {
+		_, _, err := NewUserToken(0, UserTokenPasswordReset, time.Hour, now)
+		assert.Error(t, err)
+
+		_, _, err = NewUserToken(1, UserTokenPurpose("login"), time.Hour, now)
+		assert.Error(t, err)
+
+		_, _, err = NewUserToken(1, UserTokenEmailVerification, 0, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("IsUsable", func(t *testing.T) {
+		token, _, err := NewUserToken(1, UserTokenEmailVerification, time.Hour, now)
+		require.NoError(t, err)
+
+		assert.True(t, token.IsUsable(now))
+		assert.False(t, token.IsUsable(now.Add(time.Hour)))
+
+		usedAt := now
+		token.UsedAt = &usedAt
+		assert.False(t, token.IsUsable(now))
+	})
+}
@@ -0,0 +1,70 @@
+package money
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Amount is a monetary value in minor units together with its ISO 4217 currency code
+type Amount struct {
+	Minor    int64
+	Currency string
+}
+
+// NewAmount creates an amount from minor units
+func NewAmount(minor int64, currency string) Amount {
+	return Amount{Minor: minor, Currency: strings.ToUpper(currency)}
+}
+
+// NewAmountFromMajor creates an amount from a major unit value, rounding to the currency's minor unit
+func NewAmountFromMajor(amount float64, currency string) Amount {
+	return NewAmount(ToMinor(amount, currency), currency)
+}
+
+// Major returns the amount in major units
+func (a Amount) Major() float64 {
+	return FromMinor(a.Minor, a.Currency)
+}
+
+// IsZero reports whether the amount is zero
+func (a Amount) IsZero() bool {
+	return a.Minor == 0
+}
+
+// Add returns the sum of two amounts in the same currency
+func (a Amount) Add(other Amount) (Amount, error) {
+	if err := a.checkCurrency(other); err != nil {
+		return Amount{}, err
+	}
+	return NewAmount(a.Minor+other.Minor, a.Currency), nil
+}
+
+// Sub returns the difference of two amounts in the same currency
+func (a Amount) Sub(other Amount) (Amount, error) {
+	if err := a.checkCurrency(other); err != nil {
+		return Amount{}, err
+	}
+	return NewAmount(a.Minor-other.Minor, a.Currency), nil
+}
+
+// Multiply returns the amount multiplied by a whole quantity
+func (a Amount) Multiply(quantity int64) Amount {
+	return NewAmount(a.Minor*quantity, a.Currency)
+}
+
+// Format formats the amount with the given symbol, e.g. "$12.50" or "¥1250"
+func (a Amount) Format(symbol string) string {
+	return FormatMinor(a.Minor, a.Currency, symbol)
+}
+
+// String formats the amount followed by its currency code, e.g. "12.50 USD"
+func (a Amount) String() string {
+	return FormatWithCode(a.Minor, a.Currency)
+}
+
+func (a Amount) checkCurrency(other Amount) error {
+	if !strings.EqualFold(a.Currency, other.Currency) {
+		return fmt.Errorf("currency mismatch: %s and %s", a.Currency, other.Currency)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DefaultMinorUnits is the number of decimals used for currencies without a known ISO 4217 exponent
+const DefaultMinorUnits = 2
+
+// minorUnits lists the ISO 4217 exponents that differ from DefaultMinorUnits
+var minorUnits = map[string]int{
+	// Currencies without minor units
+	"BIF": 0,
+	"CLP": 0,
+	"DJF": 0,
+	"GNF": 0,
+	"ISK": 0,
+	"JPY": 0,
+	"KMF": 0,
+	"KRW": 0,
+	"PYG": 0,
+	"RWF": 0,
+	"UGX": 0,
+	"UYI": 0,
+	"VND": 0,
+	"VUV": 0,
+	"XAF": 0,
+	"XOF": 0,
+	"XPF": 0,
+
+	// Currencies with three decimals
+	"BHD": 3,
+	"IQD": 3,
+	"JOD": 3,
+	"KWD": 3,
+	"LYD": 3,
+	"OMR": 3,
+	"TND": 3,
+
+	// Currencies with four decimals
+	"CLF": 4,
+	"UYW": 4,
+}
+
+// MinorUnits returns the number of decimals of the currency's minor unit according to ISO 4217
+func MinorUnits(currency string) int {
+	if units, ok := minorUnits[strings.ToUpper(currency)]; ok {
+		return units
+	}
+	return DefaultMinorUnits
+}
+
+// minorFactor returns the number of minor units in one major unit of the currency
+func minorFactor(currency string) float64 {
+	return math.Pow10(MinorUnits(currency))
+}
+
+// ToMinor converts a major unit amount (e.g. dollars, yen) to minor units of the currency
+func ToMinor(amount float64, currency string) int64 {
+	return int64(math.Round(amount * minorFactor(currency)))
+}
+
+// FromMinor converts an amount in minor units of the currency to major units
+func FromMinor(minor int64, currency string) float64 {
+	return float64(minor) / minorFactor(currency)
+}
+
+// ConvertNullableToMinor converts a nullable major unit amount to nullable minor units
+func ConvertNullableToMinor(amount *float64, currency string) *int64 {
+	if amount == nil {
+		return nil
+	}
+	minor := ToMinor(*amount, currency)
+	return &minor
+}
+
+// MapToMinor converts a map of major unit amounts keyed by currency code to minor units, preserving nil
+func MapToMinor(amounts map[string]float64) map[string]int64 {
+	if amounts == nil {
+		return nil
+	}
+	minor := make(map[string]int64, len(amounts))
+	for currency, amount := range amounts {
+		minor[currency] = ToMinor(amount, currency)
+	}
+	return minor
+}
+
+// MapFromMinor converts a map of minor unit amounts keyed by currency code to major units,
+// returning nil for an empty map
+func MapFromMinor(amounts map[string]int64) map[string]float64 {
+	if len(amounts) == 0 {
+		return nil
+	}
+	major := make(map[string]float64, len(amounts))
+	for currency, minor := range amounts {
+		major[currency] = FromMinor(minor, currency)
+	}
+	return major
+}
+
+// FormatMinor formats an amount in minor units with the currency symbol and the currency's decimals
+func FormatMinor(minor int64, currency, symbol string) string {
+	return symbol + formatMajor(FromMinor(minor, currency), currency)
+}
+
+// FormatWithCode formats an amount in minor units followed by the currency code, e.g. "1234 JPY"
+func FormatWithCode(minor int64, currency string) string {
+	return formatMajor(FromMinor(minor, currency), currency) + " " + strings.ToUpper(currency)
+}
+
+// formatMajor formats a major unit amount with the number of decimals used by the currency
+func formatMajor(amount float64, currency string) string {
+	return fmt.Sprintf("%.*f", MinorUnits(currency), amount)
+}
@@ -1,5 +1,7 @@
 // Package money provides utilities for handling monetary values.
-// Money is stored as integers (cents) in the database to avoid floating-point precision issues.
+// Money is stored as integers (minor units) in the database to avoid floating-point precision issues.
+// The number of minor units per major unit depends on the currency (see MinorUnits); the cent based
+// helpers in this file assume two decimals and are meant for amounts that are not tied to a currency.
 package money
 
 import (
@@ -8,14 +10,16 @@ import (
 )
 
 // ToCents converts a dollar amount (float64) to cents (int64)
-// This avoids floating-point precision issues when storing money values
+// This avoids floating-point precision issues when storing money values.
+// Use ToMinor when the currency of the amount is known.
 func ToCents(dollars float64) int64 {
 	// Round to nearest cent to avoid floating point issues
 	// Multiply by 100 to convert dollars to cents
 	return int64(math.Round(dollars * 100))
 }
 
-// FromCents converts a cent amount (int64) to dollars (float64)
+// FromCents converts a cent amount (int64) to dollars (float64).
+// Use FromMinor when the currency of the amount is known.
 func FromCents(cents int64) float64 {
 	// Divide by 100 to convert cents to dollars
 	return float64(cents) / 100
@@ -39,7 +43,8 @@ func ConvertNullableFromCents(cents *int64) *float64 {
 	return &dollars
 }
 
-// FormatCurrency formats a cents value (int64) as a currency string
+// FormatCurrency formats a cents value (int64) as a currency string.
+// Use FormatMinor when the currency of the amount is known.
 func FormatCurrency(cents int64, symbol string) string {
 	// Convert to dollars
 	dollars := FromCents(cents)
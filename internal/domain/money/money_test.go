@@ -0,0 +1,97 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinorUnits(t *testing.T) {
+	assert.Equal(t, 2, MinorUnits("USD"))
+	assert.Equal(t, 0, MinorUnits("jpy"))
+	assert.Equal(t, 0, MinorUnits("KRW"))
+	assert.Equal(t, 3, MinorUnits("BHD"))
+	assert.Equal(t, 3, MinorUnits("KWD"))
+	assert.Equal(t, DefaultMinorUnits, MinorUnits("XYZ"))
+	assert.Equal(t, DefaultMinorUnits, MinorUnits(""))
+}
+
+func TestMinorConversion(t *testing.T) {
+	assert.Equal(t, int64(1999), ToMinor(19.99, "USD"))
+	assert.Equal(t, int64(1250), ToMinor(1250, "JPY"))
+	assert.Equal(t, int64(12345), ToMinor(12.345, "BHD"))
+
+	assert.Equal(t, 19.99, FromMinor(1999, "USD"))
+	assert.Equal(t, 1250.0, FromMinor(1250, "JPY"))
+	assert.Equal(t, 12.345, FromMinor(12345, "BHD"))
+
+	assert.Equal(t, map[string]int64{"JPY": 1500, "EUR": 1250}, MapToMinor(map[string]float64{"JPY": 1500, "EUR": 12.5}))
+	assert.Nil(t, MapToMinor(nil))
+	assert.Nil(t, MapFromMinor(map[string]int64{}))
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "$12.50", FormatMinor(1250, "USD", "$"))
+	assert.Equal(t, "¥1250", FormatMinor(1250, "JPY", "¥"))
+	assert.Equal(t, "1.250 KWD", FormatWithCode(1250, "KWD"))
+	assert.Equal(t, "12.50 EUR", FormatWithCode(1250, "eur"))
+}
+
+func TestAmount(t *testing.T) {
+	t.Run("arithmetic in same currency", func(t *testing.T) {
+		a := NewAmountFromMajor(10.5, "usd")
+		b := NewAmount(250, "USD")
+
+		sum, err := a.Add(b)
+		require.NoError(t, err)
+		assert.Equal(t, NewAmount(1300, "USD"), sum)
+
+		diff, err := a.Sub(b)
+		require.NoError(t, err)
+		assert.Equal(t, int64(800), diff.Minor)
+
+		assert.Equal(t, int64(3150), a.Multiply(3).Minor)
+		assert.Equal(t, 10.5, a.Major())
+		assert.Equal(t, "10.50 USD", a.String())
+		assert.Equal(t, "$10.50", a.Format("$"))
+	})
+
+	t.Run("currency mismatch", func(t *testing.T) {
+		_, err := NewAmount(100, "USD").Add(NewAmount(100, "EUR"))
+		assert.Error(t, err)
+	})
+}
+
+func TestRound(t *testing.T) {
+	t.Run("standard strategies", func(t *testing.T) {
+		assert.Equal(t, int64(3), Round(2.5, "USD", RoundHalfUp))
+		assert.Equal(t, int64(2), Round(2.5, "USD", RoundHalfEven))
+		assert.Equal(t, int64(4), Round(3.5, "USD", RoundHalfEven))
+		assert.Equal(t, int64(2), Round(2.9, "USD", RoundDown))
+		assert.Equal(t, int64(3), Round(2.1, "USD", RoundUp))
+		assert.Equal(t, int64(3), Round(2.5, "USD", ""))
+	})
+
+	t.Run("charm pricing", func(t *testing.T) {
+		assert.Equal(t, int64(1299), Round(1260, "USD", RoundCharm99))
+		assert.Equal(t, int64(1199), Round(1240, "USD", RoundCharm99))
+		assert.Equal(t, int64(1295), Round(1280, "USD", RoundCharm95))
+		assert.Equal(t, int64(99), Round(12, "USD", RoundCharm99))
+		assert.Equal(t, int64(1299), Round(1260, "JPY", RoundCharm99))
+		assert.Equal(t, int64(12990), Round(12600, "BHD", RoundCharm99))
+	})
+
+	t.Run("ParseRoundingStrategy", func(t *testing.T) {
+		strategy, err := ParseRoundingStrategy("HALF_EVEN")
+		require.NoError(t, err)
+		assert.Equal(t, RoundHalfEven, strategy)
+
+		strategy, err = ParseRoundingStrategy("")
+		require.NoError(t, err)
+		assert.Equal(t, DefaultRoundingStrategy, strategy)
+
+		_, err = ParseRoundingStrategy("banker")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,84 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// RoundingStrategy determines how fractional minor unit amounts are rounded
+type RoundingStrategy string
+
+const (
+	// RoundHalfUp rounds to the nearest minor unit, with halves rounded away from zero
+	RoundHalfUp RoundingStrategy = "half_up"
+	// RoundHalfEven rounds to the nearest minor unit, with halves rounded to the even neighbour
+	RoundHalfEven RoundingStrategy = "half_even"
+	// RoundDown truncates towards zero
+	RoundDown RoundingStrategy = "down"
+	// RoundUp rounds away from zero
+	RoundUp RoundingStrategy = "up"
+	// RoundCharm99 rounds to the nearest price ending in .99
+	RoundCharm99 RoundingStrategy = "charm_99"
+	// RoundCharm95 rounds to the nearest price ending in .95
+	RoundCharm95 RoundingStrategy = "charm_95"
+)
+
+// DefaultRoundingStrategy is used when no strategy is configured
+const DefaultRoundingStrategy = RoundHalfUp
+
+// ParseRoundingStrategy validates a rounding strategy name. An empty name yields the default strategy.
+func ParseRoundingStrategy(name string) (RoundingStrategy, error) {
+	strategy := RoundingStrategy(strings.ToLower(strings.TrimSpace(name)))
+	switch strategy {
+	case "":
+		return DefaultRoundingStrategy, nil
+	case RoundHalfUp, RoundHalfEven, RoundDown, RoundUp, RoundCharm99, RoundCharm95:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid rounding strategy: %s", name)
+	}
+}
+
+// Round rounds a fractional amount in minor units of the currency using the given strategy
+func Round(minor float64, currency string, strategy RoundingStrategy) int64 {
+	switch strategy {
+	case RoundHalfEven:
+		return int64(math.RoundToEven(minor))
+	case RoundDown:
+		return int64(math.Trunc(minor))
+	case RoundUp:
+		if minor < 0 {
+			return int64(math.Floor(minor))
+		}
+		return int64(math.Ceil(minor))
+	case RoundCharm99:
+		return roundCharm(minor, currency, 99)
+	case RoundCharm95:
+		return roundCharm(minor, currency, 95)
+	default:
+		return int64(math.Round(minor))
+	}
+}
+
+// roundCharm rounds to the nearest price with the given ending. The ending applies to the
+// decimals of the major unit, or to the last two digits for currencies without minor units
+// (e.g. 1299 JPY), so that charm pricing is meaningful for every currency.
+func roundCharm(minor float64, currency string, ending int64) int64 {
+	if minor <= 0 {
+		return int64(math.Round(minor))
+	}
+
+	step := int64(100)
+	if units := MinorUnits(currency); units > 0 {
+		step = int64(math.Pow10(units))
+	}
+	offset := step * (100 - ending) / 100
+
+	upper := int64(math.Ceil((minor+float64(offset))/float64(step)))*step - offset
+	lower := upper - step
+	if lower <= 0 || upper-int64(math.Round(minor)) <= int64(math.Round(minor))-lower {
+		return upper
+	}
+	return lower
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// ErrAPIKeyNotFound is returned when no API key has the requested ID or hash
+var ErrAPIKeyNotFound = errors.New("API key not found")
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	Create(key *entity.APIKey) error
+	GetByID(keyID uint) (*entity.APIKey, error)
+	GetByHash(keyHash string) (*entity.APIKey, error)
+	List() ([]*entity.APIKey, error)
+	// Revoke revokes the key and returns false when it was already revoked
+	Revoke(keyID uint, now time.Time) (bool, error)
+	// MarkUsed sets the last-used timestamp of the key
+	MarkUsed(keyID uint, now time.Time) error
+	// CreateRequest records a request made with a key
+	CreateRequest(request *entity.APIKeyRequest) error
+	// ListRequests lists the requests made with a key, newest first
+	ListRequests(keyID uint, offset, limit int) ([]*entity.APIKeyRequest, error)
+}
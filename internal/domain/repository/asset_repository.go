@@ -0,0 +1,25 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// AssetRepository defines the interface for media asset data access
+type AssetRepository interface {
+	// Create saves the asset with its derivatives
+	Create(asset *entity.Asset) error
+	GetByID(assetID uint) (*entity.Asset, error)
+	List(offset, limit int) ([]*entity.Asset, error)
+	// Delete removes the asset with its derivatives and product links
+	Delete(assetID uint) error
+}
+
+// ProductAssetRepository defines the interface for the links between assets and products
+type ProductAssetRepository interface {
+	Create(link *entity.ProductAsset) error
+	GetByID(linkID uint) (*entity.ProductAsset, error)
+	Update(link *entity.ProductAsset) error
+	Delete(linkID uint) error
+	// ListByProduct returns the assets of a product and its variants in display order
+	ListByProduct(productID uint) ([]*entity.ProductAsset, error)
+	// UpdatePositions saves the positions of the links
+	UpdatePositions(links []*entity.ProductAsset) error
+}
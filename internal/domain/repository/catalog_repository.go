@@ -0,0 +1,15 @@
+package repository
+
+// CatalogRepositories are the repositories that save the products, variants and categories of the catalog
+type CatalogRepositories struct {
+	Products   ProductRepository
+	Variants   ProductVariantRepository
+	Categories CategoryRepository
+}
+
+// CatalogTransactor saves catalog changes that span several products atomically
+type CatalogTransactor interface {
+	// Transaction runs fn with catalog repositories that share one transaction. The changes are
+	// committed when fn returns nil and rolled back otherwise.
+	Transaction(fn func(repos CatalogRepositories) error) error
+}
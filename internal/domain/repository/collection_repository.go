@@ -0,0 +1,23 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// CollectionRepository defines the interface for product collection data access
+type CollectionRepository interface {
+	Create(collection *entity.Collection) error
+	GetByID(collectionID uint) (*entity.Collection, error)
+	// GetBySlug returns the collection with the current or a former slug
+	GetBySlug(slug string) (*entity.Collection, error)
+	Update(collection *entity.Collection) error
+	// Delete deletes the collection with its products
+	Delete(collectionID uint) error
+	// List returns collections by name, optionally only the active ones
+	List(activeOnly bool, offset, limit int) ([]*entity.Collection, error)
+	Count(activeOnly bool) (int, error)
+	// GetByIDs returns the collections with the IDs, leaving out unknown IDs
+	GetByIDs(collectionIDs []uint) ([]*entity.Collection, error)
+	// ProductIDs returns the IDs of the products placed in a manual collection in display order
+	ProductIDs(collectionID uint) ([]uint, error)
+	// SetProducts replaces the products placed in a manual collection
+	SetProducts(collectionID uint, products []*entity.CollectionProduct) error
+}
@@ -1,6 +1,13 @@
 package repository
 
-import "github.com/zenfulcode/commercify/internal/domain/entity"
+import (
+	"errors"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// ErrCurrencyNotFound is returned when no currency has the requested code or no default currency is configured
+var ErrCurrencyNotFound = errors.New("currency not found")
 
 // CurrencyRepository defines the contract for currency operations
 type CurrencyRepository interface {
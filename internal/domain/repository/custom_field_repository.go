@@ -0,0 +1,17 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// CustomFieldRepository defines the interface for custom field data access
+type CustomFieldRepository interface {
+	Create(definition *entity.CustomFieldDefinition) error
+	GetByID(definitionID uint) (*entity.CustomFieldDefinition, error)
+	Update(definition *entity.CustomFieldDefinition) error
+	Delete(definitionID uint) error
+	// ListByResource returns the definitions of a resource by key, the definitions of all resources when empty
+	ListByResource(resource string) ([]*entity.CustomFieldDefinition, error)
+	// GetValues returns the custom field values of a product, variant, order or user
+	GetValues(resource string, resourceID uint) (entity.CustomFields, error)
+	// SetValues replaces the custom field values of a product, variant, order or user
+	SetValues(resource string, resourceID uint, values entity.CustomFields) error
+}
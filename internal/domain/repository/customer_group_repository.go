@@ -0,0 +1,24 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// CustomerGroupRepository defines the interface for customer group data access
+type CustomerGroupRepository interface {
+	Create(group *entity.CustomerGroup) error
+	GetByID(groupID uint) (*entity.CustomerGroup, error)
+	Update(group *entity.CustomerGroup) error
+	Delete(groupID uint) error
+	List(offset, limit int) ([]*entity.CustomerGroup, error)
+}
+
+// PriceListRepository defines the interface for price list data access
+type PriceListRepository interface {
+	Create(priceList *entity.PriceList) error
+	GetByID(priceListID uint) (*entity.PriceList, error)
+	Update(priceList *entity.PriceList) error
+	Delete(priceListID uint) error
+	List(offset, limit int) ([]*entity.PriceList, error)
+	ReplaceEntries(priceList *entity.PriceList) error
+	// GetByUserID returns the price list of the user's customer group
+	GetByUserID(userID uint) (*entity.PriceList, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// DiscountCodeRepository defines the interface for discount code batch data access
+type DiscountCodeRepository interface {
+	CreateBatch(batch *entity.DiscountCodeBatch) error
+	GetBatchByID(batchID uint) (*entity.DiscountCodeBatch, error)
+	ListBatchesByDiscount(discountID uint, offset, limit int) ([]*entity.DiscountCodeBatch, error)
+	DeleteBatch(batchID uint) error
+	ListCodesByBatch(batchID uint) ([]*entity.DiscountCode, error)
+	GetByCode(code string) (*entity.DiscountCode, error)
+	FindExistingCodes(codes []string) ([]string, error)
+	// IncrementUsage records a redemption of the code and fails when its usage limit is reached
+	IncrementUsage(codeID uint) error
+	// ReleaseUsage undoes a redemption recorded with IncrementUsage
+	ReleaseUsage(codeID uint) error
+	GetBatchStats(batchID uint) (*entity.DiscountCodeBatchStats, error)
+}
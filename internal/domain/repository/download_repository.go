@@ -0,0 +1,24 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// DigitalFileRepository defines the interface for the files of digital variants
+type DigitalFileRepository interface {
+	Create(file *entity.DigitalFile) error
+	GetByID(fileID uint) (*entity.DigitalFile, error)
+	// ListByVariant returns the files of a variant in upload order
+	ListByVariant(variantID uint) ([]*entity.DigitalFile, error)
+	// Delete removes the file with the downloads granted for it
+	Delete(fileID uint) error
+}
+
+// OrderDownloadRepository defines the interface for the downloads granted by paid orders
+type OrderDownloadRepository interface {
+	// CreateMissing saves the downloads that were not granted for their order item and file before
+	CreateMissing(downloads []*entity.OrderDownload) error
+	GetByID(downloadID uint) (*entity.OrderDownload, error)
+	// ListByOrder returns the downloads of an order with their files
+	ListByOrder(orderID uint) ([]*entity.OrderDownload, error)
+	// RecordDownload counts a download, failing once the download limit is reached
+	RecordDownload(downloadID uint) error
+}
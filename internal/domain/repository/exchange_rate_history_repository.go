@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// ExchangeRateHistoryRepository defines the contract for exchange rate history operations
+type ExchangeRateHistoryRepository interface {
+	Create(history *entity.ExchangeRateHistory) error
+	ListByCurrency(code string, offset, limit int) ([]*entity.ExchangeRateHistory, error)
+}
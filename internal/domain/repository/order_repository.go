@@ -14,11 +14,14 @@ type OrderRepository interface {
 	GetByCheckoutSessionID(checkoutSessionID string) (*entity.Order, error)
 	Update(order *entity.Order) error
 	GetByUser(userID uint, offset, limit int) ([]*entity.Order, error)
-	ListByStatus(status entity.OrderStatus, offset, limit int) ([]*entity.Order, error)
+	// ListByStatus and ListAll only include the orders whose custom fields match the filters
+	ListByStatus(status entity.OrderStatus, offset, limit int, customFields []entity.CustomFieldFilter) ([]*entity.Order, error)
 	IsDiscountIdUsed(discountID uint) (bool, error)
 	GetByPaymentID(paymentID string) (*entity.Order, error)
-	ListAll(offset, limit int) ([]*entity.Order, error)
+	ListAll(offset, limit int, customFields []entity.CustomFieldFilter) ([]*entity.Order, error)
 	HasOrdersWithProduct(productID uint) (bool, error)
+	// FindPaidOrderIDWithProduct returns the ID of the latest paid order of the user containing the product, or 0 when there is none
+	FindPaidOrderIDWithProduct(userID, productID uint) (uint, error)
 
 	// Dashboard statistics methods
 	GetTotalRevenueByDateRange(startDate, endDate time.Time) (int64, error)
@@ -0,0 +1,14 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// ProductAssociationRepository defines the interface for the links between products
+type ProductAssociationRepository interface {
+	// ListByProducts returns the associations of the products by type and position
+	ListByProducts(productIDs []uint) ([]*entity.ProductAssociation, error)
+	// Replace replaces the associations of a product with one type
+	Replace(productID uint, associationType entity.AssociationType, associations []*entity.ProductAssociation) error
+	// FrequentlyBoughtTogether returns the IDs of the products most often in paid orders with any of the products,
+	// most frequent first. The given products are not included.
+	FrequentlyBoughtTogether(productIDs []uint, limit int) ([]uint, error)
+}
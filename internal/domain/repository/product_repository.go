@@ -8,10 +8,15 @@ type ProductRepository interface {
 	GetByID(productID uint) (*entity.Product, error)
 	GetByIDAndCurrency(productID uint, currency string) (*entity.Product, error)
 	GetBySKU(sku string) (*entity.Product, error)
+	GetBySlug(slug string) (*entity.Product, error)
 	Update(product *entity.Product) error
+	SetOptions(productID uint, options []*entity.ProductOption) error
 	Delete(productID uint) error
-	List(query, currency string, categoryID, offset, limit uint, minPriceCents, maxPriceCents int64, active bool) ([]*entity.Product, error)
-	Count(searchQuery, currency string, categoryID uint, minPriceCents, maxPriceCents int64, active bool) (int, error)
+	// List, Count and ListWithVariants only include the products visible in the sales channel, all products without a channel.
+	// List and Count only include the products whose custom fields match the filters.
+	List(query, currency string, categoryID, offset, limit uint, minPriceCents, maxPriceCents int64, channel entity.SalesChannel, customFields []entity.CustomFieldFilter) ([]*entity.Product, error)
+	Count(searchQuery, currency string, categoryID uint, minPriceCents, maxPriceCents int64, channel entity.SalesChannel, customFields []entity.CustomFieldFilter) (int, error)
+	ListWithVariants(currency string, channel entity.SalesChannel, productIDs []uint) ([]*entity.Product, error)
 	HasProductsWithCategory(categoryID uint) (bool, error)
 	GetTotalProductsCount() (int64, error)
 	GetLowStockProductsCount(lowStockThreshold int) (int64, error)
@@ -21,8 +26,14 @@ type ProductRepository interface {
 type CategoryRepository interface {
 	Create(category *entity.Category) error
 	GetByID(categoryID uint) (*entity.Category, error)
+	GetBySlug(slug string) (*entity.Category, error)
 	Update(category *entity.Category) error
 	Delete(categoryID uint) error
 	List() ([]*entity.Category, error)
 	GetChildren(parentID uint) ([]*entity.Category, error)
+	// Reorder moves the categories under the parent, or to the root when nil, in the given order
+	Reorder(parentID *uint, categoryIDs []uint) error
+	// CountProducts returns the number of products directly in each category, only counting
+	// products visible in the sales channel unless the channel is empty
+	CountProducts(channel entity.SalesChannel) (map[uint]int, error)
 }
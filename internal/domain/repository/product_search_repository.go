@@ -0,0 +1,37 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// ProductSearchQuery contains the full-text query and the filters of a product search
+type ProductSearchQuery struct {
+	Query         string
+	Currency      string
+	CategoryID    uint
+	MinPriceCents int64
+	MaxPriceCents int64
+	ActiveOnly    bool                // Only products visible in the sales channel
+	Channel       entity.SalesChannel // Sales channel of ActiveOnly, the webshop when empty
+	CustomFields  []entity.CustomFieldFilter
+	Offset        uint
+	Limit         uint
+}
+
+// ProductSearchHit is a product matching a search query.
+// Rank is higher for more relevant products and Highlights holds the matching
+// fields with the matched terms wrapped in <mark> tags.
+type ProductSearchHit struct {
+	ProductID  uint
+	Rank       float64
+	Highlights map[string]string
+}
+
+// ProductSearchRepository defines the interface for full-text product search.
+// Implementations keep their index in sync with product writes made through ProductRepository.
+type ProductSearchRepository interface {
+	// Search returns the page of hits sorted by relevance together with the total number of hits
+	Search(query ProductSearchQuery) ([]ProductSearchHit, int, error)
+	// Rebuild reindexes every product and returns the number of indexed products
+	Rebuild() (int, error)
+	// IsStale reports whether the index is missing products, e.g. after a data import
+	IsStale() (bool, error)
+}
@@ -11,4 +11,6 @@ type ProductVariantRepository interface {
 	Update(variant *entity.ProductVariant) error
 	Delete(variantID uint) error
 	BatchCreate(variants []*entity.ProductVariant) error
+	SetComponents(bundleVariantID uint, components []*entity.BundleComponent) error
+	IsBundleComponent(variantID uint) (bool, error)
 }
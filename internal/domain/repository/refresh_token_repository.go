@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// RefreshTokenRepository defines the interface for refresh token data access
+type RefreshTokenRepository interface {
+	Create(token *entity.RefreshToken) error
+	GetByHash(tokenHash string) (*entity.RefreshToken, error)
+	// Revoke revokes the token and returns false when it was already revoked, also by a concurrent request
+	Revoke(tokenID uint, now time.Time) (bool, error)
+	// RevokeSession revokes the tokens of the session
+	RevokeSession(sessionID string, now time.Time) error
+	// RevokeByUser revokes the tokens of all sessions of the user
+	RevokeByUser(userID uint, now time.Time) error
+}
@@ -0,0 +1,23 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// ReviewRepository defines the interface for product review data access
+type ReviewRepository interface {
+	Create(review *entity.Review) error
+	GetByID(reviewID uint) (*entity.Review, error)
+	GetByProductAndUser(productID, userID uint) (*entity.Review, error)
+	Update(review *entity.Review) error
+	Delete(reviewID uint) error
+	// ListByProduct returns the reviews of a product with the status, newest first
+	ListByProduct(productID uint, status entity.ReviewStatus, offset, limit int) ([]*entity.Review, error)
+	CountByProduct(productID uint, status entity.ReviewStatus) (int, error)
+	// List returns the reviews of all products, oldest first so the moderation queue is worked in order.
+	// An empty status lists reviews of every status.
+	List(status entity.ReviewStatus, offset, limit int) ([]*entity.Review, error)
+	Count(status entity.ReviewStatus) (int, error)
+	// RatingDistribution returns the number of approved reviews of a product by rating
+	RatingDistribution(productID uint) (map[int]int, error)
+	// RefreshProductRating recomputes the rating average and review count of a product from its approved reviews
+	RefreshProductRating(productID uint) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// ErrRoleNotFound is returned when no role has the requested ID or name
+var ErrRoleNotFound = errors.New("role not found")
+
+// RoleRepository defines the interface for the data access of admin-defined roles
+type RoleRepository interface {
+	Create(role *entity.Role) error
+	GetByID(roleID uint) (*entity.Role, error)
+	GetByName(name string) (*entity.Role, error)
+	Update(role *entity.Role) error
+	Delete(roleID uint) error
+	List() ([]*entity.Role, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// TranslationRepository defines the interface for catalog translation data access
+type TranslationRepository interface {
+	// ListByResource returns the translations of a resource by locale
+	ListByResource(resourceType string, resourceID uint) ([]*entity.Translation, error)
+	// GetByResources returns the translations of the resources in a locale by resource ID
+	GetByResources(resourceType string, resourceIDs []uint, locale string) (map[uint]*entity.Translation, error)
+	// SetForResource replaces the translations of a resource
+	SetForResource(resourceType string, resourceID uint, translations []*entity.Translation) error
+}
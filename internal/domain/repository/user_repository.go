@@ -13,7 +13,9 @@ type UserRepository interface {
 	GetByEmail(email string) (*entity.User, error)
 	Update(user *entity.User) error
 	Delete(id uint) error
-	List(offset, limit int) ([]*entity.User, error)
+	// List only includes the users whose custom fields match the filters
+	List(offset, limit int, customFields []entity.CustomFieldFilter) ([]*entity.User, error)
+	CountByRole(role string) (int64, error)
 
 	// Dashboard statistics methods
 	GetTotalCustomersCount() (int64, error)
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+var (
+	// ErrTokenInvalid is returned for unknown tokens and tokens replaced by a newer link
+	ErrTokenInvalid = errors.New("invalid token")
+	// ErrTokenUsed is returned for tokens that have already been used
+	ErrTokenUsed = errors.New("token has already been used")
+	// ErrTokenExpired is returned for tokens past their expiry
+	ErrTokenExpired = errors.New("token has expired")
+)
+
+// UserTokenRepository defines the interface for password reset and email verification token data access
+type UserTokenRepository interface {
+	Create(token *entity.UserToken) error
+	// Consume marks the unused, unexpired token with the hash and purpose as used and returns it.
+	// A token can only be consumed once, also by concurrent requests. Unusable tokens return
+	// ErrTokenInvalid, ErrTokenUsed or ErrTokenExpired.
+	Consume(tokenHash string, purpose entity.UserTokenPurpose, now time.Time) (*entity.UserToken, error)
+	// DeleteUnusedByUser deletes the unused tokens of the user for the purpose. Used tokens are kept,
+	// so using their link again returns ErrTokenUsed.
+	DeleteUnusedByUser(userID uint, purpose entity.UserTokenPurpose) error
+}
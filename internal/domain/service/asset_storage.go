@@ -0,0 +1,18 @@
+package service
+
+import "io"
+
+// AssetStorage defines the interface for the storage backends of uploaded media files
+type AssetStorage interface {
+	// Name returns the identifier of the storage backend
+	Name() string
+
+	// Put stores a file under the key, replacing any file with the same key
+	Put(key, contentType string, body io.Reader, size int64) error
+
+	// Delete removes the file with the key. Removing a missing file is not an error.
+	Delete(key string) error
+
+	// URL returns the public URL of the file with the key
+	URL(key string) string
+}
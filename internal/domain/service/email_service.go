@@ -1,6 +1,10 @@
 package service
 
-import "github.com/zenfulcode/commercify/internal/domain/entity"
+import (
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
 
 // EmailData represents the data needed to send an email
 type EmailData struct {
@@ -9,6 +13,7 @@ type EmailData struct {
 	Body     string
 	IsHTML   bool
 	Template string
+	Locale   string // Language of the template, the default template is used when it is not translated
 	Data     map[string]any
 }
 
@@ -25,4 +30,10 @@ type EmailService interface {
 
 	// SendOrderShipped sends an order shipped notification email to the customer
 	SendOrderShipped(order *entity.Order, user *entity.User, trackingNumber, trackingURL string) error
+
+	// SendPasswordReset sends a password reset link to the user
+	SendPasswordReset(user *entity.User, resetURL string, expiresAt time.Time) error
+
+	// SendEmailVerification sends an email verification link to the user
+	SendEmailVerification(user *entity.User, verificationURL string, expiresAt time.Time) error
 }
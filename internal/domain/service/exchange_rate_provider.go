@@ -0,0 +1,20 @@
+package service
+
+import "time"
+
+// ExchangeRates is a set of exchange rates relative to a base currency.
+// A rate of 7.45 for DKK with base EUR means 1 EUR = 7.45 DKK.
+type ExchangeRates struct {
+	Base      string
+	Rates     map[string]float64
+	FetchedAt time.Time
+}
+
+// ExchangeRateProvider defines the interface for exchange rate sources
+type ExchangeRateProvider interface {
+	// Name returns the identifier of the provider, recorded as the source of rate changes
+	Name() string
+
+	// FetchRates returns the latest rates relative to the given base currency
+	FetchRates(base string) (*ExchangeRates, error)
+}
@@ -0,0 +1,16 @@
+package service
+
+import "io"
+
+// FileStorage defines the interface for the private storage of digital product files. The files
+// are never public and are only served through signed download links.
+type FileStorage interface {
+	// Put stores a file under the key, replacing any file with the same key
+	Put(key, contentType string, body io.Reader, size int64) error
+
+	// Open returns the contents of the file with the key. The caller closes the reader.
+	Open(key string) (io.ReadCloser, error)
+
+	// Delete removes the file with the key. Removing a missing file is not an error.
+	Delete(key string) error
+}
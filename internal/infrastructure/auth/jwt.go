@@ -23,26 +23,31 @@ func NewJWTService(config config.AuthConfig) *JWTService {
 
 // Claims represents the JWT claims
 type Claims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID       uint   `json:"user_id"`
+	Email        string `json:"email"`
+	Role         string `json:"role"`
+	TokenVersion uint   `json:"token_version"` // Token version of the user when the token was issued
 	jwt.RegisteredClaims
 }
 
-// GenerateToken generates a JWT token for a user
+// GenerateToken generates a short-lived JWT access token for a user.
+// It returns the token and the number of seconds until it expires.
 func (s *JWTService) GenerateToken(user *entity.User) (string, int, error) {
 	// Set expiration time
-	expirationTime := time.Now().Add(time.Duration(s.config.TokenDuration) * time.Hour)
+	now := time.Now()
+	validFor := time.Duration(s.config.AccessTokenTTL) * time.Minute
+	expirationTime := now.Add(validFor)
 
 	// Create claims
 	claims := &Claims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:       user.ID,
+		Email:        user.Email,
+		Role:         user.Role,
+		TokenVersion: user.TokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "ecommerce-api",
 			Subject:   user.Email,
 		},
@@ -57,7 +62,7 @@ func (s *JWTService) GenerateToken(user *entity.User) (string, int, error) {
 		return "", 0, err
 	}
 
-	return tokenString, expirationTime.Second(), nil
+	return tokenString, int(validFor.Seconds()), nil
 }
 
 // ValidateToken validates a JWT token
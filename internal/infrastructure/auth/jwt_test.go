@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+func TestJWTService(t *testing.T) {
+	service := NewJWTService(config.AuthConfig{JWTSecret: "secret", AccessTokenTTL: 15})
+
+	user, err := entity.NewUser("jane@example.com", "password123", "Jane", "Doe", entity.RoleUser)
+	require.NoError(t, err)
+	user.ID = 7
+	user.TokenVersion = 3
+
+	token, expiresIn, err := service.GenerateToken(user)
+	require.NoError(t, err)
+	assert.Equal(t, 15*60, expiresIn)
+
+	claims, err := service.ValidateToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), claims.UserID)
+	assert.Equal(t, uint(3), claims.TokenVersion)
+	assert.Equal(t, string(entity.RoleUser), claims.Role)
+
+	other := NewJWTService(config.AuthConfig{JWTSecret: "other", AccessTokenTTL: 15})
+	_, err = other.ValidateToken(token)
+	assert.Error(t, err)
+}
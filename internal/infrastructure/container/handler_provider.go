@@ -22,6 +22,17 @@ type HandlerProvider interface {
 	HealthHandler() *handler.HealthHandler
 	EmailTestHandler() *handler.EmailTestHandler
 	DashboardHandler() *handler.DashboardHandler
+	CustomerGroupHandler() *handler.CustomerGroupHandler
+	CatalogHandler() *handler.CatalogHandler
+	AssetHandler() *handler.AssetHandler
+	DownloadHandler() *handler.DownloadHandler
+	ReviewHandler() *handler.ReviewHandler
+	ProductAssociationHandler() *handler.ProductAssociationHandler
+	CollectionHandler() *handler.CollectionHandler
+	TranslationHandler() *handler.TranslationHandler
+	CustomFieldHandler() *handler.CustomFieldHandler
+	RoleHandler() *handler.RoleHandler
+	APIKeyHandler() *handler.APIKeyHandler
 }
 
 // handlerProvider is the concrete implementation of HandlerProvider
@@ -29,20 +40,31 @@ type handlerProvider struct {
 	container Container
 	mu        sync.Mutex
 
-	userHandler            *handler.UserHandler
-	productHandler         *handler.ProductHandler
-	categoryHandler        *handler.CategoryHandler
-	checkoutHandler        *handler.CheckoutHandler
-	orderHandler           *handler.OrderHandler
-	paymentHandler         *handler.PaymentHandler
-	paymentProviderHandler *handler.PaymentProviderHandler
-	webhookHandlerProvider *handler.WebhookHandlerProvider
-	discountHandler        *handler.DiscountHandler
-	shippingHandler        *handler.ShippingHandler
-	currencyHandler        *handler.CurrencyHandler
-	healthHandler          *handler.HealthHandler
-	emailTestHandler       *handler.EmailTestHandler
-	dashboardHandler       *handler.DashboardHandler
+	userHandler               *handler.UserHandler
+	productHandler            *handler.ProductHandler
+	categoryHandler           *handler.CategoryHandler
+	checkoutHandler           *handler.CheckoutHandler
+	orderHandler              *handler.OrderHandler
+	paymentHandler            *handler.PaymentHandler
+	paymentProviderHandler    *handler.PaymentProviderHandler
+	webhookHandlerProvider    *handler.WebhookHandlerProvider
+	discountHandler           *handler.DiscountHandler
+	shippingHandler           *handler.ShippingHandler
+	currencyHandler           *handler.CurrencyHandler
+	healthHandler             *handler.HealthHandler
+	emailTestHandler          *handler.EmailTestHandler
+	dashboardHandler          *handler.DashboardHandler
+	customerGroupHandler      *handler.CustomerGroupHandler
+	catalogHandler            *handler.CatalogHandler
+	assetHandler              *handler.AssetHandler
+	downloadHandler           *handler.DownloadHandler
+	reviewHandler             *handler.ReviewHandler
+	productAssociationHandler *handler.ProductAssociationHandler
+	collectionHandler         *handler.CollectionHandler
+	translationHandler        *handler.TranslationHandler
+	customFieldHandler        *handler.CustomFieldHandler
+	roleHandler               *handler.RoleHandler
+	apiKeyHandler             *handler.APIKeyHandler
 }
 
 // NewHandlerProvider creates a new handler provider
@@ -60,6 +82,7 @@ func (p *handlerProvider) UserHandler() *handler.UserHandler {
 	if p.userHandler == nil {
 		p.userHandler = handler.NewUserHandler(
 			p.container.UseCases().UserUseCase(),
+			p.container.UseCases().CustomFieldUseCase(),
 			p.container.Services().JWTService(),
 			p.container.Logger(),
 		)
@@ -75,6 +98,8 @@ func (p *handlerProvider) ProductHandler() *handler.ProductHandler {
 	if p.productHandler == nil {
 		p.productHandler = handler.NewProductHandler(
 			p.container.UseCases().ProductUseCase(),
+			p.container.UseCases().TranslationUseCase(),
+			p.container.UseCases().CustomFieldUseCase(),
 			p.container.Logger(),
 			p.container.Config(),
 		)
@@ -90,6 +115,7 @@ func (p *handlerProvider) CategoryHandler() *handler.CategoryHandler {
 	if p.categoryHandler == nil {
 		p.categoryHandler = handler.NewCategoryHandler(
 			p.container.UseCases().CategoryUseCase(),
+			p.container.UseCases().TranslationUseCase(),
 			p.container.Logger(),
 		)
 	}
@@ -104,6 +130,7 @@ func (p *handlerProvider) OrderHandler() *handler.OrderHandler {
 	if p.orderHandler == nil {
 		p.orderHandler = handler.NewOrderHandler(
 			p.container.UseCases().OrderUseCase(),
+			p.container.UseCases().CustomFieldUseCase(),
 			p.container.Logger(),
 		)
 	}
@@ -176,6 +203,7 @@ func (p *handlerProvider) ShippingHandler() *handler.ShippingHandler {
 	if p.shippingHandler == nil {
 		p.shippingHandler = handler.NewShippingHandler(
 			p.container.UseCases().ShippingUseCase(),
+			p.container.UseCases().TranslationUseCase(),
 			p.container.Logger(),
 		)
 	}
@@ -261,3 +289,163 @@ func (p *handlerProvider) DashboardHandler() *handler.DashboardHandler {
 	}
 	return p.dashboardHandler
 }
+
+// CustomerGroupHandler returns the customer group handler
+func (p *handlerProvider) CustomerGroupHandler() *handler.CustomerGroupHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.customerGroupHandler == nil {
+		p.customerGroupHandler = handler.NewCustomerGroupHandler(
+			p.container.UseCases().CustomerGroupUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.customerGroupHandler
+}
+
+// CatalogHandler returns the catalog import and export handler
+func (p *handlerProvider) CatalogHandler() *handler.CatalogHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.catalogHandler == nil {
+		p.catalogHandler = handler.NewCatalogHandler(
+			p.container.UseCases().CatalogUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.catalogHandler
+}
+
+// AssetHandler returns the media asset handler
+func (p *handlerProvider) AssetHandler() *handler.AssetHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.assetHandler == nil {
+		p.assetHandler = handler.NewAssetHandler(
+			p.container.UseCases().AssetUseCase(),
+			p.container.Config().Storage.MaxUploadSize,
+			p.container.Logger(),
+		)
+	}
+	return p.assetHandler
+}
+
+// DownloadHandler returns the digital product download handler
+func (p *handlerProvider) DownloadHandler() *handler.DownloadHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.downloadHandler == nil {
+		p.downloadHandler = handler.NewDownloadHandler(
+			p.container.UseCases().DownloadUseCase(),
+			p.container.Config().Download.MaxFileSize,
+			p.container.Logger(),
+		)
+	}
+	return p.downloadHandler
+}
+
+// ReviewHandler returns the product review handler
+func (p *handlerProvider) ReviewHandler() *handler.ReviewHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.reviewHandler == nil {
+		p.reviewHandler = handler.NewReviewHandler(
+			p.container.UseCases().ReviewUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.reviewHandler
+}
+
+// ProductAssociationHandler returns the product association handler
+func (p *handlerProvider) ProductAssociationHandler() *handler.ProductAssociationHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.productAssociationHandler == nil {
+		p.productAssociationHandler = handler.NewProductAssociationHandler(
+			p.container.UseCases().ProductAssociationUseCase(),
+			p.container.UseCases().TranslationUseCase(),
+			p.container.UseCases().CustomFieldUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.productAssociationHandler
+}
+
+// CollectionHandler returns the product collection handler
+func (p *handlerProvider) CollectionHandler() *handler.CollectionHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.collectionHandler == nil {
+		p.collectionHandler = handler.NewCollectionHandler(
+			p.container.UseCases().CollectionUseCase(),
+			p.container.UseCases().TranslationUseCase(),
+			p.container.UseCases().CustomFieldUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.collectionHandler
+}
+
+// TranslationHandler returns the catalog translation handler
+func (p *handlerProvider) TranslationHandler() *handler.TranslationHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.translationHandler == nil {
+		p.translationHandler = handler.NewTranslationHandler(
+			p.container.UseCases().TranslationUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.translationHandler
+}
+
+// CustomFieldHandler returns the custom field handler
+func (p *handlerProvider) CustomFieldHandler() *handler.CustomFieldHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.customFieldHandler == nil {
+		p.customFieldHandler = handler.NewCustomFieldHandler(
+			p.container.UseCases().CustomFieldUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.customFieldHandler
+}
+
+// RoleHandler returns the role handler
+func (p *handlerProvider) RoleHandler() *handler.RoleHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.roleHandler == nil {
+		p.roleHandler = handler.NewRoleHandler(
+			p.container.UseCases().RoleUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.roleHandler
+}
+
+// APIKeyHandler returns the API key handler
+func (p *handlerProvider) APIKeyHandler() *handler.APIKeyHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.apiKeyHandler == nil {
+		p.apiKeyHandler = handler.NewAPIKeyHandler(
+			p.container.UseCases().APIKeyUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.apiKeyHandler
+}
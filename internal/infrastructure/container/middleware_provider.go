@@ -10,6 +10,7 @@ import (
 type MiddlewareProvider interface {
 	AuthMiddleware() *middleware.AuthMiddleware
 	CorsMiddleware() *middleware.CorsMiddleware
+	LocaleMiddleware() *middleware.LocaleMiddleware
 }
 
 // middlewareProvider is the concrete implementation of MiddlewareProvider
@@ -17,8 +18,9 @@ type middlewareProvider struct {
 	container Container
 	mu        sync.Mutex
 
-	authMiddleware *middleware.AuthMiddleware
-	corsMiddleware *middleware.CorsMiddleware
+	authMiddleware   *middleware.AuthMiddleware
+	corsMiddleware   *middleware.CorsMiddleware
+	localeMiddleware *middleware.LocaleMiddleware
 }
 
 // NewMiddlewareProvider creates a new middleware provider
@@ -36,6 +38,9 @@ func (p *middlewareProvider) AuthMiddleware() *middleware.AuthMiddleware {
 	if p.authMiddleware == nil {
 		p.authMiddleware = middleware.NewAuthMiddleware(
 			p.container.Services().JWTService(),
+			p.container.UseCases().UserUseCase(),
+			p.container.UseCases().RoleUseCase(),
+			p.container.UseCases().APIKeyUseCase(),
 			p.container.Logger(),
 		)
 	}
@@ -54,3 +59,16 @@ func (p *middlewareProvider) CorsMiddleware() *middleware.CorsMiddleware {
 	}
 	return p.corsMiddleware
 }
+
+// LocaleMiddleware returns the locale negotiation middleware
+func (p *middlewareProvider) LocaleMiddleware() *middleware.LocaleMiddleware {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.localeMiddleware == nil {
+		p.localeMiddleware = middleware.NewLocaleMiddleware(
+			p.container.Config().Locale,
+		)
+	}
+	return p.localeMiddleware
+}
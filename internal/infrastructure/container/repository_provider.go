@@ -16,9 +16,28 @@ type RepositoryProvider interface {
 	OrderRepository() repository.OrderRepository
 	CheckoutRepository() repository.CheckoutRepository
 	DiscountRepository() repository.DiscountRepository
+	DiscountCodeRepository() repository.DiscountCodeRepository
 	PaymentProviderRepository() repository.PaymentProviderRepository
 	PaymentTransactionRepository() repository.PaymentTransactionRepository
 	CurrencyRepository() repository.CurrencyRepository
+	ExchangeRateHistoryRepository() repository.ExchangeRateHistoryRepository
+	CustomerGroupRepository() repository.CustomerGroupRepository
+	PriceListRepository() repository.PriceListRepository
+	ProductSearchRepository() repository.ProductSearchRepository
+	AssetRepository() repository.AssetRepository
+	ProductAssetRepository() repository.ProductAssetRepository
+	DigitalFileRepository() repository.DigitalFileRepository
+	OrderDownloadRepository() repository.OrderDownloadRepository
+	ReviewRepository() repository.ReviewRepository
+	ProductAssociationRepository() repository.ProductAssociationRepository
+	CollectionRepository() repository.CollectionRepository
+	TranslationRepository() repository.TranslationRepository
+	CustomFieldRepository() repository.CustomFieldRepository
+	UserTokenRepository() repository.UserTokenRepository
+	RefreshTokenRepository() repository.RefreshTokenRepository
+	RoleRepository() repository.RoleRepository
+	APIKeyRepository() repository.APIKeyRepository
+	CatalogTransactor() repository.CatalogTransactor
 
 	// Shipping related repository
 	ShippingMethodRepository() repository.ShippingMethodRepository
@@ -31,16 +50,35 @@ type repositoryProvider struct {
 	container Container
 	mu        sync.Mutex
 
-	userRepo            repository.UserRepository
-	productVariantRepo  repository.ProductVariantRepository
-	productRepo         repository.ProductRepository
-	categoryRepo        repository.CategoryRepository
-	orderRepo           repository.OrderRepository
-	checkoutRepo        repository.CheckoutRepository
-	discountRepo        repository.DiscountRepository
-	paymentProviderRepo repository.PaymentProviderRepository
-	paymentTrxRepo      repository.PaymentTransactionRepository
-	currencyRepo        repository.CurrencyRepository
+	userRepo               repository.UserRepository
+	productVariantRepo     repository.ProductVariantRepository
+	productRepo            repository.ProductRepository
+	categoryRepo           repository.CategoryRepository
+	orderRepo              repository.OrderRepository
+	checkoutRepo           repository.CheckoutRepository
+	discountRepo           repository.DiscountRepository
+	discountCodeRepo       repository.DiscountCodeRepository
+	paymentProviderRepo    repository.PaymentProviderRepository
+	paymentTrxRepo         repository.PaymentTransactionRepository
+	currencyRepo           repository.CurrencyRepository
+	rateHistoryRepo        repository.ExchangeRateHistoryRepository
+	customerGroupRepo      repository.CustomerGroupRepository
+	priceListRepo          repository.PriceListRepository
+	productSearchRepo      repository.ProductSearchRepository
+	assetRepo              repository.AssetRepository
+	productAssetRepo       repository.ProductAssetRepository
+	digitalFileRepo        repository.DigitalFileRepository
+	orderDownloadRepo      repository.OrderDownloadRepository
+	reviewRepo             repository.ReviewRepository
+	productAssociationRepo repository.ProductAssociationRepository
+	collectionRepo         repository.CollectionRepository
+	translationRepo        repository.TranslationRepository
+	customFieldRepo        repository.CustomFieldRepository
+	userTokenRepo          repository.UserTokenRepository
+	refreshTokenRepo       repository.RefreshTokenRepository
+	roleRepo               repository.RoleRepository
+	apiKeyRepo             repository.APIKeyRepository
+	catalogTransactor      repository.CatalogTransactor
 
 	shippingMethodRepo repository.ShippingMethodRepository
 	shippingZoneRepo   repository.ShippingZoneRepository
@@ -135,6 +173,17 @@ func (p *repositoryProvider) DiscountRepository() repository.DiscountRepository
 	return p.discountRepo
 }
 
+// DiscountCodeRepository returns the discount code repository
+func (p *repositoryProvider) DiscountCodeRepository() repository.DiscountCodeRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discountCodeRepo == nil {
+		p.discountCodeRepo = gorm.NewDiscountCodeRepository(p.container.DB())
+	}
+	return p.discountCodeRepo
+}
+
 // PaymentProviderRepository returns the payment provider repository
 func (p *repositoryProvider) PaymentProviderRepository() repository.PaymentProviderRepository {
 	p.mu.Lock()
@@ -200,3 +249,201 @@ func (p *repositoryProvider) CurrencyRepository() repository.CurrencyRepository
 	}
 	return p.currencyRepo
 }
+
+// ExchangeRateHistoryRepository returns the exchange rate history repository
+func (p *repositoryProvider) ExchangeRateHistoryRepository() repository.ExchangeRateHistoryRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.rateHistoryRepo == nil {
+		p.rateHistoryRepo = gorm.NewExchangeRateHistoryRepository(p.container.DB())
+	}
+	return p.rateHistoryRepo
+}
+
+// CustomerGroupRepository returns the customer group repository
+func (p *repositoryProvider) CustomerGroupRepository() repository.CustomerGroupRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.customerGroupRepo == nil {
+		p.customerGroupRepo = gorm.NewCustomerGroupRepository(p.container.DB())
+	}
+	return p.customerGroupRepo
+}
+
+// PriceListRepository returns the price list repository
+func (p *repositoryProvider) PriceListRepository() repository.PriceListRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.priceListRepo == nil {
+		p.priceListRepo = gorm.NewPriceListRepository(p.container.DB())
+	}
+	return p.priceListRepo
+}
+
+// ProductSearchRepository returns the product search repository
+func (p *repositoryProvider) ProductSearchRepository() repository.ProductSearchRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.productSearchRepo == nil {
+		p.productSearchRepo = gorm.NewProductSearchRepository(p.container.DB())
+	}
+	return p.productSearchRepo
+}
+
+// AssetRepository returns the media asset repository
+func (p *repositoryProvider) AssetRepository() repository.AssetRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.assetRepo == nil {
+		p.assetRepo = gorm.NewAssetRepository(p.container.DB())
+	}
+	return p.assetRepo
+}
+
+// ProductAssetRepository returns the product asset repository
+func (p *repositoryProvider) ProductAssetRepository() repository.ProductAssetRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.productAssetRepo == nil {
+		p.productAssetRepo = gorm.NewProductAssetRepository(p.container.DB())
+	}
+	return p.productAssetRepo
+}
+
+// DigitalFileRepository returns the repository of digital product files
+func (p *repositoryProvider) DigitalFileRepository() repository.DigitalFileRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.digitalFileRepo == nil {
+		p.digitalFileRepo = gorm.NewDigitalFileRepository(p.container.DB())
+	}
+	return p.digitalFileRepo
+}
+
+// OrderDownloadRepository returns the repository of the downloads granted by orders
+func (p *repositoryProvider) OrderDownloadRepository() repository.OrderDownloadRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.orderDownloadRepo == nil {
+		p.orderDownloadRepo = gorm.NewOrderDownloadRepository(p.container.DB())
+	}
+	return p.orderDownloadRepo
+}
+
+// ReviewRepository returns the product review repository
+func (p *repositoryProvider) ReviewRepository() repository.ReviewRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.reviewRepo == nil {
+		p.reviewRepo = gorm.NewReviewRepository(p.container.DB())
+	}
+	return p.reviewRepo
+}
+
+// ProductAssociationRepository returns the product association repository
+func (p *repositoryProvider) ProductAssociationRepository() repository.ProductAssociationRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.productAssociationRepo == nil {
+		p.productAssociationRepo = gorm.NewProductAssociationRepository(p.container.DB())
+	}
+	return p.productAssociationRepo
+}
+
+// CollectionRepository returns the product collection repository
+func (p *repositoryProvider) CollectionRepository() repository.CollectionRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.collectionRepo == nil {
+		p.collectionRepo = gorm.NewCollectionRepository(p.container.DB())
+	}
+	return p.collectionRepo
+}
+
+// TranslationRepository returns the catalog translation repository
+func (p *repositoryProvider) TranslationRepository() repository.TranslationRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.translationRepo == nil {
+		p.translationRepo = gorm.NewTranslationRepository(p.container.DB())
+	}
+	return p.translationRepo
+}
+
+// CustomFieldRepository returns the custom field repository
+func (p *repositoryProvider) CustomFieldRepository() repository.CustomFieldRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.customFieldRepo == nil {
+		p.customFieldRepo = gorm.NewCustomFieldRepository(p.container.DB())
+	}
+	return p.customFieldRepo
+}
+
+// UserTokenRepository returns the password reset and email verification token repository
+func (p *repositoryProvider) UserTokenRepository() repository.UserTokenRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.userTokenRepo == nil {
+		p.userTokenRepo = gorm.NewUserTokenRepository(p.container.DB())
+	}
+	return p.userTokenRepo
+}
+
+// RefreshTokenRepository returns the refresh token repository
+func (p *repositoryProvider) RefreshTokenRepository() repository.RefreshTokenRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.refreshTokenRepo == nil {
+		p.refreshTokenRepo = gorm.NewRefreshTokenRepository(p.container.DB())
+	}
+	return p.refreshTokenRepo
+}
+
+// RoleRepository returns the role repository
+func (p *repositoryProvider) RoleRepository() repository.RoleRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.roleRepo == nil {
+		p.roleRepo = gorm.NewRoleRepository(p.container.DB())
+	}
+	return p.roleRepo
+}
+
+// APIKeyRepository returns the API key repository
+func (p *repositoryProvider) APIKeyRepository() repository.APIKeyRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.apiKeyRepo == nil {
+		p.apiKeyRepo = gorm.NewAPIKeyRepository(p.container.DB())
+	}
+	return p.apiKeyRepo
+}
+
+// CatalogTransactor returns the transactor for atomic catalog changes
+func (p *repositoryProvider) CatalogTransactor() repository.CatalogTransactor {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.catalogTransactor == nil {
+		p.catalogTransactor = gorm.NewCatalogTransactor(p.container.DB())
+	}
+	return p.catalogTransactor
+}
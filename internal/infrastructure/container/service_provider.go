@@ -7,7 +7,9 @@ import (
 	"github.com/zenfulcode/commercify/internal/domain/service"
 	"github.com/zenfulcode/commercify/internal/infrastructure/auth"
 	"github.com/zenfulcode/commercify/internal/infrastructure/email"
+	"github.com/zenfulcode/commercify/internal/infrastructure/exchangerate"
 	"github.com/zenfulcode/commercify/internal/infrastructure/payment"
+	"github.com/zenfulcode/commercify/internal/infrastructure/storage"
 )
 
 // ServiceProvider provides access to all services
@@ -16,6 +18,9 @@ type ServiceProvider interface {
 	PaymentService() service.PaymentService
 	PaymentProviderService() service.PaymentProviderService
 	EmailService() service.EmailService
+	ExchangeRateProvider() service.ExchangeRateProvider
+	AssetStorage() service.AssetStorage
+	FileStorage() service.FileStorage
 	MobilePayService() *payment.MobilePayPaymentService
 	InitializeMobilePay() *payment.MobilePayPaymentService
 }
@@ -29,6 +34,12 @@ type serviceProvider struct {
 	paymentService         service.PaymentService
 	paymentProviderService service.PaymentProviderService
 	emailService           service.EmailService
+	exchangeRateProvider   service.ExchangeRateProvider
+	exchangeRateLoaded     bool
+	assetStorage           service.AssetStorage
+	assetStorageLoaded     bool
+	fileStorage            service.FileStorage
+	fileStorageLoaded      bool
 	mobilePayService       *payment.MobilePayPaymentService
 }
 
@@ -127,3 +138,54 @@ func (p *serviceProvider) EmailService() service.EmailService {
 	}
 	return p.emailService
 }
+
+// ExchangeRateProvider returns the configured exchange rate provider, or nil when
+// automatic exchange rate updates are disabled
+func (p *serviceProvider) ExchangeRateProvider() service.ExchangeRateProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.exchangeRateLoaded {
+		provider, err := exchangerate.NewProvider(p.container.Config().ExchangeRate)
+		if err != nil {
+			p.container.Logger().Error("Failed to initialize exchange rate provider: %v", err)
+		}
+		p.exchangeRateProvider = provider
+		p.exchangeRateLoaded = true
+	}
+	return p.exchangeRateProvider
+}
+
+// AssetStorage returns the configured storage backend of uploaded media, or nil when the
+// configuration is invalid
+func (p *serviceProvider) AssetStorage() service.AssetStorage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.assetStorageLoaded {
+		assetStorage, err := storage.NewStorage(p.container.Config().Storage)
+		if err != nil {
+			p.container.Logger().Error("Failed to initialize asset storage: %v", err)
+		}
+		p.assetStorage = assetStorage
+		p.assetStorageLoaded = true
+	}
+	return p.assetStorage
+}
+
+// FileStorage returns the private storage of digital product files, or nil when the
+// configuration is invalid
+func (p *serviceProvider) FileStorage() service.FileStorage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.fileStorageLoaded {
+		fileStorage, err := storage.NewFileStorage(p.container.Config().Storage)
+		if err != nil {
+			p.container.Logger().Error("Failed to initialize download storage: %v", err)
+		}
+		p.fileStorage = fileStorage
+		p.fileStorageLoaded = true
+	}
+	return p.fileStorage
+}
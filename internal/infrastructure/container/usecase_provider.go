@@ -2,6 +2,7 @@ package container
 
 import (
 	"sync"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/application/usecase"
 )
@@ -17,6 +18,17 @@ type UseCaseProvider interface {
 	ShippingUseCase() *usecase.ShippingUseCase
 	CurrencyUsecase() *usecase.CurrencyUseCase
 	DashboardUseCase() *usecase.DashboardUseCase
+	CustomerGroupUseCase() *usecase.CustomerGroupUseCase
+	CatalogUseCase() *usecase.CatalogUseCase
+	AssetUseCase() *usecase.AssetUseCase
+	DownloadUseCase() *usecase.DownloadUseCase
+	ReviewUseCase() *usecase.ReviewUseCase
+	ProductAssociationUseCase() *usecase.ProductAssociationUseCase
+	CollectionUseCase() *usecase.CollectionUseCase
+	TranslationUseCase() *usecase.TranslationUseCase
+	CustomFieldUseCase() *usecase.CustomFieldUseCase
+	RoleUseCase() *usecase.RoleUseCase
+	APIKeyUseCase() *usecase.APIKeyUseCase
 }
 
 // useCaseProvider is the concrete implementation of UseCaseProvider
@@ -33,6 +45,18 @@ type useCaseProvider struct {
 	shippingUseCase  *usecase.ShippingUseCase
 	currencyUseCase  *usecase.CurrencyUseCase
 	dashboardUseCase *usecase.DashboardUseCase
+
+	customerGroupUseCase      *usecase.CustomerGroupUseCase
+	catalogUseCase            *usecase.CatalogUseCase
+	assetUseCase              *usecase.AssetUseCase
+	downloadUseCase           *usecase.DownloadUseCase
+	reviewUseCase             *usecase.ReviewUseCase
+	productAssociationUseCase *usecase.ProductAssociationUseCase
+	collectionUseCase         *usecase.CollectionUseCase
+	translationUseCase        *usecase.TranslationUseCase
+	customFieldUseCase        *usecase.CustomFieldUseCase
+	roleUseCase               *usecase.RoleUseCase
+	apiKeyUseCase             *usecase.APIKeyUseCase
 }
 
 // NewUseCaseProvider creates a new use case provider
@@ -48,8 +72,21 @@ func (p *useCaseProvider) UserUseCase() *usecase.UserUseCase {
 	defer p.mu.Unlock()
 
 	if p.userUseCase == nil {
+		cfg := p.container.Config().Auth
 		p.userUseCase = usecase.NewUserUseCase(
 			p.container.Repositories().UserRepository(),
+			p.container.Repositories().UserTokenRepository(),
+			p.container.Repositories().RefreshTokenRepository(),
+			p.roleUseCaseLocked(),
+			p.container.Services().EmailService(),
+			usecase.UserOptions{
+				RefreshTokenTTL:          time.Duration(cfg.RefreshTokenTTL) * 24 * time.Hour,
+				PasswordResetURL:         cfg.PasswordResetURL,
+				EmailVerificationURL:     cfg.EmailVerificationURL,
+				PasswordResetTTL:         time.Duration(cfg.PasswordResetTTL) * time.Minute,
+				EmailVerificationTTL:     time.Duration(cfg.EmailVerificationTTL) * time.Hour,
+				RequireEmailVerification: cfg.RequireEmailVerification,
+			},
 		)
 	}
 	return p.userUseCase
@@ -68,6 +105,8 @@ func (p *useCaseProvider) ProductUseCase() *usecase.ProductUseCase {
 			p.container.Repositories().CurrencyRepository(),
 			p.container.Repositories().OrderRepository(),
 			p.container.Repositories().CheckoutRepository(),
+			p.container.Repositories().PriceListRepository(),
+			p.container.Repositories().ProductSearchRepository(),
 		)
 	}
 	return p.productUseCase
@@ -99,6 +138,7 @@ func (p *useCaseProvider) CheckoutUseCase() *usecase.CheckoutUseCase {
 				p.container.Repositories().ShippingMethodRepository(),
 				p.container.Repositories().ShippingZoneRepository(),
 				p.container.Repositories().ShippingRateRepository(),
+				p.container.Repositories().CurrencyRepository(),
 			)
 		}
 
@@ -109,11 +149,15 @@ func (p *useCaseProvider) CheckoutUseCase() *usecase.CheckoutUseCase {
 			p.container.Repositories().ShippingMethodRepository(),
 			p.container.Repositories().ShippingRateRepository(),
 			p.container.Repositories().DiscountRepository(),
+			p.container.Repositories().DiscountCodeRepository(),
 			p.container.Repositories().OrderRepository(),
 			p.container.Repositories().CurrencyRepository(),
 			p.container.Repositories().PaymentTransactionRepository(),
+			p.container.Repositories().PriceListRepository(),
 			p.container.Services().PaymentService(),
 			p.shippingUseCase,
+			p.collectionUseCaseLocked(),
+			p.translationUseCaseLocked(),
 		)
 	}
 	return p.checkoutUseCase
@@ -134,6 +178,7 @@ func (p *useCaseProvider) OrderUseCase() *usecase.OrderUseCase {
 			p.container.Services().EmailService(),
 			p.container.Repositories().PaymentTransactionRepository(),
 			p.container.Repositories().CurrencyRepository(),
+			p.downloadUseCaseLocked(),
 		)
 	}
 	return p.orderUseCase
@@ -147,9 +192,12 @@ func (p *useCaseProvider) DiscountUseCase() *usecase.DiscountUseCase {
 	if p.discountUseCase == nil {
 		p.discountUseCase = usecase.NewDiscountUseCase(
 			p.container.Repositories().DiscountRepository(),
+			p.container.Repositories().DiscountCodeRepository(),
 			p.container.Repositories().ProductRepository(),
 			p.container.Repositories().CategoryRepository(),
 			p.container.Repositories().OrderRepository(),
+			p.container.Repositories().CurrencyRepository(),
+			p.collectionUseCaseLocked(),
 		)
 	}
 	return p.discountUseCase
@@ -165,6 +213,7 @@ func (p *useCaseProvider) ShippingUseCase() *usecase.ShippingUseCase {
 			p.container.Repositories().ShippingMethodRepository(),
 			p.container.Repositories().ShippingZoneRepository(),
 			p.container.Repositories().ShippingRateRepository(),
+			p.container.Repositories().CurrencyRepository(),
 		)
 	}
 	return p.shippingUseCase
@@ -178,6 +227,8 @@ func (p *useCaseProvider) CurrencyUsecase() *usecase.CurrencyUseCase {
 	if p.currencyUseCase == nil {
 		p.currencyUseCase = usecase.NewCurrencyUseCase(
 			p.container.Repositories().CurrencyRepository(),
+			p.container.Repositories().ExchangeRateHistoryRepository(),
+			p.container.Services().ExchangeRateProvider(),
 		)
 
 		var defaultCurrency usecase.CurrencyInput = usecase.CurrencyInput{
@@ -208,3 +259,221 @@ func (p *useCaseProvider) DashboardUseCase() *usecase.DashboardUseCase {
 	}
 	return p.dashboardUseCase
 }
+
+// CustomerGroupUseCase returns the customer group use case
+func (p *useCaseProvider) CustomerGroupUseCase() *usecase.CustomerGroupUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.customerGroupUseCase == nil {
+		p.customerGroupUseCase = usecase.NewCustomerGroupUseCase(
+			p.container.Repositories().CustomerGroupRepository(),
+			p.container.Repositories().PriceListRepository(),
+			p.container.Repositories().UserRepository(),
+			p.container.Repositories().ProductVariantRepository(),
+		)
+	}
+	return p.customerGroupUseCase
+}
+
+// CatalogUseCase returns the catalog import and export use case
+func (p *useCaseProvider) CatalogUseCase() *usecase.CatalogUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.catalogUseCase == nil {
+		p.catalogUseCase = usecase.NewCatalogUseCase(
+			p.container.Repositories().ProductRepository(),
+			p.container.Repositories().ProductVariantRepository(),
+			p.container.Repositories().CategoryRepository(),
+			p.container.Repositories().CurrencyRepository(),
+			p.container.Repositories().CatalogTransactor(),
+		)
+	}
+	return p.catalogUseCase
+}
+
+// AssetUseCase returns the media asset use case
+func (p *useCaseProvider) AssetUseCase() *usecase.AssetUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.assetUseCase == nil {
+		cfg := p.container.Config().Storage
+		imageSizes, err := usecase.ParseImageSizes(cfg.ImageSizes)
+		if err != nil {
+			p.container.Logger().Error("Invalid image sizes, derivatives are disabled: %v", err)
+		}
+
+		p.assetUseCase = usecase.NewAssetUseCase(
+			p.container.Repositories().AssetRepository(),
+			p.container.Repositories().ProductAssetRepository(),
+			p.container.Repositories().ProductRepository(),
+			p.container.Services().AssetStorage(),
+			usecase.AssetOptions{
+				MaxUploadSize: cfg.MaxUploadSize,
+				AllowedTypes:  cfg.AllowedTypes,
+				ImageSizes:    imageSizes,
+			},
+		)
+	}
+	return p.assetUseCase
+}
+
+// DownloadUseCase returns the digital product download use case
+func (p *useCaseProvider) DownloadUseCase() *usecase.DownloadUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.downloadUseCaseLocked()
+}
+
+// downloadUseCaseLocked returns the download use case, creating it when needed. The caller holds
+// the lock, which lets the order use case depend on it.
+func (p *useCaseProvider) downloadUseCaseLocked() *usecase.DownloadUseCase {
+	if p.downloadUseCase == nil {
+		cfg := p.container.Config().Download
+		p.downloadUseCase = usecase.NewDownloadUseCase(
+			p.container.Repositories().DigitalFileRepository(),
+			p.container.Repositories().OrderDownloadRepository(),
+			p.container.Repositories().ProductRepository(),
+			p.container.Repositories().OrderRepository(),
+			p.container.Services().FileStorage(),
+			usecase.DownloadOptions{
+				BaseURL:      cfg.BaseURL,
+				LinkTTL:      time.Duration(cfg.LinkTTL) * time.Hour,
+				MaxDownloads: cfg.MaxDownloads,
+				SigningKey:   cfg.SigningKey,
+				MaxFileSize:  cfg.MaxFileSize,
+			},
+		)
+	}
+	return p.downloadUseCase
+}
+
+// ReviewUseCase returns the product review use case
+func (p *useCaseProvider) ReviewUseCase() *usecase.ReviewUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.reviewUseCase == nil {
+		p.reviewUseCase = usecase.NewReviewUseCase(
+			p.container.Repositories().ReviewRepository(),
+			p.container.Repositories().ProductRepository(),
+			p.container.Repositories().OrderRepository(),
+			p.container.Repositories().UserRepository(),
+		)
+	}
+	return p.reviewUseCase
+}
+
+// ProductAssociationUseCase returns the product association use case
+func (p *useCaseProvider) ProductAssociationUseCase() *usecase.ProductAssociationUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.productAssociationUseCase == nil {
+		p.productAssociationUseCase = usecase.NewProductAssociationUseCase(
+			p.container.Repositories().ProductAssociationRepository(),
+			p.container.Repositories().ProductRepository(),
+			p.container.Repositories().CategoryRepository(),
+			p.container.Repositories().CheckoutRepository(),
+			p.container.Repositories().PriceListRepository(),
+		)
+	}
+	return p.productAssociationUseCase
+}
+
+// CollectionUseCase returns the product collection use case
+func (p *useCaseProvider) CollectionUseCase() *usecase.CollectionUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.collectionUseCaseLocked()
+}
+
+// collectionUseCaseLocked returns the collection use case, creating it when needed. The caller holds
+// the lock, which lets the checkout and discount use cases depend on it.
+func (p *useCaseProvider) collectionUseCaseLocked() *usecase.CollectionUseCase {
+	if p.collectionUseCase == nil {
+		p.collectionUseCase = usecase.NewCollectionUseCase(
+			p.container.Repositories().CollectionRepository(),
+			p.container.Repositories().ProductRepository(),
+			p.container.Repositories().CategoryRepository(),
+			p.container.Repositories().PriceListRepository(),
+		)
+	}
+	return p.collectionUseCase
+}
+
+// TranslationUseCase returns the catalog translation use case
+func (p *useCaseProvider) TranslationUseCase() *usecase.TranslationUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.translationUseCaseLocked()
+}
+
+// translationUseCaseLocked returns the translation use case, creating it when needed. The caller
+// holds the lock, which lets the checkout use case depend on it.
+func (p *useCaseProvider) translationUseCaseLocked() *usecase.TranslationUseCase {
+	if p.translationUseCase == nil {
+		cfg := p.container.Config().Locale
+		p.translationUseCase = usecase.NewTranslationUseCase(
+			p.container.Repositories().TranslationRepository(),
+			p.container.Repositories().ProductRepository(),
+			p.container.Repositories().CategoryRepository(),
+			p.container.Repositories().ShippingMethodRepository(),
+			cfg.Default,
+			cfg.Supported,
+		)
+	}
+	return p.translationUseCase
+}
+
+// CustomFieldUseCase returns the custom field use case
+func (p *useCaseProvider) CustomFieldUseCase() *usecase.CustomFieldUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.customFieldUseCase == nil {
+		p.customFieldUseCase = usecase.NewCustomFieldUseCase(
+			p.container.Repositories().CustomFieldRepository(),
+			p.container.Repositories().ProductVariantRepository(),
+		)
+	}
+	return p.customFieldUseCase
+}
+
+// RoleUseCase returns the role use case
+func (p *useCaseProvider) RoleUseCase() *usecase.RoleUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.roleUseCaseLocked()
+}
+
+// roleUseCaseLocked returns the role use case, creating it when needed. The caller holds
+// the lock, which lets the user use case depend on it.
+func (p *useCaseProvider) roleUseCaseLocked() *usecase.RoleUseCase {
+	if p.roleUseCase == nil {
+		p.roleUseCase = usecase.NewRoleUseCase(
+			p.container.Repositories().RoleRepository(),
+			p.container.Repositories().UserRepository(),
+		)
+	}
+	return p.roleUseCase
+}
+
+// APIKeyUseCase returns the API key use case
+func (p *useCaseProvider) APIKeyUseCase() *usecase.APIKeyUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.apiKeyUseCase == nil {
+		p.apiKeyUseCase = usecase.NewAPIKeyUseCase(
+			p.container.Repositories().APIKeyRepository(),
+		)
+	}
+	return p.apiKeyUseCase
+}
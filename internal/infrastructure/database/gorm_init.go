@@ -37,6 +37,14 @@ func InitDB(cfg config.DatabaseConfig) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to auto-migrate: %w", err)
 	}
 
+	if err := SetupProductSearch(db); err != nil {
+		return nil, err
+	}
+
+	if err := BackfillSlugs(db); err != nil {
+		return nil, err
+	}
+
 	log.Printf("Database connected (%s) and migrated successfully", cfg.Driver)
 	return db, nil
 }
@@ -92,16 +100,46 @@ func autoMigrate(db *gorm.DB) error {
 	return db.AutoMigrate(
 		// Core entities
 		&entity.User{},
+		&entity.UserToken{},
+		&entity.RefreshToken{},
+		&entity.Role{},
+		&entity.APIKey{},
+		&entity.APIKeyRequest{},
+		&entity.CustomerGroup{},
 		&entity.Category{},
 
 		// Product entities
 		&entity.Product{},
 		&entity.ProductVariant{},
+		&entity.BundleComponent{},
+		&entity.DigitalFile{},
+		&entity.ProductOption{},
+		&entity.ProductSearchDocument{},
+		&entity.ProductSearchTerm{},
+		&entity.SlugRedirect{},
+		&entity.Asset{},
+		&entity.AssetDerivative{},
+		&entity.ProductAsset{},
+		&entity.ProductAssociation{},
+		&entity.Collection{},
+		&entity.CollectionProduct{},
+		&entity.Translation{},
+		&entity.PriceList{},
+		&entity.PriceListEntry{},
 		&entity.Currency{},
+		&entity.ExchangeRateHistory{},
 
 		// Order entities
 		&entity.Order{},
 		&entity.OrderItem{},
+		&entity.OrderItemComponent{},
+		&entity.OrderDownload{},
+
+		// Review entities
+		&entity.Review{},
+
+		// Custom field entities
+		&entity.CustomFieldDefinition{},
 
 		// Checkout entities
 		&entity.Checkout{},
@@ -109,6 +147,8 @@ func autoMigrate(db *gorm.DB) error {
 
 		// Discount entities
 		&entity.Discount{},
+		&entity.DiscountCodeBatch{},
+		&entity.DiscountCode{},
 
 		// Shipping entities
 		&entity.ShippingMethod{},
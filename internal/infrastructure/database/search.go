@@ -0,0 +1,83 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SetupProductSearch creates the full-text index over product_search_documents.
+// PostgreSQL gets a weighted tsvector column with a GIN index. SQLite gets an FTS5
+// table kept in sync by triggers; when the SQLite driver is built without FTS5
+// (build tag sqlite_fts5) no index is created and search falls back to LIKE matching.
+func SetupProductSearch(db *gorm.DB) error {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return setupPostgresProductSearch(db)
+	case "sqlite":
+		return setupSQLiteProductSearch(db)
+	default:
+		return nil
+	}
+}
+
+func setupPostgresProductSearch(db *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE product_search_documents ADD COLUMN IF NOT EXISTS search_vector tsvector
+			GENERATED ALWAYS AS (
+				setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+				setweight(to_tsvector('english', coalesce(skus, '')), 'A') ||
+				setweight(to_tsvector('english', coalesce(attributes, '')), 'B') ||
+				setweight(to_tsvector('english', coalesce(category_name, '')), 'B') ||
+				setweight(to_tsvector('english', coalesce(description, '')), 'C')
+			) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_product_search_documents_vector
+			ON product_search_documents USING GIN (search_vector)`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to set up product search: %w", err)
+		}
+	}
+	return nil
+}
+
+func setupSQLiteProductSearch(db *gorm.DB) error {
+	err := db.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS product_search_fts USING fts5(
+		name, description, skus, attributes, category_name,
+		content='product_search_documents', content_rowid='product_id',
+		tokenize='porter unicode61', prefix='2 3'
+	)`).Error
+	if err != nil {
+		if strings.Contains(err.Error(), "no such module") {
+			return nil
+		}
+		return fmt.Errorf("failed to set up product search: %w", err)
+	}
+
+	const columns = "name, description, skus, attributes, category_name"
+	const newValues = "new.name, new.description, new.skus, new.attributes, new.category_name"
+	const oldValues = "old.name, old.description, old.skus, old.attributes, old.category_name"
+
+	statements := []string{
+		`CREATE TRIGGER IF NOT EXISTS product_search_documents_ai AFTER INSERT ON product_search_documents BEGIN
+			INSERT INTO product_search_fts(rowid, ` + columns + `) VALUES (new.product_id, ` + newValues + `);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS product_search_documents_ad AFTER DELETE ON product_search_documents BEGIN
+			INSERT INTO product_search_fts(product_search_fts, rowid, ` + columns + `) VALUES ('delete', old.product_id, ` + oldValues + `);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS product_search_documents_au AFTER UPDATE ON product_search_documents BEGIN
+			INSERT INTO product_search_fts(product_search_fts, rowid, ` + columns + `) VALUES ('delete', old.product_id, ` + oldValues + `);
+			INSERT INTO product_search_fts(rowid, ` + columns + `) VALUES (new.product_id, ` + newValues + `);
+		END`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to set up product search: %w", err)
+		}
+	}
+	return nil
+}
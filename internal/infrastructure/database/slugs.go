@@ -0,0 +1,52 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// BackfillSlugs generates slugs for products and categories created before slugs existed
+func BackfillSlugs(db *gorm.DB) error {
+	if err := backfillSlugs(db, &entity.Product{}, "products", entity.SlugResourceProduct); err != nil {
+		return err
+	}
+	return backfillSlugs(db, &entity.Category{}, "categories", entity.SlugResourceCategory)
+}
+
+func backfillSlugs(db *gorm.DB, model any, table, resourceType string) error {
+	var rows []struct {
+		ID   uint
+		Name string
+	}
+	if err := db.Unscoped().Model(model).Select("id", "name").
+		Where("slug IS NULL OR slug = ''").Order("id").Find(&rows).Error; err != nil {
+		return fmt.Errorf("failed to fetch %s without slug: %w", table, err)
+	}
+
+	for _, row := range rows {
+		base := entity.Slugify(row.Name)
+		if base == "" {
+			base = resourceType
+		}
+
+		slug, err := entity.UniqueSlug(base, func(candidate string) (bool, error) {
+			var count int64
+			err := db.Unscoped().Model(model).Where("slug = ?", candidate).Count(&count).Error
+			if err == nil && count == 0 {
+				err = db.Model(&entity.SlugRedirect{}).
+					Where("resource_type = ? AND slug = ?", resourceType, candidate).Count(&count).Error
+			}
+			return count > 0, err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate slug for %s %d: %w", resourceType, row.ID, err)
+		}
+
+		if err := db.Unscoped().Model(model).Where("id = ?", row.ID).UpdateColumn("slug", slug).Error; err != nil {
+			return fmt.Errorf("failed to save slug for %s %d: %w", resourceType, row.ID, err)
+		}
+	}
+	return nil
+}
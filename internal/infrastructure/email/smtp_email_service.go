@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"html/template"
 	"net/smtp"
+	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/zenfulcode/commercify/config"
 	"github.com/zenfulcode/commercify/internal/domain/entity"
@@ -14,6 +16,26 @@ import (
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
 )
 
+// templateDir is the directory of the email templates. Translated templates are kept in a
+// subdirectory per locale, e.g. templates/emails/da/order_confirmation.html.
+const templateDir = "templates/emails"
+
+// localizedSubjects are the subjects of translated customer emails by locale and template
+var localizedSubjects = map[string]map[string]string{
+	"da": {
+		"order_confirmation.html": "Ordrebekræftelse #%d",
+		"order_shipped.html":      "Din ordre #%d er afsendt! 📦",
+	},
+	"nb": {
+		"order_confirmation.html": "Ordrebekreftelse #%d",
+		"order_shipped.html":      "Bestillingen din #%d er sendt! 📦",
+	},
+	"de": {
+		"order_confirmation.html": "Bestellbestätigung #%d",
+		"order_shipped.html":      "Ihre Bestellung #%d wurde versandt! 📦",
+	},
+}
+
 // SMTPEmailService implements the email service interface using SMTP
 type SMTPEmailService struct {
 	config config.EmailConfig
@@ -44,7 +66,7 @@ func (s *SMTPEmailService) SendEmail(data service.EmailData) error {
 
 	if data.Template != "" && data.Data != nil {
 		// Use template if provided
-		body, err = s.renderTemplate(data.Template, data.Data)
+		body, err = s.renderTemplate(data.Template, data.Locale, data.Data)
 		if err != nil {
 			s.logger.Error("Failed to render email template %s: %v", data.Template, err)
 			return err
@@ -126,9 +148,10 @@ func (s *SMTPEmailService) SendOrderConfirmation(order *entity.Order, user *enti
 	// Send email
 	return s.SendEmail(service.EmailData{
 		To:       user.Email,
-		Subject:  fmt.Sprintf("Order Confirmation #%d", order.ID),
+		Subject:  orderSubject("order_confirmation.html", order, "Order Confirmation #%d"),
 		IsHTML:   true,
 		Template: "order_confirmation.html",
+		Locale:   order.Locale,
 		Data:     data,
 	})
 }
@@ -193,25 +216,88 @@ func (s *SMTPEmailService) SendOrderShipped(order *entity.Order, user *entity.Us
 	// Send email
 	return s.SendEmail(service.EmailData{
 		To:       user.Email,
-		Subject:  fmt.Sprintf("Your Order #%d Has Been Shipped! 📦", order.ID),
+		Subject:  orderSubject("order_shipped.html", order, "Your Order #%d Has Been Shipped! 📦"),
 		IsHTML:   true,
 		Template: "order_shipped.html",
+		Locale:   order.Locale,
+		Data:     data,
+	})
+}
+
+// SendPasswordReset sends a password reset link to the user
+func (s *SMTPEmailService) SendPasswordReset(user *entity.User, resetURL string, expiresAt time.Time) error {
+	s.logger.Info("Sending password reset email to User: %d", user.ID)
+
+	data := map[string]any{
+		"User":         user,
+		"StoreName":    s.config.StoreName,
+		"ContactEmail": s.config.ContactEmail,
+		"ResetURL":     resetURL,
+		"ExpiresAt":    expiresAt,
+	}
+
+	return s.SendEmail(service.EmailData{
+		To:       user.Email,
+		Subject:  fmt.Sprintf("Reset your %s password", s.config.StoreName),
+		IsHTML:   true,
+		Template: "password_reset.html",
+		Data:     data,
+	})
+}
+
+// SendEmailVerification sends an email verification link to the user
+func (s *SMTPEmailService) SendEmailVerification(user *entity.User, verificationURL string, expiresAt time.Time) error {
+	s.logger.Info("Sending email verification to User: %d", user.ID)
+
+	data := map[string]any{
+		"User":            user,
+		"StoreName":       s.config.StoreName,
+		"ContactEmail":    s.config.ContactEmail,
+		"VerificationURL": verificationURL,
+		"ExpiresAt":       expiresAt,
+	}
+
+	return s.SendEmail(service.EmailData{
+		To:       user.Email,
+		Subject:  fmt.Sprintf("Verify your email address for %s", s.config.StoreName),
+		IsHTML:   true,
+		Template: "email_verification.html",
 		Data:     data,
 	})
 }
 
-// renderTemplate renders an HTML template with the given data
-func (s *SMTPEmailService) renderTemplate(templateName string, data map[string]any) (string, error) {
+// orderSubject returns the subject of an order email in the language of the order
+func orderSubject(templateName string, order *entity.Order, defaultSubject string) string {
+	if subject, ok := localizedSubjects[order.Locale][templateName]; ok {
+		return fmt.Sprintf(subject, order.ID)
+	}
+	return fmt.Sprintf(defaultSubject, order.ID)
+}
+
+// localizedTemplatePath returns the path of the template translated to the locale,
+// or of the default template when there is no translation
+func localizedTemplatePath(dir, templateName, locale string) string {
+	if locale != "" {
+		path := filepath.Join(dir, filepath.Base(locale), templateName)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, templateName)
+}
+
+// renderTemplate renders an HTML template in the locale with the given data
+func (s *SMTPEmailService) renderTemplate(templateName, locale string, data map[string]any) (string, error) {
 	// Get template path
-	templatePath := filepath.Join("templates", "emails", templateName)
+	templatePath := localizedTemplatePath(templateDir, templateName, locale)
 
 	// Create template with helper functions
 	tmpl := template.New(templateName).Funcs(template.FuncMap{
-		"centsToDollars": func(cents int64) float64 {
-			return money.FromCents(cents)
+		"centsToDollars": func(minor int64, currency string) float64 {
+			return money.FromMinor(minor, currency)
 		},
-		"formatPrice": func(cents int64) string {
-			return fmt.Sprintf("%.2f", money.FromCents(cents))
+		"formatPrice": func(minor int64, currency string) string {
+			return money.FormatMinor(minor, currency, "")
 		},
 		"formatPriceWithCurrency": func(cents int64, currency string) string {
 			return s.formatCurrency(cents, currency)
@@ -233,15 +319,8 @@ func (s *SMTPEmailService) renderTemplate(templateName string, data map[string]a
 	return buf.String(), nil
 }
 
-// formatCurrency formats a cents amount with the currency code at the end
+// formatCurrency formats an amount in minor units with the currency code at the end,
+// using the number of decimals of the currency
 func (s *SMTPEmailService) formatCurrency(amount int64, currency string) string {
-	// Format amount as decimal
-	decimal := money.FromCents(amount)
-
-	// Format with currency code at the end for all currencies
-	if currency == "JPY" {
-		// JPY typically doesn't use decimals
-		return fmt.Sprintf("%.0f %s", decimal*100, currency) // Convert back to whole yen
-	}
-	return fmt.Sprintf("%.2f %s", decimal, currency)
+	return money.FormatWithCode(amount, currency)
 }
@@ -64,6 +64,8 @@ func TestAllTemplatesExist(t *testing.T) {
 		"order_confirmation.html",
 		"order_notification.html",
 		"checkout_recovery.html",
+		"password_reset.html",
+		"email_verification.html",
 	}
 
 	for _, template := range templates {
@@ -79,3 +81,30 @@ func TestTemplateRendering(t *testing.T) {
 	// The existence test above already verifies templates are present
 	t.Skip("Template rendering test requires proper working directory setup")
 }
+
+func TestLocalizedTemplatePath(t *testing.T) {
+	dir := "../../../templates/emails"
+
+	if path := localizedTemplatePath(dir, "order_confirmation.html", "da"); path != dir+"/da/order_confirmation.html" {
+		t.Errorf("Expected the Danish template, got: %s", path)
+	}
+	// Admin notifications are not translated
+	if path := localizedTemplatePath(dir, "order_notification.html", "da"); path != dir+"/order_notification.html" {
+		t.Errorf("Expected the default template, got: %s", path)
+	}
+	if path := localizedTemplatePath(dir, "order_shipped.html", ""); path != dir+"/order_shipped.html" {
+		t.Errorf("Expected the default template, got: %s", path)
+	}
+}
+
+func TestOrderSubject(t *testing.T) {
+	order := &entity.Order{Model: gorm.Model{ID: 42}, Locale: "de"}
+	if subject := orderSubject("order_confirmation.html", order, "Order Confirmation #%d"); subject != "Bestellbestätigung #42" {
+		t.Errorf("Expected the German subject, got: %s", subject)
+	}
+
+	order.Locale = "en"
+	if subject := orderSubject("order_confirmation.html", order, "Order Confirmation #%d"); subject != "Order Confirmation #42" {
+		t.Errorf("Expected the default subject, got: %s", subject)
+	}
+}
@@ -0,0 +1,78 @@
+package exchangerate
+
+import (
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// FakeProvider is an in-memory exchange rate provider for tests and development
+type FakeProvider struct {
+	mu    sync.Mutex
+	base  string
+	rates map[string]float64
+	err   error
+	calls int
+}
+
+// NewFakeProvider creates a new FakeProvider serving the given rates relative to base
+func NewFakeProvider(base string, rates map[string]float64) *FakeProvider {
+	p := &FakeProvider{}
+	p.SetRates(base, rates)
+	return p
+}
+
+// Name implements service.ExchangeRateProvider.
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+// SetRates replaces the rates served by the provider
+func (p *FakeProvider) SetRates(base string, rates map[string]float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.base = strings.ToUpper(base)
+	p.rates = maps.Clone(rates)
+}
+
+// SetError makes subsequent fetches fail with err, or succeed again when err is nil
+func (p *FakeProvider) SetError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.err = err
+}
+
+// Calls returns the number of times FetchRates has been called
+func (p *FakeProvider) Calls() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.calls
+}
+
+// FetchRates implements service.ExchangeRateProvider.
+func (p *FakeProvider) FetchRates(base string) (*service.ExchangeRates, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	rates, err := rebase(p.base, strings.ToUpper(base), p.rates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.ExchangeRates{
+		Base:      strings.ToUpper(base),
+		Rates:     rates,
+		FetchedAt: time.Now(),
+	}, nil
+}
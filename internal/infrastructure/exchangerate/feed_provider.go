@@ -0,0 +1,138 @@
+package exchangerate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// maxFeedSize limits the size of a rate feed that will be read
+const maxFeedSize = 1 << 20
+
+// feedDocument is the JSON document served by a rate feed, e.g.
+//
+//	{"base": "EUR", "timestamp": 1700000000, "rates": {"USD": 1.08, "DKK": 7.45}}
+type feedDocument struct {
+	Base      string             `json:"base"`
+	Timestamp int64              `json:"timestamp,omitempty"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+// FeedProvider reads exchange rates from a JSON feed stored in a local file or served over HTTP(S)
+type FeedProvider struct {
+	source string
+	client *http.Client
+}
+
+// NewFeedProvider creates a new FeedProvider for a file path or http(s) URL
+func NewFeedProvider(source string) *FeedProvider {
+	return &FeedProvider{
+		source: source,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name implements service.ExchangeRateProvider.
+func (p *FeedProvider) Name() string {
+	return "feed"
+}
+
+// FetchRates implements service.ExchangeRateProvider.
+// Rates published against another base are rebased onto the requested one.
+func (p *FeedProvider) FetchRates(base string) (*service.ExchangeRates, error) {
+	data, err := p.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc feedDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rate feed: %w", err)
+	}
+	if doc.Base == "" {
+		return nil, errors.New("exchange rate feed has no base currency")
+	}
+
+	fetchedAt := time.Now()
+	if doc.Timestamp > 0 {
+		fetchedAt = time.Unix(doc.Timestamp, 0)
+	}
+
+	rates, err := rebase(strings.ToUpper(doc.Base), strings.ToUpper(base), doc.Rates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.ExchangeRates{
+		Base:      strings.ToUpper(base),
+		Rates:     rates,
+		FetchedAt: fetchedAt,
+	}, nil
+}
+
+// read loads the raw feed document
+func (p *FeedProvider) read() ([]byte, error) {
+	if p.source == "" {
+		return nil, errors.New("exchange rate feed source is not configured")
+	}
+
+	if !strings.HasPrefix(p.source, "http://") && !strings.HasPrefix(p.source, "https://") {
+		data, err := os.ReadFile(p.source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read exchange rate feed: %w", err)
+		}
+		return data, nil
+	}
+
+	resp, err := p.client.Get(p.source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange rate feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchange rate feed returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exchange rate feed: %w", err)
+	}
+	return data, nil
+}
+
+// rebase converts rates relative to feedBase into rates relative to base
+func rebase(feedBase, base string, feedRates map[string]float64) (map[string]float64, error) {
+	rates := make(map[string]float64, len(feedRates)+1)
+	for code, rate := range feedRates {
+		if rate <= 0 {
+			continue
+		}
+		rates[strings.ToUpper(code)] = rate
+	}
+	rates[feedBase] = 1
+
+	if feedBase == base {
+		return rates, nil
+	}
+
+	baseRate, ok := rates[base]
+	if !ok {
+		return nil, fmt.Errorf("exchange rate feed has no rate for base currency %s", base)
+	}
+
+	rebased := make(map[string]float64, len(rates))
+	for code, rate := range rates {
+		rebased[code] = rate / baseRate
+	}
+	rebased[base] = 1
+
+	return rebased, nil
+}
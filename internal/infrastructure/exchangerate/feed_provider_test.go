@@ -0,0 +1,113 @@
+package exchangerate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zenfulcode/commercify/config"
+)
+
+const testFeed = `{"base": "EUR", "timestamp": 1700000000, "rates": {"USD": 1.25, "DKK": 7.5, "XXX": 0}}`
+
+func TestFeedProvider(t *testing.T) {
+	t.Run("Reads a local file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rates.json")
+		require.NoError(t, os.WriteFile(path, []byte(testFeed), 0o600))
+
+		rates, err := NewFeedProvider(path).FetchRates("eur")
+		require.NoError(t, err)
+
+		assert.Equal(t, "EUR", rates.Base)
+		assert.Equal(t, time.Unix(1700000000, 0), rates.FetchedAt)
+		assert.Equal(t, map[string]float64{"EUR": 1, "USD": 1.25, "DKK": 7.5}, rates.Rates)
+	})
+
+	t.Run("Fetches over HTTP and rebases", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(testFeed))
+		}))
+		defer server.Close()
+
+		rates, err := NewFeedProvider(server.URL).FetchRates("USD")
+		require.NoError(t, err)
+
+		assert.Equal(t, "USD", rates.Base)
+		assert.Equal(t, 1.0, rates.Rates["USD"])
+		assert.Equal(t, 0.8, rates.Rates["EUR"])
+		assert.Equal(t, 6.0, rates.Rates["DKK"])
+	})
+
+	t.Run("HTTP error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		_, err := NewFeedProvider(server.URL).FetchRates("USD")
+		assert.ErrorContains(t, err, "status 503")
+	})
+
+	t.Run("Base currency missing from feed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rates.json")
+		require.NoError(t, os.WriteFile(path, []byte(testFeed), 0o600))
+
+		_, err := NewFeedProvider(path).FetchRates("GBP")
+		assert.ErrorContains(t, err, "no rate for base currency GBP")
+	})
+
+	t.Run("Invalid documents", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rates.json")
+
+		require.NoError(t, os.WriteFile(path, []byte(`not json`), 0o600))
+		_, err := NewFeedProvider(path).FetchRates("EUR")
+		assert.Error(t, err)
+
+		require.NoError(t, os.WriteFile(path, []byte(`{"rates": {"USD": 1.1}}`), 0o600))
+		_, err = NewFeedProvider(path).FetchRates("EUR")
+		assert.ErrorContains(t, err, "no base currency")
+
+		_, err = NewFeedProvider(filepath.Join(t.TempDir(), "missing.json")).FetchRates("EUR")
+		assert.Error(t, err)
+	})
+}
+
+func TestFakeProvider(t *testing.T) {
+	provider := NewFakeProvider("USD", map[string]float64{"EUR": 0.9})
+
+	rates, err := provider.FetchRates("USD")
+	require.NoError(t, err)
+	assert.Equal(t, 0.9, rates.Rates["EUR"])
+
+	provider.SetRates("USD", map[string]float64{"EUR": 0.95})
+	rates, err = provider.FetchRates("USD")
+	require.NoError(t, err)
+	assert.Equal(t, 0.95, rates.Rates["EUR"])
+
+	provider.SetError(assert.AnError)
+	_, err = provider.FetchRates("USD")
+	assert.ErrorIs(t, err, assert.AnError)
+
+	assert.Equal(t, 3, provider.Calls())
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(config.ExchangeRateConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = NewProvider(config.ExchangeRateConfig{Provider: "feed", Source: "rates.json"})
+	require.NoError(t, err)
+	assert.Equal(t, "feed", provider.Name())
+
+	_, err = NewProvider(config.ExchangeRateConfig{Provider: "feed"})
+	assert.Error(t, err)
+
+	_, err = NewProvider(config.ExchangeRateConfig{Provider: "unknown"})
+	assert.Error(t, err)
+}
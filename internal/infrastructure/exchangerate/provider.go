@@ -0,0 +1,24 @@
+package exchangerate
+
+import (
+	"fmt"
+
+	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// NewProvider creates the exchange rate provider selected in the configuration.
+// It returns nil when automatic rate updates are disabled.
+func NewProvider(cfg config.ExchangeRateConfig) (service.ExchangeRateProvider, error) {
+	switch cfg.Provider {
+	case "", "none":
+		return nil, nil
+	case "feed":
+		if cfg.Source == "" {
+			return nil, fmt.Errorf("exchange rate feed source is required")
+		}
+		return NewFeedProvider(cfg.Source), nil
+	default:
+		return nil, fmt.Errorf("unknown exchange rate provider: %s", cfg.Provider)
+	}
+}
@@ -0,0 +1,104 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository implements repository.APIKeyRepository using GORM
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new GORM-based APIKeyRepository
+func NewAPIKeyRepository(db *gorm.DB) repository.APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Create implements repository.APIKeyRepository.
+func (r *APIKeyRepository) Create(key *entity.APIKey) error {
+	if err := r.db.Create(key).Error; err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	return nil
+}
+
+// GetByID implements repository.APIKeyRepository.
+func (r *APIKeyRepository) GetByID(keyID uint) (*entity.APIKey, error) {
+	var key entity.APIKey
+	if err := r.db.First(&key, keyID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: ID %d", repository.ErrAPIKeyNotFound, keyID)
+		}
+		return nil, fmt.Errorf("failed to fetch API key: %w", err)
+	}
+	return &key, nil
+}
+
+// GetByHash implements repository.APIKeyRepository.
+func (r *APIKeyRepository) GetByHash(keyHash string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	if err := r.db.Where("key_hash = ?", keyHash).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch API key: %w", err)
+	}
+	return &key, nil
+}
+
+// List implements repository.APIKeyRepository.
+func (r *APIKeyRepository) List() ([]*entity.APIKey, error) {
+	var keys []*entity.APIKey
+	if err := r.db.Order("created_at DESC, id DESC").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Revoke implements repository.APIKeyRepository.
+func (r *APIKeyRepository) Revoke(keyID uint, now time.Time) (bool, error) {
+	result := r.db.Model(&entity.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", keyID).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to revoke API key: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// MarkUsed implements repository.APIKeyRepository.
+func (r *APIKeyRepository) MarkUsed(keyID uint, now time.Time) error {
+	// UpdateColumn leaves updated_at alone, which tracks changes to the key itself
+	if err := r.db.Model(&entity.APIKey{}).
+		Where("id = ?", keyID).
+		UpdateColumn("last_used_at", now).Error; err != nil {
+		return fmt.Errorf("failed to update API key last use: %w", err)
+	}
+	return nil
+}
+
+// CreateRequest implements repository.APIKeyRepository.
+func (r *APIKeyRepository) CreateRequest(request *entity.APIKeyRequest) error {
+	if err := r.db.Create(request).Error; err != nil {
+		return fmt.Errorf("failed to record API key request: %w", err)
+	}
+	return nil
+}
+
+// ListRequests implements repository.APIKeyRepository.
+func (r *APIKeyRepository) ListRequests(keyID uint, offset, limit int) ([]*entity.APIKeyRequest, error) {
+	var requests []*entity.APIKeyRequest
+	if err := r.db.Where("api_key_id = ?", keyID).
+		Order("created_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&requests).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch API key requests: %w", err)
+	}
+	return requests, nil
+}
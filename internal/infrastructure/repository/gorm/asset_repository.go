@@ -0,0 +1,147 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// AssetRepository implements repository.AssetRepository using GORM
+type AssetRepository struct {
+	db *gorm.DB
+}
+
+// NewAssetRepository creates a new GORM-based AssetRepository
+func NewAssetRepository(db *gorm.DB) repository.AssetRepository {
+	return &AssetRepository{db: db}
+}
+
+// Create implements repository.AssetRepository.
+func (r *AssetRepository) Create(asset *entity.Asset) error {
+	if err := r.db.Create(asset).Error; err != nil {
+		return fmt.Errorf("failed to create asset: %w", err)
+	}
+	return nil
+}
+
+// GetByID implements repository.AssetRepository.
+func (r *AssetRepository) GetByID(assetID uint) (*entity.Asset, error) {
+	var asset entity.Asset
+	if err := r.db.Preload("Derivatives", func(db *gorm.DB) *gorm.DB { return db.Order("width") }).
+		First(&asset, assetID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("asset with ID %d not found", assetID)
+		}
+		return nil, fmt.Errorf("failed to fetch asset: %w", err)
+	}
+	return &asset, nil
+}
+
+// List implements repository.AssetRepository.
+func (r *AssetRepository) List(offset, limit int) ([]*entity.Asset, error) {
+	var assets []*entity.Asset
+	if err := r.db.Preload("Derivatives", func(db *gorm.DB) *gorm.DB { return db.Order("width") }).
+		Order("created_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&assets).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch assets: %w", err)
+	}
+	return assets, nil
+}
+
+// Delete implements repository.AssetRepository.
+func (r *AssetRepository) Delete(assetID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("asset_id = ?", assetID).Delete(&entity.ProductAsset{}).Error; err != nil {
+			return fmt.Errorf("failed to delete product assets: %w", err)
+		}
+		if err := tx.Where("asset_id = ?", assetID).Delete(&entity.AssetDerivative{}).Error; err != nil {
+			return fmt.Errorf("failed to delete asset derivatives: %w", err)
+		}
+		if err := tx.Delete(&entity.Asset{}, assetID).Error; err != nil {
+			return fmt.Errorf("failed to delete asset: %w", err)
+		}
+		return nil
+	})
+}
+
+// ProductAssetRepository implements repository.ProductAssetRepository using GORM
+type ProductAssetRepository struct {
+	db *gorm.DB
+}
+
+// NewProductAssetRepository creates a new GORM-based ProductAssetRepository
+func NewProductAssetRepository(db *gorm.DB) repository.ProductAssetRepository {
+	return &ProductAssetRepository{db: db}
+}
+
+// Create implements repository.ProductAssetRepository.
+func (r *ProductAssetRepository) Create(link *entity.ProductAsset) error {
+	if err := r.db.Omit("Asset").Create(link).Error; err != nil {
+		return fmt.Errorf("failed to create product asset: %w", err)
+	}
+	return nil
+}
+
+// GetByID implements repository.ProductAssetRepository.
+func (r *ProductAssetRepository) GetByID(linkID uint) (*entity.ProductAsset, error) {
+	var link entity.ProductAsset
+	if err := r.withAsset(r.db).First(&link, linkID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("product asset with ID %d not found", linkID)
+		}
+		return nil, fmt.Errorf("failed to fetch product asset: %w", err)
+	}
+	return &link, nil
+}
+
+// Update implements repository.ProductAssetRepository.
+func (r *ProductAssetRepository) Update(link *entity.ProductAsset) error {
+	if err := r.db.Omit("Asset").Save(link).Error; err != nil {
+		return fmt.Errorf("failed to update product asset: %w", err)
+	}
+	return nil
+}
+
+// Delete implements repository.ProductAssetRepository.
+func (r *ProductAssetRepository) Delete(linkID uint) error {
+	if err := r.db.Delete(&entity.ProductAsset{}, linkID).Error; err != nil {
+		return fmt.Errorf("failed to delete product asset: %w", err)
+	}
+	return nil
+}
+
+// ListByProduct implements repository.ProductAssetRepository.
+func (r *ProductAssetRepository) ListByProduct(productID uint) ([]*entity.ProductAsset, error) {
+	var links []*entity.ProductAsset
+	if err := r.withAsset(r.db).
+		Where("product_id = ?", productID).
+		Order("position, id").
+		Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch product assets: %w", err)
+	}
+	return links, nil
+}
+
+// UpdatePositions implements repository.ProductAssetRepository.
+func (r *ProductAssetRepository) UpdatePositions(links []*entity.ProductAsset) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, link := range links {
+			if err := tx.Model(&entity.ProductAsset{}).
+				Where("id = ?", link.ID).
+				Update("position", link.Position).Error; err != nil {
+				return fmt.Errorf("failed to update product asset position: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// withAsset preloads the asset of product asset links with its derivatives
+func (r *ProductAssetRepository) withAsset(db *gorm.DB) *gorm.DB {
+	return db.Preload("Asset").
+		Preload("Asset.Derivatives", func(db *gorm.DB) *gorm.DB { return db.Order("width") })
+}
@@ -0,0 +1,28 @@
+package gorm
+
+import (
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// CatalogTransactor implements repository.CatalogTransactor using GORM
+type CatalogTransactor struct {
+	db *gorm.DB
+}
+
+// NewCatalogTransactor creates a new GORM-based CatalogTransactor
+func NewCatalogTransactor(db *gorm.DB) repository.CatalogTransactor {
+	return &CatalogTransactor{db: db}
+}
+
+// Transaction implements repository.CatalogTransactor.
+// The transactions of the repositories become savepoints of the shared transaction.
+func (t *CatalogTransactor) Transaction(fn func(repos repository.CatalogRepositories) error) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		return fn(repository.CatalogRepositories{
+			Products:   NewProductRepository(tx),
+			Variants:   NewProductVariantRepository(tx),
+			Categories: NewCategoryRepository(tx),
+		})
+	})
+}
@@ -15,21 +15,45 @@ type CategoryRepository struct {
 }
 
 // Create implements repository.CategoryRepository.
+// A slug is generated from the name when the category has none, and the category is placed after its siblings.
 func (c *CategoryRepository) Create(category *entity.Category) error {
-	return c.db.Create(category).Error
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		if err := assignSlug(tx, &entity.Category{}, entity.SlugResourceCategory, category.ID, &category.Slug, category.Name); err != nil {
+			return err
+		}
+		var position int
+		if err := whereParent(tx.Model(&entity.Category{}), category.ParentID).
+			Select("COALESCE(MAX(position) + 1, 0)").Scan(&position).Error; err != nil {
+			return fmt.Errorf("failed to fetch category position: %w", err)
+		}
+		category.Position = position
+		if err := tx.Create(category).Error; err != nil {
+			return err
+		}
+		return saveSlugRedirect(tx, entity.SlugResourceCategory, category.ID, "", category.Slug)
+	})
 }
 
 // Delete implements repository.CategoryRepository.
 func (c *CategoryRepository) Delete(categoryID uint) error {
-	// Note: This will fail if there are products in this category due to RESTRICT constraint
-	// which is the intended behavior for data integrity
-	return c.db.Unscoped().Delete(&entity.Category{}, categoryID).Error
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		// Note: This will fail if there are products in this category due to RESTRICT constraint
+		// which is the intended behavior for data integrity
+		if err := tx.Unscoped().Delete(&entity.Category{}, categoryID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("resource_type = ? AND resource_id = ?", entity.SlugResourceCategory, categoryID).
+			Delete(&entity.SlugRedirect{}).Error; err != nil {
+			return err
+		}
+		return deleteTranslations(tx, entity.TranslationResourceCategory, categoryID)
+	})
 }
 
 // GetByID implements repository.CategoryRepository.
 func (c *CategoryRepository) GetByID(categoryID uint) (*entity.Category, error) {
 	var category entity.Category
-	if err := c.db.Preload("Parent").Preload("Children").First(&category, categoryID).Error; err != nil {
+	if err := c.db.Preload("Parent").Preload("Children", orderCategories).First(&category, categoryID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("category with ID %d not found", categoryID)
 		}
@@ -38,12 +62,29 @@ func (c *CategoryRepository) GetByID(categoryID uint) (*entity.Category, error)
 	return &category, nil
 }
 
+// GetBySlug implements repository.CategoryRepository.
+// Former slugs of a category resolve to the category.
+func (c *CategoryRepository) GetBySlug(slug string) (*entity.Category, error) {
+	var category entity.Category
+	err := c.db.Select("id").Where("slug = ?", slug).First(&category).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		categoryID, err := findSlugRedirect(c.db, entity.SlugResourceCategory, slug)
+		if err != nil {
+			return nil, err
+		}
+		return c.GetByID(categoryID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch category by slug: %w", err)
+	}
+	return c.GetByID(category.ID)
+}
+
 // GetChildren implements repository.CategoryRepository.
 func (c *CategoryRepository) GetChildren(parentID uint) ([]*entity.Category, error) {
 	var children []*entity.Category
-	if err := c.db.Preload("Parent").Preload("Children").
-		Where("parent_id = ?", parentID).
-		Order("name ASC").
+	if err := orderCategories(c.db.Preload("Parent").Preload("Children", orderCategories).
+		Where("parent_id = ?", parentID)).
 		Find(&children).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch children for category %d: %w", parentID, err)
 	}
@@ -53,16 +94,105 @@ func (c *CategoryRepository) GetChildren(parentID uint) ([]*entity.Category, err
 // List implements repository.CategoryRepository.
 func (c *CategoryRepository) List() ([]*entity.Category, error) {
 	var categories []*entity.Category
-	if err := c.db.Preload("Parent").Preload("Children").Order("name ASC").Find(&categories).Error; err != nil {
+	if err := orderCategories(c.db.Preload("Parent").Preload("Children", orderCategories)).Find(&categories).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch categories: %w", err)
 	}
 	return categories, nil
 }
 
 // Update implements repository.CategoryRepository.
+// A changed slug keeps the previous slug as a redirect.
 func (c *CategoryRepository) Update(category *entity.Category) error {
-	// Use explicit field updates to ensure parent_id is properly updated when nil
-	return c.db.Model(category).Select("name", "description", "parent_id").Updates(category).Error
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		var previous string
+		if err := tx.Model(&entity.Category{}).Where("id = ?", category.ID).Pluck("slug", &previous).Error; err != nil {
+			return fmt.Errorf("failed to fetch category slug: %w", err)
+		}
+		if err := assignSlug(tx, &entity.Category{}, entity.SlugResourceCategory, category.ID, &category.Slug, category.Name); err != nil {
+			return err
+		}
+
+		// Use explicit field updates to ensure parent_id is properly updated when nil
+		if err := tx.Model(category).Select("name", "slug", "description", "meta_title", "meta_description", "canonical_url", "parent_id").
+			Updates(category).Error; err != nil {
+			return err
+		}
+		if err := saveSlugRedirect(tx, entity.SlugResourceCategory, category.ID, previous, category.Slug); err != nil {
+			return err
+		}
+
+		// Keep the category name of the product search documents and their vocabulary in sync
+		products := tx.Model(&entity.Product{}).Select("id").Where("category_id = ?", category.ID)
+		if err := tx.Model(&entity.ProductSearchDocument{}).Where("product_id IN (?)", products).
+			Update("category_name", category.Name).Error; err != nil {
+			return fmt.Errorf("failed to update product search index: %w", err)
+		}
+		var documents []*entity.ProductSearchDocument
+		if err := tx.Where("product_id IN (?)", products).Find(&documents).Error; err != nil {
+			return fmt.Errorf("failed to load product search documents: %w", err)
+		}
+		for _, document := range documents {
+			if err := saveSearchTerms(tx, document); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Reorder implements repository.CategoryRepository.
+func (c *CategoryRepository) Reorder(parentID *uint, categoryIDs []uint) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		for position, categoryID := range categoryIDs {
+			if err := tx.Model(&entity.Category{}).Where("id = ?", categoryID).
+				Updates(map[string]any{"parent_id": parentID, "position": position}).Error; err != nil {
+				return fmt.Errorf("failed to reorder category %d: %w", categoryID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// CountProducts implements repository.CategoryRepository.
+func (c *CategoryRepository) CountProducts(channel entity.SalesChannel) (map[uint]int, error) {
+	var rows []struct {
+		CategoryID uint
+		Count      int
+	}
+	query := c.db.Model(&entity.Product{}).Select("products.category_id, COUNT(*) AS count").Group("products.category_id")
+	if channel != "" {
+		query = visibleProducts(query, channel)
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count products by category: %w", err)
+	}
+
+	counts := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Count
+	}
+	return counts, nil
+}
+
+// orderCategories orders categories by their position among their siblings
+func orderCategories(db *gorm.DB) *gorm.DB {
+	return db.Order("categories.position ASC, categories.name ASC")
+}
+
+// whereParent restricts a category query to the children of the parent, or to the root categories when nil
+func whereParent(tx *gorm.DB, parentID *uint) *gorm.DB {
+	if parentID == nil {
+		return tx.Where("parent_id IS NULL")
+	}
+	return tx.Where("parent_id = ?", *parentID)
+}
+
+// whereInCategoryTree restricts a query to the rows whose category column is the category or one of its descendants
+func whereInCategoryTree(tx *gorm.DB, column string, categoryID uint) *gorm.DB {
+	return tx.Where(column+` IN (WITH RECURSIVE category_tree(id) AS (
+		SELECT CAST(? AS BIGINT) UNION SELECT categories.id FROM categories JOIN category_tree ON categories.parent_id = category_tree.id
+		WHERE categories.deleted_at IS NULL
+	) SELECT id FROM category_tree)`, categoryID)
 }
 
 // NewCategoryRepository creates a new GORM-based CategoryRepository
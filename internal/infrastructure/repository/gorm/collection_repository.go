@@ -0,0 +1,161 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// CollectionRepository implements repository.CollectionRepository using GORM
+type CollectionRepository struct {
+	db *gorm.DB
+}
+
+// NewCollectionRepository creates a new GORM-based CollectionRepository
+func NewCollectionRepository(db *gorm.DB) repository.CollectionRepository {
+	return &CollectionRepository{db: db}
+}
+
+// Create implements repository.CollectionRepository.
+// A slug is generated from the name when the collection has none.
+func (r *CollectionRepository) Create(collection *entity.Collection) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := assignSlug(tx, &entity.Collection{}, entity.SlugResourceCollection, collection.ID, &collection.Slug, collection.Name); err != nil {
+			return err
+		}
+		if err := tx.Create(collection).Error; err != nil {
+			return fmt.Errorf("failed to create collection: %w", err)
+		}
+		return saveSlugRedirect(tx, entity.SlugResourceCollection, collection.ID, "", collection.Slug)
+	})
+}
+
+// GetByID implements repository.CollectionRepository.
+func (r *CollectionRepository) GetByID(collectionID uint) (*entity.Collection, error) {
+	var collection entity.Collection
+	if err := r.db.First(&collection, collectionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("collection with ID %d not found", collectionID)
+		}
+		return nil, fmt.Errorf("failed to fetch collection: %w", err)
+	}
+	return &collection, nil
+}
+
+// GetBySlug implements repository.CollectionRepository.
+func (r *CollectionRepository) GetBySlug(slug string) (*entity.Collection, error) {
+	var collection entity.Collection
+	err := r.db.Where("slug = ?", slug).First(&collection).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		collectionID, err := findSlugRedirect(r.db, entity.SlugResourceCollection, slug)
+		if err != nil {
+			return nil, err
+		}
+		return r.GetByID(collectionID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch collection by slug: %w", err)
+	}
+	return &collection, nil
+}
+
+// Update implements repository.CollectionRepository.
+// A changed slug keeps the previous slug as a redirect.
+func (r *CollectionRepository) Update(collection *entity.Collection) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var previous string
+		if err := tx.Model(&entity.Collection{}).Where("id = ?", collection.ID).Pluck("slug", &previous).Error; err != nil {
+			return fmt.Errorf("failed to fetch collection slug: %w", err)
+		}
+		if err := assignSlug(tx, &entity.Collection{}, entity.SlugResourceCollection, collection.ID, &collection.Slug, collection.Name); err != nil {
+			return err
+		}
+		if err := tx.Save(collection).Error; err != nil {
+			return fmt.Errorf("failed to update collection: %w", err)
+		}
+		return saveSlugRedirect(tx, entity.SlugResourceCollection, collection.ID, previous, collection.Slug)
+	})
+}
+
+// Delete implements repository.CollectionRepository.
+func (r *CollectionRepository) Delete(collectionID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", collectionID).Delete(&entity.CollectionProduct{}).Error; err != nil {
+			return fmt.Errorf("failed to delete collection products: %w", err)
+		}
+		if err := tx.Delete(&entity.Collection{}, collectionID).Error; err != nil {
+			return fmt.Errorf("failed to delete collection: %w", err)
+		}
+		return tx.Where("resource_type = ? AND resource_id = ?", entity.SlugResourceCollection, collectionID).
+			Delete(&entity.SlugRedirect{}).Error
+	})
+}
+
+// List implements repository.CollectionRepository.
+func (r *CollectionRepository) List(activeOnly bool, offset, limit int) ([]*entity.Collection, error) {
+	var collections []*entity.Collection
+	query := r.db.Order("name ASC, id ASC").Offset(offset).Limit(limit)
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	if err := query.Find(&collections).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch collections: %w", err)
+	}
+	return collections, nil
+}
+
+// Count implements repository.CollectionRepository.
+func (r *CollectionRepository) Count(activeOnly bool) (int, error) {
+	var count int64
+	query := r.db.Model(&entity.Collection{})
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count collections: %w", err)
+	}
+	return int(count), nil
+}
+
+// GetByIDs implements repository.CollectionRepository.
+func (r *CollectionRepository) GetByIDs(collectionIDs []uint) ([]*entity.Collection, error) {
+	collections := []*entity.Collection{}
+	if len(collectionIDs) == 0 {
+		return collections, nil
+	}
+	if err := r.db.Where("id IN ?", collectionIDs).Order("id").Find(&collections).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch collections: %w", err)
+	}
+	return collections, nil
+}
+
+// ProductIDs implements repository.CollectionRepository.
+func (r *CollectionRepository) ProductIDs(collectionID uint) ([]uint, error) {
+	ids := []uint{}
+	if err := r.db.Model(&entity.CollectionProduct{}).
+		Where("collection_id = ?", collectionID).
+		Order("position, id").
+		Pluck("product_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch collection products: %w", err)
+	}
+	return ids, nil
+}
+
+// SetProducts implements repository.CollectionRepository.
+func (r *CollectionRepository) SetProducts(collectionID uint, products []*entity.CollectionProduct) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", collectionID).Delete(&entity.CollectionProduct{}).Error; err != nil {
+			return fmt.Errorf("failed to delete collection products: %w", err)
+		}
+		if len(products) == 0 {
+			return nil
+		}
+		if err := tx.Create(products).Error; err != nil {
+			return fmt.Errorf("failed to create collection products: %w", err)
+		}
+		return nil
+	})
+}
@@ -29,7 +29,7 @@ func (c *CurrencyRepository) GetByCode(code string) (*entity.Currency, error) {
 	var currency entity.Currency
 	if err := c.db.Where("code = ?", code).First(&currency).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("currency with code %s not found", code)
+			return nil, fmt.Errorf("%w: code %s", repository.ErrCurrencyNotFound, code)
 		}
 		return nil, fmt.Errorf("failed to fetch currency by code: %w", err)
 	}
@@ -41,7 +41,7 @@ func (c *CurrencyRepository) GetDefault() (*entity.Currency, error) {
 	var currency entity.Currency
 	if err := c.db.Where("is_default = ?", true).First(&currency).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("no default currency found")
+			return nil, fmt.Errorf("%w: no default currency", repository.ErrCurrencyNotFound)
 		}
 		return nil, fmt.Errorf("failed to fetch default currency: %w", err)
 	}
@@ -0,0 +1,175 @@
+package gorm
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// CustomFieldRepository implements repository.CustomFieldRepository using GORM
+type CustomFieldRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomFieldRepository creates a new GORM-based CustomFieldRepository
+func NewCustomFieldRepository(db *gorm.DB) repository.CustomFieldRepository {
+	return &CustomFieldRepository{db: db}
+}
+
+// Create implements repository.CustomFieldRepository.
+func (r *CustomFieldRepository) Create(definition *entity.CustomFieldDefinition) error {
+	if err := r.db.Create(definition).Error; err != nil {
+		return fmt.Errorf("failed to create custom field: %w", err)
+	}
+	return nil
+}
+
+// GetByID implements repository.CustomFieldRepository.
+func (r *CustomFieldRepository) GetByID(definitionID uint) (*entity.CustomFieldDefinition, error) {
+	var definition entity.CustomFieldDefinition
+	if err := r.db.First(&definition, definitionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("custom field with ID %d not found", definitionID)
+		}
+		return nil, fmt.Errorf("failed to fetch custom field: %w", err)
+	}
+	return &definition, nil
+}
+
+// Update implements repository.CustomFieldRepository.
+func (r *CustomFieldRepository) Update(definition *entity.CustomFieldDefinition) error {
+	if err := r.db.Save(definition).Error; err != nil {
+		return fmt.Errorf("failed to update custom field: %w", err)
+	}
+	return nil
+}
+
+// Delete implements repository.CustomFieldRepository.
+// The values of the field are removed from the resources, so a new field can reuse the key.
+func (r *CustomFieldRepository) Delete(definitionID uint) error {
+	definition, err := r.GetByID(definitionID)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		model, err := customFieldModel(definition.Resource)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(model).
+			Where(datatypes.JSONQuery("custom_fields").HasKey(definition.Key)).
+			UpdateColumn("custom_fields", removeCustomFieldValue(tx, definition.Key)).Error; err != nil {
+			return fmt.Errorf("failed to remove custom field values: %w", err)
+		}
+
+		if err := tx.Delete(&entity.CustomFieldDefinition{}, definitionID).Error; err != nil {
+			return fmt.Errorf("failed to delete custom field: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListByResource implements repository.CustomFieldRepository.
+func (r *CustomFieldRepository) ListByResource(resource string) ([]*entity.CustomFieldDefinition, error) {
+	var definitions []*entity.CustomFieldDefinition
+	query := r.db.Order("resource ASC, key ASC")
+	if resource != "" {
+		query = query.Where("resource = ?", resource)
+	}
+	if err := query.Find(&definitions).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch custom fields: %w", err)
+	}
+	return definitions, nil
+}
+
+// GetValues implements repository.CustomFieldRepository.
+func (r *CustomFieldRepository) GetValues(resource string, resourceID uint) (entity.CustomFields, error) {
+	model, err := customFieldModel(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.NullString
+	if err := r.db.Model(model).Where("id = ?", resourceID).Pluck("custom_fields", &rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch custom field values: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s with ID %d not found", resource, resourceID)
+	}
+
+	values := entity.CustomFields{}
+	if rows[0].Valid && rows[0].String != "" {
+		if err := json.Unmarshal([]byte(rows[0].String), &values); err != nil {
+			return nil, fmt.Errorf("failed to decode custom field values: %w", err)
+		}
+	}
+	if values == nil {
+		values = entity.CustomFields{}
+	}
+	return values, nil
+}
+
+// SetValues implements repository.CustomFieldRepository.
+func (r *CustomFieldRepository) SetValues(resource string, resourceID uint, values entity.CustomFields) error {
+	model, err := customFieldModel(resource)
+	if err != nil {
+		return err
+	}
+	if values == nil {
+		values = entity.CustomFields{}
+	}
+
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to encode custom field values: %w", err)
+	}
+
+	result := r.db.Model(model).Where("id = ?", resourceID).Update("custom_fields", string(encoded))
+	if result.Error != nil {
+		return fmt.Errorf("failed to update custom field values: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s with ID %d not found", resource, resourceID)
+	}
+	return nil
+}
+
+// customFieldModel returns the model of the table holding the custom fields of a resource
+func customFieldModel(resource string) (any, error) {
+	switch resource {
+	case entity.CustomFieldResourceProduct:
+		return &entity.Product{}, nil
+	case entity.CustomFieldResourceProductVariant:
+		return &entity.ProductVariant{}, nil
+	case entity.CustomFieldResourceOrder:
+		return &entity.Order{}, nil
+	case entity.CustomFieldResourceUser:
+		return &entity.User{}, nil
+	}
+	return nil, fmt.Errorf("invalid custom field resource %q", resource)
+}
+
+// removeCustomFieldValue returns the custom fields column without the key
+func removeCustomFieldValue(db *gorm.DB, key string) any {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return gorm.Expr("custom_fields::jsonb - ?", key)
+	default:
+		return gorm.Expr("json_remove(custom_fields, ?)", "$."+key)
+	}
+}
+
+// whereCustomFields restricts a query to the rows whose custom fields in the column equal the filter values
+func whereCustomFields(tx *gorm.DB, column string, filters []entity.CustomFieldFilter) *gorm.DB {
+	for _, filter := range filters {
+		tx = tx.Where(datatypes.JSONQuery(column).Equals(filter.Value, filter.Key))
+	}
+	return tx
+}
@@ -0,0 +1,76 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// CustomerGroupRepository implements repository.CustomerGroupRepository using GORM
+type CustomerGroupRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomerGroupRepository creates a new GORM-based CustomerGroupRepository
+func NewCustomerGroupRepository(db *gorm.DB) repository.CustomerGroupRepository {
+	return &CustomerGroupRepository{db: db}
+}
+
+// Create implements repository.CustomerGroupRepository.
+func (r *CustomerGroupRepository) Create(group *entity.CustomerGroup) error {
+	if err := r.db.Create(group).Error; err != nil {
+		return fmt.Errorf("failed to create customer group: %w", err)
+	}
+	return nil
+}
+
+// GetByID implements repository.CustomerGroupRepository.
+func (r *CustomerGroupRepository) GetByID(groupID uint) (*entity.CustomerGroup, error) {
+	var group entity.CustomerGroup
+	if err := r.db.First(&group, groupID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("customer group with ID %d not found", groupID)
+		}
+		return nil, fmt.Errorf("failed to fetch customer group: %w", err)
+	}
+	return &group, nil
+}
+
+// Update implements repository.CustomerGroupRepository.
+func (r *CustomerGroupRepository) Update(group *entity.CustomerGroup) error {
+	if err := r.db.Omit("PriceList").Save(group).Error; err != nil {
+		return fmt.Errorf("failed to update customer group: %w", err)
+	}
+	return nil
+}
+
+// Delete implements repository.CustomerGroupRepository.
+// Members of the group fall back to regular prices.
+func (r *CustomerGroupRepository) Delete(groupID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.User{}).
+			Where("customer_group_id = ?", groupID).
+			Update("customer_group_id", nil).Error; err != nil {
+			return fmt.Errorf("failed to remove users from customer group: %w", err)
+		}
+
+		if err := tx.Delete(&entity.CustomerGroup{}, groupID).Error; err != nil {
+			return fmt.Errorf("failed to delete customer group: %w", err)
+		}
+		return nil
+	})
+}
+
+// List implements repository.CustomerGroupRepository.
+func (r *CustomerGroupRepository) List(offset, limit int) ([]*entity.CustomerGroup, error) {
+	var groups []*entity.CustomerGroup
+	if err := r.db.Order("name ASC").
+		Offset(offset).Limit(limit).
+		Find(&groups).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch customer groups: %w", err)
+	}
+	return groups, nil
+}
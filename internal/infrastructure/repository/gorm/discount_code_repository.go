@@ -0,0 +1,191 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// discountCodeLookupChunkSize keeps IN clauses below the SQLite variable limit
+const discountCodeLookupChunkSize = 500
+
+// DiscountCodeRepository implements repository.DiscountCodeRepository using GORM
+type DiscountCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewDiscountCodeRepository creates a new GORM-based DiscountCodeRepository
+func NewDiscountCodeRepository(db *gorm.DB) repository.DiscountCodeRepository {
+	return &DiscountCodeRepository{db: db}
+}
+
+// CreateBatch creates a batch together with all of its codes in a single transaction
+func (r *DiscountCodeRepository) CreateBatch(batch *entity.DiscountCodeBatch) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		codes := batch.Codes
+		batch.Codes = nil
+
+		if err := tx.Create(batch).Error; err != nil {
+			return fmt.Errorf("failed to create discount code batch: %w", err)
+		}
+
+		for i := range codes {
+			codes[i].BatchID = batch.ID
+			codes[i].DiscountID = batch.DiscountID
+		}
+
+		if len(codes) > 0 {
+			if err := tx.CreateInBatches(codes, discountCodeLookupChunkSize).Error; err != nil {
+				return fmt.Errorf("failed to create discount codes: %w", err)
+			}
+		}
+
+		batch.Codes = codes
+		return nil
+	})
+}
+
+// GetBatchByID retrieves a batch by ID without its codes
+func (r *DiscountCodeRepository) GetBatchByID(batchID uint) (*entity.DiscountCodeBatch, error) {
+	var batch entity.DiscountCodeBatch
+	if err := r.db.First(&batch, batchID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("discount code batch with ID %d not found", batchID)
+		}
+		return nil, fmt.Errorf("failed to fetch discount code batch: %w", err)
+	}
+	return &batch, nil
+}
+
+// ListBatchesByDiscount lists the batches belonging to a discount
+func (r *DiscountCodeRepository) ListBatchesByDiscount(discountID uint, offset, limit int) ([]*entity.DiscountCodeBatch, error) {
+	var batches []*entity.DiscountCodeBatch
+	if err := r.db.Where("discount_id = ?", discountID).
+		Offset(offset).Limit(limit).
+		Order("created_at DESC").
+		Find(&batches).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch discount code batches: %w", err)
+	}
+	return batches, nil
+}
+
+// DeleteBatch deletes a batch and all of its codes
+func (r *DiscountCodeRepository) DeleteBatch(batchID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Codes are removed permanently so they can be reused in later batches
+		if err := tx.Unscoped().Where("batch_id = ?", batchID).Delete(&entity.DiscountCode{}).Error; err != nil {
+			return fmt.Errorf("failed to delete discount codes: %w", err)
+		}
+		if err := tx.Delete(&entity.DiscountCodeBatch{}, batchID).Error; err != nil {
+			return fmt.Errorf("failed to delete discount code batch: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListCodesByBatch lists all codes in a batch
+func (r *DiscountCodeRepository) ListCodesByBatch(batchID uint) ([]*entity.DiscountCode, error) {
+	var codes []*entity.DiscountCode
+	if err := r.db.Where("batch_id = ?", batchID).Order("id ASC").Find(&codes).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch discount codes: %w", err)
+	}
+	return codes, nil
+}
+
+// GetByCode retrieves a code with its parent discount
+func (r *DiscountCodeRepository) GetByCode(code string) (*entity.DiscountCode, error) {
+	var discountCode entity.DiscountCode
+	if err := r.db.Preload("Discount").
+		Where("code = ?", entity.NormalizeDiscountCode(code)).
+		First(&discountCode).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("discount code %s not found", code)
+		}
+		return nil, fmt.Errorf("failed to fetch discount code: %w", err)
+	}
+	return &discountCode, nil
+}
+
+// FindExistingCodes returns the subset of the given codes that are already taken,
+// either by a batch code or by a standalone discount
+func (r *DiscountCodeRepository) FindExistingCodes(codes []string) ([]string, error) {
+	var existing []string
+
+	for start := 0; start < len(codes); start += discountCodeLookupChunkSize {
+		end := min(start+discountCodeLookupChunkSize, len(codes))
+		chunk := codes[start:end]
+
+		var batchCodes []string
+		if err := r.db.Model(&entity.DiscountCode{}).Where("code IN ?", chunk).Pluck("code", &batchCodes).Error; err != nil {
+			return nil, fmt.Errorf("failed to check existing discount codes: %w", err)
+		}
+		existing = append(existing, batchCodes...)
+
+		var discountCodes []string
+		if err := r.db.Model(&entity.Discount{}).Where("code IN ?", chunk).Pluck("code", &discountCodes).Error; err != nil {
+			return nil, fmt.Errorf("failed to check existing discount codes: %w", err)
+		}
+		existing = append(existing, discountCodes...)
+	}
+
+	return existing, nil
+}
+
+// IncrementUsage records a redemption of a code, failing once the usage limit is reached
+func (r *DiscountCodeRepository) IncrementUsage(codeID uint) error {
+	// The conditional update keeps concurrent checkouts from redeeming a single-use code twice
+	result := r.db.Model(&entity.DiscountCode{}).
+		Where("id = ? AND (usage_limit = 0 OR current_usage < usage_limit)", codeID).
+		UpdateColumns(map[string]any{
+			"current_usage":    gorm.Expr("current_usage + ?", 1),
+			"last_redeemed_at": time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to record discount code redemption: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("discount code has already been redeemed")
+	}
+	return nil
+}
+
+// ReleaseUsage undoes a redemption of a code whose order could not be created
+func (r *DiscountCodeRepository) ReleaseUsage(codeID uint) error {
+	if err := r.db.Model(&entity.DiscountCode{}).
+		Where("id = ? AND current_usage > 0", codeID).
+		UpdateColumn("current_usage", gorm.Expr("current_usage - ?", 1)).Error; err != nil {
+		return fmt.Errorf("failed to release discount code redemption: %w", err)
+	}
+	return nil
+}
+
+// GetBatchStats aggregates redemption statistics for a batch
+func (r *DiscountCodeRepository) GetBatchStats(batchID uint) (*entity.DiscountCodeBatchStats, error) {
+	var result struct {
+		TotalCodes       int
+		RedeemedCodes    int
+		ExhaustedCodes   int
+		TotalRedemptions int
+	}
+
+	if err := r.db.Model(&entity.DiscountCode{}).
+		Select(`COUNT(*) AS total_codes,
+			COALESCE(SUM(CASE WHEN current_usage > 0 THEN 1 ELSE 0 END), 0) AS redeemed_codes,
+			COALESCE(SUM(CASE WHEN usage_limit > 0 AND current_usage >= usage_limit THEN 1 ELSE 0 END), 0) AS exhausted_codes,
+			COALESCE(SUM(current_usage), 0) AS total_redemptions`).
+		Where("batch_id = ?", batchID).
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch discount code batch stats: %w", err)
+	}
+
+	return &entity.DiscountCodeBatchStats{
+		TotalCodes:       result.TotalCodes,
+		RedeemedCodes:    result.RedeemedCodes,
+		ExhaustedCodes:   result.ExhaustedCodes,
+		TotalRedemptions: result.TotalRedemptions,
+	}, nil
+}
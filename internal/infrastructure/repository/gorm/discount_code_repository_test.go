@@ -0,0 +1,106 @@
+package gorm
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestDiscountCodeRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	discountRepo := NewDiscountRepository(db)
+	repo := NewDiscountCodeRepository(db)
+
+	discount, err := entity.NewDiscount(
+		"CAMPAIGN-RULE",
+		entity.DiscountTypeBasket,
+		entity.DiscountMethodPercentage,
+		10,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		time.Now().Add(-time.Hour),
+		time.Now().Add(24*time.Hour),
+		0,
+	)
+	require.NoError(t, err)
+	require.NoError(t, discountRepo.Create(discount))
+
+	batch, err := entity.NewDiscountCodeBatch(discount.ID, "Campaign", "CMP-", entity.DiscountCodeBatchSourceGenerated, 1)
+	require.NoError(t, err)
+	for i := range 600 {
+		require.NoError(t, batch.AddCode(fmt.Sprintf("CMP-%04d", i)))
+	}
+
+	t.Run("CreateBatch stores batch and codes", func(t *testing.T) {
+		require.NoError(t, repo.CreateBatch(batch))
+		assert.NotZero(t, batch.ID)
+
+		codes, err := repo.ListCodesByBatch(batch.ID)
+		require.NoError(t, err)
+		assert.Len(t, codes, 600)
+		assert.Equal(t, batch.ID, codes[0].BatchID)
+		assert.Equal(t, discount.ID, codes[0].DiscountID)
+	})
+
+	t.Run("GetByCode preloads discount and is case-insensitive", func(t *testing.T) {
+		code, err := repo.GetByCode("cmp-0001")
+		require.NoError(t, err)
+		assert.Equal(t, "CMP-0001", code.Code)
+		require.NotNil(t, code.Discount)
+		assert.Equal(t, "CAMPAIGN-RULE", code.Discount.Code)
+	})
+
+	t.Run("FindExistingCodes checks batch codes and discounts", func(t *testing.T) {
+		existing, err := repo.FindExistingCodes([]string{"CMP-0002", "CAMPAIGN-RULE", "FREE-CODE"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"CMP-0002", "CAMPAIGN-RULE"}, existing)
+	})
+
+	t.Run("IncrementUsage and GetBatchStats", func(t *testing.T) {
+		code, err := repo.GetByCode("CMP-0003")
+		require.NoError(t, err)
+		require.NoError(t, repo.IncrementUsage(code.ID))
+
+		updated, err := repo.GetByCode("CMP-0003")
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated.CurrentUsage)
+		assert.NotNil(t, updated.LastRedeemedAt)
+		assert.False(t, updated.IsRedeemable())
+
+		// A second redemption of the single-use code fails without counting it
+		assert.EqualError(t, repo.IncrementUsage(code.ID), "discount code has already been redeemed")
+		require.NoError(t, repo.ReleaseUsage(code.ID))
+		require.NoError(t, repo.IncrementUsage(code.ID))
+		updated, err = repo.GetByCode("CMP-0003")
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated.CurrentUsage)
+
+		stats, err := repo.GetBatchStats(batch.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 600, stats.TotalCodes)
+		assert.Equal(t, 1, stats.RedeemedCodes)
+		assert.Equal(t, 1, stats.ExhaustedCodes)
+		assert.Equal(t, 1, stats.TotalRedemptions)
+	})
+
+	t.Run("DeleteBatch removes codes", func(t *testing.T) {
+		require.NoError(t, repo.DeleteBatch(batch.ID))
+
+		_, err := repo.GetBatchByID(batch.ID)
+		assert.Error(t, err)
+
+		_, err = repo.GetByCode("CMP-0001")
+		assert.Error(t, err)
+	})
+}
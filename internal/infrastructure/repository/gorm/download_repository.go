@@ -0,0 +1,122 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DigitalFileRepository implements repository.DigitalFileRepository using GORM
+type DigitalFileRepository struct {
+	db *gorm.DB
+}
+
+// NewDigitalFileRepository creates a new GORM-based DigitalFileRepository
+func NewDigitalFileRepository(db *gorm.DB) repository.DigitalFileRepository {
+	return &DigitalFileRepository{db: db}
+}
+
+// Create implements repository.DigitalFileRepository.
+func (r *DigitalFileRepository) Create(file *entity.DigitalFile) error {
+	if err := r.db.Create(file).Error; err != nil {
+		return fmt.Errorf("failed to create digital file: %w", err)
+	}
+	return nil
+}
+
+// GetByID implements repository.DigitalFileRepository.
+func (r *DigitalFileRepository) GetByID(fileID uint) (*entity.DigitalFile, error) {
+	var file entity.DigitalFile
+	if err := r.db.First(&file, fileID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("digital file with ID %d not found", fileID)
+		}
+		return nil, fmt.Errorf("failed to fetch digital file: %w", err)
+	}
+	return &file, nil
+}
+
+// ListByVariant implements repository.DigitalFileRepository.
+func (r *DigitalFileRepository) ListByVariant(variantID uint) ([]*entity.DigitalFile, error) {
+	var files []*entity.DigitalFile
+	if err := r.db.Where("variant_id = ?", variantID).Order("id").Find(&files).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch digital files: %w", err)
+	}
+	return files, nil
+}
+
+// Delete implements repository.DigitalFileRepository.
+func (r *DigitalFileRepository) Delete(fileID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("digital_file_id = ?", fileID).Delete(&entity.OrderDownload{}).Error; err != nil {
+			return fmt.Errorf("failed to delete order downloads: %w", err)
+		}
+		if err := tx.Delete(&entity.DigitalFile{}, fileID).Error; err != nil {
+			return fmt.Errorf("failed to delete digital file: %w", err)
+		}
+		return nil
+	})
+}
+
+// OrderDownloadRepository implements repository.OrderDownloadRepository using GORM
+type OrderDownloadRepository struct {
+	db *gorm.DB
+}
+
+// NewOrderDownloadRepository creates a new GORM-based OrderDownloadRepository
+func NewOrderDownloadRepository(db *gorm.DB) repository.OrderDownloadRepository {
+	return &OrderDownloadRepository{db: db}
+}
+
+// CreateMissing implements repository.OrderDownloadRepository.
+// Downloads granted before keep their count and expiry.
+func (r *OrderDownloadRepository) CreateMissing(downloads []*entity.OrderDownload) error {
+	if len(downloads) == 0 {
+		return nil
+	}
+	if err := r.db.Omit("DigitalFile").Clauses(clause.OnConflict{DoNothing: true}).Create(downloads).Error; err != nil {
+		return fmt.Errorf("failed to create order downloads: %w", err)
+	}
+	return nil
+}
+
+// GetByID implements repository.OrderDownloadRepository.
+func (r *OrderDownloadRepository) GetByID(downloadID uint) (*entity.OrderDownload, error) {
+	var download entity.OrderDownload
+	if err := r.db.Preload("DigitalFile").First(&download, downloadID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("download with ID %d not found", downloadID)
+		}
+		return nil, fmt.Errorf("failed to fetch download: %w", err)
+	}
+	return &download, nil
+}
+
+// ListByOrder implements repository.OrderDownloadRepository.
+func (r *OrderDownloadRepository) ListByOrder(orderID uint) ([]*entity.OrderDownload, error) {
+	var downloads []*entity.OrderDownload
+	if err := r.db.Preload("DigitalFile").Where("order_id = ?", orderID).Order("order_item_id, digital_file_id").
+		Find(&downloads).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch order downloads: %w", err)
+	}
+	return downloads, nil
+}
+
+// RecordDownload implements repository.OrderDownloadRepository.
+// The count is checked and increased in one statement, so concurrent downloads cannot exceed the limit.
+func (r *OrderDownloadRepository) RecordDownload(downloadID uint) error {
+	result := r.db.Model(&entity.OrderDownload{}).
+		Where("id = ? AND (max_downloads = 0 OR download_count < max_downloads)", downloadID).
+		Update("download_count", gorm.Expr("download_count + 1"))
+	if result.Error != nil {
+		return fmt.Errorf("failed to record download: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("download limit reached")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// ExchangeRateHistoryRepository implements repository.ExchangeRateHistoryRepository using GORM
+type ExchangeRateHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewExchangeRateHistoryRepository creates a new GORM-based ExchangeRateHistoryRepository
+func NewExchangeRateHistoryRepository(db *gorm.DB) repository.ExchangeRateHistoryRepository {
+	return &ExchangeRateHistoryRepository{db: db}
+}
+
+// Create implements repository.ExchangeRateHistoryRepository.
+func (r *ExchangeRateHistoryRepository) Create(history *entity.ExchangeRateHistory) error {
+	if err := r.db.Create(history).Error; err != nil {
+		return fmt.Errorf("failed to create exchange rate history: %w", err)
+	}
+	return nil
+}
+
+// ListByCurrency implements repository.ExchangeRateHistoryRepository.
+// An empty code lists the history of all currencies, newest first.
+func (r *ExchangeRateHistoryRepository) ListByCurrency(code string, offset, limit int) ([]*entity.ExchangeRateHistory, error) {
+	var history []*entity.ExchangeRateHistory
+
+	query := r.db.Model(&entity.ExchangeRateHistory{})
+	if code != "" {
+		query = query.Where("currency_code = ?", strings.ToUpper(code))
+	}
+
+	if err := query.Order("created_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange rate history: %w", err)
+	}
+	return history, nil
+}
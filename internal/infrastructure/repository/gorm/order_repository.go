@@ -24,7 +24,7 @@ func (o *OrderRepository) Create(order *entity.Order) error {
 // GetByCheckoutSessionID implements repository.OrderRepository.
 func (o *OrderRepository) GetByCheckoutSessionID(checkoutSessionID string) (*entity.Order, error) {
 	var order entity.Order
-	if err := o.db.Preload("Items").Preload("Items.Product").Preload("Items.ProductVariant").
+	if err := o.db.Preload("Items").Preload("Items.Product").Preload("Items.ProductVariant").Preload("Items.Components").
 		Preload("User").Preload("PaymentTransactions").
 		Where("checkout_session_id = ?", checkoutSessionID).First(&order).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -38,7 +38,7 @@ func (o *OrderRepository) GetByCheckoutSessionID(checkoutSessionID string) (*ent
 // GetByID implements repository.OrderRepository.
 func (o *OrderRepository) GetByID(orderID uint) (*entity.Order, error) {
 	var order entity.Order
-	if err := o.db.Preload("Items").Preload("Items.Product").Preload("Items.ProductVariant").
+	if err := o.db.Preload("Items").Preload("Items.Product").Preload("Items.ProductVariant").Preload("Items.Components").
 		Preload("User").Preload("PaymentTransactions").
 		First(&order, orderID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -52,7 +52,7 @@ func (o *OrderRepository) GetByID(orderID uint) (*entity.Order, error) {
 // GetByPaymentID implements repository.OrderRepository.
 func (o *OrderRepository) GetByPaymentID(paymentID string) (*entity.Order, error) {
 	var order entity.Order
-	if err := o.db.Preload("Items").Preload("Items.Product").Preload("Items.ProductVariant").
+	if err := o.db.Preload("Items").Preload("Items.Product").Preload("Items.ProductVariant").Preload("Items.Components").
 		Preload("User").Preload("PaymentTransactions").
 		Where("payment_id = ?", paymentID).First(&order).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -66,7 +66,7 @@ func (o *OrderRepository) GetByPaymentID(paymentID string) (*entity.Order, error
 // GetByUser implements repository.OrderRepository.
 func (o *OrderRepository) GetByUser(userID uint, offset int, limit int) ([]*entity.Order, error) {
 	var orders []*entity.Order
-	if err := o.db.Preload("Items").Preload("Items.Product").Preload("Items.ProductVariant").
+	if err := o.db.Preload("Items").Preload("Items.Product").Preload("Items.ProductVariant").Preload("Items.Components").
 		Where("user_id = ?", userID).
 		Offset(offset).Limit(limit).
 		Order("created_at DESC").
@@ -89,6 +89,25 @@ func (o *OrderRepository) HasOrdersWithProduct(productID uint) (bool, error) {
 	return count > 0, nil
 }
 
+// FindPaidOrderIDWithProduct implements repository.OrderRepository.
+func (o *OrderRepository) FindPaidOrderIDWithProduct(userID, productID uint) (uint, error) {
+	var orderIDs []uint
+	err := o.db.Model(&entity.Order{}).
+		Joins("JOIN order_items ON order_items.order_id = orders.id AND order_items.deleted_at IS NULL").
+		Where("orders.user_id = ? AND order_items.product_id = ?", userID, productID).
+		Where("orders.payment_status IN ?", []entity.PaymentStatus{entity.PaymentStatusAuthorized, entity.PaymentStatusCaptured}).
+		Order("orders.created_at DESC").
+		Limit(1).
+		Pluck("orders.id", &orderIDs).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to find paid orders with product %d: %w", productID, err)
+	}
+	if len(orderIDs) == 0 {
+		return 0, nil
+	}
+	return orderIDs[0], nil
+}
+
 // IsDiscountIdUsed implements repository.OrderRepository.
 func (o *OrderRepository) IsDiscountIdUsed(discountID uint) (bool, error) {
 	var count int64
@@ -102,9 +121,9 @@ func (o *OrderRepository) IsDiscountIdUsed(discountID uint) (bool, error) {
 }
 
 // ListAll implements repository.OrderRepository.
-func (o *OrderRepository) ListAll(offset int, limit int) ([]*entity.Order, error) {
+func (o *OrderRepository) ListAll(offset int, limit int, customFields []entity.CustomFieldFilter) ([]*entity.Order, error) {
 	var orders []*entity.Order
-	if err := o.db.Preload("Items").Preload("Items.Product").Preload("Items.ProductVariant").
+	if err := whereCustomFields(o.db, "orders.custom_fields", customFields).Preload("Items").Preload("Items.Product").Preload("Items.ProductVariant").Preload("Items.Components").
 		Preload("User").
 		Offset(offset).Limit(limit).
 		Order("created_at DESC").
@@ -115,9 +134,9 @@ func (o *OrderRepository) ListAll(offset int, limit int) ([]*entity.Order, error
 }
 
 // ListByStatus implements repository.OrderRepository.
-func (o *OrderRepository) ListByStatus(status entity.OrderStatus, offset int, limit int) ([]*entity.Order, error) {
+func (o *OrderRepository) ListByStatus(status entity.OrderStatus, offset int, limit int, customFields []entity.CustomFieldFilter) ([]*entity.Order, error) {
 	var orders []*entity.Order
-	if err := o.db.Preload("Items").Preload("Items.Product").Preload("Items.ProductVariant").
+	if err := whereCustomFields(o.db, "orders.custom_fields", customFields).Preload("Items").Preload("Items.Product").Preload("Items.ProductVariant").Preload("Items.Components").
 		Preload("User").
 		Where("status = ?", status).
 		Offset(offset).Limit(limit).
@@ -134,16 +153,28 @@ func (o *OrderRepository) Update(order *entity.Order) error {
 }
 
 // GetTotalRevenueByDateRange implements repository.OrderRepository.
+// Revenue is restated in the default currency using the exchange rate snapshotted on each order.
 func (o *OrderRepository) GetTotalRevenueByDateRange(startDate, endDate time.Time) (int64, error) {
-	var totalRevenue int64
+	var rows []struct {
+		Currency     string
+		BaseCurrency string
+		ExchangeRate float64
+		Total        int64
+	}
 	err := o.db.Model(&entity.Order{}).
 		Where("created_at >= ? AND created_at <= ? AND status IN (?)",
 			startDate, endDate, []entity.OrderStatus{entity.OrderStatusPaid, entity.OrderStatusShipped, entity.OrderStatusCompleted}).
-		Select("COALESCE(SUM(total_amount), 0)").
-		Scan(&totalRevenue).Error
+		Select("currency, base_currency, exchange_rate, COALESCE(SUM(total_amount), 0) as total").
+		Group("currency, base_currency, exchange_rate").
+		Scan(&rows).Error
 	if err != nil {
 		return 0, fmt.Errorf("failed to get total revenue: %w", err)
 	}
+
+	var totalRevenue int64
+	for _, row := range rows {
+		totalRevenue += entity.RestateAmount(row.Total, row.Currency, row.BaseCurrency, row.ExchangeRate)
+	}
 	return totalRevenue, nil
 }
 
@@ -0,0 +1,122 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// PriceListRepository implements repository.PriceListRepository using GORM
+type PriceListRepository struct {
+	db *gorm.DB
+}
+
+// NewPriceListRepository creates a new GORM-based PriceListRepository
+func NewPriceListRepository(db *gorm.DB) repository.PriceListRepository {
+	return &PriceListRepository{db: db}
+}
+
+// Create implements repository.PriceListRepository.
+func (r *PriceListRepository) Create(priceList *entity.PriceList) error {
+	if err := r.db.Create(priceList).Error; err != nil {
+		return fmt.Errorf("failed to create price list: %w", err)
+	}
+	return nil
+}
+
+// GetByID implements repository.PriceListRepository.
+func (r *PriceListRepository) GetByID(priceListID uint) (*entity.PriceList, error) {
+	var priceList entity.PriceList
+	if err := r.withEntries(r.db).First(&priceList, priceListID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("price list with ID %d not found", priceListID)
+		}
+		return nil, fmt.Errorf("failed to fetch price list: %w", err)
+	}
+	return &priceList, nil
+}
+
+// Update implements repository.PriceListRepository.
+// Entries are not touched, use ReplaceEntries to change them.
+func (r *PriceListRepository) Update(priceList *entity.PriceList) error {
+	if err := r.db.Omit("Entries").Save(priceList).Error; err != nil {
+		return fmt.Errorf("failed to update price list: %w", err)
+	}
+	return nil
+}
+
+// Delete implements repository.PriceListRepository.
+// Groups using the price list fall back to regular prices.
+func (r *PriceListRepository) Delete(priceListID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.CustomerGroup{}).
+			Where("price_list_id = ?", priceListID).
+			Update("price_list_id", nil).Error; err != nil {
+			return fmt.Errorf("failed to detach price list from customer groups: %w", err)
+		}
+
+		if err := tx.Unscoped().Where("price_list_id = ?", priceListID).Delete(&entity.PriceListEntry{}).Error; err != nil {
+			return fmt.Errorf("failed to delete price list entries: %w", err)
+		}
+
+		if err := tx.Delete(&entity.PriceList{}, priceListID).Error; err != nil {
+			return fmt.Errorf("failed to delete price list: %w", err)
+		}
+		return nil
+	})
+}
+
+// List implements repository.PriceListRepository.
+func (r *PriceListRepository) List(offset, limit int) ([]*entity.PriceList, error) {
+	var priceLists []*entity.PriceList
+	if err := r.db.Order("name ASC").
+		Offset(offset).Limit(limit).
+		Find(&priceLists).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch price lists: %w", err)
+	}
+	return priceLists, nil
+}
+
+// ReplaceEntries implements repository.PriceListRepository.
+func (r *PriceListRepository) ReplaceEntries(priceList *entity.PriceList) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Entries are removed permanently so the unique index can be reused
+		if err := tx.Unscoped().Where("price_list_id = ?", priceList.ID).Delete(&entity.PriceListEntry{}).Error; err != nil {
+			return fmt.Errorf("failed to delete price list entries: %w", err)
+		}
+
+		if len(priceList.Entries) > 0 {
+			if err := tx.Omit("ProductVariant").Create(&priceList.Entries).Error; err != nil {
+				return fmt.Errorf("failed to create price list entries: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetByUserID implements repository.PriceListRepository.
+func (r *PriceListRepository) GetByUserID(userID uint) (*entity.PriceList, error) {
+	var priceList entity.PriceList
+	err := r.withEntries(r.db).
+		Joins("JOIN customer_groups ON customer_groups.price_list_id = price_lists.id AND customer_groups.deleted_at IS NULL").
+		Joins("JOIN users ON users.customer_group_id = customer_groups.id").
+		Where("users.id = ?", userID).
+		First(&priceList).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("no price list found for user with ID %d", userID)
+		}
+		return nil, fmt.Errorf("failed to fetch price list for user: %w", err)
+	}
+	return &priceList, nil
+}
+
+// withEntries preloads the entries of a price list together with their variants
+func (r *PriceListRepository) withEntries(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("product_variant_id ASC, currency ASC, min_quantity ASC")
+	}).Preload("Entries.ProductVariant")
+}
@@ -0,0 +1,83 @@
+package gorm
+
+import (
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// ProductAssociationRepository implements repository.ProductAssociationRepository using GORM
+type ProductAssociationRepository struct {
+	db *gorm.DB
+}
+
+// NewProductAssociationRepository creates a new GORM-based ProductAssociationRepository
+func NewProductAssociationRepository(db *gorm.DB) repository.ProductAssociationRepository {
+	return &ProductAssociationRepository{db: db}
+}
+
+// ListByProducts implements repository.ProductAssociationRepository.
+func (r *ProductAssociationRepository) ListByProducts(productIDs []uint) ([]*entity.ProductAssociation, error) {
+	associations := []*entity.ProductAssociation{}
+	if len(productIDs) == 0 {
+		return associations, nil
+	}
+
+	if err := r.db.Where("product_id IN ?", productIDs).
+		Order("type, position, id").
+		Find(&associations).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch product associations: %w", err)
+	}
+	return associations, nil
+}
+
+// Replace implements repository.ProductAssociationRepository.
+func (r *ProductAssociationRepository) Replace(productID uint, associationType entity.AssociationType, associations []*entity.ProductAssociation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ? AND type = ?", productID, associationType).
+			Delete(&entity.ProductAssociation{}).Error; err != nil {
+			return fmt.Errorf("failed to delete product associations: %w", err)
+		}
+		if len(associations) == 0 {
+			return nil
+		}
+		if err := tx.Create(associations).Error; err != nil {
+			return fmt.Errorf("failed to create product associations: %w", err)
+		}
+		return nil
+	})
+}
+
+// FrequentlyBoughtTogether implements repository.ProductAssociationRepository.
+func (r *ProductAssociationRepository) FrequentlyBoughtTogether(productIDs []uint, limit int) ([]uint, error) {
+	if len(productIDs) == 0 {
+		return []uint{}, nil
+	}
+
+	var rows []struct {
+		ProductID  uint
+		OrderCount int
+	}
+	// Each order counts once per product, however many of the given products it contains
+	if err := r.db.Table("order_items AS base").
+		Select("other.product_id AS product_id, COUNT(DISTINCT other.order_id) AS order_count").
+		Joins("JOIN order_items AS other ON other.order_id = base.order_id AND other.deleted_at IS NULL").
+		Joins("JOIN orders ON orders.id = base.order_id AND orders.deleted_at IS NULL").
+		Where("base.product_id IN ? AND base.deleted_at IS NULL", productIDs).
+		Where("other.product_id NOT IN ?", productIDs).
+		Where("orders.payment_status IN ?", []entity.PaymentStatus{entity.PaymentStatusAuthorized, entity.PaymentStatusCaptured}).
+		Group("other.product_id").
+		Order("order_count DESC, other.product_id").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to compute frequently bought together products: %w", err)
+	}
+
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ProductID
+	}
+	return ids, nil
+}
@@ -3,6 +3,8 @@ package gorm
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/repository"
@@ -19,16 +21,31 @@ func NewProductRepository(db *gorm.DB) repository.ProductRepository {
 	return &ProductRepository{db: db}
 }
 
-// Create creates a new product with its variants
+// Create creates a new product with its variants and adds it to the search index.
+// A slug is generated from the name when the product has none.
 func (r *ProductRepository) Create(product *entity.Product) error {
-	// GORM will automatically create associated variants due to the relationship definition
-	return r.db.Create(product).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := assignSlug(tx, &entity.Product{}, entity.SlugResourceProduct, product.ID, &product.Slug, product.Name); err != nil {
+			return err
+		}
+
+		// GORM will automatically create associated variants due to the relationship definition
+		if err := tx.Create(product).Error; err != nil {
+			return err
+		}
+		if err := saveSlugRedirect(tx, entity.SlugResourceProduct, product.ID, "", product.Slug); err != nil {
+			return err
+		}
+		return indexProduct(tx, product.ID)
+	})
 }
 
 // GetByID retrieves a product by ID with all related data
 func (r *ProductRepository) GetByID(productID uint) (*entity.Product, error) {
 	var product entity.Product
-	if err := r.db.Preload("Variants").Preload("Category").First(&product, productID).Error; err != nil {
+	if err := preloadBundleComponents(r.db.Preload("Variants").Preload("Category"), "Variants").
+		Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		First(&product, productID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("product with ID %d not found", productID)
 		}
@@ -37,10 +54,27 @@ func (r *ProductRepository) GetByID(productID uint) (*entity.Product, error) {
 	return &product, nil
 }
 
+// GetBySlug retrieves a product by its current slug or a former slug that redirects to it
+func (r *ProductRepository) GetBySlug(slug string) (*entity.Product, error) {
+	var product entity.Product
+	err := r.db.Select("id").Where("slug = ?", slug).First(&product).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		productID, err := findSlugRedirect(r.db, entity.SlugResourceProduct, slug)
+		if err != nil {
+			return nil, err
+		}
+		return r.GetByID(productID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch product by slug: %w", err)
+	}
+	return r.GetByID(product.ID)
+}
+
 // GetBySKU retrieves a product by variant SKU
 func (r *ProductRepository) GetBySKU(sku string) (*entity.Product, error) {
 	var product entity.Product
-	if err := r.db.Preload("Variants").Preload("Category").
+	if err := preloadBundleComponents(r.db.Preload("Variants").Preload("Category"), "Variants").
 		Joins("JOIN product_variants ON products.id = product_variants.product_id").
 		Where("product_variants.sku = ?", sku).First(&product).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -56,53 +90,127 @@ func (r *ProductRepository) GetBySKU(sku string) (*entity.Product, error) {
 	return &product, nil
 }
 
-// GetByIDAndCurrency retrieves a product by ID, filtering for the specified currency
+// GetByIDAndCurrency retrieves a product by ID with its prices expressed in the specified currency.
+// Explicit variant prices are used when present, otherwise prices are converted using exchange rates.
 func (r *ProductRepository) GetByIDAndCurrency(productID uint, currency string) (*entity.Product, error) {
-	var product entity.Product
+	product, err := r.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
 
-	// Build the query
-	query := r.db.Preload("Category")
+	// Ensure product has variants loaded
+	if len(product.Variants) == 0 {
+		return nil, fmt.Errorf("product with ID %d has no variants", productID)
+	}
 
-	// Filter variants by currency if specified, otherwise load all variants
-	if currency != "" {
-		query = query.Preload("Variants", "price IS NOT NULL") // Basic validation that variant has a price
-		query = query.Where("currency = ?", currency)
-	} else {
-		query = query.Preload("Variants")
+	if currency == "" || product.Currency == currency {
+		return product, nil
 	}
 
-	if err := query.First(&product, productID).Error; err != nil {
+	var productCurrency, targetCurrency entity.Currency
+	if err := r.db.Where("code = ?", product.Currency).First(&productCurrency).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch currency %s: %w", product.Currency, err)
+	}
+	if err := r.db.Where("code = ?", currency).First(&targetCurrency).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("product with ID %d not found", productID)
+			return nil, fmt.Errorf("currency %s not found", currency)
 		}
-		return nil, fmt.Errorf("failed to fetch product: %w", err)
+		return nil, fmt.Errorf("failed to fetch currency %s: %w", currency, err)
 	}
 
-	// Ensure product has variants loaded
-	if len(product.Variants) == 0 {
-		return nil, fmt.Errorf("product with ID %d has no variants for currency %s", productID, currency)
-	}
+	product.ApplyCurrency(&productCurrency, &targetCurrency)
 
-	return &product, nil
+	return product, nil
 }
 
-// Update updates an existing product and its variants
+// Update updates an existing product and its variants and refreshes its search document.
+// A changed slug keeps the previous slug as a redirect.
 func (r *ProductRepository) Update(product *entity.Product) error {
-	// Use Select to explicitly update all fields including CategoryID
-	return r.db.Select("name", "description", "currency", "category_id", "images", "active", "updated_at").
-		Session(&gorm.Session{FullSaveAssociations: true}).
-		Save(product).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var previous string
+		if err := tx.Model(&entity.Product{}).Where("id = ?", product.ID).Pluck("slug", &previous).Error; err != nil {
+			return fmt.Errorf("failed to fetch product slug: %w", err)
+		}
+		if err := assignSlug(tx, &entity.Product{}, entity.SlugResourceProduct, product.ID, &product.Slug, product.Name); err != nil {
+			return err
+		}
+
+		// Use Select to explicitly update all fields including CategoryID
+		if err := tx.Select("type", "name", "slug", "description", "meta_title", "meta_description", "canonical_url",
+			"currency", "category_id", "images", "active", "publish_at", "unpublish_at", "channels", "updated_at").
+			Session(&gorm.Session{FullSaveAssociations: true}).
+			Save(product).Error; err != nil {
+			return err
+		}
+		if err := saveSlugRedirect(tx, entity.SlugResourceProduct, product.ID, previous, product.Slug); err != nil {
+			return err
+		}
+		return indexProduct(tx, product.ID)
+	})
+}
+
+// SetOptions replaces the option definitions of a product
+func (r *ProductRepository) SetOptions(productID uint, options []*entity.ProductOption) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("product_id = ?", productID).Delete(&entity.ProductOption{}).Error; err != nil {
+			return fmt.Errorf("failed to delete product options: %w", err)
+		}
+
+		for _, option := range options {
+			option.ID = 0
+			option.ProductID = productID
+		}
+		if len(options) > 0 {
+			if err := tx.Create(options).Error; err != nil {
+				return fmt.Errorf("failed to create product options: %w", err)
+			}
+		}
+		return nil
+	})
 }
 
 // Delete deletes a product by ID and its associated variants (hard deletion)
 func (r *ProductRepository) Delete(productID uint) error {
 	// Use a transaction to ensure data consistency
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// First, hard delete all variants for this product
+		// First, hard delete all variants for this product with their bundle components
+		if err := tx.Where("bundle_variant_id IN (?)", tx.Model(&entity.ProductVariant{}).Unscoped().Select("id").Where("product_id = ?", productID)).
+			Delete(&entity.BundleComponent{}).Error; err != nil {
+			return fmt.Errorf("failed to delete bundle components: %w", err)
+		}
 		if err := tx.Unscoped().Where("product_id = ?", productID).Delete(&entity.ProductVariant{}).Error; err != nil {
 			return fmt.Errorf("failed to delete product variants: %w", err)
 		}
 
+		if err := tx.Unscoped().Where("product_id = ?", productID).Delete(&entity.ProductOption{}).Error; err != nil {
+			return fmt.Errorf("failed to delete product options: %w", err)
+		}
+
+		if err := tx.Where("resource_type = ? AND resource_id = ?", entity.SlugResourceProduct, productID).
+			Delete(&entity.SlugRedirect{}).Error; err != nil {
+			return fmt.Errorf("failed to delete product slug redirects: %w", err)
+		}
+
+		if err := tx.Where("product_id = ?", productID).Delete(&entity.ProductSearchDocument{}).Error; err != nil {
+			return fmt.Errorf("failed to remove product from search index: %w", err)
+		}
+		if err := tx.Where("product_id = ?", productID).Delete(&entity.ProductSearchTerm{}).Error; err != nil {
+			return fmt.Errorf("failed to remove product from search vocabulary: %w", err)
+		}
+
+		if err := tx.Where("product_id = ? OR associated_product_id = ?", productID, productID).
+			Delete(&entity.ProductAssociation{}).Error; err != nil {
+			return fmt.Errorf("failed to delete product associations: %w", err)
+		}
+
+		if err := tx.Where("product_id = ?", productID).Delete(&entity.CollectionProduct{}).Error; err != nil {
+			return fmt.Errorf("failed to remove product from collections: %w", err)
+		}
+
+		if err := deleteTranslations(tx, entity.TranslationResourceProduct, productID); err != nil {
+			return err
+		}
+
 		// Then hard delete the product itself
 		if err := tx.Unscoped().Delete(&entity.Product{}, productID).Error; err != nil {
 			return fmt.Errorf("failed to delete product: %w", err)
@@ -112,30 +220,32 @@ func (r *ProductRepository) Delete(productID uint) error {
 	})
 }
 
-// List retrieves products with filtering and pagination
-func (r *ProductRepository) List(query, currency string, categoryID, offset, limit uint, minPriceCents, maxPriceCents int64, active bool) ([]*entity.Product, error) {
+// List retrieves products with filtering and pagination. With a sales channel only the products
+// visible in the channel are listed, otherwise all products.
+func (r *ProductRepository) List(query, currency string, categoryID, offset, limit uint, minPriceCents, maxPriceCents int64, channel entity.SalesChannel, customFields []entity.CustomFieldFilter) ([]*entity.Product, error) {
 	var products []*entity.Product
 
 	tx := r.db.Model(&entity.Product{})
 
 	// Apply filters
 	if query != "" {
-		tx = tx.Where("name ILIKE ? OR description ILIKE ?", "%"+query+"%", "%"+query+"%")
+		pattern := "%" + strings.ToLower(query) + "%"
+		tx = tx.Where("LOWER(name) LIKE ? OR LOWER(description) LIKE ?", pattern, pattern)
 	}
 
 	if categoryID > 0 {
-		tx = tx.Where("category_id = ?", categoryID)
+		tx = whereInCategoryTree(tx, "products.category_id", categoryID)
 	}
 
 	if currency != "" {
 		tx = tx.Where("currency = ?", currency)
 	}
 
-	// Active filter: if active=true, only show active products; if active=false, show all products
-	if active {
-		tx = tx.Where("active = ?", true)
+	if channel != "" {
+		tx = visibleProducts(tx, channel)
 	}
-	// If active is false, don't add any filter to show all products (active and inactive)
+
+	tx = whereCustomFields(tx, "products.custom_fields", customFields)
 
 	// Price filtering requires joining with variants
 	if minPriceCents > 0 || maxPriceCents > 0 {
@@ -153,8 +263,8 @@ func (r *ProductRepository) List(query, currency string, categoryID, offset, lim
 	}
 
 	// Apply pagination and load relationships
-	if err := tx.Offset(int(offset)).Limit(int(limit)).
-		Preload("Variants").Preload("Category").
+	if err := preloadBundleComponents(tx.Offset(int(offset)).Limit(int(limit)).
+		Preload("Variants").Preload("Category"), "Variants").
 		Find(&products).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch products: %w", err)
 	}
@@ -163,30 +273,31 @@ func (r *ProductRepository) List(query, currency string, categoryID, offset, lim
 }
 
 // Count returns the total count of products matching the filter criteria
-func (r *ProductRepository) Count(searchQuery, currency string, categoryID uint, minPriceCents, maxPriceCents int64, active bool) (int, error) {
+func (r *ProductRepository) Count(searchQuery, currency string, categoryID uint, minPriceCents, maxPriceCents int64, channel entity.SalesChannel, customFields []entity.CustomFieldFilter) (int, error) {
 	var count int64
 
 	tx := r.db.Model(&entity.Product{})
 
 	// Apply same filters as List method
 	if searchQuery != "" {
-		tx = tx.Where("name ILIKE ? OR description ILIKE ?", "%"+searchQuery+"%", "%"+searchQuery+"%")
+		pattern := "%" + strings.ToLower(searchQuery) + "%"
+		tx = tx.Where("LOWER(name) LIKE ? OR LOWER(description) LIKE ?", pattern, pattern)
 	}
 
 	if categoryID > 0 {
-		tx = tx.Where("category_id = ?", categoryID)
+		tx = whereInCategoryTree(tx, "products.category_id", categoryID)
 	}
 
 	if currency != "" {
 		tx = tx.Where("currency = ?", currency)
 	}
 
-	// Only filter by active status if active=true
-	// If active=false, return all products (active and inactive)
-	if active {
-		tx = tx.Where("active = ?", true)
+	if channel != "" {
+		tx = visibleProducts(tx, channel)
 	}
 
+	tx = whereCustomFields(tx, "products.custom_fields", customFields)
+
 	// Price filtering requires joining with variants
 	if minPriceCents > 0 || maxPriceCents > 0 {
 		tx = tx.Joins("JOIN product_variants ON products.id = product_variants.product_id")
@@ -209,6 +320,33 @@ func (r *ProductRepository) Count(searchQuery, currency string, categoryID uint,
 	return int(count), nil
 }
 
+// ListWithVariants retrieves products with their variants ordered by ID, without pagination.
+// A nil productIDs lists all products, otherwise only the given products are listed.
+// With a sales channel only the products visible in the channel are listed.
+func (r *ProductRepository) ListWithVariants(currency string, channel entity.SalesChannel, productIDs []uint) ([]*entity.Product, error) {
+	products := []*entity.Product{}
+	if productIDs != nil && len(productIDs) == 0 {
+		return products, nil
+	}
+
+	tx := r.db.Model(&entity.Product{})
+	if productIDs != nil {
+		tx = tx.Where("id IN ?", productIDs)
+	}
+	if currency != "" {
+		tx = tx.Where("currency = ?", currency)
+	}
+	if channel != "" {
+		tx = visibleProducts(tx, channel)
+	}
+
+	if err := preloadBundleComponents(tx.Order("id").Preload("Variants"), "Variants").Find(&products).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch products: %w", err)
+	}
+
+	return products, nil
+}
+
 // HasProductsWithCategory checks if any products exist for the given category
 func (r *ProductRepository) HasProductsWithCategory(categoryID uint) (bool, error) {
 	var count int64
@@ -245,3 +383,13 @@ func (r *ProductRepository) GetLowStockProductsCount(lowStockThreshold int) (int
 
 	return count, nil
 }
+
+// visibleProducts restricts a product query to the products customers of the sales channel can see
+// now: active products inside their publishing window that are limited to no channels or include the channel
+func visibleProducts(tx *gorm.DB, channel entity.SalesChannel) *gorm.DB {
+	now := time.Now()
+	return tx.Where("products.active = ?", true).
+		Where("(products.publish_at IS NULL OR products.publish_at <= ?)", now).
+		Where("(products.unpublish_at IS NULL OR products.unpublish_at > ?)", now).
+		Where("(products.channels IS NULL OR products.channels IN ('', 'null', '[]') OR products.channels LIKE ?)", `%"`+string(channel)+`"%`)
+}
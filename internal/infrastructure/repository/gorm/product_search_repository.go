@@ -0,0 +1,419 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxSearchTerms caps the number of terms taken from a search query
+const maxSearchTerms = 10
+
+// maxVocabularyTermLength is the length of the longest word kept in the vocabulary
+const maxVocabularyTermLength = 255
+
+// productSearchBackend runs a full-text query against one kind of search index
+type productSearchBackend interface {
+	search(query repository.ProductSearchQuery, terms []string) ([]repository.ProductSearchHit, int, error)
+}
+
+// ProductSearchRepository implements repository.ProductSearchRepository using the
+// full-text features of the configured database
+type ProductSearchRepository struct {
+	db      *gorm.DB
+	backend productSearchBackend
+}
+
+// NewProductSearchRepository creates a new ProductSearchRepository.
+// PostgreSQL uses tsvector ranking, SQLite uses FTS5 when the index table exists and LIKE matching otherwise.
+func NewProductSearchRepository(db *gorm.DB) repository.ProductSearchRepository {
+	var backend productSearchBackend
+	switch {
+	case db.Dialector.Name() == "postgres":
+		backend = &postgresProductSearch{db: db}
+	case db.Dialector.Name() == "sqlite" && db.Migrator().HasTable("product_search_fts"):
+		backend = &fts5ProductSearch{db: db}
+	default:
+		backend = &likeProductSearch{db: db}
+	}
+
+	return &ProductSearchRepository{db: db, backend: backend}
+}
+
+// Search returns products matching the query sorted by relevance.
+// When nothing matches, misspelled terms are replaced with the closest indexed terms and the search is retried.
+func (r *ProductSearchRepository) Search(query repository.ProductSearchQuery) ([]repository.ProductSearchHit, int, error) {
+	terms := searchTerms(query.Query)
+	if len(terms) == 0 {
+		return []repository.ProductSearchHit{}, 0, nil
+	}
+
+	hits, total, err := r.backend.search(query, terms)
+	if err != nil || total > 0 {
+		return hits, total, err
+	}
+
+	vocabulary, err := r.vocabulary()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	corrected, changed := correctSearchTerms(terms, vocabulary)
+	if !changed {
+		return hits, total, nil
+	}
+	return r.backend.search(query, corrected)
+}
+
+// Rebuild reindexes every product
+func (r *ProductSearchRepository) Rebuild() (int, error) {
+	indexed := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&entity.ProductSearchDocument{}).Error; err != nil {
+			return fmt.Errorf("failed to clear product search index: %w", err)
+		}
+		if err := tx.Where("1 = 1").Delete(&entity.ProductSearchTerm{}).Error; err != nil {
+			return fmt.Errorf("failed to clear product search vocabulary: %w", err)
+		}
+
+		var products []*entity.Product
+		return tx.Preload("Variants").Preload("Category").
+			FindInBatches(&products, 100, func(_ *gorm.DB, _ int) error {
+				for _, product := range products {
+					if err := saveSearchDocument(tx, product); err != nil {
+						return err
+					}
+				}
+				indexed += len(products)
+				return nil
+			}).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to rebuild product search index: %w", err)
+	}
+
+	return indexed, nil
+}
+
+// IsStale reports whether products are missing from the index, the index holds deleted products
+// or the vocabulary of the indexed products was never built
+func (r *ProductSearchRepository) IsStale() (bool, error) {
+	var missing, orphaned, documents, terms int64
+	if err := r.db.Model(&entity.Product{}).
+		Joins("LEFT JOIN product_search_documents ON product_search_documents.product_id = products.id").
+		Where("product_search_documents.product_id IS NULL").
+		Count(&missing).Error; err != nil {
+		return false, fmt.Errorf("failed to check product search index: %w", err)
+	}
+
+	if err := r.db.Model(&entity.ProductSearchDocument{}).
+		Where("product_id NOT IN (?)", r.db.Model(&entity.Product{}).Select("id")).
+		Count(&orphaned).Error; err != nil {
+		return false, fmt.Errorf("failed to check product search index: %w", err)
+	}
+
+	if err := r.db.Model(&entity.ProductSearchDocument{}).Count(&documents).Error; err != nil {
+		return false, fmt.Errorf("failed to check product search index: %w", err)
+	}
+	if err := r.db.Model(&entity.ProductSearchTerm{}).Count(&terms).Error; err != nil {
+		return false, fmt.Errorf("failed to check product search vocabulary: %w", err)
+	}
+
+	return missing > 0 || orphaned > 0 || (documents > 0 && terms == 0), nil
+}
+
+// vocabulary returns the distinct words of all search documents, which are kept up to date when
+// products are indexed
+func (r *ProductSearchRepository) vocabulary() ([]string, error) {
+	var words []string
+	if err := r.db.Model(&entity.ProductSearchTerm{}).Distinct("term").Order("term").Pluck("term", &words).Error; err != nil {
+		return nil, fmt.Errorf("failed to load search vocabulary: %w", err)
+	}
+	return words, nil
+}
+
+// indexProduct rebuilds the search document of a product from its current data
+func indexProduct(db *gorm.DB, productID uint) error {
+	var product entity.Product
+	if err := db.Preload("Variants").Preload("Category").First(&product, productID).Error; err != nil {
+		return fmt.Errorf("failed to load product %d for search indexing: %w", productID, err)
+	}
+	return saveSearchDocument(db, &product)
+}
+
+// saveSearchDocument inserts or replaces the search document of a product
+func saveSearchDocument(db *gorm.DB, product *entity.Product) error {
+	document := entity.NewProductSearchDocument(product, product.Category.Name)
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}},
+		UpdateAll: true,
+	}).Create(document).Error; err != nil {
+		return fmt.Errorf("failed to index product %d for search: %w", product.ID, err)
+	}
+	return saveSearchTerms(db, document)
+}
+
+// saveSearchTerms replaces the vocabulary terms of a product with the distinct words of its search
+// document. The words are taken from the document text rather than the index, which holds stemmed terms.
+func saveSearchTerms(db *gorm.DB, document *entity.ProductSearchDocument) error {
+	if err := db.Where("product_id = ?", document.ProductID).Delete(&entity.ProductSearchTerm{}).Error; err != nil {
+		return fmt.Errorf("failed to clear search terms of product %d: %w", document.ProductID, err)
+	}
+
+	seen := make(map[string]bool)
+	var terms []*entity.ProductSearchTerm
+	for _, text := range document.Fields() {
+		for _, word := range tokenizeSearchText(text) {
+			if !seen[word] && len(word) <= maxVocabularyTermLength {
+				seen[word] = true
+				terms = append(terms, &entity.ProductSearchTerm{ProductID: document.ProductID, Term: word})
+			}
+		}
+	}
+	if len(terms) == 0 {
+		return nil
+	}
+	if err := db.CreateInBatches(terms, 500).Error; err != nil {
+		return fmt.Errorf("failed to save search terms of product %d: %w", document.ProductID, err)
+	}
+	return nil
+}
+
+// productSearchRow is a raw search result with highlighted fields
+type productSearchRow struct {
+	ProductID             uint
+	SearchRank            float64
+	NameHighlight         string
+	DescriptionHighlight  string
+	SkusHighlight         string
+	AttributesHighlight   string
+	CategoryNameHighlight string
+}
+
+// toHit converts the row to a search hit keeping only the highlights that contain a match
+func (row productSearchRow) toHit() repository.ProductSearchHit {
+	highlights := make(map[string]string)
+	for field, value := range map[string]string{
+		entity.SearchFieldName:        row.NameHighlight,
+		entity.SearchFieldDescription: row.DescriptionHighlight,
+		entity.SearchFieldSKUs:        row.SkusHighlight,
+		entity.SearchFieldAttributes:  row.AttributesHighlight,
+		entity.SearchFieldCategory:    row.CategoryNameHighlight,
+	} {
+		if strings.Contains(value, highlightStart) {
+			highlights[field] = value
+		}
+	}
+
+	return repository.ProductSearchHit{
+		ProductID:  row.ProductID,
+		Rank:       row.SearchRank,
+		Highlights: highlights,
+	}
+}
+
+// applyProductSearchFilters applies the non-text filters of a search to a query joined with products
+func applyProductSearchFilters(tx *gorm.DB, query repository.ProductSearchQuery) *gorm.DB {
+	tx = tx.Where("products.deleted_at IS NULL")
+
+	if query.CategoryID > 0 {
+		tx = whereInCategoryTree(tx, "products.category_id", query.CategoryID)
+	}
+	if query.Currency != "" {
+		tx = tx.Where("products.currency = ?", query.Currency)
+	}
+	if query.ActiveOnly {
+		channel := query.Channel
+		if channel == "" {
+			channel = entity.SalesChannelWebshop
+		}
+		tx = visibleProducts(tx, channel)
+	}
+	tx = whereCustomFields(tx, "products.custom_fields", query.CustomFields)
+
+	if query.MinPriceCents > 0 || query.MaxPriceCents > 0 {
+		variants := "SELECT 1 FROM product_variants WHERE product_variants.product_id = products.id"
+		args := []any{}
+		if query.MinPriceCents > 0 {
+			variants += " AND product_variants.price >= ?"
+			args = append(args, query.MinPriceCents)
+		}
+		if query.MaxPriceCents > 0 {
+			variants += " AND product_variants.price <= ?"
+			args = append(args, query.MaxPriceCents)
+		}
+		tx = tx.Where("EXISTS ("+variants+")", args...)
+	}
+
+	return tx
+}
+
+// findSearchRows counts the matches of a filtered search query and loads the requested page
+func findSearchRows(tx *gorm.DB, query repository.ProductSearchQuery, selectQuery string, selectArgs ...any) ([]productSearchRow, int, error) {
+	var total int64
+	if err := tx.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	var rows []productSearchRow
+	tx = tx.Select(selectQuery, selectArgs...).Order("search_rank DESC").Order("products.id")
+	if query.Limit > 0 {
+		tx = tx.Limit(int(query.Limit))
+	}
+	if err := tx.Offset(int(query.Offset)).Scan(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to search products: %w", err)
+	}
+
+	return rows, int(total), nil
+}
+
+// toSearchHits converts search rows to hits
+func toSearchHits(rows []productSearchRow) []repository.ProductSearchHit {
+	hits := make([]repository.ProductSearchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = row.toHit()
+	}
+	return hits
+}
+
+// postgresProductSearch searches the weighted tsvector column with ts_rank and ts_headline
+type postgresProductSearch struct {
+	db *gorm.DB
+}
+
+func (s *postgresProductSearch) search(query repository.ProductSearchQuery, terms []string) ([]repository.ProductSearchHit, int, error) {
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		prefixes[i] = term + ":*"
+	}
+
+	tx := s.db.Table("product_search_documents AS d CROSS JOIN to_tsquery('english', ?) AS q", strings.Join(prefixes, " & ")).
+		Joins("JOIN products ON products.id = d.product_id").
+		Where("d.search_vector @@ q")
+	tx = applyProductSearchFilters(tx, query)
+
+	headline := func(column, options string) string {
+		return "ts_headline('english', coalesce(d." + column + ", ''), q, 'StartSel=" + highlightStart + ", StopSel=" + highlightEnd + ", " + options + "')"
+	}
+
+	rows, total, err := findSearchRows(tx, query, strings.Join([]string{
+		"d.product_id AS product_id",
+		"ts_rank(d.search_vector, q) AS search_rank",
+		headline("name", "HighlightAll=true") + " AS name_highlight",
+		headline("description", "MaxWords=30, MinWords=15") + " AS description_highlight",
+		headline("skus", "HighlightAll=true") + " AS skus_highlight",
+		headline("attributes", "HighlightAll=true") + " AS attributes_highlight",
+		headline("category_name", "HighlightAll=true") + " AS category_name_highlight",
+	}, ", "))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return toSearchHits(rows), total, nil
+}
+
+// fts5ProductSearch searches the SQLite FTS5 index with bm25 ranking
+type fts5ProductSearch struct {
+	db *gorm.DB
+}
+
+func (s *fts5ProductSearch) search(query repository.ProductSearchQuery, terms []string) ([]repository.ProductSearchHit, int, error) {
+	phrases := make([]string, len(terms))
+	for i, term := range terms {
+		phrases[i] = `"` + term + `"*`
+	}
+
+	tx := s.db.Table("product_search_fts").
+		Joins("JOIN products ON products.id = product_search_fts.rowid").
+		Where("product_search_fts MATCH ?", strings.Join(phrases, " AND "))
+	tx = applyProductSearchFilters(tx, query)
+
+	highlight := func(column int) string {
+		return fmt.Sprintf("highlight(product_search_fts, %d, '%s', '%s')", column, highlightStart, highlightEnd)
+	}
+
+	// bm25 is lower for better matches; the column weights follow the tsvector weights used on PostgreSQL
+	rows, total, err := findSearchRows(tx, query, strings.Join([]string{
+		"product_search_fts.rowid AS product_id",
+		"-bm25(product_search_fts, 10.0, 2.0, 10.0, 4.0, 4.0) AS search_rank",
+		highlight(0) + " AS name_highlight",
+		fmt.Sprintf("snippet(product_search_fts, 1, '%s', '%s', '…', 24) AS description_highlight", highlightStart, highlightEnd),
+		highlight(2) + " AS skus_highlight",
+		highlight(3) + " AS attributes_highlight",
+		highlight(4) + " AS category_name_highlight",
+	}, ", "))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return toSearchHits(rows), total, nil
+}
+
+// likeProductSearch matches terms with LIKE when no full-text index is available.
+// Ranking uses the same field weights as the full-text backends and highlighting is done in Go.
+type likeProductSearch struct {
+	db *gorm.DB
+}
+
+// likeSearchWeights are the rank contributions of a term found in each document column
+var likeSearchWeights = []struct {
+	column string
+	weight int
+}{
+	{"name", 10},
+	{"description", 2},
+	{"skus", 10},
+	{"attributes", 4},
+	{"category_name", 4},
+}
+
+func (s *likeProductSearch) search(query repository.ProductSearchQuery, terms []string) ([]repository.ProductSearchHit, int, error) {
+	tx := s.db.Table("product_search_documents AS d").
+		Joins("JOIN products ON products.id = d.product_id")
+
+	rankParts := make([]string, 0, len(terms)*len(likeSearchWeights))
+	rankArgs := make([]any, 0, len(terms)*len(likeSearchWeights))
+	for _, term := range terms {
+		pattern := "%" + term + "%"
+		matches := make([]string, len(likeSearchWeights))
+		matchArgs := make([]any, len(likeSearchWeights))
+		for i, w := range likeSearchWeights {
+			matches[i] = "LOWER(d." + w.column + ") LIKE ?"
+			matchArgs[i] = pattern
+			rankParts = append(rankParts, fmt.Sprintf("CASE WHEN LOWER(d.%s) LIKE ? THEN %d ELSE 0 END", w.column, w.weight))
+			rankArgs = append(rankArgs, pattern)
+		}
+		tx = tx.Where("("+strings.Join(matches, " OR ")+")", matchArgs...)
+	}
+	tx = applyProductSearchFilters(tx, query)
+
+	rows, total, err := findSearchRows(tx, query, strings.Join([]string{
+		"d.product_id AS product_id",
+		"(" + strings.Join(rankParts, " + ") + ") AS search_rank",
+		"d.name AS name_highlight",
+		"d.description AS description_highlight",
+		"d.skus AS skus_highlight",
+		"d.attributes AS attributes_highlight",
+		"d.category_name AS category_name_highlight",
+	}, ", "), rankArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range rows {
+		rows[i].NameHighlight = highlightTerms(rows[i].NameHighlight, terms)
+		rows[i].DescriptionHighlight = snippetTerms(rows[i].DescriptionHighlight, terms, 24)
+		rows[i].SkusHighlight = highlightTerms(rows[i].SkusHighlight, terms)
+		rows[i].AttributesHighlight = highlightTerms(rows[i].AttributesHighlight, terms)
+		rows[i].CategoryNameHighlight = highlightTerms(rows[i].CategoryNameHighlight, terms)
+	}
+
+	return toSearchHits(rows), total, nil
+}
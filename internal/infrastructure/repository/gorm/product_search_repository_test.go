@@ -0,0 +1,233 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestProductSearchRepository(t *testing.T) {
+	setup := func(t *testing.T) (*gorm.DB, repository.ProductRepository, repository.ProductSearchRepository) {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+
+		productRepo := NewProductRepository(db)
+		return db, productRepo, NewProductSearchRepository(db)
+	}
+
+	createCategory := func(t *testing.T, db *gorm.DB, name string) *entity.Category {
+		category, err := entity.NewCategory(name, "", nil)
+		require.NoError(t, err)
+		require.NoError(t, db.Create(category).Error)
+		return category
+	}
+
+	createProduct := func(t *testing.T, productRepo repository.ProductRepository, categoryID uint, name, description, sku string, price int64, attributes map[string]string) *entity.Product {
+		variant, err := entity.NewProductVariant(sku, 10, price, 1, attributes, nil, true)
+		require.NoError(t, err)
+		product, err := entity.NewProduct(name, description, "USD", categoryID, nil, []*entity.ProductVariant{variant}, true)
+		require.NoError(t, err)
+		require.NoError(t, productRepo.Create(product))
+		return product
+	}
+
+	seed := func(t *testing.T, db *gorm.DB, productRepo repository.ProductRepository) (*entity.Category, *entity.Product, *entity.Product, *entity.Product) {
+		furniture := createCategory(t, db, "Furniture")
+		lighting := createCategory(t, db, "Lighting")
+
+		chair := createProduct(t, productRepo, furniture.ID, "Oak Chair", "A sturdy dining chair made from solid oak", "CHAIR-OAK", 15000, map[string]string{"color": "Natural"})
+		lamp := createProduct(t, productRepo, lighting.ID, "Desk Lamp", "Adjustable lamp for reading next to your oak chair", "LAMP-01", 4000, map[string]string{"color": "Crimson"})
+		table := createProduct(t, productRepo, furniture.ID, "Dining Table", "Extendable table for six", "TABLE-XL", 60000, nil)
+
+		return furniture, chair, lamp, table
+	}
+
+	productIDs := func(hits []repository.ProductSearchHit) []uint {
+		ids := make([]uint, len(hits))
+		for i, hit := range hits {
+			ids[i] = hit.ProductID
+		}
+		return ids
+	}
+
+	t.Run("Matches are sorted by relevance and highlighted", func(t *testing.T) {
+		db, productRepo, searchRepo := setup(t)
+		_, chair, lamp, _ := seed(t, db, productRepo)
+
+		hits, total, err := searchRepo.Search(repository.ProductSearchQuery{Query: "oak", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Equal(t, []uint{chair.ID, lamp.ID}, productIDs(hits))
+		assert.Greater(t, hits[0].Rank, hits[1].Rank)
+		assert.Contains(t, hits[0].Highlights[entity.SearchFieldName], "<mark>Oak</mark>")
+		assert.Contains(t, hits[1].Highlights[entity.SearchFieldDescription], "<mark>oak</mark>")
+		assert.NotContains(t, hits[1].Highlights, entity.SearchFieldName)
+	})
+
+	t.Run("SKUs, variant attributes and category names are indexed", func(t *testing.T) {
+		db, productRepo, searchRepo := setup(t)
+		_, chair, lamp, table := seed(t, db, productRepo)
+
+		hits, _, err := searchRepo.Search(repository.ProductSearchQuery{Query: "TABLE-XL", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{table.ID}, productIDs(hits))
+
+		hits, _, err = searchRepo.Search(repository.ProductSearchQuery{Query: "crimson", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{lamp.ID}, productIDs(hits))
+		assert.Contains(t, hits[0].Highlights[entity.SearchFieldAttributes], "<mark>Crimson</mark>")
+
+		hits, total, err := searchRepo.Search(repository.ProductSearchQuery{Query: "furniture", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.ElementsMatch(t, []uint{chair.ID, table.ID}, productIDs(hits))
+	})
+
+	t.Run("All terms must match and prefixes match whole words", func(t *testing.T) {
+		db, productRepo, searchRepo := setup(t)
+		_, _, lamp, _ := seed(t, db, productRepo)
+
+		hits, _, err := searchRepo.Search(repository.ProductSearchQuery{Query: "adjust lamp", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{lamp.ID}, productIDs(hits))
+
+		_, total, err := searchRepo.Search(repository.ProductSearchQuery{Query: "lamp sofa", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+	})
+
+	t.Run("Misspelled terms are corrected", func(t *testing.T) {
+		db, productRepo, searchRepo := setup(t)
+		_, _, _, table := seed(t, db, productRepo)
+
+		hits, total, err := searchRepo.Search(repository.ProductSearchQuery{Query: "extnedable", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, []uint{table.ID}, productIDs(hits))
+	})
+
+	t.Run("Filters and pagination apply to matches", func(t *testing.T) {
+		db, productRepo, searchRepo := setup(t)
+		furniture, chair, lamp, _ := seed(t, db, productRepo)
+
+		hits, _, err := searchRepo.Search(repository.ProductSearchQuery{Query: "oak", CategoryID: furniture.ID, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{chair.ID}, productIDs(hits))
+
+		hits, _, err = searchRepo.Search(repository.ProductSearchQuery{Query: "oak", MaxPriceCents: 5000, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{lamp.ID}, productIDs(hits))
+
+		hits, total, err := searchRepo.Search(repository.ProductSearchQuery{Query: "oak", Offset: 1, Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Equal(t, []uint{lamp.ID}, productIDs(hits))
+
+		chair.Active = false
+		require.NoError(t, productRepo.Update(chair))
+		hits, _, err = searchRepo.Search(repository.ProductSearchQuery{Query: "chair", ActiveOnly: true, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{lamp.ID}, productIDs(hits))
+	})
+
+	t.Run("Index follows product and category changes", func(t *testing.T) {
+		db, productRepo, searchRepo := setup(t)
+		furniture, chair, _, table := seed(t, db, productRepo)
+
+		chair.Name = "Walnut Chair"
+		require.NoError(t, productRepo.Update(chair))
+		hits, _, err := searchRepo.Search(repository.ProductSearchQuery{Query: "walnut", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{chair.ID}, productIDs(hits))
+
+		furniture.Name = "Home Decor"
+		require.NoError(t, NewCategoryRepository(db).Update(furniture))
+		hits, _, err = searchRepo.Search(repository.ProductSearchQuery{Query: "decor", Limit: 10})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uint{chair.ID, table.ID}, productIDs(hits))
+		hits, _, err = searchRepo.Search(repository.ProductSearchQuery{Query: "decro", Limit: 10})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uint{chair.ID, table.ID}, productIDs(hits))
+
+		require.NoError(t, productRepo.Delete(table.ID))
+		hits, _, err = searchRepo.Search(repository.ProductSearchQuery{Query: "decor", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{chair.ID}, productIDs(hits))
+
+		var terms int64
+		require.NoError(t, db.Model(&entity.ProductSearchTerm{}).Where("product_id = ?", table.ID).Count(&terms).Error)
+		assert.Zero(t, terms)
+	})
+
+	t.Run("Rebuild indexes products written directly", func(t *testing.T) {
+		db, _, searchRepo := setup(t)
+		product := testutil.CreateTestProduct(t, db, 1)
+
+		stale, err := searchRepo.IsStale()
+		require.NoError(t, err)
+		assert.True(t, stale)
+
+		indexed, err := searchRepo.Rebuild()
+		require.NoError(t, err)
+		assert.Equal(t, 1, indexed)
+
+		stale, err = searchRepo.IsStale()
+		require.NoError(t, err)
+		assert.False(t, stale)
+
+		// Indexes built before the vocabulary existed are rebuilt as well
+		require.NoError(t, db.Where("1 = 1").Delete(&entity.ProductSearchTerm{}).Error)
+		stale, err = searchRepo.IsStale()
+		require.NoError(t, err)
+		assert.True(t, stale)
+		_, err = searchRepo.Rebuild()
+		require.NoError(t, err)
+
+		hits, _, err := searchRepo.Search(repository.ProductSearchQuery{Query: "test product", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{product.ID}, productIDs(hits))
+	})
+
+	t.Run("Empty queries match nothing", func(t *testing.T) {
+		db, productRepo, searchRepo := setup(t)
+		seed(t, db, productRepo)
+
+		hits, total, err := searchRepo.Search(repository.ProductSearchQuery{Query: " -- ", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.Empty(t, hits)
+	})
+}
+
+func TestProductSearchText(t *testing.T) {
+	t.Run("searchTerms splits on punctuation and removes duplicates", func(t *testing.T) {
+		assert.Equal(t, []string{"chair", "oak", "1"}, searchTerms(`Chair "OAK"-1 chair`))
+	})
+
+	t.Run("highlightTerms is case-insensitive", func(t *testing.T) {
+		assert.Equal(t, "<mark>Oak</mark> chair, <mark>oak</mark>en", highlightTerms("Oak chair, oaken", []string{"oak"}))
+	})
+
+	t.Run("editDistance counts transpositions as one edit", func(t *testing.T) {
+		assert.Equal(t, 1, editDistance("chiar", "chair"))
+		assert.Equal(t, 1, editDistance("lamp", "lamps"))
+		assert.Equal(t, 3, editDistance("oak", "elm"))
+	})
+
+	t.Run("correctSearchTerms keeps known and short terms", func(t *testing.T) {
+		vocabulary := []string{"chair", "table", "tables"}
+
+		corrected, changed := correctSearchTerms([]string{"tabel", "chair", "tbl"}, vocabulary)
+		assert.True(t, changed)
+		assert.Equal(t, []string{"table", "chair", "tbl"}, corrected)
+
+		_, changed = correctSearchTerms([]string{"tab", "sofa"}, vocabulary)
+		assert.False(t, changed)
+	})
+}
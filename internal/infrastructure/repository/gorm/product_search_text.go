@@ -0,0 +1,159 @@
+package gorm
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Markers wrapped around matched terms in search highlights
+const (
+	highlightStart = "<mark>"
+	highlightEnd   = "</mark>"
+)
+
+// tokenizeSearchText splits text into lower-case words of letters and digits,
+// mirroring how the full-text indexes tokenize documents
+func tokenizeSearchText(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// searchTerms returns the distinct words of a search query. Terms only contain
+// letters and digits, so they are safe to embed in full-text query syntax.
+func searchTerms(query string) []string {
+	terms := make([]string, 0)
+	for _, word := range tokenizeSearchText(query) {
+		if !slices.Contains(terms, word) {
+			terms = append(terms, word)
+		}
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// highlightTerms wraps every case-insensitive occurrence of the terms in highlight markers
+func highlightTerms(text string, terms []string) string {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		matched := 0
+		for _, term := range terms {
+			termRunes := []rune(term)
+			if len(termRunes) > matched && i+len(termRunes) <= len(lower) && string(lower[i:i+len(termRunes)]) == term {
+				matched = len(termRunes)
+			}
+		}
+
+		if matched == 0 {
+			b.WriteRune(runes[i])
+			i++
+			continue
+		}
+
+		b.WriteString(highlightStart)
+		b.WriteString(string(runes[i : i+matched]))
+		b.WriteString(highlightEnd)
+		i += matched
+	}
+	return b.String()
+}
+
+// snippetTerms returns up to maxWords words of text around the first matched term with the terms highlighted
+func snippetTerms(text string, terms []string, maxWords int) string {
+	words := strings.Fields(text)
+	first := slices.IndexFunc(words, func(word string) bool {
+		word = strings.ToLower(word)
+		return slices.ContainsFunc(terms, func(term string) bool { return strings.Contains(word, term) })
+	})
+	if first < 0 || len(words) <= maxWords {
+		return highlightTerms(text, terms)
+	}
+
+	start := max(0, first-maxWords/4)
+	end := min(len(words), start+maxWords)
+
+	snippet := strings.Join(words[start:end], " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(words) {
+		snippet += "…"
+	}
+	return highlightTerms(snippet, terms)
+}
+
+// correctSearchTerms replaces terms that are not in the vocabulary with the closest
+// vocabulary word within a small edit distance. Short terms and numbers are kept as they are.
+func correctSearchTerms(terms, vocabulary []string) ([]string, bool) {
+	corrected := make([]string, len(terms))
+	changed := false
+
+	for i, term := range terms {
+		corrected[i] = term
+
+		length := len([]rune(term))
+		if length < 4 || strings.IndexFunc(term, unicode.IsLetter) < 0 {
+			continue
+		}
+		maxEdits := 1
+		if length >= 8 {
+			maxEdits = 2
+		}
+
+		best, bestDistance := "", maxEdits+1
+		for _, word := range vocabulary {
+			if word == term || strings.HasPrefix(word, term) {
+				best = ""
+				break
+			}
+			if distance := editDistance(term, word); distance < bestDistance || (distance == bestDistance && word < best) {
+				best, bestDistance = word, distance
+			}
+		}
+
+		if best != "" {
+			corrected[i] = best
+			changed = true
+		}
+	}
+
+	return corrected, changed
+}
+
+// editDistance returns the optimal string alignment distance between a and b,
+// counting insertions, deletions, substitutions and transpositions of adjacent letters
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(ra)][len(rb)]
+}
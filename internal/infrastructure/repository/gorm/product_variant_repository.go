@@ -19,24 +19,41 @@ func NewProductVariantRepository(db *gorm.DB) repository.ProductVariantRepositor
 	return &ProductVariantRepository{db: db}
 }
 
-// Create creates a new product variant
+// Create creates a new product variant and adds its SKU and attributes to the product's search document
 func (r *ProductVariantRepository) Create(variant *entity.ProductVariant) error {
-	return r.db.Create(variant).Error
+	return r.BatchCreate([]*entity.ProductVariant{variant})
 }
 
-// BatchCreate creates multiple variants at once
+// BatchCreate creates multiple variants at once and refreshes the search documents of their products
 func (r *ProductVariantRepository) BatchCreate(variants []*entity.ProductVariant) error {
 	if len(variants) == 0 {
 		return nil
 	}
-	// Use GORM's CreateInBatches for better performance
-	return r.db.CreateInBatches(variants, 100).Error
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Use GORM's CreateInBatches for better performance
+		if err := tx.CreateInBatches(variants, 100).Error; err != nil {
+			return err
+		}
+
+		indexed := make(map[uint]bool)
+		for _, variant := range variants {
+			if variant.ProductID == 0 || indexed[variant.ProductID] {
+				continue
+			}
+			indexed[variant.ProductID] = true
+			if err := indexProduct(tx, variant.ProductID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // GetByID retrieves a variant by ID with product relationship
 func (r *ProductVariantRepository) GetByID(variantID uint) (*entity.ProductVariant, error) {
 	var variant entity.ProductVariant
-	if err := r.db.Preload("Product").First(&variant, variantID).Error; err != nil {
+	if err := preloadBundleComponents(r.db.Preload("Product"), "").First(&variant, variantID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("variant with ID %d not found", variantID)
 		}
@@ -48,7 +65,7 @@ func (r *ProductVariantRepository) GetByID(variantID uint) (*entity.ProductVaria
 // GetBySKU retrieves a variant by SKU with product relationship
 func (r *ProductVariantRepository) GetBySKU(sku string) (*entity.ProductVariant, error) {
 	var variant entity.ProductVariant
-	if err := r.db.Preload("Product").Where("sku = ?", sku).First(&variant).Error; err != nil {
+	if err := preloadBundleComponents(r.db.Preload("Product"), "").Where("sku = ?", sku).First(&variant).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("variant with SKU %s not found", sku)
 		}
@@ -60,18 +77,66 @@ func (r *ProductVariantRepository) GetBySKU(sku string) (*entity.ProductVariant,
 // GetByProduct retrieves all variants for a product with product relationship
 func (r *ProductVariantRepository) GetByProduct(productID uint) ([]*entity.ProductVariant, error) {
 	var variants []*entity.ProductVariant
-	if err := r.db.Preload("Product").Where("product_id = ?", productID).Find(&variants).Error; err != nil {
+	if err := preloadBundleComponents(r.db.Preload("Product"), "").Where("product_id = ?", productID).Find(&variants).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch variants for product %d: %w", productID, err)
 	}
 	return variants, nil
 }
 
-// Update updates an existing variant
+// Update updates an existing variant. Bundle components are saved with SetComponents.
 func (r *ProductVariantRepository) Update(variant *entity.ProductVariant) error {
-	return r.db.Save(variant).Error
+	return r.db.Omit("Components").Save(variant).Error
 }
 
-// Delete deletes a variant by ID
+// Delete deletes a variant by ID with its bundle components
 func (r *ProductVariantRepository) Delete(variantID uint) error {
-	return r.db.Delete(&entity.ProductVariant{}, variantID).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bundle_variant_id = ?", variantID).Delete(&entity.BundleComponent{}).Error; err != nil {
+			return fmt.Errorf("failed to delete bundle components: %w", err)
+		}
+		return tx.Delete(&entity.ProductVariant{}, variantID).Error
+	})
+}
+
+// SetComponents replaces the components of a bundle variant
+func (r *ProductVariantRepository) SetComponents(bundleVariantID uint, components []*entity.BundleComponent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bundle_variant_id = ?", bundleVariantID).Delete(&entity.BundleComponent{}).Error; err != nil {
+			return fmt.Errorf("failed to delete bundle components: %w", err)
+		}
+
+		for _, component := range components {
+			component.ID = 0
+			component.BundleVariantID = bundleVariantID
+		}
+		if len(components) > 0 {
+			if err := tx.Omit("ComponentVariant").Create(components).Error; err != nil {
+				return fmt.Errorf("failed to create bundle components: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// IsBundleComponent checks if the variant is a component of any bundle variant
+func (r *ProductVariantRepository) IsBundleComponent(variantID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&entity.BundleComponent{}).
+		Joins("JOIN product_variants ON product_variants.id = bundle_components.bundle_variant_id AND product_variants.deleted_at IS NULL").
+		Where("bundle_components.component_variant_id = ?", variantID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check bundles with variant %d: %w", variantID, err)
+	}
+	return count > 0, nil
+}
+
+// preloadBundleComponents loads the bundle components of the variants at the given association path,
+// in the order they were added, with their component variants and products
+func preloadBundleComponents(db *gorm.DB, variants string) *gorm.DB {
+	components := "Components"
+	if variants != "" {
+		components = variants + ".Components"
+	}
+	return db.Preload(components, func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload(components + ".ComponentVariant.Product")
 }
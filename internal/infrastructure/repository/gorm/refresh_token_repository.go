@@ -0,0 +1,73 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// RefreshTokenRepository implements repository.RefreshTokenRepository using GORM
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository creates a new GORM-based RefreshTokenRepository
+func NewRefreshTokenRepository(db *gorm.DB) repository.RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+// Create implements repository.RefreshTokenRepository.
+func (r *RefreshTokenRepository) Create(token *entity.RefreshToken) error {
+	if err := r.db.Create(token).Error; err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetByHash implements repository.RefreshTokenRepository.
+func (r *RefreshTokenRepository) GetByHash(tokenHash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("refresh token not found")
+		}
+		return nil, fmt.Errorf("failed to fetch refresh token: %w", err)
+	}
+	return &token, nil
+}
+
+// Revoke implements repository.RefreshTokenRepository.
+func (r *RefreshTokenRepository) Revoke(tokenID uint, now time.Time) (bool, error) {
+	// The conditional update lets only one request rotate the token
+	result := r.db.Model(&entity.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", tokenID).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to revoke refresh token: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RevokeSession implements repository.RefreshTokenRepository.
+func (r *RefreshTokenRepository) RevokeSession(sessionID string, now time.Time) error {
+	if err := r.db.Model(&entity.RefreshToken{}).
+		Where("session_id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", now).Error; err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// RevokeByUser implements repository.RefreshTokenRepository.
+func (r *RefreshTokenRepository) RevokeByUser(userID uint, now time.Time) error {
+	if err := r.db.Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error; err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// ReviewRepository implements repository.ReviewRepository using GORM
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewRepository creates a new GORM-based ReviewRepository
+func NewReviewRepository(db *gorm.DB) repository.ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+// Create implements repository.ReviewRepository.
+func (r *ReviewRepository) Create(review *entity.Review) error {
+	if err := r.db.Create(review).Error; err != nil {
+		return fmt.Errorf("failed to create review: %w", err)
+	}
+	return nil
+}
+
+// GetByID implements repository.ReviewRepository.
+func (r *ReviewRepository) GetByID(reviewID uint) (*entity.Review, error) {
+	var review entity.Review
+	if err := r.db.First(&review, reviewID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("review with ID %d not found", reviewID)
+		}
+		return nil, fmt.Errorf("failed to fetch review: %w", err)
+	}
+	return &review, nil
+}
+
+// GetByProductAndUser implements repository.ReviewRepository.
+func (r *ReviewRepository) GetByProductAndUser(productID, userID uint) (*entity.Review, error) {
+	var review entity.Review
+	if err := r.db.Where("product_id = ? AND user_id = ?", productID, userID).First(&review).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("review of product %d by user %d not found", productID, userID)
+		}
+		return nil, fmt.Errorf("failed to fetch review: %w", err)
+	}
+	return &review, nil
+}
+
+// Update implements repository.ReviewRepository.
+func (r *ReviewRepository) Update(review *entity.Review) error {
+	if err := r.db.Save(review).Error; err != nil {
+		return fmt.Errorf("failed to update review: %w", err)
+	}
+	return nil
+}
+
+// Delete implements repository.ReviewRepository.
+func (r *ReviewRepository) Delete(reviewID uint) error {
+	if err := r.db.Delete(&entity.Review{}, reviewID).Error; err != nil {
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+	return nil
+}
+
+// ListByProduct implements repository.ReviewRepository.
+func (r *ReviewRepository) ListByProduct(productID uint, status entity.ReviewStatus, offset, limit int) ([]*entity.Review, error) {
+	var reviews []*entity.Review
+	if err := r.db.Where("product_id = ? AND status = ?", productID, status).
+		Order("created_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&reviews).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+// CountByProduct implements repository.ReviewRepository.
+func (r *ReviewRepository) CountByProduct(productID uint, status entity.ReviewStatus) (int, error) {
+	var count int64
+	if err := r.db.Model(&entity.Review{}).
+		Where("product_id = ? AND status = ?", productID, status).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count reviews: %w", err)
+	}
+	return int(count), nil
+}
+
+// List implements repository.ReviewRepository.
+func (r *ReviewRepository) List(status entity.ReviewStatus, offset, limit int) ([]*entity.Review, error) {
+	var reviews []*entity.Review
+	if err := r.withStatus(status).
+		Order("created_at, id").
+		Offset(offset).Limit(limit).
+		Find(&reviews).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+// Count implements repository.ReviewRepository.
+func (r *ReviewRepository) Count(status entity.ReviewStatus) (int, error) {
+	var count int64
+	if err := r.withStatus(status).Model(&entity.Review{}).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count reviews: %w", err)
+	}
+	return int(count), nil
+}
+
+// RatingDistribution implements repository.ReviewRepository.
+func (r *ReviewRepository) RatingDistribution(productID uint) (map[int]int, error) {
+	var rows []struct {
+		Rating int
+		Count  int
+	}
+	if err := r.db.Model(&entity.Review{}).
+		Select("rating, COUNT(*) AS count").
+		Where("product_id = ? AND status = ?", productID, entity.ReviewStatusApproved).
+		Group("rating").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch rating distribution: %w", err)
+	}
+
+	distribution := make(map[int]int, entity.MaxReviewRating)
+	for rating := entity.MinReviewRating; rating <= entity.MaxReviewRating; rating++ {
+		distribution[rating] = 0
+	}
+	for _, row := range rows {
+		distribution[row.Rating] = row.Count
+	}
+	return distribution, nil
+}
+
+// RefreshProductRating implements repository.ReviewRepository.
+func (r *ReviewRepository) RefreshProductRating(productID uint) error {
+	var aggregate struct {
+		Average float64
+		Count   int
+	}
+	if err := r.db.Model(&entity.Review{}).
+		Select("COALESCE(AVG(rating), 0) AS average, COUNT(*) AS count").
+		Where("product_id = ? AND status = ?", productID, entity.ReviewStatusApproved).
+		Scan(&aggregate).Error; err != nil {
+		return fmt.Errorf("failed to compute product rating: %w", err)
+	}
+
+	// UpdateColumns keeps updated_at, the product itself did not change
+	if err := r.db.Model(&entity.Product{}).Where("id = ?", productID).
+		UpdateColumns(map[string]any{
+			"rating_average": math.Round(aggregate.Average*100) / 100,
+			"review_count":   aggregate.Count,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update product rating: %w", err)
+	}
+	return nil
+}
+
+// withStatus filters the reviews by status, when given
+func (r *ReviewRepository) withStatus(status entity.ReviewStatus) *gorm.DB {
+	if status == "" {
+		return r.db
+	}
+	return r.db.Where("status = ?", status)
+}
@@ -0,0 +1,81 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// RoleRepository implements repository.RoleRepository using GORM
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository creates a new GORM-based RoleRepository
+func NewRoleRepository(db *gorm.DB) repository.RoleRepository {
+	return &RoleRepository{db: db}
+}
+
+// Create implements repository.RoleRepository.
+func (r *RoleRepository) Create(role *entity.Role) error {
+	if err := r.db.Create(role).Error; err != nil {
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+	return nil
+}
+
+// GetByID implements repository.RoleRepository.
+func (r *RoleRepository) GetByID(roleID uint) (*entity.Role, error) {
+	var role entity.Role
+	if err := r.db.First(&role, roleID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: ID %d", repository.ErrRoleNotFound, roleID)
+		}
+		return nil, fmt.Errorf("failed to fetch role: %w", err)
+	}
+	return &role, nil
+}
+
+// GetByName implements repository.RoleRepository.
+func (r *RoleRepository) GetByName(name string) (*entity.Role, error) {
+	var role entity.Role
+	if err := r.db.Where("name = ?", name).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", repository.ErrRoleNotFound, name)
+		}
+		return nil, fmt.Errorf("failed to fetch role: %w", err)
+	}
+	return &role, nil
+}
+
+// Update implements repository.RoleRepository.
+func (r *RoleRepository) Update(role *entity.Role) error {
+	if err := r.db.Save(role).Error; err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+	return nil
+}
+
+// Delete implements repository.RoleRepository.
+func (r *RoleRepository) Delete(roleID uint) error {
+	result := r.db.Delete(&entity.Role{}, roleID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete role: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: ID %d", repository.ErrRoleNotFound, roleID)
+	}
+	return nil
+}
+
+// List implements repository.RoleRepository.
+func (r *RoleRepository) List() ([]*entity.Role, error) {
+	var roles []*entity.Role
+	if err := r.db.Order("name ASC").Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch roles: %w", err)
+	}
+	return roles, nil
+}
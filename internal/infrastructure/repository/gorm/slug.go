@@ -0,0 +1,97 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// assignSlug prepares the slug of a product or category before it is saved. An empty slug is
+// generated from the name and made unique among current and former slugs, while a given slug
+// must be valid and not be the current slug of another resource.
+func assignSlug(tx *gorm.DB, model any, resourceType string, id uint, slug *string, name string) error {
+	if *slug == "" {
+		base := entity.Slugify(name)
+		if base == "" {
+			base = resourceType
+		}
+
+		unique, err := entity.UniqueSlug(base, func(candidate string) (bool, error) {
+			return slugTaken(tx, model, resourceType, id, candidate, true)
+		})
+		if err != nil {
+			return err
+		}
+		*slug = unique
+		return nil
+	}
+
+	if err := entity.ValidateSlug(*slug); err != nil {
+		return err
+	}
+
+	taken, err := slugTaken(tx, model, resourceType, id, *slug, false)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return fmt.Errorf("slug %s is already in use", *slug)
+	}
+	return nil
+}
+
+// slugTaken checks whether another resource uses the slug, optionally including former slugs
+func slugTaken(tx *gorm.DB, model any, resourceType string, id uint, slug string, includeRedirects bool) (bool, error) {
+	var count int64
+	// Soft deleted resources keep their slug so they can be restored
+	if err := tx.Unscoped().Model(model).Where("slug = ? AND id <> ?", slug, id).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check slug: %w", err)
+	}
+	if count > 0 || !includeRedirects {
+		return count > 0, nil
+	}
+
+	if err := tx.Model(&entity.SlugRedirect{}).
+		Where("resource_type = ? AND slug = ? AND resource_id <> ?", resourceType, slug, id).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check slug redirects: %w", err)
+	}
+	return count > 0, nil
+}
+
+// saveSlugRedirect keeps the previous slug of a resource as a redirect and removes any redirect
+// of the current slug, which now belongs to the resource
+func saveSlugRedirect(tx *gorm.DB, resourceType string, id uint, previous, current string) error {
+	if err := tx.Where("resource_type = ? AND slug = ?", resourceType, current).
+		Delete(&entity.SlugRedirect{}).Error; err != nil {
+		return fmt.Errorf("failed to delete slug redirect: %w", err)
+	}
+
+	if previous == "" || previous == current {
+		return nil
+	}
+
+	redirect := entity.SlugRedirect{ResourceType: resourceType, Slug: previous, ResourceID: id}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "resource_type"}, {Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"resource_id"}),
+	}).Create(&redirect).Error; err != nil {
+		return fmt.Errorf("failed to save slug redirect: %w", err)
+	}
+	return nil
+}
+
+// findSlugRedirect returns the ID of the resource a former slug redirects to
+func findSlugRedirect(db *gorm.DB, resourceType, slug string) (uint, error) {
+	var redirect entity.SlugRedirect
+	if err := db.Where("resource_type = ? AND slug = ?", resourceType, slug).First(&redirect).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("%s with slug %s not found", resourceType, slug)
+		}
+		return 0, fmt.Errorf("failed to fetch slug redirect: %w", err)
+	}
+	return redirect.ResourceID, nil
+}
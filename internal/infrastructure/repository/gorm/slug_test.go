@@ -0,0 +1,85 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/testutil"
+)
+
+func TestSlugs(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	categoryRepo := NewCategoryRepository(db)
+	productRepo := NewProductRepository(db)
+
+	category, err := entity.NewCategory("Café Shirts", "", nil)
+	require.NoError(t, err)
+	require.NoError(t, categoryRepo.Create(category))
+	assert.Equal(t, "cafe-shirts", category.Slug)
+
+	newProduct := func(t *testing.T, name, sku string) *entity.Product {
+		variant, err := entity.NewProductVariant(sku, 1, 1000, 1, nil, nil, true)
+		require.NoError(t, err)
+		product, err := entity.NewProduct(name, "", "USD", category.ID, nil, []*entity.ProductVariant{variant}, true)
+		require.NoError(t, err)
+		require.NoError(t, productRepo.Create(product))
+		return product
+	}
+
+	t.Run("Generated slugs are unique", func(t *testing.T) {
+		first := newProduct(t, "Summer Tee", "TEE-1")
+		second := newProduct(t, "Summer Tee", "TEE-2")
+		assert.Equal(t, "summer-tee", first.Slug)
+		assert.Equal(t, "summer-tee-2", second.Slug)
+
+		second.Slug = "summer-tee"
+		assert.ErrorContains(t, productRepo.Update(second), "already in use")
+	})
+
+	t.Run("Former slugs redirect to the product", func(t *testing.T) {
+		product := newProduct(t, "Linen Shirt", "LINEN-1")
+		product.Slug = "linen-shirt-white"
+		require.NoError(t, productRepo.Update(product))
+
+		found, err := productRepo.GetBySlug("linen-shirt")
+		require.NoError(t, err)
+		assert.Equal(t, product.ID, found.ID)
+		assert.Equal(t, "linen-shirt-white", found.Slug)
+
+		// Generated slugs skip former slugs so old links keep pointing at the product
+		other := newProduct(t, "Linen Shirt", "LINEN-2")
+		assert.Equal(t, "linen-shirt-2", other.Slug)
+
+		// Moving back to the former slug removes its redirect
+		product.Slug = "linen-shirt"
+		require.NoError(t, productRepo.Update(product))
+		found, err = productRepo.GetBySlug("linen-shirt-white")
+		require.NoError(t, err)
+		assert.Equal(t, "linen-shirt", found.Slug)
+
+		var count int64
+		require.NoError(t, db.Model(&entity.SlugRedirect{}).Where("slug = ?", "linen-shirt").Count(&count).Error)
+		assert.Zero(t, count)
+
+		require.NoError(t, productRepo.Delete(product.ID))
+		_, err = productRepo.GetBySlug("linen-shirt-white")
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("Category slugs redirect after a change", func(t *testing.T) {
+		category.Slug = ""
+		category.Name = "Shirts"
+		require.NoError(t, categoryRepo.Update(category))
+		assert.Equal(t, "shirts", category.Slug)
+
+		found, err := categoryRepo.GetBySlug("cafe-shirts")
+		require.NoError(t, err)
+		assert.Equal(t, category.ID, found.ID)
+		assert.Equal(t, "shirts", found.Slug)
+	})
+}
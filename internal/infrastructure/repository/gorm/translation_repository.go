@@ -0,0 +1,72 @@
+package gorm
+
+import (
+	"fmt"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// TranslationRepository implements repository.TranslationRepository using GORM
+type TranslationRepository struct {
+	db *gorm.DB
+}
+
+// NewTranslationRepository creates a new GORM-based TranslationRepository
+func NewTranslationRepository(db *gorm.DB) repository.TranslationRepository {
+	return &TranslationRepository{db: db}
+}
+
+// ListByResource implements repository.TranslationRepository.
+func (r *TranslationRepository) ListByResource(resourceType string, resourceID uint) ([]*entity.Translation, error) {
+	var translations []*entity.Translation
+	if err := r.db.Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).
+		Order("locale").Find(&translations).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch translations: %w", err)
+	}
+	return translations, nil
+}
+
+// GetByResources implements repository.TranslationRepository.
+func (r *TranslationRepository) GetByResources(resourceType string, resourceIDs []uint, locale string) (map[uint]*entity.Translation, error) {
+	translations := make(map[uint]*entity.Translation, len(resourceIDs))
+	if len(resourceIDs) == 0 {
+		return translations, nil
+	}
+
+	var rows []*entity.Translation
+	if err := r.db.Where("resource_type = ? AND resource_id IN ? AND locale = ?", resourceType, resourceIDs, locale).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch translations: %w", err)
+	}
+	for _, translation := range rows {
+		translations[translation.ResourceID] = translation
+	}
+	return translations, nil
+}
+
+// SetForResource implements repository.TranslationRepository.
+func (r *TranslationRepository) SetForResource(resourceType string, resourceID uint, translations []*entity.Translation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteTranslations(tx, resourceType, resourceID); err != nil {
+			return err
+		}
+		if len(translations) == 0 {
+			return nil
+		}
+		if err := tx.Create(translations).Error; err != nil {
+			return fmt.Errorf("failed to create translations: %w", err)
+		}
+		return nil
+	})
+}
+
+// deleteTranslations deletes the translations of a resource, e.g. when the resource is deleted
+func deleteTranslations(tx *gorm.DB, resourceType string, resourceID uint) error {
+	if err := tx.Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).
+		Delete(&entity.Translation{}).Error; err != nil {
+		return fmt.Errorf("failed to delete translations: %w", err)
+	}
+	return nil
+}
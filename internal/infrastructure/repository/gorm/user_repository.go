@@ -50,14 +50,23 @@ func (u *UserRepository) GetByID(id uint) (*entity.User, error) {
 }
 
 // List implements repository.UserRepository.
-func (u *UserRepository) List(offset int, limit int) ([]*entity.User, error) {
+func (u *UserRepository) List(offset int, limit int, customFields []entity.CustomFieldFilter) ([]*entity.User, error) {
 	var users []*entity.User
-	if err := u.db.Offset(offset).Limit(limit).Order("created_at DESC").Find(&users).Error; err != nil {
+	if err := whereCustomFields(u.db, "users.custom_fields", customFields).Offset(offset).Limit(limit).Order("created_at DESC").Find(&users).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch users: %w", err)
 	}
 	return users, nil
 }
 
+// CountByRole implements repository.UserRepository.
+func (u *UserRepository) CountByRole(role string) (int64, error) {
+	var count int64
+	if err := u.db.Model(&entity.User{}).Where("role = ?", role).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count users with role: %w", err)
+	}
+	return count, nil
+}
+
 // Update implements repository.UserRepository.
 func (u *UserRepository) Update(user *entity.User) error {
 	return u.db.Save(user).Error
@@ -0,0 +1,80 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// UserTokenRepository implements repository.UserTokenRepository using GORM
+type UserTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewUserTokenRepository creates a new GORM-based UserTokenRepository
+func NewUserTokenRepository(db *gorm.DB) repository.UserTokenRepository {
+	return &UserTokenRepository{db: db}
+}
+
+// Create implements repository.UserTokenRepository.
+func (r *UserTokenRepository) Create(token *entity.UserToken) error {
+	if err := r.db.Create(token).Error; err != nil {
+		return fmt.Errorf("failed to create user token: %w", err)
+	}
+	return nil
+}
+
+// Consume implements repository.UserTokenRepository.
+func (r *UserTokenRepository) Consume(tokenHash string, purpose entity.UserTokenPurpose, now time.Time) (*entity.UserToken, error) {
+	var token entity.UserToken
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// The conditional update lets only one request use the token
+		result := tx.Model(&entity.UserToken{}).
+			Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, purpose, now).
+			Update("used_at", now)
+		if result.Error != nil {
+			return fmt.Errorf("failed to use user token: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return unusableTokenError(tx, tokenHash, purpose, now)
+		}
+		if err := tx.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+			return fmt.Errorf("failed to fetch user token: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// DeleteUnusedByUser implements repository.UserTokenRepository.
+func (r *UserTokenRepository) DeleteUnusedByUser(userID uint, purpose entity.UserTokenPurpose) error {
+	if err := r.db.Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).Delete(&entity.UserToken{}).Error; err != nil {
+		return fmt.Errorf("failed to delete user tokens: %w", err)
+	}
+	return nil
+}
+
+// unusableTokenError tells why the token with the hash and purpose cannot be used
+func unusableTokenError(tx *gorm.DB, tokenHash string, purpose entity.UserTokenPurpose, now time.Time) error {
+	var token entity.UserToken
+	if err := tx.Where("token_hash = ? AND purpose = ?", tokenHash, purpose).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return repository.ErrTokenInvalid
+		}
+		return fmt.Errorf("failed to fetch user token: %w", err)
+	}
+	if token.UsedAt != nil {
+		return repository.ErrTokenUsed
+	}
+	if !now.Before(token.ExpiresAt) {
+		return repository.ErrTokenExpired
+	}
+	return repository.ErrTokenInvalid
+}
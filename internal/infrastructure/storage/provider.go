@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// NewStorage creates the asset storage backend selected in the configuration
+func NewStorage(cfg config.StorageConfig) (service.AssetStorage, error) {
+	switch cfg.Provider {
+	case "", "local":
+		if cfg.LocalPath == "" {
+			return nil, errors.New("local storage path is required")
+		}
+		return NewLocalStorage(cfg.LocalPath, cfg.PublicURL), nil
+	case "s3":
+		if cfg.S3Bucket == "" {
+			return nil, errors.New("S3 bucket is required")
+		}
+		client, err := NewHTTPObjectClient(cfg.S3Endpoint, cfg.S3Region, cfg.S3AccessKey, cfg.S3SecretKey)
+		if err != nil {
+			return nil, err
+		}
+
+		// Relative public URLs are only served by the local backend
+		publicURL := cfg.PublicURL
+		if publicURL == "" || strings.HasPrefix(publicURL, "/") {
+			publicURL = client.BucketURL(cfg.S3Bucket)
+		}
+		return NewS3Storage(client, cfg.S3Bucket, publicURL), nil
+	default:
+		return nil, fmt.Errorf("unknown storage provider: %s", cfg.Provider)
+	}
+}
+
+// NewFileStorage creates the private storage of digital product files for the backend selected
+// in the configuration. Local files are kept outside the served media directory.
+func NewFileStorage(cfg config.StorageConfig) (service.FileStorage, error) {
+	switch cfg.Provider {
+	case "", "local":
+		if cfg.DownloadsPath == "" {
+			return nil, errors.New("local downloads path is required")
+		}
+		return NewLocalStorage(cfg.DownloadsPath, ""), nil
+	case "s3":
+		bucket := cfg.S3DownloadsBucket
+		if bucket == "" {
+			bucket = cfg.S3Bucket
+		}
+		if bucket == "" {
+			return nil, errors.New("S3 bucket is required")
+		}
+		client, err := NewHTTPObjectClient(cfg.S3Endpoint, cfg.S3Region, cfg.S3AccessKey, cfg.S3SecretKey)
+		if err != nil {
+			return nil, err
+		}
+		return NewS3Storage(client, bucket, ""), nil
+	default:
+		return nil, fmt.Errorf("unknown storage provider: %s", cfg.Provider)
+	}
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ObjectClient is the subset of the S3 API used to store files. It is implemented by
+// HTTPObjectClient for S3-compatible services and can be faked in tests.
+type ObjectClient interface {
+	PutObject(bucket, key, contentType string, body io.Reader, size int64) error
+	GetObject(bucket, key string) (io.ReadCloser, error)
+	DeleteObject(bucket, key string) error
+}
+
+// S3Storage stores files in a bucket of an S3-compatible object storage service
+type S3Storage struct {
+	client    ObjectClient
+	bucket    string
+	publicURL string
+}
+
+// NewS3Storage creates a new S3Storage for the bucket, serving files under the public URL
+func NewS3Storage(client ObjectClient, bucket, publicURL string) *S3Storage {
+	return &S3Storage{
+		client:    client,
+		bucket:    bucket,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}
+}
+
+// Name implements service.AssetStorage.
+func (s *S3Storage) Name() string {
+	return "s3"
+}
+
+// Put implements service.AssetStorage.
+func (s *S3Storage) Put(key, contentType string, body io.Reader, size int64) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if err := s.client.PutObject(s.bucket, key, contentType, body, size); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	return nil
+}
+
+// Open implements service.FileStorage.
+func (s *S3Storage) Open(key string) (io.ReadCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	body, err := s.client.GetObject(s.bucket, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	return body, nil
+}
+
+// Delete implements service.AssetStorage.
+func (s *S3Storage) Delete(key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if err := s.client.DeleteObject(s.bucket, key); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// URL implements service.AssetStorage.
+func (s *S3Storage) URL(key string) string {
+	return s.publicURL + "/" + escapeKey(key)
+}
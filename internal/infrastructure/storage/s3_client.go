@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HTTPObjectClient talks to an S3-compatible service over HTTP, using path-style bucket
+// URLs and AWS Signature Version 4
+type HTTPObjectClient struct {
+	endpoint  *url.URL
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewHTTPObjectClient creates a new HTTPObjectClient for the endpoint, e.g. https://s3.eu-west-1.amazonaws.com
+func NewHTTPObjectClient(endpoint, region, accessKey, secretKey string) (*HTTPObjectClient, error) {
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+
+	return &HTTPObjectClient{
+		endpoint:  u,
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: 60 * time.Second},
+		now:       time.Now,
+	}, nil
+}
+
+// PutObject implements ObjectClient.
+func (c *HTTPObjectClient) PutObject(bucket, key, contentType string, body io.Reader, size int64) error {
+	return c.do(http.MethodPut, bucket, key, contentType, body, size)
+}
+
+// GetObject implements ObjectClient.
+func (c *HTTPObjectClient) GetObject(bucket, key string) (io.ReadCloser, error) {
+	resp, err := c.send(http.MethodGet, bucket, key, "", nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// DeleteObject implements ObjectClient.
+func (c *HTTPObjectClient) DeleteObject(bucket, key string) error {
+	return c.do(http.MethodDelete, bucket, key, "", nil, 0)
+}
+
+// BucketURL returns the URL of the bucket, used as the public URL when none is configured
+func (c *HTTPObjectClient) BucketURL(bucket string) string {
+	return c.endpoint.String() + "/" + escapeKey(bucket)
+}
+
+func (c *HTTPObjectClient) do(method, bucket, key, contentType string, body io.Reader, size int64) error {
+	resp, err := c.send(method, bucket, key, contentType, body, size)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// send performs a signed request and returns the response of a successful request, whose body
+// the caller closes
+func (c *HTTPObjectClient) send(method, bucket, key, contentType string, body io.Reader, size int64) (*http.Response, error) {
+	escapedPath := c.endpoint.EscapedPath() + "/" + escapeKey(bucket) + "/" + escapeKey(key)
+	target, err := url.Parse(c.endpoint.Scheme + "://" + c.endpoint.Host + escapedPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object URL: %w", err)
+	}
+
+	req, err := http.NewRequest(method, target.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	c.sign(req, escapedPath, c.now().UTC())
+
+	client := c.client
+	if method == http.MethodGet {
+		// Downloads are streamed to the caller and may take longer than the request timeout
+		client = &http.Client{Transport: c.client.Transport}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("object storage returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return resp, nil
+}
+
+// sign adds AWS Signature Version 4 headers to the request. The payload is not signed, which
+// S3 allows over TLS and which lets uploads be streamed.
+func (c *HTTPObjectClient) sign(req *http.Request, escapedPath string, now time.Time) {
+	const payloadHash = "UNSIGNED-PAYLOAD"
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		escapedPath,
+		"", // No query string
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + c.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+c.secretKey), date)
+	signingKey = hmacSHA256(signingKey, c.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// escapeKey escapes the segments of a key as required by S3, keeping only unreserved characters
+func escapeKey(key string) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
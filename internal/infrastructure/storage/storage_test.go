@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zenfulcode/commercify/config"
+)
+
+func TestLocalStorage(t *testing.T) {
+	root := t.TempDir()
+	s := NewLocalStorage(root, "/media/")
+
+	t.Run("Stores, serves and deletes files", func(t *testing.T) {
+		require.NoError(t, s.Put("assets/a/original.png", "image/png", strings.NewReader("image"), 5))
+		data, err := os.ReadFile(filepath.Join(root, "assets", "a", "original.png"))
+		require.NoError(t, err)
+		assert.Equal(t, "image", string(data))
+		assert.Equal(t, "/media/assets/a/original.png", s.URL("assets/a/original.png"))
+
+		recorder := httptest.NewRecorder()
+		s.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/assets/a/original.png", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "image", recorder.Body.String())
+
+		body, err := s.Open("assets/a/original.png")
+		require.NoError(t, err)
+		data, err = io.ReadAll(body)
+		body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, "image", string(data))
+
+		require.NoError(t, s.Delete("assets/a/original.png"))
+		_, err = s.Open("assets/a/original.png")
+		assert.ErrorContains(t, err, "not found")
+		_, err = os.Stat(filepath.Join(root, "assets", "a", "original.png"))
+		assert.True(t, os.IsNotExist(err))
+		assert.NoError(t, s.Delete("assets/a/original.png"), "deleting a missing file is not an error")
+	})
+
+	t.Run("Does not list directories", func(t *testing.T) {
+		require.NoError(t, s.Put("assets/b/original.png", "image/png", strings.NewReader("image"), 5))
+
+		for _, path := range []string{"/assets/", "/assets/b", "/"} {
+			recorder := httptest.NewRecorder()
+			s.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusNotFound, recorder.Code, path)
+		}
+	})
+
+	t.Run("Rejects keys outside the directory", func(t *testing.T) {
+		for _, key := range []string{"", "../secret", "/etc/passwd", "assets/../../secret", "assets/./a", "assets\\a"} {
+			assert.Error(t, s.Put(key, "image/png", strings.NewReader("x"), 1), key)
+		}
+	})
+}
+
+// fakeObjectClient keeps objects in memory
+type fakeObjectClient struct {
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (c *fakeObjectClient) PutObject(bucket, key, contentType string, body io.Reader, size int64) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	c.objects[bucket+"/"+key] = data
+	c.types[bucket+"/"+key] = contentType
+	return nil
+}
+
+func (c *fakeObjectClient) GetObject(bucket, key string) (io.ReadCloser, error) {
+	data, ok := c.objects[bucket+"/"+key]
+	if !ok {
+		return nil, errors.New("object not found")
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (c *fakeObjectClient) DeleteObject(bucket, key string) error {
+	delete(c.objects, bucket+"/"+key)
+	return nil
+}
+
+func TestS3Storage(t *testing.T) {
+	client := &fakeObjectClient{objects: map[string][]byte{}, types: map[string]string{}}
+	s := NewS3Storage(client, "media", "https://cdn.example.com/")
+
+	require.NoError(t, s.Put("assets/a/original.jpg", "image/jpeg", strings.NewReader("image"), 5))
+	assert.Equal(t, []byte("image"), client.objects["media/assets/a/original.jpg"])
+	assert.Equal(t, "image/jpeg", client.types["media/assets/a/original.jpg"])
+	assert.Equal(t, "https://cdn.example.com/assets/a/original.jpg", s.URL("assets/a/original.jpg"))
+
+	body, err := s.Open("assets/a/original.jpg")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(body)
+	assert.Equal(t, "image", string(data))
+
+	require.NoError(t, s.Delete("assets/a/original.jpg"))
+	assert.Empty(t, client.objects)
+	_, err = s.Open("assets/a/original.jpg")
+	assert.Error(t, err)
+
+	assert.Error(t, s.Put("../a", "image/jpeg", strings.NewReader("x"), 1))
+}
+
+func TestHTTPObjectClient(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, string(body))
+		if r.Method == http.MethodGet {
+			w.Write([]byte("file"))
+			return
+		}
+		if strings.Contains(r.URL.Path, "missing-bucket") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("NoSuchBucket"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPObjectClient(server.URL, "eu-west-1", "AKID", "secret")
+	require.NoError(t, err)
+	client.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	require.NoError(t, client.PutObject("media", "assets/a b.png", "image/png", bytes.NewReader([]byte("image")), 5))
+	require.Len(t, requests, 1)
+	req := requests[0]
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "/media/assets/a%20b.png", req.URL.EscapedPath())
+	assert.Equal(t, "image", bodies[0])
+	assert.Equal(t, "image/png", req.Header.Get("Content-Type"))
+	assert.Equal(t, "20240501T120000Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "UNSIGNED-PAYLOAD", req.Header.Get("X-Amz-Content-Sha256"))
+	assert.Regexp(t, `^AWS4-HMAC-SHA256 Credential=AKID/20240501/eu-west-1/s3/aws4_request, `+
+		`SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`, req.Header.Get("Authorization"))
+
+	require.NoError(t, client.DeleteObject("media", "assets/a b.png"))
+	assert.Equal(t, http.MethodDelete, requests[1].Method)
+
+	body, err := client.GetObject("media", "downloads/a.zip")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "file", string(data))
+	assert.Equal(t, http.MethodGet, requests[2].Method)
+	assert.Equal(t, "/media/downloads/a.zip", requests[2].URL.EscapedPath())
+
+	err = client.DeleteObject("missing-bucket", "a")
+	assert.ErrorContains(t, err, "status 404: NoSuchBucket")
+
+	_, err = NewHTTPObjectClient("s3.example.com", "eu-west-1", "AKID", "secret")
+	assert.Error(t, err)
+}
+
+func TestNewStorage(t *testing.T) {
+	s, err := NewStorage(config.StorageConfig{Provider: "local", LocalPath: t.TempDir(), PublicURL: "/media"})
+	require.NoError(t, err)
+	assert.Equal(t, "local", s.Name())
+
+	s, err = NewStorage(config.StorageConfig{Provider: "s3", PublicURL: "/media", S3Endpoint: "https://s3.example.com", S3Region: "eu-west-1", S3Bucket: "shop"})
+	require.NoError(t, err)
+	assert.Equal(t, "s3", s.Name())
+	assert.Equal(t, "https://s3.example.com/shop/a.png", s.URL("a.png"))
+
+	_, err = NewStorage(config.StorageConfig{Provider: "s3"})
+	assert.ErrorContains(t, err, "S3 bucket is required")
+	_, err = NewStorage(config.StorageConfig{Provider: "ftp"})
+	assert.ErrorContains(t, err, "unknown storage provider")
+}
+
+func TestNewFileStorage(t *testing.T) {
+	s, err := NewFileStorage(config.StorageConfig{Provider: "local", DownloadsPath: t.TempDir()})
+	require.NoError(t, err)
+	assert.IsType(t, &LocalStorage{}, s)
+
+	s, err = NewFileStorage(config.StorageConfig{Provider: "s3", S3Endpoint: "https://s3.example.com", S3Bucket: "shop", S3DownloadsBucket: "shop-downloads"})
+	require.NoError(t, err)
+	assert.Equal(t, "shop-downloads", s.(*S3Storage).bucket)
+
+	_, err = NewFileStorage(config.StorageConfig{Provider: "local"})
+	assert.ErrorContains(t, err, "downloads path is required")
+	_, err = NewFileStorage(config.StorageConfig{Provider: "s3"})
+	assert.ErrorContains(t, err, "S3 bucket is required")
+}
@@ -0,0 +1,68 @@
+package contracts
+
+import (
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// CreateAPIKeyRequest represents a request to create an API key
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`               // Permissions of the key, e.g. catalog:write
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // The key never expires when omitted
+}
+
+// ToUseCaseInput converts CreateAPIKeyRequest to usecase.CreateAPIKeyInput
+func (r CreateAPIKeyRequest) ToUseCaseInput(createdByID uint) usecase.CreateAPIKeyInput {
+	return usecase.CreateAPIKeyInput{
+		Name:        r.Name,
+		Scopes:      r.ScopePermissions(),
+		ExpiresAt:   r.ExpiresAt,
+		CreatedByID: createdByID,
+	}
+}
+
+// ScopePermissions returns the scopes of the request as permissions
+func (r CreateAPIKeyRequest) ScopePermissions() []entity.Permission {
+	scopes := make([]entity.Permission, len(r.Scopes))
+	for i, scope := range r.Scopes {
+		scopes[i] = entity.Permission(scope)
+	}
+	return scopes
+}
+
+// CreateAPIKeysResponse converts API keys to DTOs
+func CreateAPIKeysResponse(keys []*entity.APIKey) []*dto.APIKeyDTO {
+	keyDTOs := make([]*dto.APIKeyDTO, len(keys))
+	for i, key := range keys {
+		keyDTOs[i] = key.ToAPIKeyDTO()
+	}
+	return keyDTOs
+}
+
+// APIKeyRequestListResponse creates a response for listing the requests made with an API key
+func APIKeyRequestListResponse(requests []*entity.APIKeyRequest, page, pageSize int) ListResponseDTO[dto.APIKeyRequestDTO] {
+	requestDTOs := make([]dto.APIKeyRequestDTO, len(requests))
+	for i, request := range requests {
+		requestDTOs[i] = *request.ToAPIKeyRequestDTO()
+	}
+
+	message := "API key requests retrieved successfully"
+	if len(requestDTOs) == 0 {
+		message = "No API key requests found"
+	}
+
+	return ListResponseDTO[dto.APIKeyRequestDTO]{
+		Success: true,
+		Data:    requestDTOs,
+		Pagination: PaginationDTO{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    len(requestDTOs),
+		},
+		Message: message,
+	}
+}
@@ -0,0 +1,76 @@
+package contracts
+
+import (
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// LinkProductAssetRequest represents a request to add an asset to a product or one of its variants
+type LinkProductAssetRequest struct {
+	AssetID   uint   `json:"asset_id"`
+	VariantID *uint  `json:"variant_id,omitempty"`
+	AltText   string `json:"alt_text"`
+}
+
+// UpdateProductAssetRequest represents a request to update a product asset.
+// A variant ID of 0 links the asset to the whole product again.
+type UpdateProductAssetRequest struct {
+	AltText   *string `json:"alt_text,omitempty"`
+	VariantID *uint   `json:"variant_id,omitempty"`
+}
+
+// ReorderProductAssetsRequest represents a request to set the display order of a product's assets
+type ReorderProductAssetsRequest struct {
+	Order []uint `json:"order"` // IDs of all product assets in display order
+}
+
+// ToUseCaseInput converts the request to a use case input
+func (r LinkProductAssetRequest) ToUseCaseInput() usecase.LinkProductAssetInput {
+	return usecase.LinkProductAssetInput{
+		AssetID:   r.AssetID,
+		VariantID: r.VariantID,
+		AltText:   r.AltText,
+	}
+}
+
+// ToUseCaseInput converts the request to a use case input
+func (r UpdateProductAssetRequest) ToUseCaseInput() usecase.UpdateProductAssetInput {
+	return usecase.UpdateProductAssetInput{
+		AltText:   r.AltText,
+		VariantID: r.VariantID,
+	}
+}
+
+// AssetListResponse creates a response for listing assets
+func AssetListResponse(assets []*entity.Asset, page, pageSize int) ListResponseDTO[dto.AssetDTO] {
+	assetDTOs := make([]dto.AssetDTO, len(assets))
+	for i, asset := range assets {
+		assetDTOs[i] = asset.ToAssetDTO()
+	}
+
+	message := "Assets retrieved successfully"
+	if len(assetDTOs) == 0 {
+		message = "No assets found"
+	}
+
+	return ListResponseDTO[dto.AssetDTO]{
+		Success: true,
+		Data:    assetDTOs,
+		Pagination: PaginationDTO{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    len(assetDTOs),
+		},
+		Message: message,
+	}
+}
+
+// ProductAssetsResponse creates a response with the assets of a product
+func ProductAssetsResponse(links []*entity.ProductAsset, message string) ResponseDTO[[]dto.ProductAssetDTO] {
+	linkDTOs := make([]dto.ProductAssetDTO, len(links))
+	for i, link := range links {
+		linkDTOs[i] = link.ToProductAssetDTO()
+	}
+	return SuccessResponseWithMessage(linkDTOs, message)
+}
@@ -0,0 +1,350 @@
+package contracts
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// Catalog file formats
+const (
+	CatalogFormatCSV  = "csv"
+	CatalogFormatJSON = "json"
+)
+
+// catalogListSeparator separates the images and attributes in a catalog CSV cell
+const catalogListSeparator = "|"
+
+// CatalogCSVHeader lists the columns of a catalog CSV file. Only sku is required in an import.
+var CatalogCSVHeader = []string{
+	"product_name", "slug", "description", "category", "currency", "active", "images",
+	"sku", "price", "stock", "weight", "is_default", "attributes", "variant_images",
+}
+
+// CatalogRowDTO is one variant of a product in a JSON catalog file
+type CatalogRowDTO struct {
+	ProductName   string            `json:"product_name"`
+	Slug          string            `json:"slug,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	Category      string            `json:"category,omitempty"` // Category names from the root, e.g. "Clothing > Shirts"
+	Currency      string            `json:"currency,omitempty"`
+	Active        *bool             `json:"active,omitempty"`
+	Images        []string          `json:"images,omitempty"`
+	SKU           string            `json:"sku"`
+	Price         float64           `json:"price"` // In the product currency
+	Stock         int               `json:"stock"`
+	Weight        float64           `json:"weight"`
+	IsDefault     bool              `json:"is_default"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+	VariantImages []string          `json:"variant_images,omitempty"`
+}
+
+// CatalogImportResponse is the per-row report of a catalog import
+type CatalogImportResponse struct {
+	Success bool                         `json:"success"`
+	Message string                       `json:"message"`
+	Data    *usecase.CatalogImportResult `json:"data"`
+}
+
+// CreateCatalogImportResponse creates the response of a catalog import
+func CreateCatalogImportResponse(result *usecase.CatalogImportResult) CatalogImportResponse {
+	message := "Catalog imported successfully"
+	switch {
+	case len(result.Errors) > 0:
+		message = fmt.Sprintf("Catalog has %d errors, nothing was imported", len(result.Errors))
+	case result.DryRun:
+		message = "Catalog is valid, nothing was imported in dry run"
+	}
+
+	return CatalogImportResponse{
+		Success: len(result.Errors) == 0,
+		Message: message,
+		Data:    result,
+	}
+}
+
+// CatalogFormatFromName returns the catalog format of a file name or content type, defaulting to CSV
+func CatalogFormatFromName(name string) string {
+	if strings.EqualFold(filepath.Ext(name), ".json") || strings.Contains(strings.ToLower(name), "json") {
+		return CatalogFormatJSON
+	}
+	return CatalogFormatCSV
+}
+
+// ReadCatalog reads catalog rows in the given format. Rows that cannot be parsed are returned as
+// errors, while an unreadable file returns an error.
+func ReadCatalog(r io.Reader, format string) ([]usecase.CatalogRow, []usecase.CatalogRowError, error) {
+	switch format {
+	case CatalogFormatCSV:
+		return ReadCatalogCSV(r)
+	case CatalogFormatJSON:
+		return ReadCatalogJSON(r)
+	default:
+		return nil, nil, fmt.Errorf("unsupported catalog format: %s", format)
+	}
+}
+
+// WriteCatalog writes catalog rows in the given format
+func WriteCatalog(w io.Writer, format string, rows []usecase.CatalogRow) error {
+	switch format {
+	case CatalogFormatCSV:
+		return WriteCatalogCSV(w, rows)
+	case CatalogFormatJSON:
+		return WriteCatalogJSON(w, rows)
+	default:
+		return fmt.Errorf("unsupported catalog format: %s", format)
+	}
+}
+
+// ReadCatalogCSV reads catalog rows from CSV with a header row. Columns may be in any order.
+func ReadCatalogCSV(r io.Reader) ([]usecase.CatalogRow, []usecase.CatalogRowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, errors.New("catalog file is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["sku"]; !ok {
+		return nil, nil, errors.New("catalog CSV must have a sku column")
+	}
+
+	var rows []usecase.CatalogRow
+	var invalid []usecase.CatalogRowError
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		row, err := parseCatalogCSVRow(cell)
+		if err != nil {
+			invalid = append(invalid, usecase.CatalogRowError{Line: line, SKU: cell("sku"), Message: err.Error()})
+			continue
+		}
+		row.Line = line
+		rows = append(rows, row)
+	}
+	return rows, invalid, nil
+}
+
+func parseCatalogCSVRow(cell func(string) string) (usecase.CatalogRow, error) {
+	row := usecase.CatalogRow{
+		ProductName:   cell("product_name"),
+		Slug:          cell("slug"),
+		Description:   cell("description"),
+		Category:      cell("category"),
+		Currency:      cell("currency"),
+		Images:        splitCatalogList(cell("images")),
+		SKU:           cell("sku"),
+		VariantImages: splitCatalogList(cell("variant_images")),
+	}
+
+	var err error
+	if value := cell("active"); value != "" {
+		active, err := strconv.ParseBool(value)
+		if err != nil {
+			return row, fmt.Errorf("invalid active value %q", value)
+		}
+		row.Active = &active
+	}
+	if value := cell("is_default"); value != "" {
+		if row.IsDefault, err = strconv.ParseBool(value); err != nil {
+			return row, fmt.Errorf("invalid is_default value %q", value)
+		}
+	}
+	if value := cell("price"); value != "" {
+		if row.Price, err = strconv.ParseFloat(value, 64); err != nil {
+			return row, fmt.Errorf("invalid price %q", value)
+		}
+	}
+	if value := cell("stock"); value != "" {
+		if row.Stock, err = strconv.Atoi(value); err != nil {
+			return row, fmt.Errorf("invalid stock %q", value)
+		}
+	}
+	if value := cell("weight"); value != "" {
+		if row.Weight, err = strconv.ParseFloat(value, 64); err != nil {
+			return row, fmt.Errorf("invalid weight %q", value)
+		}
+	}
+
+	// Attributes are written as name=value pairs, e.g. color=Red|size=M
+	if value := cell("attributes"); value != "" {
+		row.Attributes = make(entity.VariantAttributes)
+		for _, pair := range splitCatalogList(value) {
+			name, attributeValue, ok := strings.Cut(pair, "=")
+			name, attributeValue = strings.TrimSpace(name), strings.TrimSpace(attributeValue)
+			if !ok || name == "" || attributeValue == "" {
+				return row, fmt.Errorf("invalid attribute %q, expected name=value", pair)
+			}
+			row.Attributes[name] = attributeValue
+		}
+	}
+	return row, nil
+}
+
+// WriteCatalogCSV writes catalog rows as CSV with a header row
+func WriteCatalogCSV(w io.Writer, rows []usecase.CatalogRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(CatalogCSVHeader); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		active := ""
+		if row.Active != nil {
+			active = strconv.FormatBool(*row.Active)
+		}
+
+		names := make([]string, 0, len(row.Attributes))
+		for name := range row.Attributes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		attributes := make([]string, len(names))
+		for i, name := range names {
+			attributes[i] = name + "=" + row.Attributes[name]
+		}
+
+		if err := writer.Write([]string{
+			row.ProductName,
+			row.Slug,
+			row.Description,
+			row.Category,
+			row.Currency,
+			active,
+			strings.Join(row.Images, catalogListSeparator),
+			row.SKU,
+			strconv.FormatFloat(row.Price, 'f', -1, 64),
+			strconv.Itoa(row.Stock),
+			strconv.FormatFloat(row.Weight, 'f', -1, 64),
+			strconv.FormatBool(row.IsDefault),
+			strings.Join(attributes, catalogListSeparator),
+			strings.Join(row.VariantImages, catalogListSeparator),
+		}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadCatalogJSON reads catalog rows from a JSON array of rows. Lines are the positions of the rows, starting at 1.
+func ReadCatalogJSON(r io.Reader) ([]usecase.CatalogRow, []usecase.CatalogRowError, error) {
+	var messages []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&messages); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON, expected an array of rows: %w", err)
+	}
+
+	var rows []usecase.CatalogRow
+	var invalid []usecase.CatalogRowError
+	for i, message := range messages {
+		var dto CatalogRowDTO
+		if err := json.Unmarshal(message, &dto); err != nil {
+			invalid = append(invalid, usecase.CatalogRowError{Line: i + 1, Message: fmt.Sprintf("invalid row: %v", err)})
+			continue
+		}
+
+		row := dto.ToUseCaseInput()
+		row.Line = i + 1
+		rows = append(rows, row)
+	}
+	return rows, invalid, nil
+}
+
+// WriteCatalogJSON writes catalog rows as a JSON array
+func WriteCatalogJSON(w io.Writer, rows []usecase.CatalogRow) error {
+	dtos := make([]CatalogRowDTO, len(rows))
+	for i, row := range rows {
+		dtos[i] = NewCatalogRowDTO(row)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(dtos)
+}
+
+// ToUseCaseInput converts the JSON row to a catalog row
+func (r CatalogRowDTO) ToUseCaseInput() usecase.CatalogRow {
+	return usecase.CatalogRow{
+		ProductName:   r.ProductName,
+		Slug:          r.Slug,
+		Description:   r.Description,
+		Category:      r.Category,
+		Currency:      r.Currency,
+		Active:        r.Active,
+		Images:        r.Images,
+		SKU:           r.SKU,
+		Price:         r.Price,
+		Stock:         r.Stock,
+		Weight:        r.Weight,
+		IsDefault:     r.IsDefault,
+		Attributes:    r.Attributes,
+		VariantImages: r.VariantImages,
+	}
+}
+
+// NewCatalogRowDTO converts a catalog row to its JSON form
+func NewCatalogRowDTO(row usecase.CatalogRow) CatalogRowDTO {
+	return CatalogRowDTO{
+		ProductName:   row.ProductName,
+		Slug:          row.Slug,
+		Description:   row.Description,
+		Category:      row.Category,
+		Currency:      row.Currency,
+		Active:        row.Active,
+		Images:        row.Images,
+		SKU:           row.SKU,
+		Price:         row.Price,
+		Stock:         row.Stock,
+		Weight:        row.Weight,
+		IsDefault:     row.IsDefault,
+		Attributes:    row.Attributes,
+		VariantImages: row.VariantImages,
+	}
+}
+
+// splitCatalogList splits a CSV cell into its non-empty values
+func splitCatalogList(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, catalogListSeparator) {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
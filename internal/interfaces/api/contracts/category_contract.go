@@ -7,16 +7,41 @@ import (
 
 // CreateCategoryRequest represents the data needed to create a new category
 type CreateCategoryRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	ParentID    *uint  `json:"parent_id,omitempty"`
+	Name        string      `json:"name"`
+	Slug        string      `json:"slug,omitempty"`
+	Description string      `json:"description"`
+	SEO         *SEORequest `json:"seo,omitempty"`
+	ParentID    *uint       `json:"parent_id,omitempty"`
 }
 
 // UpdateCategoryRequest represents the data needed to update an existing category
 type UpdateCategoryRequest struct {
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
-	ParentID    *uint  `json:"parent_id,omitempty"`
+	Name        string      `json:"name,omitempty"`
+	Slug        *string     `json:"slug,omitempty"`
+	Description string      `json:"description,omitempty"`
+	SEO         *SEORequest `json:"seo,omitempty"` // Replaces all SEO fields
+	ParentID    *uint       `json:"parent_id,omitempty"`
+}
+
+// MoveCategoryRequest represents a request to move a category with its subtree
+type MoveCategoryRequest struct {
+	ParentID *uint `json:"parent_id"` // New parent, null or 0 for the root
+	Position *int  `json:"position"`  // Position among the new siblings, last when omitted
+}
+
+// ReorderCategoriesRequest represents a request to set the order of the categories on a level
+type ReorderCategoriesRequest struct {
+	ParentID    *uint  `json:"parent_id"` // Parent of the level, null or 0 for the root categories
+	CategoryIDs []uint `json:"category_ids"`
+}
+
+// CreateCategoryTreeResponse converts the category tree to DTOs
+func CreateCategoryTreeResponse(nodes []*entity.CategoryTreeNode) ResponseDTO[[]dto.CategoryTreeDTO] {
+	treeDTOs := make([]dto.CategoryTreeDTO, len(nodes))
+	for i, node := range nodes {
+		treeDTOs[i] = node.ToCategoryTreeDTO()
+	}
+	return SuccessResponse(treeDTOs)
 }
 
 func CreateCategoryResponse(category *dto.CategoryDTO) ResponseDTO[dto.CategoryDTO] {
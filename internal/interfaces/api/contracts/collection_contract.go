@@ -0,0 +1,121 @@
+package contracts
+
+import (
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// CreateCollectionRequest represents the data needed to create a collection
+type CreateCollectionRequest struct {
+	Name        string                        `json:"name"`
+	Slug        string                        `json:"slug,omitempty"`
+	Description string                        `json:"description"`
+	SEO         *SEORequest                   `json:"seo,omitempty"`
+	Type        string                        `json:"type"`                  // manual or smart
+	Rules       *usecase.CollectionRulesInput `json:"rules,omitempty"`       // Required for smart collections, prices in dollars
+	ProductIDs  []uint                        `json:"product_ids,omitempty"` // Products of a manual collection in display order
+	Active      bool                          `json:"active"`
+}
+
+// UpdateCollectionRequest represents the data needed to update a collection
+type UpdateCollectionRequest struct {
+	Name        *string                       `json:"name,omitempty"`
+	Slug        *string                       `json:"slug,omitempty"`
+	Description *string                       `json:"description,omitempty"`
+	SEO         *SEORequest                   `json:"seo,omitempty"`   // Replaces all SEO fields
+	Rules       *usecase.CollectionRulesInput `json:"rules,omitempty"` // Replaces the rules of a smart collection
+	Active      *bool                         `json:"active,omitempty"`
+}
+
+// SetCollectionProductsRequest represents a request to replace the products of a manual collection
+type SetCollectionProductsRequest struct {
+	ProductIDs []uint `json:"product_ids"` // In display order, empty to remove all
+}
+
+// CollectionProductsResponse is a page of the products of a collection with the collection
+type CollectionProductsResponse struct {
+	ListResponseDTO[dto.ProductDTO]
+	Collection dto.CollectionDTO `json:"collection"`
+}
+
+// ToUseCaseInput converts the request to a use case input
+func (r CreateCollectionRequest) ToUseCaseInput() usecase.CreateCollectionInput {
+	return usecase.CreateCollectionInput{
+		Name:        r.Name,
+		Slug:        r.Slug,
+		Description: r.Description,
+		SEO:         r.SEO.ToEntity(),
+		Type:        entity.CollectionType(r.Type),
+		Rules:       r.Rules,
+		ProductIDs:  r.ProductIDs,
+		Active:      r.Active,
+	}
+}
+
+// ToUseCaseInput converts the request to a use case input
+func (r UpdateCollectionRequest) ToUseCaseInput() usecase.UpdateCollectionInput {
+	input := usecase.UpdateCollectionInput{
+		Name:        r.Name,
+		Slug:        r.Slug,
+		Description: r.Description,
+		Rules:       r.Rules,
+		Active:      r.Active,
+	}
+	if r.SEO != nil {
+		seo := r.SEO.ToEntity()
+		input.SEO = &seo
+	}
+	return input
+}
+
+// CollectionListResponse creates the response listing collections
+func CollectionListResponse(collections []*entity.Collection, total, page, pageSize int) ListResponseDTO[dto.CollectionDTO] {
+	collectionDTOs := make([]dto.CollectionDTO, len(collections))
+	for i, collection := range collections {
+		collectionDTOs[i] = collection.ToCollectionDTO()
+	}
+
+	message := "Collections retrieved successfully"
+	if len(collectionDTOs) == 0 {
+		message = "No collections found"
+	}
+
+	return ListResponseDTO[dto.CollectionDTO]{
+		Success: true,
+		Data:    collectionDTOs,
+		Pagination: PaginationDTO{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    total,
+		},
+		Message: message,
+	}
+}
+
+// CreateCollectionProductsResponse creates the response listing the products of a collection
+func CreateCollectionProductsResponse(result *usecase.CollectionProducts, page, pageSize int) CollectionProductsResponse {
+	productDTOs := make([]dto.ProductDTO, len(result.Products))
+	for i, product := range result.Products {
+		productDTOs[i] = *product.ToProductSummaryDTO()
+	}
+
+	message := "Products retrieved successfully"
+	if len(productDTOs) == 0 {
+		message = "No products found"
+	}
+
+	return CollectionProductsResponse{
+		ListResponseDTO: ListResponseDTO[dto.ProductDTO]{
+			Success: true,
+			Data:    productDTOs,
+			Pagination: PaginationDTO{
+				Page:     page,
+				PageSize: pageSize,
+				Total:    result.Total,
+			},
+			Message: message,
+		},
+		Collection: result.Collection.ToCollectionDTO(),
+	}
+}
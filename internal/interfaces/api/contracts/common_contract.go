@@ -1,5 +1,7 @@
 package contracts
 
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
 // PaginationDTO represents pagination parameters
 type PaginationDTO struct {
 	Page     int `json:"page"`
@@ -55,3 +57,22 @@ func SuccessResponse[T any](data T) ResponseDTO[T] {
 
 	return response
 }
+
+// SEORequest represents the search engine metadata of a product or category page
+type SEORequest struct {
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
+	CanonicalURL    string `json:"canonical_url"`
+}
+
+// ToEntity converts the request to SEO metadata; a nil request has no metadata
+func (r *SEORequest) ToEntity() entity.SEOMetadata {
+	if r == nil {
+		return entity.SEOMetadata{}
+	}
+	return entity.SEOMetadata{
+		MetaTitle:       r.MetaTitle,
+		MetaDescription: r.MetaDescription,
+		CanonicalURL:    r.CanonicalURL,
+	}
+}
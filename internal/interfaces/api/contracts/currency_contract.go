@@ -9,21 +9,23 @@ import (
 
 // CreateCurrencyRequest represents a request to create a new currency
 type CreateCurrencyRequest struct {
-	Code         string  `json:"code"`
-	Name         string  `json:"name"`
-	Symbol       string  `json:"symbol"`
-	ExchangeRate float64 `json:"exchange_rate"`
-	IsEnabled    bool    `json:"is_enabled"`
-	IsDefault    bool    `json:"is_default,omitempty"`
+	Code             string  `json:"code"`
+	Name             string  `json:"name"`
+	Symbol           string  `json:"symbol"`
+	ExchangeRate     float64 `json:"exchange_rate"`
+	IsEnabled        bool    `json:"is_enabled"`
+	IsDefault        bool    `json:"is_default,omitempty"`
+	RoundingStrategy string  `json:"rounding_strategy,omitempty"`
 }
 
 // UpdateCurrencyRequest represents a request to update an existing currency
 type UpdateCurrencyRequest struct {
-	Name         string  `json:"name,omitempty"`
-	Symbol       string  `json:"symbol,omitempty"`
-	ExchangeRate float64 `json:"exchange_rate,omitempty"`
-	IsEnabled    *bool   `json:"is_enabled,omitempty"`
-	IsDefault    *bool   `json:"is_default,omitempty"`
+	Name             string  `json:"name,omitempty"`
+	Symbol           string  `json:"symbol,omitempty"`
+	ExchangeRate     float64 `json:"exchange_rate,omitempty"`
+	IsEnabled        *bool   `json:"is_enabled,omitempty"`
+	IsDefault        *bool   `json:"is_default,omitempty"`
+	RoundingStrategy string  `json:"rounding_strategy,omitempty"`
 }
 
 // ConvertAmountRequest represents a request to convert an amount between currencies
@@ -48,7 +50,8 @@ type ConvertAmountResponse struct {
 type ConvertedAmountDTO struct {
 	Currency string  `json:"currency"`
 	Amount   float64 `json:"amount"`
-	Cents    int64   `json:"cents"`
+	Cents    int64   `json:"cents"` // Amount in minor units of the currency
+
 }
 
 // DeleteCurrencyResponse represents the response after deleting a currency
@@ -60,21 +63,23 @@ type DeleteCurrencyResponse struct {
 // ToUseCaseInput converts CreateCurrencyRequest to usecase.CurrencyInput
 func (r CreateCurrencyRequest) ToUseCaseInput() usecase.CurrencyInput {
 	return usecase.CurrencyInput{
-		Code:         r.Code,
-		Name:         r.Name,
-		Symbol:       r.Symbol,
-		ExchangeRate: r.ExchangeRate,
-		IsEnabled:    r.IsEnabled,
-		IsDefault:    r.IsDefault,
+		Code:             r.Code,
+		Name:             r.Name,
+		Symbol:           r.Symbol,
+		ExchangeRate:     r.ExchangeRate,
+		IsEnabled:        r.IsEnabled,
+		IsDefault:        r.IsDefault,
+		RoundingStrategy: r.RoundingStrategy,
 	}
 }
 
 // ToUseCaseInput converts UpdateCurrencyRequest to usecase.CurrencyInput
 func (r UpdateCurrencyRequest) ToUseCaseInput() usecase.CurrencyInput {
 	input := usecase.CurrencyInput{
-		Name:         r.Name,
-		Symbol:       r.Symbol,
-		ExchangeRate: r.ExchangeRate,
+		Name:             r.Name,
+		Symbol:           r.Symbol,
+		ExchangeRate:     r.ExchangeRate,
+		RoundingStrategy: r.RoundingStrategy,
 	}
 
 	// Handle optional boolean fields
@@ -90,10 +95,10 @@ func (r UpdateCurrencyRequest) ToUseCaseInput() usecase.CurrencyInput {
 
 // CreateConvertAmountResponse creates a ConvertAmountResponse from conversion data
 func CreateConvertAmountResponse(fromCurrency string, fromAmount float64, toCurrency string, toAmountCents int64) ConvertAmountResponse {
-	fromCents := money.ToCents(fromAmount)
+	fromMinor := money.ToMinor(fromAmount, fromCurrency)
 
 	return ConvertAmountResponse{
-		From: createConvertedAmountDTO(fromCurrency, fromCents),
+		From: createConvertedAmountDTO(fromCurrency, fromMinor),
 		To:   createConvertedAmountDTO(toCurrency, toAmountCents),
 	}
 }
@@ -138,10 +143,39 @@ func CreateDeleteCurrencyResponse() ResponseDTO[DeleteCurrencyResponse] {
 	})
 }
 
-func createConvertedAmountDTO(currency string, amountCents int64) ConvertedAmountDTO {
+func createConvertedAmountDTO(currency string, amountMinor int64) ConvertedAmountDTO {
 	return ConvertedAmountDTO{
 		Currency: currency,
-		Amount:   money.FromCents(amountCents),
-		Cents:    amountCents,
+		Amount:   money.FromMinor(amountMinor, currency),
+		Cents:    amountMinor,
+	}
+}
+
+// ExchangeRateRefreshResponse creates a response for a completed exchange rate refresh
+func ExchangeRateRefreshResponse(result *usecase.ExchangeRateRefreshResult) ResponseDTO[usecase.ExchangeRateRefreshResult] {
+	return SuccessResponseWithMessage(*result, "Exchange rates refreshed successfully")
+}
+
+// ExchangeRateHistoryListResponse creates a response for listing exchange rate history
+func ExchangeRateHistoryListResponse(history []*entity.ExchangeRateHistory, page, pageSize int) ListResponseDTO[dto.ExchangeRateHistoryDTO] {
+	historyDTOs := make([]dto.ExchangeRateHistoryDTO, len(history))
+	for i, entry := range history {
+		historyDTOs[i] = *entry.ToExchangeRateHistoryDTO()
+	}
+
+	message := "Exchange rate history retrieved successfully"
+	if len(historyDTOs) == 0 {
+		message = "No exchange rate history found"
+	}
+
+	return ListResponseDTO[dto.ExchangeRateHistoryDTO]{
+		Success: true,
+		Data:    historyDTOs,
+		Pagination: PaginationDTO{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    len(historyDTOs),
+		},
+		Message: message,
 	}
 }
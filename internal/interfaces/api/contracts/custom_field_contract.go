@@ -0,0 +1,63 @@
+package contracts
+
+import (
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// CreateCustomFieldRequest represents a request to define a custom field
+type CreateCustomFieldRequest struct {
+	Resource string `json:"resource"` // product, product_variant, order or user
+	Key      string `json:"key"`
+	Type     string `json:"type"` // text, number, boolean, date or select
+	UpdateCustomFieldRequest
+}
+
+// UpdateCustomFieldRequest represents a request to change the label, visibility and validation rules of a custom field
+type UpdateCustomFieldRequest struct {
+	Label    string   `json:"label"`
+	Required bool     `json:"required"`
+	Public   bool     `json:"public"`            // Whether customers see the field in storefront responses
+	Options  []string `json:"options,omitempty"` // Accepted values of select fields
+	Pattern  string   `json:"pattern,omitempty"` // Regular expression texts must match
+	Min      *float64 `json:"min,omitempty"`     // Lowest number, or minimum length of texts
+	Max      *float64 `json:"max,omitempty"`     // Highest number, or maximum length of texts
+}
+
+// SetCustomFieldValuesRequest represents a request to set custom field values. A null value removes the field.
+type SetCustomFieldValuesRequest struct {
+	CustomFields map[string]any `json:"custom_fields"`
+}
+
+// ToUseCaseInput converts CreateCustomFieldRequest to usecase.CreateCustomFieldInput
+func (r CreateCustomFieldRequest) ToUseCaseInput() usecase.CreateCustomFieldInput {
+	return usecase.CreateCustomFieldInput{
+		Resource:               r.Resource,
+		Key:                    r.Key,
+		Type:                   entity.CustomFieldType(r.Type),
+		UpdateCustomFieldInput: r.UpdateCustomFieldRequest.ToUseCaseInput(),
+	}
+}
+
+// ToUseCaseInput converts UpdateCustomFieldRequest to usecase.UpdateCustomFieldInput
+func (r UpdateCustomFieldRequest) ToUseCaseInput() usecase.UpdateCustomFieldInput {
+	return usecase.UpdateCustomFieldInput{
+		Label:    r.Label,
+		Required: r.Required,
+		Public:   r.Public,
+		Options:  r.Options,
+		Pattern:  r.Pattern,
+		Min:      r.Min,
+		Max:      r.Max,
+	}
+}
+
+// CreateCustomFieldsResponse converts custom field definitions to DTOs
+func CreateCustomFieldsResponse(definitions []*entity.CustomFieldDefinition) []dto.CustomFieldDefinitionDTO {
+	definitionDTOs := make([]dto.CustomFieldDefinitionDTO, len(definitions))
+	for i, definition := range definitions {
+		definitionDTOs[i] = definition.ToCustomFieldDefinitionDTO()
+	}
+	return definitionDTOs
+}
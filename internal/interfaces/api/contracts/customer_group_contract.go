@@ -0,0 +1,123 @@
+package contracts
+
+import (
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+)
+
+// CustomerGroupRequest represents a request to create or update a customer group
+type CustomerGroupRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	PriceListID *uint  `json:"price_list_id,omitempty"`
+}
+
+// AssignCustomerGroupRequest represents a request to assign a user to a customer group.
+// A null customer group ID removes the user from its group.
+type AssignCustomerGroupRequest struct {
+	CustomerGroupID *uint `json:"customer_group_id"`
+}
+
+// PriceListRequest represents a request to create or update a price list
+type PriceListRequest struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Active      *bool                    `json:"active,omitempty"`
+	Entries     *[]PriceListEntryRequest `json:"entries,omitempty"`
+}
+
+// PriceListEntryRequest represents a variant price in a price list. The variant is identified by ID or SKU.
+type PriceListEntryRequest struct {
+	VariantID   uint    `json:"variant_id,omitempty"`
+	SKU         string  `json:"sku,omitempty"`
+	Currency    string  `json:"currency"`
+	MinQuantity int     `json:"min_quantity,omitempty"`
+	Price       float64 `json:"price"`
+}
+
+// ToUseCaseInput converts CustomerGroupRequest to usecase.CustomerGroupInput
+func (r CustomerGroupRequest) ToUseCaseInput() usecase.CustomerGroupInput {
+	return usecase.CustomerGroupInput{
+		Name:        r.Name,
+		Description: r.Description,
+		PriceListID: r.PriceListID,
+	}
+}
+
+// ToUseCaseInput converts PriceListRequest to usecase.PriceListInput
+func (r PriceListRequest) ToUseCaseInput() usecase.PriceListInput {
+	input := usecase.PriceListInput{
+		Name:        r.Name,
+		Description: r.Description,
+		Active:      r.Active,
+	}
+
+	if r.Entries != nil {
+		entries := make([]usecase.PriceListEntryInput, len(*r.Entries))
+		for i, entry := range *r.Entries {
+			currency := strings.ToUpper(entry.Currency)
+			entries[i] = usecase.PriceListEntryInput{
+				VariantID:   entry.VariantID,
+				SKU:         entry.SKU,
+				Currency:    currency,
+				MinQuantity: entry.MinQuantity,
+				Price:       money.ToMinor(entry.Price, currency),
+			}
+		}
+		input.Entries = &entries
+	}
+
+	return input
+}
+
+// CustomerGroupListResponse creates a response for listing customer groups
+func CustomerGroupListResponse(groups []*entity.CustomerGroup, page, pageSize int) ListResponseDTO[dto.CustomerGroupDTO] {
+	groupDTOs := make([]dto.CustomerGroupDTO, len(groups))
+	for i, group := range groups {
+		groupDTOs[i] = *group.ToCustomerGroupDTO()
+	}
+
+	message := "Customer groups retrieved successfully"
+	if len(groupDTOs) == 0 {
+		message = "No customer groups found"
+	}
+
+	return ListResponseDTO[dto.CustomerGroupDTO]{
+		Success: true,
+		Data:    groupDTOs,
+		Pagination: PaginationDTO{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    len(groupDTOs),
+		},
+		Message: message,
+	}
+}
+
+// PriceListListResponse creates a response for listing price lists
+func PriceListListResponse(priceLists []*entity.PriceList, page, pageSize int) ListResponseDTO[dto.PriceListDTO] {
+	priceListDTOs := make([]dto.PriceListDTO, len(priceLists))
+	for i, priceList := range priceLists {
+		priceListDTOs[i] = *priceList.ToPriceListDTO()
+	}
+
+	message := "Price lists retrieved successfully"
+	if len(priceListDTOs) == 0 {
+		message = "No price lists found"
+	}
+
+	return ListResponseDTO[dto.PriceListDTO]{
+		Success: true,
+		Data:    priceListDTOs,
+		Pagination: PaginationDTO{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    len(priceListDTOs),
+		},
+		Message: message,
+	}
+}
@@ -6,37 +6,42 @@ import (
 	"github.com/zenfulcode/commercify/internal/application/usecase"
 	"github.com/zenfulcode/commercify/internal/domain/dto"
 	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
 )
 
 // CreateDiscountRequest represents the data needed to create a new discount
 type CreateDiscountRequest struct {
-	Code             string    `json:"code"`
-	Type             string    `json:"type"`
-	Method           string    `json:"method"`
-	Value            float64   `json:"value"`
-	MinOrderValue    float64   `json:"min_order_value,omitempty"`
-	MaxDiscountValue float64   `json:"max_discount_value,omitempty"`
-	ProductIDs       []uint    `json:"product_ids,omitempty"`
-	CategoryIDs      []uint    `json:"category_ids,omitempty"`
-	StartDate        time.Time `json:"start_date,omitempty"`
-	EndDate          time.Time `json:"end_date,omitempty"`
-	UsageLimit       int       `json:"usage_limit,omitempty"`
+	Code             string             `json:"code"`
+	Type             string             `json:"type"`
+	Method           string             `json:"method"`
+	Value            float64            `json:"value"`
+	MinOrderValue    float64            `json:"min_order_value,omitempty"`
+	MaxDiscountValue float64            `json:"max_discount_value,omitempty"`
+	ProductIDs       []uint             `json:"product_ids,omitempty"`
+	CategoryIDs      []uint             `json:"category_ids,omitempty"`
+	CollectionIDs    []uint             `json:"collection_ids,omitempty"`
+	StartDate        time.Time          `json:"start_date,omitempty"`
+	EndDate          time.Time          `json:"end_date,omitempty"`
+	UsageLimit       int                `json:"usage_limit,omitempty"`
+	CurrencyValues   map[string]float64 `json:"currency_values,omitempty"` // Explicit fixed amounts per currency
 }
 
 // UpdateDiscountRequest represents the data needed to update a discount
 type UpdateDiscountRequest struct {
-	Code             string    `json:"code,omitempty"`
-	Type             string    `json:"type,omitempty"`
-	Method           string    `json:"method,omitempty"`
-	Value            float64   `json:"value,omitempty"`
-	MinOrderValue    float64   `json:"min_order_value,omitempty"`
-	MaxDiscountValue float64   `json:"max_discount_value,omitempty"`
-	ProductIDs       []uint    `json:"product_ids,omitempty"`
-	CategoryIDs      []uint    `json:"category_ids,omitempty"`
-	StartDate        time.Time `json:"start_date"`
-	EndDate          time.Time `json:"end_date"`
-	UsageLimit       int       `json:"usage_limit,omitempty"`
-	Active           bool      `json:"active"`
+	Code             string              `json:"code,omitempty"`
+	Type             string              `json:"type,omitempty"`
+	Method           string              `json:"method,omitempty"`
+	Value            float64             `json:"value,omitempty"`
+	MinOrderValue    float64             `json:"min_order_value,omitempty"`
+	MaxDiscountValue float64             `json:"max_discount_value,omitempty"`
+	ProductIDs       []uint              `json:"product_ids,omitempty"`
+	CategoryIDs      []uint              `json:"category_ids,omitempty"`
+	CollectionIDs    []uint              `json:"collection_ids,omitempty"`
+	StartDate        time.Time           `json:"start_date"`
+	EndDate          time.Time           `json:"end_date"`
+	UsageLimit       int                 `json:"usage_limit,omitempty"`
+	Active           bool                `json:"active"`
+	CurrencyValues   *map[string]float64 `json:"currency_values,omitempty"` // Replaces all explicit fixed amounts
 }
 
 // ValidateDiscountRequest represents the data needed to validate a discount code
@@ -79,6 +84,9 @@ func (r *CreateDiscountRequest) ToUseCaseInput() usecase.CreateDiscountInput {
 	if r.CategoryIDs == nil {
 		r.CategoryIDs = []uint{}
 	}
+	if r.CollectionIDs == nil {
+		r.CollectionIDs = []uint{}
+	}
 
 	return usecase.CreateDiscountInput{
 		Code:             r.Code,
@@ -89,9 +97,11 @@ func (r *CreateDiscountRequest) ToUseCaseInput() usecase.CreateDiscountInput {
 		MaxDiscountValue: r.MaxDiscountValue,
 		ProductIDs:       r.ProductIDs,
 		CategoryIDs:      r.CategoryIDs,
+		CollectionIDs:    r.CollectionIDs,
 		StartDate:        r.StartDate,
 		EndDate:          r.EndDate,
 		UsageLimit:       r.UsageLimit,
+		CurrencyValues:   money.MapToMinor(r.CurrencyValues),
 	}
 }
 
@@ -105,11 +115,24 @@ func (r *UpdateDiscountRequest) ToUseCaseInput() usecase.UpdateDiscountInput {
 		MaxDiscountValue: r.MaxDiscountValue,
 		ProductIDs:       r.ProductIDs,
 		CategoryIDs:      r.CategoryIDs,
+		CollectionIDs:    r.CollectionIDs,
 		StartDate:        r.StartDate,
 		EndDate:          r.EndDate,
 		UsageLimit:       r.UsageLimit,
 		Active:           r.Active,
+		CurrencyValues:   r.currencyValuesInCents(),
+	}
+}
+
+func (r *UpdateDiscountRequest) currencyValuesInCents() *map[string]int64 {
+	if r.CurrencyValues == nil {
+		return nil
 	}
+	values := money.MapToMinor(*r.CurrencyValues)
+	if values == nil {
+		values = map[string]int64{}
+	}
+	return &values
 }
 
 func DiscountCreateResponse(discount *dto.DiscountDTO) ResponseDTO[dto.DiscountDTO] {
@@ -154,3 +177,63 @@ func DiscountListResponse(discounts []*entity.Discount, totalCount, page, pageSi
 		Message: "Discounts retrieved successfully",
 	}
 }
+
+// GenerateDiscountCodeBatchRequest represents the data needed to generate a batch of unique codes
+type GenerateDiscountCodeBatchRequest struct {
+	Name              string `json:"name"`
+	Prefix            string `json:"prefix,omitempty"`
+	Quantity          int    `json:"quantity"`
+	CodeLength        int    `json:"code_length,omitempty"`
+	UsageLimitPerCode *int   `json:"usage_limit_per_code,omitempty"`
+}
+
+func (r *GenerateDiscountCodeBatchRequest) ToUseCaseInput(discountID uint) usecase.GenerateDiscountCodeBatchInput {
+	// Codes are single-use unless specified otherwise
+	usageLimitPerCode := 1
+	if r.UsageLimitPerCode != nil {
+		usageLimitPerCode = *r.UsageLimitPerCode
+	}
+
+	return usecase.GenerateDiscountCodeBatchInput{
+		DiscountID:        discountID,
+		Name:              r.Name,
+		Prefix:            r.Prefix,
+		Quantity:          r.Quantity,
+		CodeLength:        r.CodeLength,
+		UsageLimitPerCode: usageLimitPerCode,
+	}
+}
+
+func DiscountCodeBatchCreateResponse(batch dto.DiscountCodeBatchDTO) ResponseDTO[dto.DiscountCodeBatchDTO] {
+	return SuccessResponseWithMessage(batch, "Discount code batch created successfully")
+}
+
+func DiscountCodeBatchRetrieveResponse(batch dto.DiscountCodeBatchDTO) ResponseDTO[dto.DiscountCodeBatchDTO] {
+	return SuccessResponse(batch)
+}
+
+func DiscountCodeBatchDeleteResponse() ResponseDTO[any] {
+	return SuccessResponseMessage("Discount code batch deleted successfully")
+}
+
+func DiscountCodeBatchListResponse(batches []dto.DiscountCodeBatchDTO, page, pageSize int) ListResponseDTO[dto.DiscountCodeBatchDTO] {
+	if len(batches) == 0 {
+		return ListResponseDTO[dto.DiscountCodeBatchDTO]{
+			Success:    true,
+			Data:       []dto.DiscountCodeBatchDTO{},
+			Pagination: PaginationDTO{Page: page, PageSize: pageSize, Total: 0},
+			Message:    "No discount code batches found",
+		}
+	}
+
+	return ListResponseDTO[dto.DiscountCodeBatchDTO]{
+		Success: true,
+		Data:    batches,
+		Pagination: PaginationDTO{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    len(batches),
+		},
+		Message: "Discount code batches retrieved successfully",
+	}
+}
@@ -0,0 +1,20 @@
+package contracts
+
+import (
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// DigitalFilesResponse creates a response with the files of a digital variant
+func DigitalFilesResponse(files []*entity.DigitalFile) ResponseDTO[[]dto.DigitalFileDTO] {
+	fileDTOs := make([]dto.DigitalFileDTO, len(files))
+	for i, file := range files {
+		fileDTOs[i] = file.ToDigitalFileDTO()
+	}
+
+	message := "Digital files retrieved successfully"
+	if len(fileDTOs) == 0 {
+		message = "No digital files found"
+	}
+	return SuccessResponseWithMessage(fileDTOs, message)
+}
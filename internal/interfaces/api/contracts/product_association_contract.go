@@ -0,0 +1,40 @@
+package contracts
+
+import (
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// SetProductAssociationsRequest represents a request to replace the products associated with a product with one type
+type SetProductAssociationsRequest struct {
+	ProductIDs []uint `json:"product_ids"` // In display order, empty to remove all
+}
+
+// ProductAssociationsResponse converts the products associated with a product or a checkout to a DTO
+func ProductAssociationsResponse(associations *usecase.ProductAssociations) dto.ProductAssociationsDTO {
+	toDTOs := func(products []*entity.Product) []dto.ProductDTO {
+		productDTOs := make([]dto.ProductDTO, len(products))
+		for i, product := range products {
+			productDTOs[i] = *product.ToProductSummaryDTO()
+		}
+		return productDTOs
+	}
+
+	return dto.ProductAssociationsDTO{
+		Related:                  toDTOs(associations.Related),
+		CrossSell:                toDTOs(associations.CrossSell),
+		Upsell:                   toDTOs(associations.Upsell),
+		Accessory:                toDTOs(associations.Accessory),
+		FrequentlyBoughtTogether: toDTOs(associations.FrequentlyBoughtTogether),
+	}
+}
+
+// ProductAssociationListResponse converts the associations of a product to DTOs
+func ProductAssociationListResponse(associations []*entity.ProductAssociation) []dto.ProductAssociationDTO {
+	associationDTOs := make([]dto.ProductAssociationDTO, len(associations))
+	for i, association := range associations {
+		associationDTOs[i] = association.ToProductAssociationDTO()
+	}
+	return associationDTOs
+}
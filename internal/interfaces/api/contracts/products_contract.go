@@ -1,6 +1,8 @@
 package contracts
 
 import (
+	"time"
+
 	"github.com/zenfulcode/commercify/internal/application/usecase"
 	"github.com/zenfulcode/commercify/internal/domain/dto"
 	"github.com/zenfulcode/commercify/internal/domain/entity"
@@ -9,15 +11,59 @@ import (
 
 // CreateProductRequest represents the data needed to create a new product
 type CreateProductRequest struct {
+	Type        string                 `json:"type,omitempty"` // standard (default) or bundle
 	Name        string                 `json:"name"`
+	Slug        string                 `json:"slug,omitempty"`
 	Description string                 `json:"description"`
+	SEO         *SEORequest            `json:"seo,omitempty"`
 	Currency    string                 `json:"currency"`
 	CategoryID  uint                   `json:"category_id"`
 	Images      []string               `json:"images"`
 	Active      bool                   `json:"active"`
+	Visibility  *VisibilityRequest     `json:"visibility,omitempty"`
+	Options     []ProductOptionRequest `json:"options,omitempty"`
 	Variants    []CreateVariantRequest `json:"variants"`
 }
 
+// VisibilityRequest represents the optional publishing window of a product and the sales channels
+// showing it, e.g. webshop and pos. No channels make the product visible in all channels.
+type VisibilityRequest struct {
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+	Channels    []string   `json:"channels,omitempty"`
+}
+
+// ProductOptionRequest represents a product option with its allowed values in display order
+type ProductOptionRequest struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// SetProductOptionsRequest represents the data needed to replace the options of a product
+type SetProductOptionsRequest struct {
+	Options []ProductOptionRequest `json:"options"`
+}
+
+// GenerateVariantsRequest represents the defaults of variants generated from the product options.
+// The SKU pattern supports {id} and {OptionName} placeholders.
+type GenerateVariantsRequest struct {
+	SKUPattern string  `json:"sku_pattern,omitempty"`
+	Price      float64 `json:"price,omitempty"`
+	Stock      int     `json:"stock"`
+	Weight     float64 `json:"weight"`
+}
+
+// BundleComponentRequest represents a component variant of a bundle with the quantity per bundle
+type BundleComponentRequest struct {
+	VariantID uint `json:"variant_id"`
+	Quantity  int  `json:"quantity"`
+}
+
+// SetBundleComponentsRequest represents the data needed to replace the components of a bundle variant
+type SetBundleComponentsRequest struct {
+	Components []BundleComponentRequest `json:"components"`
+}
+
 // AttributeKeyValue represents a key-value pair for product attributes
 type AttributeKeyValue struct {
 	Name  string `json:"name"`
@@ -26,35 +72,55 @@ type AttributeKeyValue struct {
 
 // CreateVariantRequest represents the data needed to create a new product variant
 type CreateVariantRequest struct {
-	SKU        string              `json:"sku"`
-	Stock      int                 `json:"stock"`
-	Attributes []AttributeKeyValue `json:"attributes"`
-	Images     []string            `json:"images"`
-	IsDefault  bool                `json:"is_default"`
-	Weight     float64             `json:"weight"`
-	Price      float64             `json:"price"`
+	SKU        string                 `json:"sku"`
+	Stock      int                    `json:"stock"`
+	Attributes []AttributeKeyValue    `json:"attributes"`
+	Images     []string               `json:"images"`
+	IsDefault  bool                   `json:"is_default"`
+	Weight     float64                `json:"weight"`
+	IsDigital  bool                   `json:"is_digital"` // Delivered as downloads, without shipping
+	Price      float64                `json:"price"`
+	Prices     map[string]float64     `json:"prices,omitempty"` // Explicit prices in other currencies
+	Sale       *ScheduledPriceRequest `json:"sale,omitempty"`
+	CompareAt  *ScheduledPriceRequest `json:"compare_at,omitempty"`
+}
+
+// ScheduledPriceRequest represents a sale or compare-at price in the product currency with an
+// optional time window. A null price removes the scheduled price.
+type ScheduledPriceRequest struct {
+	Price    *float64   `json:"price"`
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
 }
 
 // UpdateProductRequest represents the data needed to update an existing product
 type UpdateProductRequest struct {
+	Type        *string                 `json:"type,omitempty"`
 	Name        *string                 `json:"name,omitempty"`
+	Slug        *string                 `json:"slug,omitempty"` // An empty slug is generated from the name again
 	Description *string                 `json:"description,omitempty"`
+	SEO         *SEORequest             `json:"seo,omitempty"` // Replaces all SEO fields
 	Currency    *string                 `json:"currency,omitempty"`
 	CategoryID  *uint                   `json:"category_id,omitempty"`
 	Images      *[]string               `json:"images,omitempty"`
 	Active      *bool                   `json:"active,omitempty"`
-	Variants    *[]UpdateVariantRequest `json:"variants,omitempty"` // Optional, can be nil if no variants are updated
+	Visibility  *VisibilityRequest      `json:"visibility,omitempty"` // Replaces the publishing window and channels
+	Variants    *[]UpdateVariantRequest `json:"variants,omitempty"`   // Optional, can be nil if no variants are updated
 }
 
 // UpdateVariantRequest represents the data needed to update an existing product variant
 type UpdateVariantRequest struct {
-	SKU        *string              `json:"sku,omitempty"`
-	Stock      *int                 `json:"stock,omitempty"`
-	Attributes *[]AttributeKeyValue `json:"attributes,omitempty"`
-	Images     *[]string            `json:"images,omitempty"`
-	IsDefault  *bool                `json:"is_default,omitempty"`
-	Weight     *float64             `json:"weight,omitempty"`
-	Price      *float64             `json:"price,omitempty"`
+	SKU        *string                `json:"sku,omitempty"`
+	Stock      *int                   `json:"stock,omitempty"`
+	Attributes *[]AttributeKeyValue   `json:"attributes,omitempty"`
+	Images     *[]string              `json:"images,omitempty"`
+	IsDefault  *bool                  `json:"is_default,omitempty"`
+	Weight     *float64               `json:"weight,omitempty"`
+	IsDigital  *bool                  `json:"is_digital,omitempty"`
+	Price      *float64               `json:"price,omitempty"`
+	Prices     *map[string]float64    `json:"prices,omitempty"` // Replaces all explicit prices in other currencies
+	Sale       *ScheduledPriceRequest `json:"sale,omitempty"`
+	CompareAt  *ScheduledPriceRequest `json:"compare_at,omitempty"`
 }
 
 func CreateProductListResponse(products []*entity.Product, totalCount, page, pageSize int) ListResponseDTO[dto.ProductDTO] {
@@ -83,24 +149,76 @@ func CreateProductListResponse(products []*entity.Product, totalCount, page, pag
 	}
 }
 
+// ProductSearchResponse is a page of products with the facet counts of all matching products
+type ProductSearchResponse struct {
+	ListResponseDTO[dto.ProductDTO]
+	Facets dto.ProductFacetsDTO `json:"facets"`
+}
+
+// CreateProductSearchResponse creates the response of a faceted product search
+func CreateProductSearchResponse(result *usecase.ProductSearchResult, page, pageSize int) ProductSearchResponse {
+	return ProductSearchResponse{
+		ListResponseDTO: CreateProductListResponse(result.Products, result.Total, page, pageSize),
+		Facets:          result.Facets.ToProductFacetsDTO(result.FacetCurrency),
+	}
+}
+
 func (cp *CreateProductRequest) ToUseCaseInput() usecase.CreateProductInput {
 	variants := make([]usecase.CreateVariantInput, len(cp.Variants))
 	for i, v := range cp.Variants {
-		variants[i] = v.ToUseCaseInput()
+		variants[i] = v.ToUseCaseInput(cp.Currency)
 	}
 
 	return usecase.CreateProductInput{
+		Type:        entity.ProductType(cp.Type),
 		Name:        cp.Name,
+		Slug:        cp.Slug,
 		Description: cp.Description,
+		SEO:         cp.SEO.ToEntity(),
 		Currency:    cp.Currency,
 		CategoryID:  cp.CategoryID,
 		Images:      cp.Images,
 		Active:      cp.Active,
+		Visibility:  cp.Visibility.toUseCaseInput(),
+		Options:     toProductOptionInputs(cp.Options),
 		Variants:    variants,
 	}
 }
 
-func (cv *CreateVariantRequest) ToUseCaseInput() usecase.CreateVariantInput {
+// ToUseCaseInput converts the request to use case input
+func (r *SetProductOptionsRequest) ToUseCaseInput() []usecase.ProductOptionInput {
+	return toProductOptionInputs(r.Options)
+}
+
+// ToUseCaseInput converts the request to use case input
+func (r *SetBundleComponentsRequest) ToUseCaseInput() []usecase.BundleComponentInput {
+	inputs := make([]usecase.BundleComponentInput, len(r.Components))
+	for i, component := range r.Components {
+		inputs[i] = usecase.BundleComponentInput{VariantID: component.VariantID, Quantity: component.Quantity}
+	}
+	return inputs
+}
+
+// ToUseCaseInput converts the request to use case input, with the price in minor units of the product currency
+func (r *GenerateVariantsRequest) ToUseCaseInput(currency string) usecase.GenerateVariantsInput {
+	return usecase.GenerateVariantsInput{
+		SKUPattern: r.SKUPattern,
+		Price:      money.ToMinor(r.Price, currency),
+		Stock:      r.Stock,
+		Weight:     r.Weight,
+	}
+}
+
+func toProductOptionInputs(options []ProductOptionRequest) []usecase.ProductOptionInput {
+	inputs := make([]usecase.ProductOptionInput, len(options))
+	for i, option := range options {
+		inputs[i] = usecase.ProductOptionInput{Name: option.Name, Values: option.Values}
+	}
+	return inputs
+}
+
+// ToUseCaseInput converts the request to use case input, with prices in minor units of the product currency
+func (cv *CreateVariantRequest) ToUseCaseInput(currency string) usecase.CreateVariantInput {
 	// Convert attributes array to map
 	attributesMap := make(entity.VariantAttributes)
 	for _, attr := range cv.Attributes {
@@ -114,27 +232,49 @@ func (cv *CreateVariantRequest) ToUseCaseInput() usecase.CreateVariantInput {
 			Weight:     cv.Weight,
 			Images:     cv.Images,
 			Attributes: attributesMap,
-			Price:      money.ToCents(cv.Price),
+			Price:      money.ToMinor(cv.Price, currency),
+			Prices:     money.MapToMinor(cv.Prices),
+			Sale:       cv.Sale.toUseCaseInput(currency),
+			CompareAt:  cv.CompareAt.toUseCaseInput(currency),
+			IsDigital:  &cv.IsDigital,
 			IsDefault:  cv.IsDefault,
 		},
 	}
 }
 
-func (up *UpdateProductRequest) ToUseCaseInput() usecase.UpdateProductInput {
+// ToUseCaseInput converts the request to use case input. Variant prices are converted to minor units
+// of the requested currency, or of the given current product currency if the currency is unchanged.
+func (up *UpdateProductRequest) ToUseCaseInput(currentCurrency string) usecase.UpdateProductInput {
 	input := usecase.UpdateProductInput{
 		Name:        up.Name,
+		Slug:        up.Slug,
 		Description: up.Description,
 		Currency:    up.Currency,
 		CategoryID:  up.CategoryID,
 		Images:      up.Images,
 		Active:      up.Active,
+		Visibility:  up.Visibility.toUseCaseInput(),
+	}
+
+	if up.Type != nil {
+		productType := entity.ProductType(*up.Type)
+		input.Type = &productType
+	}
+	if up.SEO != nil {
+		seo := up.SEO.ToEntity()
+		input.SEO = &seo
 	}
 
 	// Convert variants if provided
 	if up.Variants != nil {
+		currency := currentCurrency
+		if up.Currency != nil && *up.Currency != "" {
+			currency = *up.Currency
+		}
+
 		variants := make([]usecase.UpdateVariantInput, len(*up.Variants))
 		for i, v := range *up.Variants {
-			variants[i] = v.ToUseCaseInput()
+			variants[i] = v.ToUseCaseInput(currency)
 		}
 		input.Variants = &variants
 	}
@@ -142,7 +282,8 @@ func (up *UpdateProductRequest) ToUseCaseInput() usecase.UpdateProductInput {
 	return input
 }
 
-func (u UpdateVariantRequest) ToUseCaseInput() usecase.UpdateVariantInput {
+// ToUseCaseInput converts the request to use case input, with prices in minor units of the product currency
+func (u UpdateVariantRequest) ToUseCaseInput(currency string) usecase.UpdateVariantInput {
 	var variantInput usecase.VariantInput
 
 	// Set defaults for required fields
@@ -168,8 +309,17 @@ func (u UpdateVariantRequest) ToUseCaseInput() usecase.UpdateVariantInput {
 		variantInput.Images = *u.Images
 	}
 	if u.Price != nil {
-		variantInput.Price = money.ToCents(*u.Price)
+		variantInput.Price = money.ToMinor(*u.Price, currency)
+	}
+	if u.Prices != nil {
+		variantInput.Prices = money.MapToMinor(*u.Prices)
+		if variantInput.Prices == nil {
+			variantInput.Prices = map[string]int64{}
+		}
 	}
+	variantInput.Sale = u.Sale.toUseCaseInput(currency)
+	variantInput.CompareAt = u.CompareAt.toUseCaseInput(currency)
+	variantInput.IsDigital = u.IsDigital
 	if u.IsDefault != nil {
 		variantInput.IsDefault = *u.IsDefault
 	}
@@ -186,3 +336,33 @@ func (u UpdateVariantRequest) ToUseCaseInput() usecase.UpdateVariantInput {
 		VariantInput: variantInput,
 	}
 }
+
+// toUseCaseInput converts the visibility to use case input
+func (r *VisibilityRequest) toUseCaseInput() *usecase.ProductVisibilityInput {
+	if r == nil {
+		return nil
+	}
+
+	channels := make([]entity.SalesChannel, len(r.Channels))
+	for i, channel := range r.Channels {
+		channels[i] = entity.SalesChannel(channel)
+	}
+	return &usecase.ProductVisibilityInput{
+		PublishAt:   r.PublishAt,
+		UnpublishAt: r.UnpublishAt,
+		Channels:    channels,
+	}
+}
+
+// toUseCaseInput converts the scheduled price to minor units of the product currency
+func (r *ScheduledPriceRequest) toUseCaseInput(currency string) *usecase.ScheduledPriceInput {
+	if r == nil {
+		return nil
+	}
+
+	return &usecase.ScheduledPriceInput{
+		Price:    money.ConvertNullableToMinor(r.Price, currency),
+		StartsAt: r.StartsAt,
+		EndsAt:   r.EndsAt,
+	}
+}
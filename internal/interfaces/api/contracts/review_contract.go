@@ -0,0 +1,89 @@
+package contracts
+
+import (
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// SubmitReviewRequest represents a request to review a product
+type SubmitReviewRequest struct {
+	Rating int    `json:"rating"` // 1 to 5
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+}
+
+// RejectReviewRequest represents a request to reject a review with an optional reason
+type RejectReviewRequest struct {
+	Note string `json:"note"`
+}
+
+// ProductReviewsResponse is a page of the approved reviews of a product with its rating summary
+type ProductReviewsResponse struct {
+	ListResponseDTO[dto.ReviewDTO]
+	Summary dto.ReviewSummaryDTO `json:"summary"`
+}
+
+// ToUseCaseInput converts the request to a use case input
+func (r SubmitReviewRequest) ToUseCaseInput() usecase.SubmitReviewInput {
+	return usecase.SubmitReviewInput{
+		Rating: r.Rating,
+		Title:  r.Title,
+		Body:   r.Body,
+	}
+}
+
+// CreateProductReviewsResponse creates the response listing the reviews of a product
+func CreateProductReviewsResponse(result *usecase.ProductReviews, page, pageSize int) ProductReviewsResponse {
+	reviewDTOs := make([]dto.ReviewDTO, len(result.Reviews))
+	for i, review := range result.Reviews {
+		reviewDTOs[i] = review.ToReviewDTO()
+	}
+
+	message := "Reviews retrieved successfully"
+	if len(reviewDTOs) == 0 {
+		message = "No reviews found"
+	}
+
+	return ProductReviewsResponse{
+		ListResponseDTO: ListResponseDTO[dto.ReviewDTO]{
+			Success: true,
+			Data:    reviewDTOs,
+			Pagination: PaginationDTO{
+				Page:     page,
+				PageSize: pageSize,
+				Total:    result.Total,
+			},
+			Message: message,
+		},
+		Summary: dto.ReviewSummaryDTO{
+			RatingAverage: result.RatingAverage,
+			ReviewCount:   result.ReviewCount,
+			Distribution:  result.Distribution,
+		},
+	}
+}
+
+// ReviewListResponse creates the response listing reviews with their moderation details
+func ReviewListResponse(reviews []*entity.Review, total, page, pageSize int) ListResponseDTO[dto.AdminReviewDTO] {
+	reviewDTOs := make([]dto.AdminReviewDTO, len(reviews))
+	for i, review := range reviews {
+		reviewDTOs[i] = review.ToAdminReviewDTO()
+	}
+
+	message := "Reviews retrieved successfully"
+	if len(reviewDTOs) == 0 {
+		message = "No reviews found"
+	}
+
+	return ListResponseDTO[dto.AdminReviewDTO]{
+		Success: true,
+		Data:    reviewDTOs,
+		Pagination: PaginationDTO{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    total,
+		},
+		Message: message,
+	}
+}
@@ -0,0 +1,53 @@
+package contracts
+
+import (
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// CreateRoleRequest represents a request to define a role
+type CreateRoleRequest struct {
+	Name string `json:"name"`
+	UpdateRoleRequest
+}
+
+// UpdateRoleRequest represents a request to change the description and permissions of a role
+type UpdateRoleRequest struct {
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"` // e.g. orders:read, payments:refund
+}
+
+// AssignRoleRequest represents a request to assign a role to a user
+type AssignRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// ToUseCaseInput converts CreateRoleRequest to usecase.CreateRoleInput
+func (r CreateRoleRequest) ToUseCaseInput() usecase.CreateRoleInput {
+	return usecase.CreateRoleInput{
+		Name:            r.Name,
+		UpdateRoleInput: r.UpdateRoleRequest.ToUseCaseInput(),
+	}
+}
+
+// ToUseCaseInput converts UpdateRoleRequest to usecase.UpdateRoleInput
+func (r UpdateRoleRequest) ToUseCaseInput() usecase.UpdateRoleInput {
+	permissions := make([]entity.Permission, len(r.Permissions))
+	for i, permission := range r.Permissions {
+		permissions[i] = entity.Permission(permission)
+	}
+	return usecase.UpdateRoleInput{
+		Description: r.Description,
+		Permissions: permissions,
+	}
+}
+
+// CreateRolesResponse converts roles to DTOs
+func CreateRolesResponse(roles []*entity.Role) []*dto.RoleDTO {
+	roleDTOs := make([]*dto.RoleDTO, len(roles))
+	for i, role := range roles {
+		roleDTOs[i] = role.ToRoleDTO()
+	}
+	return roleDTOs
+}
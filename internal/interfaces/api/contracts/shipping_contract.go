@@ -43,12 +43,13 @@ type UpdateShippingZoneRequest struct {
 
 // CreateShippingRateRequest represents the data needed to create a new shipping rate
 type CreateShippingRateRequest struct {
-	ShippingMethodID      uint     `json:"shipping_method_id"`
-	ShippingZoneID        uint     `json:"shipping_zone_id"`
-	BaseRate              float64  `json:"base_rate"`
-	MinOrderValue         float64  `json:"min_order_value"`
-	FreeShippingThreshold *float64 `json:"free_shipping_threshold"`
-	Active                bool     `json:"active"`
+	ShippingMethodID      uint               `json:"shipping_method_id"`
+	ShippingZoneID        uint               `json:"shipping_zone_id"`
+	BaseRate              float64            `json:"base_rate"`
+	MinOrderValue         float64            `json:"min_order_value"`
+	FreeShippingThreshold *float64           `json:"free_shipping_threshold"`
+	CurrencyBaseRates     map[string]float64 `json:"currency_base_rates,omitempty"`
+	Active                bool               `json:"active"`
 }
 
 // CreateValueBasedRateRequest represents the data needed to create a value-based rate
@@ -61,10 +62,11 @@ type CreateValueBasedRateRequest struct {
 
 // UpdateShippingRateRequest represents the data needed to update a shipping rate
 type UpdateShippingRateRequest struct {
-	BaseRate              float64  `json:"base_rate,omitempty"`
-	MinOrderValue         float64  `json:"min_order_value,omitempty"`
-	FreeShippingThreshold *float64 `json:"free_shipping_threshold"`
-	Active                bool     `json:"active"`
+	BaseRate              float64             `json:"base_rate,omitempty"`
+	MinOrderValue         float64             `json:"min_order_value,omitempty"`
+	FreeShippingThreshold *float64            `json:"free_shipping_threshold"`
+	CurrencyBaseRates     *map[string]float64 `json:"currency_base_rates,omitempty"`
+	Active                bool                `json:"active"`
 }
 
 // CreateWeightBasedRateRequest represents the data needed to create a weight-based rate
@@ -80,6 +82,7 @@ type CalculateShippingOptionsRequest struct {
 	Address     dto.AddressDTO `json:"address"`
 	OrderValue  float64        `json:"order_value"`
 	OrderWeight float64        `json:"order_weight"`
+	Currency    string         `json:"currency,omitempty"`
 }
 
 func (c CalculateShippingOptionsRequest) ToUseCaseInput() usecase.CalculateShippingOptionsInput {
@@ -92,8 +95,9 @@ func (c CalculateShippingOptionsRequest) ToUseCaseInput() usecase.CalculateShipp
 			Country:    c.Address.Country,
 			PostalCode: c.Address.PostalCode,
 		},
-		OrderValue:  money.ToCents(c.OrderValue),
+		OrderValue:  money.ToMinor(c.OrderValue, c.Currency),
 		OrderWeight: c.OrderWeight,
+		Currency:    c.Currency,
 	}
 }
 
@@ -163,6 +167,7 @@ func (req CreateShippingRateRequest) ToCreateShippingRateInput() usecase.CreateS
 		BaseRate:              req.BaseRate,
 		MinOrderValue:         req.MinOrderValue,
 		FreeShippingThreshold: req.FreeShippingThreshold,
+		CurrencyBaseRates:     req.CurrencyBaseRates,
 		Active:                req.Active,
 	}
 }
@@ -174,6 +179,7 @@ func (req UpdateShippingRateRequest) ToUpdateShippingRateInput(id uint) usecase.
 		BaseRate:              req.BaseRate,
 		MinOrderValue:         req.MinOrderValue,
 		FreeShippingThreshold: req.FreeShippingThreshold,
+		CurrencyBaseRates:     req.CurrencyBaseRates,
 		Active:                req.Active,
 	}
 }
@@ -198,10 +204,11 @@ func (req CreateValueBasedRateRequest) ToCreateValueBasedRateInput() usecase.Cre
 	}
 }
 
-func CreateShippingOptionsListResponse(options []*entity.ShippingOption, totalCount, page, pageSize int) ListResponseDTO[dto.ShippingOptionDTO] {
+// CreateShippingOptionsListResponse creates a response listing shipping options with costs in the given currency
+func CreateShippingOptionsListResponse(options []*entity.ShippingOption, currency string, totalCount, page, pageSize int) ListResponseDTO[dto.ShippingOptionDTO] {
 	var response []dto.ShippingOptionDTO
 	for _, option := range options {
-		response = append(response, *option.ToShippingOptionDTO())
+		response = append(response, *option.ToShippingOptionDTOInCurrency(currency))
 	}
 	if len(response) == 0 {
 		return ListResponseDTO[dto.ShippingOptionDTO]{
@@ -0,0 +1,21 @@
+package contracts
+
+import (
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/dto"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// SetTranslationsRequest represents a request to replace the translations of a product, category or shipping method
+type SetTranslationsRequest struct {
+	Translations []usecase.TranslationInput `json:"translations"` // One per locale, empty to remove all
+}
+
+// CreateTranslationsResponse converts the translations of a resource to DTOs
+func CreateTranslationsResponse(translations []*entity.Translation) []dto.TranslationDTO {
+	translationDTOs := make([]dto.TranslationDTO, len(translations))
+	for i, translation := range translations {
+		translationDTOs[i] = translation.ToTranslationDTO()
+	}
+	return translationDTOs
+}
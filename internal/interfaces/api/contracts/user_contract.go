@@ -47,6 +47,39 @@ type ChangePasswordRequest struct {
 	NewPassword     string `json:"new_password"`
 }
 
+// RefreshTokenRequest represents the refresh token of a session to refresh or end
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ForgotPasswordRequest represents a request for a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest represents the data needed to set a new password with a password reset link
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// EmailVerificationRequest represents a request for a new email verification link
+type EmailVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// VerifyEmailRequest represents the data needed to verify an email address with a verification link
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+func (r *ResetPasswordRequest) ToUseCaseInput() usecase.ResetPasswordInput {
+	return usecase.ResetPasswordInput{
+		Token:       r.Token,
+		NewPassword: r.NewPassword,
+	}
+}
+
 func (r *CreateUserRequest) ToUseCaseInput() usecase.RegisterInput {
 	return usecase.RegisterInput{
 		Email:     r.Email,
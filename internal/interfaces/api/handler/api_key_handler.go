@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
+)
+
+// APIKeyHandler handles the API keys of server-to-server integrations (admin only)
+type APIKeyHandler struct {
+	apiKeyUseCase *usecase.APIKeyUseCase
+	logger        logger.Logger
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler
+func NewAPIKeyHandler(apiKeyUseCase *usecase.APIKeyUseCase, logger logger.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUseCase: apiKeyUseCase,
+		logger:        logger,
+	}
+}
+
+// ListAPIKeys handles listing all API keys, without the keys themselves
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.apiKeyUseCase.ListAPIKeys()
+	if err != nil {
+		h.logger.Error("Failed to list API keys: %v", err)
+		writeAPIKeyError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(contracts.CreateAPIKeysResponse(keys)))
+}
+
+// CreateAPIKey handles creating an API key. The key is only returned in this response.
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var request contracts.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode API key request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.UserIDKey).(uint)
+	key, plain, err := h.apiKeyUseCase.CreateAPIKey(roleGrantor(r), request.ToUseCaseInput(userID))
+	if err != nil {
+		h.logger.Error("Failed to create API key: %v", err)
+		writeAPIKeyError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	response := key.ToAPIKeyDTO()
+	response.Key = plain
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(response, "API key created successfully. Store the key now, it is not shown again"))
+}
+
+// RevokeAPIKey handles revoking an API key
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID, err := strconv.ParseUint(mux.Vars(r)["apiKeyId"], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid apiKeyId: %v", err)
+		http.Error(w, "Invalid apiKeyId", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.apiKeyUseCase.RevokeAPIKey(roleGrantor(r), uint(keyID)); err != nil {
+		h.logger.Error("Failed to revoke API key: %v", err)
+		writeAPIKeyError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAPIKeyRequests handles listing the requests made with an API key
+func (h *APIKeyHandler) ListAPIKeyRequests(w http.ResponseWriter, r *http.Request) {
+	keyID, err := strconv.ParseUint(mux.Vars(r)["apiKeyId"], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid apiKeyId: %v", err)
+		http.Error(w, "Invalid apiKeyId", http.StatusBadRequest)
+		return
+	}
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 50 // Default limit
+	}
+
+	requests, err := h.apiKeyUseCase.ListAPIKeyRequests(uint(keyID), offset, limit)
+	if err != nil {
+		h.logger.Error("Failed to list API key requests: %v", err)
+		writeAPIKeyError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	page := (offset / limit) + 1
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.APIKeyRequestListResponse(requests, page, limit))
+}
+
+// writeAPIKeyError writes an error response, mapping known API key errors to their status
+func writeAPIKeyError(w http.ResponseWriter, err error, status int) {
+	switch {
+	case errors.Is(err, repository.ErrAPIKeyNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, usecase.ErrPermissionDenied):
+		status = http.StatusForbidden
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.ErrorResponse(err.Error()))
+}
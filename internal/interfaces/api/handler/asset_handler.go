@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+)
+
+// multipartOverhead is the room left for the multipart headers of an upload
+const multipartOverhead = 1 << 20
+
+// AssetHandler handles media asset requests
+type AssetHandler struct {
+	assetUseCase  *usecase.AssetUseCase
+	maxUploadSize int64
+	logger        logger.Logger
+}
+
+// NewAssetHandler creates a new AssetHandler
+func NewAssetHandler(assetUseCase *usecase.AssetUseCase, maxUploadSize int64, logger logger.Logger) *AssetHandler {
+	return &AssetHandler{
+		assetUseCase:  assetUseCase,
+		maxUploadSize: maxUploadSize,
+		logger:        logger,
+	}
+}
+
+// UploadAsset handles uploading a file in the "file" field of a multipart form (admin only)
+func (h *AssetHandler) UploadAsset(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize+multipartOverhead)
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		h.logger.Error("Failed to read asset upload: %v", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.writeError(w, errors.New("file exceeds the maximum upload size"), http.StatusRequestEntityTooLarge)
+			return
+		}
+		h.writeError(w, errors.New("file is required in the file field of a multipart form"), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, h.maxUploadSize+1))
+	if err != nil {
+		h.logger.Error("Failed to read asset upload: %v", err)
+		h.writeError(w, errors.New("failed to read file"), http.StatusBadRequest)
+		return
+	}
+
+	asset, err := h.assetUseCase.UploadAsset(usecase.UploadAssetInput{
+		FileName:    header.Filename,
+		ContentType: header.Header.Get("Content-Type"),
+		Data:        data,
+	})
+	if err != nil {
+		h.logger.Error("Failed to upload asset: %v", err)
+		status := http.StatusBadRequest
+		switch {
+		case strings.Contains(err.Error(), "maximum upload size"):
+			status = http.StatusRequestEntityTooLarge
+		case strings.Contains(err.Error(), "content type"):
+			status = http.StatusUnsupportedMediaType
+		case strings.Contains(err.Error(), "failed to"), strings.Contains(err.Error(), "not configured"):
+			status = http.StatusInternalServerError
+		}
+		h.writeError(w, err, status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(asset.ToAssetDTO(), "Asset uploaded successfully"))
+}
+
+// GetAsset handles retrieving an asset (admin only)
+func (h *AssetHandler) GetAsset(w http.ResponseWriter, r *http.Request) {
+	assetID, ok := h.parseID(w, r, "assetId")
+	if !ok {
+		return
+	}
+
+	asset, err := h.assetUseCase.GetAsset(assetID)
+	if err != nil {
+		h.logger.Error("Failed to get asset: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(asset.ToAssetDTO()))
+}
+
+// ListAssets handles listing assets, newest first (admin only)
+func (h *AssetHandler) ListAssets(w http.ResponseWriter, r *http.Request) {
+	offset, limit := parseOffsetLimit(r)
+
+	assets, err := h.assetUseCase.ListAssets(offset, limit)
+	if err != nil {
+		h.logger.Error("Failed to list assets: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.AssetListResponse(assets, (offset/limit)+1, limit))
+}
+
+// DeleteAsset handles deleting an asset with its files and product links (admin only)
+func (h *AssetHandler) DeleteAsset(w http.ResponseWriter, r *http.Request) {
+	assetID, ok := h.parseID(w, r, "assetId")
+	if !ok {
+		return
+	}
+
+	if err := h.assetUseCase.DeleteAsset(assetID); err != nil {
+		h.logger.Error("Failed to delete asset: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseMessage("Asset deleted successfully"))
+}
+
+// ListProductAssets handles listing the assets of a product in display order.
+// With the variant_id query parameter, only the assets of the whole product and that variant are listed.
+func (h *AssetHandler) ListProductAssets(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+
+	var variantID *uint
+	if value := r.URL.Query().Get("variant_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid variant_id", http.StatusBadRequest)
+			return
+		}
+		v := uint(id)
+		variantID = &v
+	}
+
+	links, err := h.assetUseCase.ListProductAssets(productID, variantID)
+	if err != nil {
+		h.logger.Error("Failed to list product assets: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.ProductAssetsResponse(links, "Product assets retrieved successfully"))
+}
+
+// LinkProductAsset handles adding an asset to a product or one of its variants (admin only)
+func (h *AssetHandler) LinkProductAsset(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+
+	var request contracts.LinkProductAssetRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode product asset request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	link, err := h.assetUseCase.LinkProductAsset(productID, request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to link product asset: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(link.ToProductAssetDTO(), "Asset added to product successfully"))
+}
+
+// UpdateProductAsset handles updating the alt text or variant of a product asset (admin only)
+func (h *AssetHandler) UpdateProductAsset(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+	linkID, ok := h.parseID(w, r, "productAssetId")
+	if !ok {
+		return
+	}
+
+	var request contracts.UpdateProductAssetRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode product asset request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	link, err := h.assetUseCase.UpdateProductAsset(productID, linkID, request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to update product asset: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(link.ToProductAssetDTO(), "Product asset updated successfully"))
+}
+
+// RemoveProductAsset handles removing an asset from a product, keeping the asset (admin only)
+func (h *AssetHandler) RemoveProductAsset(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+	linkID, ok := h.parseID(w, r, "productAssetId")
+	if !ok {
+		return
+	}
+
+	if err := h.assetUseCase.RemoveProductAsset(productID, linkID); err != nil {
+		h.logger.Error("Failed to remove product asset: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseMessage("Asset removed from product successfully"))
+}
+
+// ReorderProductAssets handles setting the display order of a product's assets (admin only)
+func (h *AssetHandler) ReorderProductAssets(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+
+	var request contracts.ReorderProductAssetsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode reorder product assets request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	links, err := h.assetUseCase.ReorderProductAssets(productID, request.Order)
+	if err != nil {
+		h.logger.Error("Failed to reorder product assets: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.ProductAssetsResponse(links, "Product assets reordered successfully"))
+}
+
+// parseID reads a numeric path parameter
+func (h *AssetHandler) parseID(w http.ResponseWriter, r *http.Request, name string) (uint, bool) {
+	id, err := strconv.ParseUint(mux.Vars(r)[name], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid %s: %v", name, err)
+		http.Error(w, "Invalid "+name, http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeError writes an error response, using 404 for missing resources
+func (h *AssetHandler) writeError(w http.ResponseWriter, err error, status int) {
+	if strings.Contains(err.Error(), "not found") {
+		status = http.StatusNotFound
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.ErrorResponse(err.Error()))
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+)
+
+// maxCatalogImportBytes limits the size of an uploaded catalog file
+const maxCatalogImportBytes = 32 << 20
+
+// CatalogHandler handles product catalog import and export requests
+type CatalogHandler struct {
+	catalogUseCase *usecase.CatalogUseCase
+	logger         logger.Logger
+}
+
+// NewCatalogHandler creates a new CatalogHandler
+func NewCatalogHandler(catalogUseCase *usecase.CatalogUseCase, logger logger.Logger) *CatalogHandler {
+	return &CatalogHandler{
+		catalogUseCase: catalogUseCase,
+		logger:         logger,
+	}
+}
+
+// ImportCatalog handles importing products from a CSV or JSON catalog file (admin only).
+// The file is the request body, or the "file" field of a multipart form. The format is taken
+// from the format query parameter, or else from the file name or content type.
+// With dry_run=true the file is only validated.
+func (h *CatalogHandler) ImportCatalog(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxCatalogImportBytes)
+
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			h.writeError(w, "Invalid dry_run value", http.StatusBadRequest)
+			return
+		}
+	}
+
+	var body io.Reader = r.Body
+	formatSource := r.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(formatSource); mediaType == "multipart/form-data" {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			h.logger.Error("Failed to read catalog upload: %v", err)
+			h.writeError(w, "Catalog file is required in the file field", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		body = file
+		formatSource = header.Filename
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = contracts.CatalogFormatFromName(formatSource)
+	}
+
+	rows, invalid, err := contracts.ReadCatalog(body, format)
+	if err != nil {
+		h.logger.Error("Failed to read catalog: %v", err)
+		h.writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.catalogUseCase.ImportCatalog(usecase.CatalogImportInput{
+		Rows:    rows,
+		Invalid: invalid,
+		DryRun:  dryRun,
+	})
+	if err != nil {
+		h.logger.Error("Failed to import catalog: %v", err)
+		h.writeError(w, "Failed to import catalog", http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	if len(result.Errors) > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.CreateCatalogImportResponse(result))
+}
+
+// ExportCatalog handles exporting all products as a CSV or JSON catalog file (admin only)
+func (h *CatalogHandler) ExportCatalog(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = contracts.CatalogFormatCSV
+	}
+	if format != contracts.CatalogFormatCSV && format != contracts.CatalogFormatJSON {
+		h.writeError(w, "Invalid format, use csv or json", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := h.catalogUseCase.ExportCatalog()
+	if err != nil {
+		h.logger.Error("Failed to export catalog: %v", err)
+		h.writeError(w, "Failed to export catalog", http.StatusInternalServerError)
+		return
+	}
+
+	contentType := "text/csv"
+	if format == contracts.CatalogFormatJSON {
+		contentType = "application/json"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename=\"catalog."+format+"\"")
+
+	if err := contracts.WriteCatalog(w, format, rows); err != nil {
+		h.logger.Error("Failed to write catalog: %v", err)
+	}
+}
+
+// writeError writes an error response
+func (h *CatalogHandler) writeError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.ErrorResponse(message))
+}
@@ -8,21 +8,25 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
 	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
 )
 
 // CategoryHandler handles category-related HTTP requests
 type CategoryHandler struct {
-	categoryUseCase *usecase.CategoryUseCase
-	logger          logger.Logger
+	categoryUseCase    *usecase.CategoryUseCase
+	translationUseCase *usecase.TranslationUseCase
+	logger             logger.Logger
 }
 
 // NewCategoryHandler creates a new CategoryHandler
-func NewCategoryHandler(categoryUseCase *usecase.CategoryUseCase, logger logger.Logger) *CategoryHandler {
+func NewCategoryHandler(categoryUseCase *usecase.CategoryUseCase, translationUseCase *usecase.TranslationUseCase, logger logger.Logger) *CategoryHandler {
 	return &CategoryHandler{
-		categoryUseCase: categoryUseCase,
-		logger:          logger,
+		categoryUseCase:    categoryUseCase,
+		translationUseCase: translationUseCase,
+		logger:             logger,
 	}
 }
 
@@ -41,7 +45,9 @@ func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 
 	input := usecase.CreateCategory{
 		Name:        req.Name,
+		Slug:        req.Slug,
 		Description: req.Description,
+		SEO:         req.SEO.ToEntity(),
 		ParentID:    req.ParentID,
 	}
 
@@ -58,9 +64,12 @@ func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 			errorMessage = err.Error()
 		}
 
-		if strings.Contains(err.Error(), "a category with the name") {
+		if strings.Contains(err.Error(), "a category with the name") || strings.Contains(err.Error(), "already in use") {
 			statusCode = http.StatusConflict
 			errorMessage = err.Error()
+		} else if isSlugOrSEOError(err) {
+			statusCode = http.StatusBadRequest
+			errorMessage = err.Error()
 		}
 
 		response := contracts.ErrorResponse(errorMessage)
@@ -110,6 +119,49 @@ func (h *CategoryHandler) GetCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !h.localizeCategories(w, r, category) {
+		return
+	}
+
+	response := contracts.CreateCategoryResponse(category.ToCategoryDTO())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetCategoryBySlug handles retrieving a category by its slug. Former slugs redirect to the current slug.
+func (h *CategoryHandler) GetCategoryBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := mux.Vars(r)["slug"]
+
+	category, err := h.categoryUseCase.GetCategoryBySlug(slug)
+	if err != nil {
+		h.logger.Error("Failed to get category by slug: %v", err)
+
+		statusCode := http.StatusInternalServerError
+		errorMessage := "Failed to retrieve category"
+
+		if strings.Contains(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+			errorMessage = "Category not found"
+		}
+
+		response := contracts.ErrorResponse(errorMessage)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	if category.Slug != slug {
+		redirectToSlug(w, r, category.Slug)
+		return
+	}
+
+	if !h.localizeCategories(w, r, category) {
+		return
+	}
+
 	response := contracts.CreateCategoryResponse(category.ToCategoryDTO())
 
 	w.Header().Set("Content-Type", "application/json")
@@ -144,9 +196,14 @@ func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request)
 	input := usecase.UpdateCategory{
 		CategoryID:  uint(categoryID),
 		Name:        req.Name,
+		Slug:        req.Slug,
 		Description: req.Description,
 		ParentID:    req.ParentID,
 	}
+	if req.SEO != nil {
+		seo := req.SEO.ToEntity()
+		input.SEO = &seo
+	}
 
 	category, err := h.categoryUseCase.UpdateCategory(input)
 	if err != nil {
@@ -159,9 +216,16 @@ func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request)
 			statusCode = http.StatusNotFound
 			errorMessage = "Category not found"
 		} else if err.Error() == "category cannot be its own parent" ||
+			err.Error() == "category cannot be moved below itself or its descendants" ||
 			err.Error() == "parent category does not exist" {
 			statusCode = http.StatusBadRequest
 			errorMessage = err.Error()
+		} else if strings.Contains(err.Error(), "already in use") {
+			statusCode = http.StatusConflict
+			errorMessage = err.Error()
+		} else if isSlugOrSEOError(err) {
+			statusCode = http.StatusBadRequest
+			errorMessage = err.Error()
 		}
 
 		response := contracts.ErrorResponse(errorMessage)
@@ -240,6 +304,10 @@ func (h *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if !h.localizeCategories(w, r, categories...) {
+		return
+	}
+
 	response := contracts.CreateCategoryListResponse(categories, len(categories), 1, len(categories))
 
 	w.Header().Set("Content-Type", "application/json")
@@ -281,9 +349,183 @@ func (h *CategoryHandler) GetChildCategories(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	if !h.localizeCategories(w, r, categories...) {
+		return
+	}
+
+	response := contracts.CreateCategoryListResponse(categories, len(categories), 1, len(categories))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetCategoryTree handles retrieving all categories as a nested tree with product counts.
+// Products are counted in the webshop unless another sales channel is given.
+func (h *CategoryHandler) GetCategoryTree(w http.ResponseWriter, r *http.Request) {
+	channel := entity.SalesChannelWebshop
+	if name := r.URL.Query().Get("channel"); name != "" {
+		salesChannel, err := entity.ParseSalesChannel(name)
+		if err != nil {
+			response := contracts.ErrorResponse(err.Error())
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+		channel = salesChannel
+	}
+
+	tree, err := h.categoryUseCase.GetCategoryTree(channel)
+	if err != nil {
+		h.logger.Error("Failed to get category tree: %v", err)
+		response := contracts.ErrorResponse("Failed to retrieve categories")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	var categories []*entity.Category
+	var collect func(nodes []*entity.CategoryTreeNode)
+	collect = func(nodes []*entity.CategoryTreeNode) {
+		for _, node := range nodes {
+			categories = append(categories, node.Category)
+			collect(node.Children)
+		}
+	}
+	collect(tree)
+	if !h.localizeCategories(w, r, categories...) {
+		return
+	}
+
+	response := contracts.CreateCategoryTreeResponse(tree)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// MoveCategory handles moving a category with its subtree to another parent or position (admin only)
+func (h *CategoryHandler) MoveCategory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	categoryID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid category ID: %v", err)
+		response := contracts.ErrorResponse("Invalid category ID")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	var req contracts.MoveCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode move category request: %v", err)
+		response := contracts.ErrorResponse("Invalid request body")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	input := usecase.MoveCategory{
+		CategoryID: uint(categoryID),
+		ParentID:   req.ParentID,
+		Position:   -1,
+	}
+	if req.Position != nil {
+		input.Position = *req.Position
+	}
+
+	category, err := h.categoryUseCase.MoveCategory(input)
+	if err != nil {
+		h.logger.Error("Failed to move category: %v", err)
+		h.writeTreeError(w, err, "Failed to move category")
+		return
+	}
+
+	response := contracts.CreateCategoryResponse(category.ToCategoryDTO())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// ReorderCategories handles setting the order of the categories on a level of the tree (admin only)
+func (h *CategoryHandler) ReorderCategories(w http.ResponseWriter, r *http.Request) {
+	var req contracts.ReorderCategoriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode reorder categories request: %v", err)
+		response := contracts.ErrorResponse("Invalid request body")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	categories, err := h.categoryUseCase.ReorderCategories(req.ParentID, req.CategoryIDs)
+	if err != nil {
+		h.logger.Error("Failed to reorder categories: %v", err)
+		h.writeTreeError(w, err, "Failed to reorder categories")
+		return
+	}
+
 	response := contracts.CreateCategoryListResponse(categories, len(categories), 1, len(categories))
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
+
+// writeTreeError writes the error response of a change to the category tree
+func (h *CategoryHandler) writeTreeError(w http.ResponseWriter, err error, fallback string) {
+	statusCode := http.StatusInternalServerError
+	errorMessage := fallback
+
+	switch {
+	case strings.Contains(err.Error(), "parent category not found"):
+		statusCode = http.StatusBadRequest
+		errorMessage = "Parent category not found"
+	case strings.Contains(err.Error(), "not found"):
+		statusCode = http.StatusNotFound
+		errorMessage = "Category not found"
+	case strings.Contains(err.Error(), "below itself"),
+		strings.Contains(err.Error(), "category order"),
+		strings.Contains(err.Error(), "more than once"),
+		strings.Contains(err.Error(), "position"):
+		statusCode = http.StatusBadRequest
+		errorMessage = err.Error()
+	}
+
+	response := contracts.ErrorResponse(errorMessage)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+// isSlugOrSEOError reports whether the error is a validation error of a slug or SEO metadata
+func isSlugOrSEOError(err error) bool {
+	return strings.Contains(err.Error(), "slug") || strings.Contains(err.Error(), "meta ") || strings.Contains(err.Error(), "canonical URL")
+}
+
+// localizeCategories translates the categories into the locale of the request and writes an
+// error response when the translations cannot be loaded
+func (h *CategoryHandler) localizeCategories(w http.ResponseWriter, r *http.Request, categories ...*entity.Category) bool {
+	locale, _ := r.Context().Value(middleware.LocaleKey).(string)
+	if err := h.translationUseCase.LocalizeCategories(locale, categories...); err != nil {
+		h.logger.Error("Failed to translate categories: %v", err)
+		response := contracts.ErrorResponse("Failed to retrieve categories")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return false
+	}
+	return true
+}
@@ -10,10 +10,12 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/zenfulcode/commercify/internal/application/usecase"
 	"github.com/zenfulcode/commercify/internal/domain/common"
+	"github.com/zenfulcode/commercify/internal/domain/dto"
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/service"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
 	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
 )
 
 // CheckoutHandler handles checkout-related HTTP requests
@@ -81,6 +83,30 @@ func (h *CheckoutHandler) GetCheckout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Reprice items whose sale started or ended since they were added
+	userID, _ := r.Context().Value(middleware.UserIDKey).(uint)
+	checkout, err = h.checkoutUseCase.RefreshItemPrices(checkout, userID)
+	if err != nil {
+		h.logger.Error("Failed to refresh checkout prices: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Item names follow the language of the customer
+	locale, _ := r.Context().Value(middleware.LocaleKey).(string)
+	checkout, err = h.checkoutUseCase.SetLocale(checkout, locale)
+	if err != nil {
+		h.logger.Error("Failed to set checkout locale: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	response := contracts.CreateCheckoutResponse(checkout.ToCheckoutDTO())
 
 	// Return updated checkout
@@ -125,11 +151,25 @@ func (h *CheckoutHandler) AddToCheckout(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Items are named in the language of the customer
+	locale, _ := r.Context().Value(middleware.LocaleKey).(string)
+	checkout, err = h.checkoutUseCase.SetLocale(checkout, locale)
+	if err != nil {
+		h.logger.Error("Failed to set checkout locale: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	// Convert DTO to usecase input
 	checkoutInput := usecase.CheckoutInput{
 		SKU:      request.SKU,
 		Quantity: request.Quantity,
 	}
+	checkoutInput.UserID, _ = r.Context().Value(middleware.UserIDKey).(uint)
 
 	// Add item to checkout
 	checkout, err = h.checkoutUseCase.AddItemToCheckout(checkout.ID, checkoutInput)
@@ -189,6 +229,7 @@ func (h *CheckoutHandler) UpdateCheckoutItem(w http.ResponseWriter, r *http.Requ
 		SKU:      sku,
 		Quantity: request.Quantity,
 	}
+	updateInput.UserID, _ = r.Context().Value(middleware.UserIDKey).(uint)
 
 	// Update item in checkout using the new usecase method
 	checkout, err = h.checkoutUseCase.UpdateCheckoutItemBySKU(checkout.ID, updateInput)
@@ -513,6 +554,19 @@ func (h *CheckoutHandler) ApplyDiscount(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Minimum order values are checked against the current and customer group prices
+	userID, _ := r.Context().Value(middleware.UserIDKey).(uint)
+	checkout, err = h.checkoutUseCase.RefreshItemPrices(checkout, userID)
+	if err != nil {
+		h.logger.Error("Failed to refresh checkout prices: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	checkout, err = h.checkoutUseCase.ApplyDiscountCode(checkout, request.DiscountCode)
 
 	if err != nil {
@@ -600,6 +654,19 @@ func (h *CheckoutHandler) SetCurrency(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Customer group prices are defined per currency
+	if userID, ok := r.Context().Value(middleware.UserIDKey).(uint); ok {
+		checkout, err = h.checkoutUseCase.ApplyCustomerPrices(checkout, userID)
+		if err != nil {
+			h.logger.Error("Failed to apply customer prices: %v", err)
+			response := contracts.ErrorResponse(err.Error())
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+	}
+
 	response := contracts.CreateCheckoutResponse(checkout.ToCheckoutDTO())
 
 	// Return updated checkout
@@ -648,6 +715,33 @@ func (h *CheckoutHandler) CompleteOrder(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Prices may have changed since the shopper last saw the checkout, e.g. when a sale ended.
+	// The shopper has to review the new prices before the order is placed.
+	userID, _ := r.Context().Value(middleware.UserIDKey).(uint)
+	checkout, err = h.checkoutUseCase.RefreshItemPrices(checkout, userID)
+	if err != nil {
+		h.logger.Error("Failed to refresh checkout prices: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+	if len(checkout.PriceChanges) > 0 {
+		h.logger.Info("Prices changed for checkout %d, asking shopper to review", checkout.ID)
+		response := contracts.ResponseDTO[dto.CheckoutDTO]{
+			Success: false,
+			Error:   "Prices of some items have changed. Please review your checkout before completing the order.",
+			Data:    *checkout.ToCheckoutDTO(),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	// If checkout exists for this session, convert it to order
 	order, err := h.checkoutUseCase.CreateOrderFromCheckout(checkout.ID)
 	if err != nil {
@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
+)
+
+// CollectionHandler handles product collection requests
+type CollectionHandler struct {
+	collectionUseCase  *usecase.CollectionUseCase
+	translationUseCase *usecase.TranslationUseCase
+	customFieldUseCase *usecase.CustomFieldUseCase
+	logger             logger.Logger
+}
+
+// NewCollectionHandler creates a new CollectionHandler
+func NewCollectionHandler(collectionUseCase *usecase.CollectionUseCase, translationUseCase *usecase.TranslationUseCase, customFieldUseCase *usecase.CustomFieldUseCase, logger logger.Logger) *CollectionHandler {
+	return &CollectionHandler{
+		collectionUseCase:  collectionUseCase,
+		translationUseCase: translationUseCase,
+		customFieldUseCase: customFieldUseCase,
+		logger:             logger,
+	}
+}
+
+// ListActiveCollections handles listing the active collections by name
+func (h *CollectionHandler) ListActiveCollections(w http.ResponseWriter, r *http.Request) {
+	page, pageSize := parsePage(r)
+
+	collections, total, err := h.collectionUseCase.ListCollections(true, (page-1)*pageSize, pageSize)
+	if err != nil {
+		h.logger.Error("Failed to list collections: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.CollectionListResponse(collections, total, page, pageSize))
+}
+
+// GetCollectionBySlug handles getting an active collection by slug; former slugs redirect with 301
+func (h *CollectionHandler) GetCollectionBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := mux.Vars(r)["slug"]
+
+	collection, err := h.collectionUseCase.GetActiveCollectionBySlug(slug)
+	if err != nil {
+		h.logger.Error("Failed to get collection by slug: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if collection.Slug != slug {
+		redirectToSlug(w, r, collection.Slug)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(collection.ToCollectionDTO()))
+}
+
+// ListCollectionProducts handles listing the active products of an active collection
+func (h *CollectionHandler) ListCollectionProducts(w http.ResponseWriter, r *http.Request) {
+	collectionID, ok := h.parseID(w, r, "collectionId")
+	if !ok {
+		return
+	}
+	page, pageSize := parsePage(r)
+	userID, _ := r.Context().Value(middleware.UserIDKey).(uint)
+
+	result, err := h.collectionUseCase.ListCollectionProducts(usecase.ListCollectionProductsInput{
+		CollectionID: collectionID,
+		Sort:         usecase.CollectionSort(r.URL.Query().Get("sort")),
+		CurrencyCode: strings.ToUpper(r.URL.Query().Get("currency")),
+		Offset:       (page - 1) * pageSize,
+		Limit:        pageSize,
+		UserID:       userID,
+	})
+	if err != nil {
+		h.logger.Error("Failed to list collection products: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	locale, _ := r.Context().Value(middleware.LocaleKey).(string)
+	if err := h.translationUseCase.LocalizeProducts(locale, result.Products...); err != nil {
+		h.logger.Error("Failed to translate collection products: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+	if err := h.customFieldUseCase.FilterProductFields(false, result.Products...); err != nil {
+		h.logger.Error("Failed to filter custom fields of collection products: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.CreateCollectionProductsResponse(result, page, pageSize))
+}
+
+// ListCollections handles listing all collections by name (admin only)
+func (h *CollectionHandler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	offset, limit := parseOffsetLimit(r)
+
+	collections, total, err := h.collectionUseCase.ListCollections(false, offset, limit)
+	if err != nil {
+		h.logger.Error("Failed to list collections: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.CollectionListResponse(collections, total, (offset/limit)+1, limit))
+}
+
+// CreateCollection handles creating a collection (admin only)
+func (h *CollectionHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	var request contracts.CreateCollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode collection request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	collection, err := h.collectionUseCase.CreateCollection(request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to create collection: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(collection.ToCollectionDTO(), "Collection created successfully"))
+}
+
+// GetCollection handles getting a collection by ID, active or not (admin only)
+func (h *CollectionHandler) GetCollection(w http.ResponseWriter, r *http.Request) {
+	collectionID, ok := h.parseID(w, r, "collectionId")
+	if !ok {
+		return
+	}
+
+	collection, err := h.collectionUseCase.GetCollection(collectionID)
+	if err != nil {
+		h.logger.Error("Failed to get collection: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(collection.ToCollectionDTO()))
+}
+
+// UpdateCollection handles updating a collection (admin only)
+func (h *CollectionHandler) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	collectionID, ok := h.parseID(w, r, "collectionId")
+	if !ok {
+		return
+	}
+
+	var request contracts.UpdateCollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode collection request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	collection, err := h.collectionUseCase.UpdateCollection(collectionID, request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to update collection: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(collection.ToCollectionDTO(), "Collection updated successfully"))
+}
+
+// DeleteCollection handles deleting a collection (admin only)
+func (h *CollectionHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	collectionID, ok := h.parseID(w, r, "collectionId")
+	if !ok {
+		return
+	}
+
+	if err := h.collectionUseCase.DeleteCollection(collectionID); err != nil {
+		h.logger.Error("Failed to delete collection: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseMessage("Collection deleted successfully"))
+}
+
+// GetCollectionProducts handles listing the IDs of the products placed in a manual collection (admin only)
+func (h *CollectionHandler) GetCollectionProducts(w http.ResponseWriter, r *http.Request) {
+	collectionID, ok := h.parseID(w, r, "collectionId")
+	if !ok {
+		return
+	}
+
+	productIDs, err := h.collectionUseCase.GetCollectionProductIDs(collectionID)
+	if err != nil {
+		h.logger.Error("Failed to get collection products: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(contracts.SetCollectionProductsRequest{ProductIDs: productIDs}))
+}
+
+// SetCollectionProducts handles replacing the products of a manual collection (admin only)
+func (h *CollectionHandler) SetCollectionProducts(w http.ResponseWriter, r *http.Request) {
+	collectionID, ok := h.parseID(w, r, "collectionId")
+	if !ok {
+		return
+	}
+
+	var request contracts.SetCollectionProductsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode collection products request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	productIDs, err := h.collectionUseCase.SetCollectionProducts(collectionID, request.ProductIDs)
+	if err != nil {
+		h.logger.Error("Failed to set collection products: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(contracts.SetCollectionProductsRequest{ProductIDs: productIDs}, "Collection products updated successfully"))
+}
+
+// parseID reads a numeric path parameter
+func (h *CollectionHandler) parseID(w http.ResponseWriter, r *http.Request, name string) (uint, bool) {
+	id, err := strconv.ParseUint(mux.Vars(r)[name], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid %s: %v", name, err)
+		http.Error(w, "Invalid "+name, http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeError writes an error response, mapping known collection errors to their status
+func (h *CollectionHandler) writeError(w http.ResponseWriter, err error, status int) {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		status = http.StatusNotFound
+	case strings.Contains(err.Error(), "already in use"):
+		status = http.StatusConflict
+	case strings.Contains(err.Error(), "invalid"), strings.Contains(err.Error(), "cannot"),
+		strings.Contains(err.Error(), "need at least one rule"), strings.Contains(err.Error(), "only be placed"),
+		strings.Contains(err.Error(), "is required"), strings.Contains(err.Error(), "more than once"):
+		status = http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.ErrorResponse(err.Error()))
+}
+
+// parsePage reads the page and page_size query parameters of public lists
+func parsePage(r *http.Request) (int, int) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	return page, pageSize
+}
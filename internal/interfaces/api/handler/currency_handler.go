@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/zenfulcode/commercify/internal/application/usecase"
@@ -324,8 +325,8 @@ func (h *CurrencyHandler) ConvertAmount(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Convert amount
-	fromCents := money.ToCents(request.Amount)
-	toCents, err := h.currencyUseCase.ConvertPrice(fromCents, request.FromCurrency, request.ToCurrency)
+	fromMinor := money.ToMinor(request.Amount, request.FromCurrency)
+	toMinor, err := h.currencyUseCase.ConvertPrice(fromMinor, request.FromCurrency, request.ToCurrency)
 	if err != nil {
 		h.logger.Error("Failed to convert amount: %v", err)
 		response := contracts.ErrorResponse("Failed to convert amount")
@@ -336,9 +337,61 @@ func (h *CurrencyHandler) ConvertAmount(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Create response DTO
-	response := contracts.CreateConvertAmountResponse(request.FromCurrency, request.Amount, request.ToCurrency, toCents)
+	response := contracts.CreateConvertAmountResponse(request.FromCurrency, request.Amount, request.ToCurrency, toMinor)
 
 	// Return converted amount
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// RefreshExchangeRates handles refreshing exchange rates from the configured provider (admin only)
+func (h *CurrencyHandler) RefreshExchangeRates(w http.ResponseWriter, r *http.Request) {
+	if !h.currencyUseCase.HasExchangeRateProvider() {
+		response := contracts.ErrorResponse("No exchange rate provider configured")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	result, err := h.currencyUseCase.RefreshExchangeRates()
+	if err != nil {
+		h.logger.Error("Failed to refresh exchange rates: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadGateway)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	response := contracts.ExchangeRateRefreshResponse(result)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetExchangeRateHistory handles listing recorded exchange rate changes (admin only)
+func (h *CurrencyHandler) GetExchangeRateHistory(w http.ResponseWriter, r *http.Request) {
+	code := r.URL.Query().Get("code")
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 50 // Default limit
+	}
+
+	history, err := h.currencyUseCase.GetExchangeRateHistory(code, offset, limit)
+	if err != nil {
+		h.logger.Error("Failed to get exchange rate history: %v", err)
+		response := contracts.ErrorResponse("Failed to get exchange rate history")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	page := (offset / limit) + 1
+	response := contracts.ExchangeRateHistoryListResponse(history, page, limit)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
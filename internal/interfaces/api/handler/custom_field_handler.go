@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+)
+
+// customFieldParamPrefix prefixes the list query parameters filtering by custom field, e.g. cf.erp_id=A-100
+const customFieldParamPrefix = "cf."
+
+// CustomFieldHandler handles custom field definitions and values (admin only)
+type CustomFieldHandler struct {
+	customFieldUseCase *usecase.CustomFieldUseCase
+	logger             logger.Logger
+}
+
+// NewCustomFieldHandler creates a new CustomFieldHandler
+func NewCustomFieldHandler(customFieldUseCase *usecase.CustomFieldUseCase, logger logger.Logger) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		customFieldUseCase: customFieldUseCase,
+		logger:             logger,
+	}
+}
+
+// ListCustomFields handles listing the custom field definitions, optionally of one resource
+func (h *CustomFieldHandler) ListCustomFields(w http.ResponseWriter, r *http.Request) {
+	definitions, err := h.customFieldUseCase.ListDefinitions(r.URL.Query().Get("resource"))
+	if err != nil {
+		h.logger.Error("Failed to list custom fields: %v", err)
+		writeCustomFieldError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(contracts.CreateCustomFieldsResponse(definitions)))
+}
+
+// CreateCustomField handles defining a custom field
+func (h *CustomFieldHandler) CreateCustomField(w http.ResponseWriter, r *http.Request) {
+	var request contracts.CreateCustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode custom field request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	definition, err := h.customFieldUseCase.CreateDefinition(request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to create custom field: %v", err)
+		writeCustomFieldError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(definition.ToCustomFieldDefinitionDTO(), "Custom field created successfully"))
+}
+
+// UpdateCustomField handles changing the label, visibility and validation rules of a custom field
+func (h *CustomFieldHandler) UpdateCustomField(w http.ResponseWriter, r *http.Request) {
+	definitionID, ok := h.parseID(w, r, "customFieldId")
+	if !ok {
+		return
+	}
+
+	var request contracts.UpdateCustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode custom field request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	definition, err := h.customFieldUseCase.UpdateDefinition(definitionID, request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to update custom field: %v", err)
+		writeCustomFieldError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(definition.ToCustomFieldDefinitionDTO(), "Custom field updated successfully"))
+}
+
+// DeleteCustomField handles deleting a custom field and its values
+func (h *CustomFieldHandler) DeleteCustomField(w http.ResponseWriter, r *http.Request) {
+	definitionID, ok := h.parseID(w, r, "customFieldId")
+	if !ok {
+		return
+	}
+
+	if err := h.customFieldUseCase.DeleteDefinition(definitionID); err != nil {
+		h.logger.Error("Failed to delete custom field: %v", err)
+		writeCustomFieldError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetProductCustomFields handles getting the custom field values of a product
+func (h *CustomFieldHandler) GetProductCustomFields(w http.ResponseWriter, r *http.Request) {
+	h.getValues(w, r, entity.CustomFieldResourceProduct, "productId")
+}
+
+// SetProductCustomFields handles setting the custom field values of a product
+func (h *CustomFieldHandler) SetProductCustomFields(w http.ResponseWriter, r *http.Request) {
+	h.setValues(w, r, entity.CustomFieldResourceProduct, "productId")
+}
+
+// GetOrderCustomFields handles getting the custom field values of an order
+func (h *CustomFieldHandler) GetOrderCustomFields(w http.ResponseWriter, r *http.Request) {
+	h.getValues(w, r, entity.CustomFieldResourceOrder, "orderId")
+}
+
+// SetOrderCustomFields handles setting the custom field values of an order
+func (h *CustomFieldHandler) SetOrderCustomFields(w http.ResponseWriter, r *http.Request) {
+	h.setValues(w, r, entity.CustomFieldResourceOrder, "orderId")
+}
+
+// GetUserCustomFields handles getting the custom field values of a user
+func (h *CustomFieldHandler) GetUserCustomFields(w http.ResponseWriter, r *http.Request) {
+	h.getValues(w, r, entity.CustomFieldResourceUser, "userId")
+}
+
+// SetUserCustomFields handles setting the custom field values of a user
+func (h *CustomFieldHandler) SetUserCustomFields(w http.ResponseWriter, r *http.Request) {
+	h.setValues(w, r, entity.CustomFieldResourceUser, "userId")
+}
+
+// GetVariantCustomFields handles getting the custom field values of a product variant
+func (h *CustomFieldHandler) GetVariantCustomFields(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+	variantID, ok := h.parseID(w, r, "variantId")
+	if !ok {
+		return
+	}
+
+	values, err := h.customFieldUseCase.GetVariantValues(productID, variantID)
+	if err != nil {
+		h.logger.Error("Failed to get variant custom fields: %v", err)
+		writeCustomFieldError(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.writeValues(w, values, "")
+}
+
+// SetVariantCustomFields handles setting the custom field values of a product variant
+func (h *CustomFieldHandler) SetVariantCustomFields(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+	variantID, ok := h.parseID(w, r, "variantId")
+	if !ok {
+		return
+	}
+
+	var request contracts.SetCustomFieldValuesRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode custom field values: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	values, err := h.customFieldUseCase.SetVariantValues(productID, variantID, request.CustomFields)
+	if err != nil {
+		h.logger.Error("Failed to set variant custom fields: %v", err)
+		writeCustomFieldError(w, err, http.StatusBadRequest)
+		return
+	}
+	h.writeValues(w, values, "Custom fields updated successfully")
+}
+
+// getValues writes the custom field values of the resource identified by the path parameter
+func (h *CustomFieldHandler) getValues(w http.ResponseWriter, r *http.Request, resource, param string) {
+	resourceID, ok := h.parseID(w, r, param)
+	if !ok {
+		return
+	}
+
+	values, err := h.customFieldUseCase.GetValues(resource, resourceID)
+	if err != nil {
+		h.logger.Error("Failed to get %s custom fields: %v", resource, err)
+		writeCustomFieldError(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.writeValues(w, values, "")
+}
+
+// setValues sets the custom field values of the resource identified by the path parameter
+func (h *CustomFieldHandler) setValues(w http.ResponseWriter, r *http.Request, resource, param string) {
+	resourceID, ok := h.parseID(w, r, param)
+	if !ok {
+		return
+	}
+
+	var request contracts.SetCustomFieldValuesRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode custom field values: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	values, err := h.customFieldUseCase.SetValues(resource, resourceID, request.CustomFields)
+	if err != nil {
+		h.logger.Error("Failed to set %s custom fields: %v", resource, err)
+		writeCustomFieldError(w, err, http.StatusBadRequest)
+		return
+	}
+	h.writeValues(w, values, "Custom fields updated successfully")
+}
+
+// writeValues writes custom field values, with a message after changes
+func (h *CustomFieldHandler) writeValues(w http.ResponseWriter, values entity.CustomFields, message string) {
+	data := map[string]any{"custom_fields": values}
+
+	w.Header().Set("Content-Type", "application/json")
+	if message != "" {
+		json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(data, message))
+		return
+	}
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(data))
+}
+
+// parseID reads a numeric path parameter
+func (h *CustomFieldHandler) parseID(w http.ResponseWriter, r *http.Request, name string) (uint, bool) {
+	id, err := strconv.ParseUint(mux.Vars(r)[name], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid %s: %v", name, err)
+		http.Error(w, "Invalid "+name, http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeCustomFieldError writes an error response, mapping known custom field errors to their status
+func writeCustomFieldError(w http.ResponseWriter, err error, status int) {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		status = http.StatusNotFound
+	case strings.Contains(err.Error(), "already exists"):
+		status = http.StatusConflict
+	case strings.Contains(err.Error(), "custom field"):
+		status = http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.ErrorResponse(err.Error()))
+}
+
+// customFieldFilters parses the custom field filters of a list request, e.g. cf.erp_id=A-100
+func customFieldFilters(customFieldUseCase *usecase.CustomFieldUseCase, r *http.Request, resource string) ([]entity.CustomFieldFilter, error) {
+	params := make(map[string]string)
+	for name, values := range r.URL.Query() {
+		if key, ok := strings.CutPrefix(name, customFieldParamPrefix); ok && len(values) > 0 {
+			params[key] = values[0]
+		}
+	}
+	return customFieldUseCase.ParseFilters(resource, params)
+}
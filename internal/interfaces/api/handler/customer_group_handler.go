@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+)
+
+// CustomerGroupHandler handles customer group and price list HTTP requests
+type CustomerGroupHandler struct {
+	customerGroupUseCase *usecase.CustomerGroupUseCase
+	logger               logger.Logger
+}
+
+// NewCustomerGroupHandler creates a new CustomerGroupHandler
+func NewCustomerGroupHandler(customerGroupUseCase *usecase.CustomerGroupUseCase, logger logger.Logger) *CustomerGroupHandler {
+	return &CustomerGroupHandler{
+		customerGroupUseCase: customerGroupUseCase,
+		logger:               logger,
+	}
+}
+
+// CreateCustomerGroup handles creating a customer group (admin only)
+func (h *CustomerGroupHandler) CreateCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	var request contracts.CustomerGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode customer group request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	group, err := h.customerGroupUseCase.CreateCustomerGroup(request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to create customer group: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(group.ToCustomerGroupDTO(), "Customer group created successfully"))
+}
+
+// GetCustomerGroup handles retrieving a customer group (admin only)
+func (h *CustomerGroupHandler) GetCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	groupID, ok := h.parseID(w, r, "groupId")
+	if !ok {
+		return
+	}
+
+	group, err := h.customerGroupUseCase.GetCustomerGroup(groupID)
+	if err != nil {
+		h.logger.Error("Failed to get customer group: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(group.ToCustomerGroupDTO()))
+}
+
+// UpdateCustomerGroup handles updating a customer group (admin only)
+func (h *CustomerGroupHandler) UpdateCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	groupID, ok := h.parseID(w, r, "groupId")
+	if !ok {
+		return
+	}
+
+	var request contracts.CustomerGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode customer group request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	group, err := h.customerGroupUseCase.UpdateCustomerGroup(groupID, request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to update customer group: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(group.ToCustomerGroupDTO(), "Customer group updated successfully"))
+}
+
+// DeleteCustomerGroup handles deleting a customer group (admin only)
+func (h *CustomerGroupHandler) DeleteCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	groupID, ok := h.parseID(w, r, "groupId")
+	if !ok {
+		return
+	}
+
+	if err := h.customerGroupUseCase.DeleteCustomerGroup(groupID); err != nil {
+		h.logger.Error("Failed to delete customer group: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseMessage("Customer group deleted successfully"))
+}
+
+// ListCustomerGroups handles listing customer groups (admin only)
+func (h *CustomerGroupHandler) ListCustomerGroups(w http.ResponseWriter, r *http.Request) {
+	offset, limit := parseOffsetLimit(r)
+
+	groups, err := h.customerGroupUseCase.ListCustomerGroups(offset, limit)
+	if err != nil {
+		h.logger.Error("Failed to list customer groups: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.CustomerGroupListResponse(groups, (offset/limit)+1, limit))
+}
+
+// AssignCustomerGroup handles assigning a user to a customer group (admin only)
+func (h *CustomerGroupHandler) AssignCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.parseID(w, r, "userId")
+	if !ok {
+		return
+	}
+
+	var request contracts.AssignCustomerGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode assign customer group request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.customerGroupUseCase.AssignUserToGroup(userID, request.CustomerGroupID)
+	if err != nil {
+		h.logger.Error("Failed to assign customer group: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(user.ToUserDTO()))
+}
+
+// CreatePriceList handles creating a price list (admin only)
+func (h *CustomerGroupHandler) CreatePriceList(w http.ResponseWriter, r *http.Request) {
+	var request contracts.PriceListRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode price list request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	priceList, err := h.customerGroupUseCase.CreatePriceList(request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to create price list: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(priceList.ToPriceListDTO(), "Price list created successfully"))
+}
+
+// GetPriceList handles retrieving a price list with its entries (admin only)
+func (h *CustomerGroupHandler) GetPriceList(w http.ResponseWriter, r *http.Request) {
+	priceListID, ok := h.parseID(w, r, "priceListId")
+	if !ok {
+		return
+	}
+
+	priceList, err := h.customerGroupUseCase.GetPriceList(priceListID)
+	if err != nil {
+		h.logger.Error("Failed to get price list: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(priceList.ToPriceListDTO()))
+}
+
+// UpdatePriceList handles updating a price list. Entries in the body replace all existing entries (admin only).
+func (h *CustomerGroupHandler) UpdatePriceList(w http.ResponseWriter, r *http.Request) {
+	priceListID, ok := h.parseID(w, r, "priceListId")
+	if !ok {
+		return
+	}
+
+	var request contracts.PriceListRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode price list request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	priceList, err := h.customerGroupUseCase.UpdatePriceList(priceListID, request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to update price list: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(priceList.ToPriceListDTO(), "Price list updated successfully"))
+}
+
+// DeletePriceList handles deleting a price list (admin only)
+func (h *CustomerGroupHandler) DeletePriceList(w http.ResponseWriter, r *http.Request) {
+	priceListID, ok := h.parseID(w, r, "priceListId")
+	if !ok {
+		return
+	}
+
+	if err := h.customerGroupUseCase.DeletePriceList(priceListID); err != nil {
+		h.logger.Error("Failed to delete price list: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseMessage("Price list deleted successfully"))
+}
+
+// ListPriceLists handles listing price lists (admin only)
+func (h *CustomerGroupHandler) ListPriceLists(w http.ResponseWriter, r *http.Request) {
+	offset, limit := parseOffsetLimit(r)
+
+	priceLists, err := h.customerGroupUseCase.ListPriceLists(offset, limit)
+	if err != nil {
+		h.logger.Error("Failed to list price lists: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.PriceListListResponse(priceLists, (offset/limit)+1, limit))
+}
+
+// parseID reads a numeric path parameter and writes a bad request response when it is invalid
+func (h *CustomerGroupHandler) parseID(w http.ResponseWriter, r *http.Request, name string) (uint, bool) {
+	id, err := strconv.ParseUint(mux.Vars(r)[name], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid %s: %v", name, err)
+		http.Error(w, "Invalid "+name, http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeError writes an error response, using 404 for missing resources
+func (h *CustomerGroupHandler) writeError(w http.ResponseWriter, err error, status int) {
+	if strings.Contains(err.Error(), "not found") {
+		status = http.StatusNotFound
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.ErrorResponse(err.Error()))
+}
+
+// parseOffsetLimit reads the offset and limit query parameters
+func parseOffsetLimit(r *http.Request) (int, int) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = 50 // Default limit
+	}
+	return offset, limit
+}
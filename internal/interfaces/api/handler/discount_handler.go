@@ -1,15 +1,23 @@
 package handler
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/dto"
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
 	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
 )
 
 // DiscountHandler handles discount-related HTTP requests
@@ -251,10 +259,8 @@ func (h *DiscountHandler) ApplyDiscountToOrder(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	role, _ := r.Context().Value("role").(string)
-
 	// Check if the user is authorized to apply discount to this order
-	if (order.UserID == nil || *order.UserID != userID) && role != string(entity.RoleAdmin) {
+	if (order.UserID == nil || *order.UserID != userID) && !middleware.HasPermission(r, entity.PermissionOrdersWrite) {
 		h.logger.Error("Unauthorized access: user does not own the order")
 		response := contracts.ErrorResponse("Unauthorized access: user does not own the order")
 		w.Header().Set("Content-Type", "application/json")
@@ -327,10 +333,8 @@ func (h *DiscountHandler) RemoveDiscountFromOrder(w http.ResponseWriter, r *http
 		return
 	}
 
-	role, _ := r.Context().Value("role").(string)
-
 	// Check if the user is authorized to remove discount from this order
-	if (order.UserID == nil || *order.UserID != userID) && role != string(entity.RoleAdmin) {
+	if (order.UserID == nil || *order.UserID != userID) && !middleware.HasPermission(r, entity.PermissionOrdersWrite) {
 		response := contracts.ErrorResponse("Unauthorized access: user does not own the order")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusForbidden)
@@ -416,3 +420,259 @@ func (h *DiscountHandler) ValidateDiscountCode(w http.ResponseWriter, r *http.Re
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// GenerateDiscountCodeBatch handles generating a batch of unique codes for a discount (admin only)
+func (h *DiscountHandler) GenerateDiscountCodeBatch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	discountID, err := strconv.ParseUint(vars["discountId"], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid discount ID: %v", err)
+		http.Error(w, "Invalid discount ID", http.StatusBadRequest)
+		return
+	}
+
+	var req contracts.GenerateDiscountCodeBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	batch, err := h.discountUseCase.GenerateDiscountCodeBatch(req.ToUseCaseInput(uint(discountID)))
+	if err != nil {
+		h.logger.Error("Failed to generate discount code batch: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	h.writeCreatedBatch(w, batch)
+}
+
+// ImportDiscountCodeBatch handles importing a batch of codes from a CSV body (admin only).
+// The first column of every row is used as the code; a leading "code" header row is skipped.
+func (h *DiscountHandler) ImportDiscountCodeBatch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	discountID, err := strconv.ParseUint(vars["discountId"], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid discount ID: %v", err)
+		http.Error(w, "Invalid discount ID", http.StatusBadRequest)
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	usageLimitPerCode := 1
+	if value := r.URL.Query().Get("usage_limit_per_code"); value != "" {
+		usageLimitPerCode, err = strconv.Atoi(value)
+		if err != nil {
+			h.logger.Error("Invalid usage limit per code: %v", err)
+			http.Error(w, "Invalid usage_limit_per_code", http.StatusBadRequest)
+			return
+		}
+	}
+
+	codes, err := readDiscountCodesCSV(r.Body)
+	if err != nil {
+		h.logger.Error("Failed to read discount codes CSV: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	batch, err := h.discountUseCase.ImportDiscountCodeBatch(usecase.ImportDiscountCodeBatchInput{
+		DiscountID:        uint(discountID),
+		Name:              name,
+		UsageLimitPerCode: usageLimitPerCode,
+		Codes:             codes,
+	})
+	if err != nil {
+		h.logger.Error("Failed to import discount code batch: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	h.writeCreatedBatch(w, batch)
+}
+
+// ListDiscountCodeBatches handles listing the code batches of a discount with redemption stats (admin only)
+func (h *DiscountHandler) ListDiscountCodeBatches(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	discountID, err := strconv.ParseUint(vars["discountId"], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid discount ID: %v", err)
+		http.Error(w, "Invalid discount ID", http.StatusBadRequest)
+		return
+	}
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	batches, stats, err := h.discountUseCase.ListDiscountCodeBatches(uint(discountID), offset, limit)
+	if err != nil {
+		h.logger.Error("Failed to list discount code batches: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	batchDTOs := make([]dto.DiscountCodeBatchDTO, 0, len(batches))
+	for _, batch := range batches {
+		batchDTOs = append(batchDTOs, batch.ToDiscountCodeBatchDTO(stats[batch.ID]))
+	}
+
+	page := (offset / limit) + 1
+	response := contracts.DiscountCodeBatchListResponse(batchDTOs, page, limit)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetDiscountCodeBatch handles getting a code batch with its redemption stats (admin only)
+func (h *DiscountHandler) GetDiscountCodeBatch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	batchID, err := strconv.ParseUint(vars["batchId"], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid batch ID: %v", err)
+		http.Error(w, "Invalid batch ID", http.StatusBadRequest)
+		return
+	}
+
+	batch, stats, err := h.discountUseCase.GetDiscountCodeBatch(uint(batchID))
+	if err != nil {
+		h.logger.Error("Failed to get discount code batch: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	response := contracts.DiscountCodeBatchRetrieveResponse(batch.ToDiscountCodeBatchDTO(stats))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ExportDiscountCodeBatch handles exporting all codes of a batch as CSV (admin only)
+func (h *DiscountHandler) ExportDiscountCodeBatch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	batchID, err := strconv.ParseUint(vars["batchId"], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid batch ID: %v", err)
+		http.Error(w, "Invalid batch ID", http.StatusBadRequest)
+		return
+	}
+
+	codes, err := h.discountUseCase.ListDiscountCodes(uint(batchID))
+	if err != nil {
+		h.logger.Error("Failed to export discount code batch: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"discount-codes-%d.csv\"", batchID))
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"code", "usage_limit", "current_usage", "last_redeemed_at"})
+	for _, code := range codes {
+		lastRedeemedAt := ""
+		if code.LastRedeemedAt != nil {
+			lastRedeemedAt = code.LastRedeemedAt.UTC().Format(time.RFC3339)
+		}
+		writer.Write([]string{
+			code.Code,
+			strconv.Itoa(code.UsageLimit),
+			strconv.Itoa(code.CurrentUsage),
+			lastRedeemedAt,
+		})
+	}
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		h.logger.Error("Failed to write discount codes CSV: %v", err)
+	}
+}
+
+// DeleteDiscountCodeBatch handles deleting a code batch and all of its codes (admin only)
+func (h *DiscountHandler) DeleteDiscountCodeBatch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	batchID, err := strconv.ParseUint(vars["batchId"], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid batch ID: %v", err)
+		http.Error(w, "Invalid batch ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.discountUseCase.DeleteDiscountCodeBatch(uint(batchID)); err != nil {
+		h.logger.Error("Failed to delete discount code batch: %v", err)
+		response := contracts.ErrorResponse(err.Error())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	response := contracts.DiscountCodeBatchDeleteResponse()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// writeCreatedBatch writes the response for a newly created code batch
+func (h *DiscountHandler) writeCreatedBatch(w http.ResponseWriter, batch *entity.DiscountCodeBatch) {
+	stats := &entity.DiscountCodeBatchStats{TotalCodes: len(batch.Codes)}
+	response := contracts.DiscountCodeBatchCreateResponse(batch.ToDiscountCodeBatchDTO(stats))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// readDiscountCodesCSV reads discount codes from the first column of a CSV document
+func readDiscountCodesCSV(body io.Reader) ([]string, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var codes []string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		if len(record) == 0 {
+			continue
+		}
+
+		code := strings.TrimSpace(record[0])
+		if code == "" || (len(codes) == 0 && strings.EqualFold(code, "code")) {
+			continue
+		}
+		codes = append(codes, code)
+	}
+
+	if len(codes) == 0 {
+		return nil, errors.New("CSV contains no codes")
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+)
+
+// DownloadHandler handles the files of digital variants and their downloads
+type DownloadHandler struct {
+	downloadUseCase *usecase.DownloadUseCase
+	maxFileSize     int64
+	logger          logger.Logger
+}
+
+// NewDownloadHandler creates a new DownloadHandler
+func NewDownloadHandler(downloadUseCase *usecase.DownloadUseCase, maxFileSize int64, logger logger.Logger) *DownloadHandler {
+	return &DownloadHandler{
+		downloadUseCase: downloadUseCase,
+		maxFileSize:     maxFileSize,
+		logger:          logger,
+	}
+}
+
+// UploadDigitalFile handles uploading a file of a digital variant in the "file" field of a multipart form (admin only)
+func (h *DownloadHandler) UploadDigitalFile(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+	variantID, ok := h.parseID(w, r, "variantId")
+	if !ok {
+		return
+	}
+
+	if h.maxFileSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxFileSize+multipartOverhead)
+	}
+	// Large files are buffered on disk rather than in memory
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		h.logger.Error("Failed to read digital file upload: %v", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.writeError(w, errors.New("file exceeds the maximum upload size"), http.StatusRequestEntityTooLarge)
+			return
+		}
+		h.writeError(w, errors.New("file is required in the file field of a multipart form"), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	digitalFile, err := h.downloadUseCase.UploadDigitalFile(productID, variantID, usecase.UploadDigitalFileInput{
+		FileName:    header.Filename,
+		ContentType: header.Header.Get("Content-Type"),
+		Body:        file,
+		Size:        header.Size,
+	})
+	if err != nil {
+		h.logger.Error("Failed to upload digital file: %v", err)
+		status := http.StatusBadRequest
+		switch {
+		case strings.Contains(err.Error(), "maximum upload size"):
+			status = http.StatusRequestEntityTooLarge
+		case strings.Contains(err.Error(), "failed to"), strings.Contains(err.Error(), "not configured"):
+			status = http.StatusInternalServerError
+		}
+		h.writeError(w, err, status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(digitalFile.ToDigitalFileDTO(), "Digital file uploaded successfully"))
+}
+
+// ListDigitalFiles handles listing the files of a digital variant (admin only)
+func (h *DownloadHandler) ListDigitalFiles(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+	variantID, ok := h.parseID(w, r, "variantId")
+	if !ok {
+		return
+	}
+
+	files, err := h.downloadUseCase.ListDigitalFiles(productID, variantID)
+	if err != nil {
+		h.logger.Error("Failed to list digital files: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.DigitalFilesResponse(files))
+}
+
+// DeleteDigitalFile handles deleting a file of a digital variant with its downloads (admin only)
+func (h *DownloadHandler) DeleteDigitalFile(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+	variantID, ok := h.parseID(w, r, "variantId")
+	if !ok {
+		return
+	}
+	fileID, ok := h.parseID(w, r, "fileId")
+	if !ok {
+		return
+	}
+
+	if err := h.downloadUseCase.DeleteDigitalFile(productID, variantID, fileID); err != nil {
+		h.logger.Error("Failed to delete digital file: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseMessage("Digital file deleted successfully"))
+}
+
+// Download handles a signed download link, streaming the file as an attachment
+func (h *DownloadHandler) Download(w http.ResponseWriter, r *http.Request) {
+	downloadID, ok := h.parseID(w, r, "downloadId")
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	file, body, err := h.downloadUseCase.DownloadFile(downloadID, query.Get("expires"), query.Get("signature"))
+	if err != nil {
+		h.logger.Error("Failed to download file: %v", err)
+		status := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "signature"):
+			status = http.StatusForbidden
+		case strings.Contains(err.Error(), "expired"), strings.Contains(err.Error(), "no longer available"):
+			status = http.StatusGone
+		case strings.Contains(err.Error(), "limit"):
+			status = http.StatusTooManyRequests
+		}
+		h.writeError(w, err, status)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(file.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	w.Header().Set("Cache-Control", "private, no-store")
+	if _, err := io.Copy(w, body); err != nil {
+		h.logger.Error("Failed to stream download %d: %v", downloadID, err)
+	}
+}
+
+// parseID reads a numeric path parameter
+func (h *DownloadHandler) parseID(w http.ResponseWriter, r *http.Request, name string) (uint, bool) {
+	id, err := strconv.ParseUint(mux.Vars(r)[name], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid %s: %v", name, err)
+		http.Error(w, "Invalid "+name, http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeError writes an error response, using 404 for missing resources
+func (h *DownloadHandler) writeError(w http.ResponseWriter, err error, status int) {
+	if strings.Contains(err.Error(), "not found") {
+		status = http.StatusNotFound
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.ErrorResponse(err.Error()))
+}
@@ -16,15 +16,17 @@ import (
 
 // OrderHandler handles order-related HTTP requests
 type OrderHandler struct {
-	orderUseCase *usecase.OrderUseCase
-	logger       logger.Logger
+	orderUseCase       *usecase.OrderUseCase
+	customFieldUseCase *usecase.CustomFieldUseCase
+	logger             logger.Logger
 }
 
 // NewOrderHandler creates a new OrderHandler
-func NewOrderHandler(orderUseCase *usecase.OrderUseCase, logger logger.Logger) *OrderHandler {
+func NewOrderHandler(orderUseCase *usecase.OrderUseCase, customFieldUseCase *usecase.CustomFieldUseCase, logger logger.Logger) *OrderHandler {
 	return &OrderHandler{
-		orderUseCase: orderUseCase,
-		logger:       logger,
+		orderUseCase:       orderUseCase,
+		customFieldUseCase: customFieldUseCase,
+		logger:             logger,
 	}
 }
 
@@ -56,20 +58,14 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check authorization: user owns the order, admin, or checkout session matches
+	// Check authorization: user owns the order, can read all orders, or checkout session matches
 	authorized := false
 
-	// Check if authenticated user owns the order or is admin
-	if isAuthenticated {
-		if order.UserID != nil && *order.UserID == userID {
-			authorized = true
-		} else {
-			// Check if user is admin
-			role, ok := r.Context().Value(middleware.RoleKey).(string)
-			if ok && role == string(entity.RoleAdmin) {
-				authorized = true
-			}
-		}
+	// Check if the user or API key can read all orders, or the authenticated user owns the order
+	if middleware.HasPermission(r, entity.PermissionOrdersRead) {
+		authorized = true
+	} else if isAuthenticated && order.UserID != nil && *order.UserID == userID {
+		authorized = true
 	}
 
 	// If not authorized by user auth, check checkout session cookie
@@ -90,6 +86,21 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Paid orders list the downloads of their digital items
+	if err := h.orderUseCase.LoadOrderDownloads(order); err != nil {
+		h.logger.Error("Failed to load downloads of order %d: %v", order.ID, err)
+	}
+
+	// Customers only see the public custom fields
+	if err := h.customFieldUseCase.FilterOrderFields(middleware.HasPermission(r, entity.PermissionOrdersRead), order); err != nil {
+		h.logger.Error("Failed to filter custom fields of order %d: %v", order.ID, err)
+		response := contracts.ErrorResponse("Failed to get order")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	// Create order DTO with conditional includes
 	options := entity.OrderDetailOptions{
 		IncludePaymentTransactions: includePaymentTransactions,
@@ -132,6 +143,9 @@ func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 
 	// Get orders
 	orders, err := h.orderUseCase.GetUserOrders(userID, offset, pageSize)
+	if err == nil {
+		err = h.customFieldUseCase.FilterOrderFields(false, orders...)
+	}
 	if err != nil {
 		h.logger.Error("Failed to list orders: %v", err)
 		// TODO: Add proper error handling
@@ -152,17 +166,6 @@ func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 
 // ListAllOrders handles listing all orders (admin only)
 func (h *OrderHandler) ListAllOrders(w http.ResponseWriter, r *http.Request) {
-	// Get user ID from context
-	_, ok := r.Context().Value(middleware.UserIDKey).(uint)
-	if !ok {
-		h.logger.Error("Unauthorized access attempt")
-		response := contracts.ErrorResponse("Unauthorized")
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(response)
-		return
-	}
-
 	// Parse pagination parameters
 	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
 	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
@@ -178,14 +181,24 @@ func (h *OrderHandler) ListAllOrders(w http.ResponseWriter, r *http.Request) {
 	// Calculate offset for pagination
 	offset := (page - 1) * pageSize
 
+	// cf.<key> parameters filter by custom field
+	customFields, err := customFieldFilters(h.customFieldUseCase, r, entity.CustomFieldResourceOrder)
+	if err != nil {
+		h.logger.Error("Invalid custom field filter: %v", err)
+		writeCustomFieldError(w, err, http.StatusBadRequest)
+		return
+	}
+
 	// Get orders by status if provided
 	var orders []*entity.Order
-	var err error
 
 	if status != "" {
-		orders, err = h.orderUseCase.ListOrdersByStatus(entity.OrderStatus(status), offset, pageSize)
+		orders, err = h.orderUseCase.ListOrdersByStatus(entity.OrderStatus(status), offset, pageSize, customFields)
 	} else {
-		orders, err = h.orderUseCase.ListAllOrders(offset, pageSize)
+		orders, err = h.orderUseCase.ListAllOrders(offset, pageSize, customFields)
+	}
+	if err == nil {
+		err = h.customFieldUseCase.FilterOrderFields(true, orders...)
 	}
 
 	if err != nil {
@@ -209,16 +222,6 @@ func (h *OrderHandler) ListAllOrders(w http.ResponseWriter, r *http.Request) {
 
 // UpdateOrderStatus handles updating an order's status (admin only)
 func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
-	_, ok := r.Context().Value(middleware.UserIDKey).(uint)
-	if !ok {
-		h.logger.Error("Unauthorized access attempt")
-		response := contracts.ErrorResponse("Unauthorized")
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(response)
-		return
-	}
-
 	// Get order ID from URL
 	vars := mux.Vars(r)
 	id, err := strconv.ParseUint(vars["orderId"], 10, 32)
@@ -264,16 +267,6 @@ func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
 
 // UpdateOrderStatusWithTracking handles updating an order's status with tracking information (admin only)
 func (h *OrderHandler) UpdateOrderStatusWithTracking(w http.ResponseWriter, r *http.Request) {
-	_, ok := r.Context().Value(middleware.UserIDKey).(uint)
-	if !ok {
-		h.logger.Error("Unauthorized access attempt")
-		response := contracts.ErrorResponse("Unauthorized")
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(response)
-		return
-	}
-
 	// Get order ID from URL
 	vars := mux.Vars(r)
 	id, err := strconv.ParseUint(vars["orderId"], 10, 32)
@@ -87,19 +87,19 @@ func (h *PaymentHandler) CapturePayment(w http.ResponseWriter, r *http.Request)
 		h.logger.Info("Both amount and is_full specified for payment %s, using is_full=true", paymentID)
 	}
 
+	// Get the order to determine the full amount and the currency of partial amounts
+	order, err := h.orderUseCase.GetOrderByPaymentID(paymentID)
+	if err != nil {
+		h.logger.Error("Failed to get order for payment %s: %v", paymentID, err)
+		http.Error(w, "Order not found for payment ID", http.StatusNotFound)
+		return
+	}
+
 	// Capture payment
-	var err error
 	if request.IsFull {
-		// For full capture, we need to get the order first to determine the full amount
-		order, orderErr := h.orderUseCase.GetOrderByPaymentID(paymentID)
-		if orderErr != nil {
-			h.logger.Error("Failed to get order for payment %s: %v", paymentID, orderErr)
-			http.Error(w, "Order not found for payment ID", http.StatusNotFound)
-			return
-		}
 		err = h.orderUseCase.CapturePayment(paymentID, order.FinalAmount)
 	} else {
-		err = h.orderUseCase.CapturePayment(paymentID, money.ToCents(request.Amount))
+		err = h.orderUseCase.CapturePayment(paymentID, money.ToMinor(request.Amount, order.Currency))
 	}
 	if err != nil {
 		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to capture payment: "+err.Error())
@@ -160,19 +160,19 @@ func (h *PaymentHandler) RefundPayment(w http.ResponseWriter, r *http.Request) {
 		h.logger.Info("Both amount and is_full specified for payment %s, using is_full=true", paymentID)
 	}
 
+	// Get the order to determine the full amount and the currency of partial amounts
+	order, err := h.orderUseCase.GetOrderByPaymentID(paymentID)
+	if err != nil {
+		h.logger.Error("Failed to get order for payment %s: %v", paymentID, err)
+		http.Error(w, "Order not found for payment ID", http.StatusNotFound)
+		return
+	}
+
 	// Refund payment
-	var err error
 	if request.IsFull {
-		// For full refund, we need to get the order first to determine the full amount
-		order, orderErr := h.orderUseCase.GetOrderByPaymentID(paymentID)
-		if orderErr != nil {
-			h.logger.Error("Failed to get order for payment %s: %v", paymentID, orderErr)
-			http.Error(w, "Order not found for payment ID", http.StatusNotFound)
-			return
-		}
 		err = h.orderUseCase.RefundPayment(paymentID, order.FinalAmount)
 	} else {
-		err = h.orderUseCase.RefundPayment(paymentID, money.ToCents(request.Amount))
+		err = h.orderUseCase.RefundPayment(paymentID, money.ToMinor(request.Amount, order.Currency))
 	}
 	if err != nil {
 		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to refund payment: "+err.Error())
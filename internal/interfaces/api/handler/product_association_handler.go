@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/common"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
+)
+
+// ProductAssociationHandler handles related, cross-sell, upsell and accessory product requests
+type ProductAssociationHandler struct {
+	associationUseCase *usecase.ProductAssociationUseCase
+	translationUseCase *usecase.TranslationUseCase
+	customFieldUseCase *usecase.CustomFieldUseCase
+	logger             logger.Logger
+}
+
+// NewProductAssociationHandler creates a new ProductAssociationHandler
+func NewProductAssociationHandler(associationUseCase *usecase.ProductAssociationUseCase, translationUseCase *usecase.TranslationUseCase, customFieldUseCase *usecase.CustomFieldUseCase, logger logger.Logger) *ProductAssociationHandler {
+	return &ProductAssociationHandler{
+		associationUseCase: associationUseCase,
+		translationUseCase: translationUseCase,
+		customFieldUseCase: customFieldUseCase,
+		logger:             logger,
+	}
+}
+
+// GetProductAssociations handles getting the active products associated with a product
+func (h *ProductAssociationHandler) GetProductAssociations(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+	userID, _ := r.Context().Value(middleware.UserIDKey).(uint)
+
+	associations, err := h.associationUseCase.GetProductAssociations(productID, userID)
+	if err != nil {
+		h.logger.Error("Failed to get product associations: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	locale, _ := r.Context().Value(middleware.LocaleKey).(string)
+	if err := h.translationUseCase.LocalizeProducts(locale, associations.Products()...); err != nil {
+		h.logger.Error("Failed to translate product associations: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+	if err := h.customFieldUseCase.FilterProductFields(false, associations.Products()...); err != nil {
+		h.logger.Error("Failed to filter custom fields of product associations: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(contracts.ProductAssociationsResponse(associations)))
+}
+
+// GetCheckoutAssociations handles getting the active products associated with the contents of the current checkout
+func (h *ProductAssociationHandler) GetCheckoutAssociations(w http.ResponseWriter, r *http.Request) {
+	// Recommendations never start a checkout session
+	var sessionID string
+	if cookie, err := r.Cookie(common.CheckoutSessionCookie); err == nil {
+		sessionID = cookie.Value
+	}
+	userID, _ := r.Context().Value(middleware.UserIDKey).(uint)
+
+	associations, err := h.associationUseCase.GetCheckoutAssociations(sessionID, userID)
+	if err != nil {
+		h.logger.Error("Failed to get checkout associations: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	locale, _ := r.Context().Value(middleware.LocaleKey).(string)
+	if err := h.translationUseCase.LocalizeProducts(locale, associations.Products()...); err != nil {
+		h.logger.Error("Failed to translate checkout associations: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+	if err := h.customFieldUseCase.FilterProductFields(false, associations.Products()...); err != nil {
+		h.logger.Error("Failed to filter custom fields of checkout associations: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(contracts.ProductAssociationsResponse(associations)))
+}
+
+// ListAssociations handles listing the associations of a product of all types, including inactive products (admin only)
+func (h *ProductAssociationHandler) ListAssociations(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+
+	associations, err := h.associationUseCase.ListAssociations(productID)
+	if err != nil {
+		h.logger.Error("Failed to list product associations: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(contracts.ProductAssociationListResponse(associations)))
+}
+
+// SetAssociations handles replacing the products associated with a product with one type (admin only)
+func (h *ProductAssociationHandler) SetAssociations(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+	associationType := entity.AssociationType(mux.Vars(r)["type"])
+
+	var request contracts.SetProductAssociationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode product associations request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	associations, err := h.associationUseCase.SetAssociations(productID, associationType, request.ProductIDs)
+	if err != nil {
+		h.logger.Error("Failed to set product associations: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(contracts.ProductAssociationListResponse(associations), "Product associations updated successfully"))
+}
+
+// parseID reads a numeric path parameter
+func (h *ProductAssociationHandler) parseID(w http.ResponseWriter, r *http.Request, name string) (uint, bool) {
+	id, err := strconv.ParseUint(mux.Vars(r)[name], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid %s: %v", name, err)
+		http.Error(w, "Invalid "+name, http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeError writes an error response, mapping known association errors to their status
+func (h *ProductAssociationHandler) writeError(w http.ResponseWriter, err error, status int) {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		status = http.StatusNotFound
+	case strings.Contains(err.Error(), "invalid association type"), strings.Contains(err.Error(), "cannot"),
+		strings.Contains(err.Error(), "is required"), strings.Contains(err.Error(), "more than once"):
+		status = http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.ErrorResponse(err.Error()))
+}
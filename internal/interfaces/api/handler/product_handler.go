@@ -3,12 +3,16 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
+	"path"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/zenfulcode/commercify/config"
 	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/dto"
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	errors "github.com/zenfulcode/commercify/internal/domain/error"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
@@ -18,17 +22,21 @@ import (
 
 // ProductHandler handles product-related HTTP requests
 type ProductHandler struct {
-	productUseCase *usecase.ProductUseCase
-	logger         logger.Logger
-	config         *config.Config
+	productUseCase     *usecase.ProductUseCase
+	translationUseCase *usecase.TranslationUseCase
+	customFieldUseCase *usecase.CustomFieldUseCase
+	logger             logger.Logger
+	config             *config.Config
 }
 
 // NewProductHandler creates a new ProductHandler
-func NewProductHandler(productUseCase *usecase.ProductUseCase, logger logger.Logger, config *config.Config) *ProductHandler {
+func NewProductHandler(productUseCase *usecase.ProductUseCase, translationUseCase *usecase.TranslationUseCase, customFieldUseCase *usecase.CustomFieldUseCase, logger logger.Logger, config *config.Config) *ProductHandler {
 	return &ProductHandler{
-		productUseCase: productUseCase,
-		logger:         logger,
-		config:         config,
+		productUseCase:     productUseCase,
+		translationUseCase: translationUseCase,
+		customFieldUseCase: customFieldUseCase,
+		logger:             logger,
+		config:             config,
 	}
 }
 
@@ -44,9 +52,34 @@ func (h *ProductHandler) handleError(w http.ResponseWriter, err error, operation
 	case err.Error() == errors.ProductNotFoundError:
 		statusCode = http.StatusNotFound
 		errorMessage = err.Error()
+	case strings.HasPrefix(err.Error(), "product with ID") && strings.HasSuffix(err.Error(), "not found"):
+		statusCode = http.StatusNotFound
+		errorMessage = errors.ProductNotFoundError
+	case strings.Contains(err.Error(), "sales channel") || strings.Contains(err.Error(), "publish time") ||
+		strings.Contains(err.Error(), "custom field"):
+		statusCode = http.StatusBadRequest
+		errorMessage = err.Error()
 	case strings.Contains(err.Error(), "unauthorized") || strings.Contains(err.Error(), "not authorized"):
 		statusCode = http.StatusForbidden
 		errorMessage = "Not authorized to perform this operation"
+	case strings.Contains(err.Error(), "slug") && strings.Contains(err.Error(), "not found"):
+		statusCode = http.StatusNotFound
+		errorMessage = "Product not found"
+	case strings.Contains(err.Error(), "already in use"):
+		statusCode = http.StatusConflict
+		errorMessage = err.Error()
+	case isSlugOrSEOError(err):
+		statusCode = http.StatusBadRequest
+		errorMessage = err.Error()
+	case strings.Contains(err.Error(), "option") || strings.Contains(err.Error(), "SKU pattern"):
+		statusCode = http.StatusBadRequest
+		errorMessage = err.Error()
+	case strings.Contains(err.Error(), "component of a bundle") && strings.Contains(err.Error(), "cannot delete"):
+		statusCode = http.StatusConflict
+		errorMessage = err.Error()
+	case strings.Contains(err.Error(), "bundle") || strings.Contains(err.Error(), "component") || strings.Contains(err.Error(), "product type"):
+		statusCode = http.StatusBadRequest
+		errorMessage = err.Error()
 	case strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "already exists"):
 		statusCode = http.StatusConflict
 		if strings.Contains(err.Error(), "variant") {
@@ -113,18 +146,18 @@ func (h *ProductHandler) writeErrorResponse(w http.ResponseWriter, statusCode in
 	json.NewEncoder(w).Encode(response)
 }
 
-// checkAdminAuthorization checks if the user has admin role
-func (h *ProductHandler) checkAdminAuthorization(r *http.Request) bool {
-	role, ok := r.Context().Value(middleware.RoleKey).(string)
-	return ok && role == string(entity.RoleAdmin)
+// redirectToSlug permanently redirects a request for a former slug to the same route with the current slug
+func redirectToSlug(w http.ResponseWriter, r *http.Request, current string) {
+	target := url.URL{Path: path.Join(path.Dir(r.URL.Path), url.PathEscape(current)), RawQuery: r.URL.RawQuery}
+	http.Redirect(w, r, target.String(), http.StatusMovedPermanently)
 }
 
 // --- Handlers --- //
 
 // CreateProduct handles product creation (admin only)
 func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
-	// Check admin authorization
-	if !h.checkAdminAuthorization(r) {
+	// Check permission
+	if !middleware.HasPermission(r, entity.PermissionCatalogWrite) {
 		h.handleAuthorizationError(w, "CreateProduct")
 		return
 	}
@@ -165,13 +198,33 @@ func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get product - no currency filtering needed since each product has its own currency
-	product, err := h.productUseCase.GetProductByID(uint(id))
+	// Prices are expressed in the product currency unless another currency is requested
+	currency := strings.ToUpper(r.URL.Query().Get("currency"))
+	// Signed-in customers see the prices of their customer group
+	userID, _ := r.Context().Value(middleware.UserIDKey).(uint)
+	channel, err := visibleIn(r)
+	if err != nil {
+		h.handleError(w, err, "retrieve product")
+		return
+	}
+	product, err := h.productUseCase.GetProductForCustomer(uint(id), currency, userID, channel)
 	if err != nil {
 		h.handleError(w, err, "retrieve product")
 		return
 	}
 
+	// Names and descriptions are shown in the language of the customer
+	locale, _ := r.Context().Value(middleware.LocaleKey).(string)
+	if err := h.translationUseCase.LocalizeProducts(locale, product); err != nil {
+		h.handleError(w, err, "translate product")
+		return
+	}
+	// Customers only see the public custom fields
+	if err := h.customFieldUseCase.FilterProductFields(middleware.HasPermission(r, entity.PermissionCatalogRead), product); err != nil {
+		h.handleError(w, err, "retrieve product")
+		return
+	}
+
 	// Convert to DTO
 	response := contracts.SuccessResponse(product.ToProductDTO())
 
@@ -179,10 +232,51 @@ func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// GetProductBySlug handles getting a product by its slug. Former slugs redirect to the current slug.
+func (h *ProductHandler) GetProductBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := mux.Vars(r)["slug"]
+
+	// Prices are expressed in the product currency unless another currency is requested
+	currency := strings.ToUpper(r.URL.Query().Get("currency"))
+	// Signed-in customers see the prices of their customer group
+	userID, _ := r.Context().Value(middleware.UserIDKey).(uint)
+	channel, err := visibleIn(r)
+	if err != nil {
+		h.handleError(w, err, "retrieve product")
+		return
+	}
+	product, err := h.productUseCase.GetProductBySlugForCustomer(slug, currency, userID, channel)
+	if err != nil {
+		h.handleError(w, err, "retrieve product")
+		return
+	}
+
+	if product.Slug != slug {
+		redirectToSlug(w, r, product.Slug)
+		return
+	}
+
+	locale, _ := r.Context().Value(middleware.LocaleKey).(string)
+	if err := h.translationUseCase.LocalizeProducts(locale, product); err != nil {
+		h.handleError(w, err, "translate product")
+		return
+	}
+	// Customers only see the public custom fields
+	if err := h.customFieldUseCase.FilterProductFields(middleware.HasPermission(r, entity.PermissionCatalogRead), product); err != nil {
+		h.handleError(w, err, "retrieve product")
+		return
+	}
+
+	response := contracts.SuccessResponse(product.ToProductDTO())
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // UpdateProduct handles updating a product (admin only)
 func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
-	// Check admin authorization
-	if !h.checkAdminAuthorization(r) {
+	// Check permission
+	if !middleware.HasPermission(r, entity.PermissionCatalogWrite) {
 		h.handleAuthorizationError(w, "UpdateProduct")
 		return
 	}
@@ -202,8 +296,15 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Prices are given in the product currency
+	existing, err := h.productUseCase.GetProductByID(uint(id))
+	if err != nil {
+		h.handleError(w, err, "update product")
+		return
+	}
+
 	// Convert DTO to usecase input
-	input := request.ToUseCaseInput()
+	input := request.ToUseCaseInput(existing.Currency)
 
 	// Update product
 	product, err := h.productUseCase.UpdateProduct(uint(id), input)
@@ -221,8 +322,8 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 
 // DeleteProduct handles deleting a product (admin only)
 func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
-	// Check admin authorization
-	if !h.checkAdminAuthorization(r) {
+	// Check permission
+	if !middleware.HasPermission(r, entity.PermissionCatalogWrite) {
 		h.handleAuthorizationError(w, "DeleteProduct")
 		return
 	}
@@ -250,8 +351,8 @@ func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 
 // ListProducts handles listing all products (admin only)
 func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
-	// Check admin authorization
-	if !h.checkAdminAuthorization(r) {
+	// Check permission
+	if !middleware.HasPermission(r, entity.PermissionCatalogRead) {
 		h.handleAuthorizationError(w, "ListProducts")
 		return
 	}
@@ -326,6 +427,24 @@ func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
 		ActiveOnly:   activeOnly,
 	}
 
+	// Active products are the products visible in the sales channel, the webshop by default
+	if channel := r.URL.Query().Get("channel"); channel != "" {
+		salesChannel, err := entity.ParseSalesChannel(channel)
+		if err != nil {
+			h.handleError(w, err, "search products")
+			return
+		}
+		input.Channel = salesChannel
+	}
+
+	// cf.<key> parameters filter by custom field
+	customFields, err := customFieldFilters(h.customFieldUseCase, r, entity.CustomFieldResourceProduct)
+	if err != nil {
+		h.handleError(w, err, "search products")
+		return
+	}
+	input.CustomFields = customFields
+
 	// Handle optional fields
 	if query != nil {
 		input.Query = *query
@@ -345,6 +464,10 @@ func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
 		h.handleError(w, err, "search products")
 		return
 	}
+	if err := h.customFieldUseCase.FilterProductFields(true, products...); err != nil {
+		h.handleError(w, err, "search products")
+		return
+	}
 
 	response := contracts.CreateProductListResponse(products, total, page, pageSize)
 
@@ -352,84 +475,130 @@ func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// SearchProducts handles searching products
+// searchReservedParams are the search query parameters that are not variant attribute filters
+var searchReservedParams = []string{
+	"query", "category_id", "include_subcategories", "min_price", "max_price",
+	"currency", "in_stock", "sort", "page", "page_size", "locale", "channel",
+}
+
+// SearchProducts handles searching products with faceted filters.
+// Query parameters other than the reserved ones filter by variant attribute, e.g. color=red&size=M.
+// Several values of an attribute can be repeated or comma-separated.
 func (h *ProductHandler) SearchProducts(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
 	// Parse query parameters
-	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	page, _ := strconv.Atoi(params.Get("page"))
 	if page <= 0 {
 		page = 1 // Default page
 	}
 
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	pageSize, _ := strconv.Atoi(params.Get("page_size"))
 	if pageSize <= 0 {
 		pageSize = 10 // Default page size
 	}
 
-	// Parse optional parameters
-	var query *string
-	if queryStr := r.URL.Query().Get("query"); queryStr != "" {
-		query = &queryStr
+	offset := (page - 1) * pageSize
+
+	// Convert to usecase input
+	input := usecase.SearchProductsInput{
+		Query:                params.Get("query"),
+		Offset:               uint(offset),
+		Limit:                uint(pageSize),
+		CurrencyCode:         params.Get("currency"),
+		ActiveOnly:           true, // Only active products by default
+		IncludeSubcategories: params.Get("include_subcategories") != "false",
+		InStockOnly:          params.Get("in_stock") == "true",
+		Sort:                 usecase.ProductSort(params.Get("sort")),
+		Attributes:           make(map[string][]string),
+	}
+	input.UserID, _ = r.Context().Value(middleware.UserIDKey).(uint)
+
+	// Only the products visible in the sales channel are found, the webshop by default
+	if channel := params.Get("channel"); channel != "" {
+		salesChannel, err := entity.ParseSalesChannel(channel)
+		if err != nil {
+			h.handleError(w, err, "search products")
+			return
+		}
+		input.Channel = salesChannel
 	}
 
-	var categoryID *uint
-	if catIDStr := r.URL.Query().Get("category_id"); catIDStr != "" {
+	// Handle optional fields
+	if catIDStr := params.Get("category_id"); catIDStr != "" {
 		if catID, err := strconv.ParseUint(catIDStr, 10, 32); err == nil {
-			catIDUint := uint(catID)
-			categoryID = &catIDUint
+			input.CategoryID = uint(catID)
 		}
 	}
-
-	var minPrice *float64
-	if minPriceStr := r.URL.Query().Get("min_price"); minPriceStr != "" {
+	if minPriceStr := params.Get("min_price"); minPriceStr != "" {
 		if minPriceVal, err := strconv.ParseFloat(minPriceStr, 64); err == nil {
-			minPrice = &minPriceVal
+			input.MinPrice = minPriceVal
 		}
 	}
-
-	var maxPrice *float64
-	if maxPriceStr := r.URL.Query().Get("max_price"); maxPriceStr != "" {
+	if maxPriceStr := params.Get("max_price"); maxPriceStr != "" {
 		if maxPriceVal, err := strconv.ParseFloat(maxPriceStr, 64); err == nil {
-			maxPrice = &maxPriceVal
+			input.MaxPrice = maxPriceVal
 		}
 	}
 
-	var currencyCode string
-	if currencyCodeStr := r.URL.Query().Get("currency"); currencyCodeStr != "" {
-		currencyCode = currencyCodeStr
+	// Remaining parameters filter by variant attribute
+	for name, values := range params {
+		if slices.Contains(searchReservedParams, name) {
+			continue
+		}
+		for _, value := range values {
+			for _, v := range strings.Split(value, ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					input.Attributes[name] = append(input.Attributes[name], v)
+				}
+			}
+		}
 	}
 
-	offset := (page - 1) * pageSize
-
-	// Convert to usecase input
-	input := usecase.SearchProductsInput{
-		Offset:       uint(offset),
-		Limit:        uint(pageSize),
-		CurrencyCode: currencyCode,
-		ActiveOnly:   true, // Only active products by default
+	result, err := h.productUseCase.SearchProducts(input)
+	if err != nil {
+		h.handleError(w, err, "search products")
+		return
 	}
 
-	// Handle optional fields
-	if query != nil {
-		input.Query = *query
+	locale, _ := r.Context().Value(middleware.LocaleKey).(string)
+	if err := h.translationUseCase.LocalizeProducts(locale, result.Products...); err != nil {
+		h.handleError(w, err, "translate products")
+		return
 	}
-	if categoryID != nil {
-		input.CategoryID = *categoryID
+	if result.Facets != nil {
+		if err := h.translationUseCase.LocalizeCategoryFacets(locale, result.Facets.Categories); err != nil {
+			h.handleError(w, err, "translate products")
+			return
+		}
 	}
-	if minPrice != nil {
-		input.MinPrice = *minPrice
+	if err := h.customFieldUseCase.FilterProductFields(middleware.HasPermission(r, entity.PermissionCatalogRead), result.Products...); err != nil {
+		h.handleError(w, err, "search products")
+		return
 	}
-	if maxPrice != nil {
-		input.MaxPrice = *maxPrice
+
+	// Convert to DTOs
+	response := contracts.CreateProductSearchResponse(result, page, pageSize)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RebuildSearchIndex handles reindexing all products for search (admin only)
+func (h *ProductHandler) RebuildSearchIndex(w http.ResponseWriter, r *http.Request) {
+	// Check permission
+	if !middleware.HasPermission(r, entity.PermissionCatalogWrite) {
+		h.handleAuthorizationError(w, "RebuildSearchIndex")
+		return
 	}
 
-	products, total, err := h.productUseCase.ListProducts(input)
+	indexed, err := h.productUseCase.RebuildSearchIndex()
 	if err != nil {
-		h.handleError(w, err, "search products")
+		h.handleError(w, err, "rebuild search index")
 		return
 	}
 
-	// Convert to DTOs
-	response := contracts.CreateProductListResponse(products, total, page, pageSize)
+	response := contracts.SuccessResponseWithMessage(map[string]int{"indexed": indexed}, "Product search index rebuilt successfully")
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
@@ -455,8 +624,8 @@ func (h *ProductHandler) ListCategories(w http.ResponseWriter, r *http.Request)
 
 // AddVariant handles adding a new variant to a product (admin only)
 func (h *ProductHandler) AddVariant(w http.ResponseWriter, r *http.Request) {
-	// Check admin authorization
-	if !h.checkAdminAuthorization(r) {
+	// Check permission
+	if !middleware.HasPermission(r, entity.PermissionCatalogWrite) {
 		h.handleAuthorizationError(w, "AddVariant")
 		return
 	}
@@ -476,8 +645,15 @@ func (h *ProductHandler) AddVariant(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Prices are given in the product currency
+	product, err := h.productUseCase.GetProductByID(uint(productID))
+	if err != nil {
+		h.handleError(w, err, "add variant")
+		return
+	}
+
 	// Convert DTO to usecase input
-	input := request.ToUseCaseInput()
+	input := request.ToUseCaseInput(product.Currency)
 
 	// Add variant
 	variant, err := h.productUseCase.AddVariant(uint(productID), input)
@@ -494,10 +670,93 @@ func (h *ProductHandler) AddVariant(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// SetProductOptions handles replacing the option definitions of a product (admin only)
+func (h *ProductHandler) SetProductOptions(w http.ResponseWriter, r *http.Request) {
+	// Check permission
+	if !middleware.HasPermission(r, entity.PermissionCatalogWrite) {
+		h.handleAuthorizationError(w, "SetProductOptions")
+		return
+	}
+
+	// Get product ID from URL
+	vars := mux.Vars(r)
+	productID, err := strconv.ParseUint(vars["productId"], 10, 32)
+	if err != nil {
+		h.handleIDParsingError(w, err, "product", "SetProductOptions")
+		return
+	}
+
+	// Parse request body
+	var request contracts.SetProductOptionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.handleValidationError(w, err, "SetProductOptions")
+		return
+	}
+
+	product, err := h.productUseCase.SetProductOptions(uint(productID), request.ToUseCaseInput())
+	if err != nil {
+		h.handleError(w, err, "set product options")
+		return
+	}
+
+	response := contracts.SuccessResponseWithMessage(product.ToProductDTO(), "Product options updated successfully")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GenerateVariants handles creating the missing option combinations of a product as variants (admin only)
+func (h *ProductHandler) GenerateVariants(w http.ResponseWriter, r *http.Request) {
+	// Check permission
+	if !middleware.HasPermission(r, entity.PermissionCatalogWrite) {
+		h.handleAuthorizationError(w, "GenerateVariants")
+		return
+	}
+
+	// Get product ID from URL
+	vars := mux.Vars(r)
+	productID, err := strconv.ParseUint(vars["productId"], 10, 32)
+	if err != nil {
+		h.handleIDParsingError(w, err, "product", "GenerateVariants")
+		return
+	}
+
+	// Parse request body
+	var request contracts.GenerateVariantsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.handleValidationError(w, err, "GenerateVariants")
+		return
+	}
+
+	// Prices are given in the product currency
+	product, err := h.productUseCase.GetProductByID(uint(productID))
+	if err != nil {
+		h.handleError(w, err, "generate variants")
+		return
+	}
+
+	variants, err := h.productUseCase.GenerateVariants(uint(productID), request.ToUseCaseInput(product.Currency))
+	if err != nil {
+		h.handleError(w, err, "generate variants")
+		return
+	}
+
+	variantDTOs := make([]dto.VariantDTO, len(variants))
+	for i, variant := range variants {
+		variantDTOs[i] = *variant.ToVariantDTO()
+	}
+
+	response := contracts.SuccessResponseWithMessage(variantDTOs, "Variants generated successfully")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
 // UpdateVariant handles updating a product variant (admin only)
 func (h *ProductHandler) UpdateVariant(w http.ResponseWriter, r *http.Request) {
-	// Check admin authorization
-	if !h.checkAdminAuthorization(r) {
+	// Check permission
+	if !middleware.HasPermission(r, entity.PermissionCatalogWrite) {
 		h.handleAuthorizationError(w, "UpdateVariant")
 		return
 	}
@@ -523,8 +782,15 @@ func (h *ProductHandler) UpdateVariant(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Prices are given in the product currency
+	product, err := h.productUseCase.GetProductByID(uint(productID))
+	if err != nil {
+		h.handleError(w, err, "update variant")
+		return
+	}
+
 	// Convert DTO to usecase input
-	input := request.ToUseCaseInput()
+	input := request.ToUseCaseInput(product.Currency)
 
 	// Update variant
 	variant, err := h.productUseCase.UpdateVariant(uint(productID), uint(variantID), input)
@@ -539,10 +805,51 @@ func (h *ProductHandler) UpdateVariant(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// SetBundleComponents handles replacing the components of a bundle variant (admin only)
+func (h *ProductHandler) SetBundleComponents(w http.ResponseWriter, r *http.Request) {
+	// Check permission
+	if !middleware.HasPermission(r, entity.PermissionCatalogWrite) {
+		h.handleAuthorizationError(w, "SetBundleComponents")
+		return
+	}
+
+	// Get IDs from URL
+	vars := mux.Vars(r)
+	productID, err := strconv.ParseUint(vars["productId"], 10, 32)
+	if err != nil {
+		h.handleIDParsingError(w, err, "product", "SetBundleComponents")
+		return
+	}
+
+	variantID, err := strconv.ParseUint(vars["variantId"], 10, 32)
+	if err != nil {
+		h.handleIDParsingError(w, err, "variant", "SetBundleComponents")
+		return
+	}
+
+	// Parse request body
+	var request contracts.SetBundleComponentsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.handleValidationError(w, err, "SetBundleComponents")
+		return
+	}
+
+	variant, err := h.productUseCase.SetBundleComponents(uint(productID), uint(variantID), request.ToUseCaseInput())
+	if err != nil {
+		h.handleError(w, err, "set bundle components")
+		return
+	}
+
+	response := contracts.SuccessResponseWithMessage(variant.ToVariantDTO(), "Bundle components updated successfully")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // DeleteVariant handles deleting a product variant (admin only)
 func (h *ProductHandler) DeleteVariant(w http.ResponseWriter, r *http.Request) {
-	// Check admin authorization
-	if !h.checkAdminAuthorization(r) {
+	// Check permission
+	if !middleware.HasPermission(r, entity.PermissionCatalogWrite) {
 		h.handleAuthorizationError(w, "DeleteVariant")
 		return
 	}
@@ -573,3 +880,16 @@ func (h *ProductHandler) DeleteVariant(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// visibleIn returns the sales channel a product must be visible in to be shown, from the channel
+// query parameter or the webshop by default. Staff who can read the catalog also see hidden products unless they ask for a channel.
+func visibleIn(r *http.Request) (entity.SalesChannel, error) {
+	channel := r.URL.Query().Get("channel")
+	if channel == "" {
+		if middleware.HasPermission(r, entity.PermissionCatalogRead) {
+			return "", nil
+		}
+		return entity.SalesChannelWebshop, nil
+	}
+	return entity.ParseSalesChannel(channel)
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
+)
+
+// ReviewHandler handles product review requests
+type ReviewHandler struct {
+	reviewUseCase *usecase.ReviewUseCase
+	logger        logger.Logger
+}
+
+// NewReviewHandler creates a new ReviewHandler
+func NewReviewHandler(reviewUseCase *usecase.ReviewUseCase, logger logger.Logger) *ReviewHandler {
+	return &ReviewHandler{
+		reviewUseCase: reviewUseCase,
+		logger:        logger,
+	}
+}
+
+// SubmitReview handles reviewing a product by a customer with a paid order of it
+func (h *ReviewHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uint)
+	if !ok || userID == 0 {
+		h.logger.Error("Unauthorized access attempt")
+		h.writeError(w, errors.New("unauthorized"), http.StatusUnauthorized)
+		return
+	}
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+
+	var request contracts.SubmitReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode review request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	review, err := h.reviewUseCase.SubmitReview(userID, productID, request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to submit review: %v", err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(review.ToAdminReviewDTO(), "Review submitted for moderation"))
+}
+
+// ListProductReviews handles listing the approved reviews of a product with its rating summary
+func (h *ReviewHandler) ListProductReviews(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.parseID(w, r, "productId")
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	result, err := h.reviewUseCase.ListProductReviews(productID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		h.logger.Error("Failed to list product reviews: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.CreateProductReviewsResponse(result, page, pageSize))
+}
+
+// ListReviews handles listing reviews by status, oldest first (admin only).
+// Without a status parameter the pending reviews of the moderation queue are listed; status=all lists every review.
+func (h *ReviewHandler) ListReviews(w http.ResponseWriter, r *http.Request) {
+	offset, limit := parseOffsetLimit(r)
+
+	status := entity.ReviewStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "":
+		status = entity.ReviewStatusPending
+	case "all":
+		status = ""
+	}
+
+	reviews, total, err := h.reviewUseCase.ListReviews(status, offset, limit)
+	if err != nil {
+		h.logger.Error("Failed to list reviews: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.ReviewListResponse(reviews, total, (offset/limit)+1, limit))
+}
+
+// ApproveReview handles publishing a review (admin only)
+func (h *ReviewHandler) ApproveReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := h.parseID(w, r, "reviewId")
+	if !ok {
+		return
+	}
+
+	review, err := h.reviewUseCase.ApproveReview(reviewID)
+	if err != nil {
+		h.logger.Error("Failed to approve review: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(review.ToAdminReviewDTO(), "Review approved successfully"))
+}
+
+// RejectReview handles hiding a review with an optional reason (admin only)
+func (h *ReviewHandler) RejectReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := h.parseID(w, r, "reviewId")
+	if !ok {
+		return
+	}
+
+	var request contracts.RejectReviewRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			h.logger.Error("Failed to decode reject review request: %v", err)
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	review, err := h.reviewUseCase.RejectReview(reviewID, request.Note)
+	if err != nil {
+		h.logger.Error("Failed to reject review: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(review.ToAdminReviewDTO(), "Review rejected successfully"))
+}
+
+// DeleteReview handles deleting a review (admin only)
+func (h *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := h.parseID(w, r, "reviewId")
+	if !ok {
+		return
+	}
+
+	if err := h.reviewUseCase.DeleteReview(reviewID); err != nil {
+		h.logger.Error("Failed to delete review: %v", err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseMessage("Review deleted successfully"))
+}
+
+// parseID reads a numeric path parameter
+func (h *ReviewHandler) parseID(w http.ResponseWriter, r *http.Request, name string) (uint, bool) {
+	id, err := strconv.ParseUint(mux.Vars(r)[name], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid %s: %v", name, err)
+		http.Error(w, "Invalid "+name, http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeError writes an error response, mapping known review errors to their status
+func (h *ReviewHandler) writeError(w http.ResponseWriter, err error, status int) {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		status = http.StatusNotFound
+	case strings.Contains(err.Error(), "already reviewed"):
+		status = http.StatusConflict
+	case strings.Contains(err.Error(), "paid order"):
+		status = http.StatusForbidden
+	case strings.Contains(err.Error(), "rating must"), strings.Contains(err.Error(), "cannot exceed"),
+		strings.Contains(err.Error(), "invalid"):
+		status = http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.ErrorResponse(err.Error()))
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
+)
+
+// RoleHandler handles roles and their assignment to users (admin only)
+type RoleHandler struct {
+	roleUseCase *usecase.RoleUseCase
+	logger      logger.Logger
+}
+
+// NewRoleHandler creates a new RoleHandler
+func NewRoleHandler(roleUseCase *usecase.RoleUseCase, logger logger.Logger) *RoleHandler {
+	return &RoleHandler{
+		roleUseCase: roleUseCase,
+		logger:      logger,
+	}
+}
+
+// ListRoles handles listing the built-in and admin-defined roles
+func (h *RoleHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.roleUseCase.ListRoles()
+	if err != nil {
+		h.logger.Error("Failed to list roles: %v", err)
+		writeRoleError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(contracts.CreateRolesResponse(roles)))
+}
+
+// ListPermissions handles listing the permissions that can be granted to roles
+func (h *RoleHandler) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	permissions := make([]string, len(entity.AllPermissions))
+	for i, permission := range entity.AllPermissions {
+		permissions[i] = string(permission)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(permissions))
+}
+
+// CreateRole handles defining a role
+func (h *RoleHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
+	var request contracts.CreateRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode role request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	role, err := h.roleUseCase.CreateRole(roleGrantor(r), request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to create role: %v", err)
+		writeRoleError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(role.ToRoleDTO(), "Role created successfully"))
+}
+
+// UpdateRole handles changing the description and permissions of a role
+func (h *RoleHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	roleID, ok := h.parseID(w, r, "roleId")
+	if !ok {
+		return
+	}
+
+	var request contracts.UpdateRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode role request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	role, err := h.roleUseCase.UpdateRole(roleGrantor(r), roleID, request.ToUseCaseInput())
+	if err != nil {
+		h.logger.Error("Failed to update role: %v", err)
+		writeRoleError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(role.ToRoleDTO(), "Role updated successfully"))
+}
+
+// DeleteRole handles deleting a role that is not assigned to any user
+func (h *RoleHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	roleID, ok := h.parseID(w, r, "roleId")
+	if !ok {
+		return
+	}
+
+	if err := h.roleUseCase.DeleteRole(roleID); err != nil {
+		h.logger.Error("Failed to delete role: %v", err)
+		writeRoleError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AssignUserRole handles assigning a role to a user
+func (h *RoleHandler) AssignUserRole(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.parseID(w, r, "userId")
+	if !ok {
+		return
+	}
+
+	var request contracts.AssignRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode role assignment: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.roleUseCase.AssignRole(roleGrantor(r), userID, request.Role)
+	if err != nil {
+		h.logger.Error("Failed to assign role: %v", err)
+		writeRoleError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(user.ToUserDTO(), "Role assigned successfully"))
+}
+
+// parseID reads a numeric path parameter
+func (h *RoleHandler) parseID(w http.ResponseWriter, r *http.Request, name string) (uint, bool) {
+	id, err := strconv.ParseUint(mux.Vars(r)[name], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid %s: %v", name, err)
+		http.Error(w, "Invalid "+name, http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// roleGrantor returns the user or API key of the request as the grantor of roles.
+// API keys have no role, so they are never admins.
+func roleGrantor(r *http.Request) usecase.Grantor {
+	role, _ := r.Context().Value(middleware.RoleKey).(string)
+	return usecase.Grantor{
+		Permissions: middleware.Permissions(r),
+		IsAdmin:     role == string(entity.RoleAdmin),
+	}
+}
+
+// writeRoleError writes an error response, mapping known role errors to their status
+func writeRoleError(w http.ResponseWriter, err error, status int) {
+	switch {
+	case errors.Is(err, usecase.ErrPermissionDenied):
+		status = http.StatusForbidden
+	case strings.Contains(err.Error(), "not found"):
+		status = http.StatusNotFound
+	case strings.Contains(err.Error(), "already exists"), strings.Contains(err.Error(), "is assigned to"):
+		status = http.StatusConflict
+	case strings.Contains(err.Error(), "role"), strings.Contains(err.Error(), "permission"):
+		status = http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.ErrorResponse(err.Error()))
+}
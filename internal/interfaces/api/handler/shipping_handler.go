@@ -7,19 +7,22 @@ import (
 	"github.com/zenfulcode/commercify/internal/application/usecase"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
 	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
 )
 
 // ShippingHandler handles shipping-related HTTP requests
 type ShippingHandler struct {
-	shippingUseCase *usecase.ShippingUseCase
-	logger          logger.Logger
+	shippingUseCase    *usecase.ShippingUseCase
+	translationUseCase *usecase.TranslationUseCase
+	logger             logger.Logger
 }
 
 // NewShippingHandler creates a new ShippingHandler
-func NewShippingHandler(shippingUseCase *usecase.ShippingUseCase, logger logger.Logger) *ShippingHandler {
+func NewShippingHandler(shippingUseCase *usecase.ShippingUseCase, translationUseCase *usecase.TranslationUseCase, logger logger.Logger) *ShippingHandler {
 	return &ShippingHandler{
-		shippingUseCase: shippingUseCase,
-		logger:          logger,
+		shippingUseCase:    shippingUseCase,
+		translationUseCase: translationUseCase,
+		logger:             logger,
 	}
 }
 
@@ -42,8 +45,15 @@ func (h *ShippingHandler) CalculateShippingOptions(w http.ResponseWriter, r *htt
 		return
 	}
 
+	locale, _ := r.Context().Value(middleware.LocaleKey).(string)
+	if err := h.translationUseCase.LocalizeShippingOptions(locale, shippingOptions.Options...); err != nil {
+		h.logger.Error("Failed to translate shipping options: %v", err)
+		http.Error(w, "Failed to calculate shipping options", http.StatusInternalServerError)
+		return
+	}
+
 	// Convert to DTO response
-	response := contracts.CreateShippingOptionsListResponse(shippingOptions.Options, len(shippingOptions.Options), 1, len(shippingOptions.Options))
+	response := contracts.CreateShippingOptionsListResponse(shippingOptions.Options, request.Currency, len(shippingOptions.Options), 1, len(shippingOptions.Options))
 
 	// Return shipping options
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
+)
+
+// TranslationHandler handles the translations of products, categories and shipping methods (admin only)
+type TranslationHandler struct {
+	translationUseCase *usecase.TranslationUseCase
+	logger             logger.Logger
+}
+
+// NewTranslationHandler creates a new TranslationHandler
+func NewTranslationHandler(translationUseCase *usecase.TranslationUseCase, logger logger.Logger) *TranslationHandler {
+	return &TranslationHandler{
+		translationUseCase: translationUseCase,
+		logger:             logger,
+	}
+}
+
+// GetProductTranslations handles listing the translations of a product
+func (h *TranslationHandler) GetProductTranslations(w http.ResponseWriter, r *http.Request) {
+	h.getTranslations(w, r, entity.TranslationResourceProduct, "productId")
+}
+
+// SetProductTranslations handles replacing the translations of a product
+func (h *TranslationHandler) SetProductTranslations(w http.ResponseWriter, r *http.Request) {
+	h.setTranslations(w, r, entity.TranslationResourceProduct, "productId")
+}
+
+// GetCategoryTranslations handles listing the translations of a category
+func (h *TranslationHandler) GetCategoryTranslations(w http.ResponseWriter, r *http.Request) {
+	h.getTranslations(w, r, entity.TranslationResourceCategory, "id")
+}
+
+// SetCategoryTranslations handles replacing the translations of a category
+func (h *TranslationHandler) SetCategoryTranslations(w http.ResponseWriter, r *http.Request) {
+	h.setTranslations(w, r, entity.TranslationResourceCategory, "id")
+}
+
+// GetShippingMethodTranslations handles listing the translations of a shipping method
+func (h *TranslationHandler) GetShippingMethodTranslations(w http.ResponseWriter, r *http.Request) {
+	h.getTranslations(w, r, entity.TranslationResourceShippingMethod, "shippingMethodId")
+}
+
+// SetShippingMethodTranslations handles replacing the translations of a shipping method
+func (h *TranslationHandler) SetShippingMethodTranslations(w http.ResponseWriter, r *http.Request) {
+	h.setTranslations(w, r, entity.TranslationResourceShippingMethod, "shippingMethodId")
+}
+
+// getTranslations writes the translations of the resource identified by the path parameter
+func (h *TranslationHandler) getTranslations(w http.ResponseWriter, r *http.Request, resourceType, param string) {
+	resourceID, ok := h.parseID(w, r, param)
+	if !ok {
+		return
+	}
+
+	translations, err := h.translationUseCase.GetTranslations(resourceType, resourceID)
+	if err != nil {
+		h.logger.Error("Failed to get %s translations: %v", resourceType, err)
+		h.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponse(contracts.CreateTranslationsResponse(translations)))
+}
+
+// setTranslations replaces the translations of the resource identified by the path parameter
+func (h *TranslationHandler) setTranslations(w http.ResponseWriter, r *http.Request, resourceType, param string) {
+	resourceID, ok := h.parseID(w, r, param)
+	if !ok {
+		return
+	}
+
+	var request contracts.SetTranslationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Failed to decode translations request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	translations, err := h.translationUseCase.SetTranslations(resourceType, resourceID, request.Translations)
+	if err != nil {
+		h.logger.Error("Failed to set %s translations: %v", resourceType, err)
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(contracts.SuccessResponseWithMessage(contracts.CreateTranslationsResponse(translations), "Translations updated successfully"))
+}
+
+// parseID reads a numeric path parameter
+func (h *TranslationHandler) parseID(w http.ResponseWriter, r *http.Request, name string) (uint, bool) {
+	id, err := strconv.ParseUint(mux.Vars(r)[name], 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid %s: %v", name, err)
+		http.Error(w, "Invalid "+name, http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeError writes an error response, mapping known translation errors to their status
+func (h *TranslationHandler) writeError(w http.ResponseWriter, err error, status int) {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		status = http.StatusNotFound
+	case strings.Contains(err.Error(), "not supported"), strings.Contains(err.Error(), "default locale"),
+		strings.Contains(err.Error(), "cannot"), strings.Contains(err.Error(), "is required"),
+		strings.Contains(err.Error(), "more than once"):
+		status = http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.ErrorResponse(err.Error()))
+}
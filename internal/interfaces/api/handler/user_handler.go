@@ -2,10 +2,13 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
 	"github.com/zenfulcode/commercify/internal/infrastructure/auth"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
 	"github.com/zenfulcode/commercify/internal/interfaces/api/contracts"
@@ -14,17 +17,19 @@ import (
 
 // UserHandler handles user-related HTTP requests
 type UserHandler struct {
-	userUseCase *usecase.UserUseCase
-	jwtService  *auth.JWTService
-	logger      logger.Logger
+	userUseCase        *usecase.UserUseCase
+	customFieldUseCase *usecase.CustomFieldUseCase
+	jwtService         *auth.JWTService
+	logger             logger.Logger
 }
 
 // NewUserHandler creates a new UserHandler
-func NewUserHandler(userUseCase *usecase.UserUseCase, jwtService *auth.JWTService, logger logger.Logger) *UserHandler {
+func NewUserHandler(userUseCase *usecase.UserUseCase, customFieldUseCase *usecase.CustomFieldUseCase, jwtService *auth.JWTService, logger logger.Logger) *UserHandler {
 	return &UserHandler{
-		userUseCase: userUseCase,
-		jwtService:  jwtService,
-		logger:      logger,
+		userUseCase:        userUseCase,
+		customFieldUseCase: customFieldUseCase,
+		jwtService:         jwtService,
+		logger:             logger,
 	}
 }
 
@@ -58,26 +63,16 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Generate JWT token
-	token, expirationTime, err := h.jwtService.GenerateToken(user)
-	if err != nil {
-		h.logger.Error("Failed to generate token: %v", err)
-		response := contracts.ResponseDTO[any]{
-			Success: false,
-			Error:   "Failed to generate token",
-		}
+	// Users who must verify their email address sign in after following the verification link
+	if h.userUseCase.MustVerifyEmail(user) {
+		response := contracts.SuccessResponseWithMessage(user.ToUserDTO(), "Check your email to verify your address before signing in")
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusCreated)
 		json.NewEncoder(w).Encode(response)
 		return
 	}
 
-	// Create login response
-	loginResponse := contracts.CreateUserLoginResponse(user.ToUserDTO(), token, "", expirationTime)
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(loginResponse)
+	h.startSession(w, user, http.StatusCreated)
 }
 
 // Login handles user login
@@ -101,6 +96,13 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	user, err := h.userUseCase.Login(input)
+	if errors.Is(err, usecase.ErrEmailNotVerified) {
+		response := contracts.ErrorResponse("Email address is not verified")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
 	if err != nil {
 		h.logger.Error("Login failed: %v", err)
 		response := contracts.ResponseDTO[any]{
@@ -113,27 +115,93 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Generate JWT token
-	token, expiresIn, err := h.jwtService.GenerateToken(user)
+	h.startSession(w, user, http.StatusOK)
+}
+
+// RefreshToken handles exchanging a refresh token for a new access and refresh token
+func (h *UserHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var request contracts.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.RefreshToken == "" {
+		response := contracts.ErrorResponse("Invalid request body")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	user, refreshToken, err := h.userUseCase.RefreshSession(request.RefreshToken)
 	if err != nil {
-		h.logger.Error("Failed to generate token: %v", err)
-		response := contracts.ResponseDTO[any]{
-			Success: false,
-			Error:   "Failed to generate token",
+		h.logger.Error("Failed to refresh session: %v", err)
+		statusCode := http.StatusInternalServerError
+		errorMessage := "Failed to refresh session"
+		if errors.Is(err, usecase.ErrInvalidRefreshToken) {
+			statusCode = http.StatusUnauthorized
+			errorMessage = "Invalid or expired refresh token"
 		}
+
+		response := contracts.ErrorResponse(errorMessage)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	h.writeTokens(w, user, refreshToken, http.StatusOK)
+}
+
+// Logout handles ending the session of a refresh token
+func (h *UserHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var request contracts.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.RefreshToken == "" {
+		response := contracts.ErrorResponse("Invalid request body")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	if err := h.userUseCase.Logout(request.RefreshToken); err != nil {
+		h.logger.Error("Failed to log out: %v", err)
+		response := contracts.ErrorResponse("Failed to log out")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	response := contracts.ResponseDTO[any]{
+		Success: true,
+		Message: "Logged out successfully",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// LogoutAll handles ending all sessions of the current user on all devices
+func (h *UserHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uint)
+	if !ok || userID == 0 {
+		response := contracts.ErrorResponse("Unauthorized")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	if err := h.userUseCase.LogoutAll(userID); err != nil {
+		h.logger.Error("Failed to log out of all sessions: %v", err)
+		response := contracts.ErrorResponse("Failed to log out")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(response)
 		return
 	}
 
-	// Convert domain user to DTO
-	response := contracts.CreateUserLoginResponse(
-		user.ToUserDTO(),
-		token,
-		"", // Refresh token not implemented
-		expiresIn,
-	)
+	response := contracts.ResponseDTO[any]{
+		Success: true,
+		Message: "Logged out of all sessions successfully",
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
@@ -153,6 +221,10 @@ func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	user, err := h.userUseCase.GetUserByID(userID)
+	if err == nil {
+		// Customers only see their public custom fields
+		err = h.customFieldUseCase.FilterUserFields(false, user)
+	}
 	if err != nil {
 		h.logger.Error("Failed to get user profile: %v", err)
 		response := contracts.ErrorResponse("Failed to get user profile")
@@ -203,6 +275,9 @@ func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	user, err := h.userUseCase.UpdateUser(userID, input)
+	if err == nil {
+		err = h.customFieldUseCase.FilterUserFields(false, user)
+	}
 	if err != nil {
 		h.logger.Error("Failed to update user profile: %v", err)
 		response := contracts.ResponseDTO[any]{
@@ -230,8 +305,19 @@ func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		pageSize = 10 // Default page size
 	}
 
+	// cf.<key> parameters filter by custom field
+	customFields, err := customFieldFilters(h.customFieldUseCase, r, entity.CustomFieldResourceUser)
+	if err != nil {
+		h.logger.Error("Invalid custom field filter: %v", err)
+		writeCustomFieldError(w, err, http.StatusBadRequest)
+		return
+	}
+
 	offset := (page - 1) * pageSize
-	users, err := h.userUseCase.ListUsers(offset, pageSize)
+	users, err := h.userUseCase.ListUsers(offset, pageSize, customFields)
+	if err == nil {
+		err = h.customFieldUseCase.FilterUserFields(true, users...)
+	}
 	if err != nil {
 		h.logger.Error("Failed to list users: %v", err)
 		response := contracts.ResponseDTO[any]{
@@ -253,10 +339,11 @@ func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// ChangePassword handles changing the user's password
+// ChangePassword handles changing the user's password. All sessions are ended,
+// so the response starts a new session for the current device.
 func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context
-	userID, ok := r.Context().Value("user_id").(uint)
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uint)
 	if !ok {
 		response := contracts.ResponseDTO[any]{
 			Success: false,
@@ -299,11 +386,184 @@ func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	user, err := h.userUseCase.GetUserByID(userID)
+	if err != nil {
+		h.logger.Error("Failed to get user %d after changing password: %v", userID, err)
+		response := contracts.ErrorResponse("Failed to start a new session")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	h.startSession(w, user, http.StatusOK)
+}
+
+// ForgotPassword handles requesting a password reset link. The response is the same whether
+// or not an account exists for the email address.
+func (h *UserHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var request contracts.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Email == "" {
+		response := contracts.ErrorResponse("Invalid request body")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	if err := h.userUseCase.RequestPasswordReset(request.Email); err != nil {
+		h.logger.Error("Failed to request password reset: %v", err)
+		response := contracts.ErrorResponse("Failed to send password reset email")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	response := contracts.ResponseDTO[any]{
+		Success: true,
+		Message: "If an account exists for the email address, a password reset link has been sent",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ResetPassword handles setting a new password with the token of a password reset link
+func (h *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var request contracts.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response := contracts.ErrorResponse("Invalid request body")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	if err := h.userUseCase.ResetPassword(request.ToUseCaseInput()); err != nil {
+		h.logger.Error("Failed to reset password: %v", err)
+		h.writeTokenError(w, err, "Failed to reset password")
+		return
+	}
+
+	response := contracts.ResponseDTO[any]{
+		Success: true,
+		Message: "Password reset successfully",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RequestEmailVerification handles requesting a new email verification link. The response is the
+// same whether or not an unverified account exists for the email address.
+func (h *UserHandler) RequestEmailVerification(w http.ResponseWriter, r *http.Request) {
+	var request contracts.EmailVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Email == "" {
+		response := contracts.ErrorResponse("Invalid request body")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	if err := h.userUseCase.RequestEmailVerification(request.Email); err != nil {
+		h.logger.Error("Failed to request email verification: %v", err)
+		response := contracts.ErrorResponse("Failed to send verification email")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	response := contracts.ResponseDTO[any]{
 		Success: true,
-		Message: "Password changed successfully",
+		Message: "If an unverified account exists for the email address, a verification link has been sent",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// VerifyEmail handles verifying an email address with the token of a verification link
+func (h *UserHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var request contracts.VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response := contracts.ErrorResponse("Invalid request body")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	user, err := h.userUseCase.VerifyEmail(request.Token)
+	if err == nil {
+		err = h.customFieldUseCase.FilterUserFields(false, user)
+	}
+	if err != nil {
+		h.logger.Error("Failed to verify email: %v", err)
+		h.writeTokenError(w, err, "Failed to verify email address")
+		return
+	}
+
+	response := contracts.SuccessResponseWithMessage(user.ToUserDTO(), "Email address verified successfully")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// writeTokenError writes the error response of a password reset or email verification link
+func (h *UserHandler) writeTokenError(w http.ResponseWriter, err error, fallback string) {
+	statusCode := http.StatusInternalServerError
+	errorMessage := fallback
+	if errors.Is(err, repository.ErrTokenInvalid) || errors.Is(err, repository.ErrTokenUsed) ||
+		errors.Is(err, repository.ErrTokenExpired) || errors.Is(err, usecase.ErrEmptyPassword) {
+		statusCode = http.StatusBadRequest
+		errorMessage = err.Error()
+	}
+
+	response := contracts.ErrorResponse(errorMessage)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+// startSession starts a session of the signed in user and writes its access and refresh tokens
+func (h *UserHandler) startSession(w http.ResponseWriter, user *entity.User, statusCode int) {
+	refreshToken, err := h.userUseCase.StartSession(user)
+	if err != nil {
+		h.logger.Error("Failed to start session of user %d: %v", user.ID, err)
+		response := contracts.ErrorResponse("Failed to generate token")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
 	}
 
+	h.writeTokens(w, user, refreshToken, statusCode)
+}
+
+// writeTokens writes a new access token of the user together with the refresh token of the session
+func (h *UserHandler) writeTokens(w http.ResponseWriter, user *entity.User, refreshToken string, statusCode int) {
+	// Generate JWT token
+	token, expiresIn, err := h.jwtService.GenerateToken(user)
+	if err != nil {
+		h.logger.Error("Failed to generate token: %v", err)
+		response := contracts.ErrorResponse("Failed to generate token")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Customers only see their public custom fields
+	if err := h.customFieldUseCase.FilterUserFields(false, user); err != nil {
+		h.logger.Error("Failed to filter custom fields of user %d: %v", user.ID, err)
+	}
+
+	response := contracts.CreateUserLoginResponse(user.ToUserDTO(), token, refreshToken, expiresIn)
+
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(response)
 }
@@ -3,32 +3,49 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"slices"
 	"strings"
 
+	"github.com/zenfulcode/commercify/internal/application/usecase"
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/infrastructure/auth"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
 )
 
-// AuthMiddleware handles authentication
+// AuthMiddleware handles authentication with a JWT token of a user ("Bearer <token>")
+// or an API key of an integration ("ApiKey <key>")
 type AuthMiddleware struct {
-	jwtService *auth.JWTService
-	logger     logger.Logger
+	jwtService    *auth.JWTService
+	userUseCase   *usecase.UserUseCase
+	roleUseCase   *usecase.RoleUseCase
+	apiKeyUseCase *usecase.APIKeyUseCase
+	logger        logger.Logger
 }
 
 type contextKey string
 
 const (
-	UserIDKey contextKey = "user_id"
-	emailKey  contextKey = "email"
-	RoleKey   contextKey = "role"
+	UserIDKey      contextKey = "user_id"
+	emailKey       contextKey = "email"
+	RoleKey        contextKey = "role"
+	PermissionsKey contextKey = "permissions"
+	APIKeyIDKey    contextKey = "api_key_id" // Set instead of the user keys for requests made with an API key
 )
 
 // NewAuthMiddleware creates a new AuthMiddleware
-func NewAuthMiddleware(jwtService *auth.JWTService, logger logger.Logger) *AuthMiddleware {
+func NewAuthMiddleware(
+	jwtService *auth.JWTService,
+	userUseCase *usecase.UserUseCase,
+	roleUseCase *usecase.RoleUseCase,
+	apiKeyUseCase *usecase.APIKeyUseCase,
+	logger logger.Logger,
+) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtService: jwtService,
-		logger:     logger,
+		jwtService:    jwtService,
+		userUseCase:   userUseCase,
+		roleUseCase:   roleUseCase,
+		apiKeyUseCase: apiKeyUseCase,
+		logger:        logger,
 	}
 }
 
@@ -42,40 +59,34 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
-		// Check if the header has the Bearer prefix
-		if !strings.HasPrefix(authHeader, "Bearer ") {
+		// Validate the token or API key
+		var ctx context.Context
+		var err error
+		switch {
+		case strings.HasPrefix(authHeader, "Bearer "):
+			ctx, err = m.authenticate(r.Context(), strings.TrimPrefix(authHeader, "Bearer "))
+		case strings.HasPrefix(authHeader, "ApiKey "):
+			ctx, err = m.authenticateAPIKey(r, strings.TrimPrefix(authHeader, "ApiKey "))
+		default:
 			http.Error(w, "Invalid authorization format", http.StatusUnauthorized)
 			return
 		}
-
-		// Extract token
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-
-		// Validate token
-		claims, err := m.jwtService.ValidateToken(tokenString)
 		if err != nil {
 			m.logger.Error("Invalid token: %v", err)
 			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
 			return
 		}
 
-		// Add user info to request context
-		ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
-		ctx = context.WithValue(ctx, emailKey, claims.Email)
-		ctx = context.WithValue(ctx, RoleKey, claims.Role)
-
 		// Call the next handler with the updated context
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
-// AdminOnly middleware ensures the user has admin role
-func AdminOnly(next http.Handler) http.Handler {
+// RequirePermission only lets requests through whose user has the permission
+func RequirePermission(permission entity.Permission, next http.HandlerFunc) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Get role from context
-		role, ok := r.Context().Value(RoleKey).(string)
-		if !ok || role != string(entity.RoleAdmin) {
-			http.Error(w, "Admin access required", http.StatusForbidden)
+		if !HasPermission(r, permission) {
+			http.Error(w, "Permission "+string(permission)+" required", http.StatusForbidden)
 			return
 		}
 
@@ -84,6 +95,17 @@ func AdminOnly(next http.Handler) http.Handler {
 	})
 }
 
+// HasPermission returns true when the role of the authenticated user, or the scopes of the API key, grant the permission
+func HasPermission(r *http.Request, permission entity.Permission) bool {
+	return slices.Contains(Permissions(r), permission)
+}
+
+// Permissions returns the permissions of the authenticated user or API key
+func Permissions(r *http.Request) []entity.Permission {
+	permissions, _ := r.Context().Value(PermissionsKey).([]entity.Permission)
+	return permissions
+}
+
 // OptionalAuthenticate attempts to authenticate a request but allows it to proceed even if authentication fails
 func (m *AuthMiddleware) OptionalAuthenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -96,18 +118,19 @@ func (m *AuthMiddleware) OptionalAuthenticate(next http.Handler) http.Handler {
 			return
 		}
 
-		// Check if the header has the Bearer prefix
-		if !strings.HasPrefix(authHeader, "Bearer ") {
+		// Validate the token or API key
+		var ctx context.Context
+		var err error
+		switch {
+		case strings.HasPrefix(authHeader, "Bearer "):
+			ctx, err = m.authenticate(r.Context(), strings.TrimPrefix(authHeader, "Bearer "))
+		case strings.HasPrefix(authHeader, "ApiKey "):
+			ctx, err = m.authenticateAPIKey(r, strings.TrimPrefix(authHeader, "ApiKey "))
+		default:
 			// Invalid format, but proceed without authentication
 			next.ServeHTTP(w, r)
 			return
 		}
-
-		// Extract token
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-
-		// Validate token
-		claims, err := m.jwtService.ValidateToken(tokenString)
 		if err != nil {
 			// Invalid token, but proceed without authentication
 			m.logger.Debug("Optional authentication failed: %v", err)
@@ -115,12 +138,49 @@ func (m *AuthMiddleware) OptionalAuthenticate(next http.Handler) http.Handler {
 			return
 		}
 
-		// Add user info to request context if authentication succeeded
-		ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
-		ctx = context.WithValue(ctx, emailKey, claims.Email)
-		ctx = context.WithValue(ctx, RoleKey, claims.Role)
-
 		// Call the next handler with the updated context
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// authenticate validates a JWT token, checks that the user has not been signed out of all sessions
+// since it was issued and adds the user, their current role and its permissions to the context
+func (m *AuthMiddleware) authenticate(ctx context.Context, tokenString string) (context.Context, error) {
+	claims, err := m.jwtService.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	user, err := m.userUseCase.ValidateTokenVersion(claims.UserID, claims.TokenVersion)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := m.roleUseCase.GetPermissions(user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add user info to request context
+	ctx = context.WithValue(ctx, UserIDKey, user.ID)
+	ctx = context.WithValue(ctx, emailKey, user.Email)
+	ctx = context.WithValue(ctx, RoleKey, user.Role)
+	ctx = context.WithValue(ctx, PermissionsKey, permissions)
+	return ctx, nil
+}
+
+// authenticateAPIKey validates an API key, records the request made with it
+// and adds the key and its scopes to the context
+func (m *AuthMiddleware) authenticateAPIKey(r *http.Request, plain string) (context.Context, error) {
+	key, err := m.apiKeyUseCase.Authenticate(plain)
+	if err != nil {
+		return nil, err
+	}
+	// The key is valid even when its request cannot be recorded, so a failing audit log does not block integrations
+	if err := m.apiKeyUseCase.RecordRequest(key, r.Method, r.URL.Path); err != nil {
+		m.logger.Error("Failed to record request of API key %d: %v", key.ID, err)
+	}
+	m.logger.Info("API key %d (%s) used for %s %s", key.ID, key.Prefix, r.Method, r.URL.Path)
+
+	ctx := context.WithValue(r.Context(), APIKeyIDKey, key.ID)
+	ctx = context.WithValue(ctx, PermissionsKey, key.Scopes)
+	return ctx, nil
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// LocaleKey is the context key of the locale negotiated for the request
+const LocaleKey contextKey = "locale"
+
+// LocaleMiddleware selects the language of catalog content for each request
+type LocaleMiddleware struct {
+	config config.LocaleConfig
+}
+
+// NewLocaleMiddleware creates a new LocaleMiddleware
+func NewLocaleMiddleware(config config.LocaleConfig) *LocaleMiddleware {
+	return &LocaleMiddleware{config: config}
+}
+
+// NegotiateLocale stores the locale of the request in its context. The locale query parameter
+// takes precedence over the Accept-Language header; unsupported languages fall back to the default locale.
+func (m *LocaleMiddleware) NegotiateLocale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := m.negotiate(r)
+		w.Header().Set("Content-Language", locale)
+		w.Header().Add("Vary", "Accept-Language")
+
+		ctx := context.WithValue(r.Context(), LocaleKey, locale)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// negotiate returns the supported locale best matching the request
+func (m *LocaleMiddleware) negotiate(r *http.Request) string {
+	if locale := entity.MatchLocale(r.URL.Query().Get("locale"), m.config.Supported); locale != "" {
+		return locale
+	}
+	for _, tag := range parseAcceptLanguage(r.Header.Get("Accept-Language")) {
+		if locale := entity.MatchLocale(tag, m.config.Supported); locale != "" {
+			return locale
+		}
+	}
+	return m.config.Default
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header by preference,
+// e.g. "da-DK,da;q=0.9,en;q=0.8". Wildcards and tags with a zero quality are left out.
+func parseAcceptLanguage(header string) []string {
+	type weightedTag struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		tags = append(tags, weightedTag{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].quality > tags[j].quality
+	})
+
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.tag
+	}
+	return result
+}
@@ -3,12 +3,15 @@ package api
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/infrastructure/container"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/infrastructure/storage"
 	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
 	"gorm.io/gorm"
 )
@@ -67,7 +70,18 @@ func (s *Server) setupRoutes() {
 	userHandler := s.container.Handlers().UserHandler()
 	productHandler := s.container.Handlers().ProductHandler()
 	categoryHandler := s.container.Handlers().CategoryHandler()
+	catalogHandler := s.container.Handlers().CatalogHandler()
+	assetHandler := s.container.Handlers().AssetHandler()
+	downloadHandler := s.container.Handlers().DownloadHandler()
+	reviewHandler := s.container.Handlers().ReviewHandler()
+	productAssociationHandler := s.container.Handlers().ProductAssociationHandler()
+	collectionHandler := s.container.Handlers().CollectionHandler()
+	translationHandler := s.container.Handlers().TranslationHandler()
+	customFieldHandler := s.container.Handlers().CustomFieldHandler()
+	roleHandler := s.container.Handlers().RoleHandler()
+	apiKeyHandler := s.container.Handlers().APIKeyHandler()
 	checkoutHandler := s.container.Handlers().CheckoutHandler()
+	customerGroupHandler := s.container.Handlers().CustomerGroupHandler()
 	orderHandler := s.container.Handlers().OrderHandler()
 	paymentHandler := s.container.Handlers().PaymentHandler()
 	paymentProviderHandler := s.container.Handlers().PaymentProviderHandler()
@@ -82,13 +96,21 @@ func (s *Server) setupRoutes() {
 	// Extract middleware from container
 	authMiddleware := s.container.Middlewares().AuthMiddleware()
 	corsMiddleware := s.container.Middlewares().CorsMiddleware()
+	localeMiddleware := s.container.Middlewares().LocaleMiddleware()
 
 	// Health check routes (no prefix, for load balancers and monitoring)
 	s.router.HandleFunc("/health", healthHandler.Health).Methods(http.MethodGet)
 
+	// Uploaded media stored on the local disk is served under its relative public URL
+	publicURL := strings.TrimSuffix(s.config.Storage.PublicURL, "/")
+	if localStorage, ok := s.container.Services().AssetStorage().(*storage.LocalStorage); ok && strings.HasPrefix(publicURL, "/") {
+		s.router.PathPrefix(publicURL+"/").Handler(http.StripPrefix(publicURL+"/", localStorage.Handler())).Methods(http.MethodGet, http.MethodHead)
+	}
+
 	// Register routes
 	api := s.router.PathPrefix("/api").Subrouter()
 	api.Use(corsMiddleware.ApplyCors)
+	api.Use(localeMiddleware.NegotiateLocale)
 
 	// Webhook routes (separate subrouter without CORS middleware for server-to-server communication)
 	webhooks := s.router.PathPrefix("/api/webhooks").Subrouter()
@@ -96,12 +118,17 @@ func (s *Server) setupRoutes() {
 	// Public routes
 	api.HandleFunc("/auth/register", userHandler.Register).Methods(http.MethodPost)
 	api.HandleFunc("/auth/signin", userHandler.Login).Methods(http.MethodPost)
-	api.HandleFunc("/products/{productId:[0-9]+}", productHandler.GetProduct).Methods(http.MethodGet)
-
-	api.HandleFunc("/products/search", productHandler.SearchProducts).Methods(http.MethodGet)
+	api.HandleFunc("/auth/refresh", userHandler.RefreshToken).Methods(http.MethodPost)
+	api.HandleFunc("/auth/logout", userHandler.Logout).Methods(http.MethodPost)
+	api.HandleFunc("/auth/password/forgot", userHandler.ForgotPassword).Methods(http.MethodPost)
+	api.HandleFunc("/auth/password/reset", userHandler.ResetPassword).Methods(http.MethodPost)
+	api.HandleFunc("/auth/email/verification", userHandler.RequestEmailVerification).Methods(http.MethodPost)
+	api.HandleFunc("/auth/email/verify", userHandler.VerifyEmail).Methods(http.MethodPost)
 	api.HandleFunc("/categories", categoryHandler.ListCategories).Methods(http.MethodGet)
+	api.HandleFunc("/categories/tree", categoryHandler.GetCategoryTree).Methods(http.MethodGet)
 	api.HandleFunc("/categories/{id:[0-9]+}", categoryHandler.GetCategory).Methods(http.MethodGet)
 	api.HandleFunc("/categories/{id:[0-9]+}/children", categoryHandler.GetChildCategories).Methods(http.MethodGet)
+	api.HandleFunc("/categories/slug/{slug}", categoryHandler.GetCategoryBySlug).Methods(http.MethodGet)
 	api.HandleFunc("/payment/providers", paymentHandler.GetAvailablePaymentProviders).Methods(http.MethodGet)
 
 	// Webhook routes (public, no authentication or CORS required for server-to-server communication)
@@ -122,27 +149,44 @@ func (s *Server) setupRoutes() {
 	// api.HandleFunc("/shipping/methods/{shippingMethodId:[0-9]+}", shippingHandler.GetShippingMethodByID).Methods(http.MethodGet)
 	// api.HandleFunc("/shipping/rates/{shippingRateId:[0-9]+}/cost", shippingHandler.GetShippingCost).Methods(http.MethodPost)
 
-	// Guest checkout routes (no authentication required)
-	api.HandleFunc("/checkout", checkoutHandler.GetCheckout).Methods(http.MethodGet)
-	api.HandleFunc("/checkout/items", checkoutHandler.AddToCheckout).Methods(http.MethodPost)
-	api.HandleFunc("/checkout/items/{sku}", checkoutHandler.UpdateCheckoutItem).Methods(http.MethodPut)
-	api.HandleFunc("/checkout/items/{sku}", checkoutHandler.RemoveFromCheckout).Methods(http.MethodDelete)
-	api.HandleFunc("/checkout", checkoutHandler.ClearCheckout).Methods(http.MethodDelete)
-	api.HandleFunc("/checkout/shipping-address", checkoutHandler.SetShippingAddress).Methods(http.MethodPut)
-	api.HandleFunc("/checkout/billing-address", checkoutHandler.SetBillingAddress).Methods(http.MethodPut)
-	api.HandleFunc("/checkout/customer-details", checkoutHandler.SetCustomerDetails).Methods(http.MethodPut)
-	api.HandleFunc("/checkout/shipping-method", checkoutHandler.SetShippingMethod).Methods(http.MethodPut)
-	api.HandleFunc("/checkout/currency", checkoutHandler.SetCurrency).Methods(http.MethodPut)
-	api.HandleFunc("/checkout/discount", checkoutHandler.ApplyDiscount).Methods(http.MethodPost)
-	api.HandleFunc("/checkout/discount", checkoutHandler.RemoveDiscount).Methods(http.MethodDelete)
-	api.HandleFunc("/checkout/complete", checkoutHandler.CompleteOrder).Methods(http.MethodPost)
-	// api.HandleFunc("/checkout/convert", checkoutHandler.ConvertGuestCheckoutToUserCheckout).Methods(http.MethodPost)
-
 	// Routes with optional authentication (accessible via auth or checkout session)
 	optionalAuth := api.PathPrefix("").Subrouter()
 	optionalAuth.Use(authMiddleware.OptionalAuthenticate)
 	optionalAuth.HandleFunc("/orders/{orderId:[0-9]+}", orderHandler.GetOrder).Methods(http.MethodGet)
 
+	// Product routes (signed-in customers see their group prices)
+	optionalAuth.HandleFunc("/products/{productId:[0-9]+}", productHandler.GetProduct).Methods(http.MethodGet)
+	optionalAuth.HandleFunc("/products/slug/{slug}", productHandler.GetProductBySlug).Methods(http.MethodGet)
+	optionalAuth.HandleFunc("/products/search", productHandler.SearchProducts).Methods(http.MethodGet)
+	optionalAuth.HandleFunc("/products/{productId:[0-9]+}/associations", productAssociationHandler.GetProductAssociations).Methods(http.MethodGet)
+	optionalAuth.HandleFunc("/collections/{collectionId:[0-9]+}/products", collectionHandler.ListCollectionProducts).Methods(http.MethodGet)
+	api.HandleFunc("/products/{productId:[0-9]+}/assets", assetHandler.ListProductAssets).Methods(http.MethodGet)
+	api.HandleFunc("/products/{productId:[0-9]+}/reviews", reviewHandler.ListProductReviews).Methods(http.MethodGet)
+
+	// Collection routes
+	api.HandleFunc("/collections", collectionHandler.ListActiveCollections).Methods(http.MethodGet)
+	api.HandleFunc("/collections/slug/{slug}", collectionHandler.GetCollectionBySlug).Methods(http.MethodGet)
+
+	// Signed download links of digital products sent to customers of paid orders
+	api.HandleFunc("/downloads/{downloadId:[0-9]+}", downloadHandler.Download).Methods(http.MethodGet)
+
+	// Checkout routes (guests allowed, signed-in customers get their group prices)
+	optionalAuth.HandleFunc("/checkout", checkoutHandler.GetCheckout).Methods(http.MethodGet)
+	optionalAuth.HandleFunc("/checkout/items", checkoutHandler.AddToCheckout).Methods(http.MethodPost)
+	optionalAuth.HandleFunc("/checkout/items/{sku}", checkoutHandler.UpdateCheckoutItem).Methods(http.MethodPut)
+	optionalAuth.HandleFunc("/checkout/items/{sku}", checkoutHandler.RemoveFromCheckout).Methods(http.MethodDelete)
+	optionalAuth.HandleFunc("/checkout", checkoutHandler.ClearCheckout).Methods(http.MethodDelete)
+	optionalAuth.HandleFunc("/checkout/shipping-address", checkoutHandler.SetShippingAddress).Methods(http.MethodPut)
+	optionalAuth.HandleFunc("/checkout/billing-address", checkoutHandler.SetBillingAddress).Methods(http.MethodPut)
+	optionalAuth.HandleFunc("/checkout/customer-details", checkoutHandler.SetCustomerDetails).Methods(http.MethodPut)
+	optionalAuth.HandleFunc("/checkout/shipping-method", checkoutHandler.SetShippingMethod).Methods(http.MethodPut)
+	optionalAuth.HandleFunc("/checkout/currency", checkoutHandler.SetCurrency).Methods(http.MethodPut)
+	optionalAuth.HandleFunc("/checkout/discount", checkoutHandler.ApplyDiscount).Methods(http.MethodPost)
+	optionalAuth.HandleFunc("/checkout/discount", checkoutHandler.RemoveDiscount).Methods(http.MethodDelete)
+	optionalAuth.HandleFunc("/checkout/complete", checkoutHandler.CompleteOrder).Methods(http.MethodPost)
+	optionalAuth.HandleFunc("/checkout/associations", productAssociationHandler.GetCheckoutAssociations).Methods(http.MethodGet)
+	// optionalAuth.HandleFunc("/checkout/convert", checkoutHandler.ConvertGuestCheckoutToUserCheckout).Methods(http.MethodPost)
+
 	// Protected routes
 	protected := api.PathPrefix("").Subrouter()
 	protected.Use(authMiddleware.Authenticate)
@@ -151,88 +195,194 @@ func (s *Server) setupRoutes() {
 	protected.HandleFunc("/users/me", userHandler.GetProfile).Methods(http.MethodGet)
 	protected.HandleFunc("/users/me", userHandler.UpdateProfile).Methods(http.MethodPut)
 	protected.HandleFunc("/users/me/password", userHandler.ChangePassword).Methods(http.MethodPut)
+	protected.HandleFunc("/auth/logout-all", userHandler.LogoutAll).Methods(http.MethodPost)
 
 	// Order routes (authenticated users only)
 	protected.HandleFunc("/orders", orderHandler.ListOrders).Methods(http.MethodGet)
 
-	// Admin routes
+	// Review routes (customers with a paid order of the product)
+	protected.HandleFunc("/products/{productId:[0-9]+}/reviews", reviewHandler.SubmitReview).Methods(http.MethodPost)
+
+	// Admin routes, each requiring a permission of the role of the user
 	admin := protected.PathPrefix("/admin").Subrouter()
-	admin.Use(middleware.AdminOnly)
-	admin.HandleFunc("/users", userHandler.ListUsers).Methods(http.MethodGet)
-	admin.HandleFunc("/orders", orderHandler.ListAllOrders).Methods(http.MethodGet)
-	admin.HandleFunc("/orders/{orderId:[0-9]+}/status", orderHandler.UpdateOrderStatus).Methods(http.MethodPut)
-	admin.HandleFunc("/orders/{orderId:[0-9]+}/status-with-tracking", orderHandler.UpdateOrderStatusWithTracking).Methods(http.MethodPut)
+	admin.Handle("/users", middleware.RequirePermission(entity.PermissionCustomersRead, userHandler.ListUsers)).Methods(http.MethodGet)
+	admin.Handle("/orders", middleware.RequirePermission(entity.PermissionOrdersRead, orderHandler.ListAllOrders)).Methods(http.MethodGet)
+	admin.Handle("/orders/{orderId:[0-9]+}/status", middleware.RequirePermission(entity.PermissionOrdersFulfill, orderHandler.UpdateOrderStatus)).Methods(http.MethodPut)
+	admin.Handle("/orders/{orderId:[0-9]+}/status-with-tracking", middleware.RequirePermission(entity.PermissionOrdersFulfill, orderHandler.UpdateOrderStatusWithTracking)).Methods(http.MethodPut)
+
+	// Admin customer group routes
+	admin.Handle("/customer-groups", middleware.RequirePermission(entity.PermissionCustomersRead, customerGroupHandler.ListCustomerGroups)).Methods(http.MethodGet)
+	admin.Handle("/customer-groups", middleware.RequirePermission(entity.PermissionCustomersWrite, customerGroupHandler.CreateCustomerGroup)).Methods(http.MethodPost)
+	admin.Handle("/customer-groups/{groupId:[0-9]+}", middleware.RequirePermission(entity.PermissionCustomersRead, customerGroupHandler.GetCustomerGroup)).Methods(http.MethodGet)
+	admin.Handle("/customer-groups/{groupId:[0-9]+}", middleware.RequirePermission(entity.PermissionCustomersWrite, customerGroupHandler.UpdateCustomerGroup)).Methods(http.MethodPut)
+	admin.Handle("/customer-groups/{groupId:[0-9]+}", middleware.RequirePermission(entity.PermissionCustomersWrite, customerGroupHandler.DeleteCustomerGroup)).Methods(http.MethodDelete)
+	admin.Handle("/users/{userId:[0-9]+}/customer-group", middleware.RequirePermission(entity.PermissionCustomersWrite, customerGroupHandler.AssignCustomerGroup)).Methods(http.MethodPut)
+
+	// Admin price list routes
+	admin.Handle("/price-lists", middleware.RequirePermission(entity.PermissionCustomersRead, customerGroupHandler.ListPriceLists)).Methods(http.MethodGet)
+	admin.Handle("/price-lists", middleware.RequirePermission(entity.PermissionCustomersWrite, customerGroupHandler.CreatePriceList)).Methods(http.MethodPost)
+	admin.Handle("/price-lists/{priceListId:[0-9]+}", middleware.RequirePermission(entity.PermissionCustomersRead, customerGroupHandler.GetPriceList)).Methods(http.MethodGet)
+	admin.Handle("/price-lists/{priceListId:[0-9]+}", middleware.RequirePermission(entity.PermissionCustomersWrite, customerGroupHandler.UpdatePriceList)).Methods(http.MethodPut)
+	admin.Handle("/price-lists/{priceListId:[0-9]+}", middleware.RequirePermission(entity.PermissionCustomersWrite, customerGroupHandler.DeletePriceList)).Methods(http.MethodDelete)
 
 	// Admin checkout routes
-	admin.HandleFunc("/checkouts", checkoutHandler.ListAdminCheckouts).Methods(http.MethodGet)
-	admin.HandleFunc("/checkouts/{checkoutId:[0-9]+}", checkoutHandler.GetAdminCheckout).Methods(http.MethodGet)
-	admin.HandleFunc("/checkouts/{checkoutId:[0-9]+}", checkoutHandler.DeleteAdminCheckout).Methods(http.MethodDelete)
+	admin.Handle("/checkouts", middleware.RequirePermission(entity.PermissionOrdersRead, checkoutHandler.ListAdminCheckouts)).Methods(http.MethodGet)
+	admin.Handle("/checkouts/{checkoutId:[0-9]+}", middleware.RequirePermission(entity.PermissionOrdersRead, checkoutHandler.GetAdminCheckout)).Methods(http.MethodGet)
+	admin.Handle("/checkouts/{checkoutId:[0-9]+}", middleware.RequirePermission(entity.PermissionOrdersWrite, checkoutHandler.DeleteAdminCheckout)).Methods(http.MethodDelete)
 
 	// Admin currency routes
-	admin.HandleFunc("/currencies/all", currencyHandler.ListCurrencies).Methods(http.MethodGet)
-	admin.HandleFunc("/currencies", currencyHandler.CreateCurrency).Methods(http.MethodPost)
-	admin.HandleFunc("/currencies", currencyHandler.UpdateCurrency).Methods(http.MethodPut)
-	admin.HandleFunc("/currencies", currencyHandler.DeleteCurrency).Methods(http.MethodDelete)
-	admin.HandleFunc("/currencies/default", currencyHandler.SetDefaultCurrency).Methods(http.MethodPut)
+	admin.Handle("/currencies/all", middleware.RequirePermission(entity.PermissionSettingsRead, currencyHandler.ListCurrencies)).Methods(http.MethodGet)
+	admin.Handle("/currencies", middleware.RequirePermission(entity.PermissionSettingsWrite, currencyHandler.CreateCurrency)).Methods(http.MethodPost)
+	admin.Handle("/currencies", middleware.RequirePermission(entity.PermissionSettingsWrite, currencyHandler.UpdateCurrency)).Methods(http.MethodPut)
+	admin.Handle("/currencies", middleware.RequirePermission(entity.PermissionSettingsWrite, currencyHandler.DeleteCurrency)).Methods(http.MethodDelete)
+	admin.Handle("/currencies/default", middleware.RequirePermission(entity.PermissionSettingsWrite, currencyHandler.SetDefaultCurrency)).Methods(http.MethodPut)
+	admin.Handle("/currencies/rates/refresh", middleware.RequirePermission(entity.PermissionSettingsWrite, currencyHandler.RefreshExchangeRates)).Methods(http.MethodPost)
+	admin.Handle("/currencies/rates/history", middleware.RequirePermission(entity.PermissionSettingsRead, currencyHandler.GetExchangeRateHistory)).Methods(http.MethodGet)
 
 	// Admin dashboard routes
-	admin.HandleFunc("/dashboard/stats", dashboardHandler.GetStats).Methods(http.MethodGet)
+	admin.Handle("/dashboard/stats", middleware.RequirePermission(entity.PermissionDashboardRead, dashboardHandler.GetStats)).Methods(http.MethodGet)
 
 	// Admin email test route
-	admin.HandleFunc("/test/email", emailTestHandler.TestEmail).Methods(http.MethodPost)
+	admin.Handle("/test/email", middleware.RequirePermission(entity.PermissionSettingsWrite, emailTestHandler.TestEmail)).Methods(http.MethodPost)
 
 	// Admin category routes
-	admin.HandleFunc("/categories", categoryHandler.CreateCategory).Methods(http.MethodPost)
-	admin.HandleFunc("/categories/{id:[0-9]+}", categoryHandler.UpdateCategory).Methods(http.MethodPut)
-	admin.HandleFunc("/categories/{id:[0-9]+}", categoryHandler.DeleteCategory).Methods(http.MethodDelete)
+	admin.Handle("/categories", middleware.RequirePermission(entity.PermissionCatalogWrite, categoryHandler.CreateCategory)).Methods(http.MethodPost)
+	admin.Handle("/categories/{id:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogWrite, categoryHandler.UpdateCategory)).Methods(http.MethodPut)
+	admin.Handle("/categories/{id:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogWrite, categoryHandler.DeleteCategory)).Methods(http.MethodDelete)
+	admin.Handle("/categories/{id:[0-9]+}/move", middleware.RequirePermission(entity.PermissionCatalogWrite, categoryHandler.MoveCategory)).Methods(http.MethodPut)
+	admin.Handle("/categories/order", middleware.RequirePermission(entity.PermissionCatalogWrite, categoryHandler.ReorderCategories)).Methods(http.MethodPut)
 
 	// Shipping management routes (admin only)
-	admin.HandleFunc("/shipping/methods", shippingHandler.CreateShippingMethod).Methods(http.MethodPost)
+	admin.Handle("/shipping/methods", middleware.RequirePermission(entity.PermissionSettingsWrite, shippingHandler.CreateShippingMethod)).Methods(http.MethodPost)
 	// admin.HandleFunc("/shipping/methods/{shippingMethodId:[0-9]+}", shippingHandler.UpdateShippingMethod).Methods(http.MethodPut)
-	admin.HandleFunc("/shipping/zones", shippingHandler.CreateShippingZone).Methods(http.MethodPost)
+	admin.Handle("/shipping/zones", middleware.RequirePermission(entity.PermissionSettingsWrite, shippingHandler.CreateShippingZone)).Methods(http.MethodPost)
 	// admin.HandleFunc("/shipping/zones", shippingHandler.ListShippingZones).Methods(http.MethodGet)
 	// admin.HandleFunc("/shipping/zones/{shippingZoneId:[0-9]+}", shippingHandler.GetShippingZoneByID).Methods(http.MethodGet)
 	// admin.HandleFunc("/shipping/zones/{shippingZoneId:[0-9]+}", shippingHandler.UpdateShippingZone).Methods(http.MethodPut)
-	admin.HandleFunc("/shipping/rates", shippingHandler.CreateShippingRate).Methods(http.MethodPost)
+	admin.Handle("/shipping/rates", middleware.RequirePermission(entity.PermissionSettingsWrite, shippingHandler.CreateShippingRate)).Methods(http.MethodPost)
 	// admin.HandleFunc("/shipping/rates/{shippingRateId:[0-9]+}", shippingHandler.GetShippingRateByID).Methods(http.MethodGet)
 	// admin.HandleFunc("/shipping/rates/{shippingRateId:[0-9]+}", shippingHandler.UpdateShippingRate).Methods(http.MethodPut)
-	admin.HandleFunc("/shipping/rates/weight", shippingHandler.CreateWeightBasedRate).Methods(http.MethodPost)
-	admin.HandleFunc("/shipping/rates/value", shippingHandler.CreateValueBasedRate).Methods(http.MethodPost)
+	admin.Handle("/shipping/rates/weight", middleware.RequirePermission(entity.PermissionSettingsWrite, shippingHandler.CreateWeightBasedRate)).Methods(http.MethodPost)
+	admin.Handle("/shipping/rates/value", middleware.RequirePermission(entity.PermissionSettingsWrite, shippingHandler.CreateValueBasedRate)).Methods(http.MethodPost)
 
 	// Discount routes
-	admin.HandleFunc("/discounts", discountHandler.CreateDiscount).Methods(http.MethodPost)
-	admin.HandleFunc("/discounts/{discountId:[0-9]+}", discountHandler.UpdateDiscount).Methods(http.MethodPut)
-	admin.HandleFunc("/discounts/{discountId:[0-9]+}", discountHandler.DeleteDiscount).Methods(http.MethodDelete)
-	admin.HandleFunc("/discounts", discountHandler.ListDiscounts).Methods(http.MethodGet)
-	admin.HandleFunc("/discounts/active", discountHandler.ListActiveDiscounts).Methods(http.MethodGet)
-	admin.HandleFunc("/discounts/apply/{orderId:[0-9]+}", discountHandler.ApplyDiscountToOrder).Methods(http.MethodPost)
-	admin.HandleFunc("/discounts/remove/{orderId:[0-9]+}", discountHandler.RemoveDiscountFromOrder).Methods(http.MethodDelete)
-	admin.HandleFunc("/discounts/{discountId:[0-9]+}", discountHandler.GetDiscount).Methods(http.MethodGet)
+	admin.Handle("/discounts", middleware.RequirePermission(entity.PermissionDiscountsWrite, discountHandler.CreateDiscount)).Methods(http.MethodPost)
+	admin.Handle("/discounts/{discountId:[0-9]+}", middleware.RequirePermission(entity.PermissionDiscountsWrite, discountHandler.UpdateDiscount)).Methods(http.MethodPut)
+	admin.Handle("/discounts/{discountId:[0-9]+}", middleware.RequirePermission(entity.PermissionDiscountsWrite, discountHandler.DeleteDiscount)).Methods(http.MethodDelete)
+	admin.Handle("/discounts", middleware.RequirePermission(entity.PermissionDiscountsRead, discountHandler.ListDiscounts)).Methods(http.MethodGet)
+	admin.Handle("/discounts/active", middleware.RequirePermission(entity.PermissionDiscountsRead, discountHandler.ListActiveDiscounts)).Methods(http.MethodGet)
+	admin.Handle("/discounts/apply/{orderId:[0-9]+}", middleware.RequirePermission(entity.PermissionOrdersWrite, discountHandler.ApplyDiscountToOrder)).Methods(http.MethodPost)
+	admin.Handle("/discounts/remove/{orderId:[0-9]+}", middleware.RequirePermission(entity.PermissionOrdersWrite, discountHandler.RemoveDiscountFromOrder)).Methods(http.MethodDelete)
+	admin.Handle("/discounts/{discountId:[0-9]+}", middleware.RequirePermission(entity.PermissionDiscountsRead, discountHandler.GetDiscount)).Methods(http.MethodGet)
+
+	// Discount code batch routes
+	admin.Handle("/discounts/{discountId:[0-9]+}/batches", middleware.RequirePermission(entity.PermissionDiscountsWrite, discountHandler.GenerateDiscountCodeBatch)).Methods(http.MethodPost)
+	admin.Handle("/discounts/{discountId:[0-9]+}/batches/import", middleware.RequirePermission(entity.PermissionDiscountsWrite, discountHandler.ImportDiscountCodeBatch)).Methods(http.MethodPost)
+	admin.Handle("/discounts/{discountId:[0-9]+}/batches", middleware.RequirePermission(entity.PermissionDiscountsRead, discountHandler.ListDiscountCodeBatches)).Methods(http.MethodGet)
+	admin.Handle("/discounts/batches/{batchId:[0-9]+}", middleware.RequirePermission(entity.PermissionDiscountsRead, discountHandler.GetDiscountCodeBatch)).Methods(http.MethodGet)
+	admin.Handle("/discounts/batches/{batchId:[0-9]+}", middleware.RequirePermission(entity.PermissionDiscountsWrite, discountHandler.DeleteDiscountCodeBatch)).Methods(http.MethodDelete)
+	admin.Handle("/discounts/batches/{batchId:[0-9]+}/export", middleware.RequirePermission(entity.PermissionDiscountsRead, discountHandler.ExportDiscountCodeBatch)).Methods(http.MethodGet)
 
 	// Payment management routes (admin only)
-	admin.HandleFunc("/payments/{paymentId}/capture", paymentHandler.CapturePayment).Methods(http.MethodPost)
-	admin.HandleFunc("/payments/{paymentId}/cancel", paymentHandler.CancelPayment).Methods(http.MethodPost)
-	admin.HandleFunc("/payments/{paymentId}/refund", paymentHandler.RefundPayment).Methods(http.MethodPost)
-	admin.HandleFunc("/payments/{paymentId}/force-approve", paymentHandler.ForceApproveMobilePayPayment).Methods(http.MethodPost)
+	admin.Handle("/payments/{paymentId}/capture", middleware.RequirePermission(entity.PermissionPaymentsCapture, paymentHandler.CapturePayment)).Methods(http.MethodPost)
+	admin.Handle("/payments/{paymentId}/cancel", middleware.RequirePermission(entity.PermissionPaymentsCapture, paymentHandler.CancelPayment)).Methods(http.MethodPost)
+	admin.Handle("/payments/{paymentId}/refund", middleware.RequirePermission(entity.PermissionPaymentsRefund, paymentHandler.RefundPayment)).Methods(http.MethodPost)
+	admin.Handle("/payments/{paymentId}/force-approve", middleware.RequirePermission(entity.PermissionPaymentsCapture, paymentHandler.ForceApproveMobilePayPayment)).Methods(http.MethodPost)
 
 	// Payment provider management routes (admin only)
-	admin.HandleFunc("/payment-providers", paymentProviderHandler.GetPaymentProviders).Methods(http.MethodGet)
-	admin.HandleFunc("/payment-providers/enabled", paymentProviderHandler.GetEnabledPaymentProviders).Methods(http.MethodGet)
-	admin.HandleFunc("/payment-providers/{providerType}/enable", paymentProviderHandler.EnablePaymentProvider).Methods(http.MethodPut)
-	admin.HandleFunc("/payment-providers/{providerType}/configuration", paymentProviderHandler.UpdateProviderConfiguration).Methods(http.MethodPut)
-	admin.HandleFunc("/payment-providers/{providerType}/webhook", paymentProviderHandler.RegisterWebhook).Methods(http.MethodPost)
-	admin.HandleFunc("/payment-providers/{providerType}/webhook", paymentProviderHandler.DeleteWebhook).Methods(http.MethodDelete)
-	admin.HandleFunc("/payment-providers/{providerType}/webhook", paymentProviderHandler.GetWebhookInfo).Methods(http.MethodGet)
-
-	admin.HandleFunc("/products", productHandler.ListProducts).Methods(http.MethodGet)
-	admin.HandleFunc("/products", productHandler.CreateProduct).Methods(http.MethodPost)
-	admin.HandleFunc("/products/{productId:[0-9]+}", productHandler.UpdateProduct).Methods(http.MethodPut)
-	admin.HandleFunc("/products/{productId:[0-9]+}", productHandler.DeleteProduct).Methods(http.MethodDelete)
+	admin.Handle("/payment-providers", middleware.RequirePermission(entity.PermissionSettingsRead, paymentProviderHandler.GetPaymentProviders)).Methods(http.MethodGet)
+	admin.Handle("/payment-providers/enabled", middleware.RequirePermission(entity.PermissionSettingsRead, paymentProviderHandler.GetEnabledPaymentProviders)).Methods(http.MethodGet)
+	admin.Handle("/payment-providers/{providerType}/enable", middleware.RequirePermission(entity.PermissionSettingsWrite, paymentProviderHandler.EnablePaymentProvider)).Methods(http.MethodPut)
+	admin.Handle("/payment-providers/{providerType}/configuration", middleware.RequirePermission(entity.PermissionSettingsWrite, paymentProviderHandler.UpdateProviderConfiguration)).Methods(http.MethodPut)
+	admin.Handle("/payment-providers/{providerType}/webhook", middleware.RequirePermission(entity.PermissionSettingsWrite, paymentProviderHandler.RegisterWebhook)).Methods(http.MethodPost)
+	admin.Handle("/payment-providers/{providerType}/webhook", middleware.RequirePermission(entity.PermissionSettingsWrite, paymentProviderHandler.DeleteWebhook)).Methods(http.MethodDelete)
+	admin.Handle("/payment-providers/{providerType}/webhook", middleware.RequirePermission(entity.PermissionSettingsRead, paymentProviderHandler.GetWebhookInfo)).Methods(http.MethodGet)
+
+	admin.Handle("/products", middleware.RequirePermission(entity.PermissionCatalogRead, productHandler.ListProducts)).Methods(http.MethodGet)
+	admin.Handle("/products", middleware.RequirePermission(entity.PermissionCatalogWrite, productHandler.CreateProduct)).Methods(http.MethodPost)
+	admin.Handle("/products/{productId:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogWrite, productHandler.UpdateProduct)).Methods(http.MethodPut)
+	admin.Handle("/products/{productId:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogWrite, productHandler.DeleteProduct)).Methods(http.MethodDelete)
+	admin.Handle("/products/search/reindex", middleware.RequirePermission(entity.PermissionCatalogWrite, productHandler.RebuildSearchIndex)).Methods(http.MethodPost)
+	admin.Handle("/products/import", middleware.RequirePermission(entity.PermissionCatalogWrite, catalogHandler.ImportCatalog)).Methods(http.MethodPost)
+	admin.Handle("/products/export", middleware.RequirePermission(entity.PermissionCatalogRead, catalogHandler.ExportCatalog)).Methods(http.MethodGet)
+
+	// Media asset routes
+	admin.Handle("/assets", middleware.RequirePermission(entity.PermissionCatalogWrite, assetHandler.UploadAsset)).Methods(http.MethodPost)
+	admin.Handle("/assets", middleware.RequirePermission(entity.PermissionCatalogRead, assetHandler.ListAssets)).Methods(http.MethodGet)
+	admin.Handle("/assets/{assetId:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogRead, assetHandler.GetAsset)).Methods(http.MethodGet)
+	admin.Handle("/assets/{assetId:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogWrite, assetHandler.DeleteAsset)).Methods(http.MethodDelete)
+	admin.Handle("/products/{productId:[0-9]+}/assets", middleware.RequirePermission(entity.PermissionCatalogWrite, assetHandler.LinkProductAsset)).Methods(http.MethodPost)
+	admin.Handle("/products/{productId:[0-9]+}/assets/order", middleware.RequirePermission(entity.PermissionCatalogWrite, assetHandler.ReorderProductAssets)).Methods(http.MethodPut)
+	admin.Handle("/products/{productId:[0-9]+}/assets/{productAssetId:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogWrite, assetHandler.UpdateProductAsset)).Methods(http.MethodPut)
+	admin.Handle("/products/{productId:[0-9]+}/assets/{productAssetId:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogWrite, assetHandler.RemoveProductAsset)).Methods(http.MethodDelete)
+
+	// Product association routes
+	admin.Handle("/products/{productId:[0-9]+}/associations", middleware.RequirePermission(entity.PermissionCatalogRead, productAssociationHandler.ListAssociations)).Methods(http.MethodGet)
+	admin.Handle("/products/{productId:[0-9]+}/associations/{type}", middleware.RequirePermission(entity.PermissionCatalogWrite, productAssociationHandler.SetAssociations)).Methods(http.MethodPut)
+
+	// Collection routes
+	admin.Handle("/collections", middleware.RequirePermission(entity.PermissionCatalogRead, collectionHandler.ListCollections)).Methods(http.MethodGet)
+	admin.Handle("/collections", middleware.RequirePermission(entity.PermissionCatalogWrite, collectionHandler.CreateCollection)).Methods(http.MethodPost)
+	admin.Handle("/collections/{collectionId:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogRead, collectionHandler.GetCollection)).Methods(http.MethodGet)
+	admin.Handle("/collections/{collectionId:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogWrite, collectionHandler.UpdateCollection)).Methods(http.MethodPut)
+	admin.Handle("/collections/{collectionId:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogWrite, collectionHandler.DeleteCollection)).Methods(http.MethodDelete)
+	admin.Handle("/collections/{collectionId:[0-9]+}/products", middleware.RequirePermission(entity.PermissionCatalogRead, collectionHandler.GetCollectionProducts)).Methods(http.MethodGet)
+	admin.Handle("/collections/{collectionId:[0-9]+}/products", middleware.RequirePermission(entity.PermissionCatalogWrite, collectionHandler.SetCollectionProducts)).Methods(http.MethodPut)
+
+	// Translation routes
+	admin.Handle("/products/{productId:[0-9]+}/translations", middleware.RequirePermission(entity.PermissionCatalogRead, translationHandler.GetProductTranslations)).Methods(http.MethodGet)
+	admin.Handle("/products/{productId:[0-9]+}/translations", middleware.RequirePermission(entity.PermissionCatalogWrite, translationHandler.SetProductTranslations)).Methods(http.MethodPut)
+	admin.Handle("/categories/{id:[0-9]+}/translations", middleware.RequirePermission(entity.PermissionCatalogRead, translationHandler.GetCategoryTranslations)).Methods(http.MethodGet)
+	admin.Handle("/categories/{id:[0-9]+}/translations", middleware.RequirePermission(entity.PermissionCatalogWrite, translationHandler.SetCategoryTranslations)).Methods(http.MethodPut)
+	admin.Handle("/shipping/methods/{shippingMethodId:[0-9]+}/translations", middleware.RequirePermission(entity.PermissionSettingsRead, translationHandler.GetShippingMethodTranslations)).Methods(http.MethodGet)
+	admin.Handle("/shipping/methods/{shippingMethodId:[0-9]+}/translations", middleware.RequirePermission(entity.PermissionSettingsWrite, translationHandler.SetShippingMethodTranslations)).Methods(http.MethodPut)
+
+	// Review moderation routes
+	admin.Handle("/reviews", middleware.RequirePermission(entity.PermissionReviewsModerate, reviewHandler.ListReviews)).Methods(http.MethodGet)
+	admin.Handle("/reviews/{reviewId:[0-9]+}/approve", middleware.RequirePermission(entity.PermissionReviewsModerate, reviewHandler.ApproveReview)).Methods(http.MethodPut)
+	admin.Handle("/reviews/{reviewId:[0-9]+}/reject", middleware.RequirePermission(entity.PermissionReviewsModerate, reviewHandler.RejectReview)).Methods(http.MethodPut)
+	admin.Handle("/reviews/{reviewId:[0-9]+}", middleware.RequirePermission(entity.PermissionReviewsModerate, reviewHandler.DeleteReview)).Methods(http.MethodDelete)
+
+	// Custom field routes
+	admin.Handle("/custom-fields", middleware.RequirePermission(entity.PermissionSettingsRead, customFieldHandler.ListCustomFields)).Methods(http.MethodGet)
+	admin.Handle("/custom-fields", middleware.RequirePermission(entity.PermissionSettingsWrite, customFieldHandler.CreateCustomField)).Methods(http.MethodPost)
+	admin.Handle("/custom-fields/{customFieldId:[0-9]+}", middleware.RequirePermission(entity.PermissionSettingsWrite, customFieldHandler.UpdateCustomField)).Methods(http.MethodPut)
+	admin.Handle("/custom-fields/{customFieldId:[0-9]+}", middleware.RequirePermission(entity.PermissionSettingsWrite, customFieldHandler.DeleteCustomField)).Methods(http.MethodDelete)
+	admin.Handle("/products/{productId:[0-9]+}/custom-fields", middleware.RequirePermission(entity.PermissionCatalogRead, customFieldHandler.GetProductCustomFields)).Methods(http.MethodGet)
+	admin.Handle("/products/{productId:[0-9]+}/custom-fields", middleware.RequirePermission(entity.PermissionCatalogWrite, customFieldHandler.SetProductCustomFields)).Methods(http.MethodPut)
+	admin.Handle("/products/{productId:[0-9]+}/variants/{variantId:[0-9]+}/custom-fields", middleware.RequirePermission(entity.PermissionCatalogRead, customFieldHandler.GetVariantCustomFields)).Methods(http.MethodGet)
+	admin.Handle("/products/{productId:[0-9]+}/variants/{variantId:[0-9]+}/custom-fields", middleware.RequirePermission(entity.PermissionCatalogWrite, customFieldHandler.SetVariantCustomFields)).Methods(http.MethodPut)
+	admin.Handle("/orders/{orderId:[0-9]+}/custom-fields", middleware.RequirePermission(entity.PermissionOrdersRead, customFieldHandler.GetOrderCustomFields)).Methods(http.MethodGet)
+	admin.Handle("/orders/{orderId:[0-9]+}/custom-fields", middleware.RequirePermission(entity.PermissionOrdersWrite, customFieldHandler.SetOrderCustomFields)).Methods(http.MethodPut)
+	admin.Handle("/users/{userId:[0-9]+}/custom-fields", middleware.RequirePermission(entity.PermissionCustomersRead, customFieldHandler.GetUserCustomFields)).Methods(http.MethodGet)
+	admin.Handle("/users/{userId:[0-9]+}/custom-fields", middleware.RequirePermission(entity.PermissionCustomersWrite, customFieldHandler.SetUserCustomFields)).Methods(http.MethodPut)
+
+	// Role routes
+	admin.Handle("/roles", middleware.RequirePermission(entity.PermissionRolesRead, roleHandler.ListRoles)).Methods(http.MethodGet)
+	admin.Handle("/roles", middleware.RequirePermission(entity.PermissionRolesWrite, roleHandler.CreateRole)).Methods(http.MethodPost)
+	admin.Handle("/roles/{roleId:[0-9]+}", middleware.RequirePermission(entity.PermissionRolesWrite, roleHandler.UpdateRole)).Methods(http.MethodPut)
+	admin.Handle("/roles/{roleId:[0-9]+}", middleware.RequirePermission(entity.PermissionRolesWrite, roleHandler.DeleteRole)).Methods(http.MethodDelete)
+	admin.Handle("/permissions", middleware.RequirePermission(entity.PermissionRolesRead, roleHandler.ListPermissions)).Methods(http.MethodGet)
+	admin.Handle("/users/{userId:[0-9]+}/role", middleware.RequirePermission(entity.PermissionRolesWrite, roleHandler.AssignUserRole)).Methods(http.MethodPut)
+
+	// API key routes
+	admin.Handle("/api-keys", middleware.RequirePermission(entity.PermissionAPIKeysRead, apiKeyHandler.ListAPIKeys)).Methods(http.MethodGet)
+	admin.Handle("/api-keys", middleware.RequirePermission(entity.PermissionAPIKeysWrite, apiKeyHandler.CreateAPIKey)).Methods(http.MethodPost)
+	admin.Handle("/api-keys/{apiKeyId:[0-9]+}", middleware.RequirePermission(entity.PermissionAPIKeysWrite, apiKeyHandler.RevokeAPIKey)).Methods(http.MethodDelete)
+	admin.Handle("/api-keys/{apiKeyId:[0-9]+}/requests", middleware.RequirePermission(entity.PermissionAPIKeysRead, apiKeyHandler.ListAPIKeyRequests)).Methods(http.MethodGet)
 
 	// Product variant routes
-	admin.HandleFunc("/products/{productId:[0-9]+}/variants", productHandler.AddVariant).Methods(http.MethodPost)
-	admin.HandleFunc("/products/{productId:[0-9]+}/variants/{variantId:[0-9]+}", productHandler.UpdateVariant).Methods(http.MethodPut)
-	admin.HandleFunc("/products/{productId:[0-9]+}/variants/{variantId:[0-9]+}", productHandler.DeleteVariant).Methods(http.MethodDelete)
+	admin.Handle("/products/{productId:[0-9]+}/options", middleware.RequirePermission(entity.PermissionCatalogWrite, productHandler.SetProductOptions)).Methods(http.MethodPut)
+	admin.Handle("/products/{productId:[0-9]+}/variants", middleware.RequirePermission(entity.PermissionCatalogWrite, productHandler.AddVariant)).Methods(http.MethodPost)
+	admin.Handle("/products/{productId:[0-9]+}/variants/generate", middleware.RequirePermission(entity.PermissionCatalogWrite, productHandler.GenerateVariants)).Methods(http.MethodPost)
+	admin.Handle("/products/{productId:[0-9]+}/variants/{variantId:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogWrite, productHandler.UpdateVariant)).Methods(http.MethodPut)
+	admin.Handle("/products/{productId:[0-9]+}/variants/{variantId:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogWrite, productHandler.DeleteVariant)).Methods(http.MethodDelete)
+	admin.Handle("/products/{productId:[0-9]+}/variants/{variantId:[0-9]+}/components", middleware.RequirePermission(entity.PermissionCatalogWrite, productHandler.SetBundleComponents)).Methods(http.MethodPut)
+
+	// Digital product file routes
+	admin.Handle("/products/{productId:[0-9]+}/variants/{variantId:[0-9]+}/files", middleware.RequirePermission(entity.PermissionCatalogWrite, downloadHandler.UploadDigitalFile)).Methods(http.MethodPost)
+	admin.Handle("/products/{productId:[0-9]+}/variants/{variantId:[0-9]+}/files", middleware.RequirePermission(entity.PermissionCatalogRead, downloadHandler.ListDigitalFiles)).Methods(http.MethodGet)
+	admin.Handle("/products/{productId:[0-9]+}/variants/{variantId:[0-9]+}/files/{fileId:[0-9]+}", middleware.RequirePermission(entity.PermissionCatalogWrite, downloadHandler.DeleteDigitalFile)).Methods(http.MethodDelete)
 }
 
 // GetContainer returns the dependency injection container
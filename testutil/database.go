@@ -12,6 +12,7 @@ import (
 
 	"github.com/zenfulcode/commercify/internal/domain/common"
 	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/database"
 )
 
 // SetupTestDB creates an in-memory SQLite database for testing
@@ -55,19 +56,57 @@ func SetupTestDBWithLogger(t *testing.T, logLevel logger.LogLevel) *gorm.DB {
 
 // autoMigrate performs automatic migration of all entities
 func autoMigrate(db *gorm.DB) error {
+	if err := migrateEntities(db); err != nil {
+		return err
+	}
+	return database.SetupProductSearch(db)
+}
+
+// migrateEntities migrates the tables of all entities
+func migrateEntities(db *gorm.DB) error {
 	return db.AutoMigrate(
 		// Core entities
 		&entity.User{},
+		&entity.UserToken{},
+		&entity.RefreshToken{},
+		&entity.Role{},
+		&entity.APIKey{},
+		&entity.APIKeyRequest{},
+		&entity.CustomerGroup{},
 		&entity.Category{},
 
 		// Product entities
 		&entity.Product{},
 		&entity.ProductVariant{},
+		&entity.BundleComponent{},
+		&entity.DigitalFile{},
+		&entity.ProductOption{},
+		&entity.ProductSearchDocument{},
+		&entity.ProductSearchTerm{},
+		&entity.SlugRedirect{},
+		&entity.Asset{},
+		&entity.AssetDerivative{},
+		&entity.ProductAsset{},
+		&entity.ProductAssociation{},
+		&entity.Collection{},
+		&entity.CollectionProduct{},
+		&entity.Translation{},
+		&entity.PriceList{},
+		&entity.PriceListEntry{},
 		&entity.Currency{},
+		&entity.ExchangeRateHistory{},
 
 		// Order entities
 		&entity.Order{},
 		&entity.OrderItem{},
+		&entity.OrderItemComponent{},
+		&entity.OrderDownload{},
+
+		// Review entities
+		&entity.Review{},
+
+		// Custom field entities
+		&entity.CustomFieldDefinition{},
 
 		// Checkout entities
 		&entity.Checkout{},
@@ -75,6 +114,8 @@ func autoMigrate(db *gorm.DB) error {
 
 		// Discount entities
 		&entity.Discount{},
+		&entity.DiscountCodeBatch{},
+		&entity.DiscountCode{},
 
 		// Shipping entities
 		&entity.ShippingMethod{},
@@ -177,20 +218,48 @@ func TruncateAllTables(t *testing.T, db *gorm.DB) {
 	tables := []string{
 		"payment_transactions",
 		// "payment_providers", // Commented out since we don't migrate this entity
+		"order_downloads",
+		"reviews",
+		"order_item_components",
 		"order_items",
 		"orders",
 		"checkout_items",
 		"checkouts",
+		"product_search_documents",
+		"product_search_terms",
+		"slug_redirects",
+		"product_associations",
+		"translations",
+		"custom_field_definitions",
+		"collection_products",
+		"collections",
+		"product_assets",
+		"asset_derivatives",
+		"assets",
+		"product_options",
+		"bundle_components",
+		"digital_files",
 		"product_variants",
 		"products",
 		"categories",
+		"price_list_entries",
+		"price_lists",
+		"customer_groups",
+		"user_tokens",
+		"refresh_tokens",
+		"roles",
+		"api_key_requests",
+		"api_keys",
 		"users",
+		"discount_codes",
+		"discount_code_batches",
 		"discounts",
 		"shipping_methods",
 		"shipping_zones",
 		"shipping_rates",
 		"weight_based_rates",
 		"value_based_rates",
+		"exchange_rate_histories",
 	}
 
 	// Disable foreign key checks temporarily